@@ -13,6 +13,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/backup/backupdest"
 	"github.com/cockroachdb/cockroach/pkg/backup/backupencryption"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
 	"github.com/cockroachdb/cockroach/pkg/featureflag"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/sql"
@@ -150,15 +151,35 @@ func doAlterBackupPlan(
 		baseStore.Settings(), &ioConf, p.ExecCfg().InternalDB, p.User(),
 	)
 
+	encryptionInfo, err := rewrapEncryptionInfo(ctx, opts, oldKms, newKms, &kmsEnv)
+	if err != nil {
+		return err
+	}
+
+	// Write the new ENCRYPTION-INFO file.
+	return backupencryption.WriteNewEncryptionInfoToBackup(ctx, encryptionInfo, baseStore, len(opts))
+}
+
+// rewrapEncryptionInfo unwraps the data key of a backup, given the contents of
+// its ENCRYPTION-INFO files, with the first of oldKms that was used to wrap
+// it, and returns encryption info that wraps the same key with each of newKms.
+func rewrapEncryptionInfo(
+	ctx context.Context,
+	opts []jobspb.EncryptionInfo,
+	oldKms []string,
+	newKms []string,
+	kmsEnv cloud.KMSEnv,
+) (*jobspb.EncryptionInfo, error) {
 	// Check that at least one of the old keys has been used to encrypt the backup in the past.
 	// Use the first one that works to decrypt the ENCRYPTION-INFO file(s).
 	var defaultKMSInfo *jobspb.BackupEncryptionOptions_KMSInfo
+	var err error
 	oldKMSFound := false
 	for _, old := range oldKms {
 		for _, encFile := range opts {
 			defaultKMSInfo, err = backupencryption.ValidateKMSURIsAgainstFullBackup(ctx, []string{old},
 				backupencryption.NewEncryptedDataKeyMapFromProtoMap(encFile.EncryptedDataKeyByKMSMasterKeyID),
-				&kmsEnv)
+				kmsEnv)
 
 			if err == nil {
 				oldKMSFound = true
@@ -170,7 +191,7 @@ func doAlterBackupPlan(
 		}
 	}
 	if !oldKMSFound {
-		return errors.New("no key in OLD_KMS matches a key that was previously used to encrypt the backup")
+		return nil, errors.New("no key in OLD_KMS matches a key that was previously used to encrypt the backup")
 	}
 
 	encryption := &jobspb.BackupEncryptionOptions{
@@ -178,10 +199,9 @@ func doAlterBackupPlan(
 		KMSInfo: defaultKMSInfo}
 
 	// Recover the encryption key using the old key, so we can encrypt it again with the new keys.
-	var plaintextDataKey []byte
-	plaintextDataKey, err = backupencryption.GetEncryptionKey(ctx, encryption, &kmsEnv)
+	plaintextDataKey, err := backupencryption.GetEncryptionKey(ctx, encryption, kmsEnv)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	encryptedDataKeyByKMSMasterKeyID := backupencryption.NewEncryptedDataKeyMap()
@@ -189,9 +209,9 @@ func doAlterBackupPlan(
 	// Add each new key user wants to add to a new data key map.
 	for _, kmsURI := range newKms {
 		masterKeyID, encryptedDataKey, err := backupencryption.GetEncryptedDataKeyFromURI(ctx,
-			plaintextDataKey, kmsURI, &kmsEnv)
+			plaintextDataKey, kmsURI, kmsEnv)
 		if err != nil {
-			return errors.Wrap(err, "failed to encrypt data key when adding new KMS")
+			return nil, errors.Wrap(err, "failed to encrypt data key when adding new KMS")
 		}
 
 		encryptedDataKeyByKMSMasterKeyID.AddEncryptedDataKey(backupencryption.PlaintextMasterKeyID(masterKeyID),
@@ -204,10 +224,7 @@ func doAlterBackupPlan(
 			encryptedDataKeyMapForProto[string(masterKeyID)] = dataKey
 		})
 
-	encryptionInfo := &jobspb.EncryptionInfo{EncryptedDataKeyByKMSMasterKeyID: encryptedDataKeyMapForProto}
-
-	// Write the new ENCRYPTION-INFO file.
-	return backupencryption.WriteNewEncryptionInfoToBackup(ctx, encryptionInfo, baseStore, len(opts))
+	return &jobspb.EncryptionInfo{EncryptedDataKeyByKMSMasterKeyID: encryptedDataKeyMapForProto}, nil
 }
 
 func init() {
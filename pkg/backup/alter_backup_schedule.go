@@ -40,9 +40,8 @@ type scheduleDetails struct {
 }
 
 func loadSchedules(
-	ctx context.Context, p sql.PlanHookState, spec *alterBackupScheduleSpec,
+	ctx context.Context, p sql.PlanHookState, scheduleID jobspb.ScheduleID,
 ) (scheduleDetails, error) {
-	scheduleID := spec.scheduleID
 	s := scheduleDetails{}
 	if scheduleID == jobspb.InvalidScheduleID {
 		return s, errors.Newf("Schedule ID expected, none found")
@@ -110,7 +109,7 @@ func doAlterBackupSchedules(
 	spec *alterBackupScheduleSpec,
 	resultsCh chan<- tree.Datums,
 ) error {
-	s, err := loadSchedules(ctx, p, spec)
+	s, err := loadSchedules(ctx, p, spec.scheduleID)
 	if err != nil {
 		return err
 	}
@@ -402,6 +401,9 @@ func processOptionsForArgs(inOpts tree.BackupOptions, outOpts *tree.BackupOption
 	if inOpts.UpdatesClusterMonitoringMetrics != nil {
 		outOpts.UpdatesClusterMonitoringMetrics = inOpts.UpdatesClusterMonitoringMetrics
 	}
+	if inOpts.Deduplicate != nil {
+		outOpts.Deduplicate = inOpts.Deduplicate
+	}
 	return nil
 }
 
@@ -0,0 +1,525 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupinfo"
+	"github.com/cockroachdb/cockroach/pkg/build"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/featureflag"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/revert"
+	"github.com/cockroachdb/cockroach/pkg/revlog"
+	"github.com/cockroachdb/cockroach/pkg/revlog/restorerevlog"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/regions"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+)
+
+// offlineReasonReverting is the offline reason of a table while the keys it
+// had changed since the target time of ALTER TABLE ... REVERT are rewritten.
+const offlineReasonReverting = "reverting"
+
+// revertTableBatchSize is the number of reverted keys read from the revision
+// log and written per transaction.
+const revertTableBatchSize = 1000
+
+var alterTableRevertHeader = colinfo.ResultColumns{
+	{Name: "keys_reverted", Typ: types.Int},
+}
+
+func alterTableRevertTypeCheck(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (matched bool, header colinfo.ResultColumns, _ error) {
+	revertStmt, ok := stmt.(*tree.AlterTableRevert)
+	if !ok {
+		return false, nil, nil
+	}
+	if err := exprutil.TypeCheck(
+		ctx, "ALTER TABLE REVERT", p.SemaCtx(), exprutil.Strings{revertStmt.Collection},
+	); err != nil {
+		return false, nil, err
+	}
+	return true, alterTableRevertHeader, nil
+}
+
+// alterTableRevertPlanHook implements sql.PlanHookFn for ALTER TABLE ... REVERT
+// TO SYSTEM TIME.
+//
+// The table is reverted in place by a job: the revision log of the collection,
+// which continues the latest backup taken at or before the target time, yields
+// the value as of the target time of every key changed since then, up to the
+// end of the last closed tick. The statement checks the log and takes the table
+// offline in the transaction that creates the job. The job then reverts the
+// changes made after the end of the last closed tick with RevertRange, which is
+// within the GC window, rewrites the keys changed before it with their values
+// as of the target time, and brings the table back online. If the job fails,
+// the table is brought back online, possibly partially reverted.
+func alterTableRevertPlanHook(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, bool, error) {
+	revertStmt, ok := stmt.(*tree.AlterTableRevert)
+	if !ok {
+		return nil, nil, false, nil
+	}
+	if err := featureflag.CheckEnabled(
+		ctx,
+		p.ExecCfg(),
+		featureRestoreEnabled,
+		"ALTER TABLE REVERT",
+	); err != nil {
+		return nil, nil, false, err
+	}
+
+	collection, err := p.ExprEvaluator("ALTER TABLE REVERT").String(ctx, revertStmt.Collection)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if err := logAndSanitizeBackupDestinations(ctx, collection); err != nil {
+		return nil, nil, false, err
+	}
+
+	fn := func(ctx context.Context, resultsCh chan<- tree.Datums) error {
+		ctx, span := tracing.ChildSpan(ctx, stmt.StatementTag())
+		defer span.Finish()
+
+		if !p.ExtendedEvalContext().TxnIsSingleStmt {
+			return errors.Errorf("ALTER TABLE REVERT cannot be used inside a multi-statement transaction")
+		}
+		asOf, err := p.EvalAsOfTimestamp(ctx, revertStmt.AsOf)
+		if err != nil {
+			return err
+		}
+		table, err := p.ResolveExistingObjectEx(ctx, revertStmt.Name, true /* required */, tree.ResolveRequireTableDesc)
+		if err != nil {
+			return err
+		}
+		if err := checkPrivilegesForRevert(ctx, p, table, collection); err != nil {
+			return err
+		}
+		if !table.IsPhysicalTable() || table.IsSequence() {
+			return pgerror.Newf(pgcode.WrongObjectType, "%q is not a table", table.GetName())
+		}
+		if mutations := table.AllMutations(); len(mutations) > 0 {
+			return errors.Errorf(
+				"cannot revert a table with schema changes in progress -- try again later (pending mutation %s)",
+				mutations[0],
+			)
+		}
+		details, err := planTableRevert(ctx, p.ExecCfg(), p.User(), table, collection, asOf.Timestamp)
+		if err != nil {
+			return err
+		}
+
+		// The table is taken offline by the transaction that creates the job,
+		// so that the job, whose OnFailOrCancel brings it back online, is
+		// responsible for it as soon as it is offline.
+		mut, err := p.Descriptors().MutableByID(p.Txn()).Table(ctx, table.GetID())
+		if err != nil {
+			return err
+		}
+		mut.SetOffline(offlineReasonReverting)
+		if err := p.Descriptors().WriteDesc(ctx, false /* kvTrace */, mut, p.Txn()); err != nil {
+			return err
+		}
+		redactedCollection, err := cloud.SanitizeExternalStorageURI(collection, nil /* extraParams */)
+		if err != nil {
+			return err
+		}
+		record := jobs.Record{
+			Description: fmt.Sprintf(
+				"ALTER TABLE %s REVERT TO SYSTEM TIME '%s' FROM '%s'",
+				revertStmt.Name, asOf.Timestamp.AsOfSystemTime(), redactedCollection,
+			),
+			Username:      p.User(),
+			DescriptorIDs: descpb.IDs{table.GetID()},
+			Details:       details,
+			Progress:      jobspb.AlterTableRevertProgress{},
+		}
+
+		// Like RESTORE, the job is created in the planner's transaction, which
+		// is then committed so that the job can be started and awaited.
+		var sj *jobs.StartableJob
+		if err := func() (err error) {
+			defer func() {
+				if err == nil || sj == nil {
+					return
+				}
+				if cleanupErr := sj.CleanupOnRollback(ctx); cleanupErr != nil {
+					log.Dev.Errorf(ctx, "failed to cleanup job: %v", cleanupErr)
+				}
+			}()
+			jobID := p.ExecCfg().JobRegistry.MakeJobID()
+			if err := p.ExecCfg().JobRegistry.CreateStartableJobWithTxn(
+				ctx, &sj, jobID, p.InternalSQLTxn(), record,
+			); err != nil {
+				return err
+			}
+			return p.Txn().Commit(ctx)
+		}(); err != nil {
+			return err
+		}
+		p.InternalSQLTxn().Descriptors().ReleaseAll(ctx)
+		if err := sj.Start(ctx); err != nil {
+			return err
+		}
+		if err := sj.AwaitCompletion(ctx); err != nil {
+			return err
+		}
+		return sj.ReportExecutionResults(ctx, resultsCh)
+	}
+	return fn, alterTableRevertHeader, false, nil
+}
+
+// checkPrivilegesForRevert checks that the user may revert table from the
+// revision log in collection. Like a restore, this requires the RESTORE
+// system privilege; the user must also be allowed to write to the table.
+func checkPrivilegesForRevert(
+	ctx context.Context, p sql.PlanHookState, table catalog.TableDescriptor, collection string,
+) error {
+	hasAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+	if !hasAdmin {
+		if err := p.CheckPrivilegeForUser(
+			ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.RESTORE, p.User(),
+		); err != nil {
+			return pgerror.Wrapf(
+				err,
+				pgcode.InsufficientPrivilege,
+				"only users with the admin role or the RESTORE system privilege are allowed to revert a table",
+			)
+		}
+		for _, priv := range []privilege.Kind{privilege.INSERT, privilege.UPDATE, privilege.DELETE} {
+			if err := p.CheckPrivilege(ctx, table, priv); err != nil {
+				return err
+			}
+		}
+	}
+	return sql.CheckDestinationPrivileges(ctx, p, []string{collection})
+}
+
+// planTableRevert checks that table can be reverted to asOf using the revision
+// log in collection, and returns the details of the job reverting it.
+func planTableRevert(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	user username.SQLUsername,
+	table catalog.TableDescriptor,
+	collection string,
+	asOf hlc.Timestamp,
+) (jobspb.AlterTableRevertDetails, error) {
+	if now := execCfg.Clock.Now(); now.Less(asOf) {
+		return jobspb.AlterTableRevertDetails{}, errors.Errorf("cannot revert to %s, which is in the future", asOf)
+	}
+	store, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, collection, user)
+	if err != nil {
+		return jobspb.AlterTableRevertDetails{}, err
+	}
+	defer func() { _ = store.Close() }()
+
+	logStart, err := revertLogStart(ctx, store, asOf)
+	if err != nil {
+		return jobspb.AlterTableRevertDetails{}, err
+	}
+	spans := []roachpb.Span{table.TableSpan(execCfg.Codec)}
+	resolved, err := restorerevlog.ResolvedAfter(ctx, store, spans, logStart, asOf)
+	if err != nil {
+		return jobspb.AlterTableRevertDetails{}, err
+	}
+	if err := checkTableLayoutSince(ctx, store, table, logStart, asOf, resolved); err != nil {
+		return jobspb.AlterTableRevertDetails{}, err
+	}
+	return jobspb.AlterTableRevertDetails{
+		TableID:    table.GetID(),
+		Collection: collection,
+		AsOf:       asOf,
+		LogStart:   logStart,
+		Resolved:   resolved,
+	}, nil
+}
+
+// revertLogStart returns the end time of the latest backup in the collection
+// in store taken at or before asOf, from which its revision log is read.
+func revertLogStart(
+	ctx context.Context, store cloud.ExternalStorage, asOf hlc.Timestamp,
+) (hlc.Timestamp, error) {
+	// Like restores from a revision log, this is prototype-only.
+	if build.IsRelease() {
+		return hlc.Timestamp{}, unimplemented.New("backup.revlog_revert", "reverting a table from a revision log is not supported")
+	}
+	hasLog, err := revlog.HasLog(ctx, store)
+	if err != nil {
+		return hlc.Timestamp{}, err
+	}
+	if !hasLog {
+		return hlc.Timestamp{}, errors.New("backup collection has no revision log")
+	}
+	// End times are only filtered to tens of milliseconds, so a backup slightly
+	// newer than asOf may be listed first.
+	backups, _, err := backupinfo.ListRestorableBackups(
+		ctx, store, time.Time{} /* newerThan */, asOf.GoTime(), 4 /* maxCount */, true, /* openIndex */
+	)
+	if err != nil {
+		return hlc.Timestamp{}, errors.Wrap(err, "finding backup to revert from")
+	}
+	for _, b := range backups {
+		if !asOf.Less(b.EndTime) {
+			return b.EndTime, nil
+		}
+	}
+	return hlc.Timestamp{}, errors.Newf("no backup in the collection ends at or before %s", asOf)
+}
+
+// checkTableLayoutSince checks that the keys of table are encoded now as they
+// were at asOf, according to the schema changes recorded in the revision log
+// between logStart and resolved, so that they can be rewritten with their
+// values as of asOf.
+func checkTableLayoutSince(
+	ctx context.Context,
+	store cloud.ExternalStorage,
+	table catalog.TableDescriptor,
+	logStart, asOf, resolved hlc.Timestamp,
+) error {
+	want := tableLayout(table)
+	for change, err := range revlog.IterSchemaChanges(ctx, store, logStart, resolved) {
+		if err != nil {
+			return err
+		}
+		if change.DescID != table.GetID() {
+			continue
+		}
+		if change.Descriptor == nil || change.Descriptor.GetTable() == nil {
+			return errors.AssertionFailedf("revision log records table %d as dropped at %s", table.GetID(), change.ChangedAt)
+		}
+		desc := tabledesc.NewBuilder(change.Descriptor.GetTable()).BuildImmutableTable()
+		// Only the last change at or before asOf, which is the schema the
+		// table had then, and every change after it matter.
+		if !asOf.Less(change.ChangedAt) {
+			want = tableLayout(desc)
+			continue
+		}
+		if len(desc.AllMutations()) > 0 || tableLayout(desc) != want {
+			return errors.Newf(
+				"cannot revert table %q to %s: its schema was changed at %s", table.GetName(), asOf, change.ChangedAt,
+			)
+		}
+	}
+	if tableLayout(table) != want {
+		return errors.Newf("cannot revert table %q to %s: its schema has changed since", table.GetName(), asOf)
+	}
+	return nil
+}
+
+// tableLayout describes the columns, indexes and column families of a table,
+// which determine how its rows are encoded into keys.
+func tableLayout(desc catalog.TableDescriptor) string {
+	var b strings.Builder
+	for _, col := range desc.PublicColumns() {
+		fmt.Fprintf(&b, "c%d ", col.GetID())
+	}
+	for _, idx := range desc.ActiveIndexes() {
+		fmt.Fprintf(&b, "i%d ", idx.GetID())
+	}
+	for _, fam := range desc.GetFamilies() {
+		fmt.Fprintf(&b, "f%d%v ", fam.ID, fam.ColumnIDs)
+	}
+	return b.String()
+}
+
+// alterTableRevertResumer implements the job that reverts a table for ALTER
+// TABLE ... REVERT TO SYSTEM TIME. The table is taken offline when the job is
+// created, and brought back online when the job completes or fails.
+type alterTableRevertResumer struct {
+	job *jobs.Job
+
+	// keysReverted is the number of keys rewritten from the revision log, which
+	// is reported as the result of the statement.
+	keysReverted int
+}
+
+var _ jobs.Resumer = (*alterTableRevertResumer)(nil)
+
+// Resume is part of the jobs.Resumer interface.
+func (r *alterTableRevertResumer) Resume(ctx context.Context, execCtx interface{}) error {
+	p := execCtx.(sql.JobExecContext)
+	execCfg := p.ExecCfg()
+	details := r.job.Details().(jobspb.AlterTableRevertDetails)
+
+	// Once the leases on the versions of the table before it was taken offline
+	// have drained, nothing can write to it.
+	cachedRegions, err := regions.NewCachedDatabaseRegions(ctx, execCfg.DB, execCfg.LeaseManager)
+	if err != nil {
+		return err
+	}
+	if _, err := sql.WaitToUpdateLeases(ctx, execCfg.LeaseManager, cachedRegions, details.TableID); err != nil {
+		return err
+	}
+
+	store, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, details.Collection, r.job.Payload().UsernameProto.Decode())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = store.Close() }()
+
+	spans := []roachpb.Span{execCfg.Codec.TableSpan(uint32(details.TableID))}
+	if err := revert.RevertSpans(
+		ctx, execCfg.DB, spans, details.Resolved, false /* ignoreGCThreshold */, revert.RevertDefaultBatchSize, nil, /* onCompletedSpan */
+	); err != nil {
+		return errors.Wrapf(err, "reverting changes made after %s", details.Resolved)
+	}
+	if err := execCfg.JobRegistry.CheckPausepoint("alter_table_revert.before_rewrite"); err != nil {
+		return err
+	}
+	mem := execCfg.RootMemoryMonitor.MakeBoundAccount()
+	defer mem.Close(ctx)
+	r.keysReverted = 0
+	if err := restorerevlog.ValuesAsOf(
+		ctx, store, spans, details.LogStart, details.AsOf, details.Resolved, &mem, revertTableBatchSize,
+		func(page []roachpb.KeyValue) error {
+			if err := rewriteKeys(ctx, execCfg, page); err != nil {
+				return err
+			}
+			r.keysReverted += len(page)
+			return nil
+		},
+	); err != nil {
+		return errors.Wrap(err, "rewriting reverted keys")
+	}
+	log.Dev.Infof(ctx,
+		"reverted %d keys of table %d to %s; revision log resolved through %s",
+		r.keysReverted, details.TableID, details.AsOf, details.Resolved,
+	)
+
+	table, err := setTableOnline(ctx, execCfg, details.TableID)
+	if err != nil {
+		return err
+	}
+	if table != nil {
+		execCfg.StatsRefresher.NotifyMutation(ctx, table, r.keysReverted)
+	}
+	return nil
+}
+
+// OnFailOrCancel is part of the jobs.Resumer interface. It brings the table
+// back online, since leaving it offline would make it unusable. The table may
+// be partially reverted; reverting it again rewrites every key changed since
+// the target time, including the ones rewritten by this job.
+func (r *alterTableRevertResumer) OnFailOrCancel(
+	ctx context.Context, execCtx interface{}, jobErr error,
+) error {
+	details := r.job.Details().(jobspb.AlterTableRevertDetails)
+	log.Dev.Warningf(ctx,
+		"bringing table %d back online after failing to revert it, which may have left it partially reverted: %v",
+		details.TableID, jobErr,
+	)
+	_, err := setTableOnline(ctx, execCtx.(sql.JobExecContext).ExecCfg(), details.TableID)
+	return err
+}
+
+// CollectProfile is part of the jobs.Resumer interface.
+func (r *alterTableRevertResumer) CollectProfile(context.Context, interface{}) error {
+	return nil
+}
+
+// ReportResults implements the jobs.JobResultsReporter interface.
+func (r *alterTableRevertResumer) ReportResults(
+	ctx context.Context, resultsCh chan<- tree.Datums,
+) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case resultsCh <- tree.Datums{tree.NewDInt(tree.DInt(r.keysReverted))}:
+		return nil
+	}
+}
+
+// setTableOnline brings the table with the given ID back online after it was
+// taken offline to be reverted, and returns its descriptor. It does nothing if
+// the table is already online or has been dropped.
+func setTableOnline(
+	ctx context.Context, execCfg *sql.ExecutorConfig, tableID descpb.ID,
+) (catalog.TableDescriptor, error) {
+	var table catalog.TableDescriptor
+	err := sql.DescsTxn(ctx, execCfg, func(ctx context.Context, txn isql.Txn, col *descs.Collection) error {
+		mut, err := col.MutableByID(txn.KV()).Table(ctx, tableID)
+		if err != nil {
+			return err
+		}
+		table = mut
+		if !mut.Offline() {
+			return nil
+		}
+		if mut.GetOfflineReason() != offlineReasonReverting {
+			return errors.AssertionFailedf("table %d is offline: %s", tableID, mut.GetOfflineReason())
+		}
+		mut.SetPublic()
+		return col.WriteDesc(ctx, false /* kvTrace */, mut, txn.KV())
+	})
+	if errors.Is(err, catalog.ErrDescriptorNotFound) {
+		return nil, nil
+	}
+	return table, err
+}
+
+// rewriteKeys writes kvs, the values as of the target time of keys changed
+// since then, over the current values of the keys. An empty value deletes its
+// key.
+func rewriteKeys(ctx context.Context, execCfg *sql.ExecutorConfig, kvs []roachpb.KeyValue) error {
+	return execCfg.DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		b := txn.NewBatch()
+		for _, e := range kvs {
+			if len(e.Value.RawBytes) == 0 {
+				b.Del(e.Key)
+				continue
+			}
+			v := e.Value
+			v.ClearChecksum()
+			v.InitChecksum(e.Key)
+			b.Put(e.Key, &v)
+		}
+		return txn.CommitInBatch(ctx, b)
+	})
+}
+
+func init() {
+	sql.AddPlanHook("alter table revert", alterTableRevertPlanHook, alterTableRevertTypeCheck)
+	jobs.RegisterConstructor(
+		jobspb.TypeAlterTableRevert,
+		func(job *jobs.Job, _ *cluster.Settings) jobs.Resumer {
+			return &alterTableRevertResumer{job: job}
+		},
+		jobs.UsesTenantCostControl,
+	)
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+// Full backups taken with the deduplicate option store their data files in a
+// pool shared by the backups of the collection rather than in their own
+// directory. A pooled file is named by the digest of its contents, so a file
+// that a previous full backup already wrote is referenced by the manifest of
+// the new backup instead of being uploaded again. Manifests reference pooled
+// files by their path relative to the backup directory, so restore, compaction
+// and COPY BACKUP read them like any other file.
+//
+// Pooled files are never overwritten or deleted by backups, which keeps the
+// pool compatible with WORM buckets. Rather than counting references, the pool
+// is split into generations: a full backup only shares files with backups
+// whose end times fall in the same generation, so the files of a generation
+// are no longer needed once every backup of the generation has expired. A
+// bucket TTL on the pool must therefore exceed the TTL of the backups by the
+// generation interval.
+
+// dedupPoolDirectory is the directory of a collection that holds the pool.
+const dedupPoolDirectory = "dedup"
+
+// dedupGenerationFormat is the format of the start time of a generation that
+// names its directory in the pool.
+const dedupGenerationFormat = "20060102-150405"
+
+var dedupGenerationInterval = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"backup.deduplicate.generation_interval",
+	"the length of time covered by each generation of the pool that deduplicated full backups "+
+		"store their files in; a bucket TTL on the pool must exceed the TTL of the backups by this interval",
+	7*24*time.Hour,
+	settings.PositiveDuration,
+)
+
+// dedupPoolPath returns the directory of the pool generation that a
+// deduplicated full backup in subdir that ends at endTime writes its files to,
+// relative to the directory of the backup.
+func dedupPoolPath(subdir string, endTime hlc.Timestamp, interval time.Duration) (string, error) {
+	subdir = strings.Trim(subdir, "/")
+	if subdir == "" {
+		return "", errors.New("the deduplicate option is only supported for backups into a collection")
+	}
+	generation := endTime.GoTime().UTC().Truncate(interval).Format(dedupGenerationFormat)
+	return strings.Repeat("../", strings.Count(subdir, "/")+1) +
+		path.Join(dedupPoolDirectory, generation), nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDedupPoolPath(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	endTime := hlc.Timestamp{WallTime: time.Date(2026, 3, 12, 15, 4, 5, 0, time.UTC).UnixNano()}
+	for _, tc := range []struct {
+		subdir   string
+		interval time.Duration
+		expected string
+	}{
+		{"/2026/03/12-150405.00", 24 * time.Hour, "../../../dedup/20260312-000000"},
+		{"2026/03/12-150405.00/", time.Hour, "../../../dedup/20260312-150000"},
+		{"/full", 24 * time.Hour, "../dedup/20260312-000000"},
+	} {
+		t.Run(tc.subdir, func(t *testing.T) {
+			p, err := dedupPoolPath(tc.subdir, endTime, tc.interval)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, p)
+		})
+	}
+
+	_, err := dedupPoolPath("", endTime, time.Hour)
+	require.ErrorContains(t, err, "only supported for backups into a collection")
+}
+
+// TestDeduplicatedBackup checks that deduplicated full backups share the files
+// of unchanged data, and that they can be restored.
+func TestDeduplicatedBackup(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numAccounts = 100
+	_, sqlDB, dir, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, InitManualReplication)
+	defer cleanupFn()
+
+	const collection = "nodelocal://1/deduped"
+	pooledFiles := func() []string {
+		matches, err := filepath.Glob(filepath.Join(dir, "deduped", dedupPoolDirectory, "*", "*.sst"))
+		require.NoError(t, err)
+		return matches
+	}
+
+	sqlDB.ExpectErr(t, "cannot be used with encrypted backups",
+		`BACKUP TABLE data.bank INTO $1 WITH deduplicate, encryption_passphrase = 'abc'`, collection)
+
+	sqlDB.Exec(t, `BACKUP TABLE data.bank INTO $1 WITH deduplicate`, collection)
+	firstFiles := pooledFiles()
+	require.NotEmpty(t, firstFiles)
+	ownFiles, err := filepath.Glob(filepath.Join(dir, "deduped", "*", "*", "*", "data", "*.sst"))
+	require.NoError(t, err)
+	require.Empty(t, ownFiles)
+
+	// A second full backup of the same data writes no new files to the pool.
+	sqlDB.Exec(t, `BACKUP TABLE data.bank INTO $1 WITH deduplicate`, collection)
+	require.Equal(t, firstFiles, pooledFiles())
+
+	// Incremental backups write their files to their own directory.
+	sqlDB.Exec(t, `UPDATE data.bank SET balance = balance + 1 WHERE id < 10`)
+	sqlDB.Exec(t, `BACKUP TABLE data.bank INTO LATEST IN $1 WITH deduplicate`, collection)
+	require.Equal(t, firstFiles, pooledFiles())
+	expected := sqlDB.QueryStr(t, `SELECT * FROM data.bank ORDER BY id`)
+
+	sqlDB.Exec(t, `SHOW BACKUP LATEST IN $1 WITH check_files`, collection)
+	sqlDB.Exec(t, `CREATE DATABASE restored`)
+	sqlDB.Exec(t, `RESTORE TABLE data.bank FROM LATEST IN $1 WITH into_db = 'restored'`, collection)
+	sqlDB.CheckQueryResults(t, `SELECT * FROM restored.bank ORDER BY id`, expected)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/backup/backupencryption"
 	"github.com/cockroachdb/cockroach/pkg/backup/backupinfo"
 	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupsign"
 	"github.com/cockroachdb/cockroach/pkg/backup/backuputils"
 	"github.com/cockroachdb/cockroach/pkg/build"
 	"github.com/cockroachdb/cockroach/pkg/build/bazel"
@@ -354,6 +355,8 @@ func backup(
 		backupManifest.StartTime,
 		backupManifest.EndTime,
 		backupManifest.ElidedPrefix,
+		details.SigningKMSURI != "",
+		details.DedupPool,
 	)
 	if err != nil {
 		return roachpb.RowCount{}, nil, 0, err
@@ -900,6 +903,12 @@ func (b *backupResumer) Resume(ctx context.Context, execCtx interface{}) error {
 		}
 	}
 
+	// The recorded size is only used to forecast future backups, so failing to
+	// write it should not fail the backup.
+	if err := recordBackupSize(ctx, p.ExecCfg().InternalDB, b.job.ID(), backupManifest, res); err != nil {
+		log.Dev.Warningf(ctx, "failed to record size of backup: %v", err)
+	}
+
 	b.backupStats = res
 
 	// Collect telemetry.
@@ -1843,6 +1852,26 @@ func getBackupDetailAndManifest(
 		return jobspb.BackupDetails{}, nil, err
 	}
 
+	if updatedDetails.Deduplicate && len(prevBackups) == 0 {
+		updatedDetails.DedupPool, err = dedupPoolPath(
+			updatedDetails.Destination.Subdir, updatedDetails.EndTime,
+			dedupGenerationInterval.Get(&execCfg.Settings.SV),
+		)
+		if err != nil {
+			return jobspb.BackupDetails{}, nil, err
+		}
+	}
+
+	if len(prevBackups) > 0 {
+		updatedDetails.SigningPreviousMAC, err = previousBackupSignature(
+			ctx, execCfg, user, backupDestination.PrevBackupURIs[len(prevBackups)-1],
+			updatedDetails.SigningKMSURI, updatedDetails.SigningKey, &kmsEnv,
+		)
+		if err != nil {
+			return jobspb.BackupDetails{}, nil, err
+		}
+	}
+
 	layerToIterFactory, err := backupinfo.GetBackupManifestIterFactories(ctx, execCfg.DistSQLSrv.ExternalStorage, prevBackups, baseEncryptionOptions, &kmsEnv)
 	if err != nil {
 		return jobspb.BackupDetails{}, nil, err
@@ -1864,6 +1893,52 @@ func getBackupDetailAndManifest(
 	return updatedDetails, &backupManifest, nil
 }
 
+// previousBackupSignature returns the MAC of the signature of the backup at
+// prevURI, which an incremental backup signed with signingKey, wrapped by
+// signingKMSURI, links to. A signed chain can only be extended by signed
+// backups, and vice versa.
+func previousBackupSignature(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	user username.SQLUsername,
+	prevURI string,
+	signingKMSURI string,
+	signingKey []byte,
+	kmsEnv cloud.KMSEnv,
+) ([]byte, error) {
+	store, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, prevURI, user)
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+	sig, err := backupsign.ReadSignature(ctx, store)
+	if err != nil {
+		if !errors.Is(err, cloud.ErrFileDoesNotExist) {
+			return nil, errors.Wrap(err, "reading signature of previous backup")
+		}
+		if signingKMSURI != "" {
+			return nil, errors.New("cannot sign an incremental backup on top of an unsigned backup; take a new signed full backup")
+		}
+		return nil, nil
+	}
+	if signingKMSURI == "" {
+		return nil, errors.New("the previous backup in the chain is signed; incremental backups on top of it must set signing_kms and signing_key")
+	}
+	kms, err := cloud.KMSFromURI(ctx, signingKMSURI, kmsEnv)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := kms.Close(); err != nil {
+			log.Dev.Infof(ctx, "failed to close KMS: %+v", err)
+		}
+	}()
+	if _, err := backupsign.Verify(ctx, kms, signingKey, sig); err != nil {
+		return nil, errors.Wrap(err, "verifying signature of previous backup")
+	}
+	return sig.MAC, nil
+}
+
 func (b *backupResumer) readManifestOnResume(
 	ctx context.Context,
 	mem *mon.BoundAccount,
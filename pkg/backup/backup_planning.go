@@ -8,6 +8,7 @@ package backup
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"strings"
 
@@ -78,6 +79,7 @@ func resolveOptionsForBackupJobDescription(
 		UpdatesClusterMonitoringMetrics: opts.UpdatesClusterMonitoringMetrics,
 		Strict:                          opts.Strict,
 		RevisionStream:                  opts.RevisionStream,
+		Deduplicate:                     opts.Deduplicate,
 	}
 
 	if opts.EncryptionPassphrase != nil {
@@ -91,9 +93,71 @@ func resolveOptionsForBackupJobDescription(
 		return tree.BackupOptions{}, err
 	}
 
+	if opts.SigningKMSURI != nil {
+		newOpts.SigningKMSURI, err = redactKMSURIExpr(opts.SigningKMSURI)
+		if err != nil {
+			return tree.BackupOptions{}, err
+		}
+	}
+	if opts.SigningKey != nil {
+		newOpts.SigningKey = tree.NewDString("redacted")
+	}
+
 	return newOpts, nil
 }
 
+// evalSigningOptions evaluates the signing_kms and signing_key options, which
+// must be given together, and returns the KMS URI and the wrapped signing key.
+func evalSigningOptions(
+	ctx context.Context, exprEval exprutil.Evaluator, kmsExpr, keyExpr tree.Expr,
+) (kmsURI string, wrappedKey []byte, _ error) {
+	if kmsExpr == nil && keyExpr == nil {
+		return "", nil, nil
+	}
+	if kmsExpr == nil || keyExpr == nil {
+		return "", nil, errors.WithHint(
+			errors.New("signing_kms and signing_key must be specified together"),
+			"signing_key is a signing key of at least 32 bytes, wrapped by the KMS given by "+
+				"signing_kms and encoded in base64",
+		)
+	}
+	kmsURI, err := exprEval.String(ctx, kmsExpr)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := logAndSanitizeKmsURIs(ctx, kmsURI); err != nil {
+		return "", nil, err
+	}
+	encodedKey, err := exprEval.String(ctx, keyExpr)
+	if err != nil {
+		return "", nil, err
+	}
+	wrappedKey, err = base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "decoding signing_key")
+	}
+	return kmsURI, wrappedKey, nil
+}
+
+// redactKMSURIExpr redacts the secrets of a KMS URI given as a string literal.
+// Placeholders are left as they are.
+func redactKMSURIExpr(e tree.Expr) (tree.Expr, error) {
+	var uri string
+	switch t := e.(type) {
+	case *tree.StrVal:
+		uri = t.RawString()
+	case *tree.DString:
+		uri = string(*t)
+	default:
+		return e, nil
+	}
+	redacted, err := cloud.RedactKMSURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDString(redacted), nil
+}
+
 // GetRedactedBackupNode returns a copy of the argument `backup`, but with all
 // the secret information redacted.
 func GetRedactedBackupNode(
@@ -368,6 +432,8 @@ func backupTypeCheck(
 			backupStmt.Subdir,
 			backupStmt.Options.EncryptionPassphrase,
 			backupStmt.Options.ExecutionLocality,
+			backupStmt.Options.SigningKMSURI,
+			backupStmt.Options.SigningKey,
 		},
 		exprutil.StringArrays{
 			tree.Exprs(backupStmt.To),
@@ -377,6 +443,7 @@ func backupTypeCheck(
 			backupStmt.Options.CaptureRevisionHistory,
 			backupStmt.Options.IncludeAllSecondaryTenants,
 			backupStmt.Options.UpdatesClusterMonitoringMetrics,
+			backupStmt.Options.Deduplicate,
 		}); err != nil {
 		return false, nil, err
 	}
@@ -499,6 +566,13 @@ func backupPlanHook(
 		}
 	}
 
+	signingKMS, signingKey, err := evalSigningOptions(
+		ctx, exprEval, backupStmt.Options.SigningKMSURI, backupStmt.Options.SigningKey,
+	)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
 	var updatesClusterMonitoringMetrics bool
 	if backupStmt.Options.UpdatesClusterMonitoringMetrics != nil {
 		updatesClusterMonitoringMetrics, err = exprEval.Bool(
@@ -509,6 +583,19 @@ func backupPlanHook(
 		}
 	}
 
+	var deduplicate bool
+	if backupStmt.Options.Deduplicate != nil {
+		deduplicate, err = exprEval.Bool(ctx, backupStmt.Options.Deduplicate)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		// Pooled files are shared by backups that may not share an encryption
+		// key, and encrypting them would defeat content addressing anyway.
+		if deduplicate && encryptionParams.Mode != jobspb.EncryptionMode_None {
+			return nil, nil, false, errors.New("the deduplicate option cannot be used with encrypted backups")
+		}
+	}
+
 	fn := func(ctx context.Context, resultsCh chan<- tree.Datums) error {
 		// TODO(dan): Move this span into sql.
 		ctx, span := tracing.ChildSpan(ctx, stmt.StatementTag())
@@ -599,6 +686,9 @@ func backupPlanHook(
 			UpdatesClusterMonitoringMetrics: updatesClusterMonitoringMetrics,
 			StrictLocalityFiltering:         backupStmt.Options.Strict,
 			CreateRevlogJob:                 backupStmt.Options.RevisionStream,
+			SigningKMSURI:                   signingKMS,
+			SigningKey:                      signingKey,
+			Deduplicate:                     deduplicate,
 		}
 		if backupStmt.CreatedByInfo != nil {
 			initialDetails.ScheduleID = backupStmt.CreatedByInfo.ScheduleID()
@@ -372,11 +372,14 @@ func runBackupProcessor(
 	}
 
 	sinkConf := backupsink.SSTSinkConf{
-		ID:        flowCtx.NodeID.SQLInstanceID(),
-		Enc:       spec.Encryption,
-		ProgCh:    progCh,
-		Settings:  &flowCtx.Cfg.Settings.SV,
-		ElideMode: spec.ElidePrefix,
+		ID:            flowCtx.NodeID.SQLInstanceID(),
+		Enc:           spec.Encryption,
+		ProgCh:        progCh,
+		Settings:      &flowCtx.Cfg.Settings.SV,
+		ElideMode:     spec.ElidePrefix,
+		ChecksumFiles: spec.ChecksumFiles,
+		DedupPool:     spec.DedupPool,
+		MemMonitor:    memAcc.Monitor(),
 	}
 
 	storage, err := flowCtx.Cfg.ExternalStorage(ctx, dest, cloud.WithClientName("backup"))
@@ -44,6 +44,8 @@ func distBackupPlanSpecs(
 	mvccFilter kvpb.MVCCFilter,
 	startTime, endTime hlc.Timestamp,
 	elide execinfrapb.ElidePrefix,
+	checksumFiles bool,
+	dedupPool string,
 ) (map[base.SQLInstanceID]*execinfrapb.BackupDataSpec, error) {
 	var span *tracing.Span
 	ctx, span = tracing.ChildSpan(ctx, "backup.distBackupPlanSpecs")
@@ -109,6 +111,8 @@ func distBackupPlanSpecs(
 			ElidePrefix:            elide,
 			IncludeMVCCValueHeader: true,
 			StrictLocality:         strictLocalityFiltering,
+			ChecksumFiles:          checksumFiles,
+			DedupPool:              dedupPool,
 		}
 		sqlInstanceIDToSpec[partition.SQLInstanceID] = spec
 	}
@@ -133,6 +137,8 @@ func distBackupPlanSpecs(
 				UserProto:              user.EncodeProto(),
 				IncludeMVCCValueHeader: true,
 				StrictLocality:         strictLocalityFiltering,
+				ChecksumFiles:          checksumFiles,
+				DedupPool:              dedupPool,
 			}
 			sqlInstanceIDToSpec[partition.SQLInstanceID] = spec
 		}
@@ -0,0 +1,423 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupsink"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowexec"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/errors"
+)
+
+const backupTableReaderProcessorName = "backupTableReader"
+
+// backupTableReaderBatchSize is the number of KVs which are decoded into rows
+// at a time by a backupTableReader.
+const backupTableReaderBatchSize = 1000
+
+// backupTableReader reads the rows of a span of a table's primary index
+// directly from the files of a backup, or, if its spec has DiffFrom set, the
+// differences between the rows in the span in two backups of the table.
+type backupTableReader struct {
+	execinfra.ProcessorBase
+
+	spec   execinfrapb.BackupTableReaderSpec
+	backup backupTableSource
+	// diffFrom is set if the processor returns the differences between the
+	// rows of diffFrom and backup.
+	diffFrom *backupTableSource
+
+	rowCh                  chan rowenc.EncDatumRow
+	cancelAndWaitForWorker func()
+	readErr                error
+}
+
+var (
+	_ execinfra.Processor = &backupTableReader{}
+	_ execinfra.RowSource = &backupTableReader{}
+)
+
+func newBackupTableReader(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	spec execinfrapb.BackupTableReaderSpec,
+	post *execinfrapb.PostProcessSpec,
+) (execinfra.Processor, error) {
+	backup, err := makeBackupTableSource(ctx, &spec.Backup)
+	if err != nil {
+		return nil, err
+	}
+	processor := &backupTableReader{
+		spec:   spec,
+		backup: backup,
+		rowCh:  make(chan rowenc.EncDatumRow),
+	}
+	var outTypes []*types.T
+	if spec.DiffFrom != nil {
+		diffFrom, err := makeBackupTableSource(ctx, spec.DiffFrom)
+		if err != nil {
+			return nil, err
+		}
+		processor.diffFrom = &diffFrom
+		outTypes = make([]*types.T, len(showBackupDiffHeader))
+		for i, col := range showBackupDiffHeader {
+			outTypes[i] = col.Typ
+		}
+	} else {
+		outTypes = make([]*types.T, len(backup.cols))
+		for i, col := range backup.cols {
+			outTypes[i] = backupTableColumnType(col)
+		}
+	}
+	if err := processor.Init(ctx, processor, post, outTypes, flowCtx, processorID, nil, /* memMonitor */
+		execinfra.ProcStateOpts{
+			InputsToDrain: nil,
+			TrailingMetaCallback: func() []execinfrapb.ProducerMetadata {
+				processor.close()
+				return nil
+			},
+		}); err != nil {
+		return nil, err
+	}
+	return processor, nil
+}
+
+func (p *backupTableReader) Start(ctx context.Context) {
+	p.StartInternal(ctx, backupTableReaderProcessorName)
+	ctx, cancel := context.WithCancel(ctx)
+	p.cancelAndWaitForWorker = func() {
+		cancel()
+		for range p.rowCh {
+		}
+	}
+	if err := p.FlowCtx.Stopper().RunAsyncTaskEx(ctx, stop.TaskOpts{
+		TaskName: backupTableReaderProcessorName + ".read",
+		SpanOpt:  stop.ChildSpan,
+	}, func(ctx context.Context) {
+		p.readErr = p.read(ctx)
+		cancel()
+		close(p.rowCh)
+	}); err != nil {
+		p.readErr = err
+		cancel()
+		close(p.rowCh)
+	}
+}
+
+func (p *backupTableReader) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata) {
+	for p.State == execinfra.StateRunning {
+		row, ok := <-p.rowCh
+		if !ok {
+			p.MoveToDraining(p.readErr)
+			break
+		}
+		if outRow := p.ProcessRowHelper(row); outRow != nil {
+			return outRow, nil
+		}
+	}
+	return nil, p.DrainHelper()
+}
+
+func (p *backupTableReader) close() {
+	if p.Closed {
+		return
+	}
+	if p.cancelAndWaitForWorker != nil {
+		p.cancelAndWaitForWorker()
+	}
+	p.InternalClose()
+}
+
+// ConsumerClosed is part of the RowSource interface. We have to override the
+// implementation provided by ProcessorBase.
+func (p *backupTableReader) ConsumerClosed() {
+	p.close()
+}
+
+// read reads the rows of the processor's span from the backup, or their
+// differences from the rows of diffFrom, and sends them to rowCh.
+func (p *backupTableReader) read(ctx context.Context) error {
+	emit := func(datums tree.Datums) error {
+		row := make(rowenc.EncDatumRow, len(datums))
+		for i, d := range datums {
+			row[i] = rowenc.DatumToEncDatumUnsafe(p.OutputTypes[i], backupTableDatum(d))
+		}
+		return p.emit(ctx, row)
+	}
+	if p.diffFrom != nil {
+		return diffBackupTableRows(ctx, p.FlowCtx, *p.diffFrom, p.backup, p.spec.Span, emit)
+	}
+	return readBackupTableRows(ctx, p.FlowCtx, p.backup, p.spec.Span, emit)
+}
+
+// emit sends a row to the consumer of the processor.
+func (p *backupTableReader) emit(ctx context.Context, row rowenc.EncDatumRow) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case p.rowCh <- row:
+		return nil
+	}
+}
+
+// backupTableSource is a backup of a table from which a backupTableReader
+// reads rows.
+type backupTableSource struct {
+	spec  *execinfrapb.BackupTableReaderSpec_Backup
+	table catalog.TableDescriptor
+	codec keys.SQLCodec
+	// cols are the columns of the table which are read, in the order of
+	// spec.ColumnIDs.
+	cols []catalog.Column
+}
+
+func makeBackupTableSource(
+	ctx context.Context, spec *execinfrapb.BackupTableReaderSpec_Backup,
+) (backupTableSource, error) {
+	table, err := hydrateBackupTable(ctx, &spec.Table, spec.Types)
+	if err != nil {
+		return backupTableSource{}, err
+	}
+	cols := make([]catalog.Column, len(spec.ColumnIDs))
+	for i, id := range spec.ColumnIDs {
+		if cols[i], err = catalog.MustFindColumnByID(table, id); err != nil {
+			return backupTableSource{}, err
+		}
+	}
+	return backupTableSource{
+		spec:  spec,
+		table: table,
+		codec: keys.MakeSQLCodec(spec.TenantID),
+		cols:  cols,
+	}, nil
+}
+
+// backupTableColumnType returns the type with which the values of the column
+// are returned from a backup. Values of user-defined types are returned as
+// strings, since the type in the backup need not exist in the cluster, or may
+// be a different type there.
+func backupTableColumnType(col catalog.Column) *types.T {
+	if col.GetType().UserDefined() {
+		return types.String
+	}
+	return col.GetType()
+}
+
+// backupTableDatum converts a value read from a backup to the type returned
+// by backupTableColumnType.
+func backupTableDatum(d tree.Datum) tree.Datum {
+	if d == tree.DNull || !d.ResolvedType().UserDefined() {
+		return d
+	}
+	return tree.NewDString(tree.AsStringWithFlags(d, tree.FmtPgwireText))
+}
+
+// hydrateBackupTable builds the descriptor of a table read from a backup, with
+// the user-defined types of its columns hydrated from the descriptors of the
+// types in the backup.
+func hydrateBackupTable(
+	ctx context.Context, table *descpb.TableDescriptor, typeDescs []*descpb.TypeDescriptor,
+) (catalog.TableDescriptor, error) {
+	byID := make(map[descpb.ID]catalog.TypeDescriptor, len(typeDescs))
+	for _, t := range typeDescs {
+		byID[t.ID] = typedesc.NewBuilder(t).BuildImmutableType()
+	}
+	desc := tabledesc.NewBuilder(table).BuildImmutableTable()
+	if err := typedesc.HydrateTypesInDescriptor(ctx, desc, typedesc.TypeLookupFunc(
+		func(ctx context.Context, id descpb.ID) (tree.TypeName, catalog.TypeDescriptor, error) {
+			typ, ok := byID[id]
+			if !ok {
+				return tree.TypeName{}, nil, catalog.NewDescriptorNotFoundError(id)
+			}
+			return tree.MakeUnqualifiedTypeName(typ.GetName()), typ, nil
+		},
+	)); err != nil {
+		return nil, errors.Wrapf(err, "hydrating the types of table %q in the backup", table.Name)
+	}
+	return desc, nil
+}
+
+// readBackupTableRows reads the rows of the span of the table's primary index
+// from the files of the backup's restore span entries, in primary key order,
+// and calls fn with the values of the backup's columns in each of them. The
+// datums passed to fn are only valid until fn returns.
+func readBackupTableRows(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	src backupTableSource,
+	span roachpb.Span,
+	fn func(tree.Datums) error,
+) error {
+	backup, table := src.spec, src.table
+	var spec fetchpb.IndexFetchSpec
+	if err := rowenc.InitIndexFetchSpec(
+		&spec, src.codec, table, table.GetPrimaryIndex(), backup.ColumnIDs,
+	); err != nil {
+		return err
+	}
+	var rf row.Fetcher
+	if err := rf.Init(ctx, row.FetcherInitArgs{
+		WillUseKVProvider: true,
+		Alloc:             &tree.DatumAlloc{},
+		Spec:              &spec,
+	}); err != nil {
+		return err
+	}
+	defer rf.Close(ctx)
+
+	// emitRows decodes a batch of KVs, which must not split a row, into rows.
+	emitRows := func(kvs []roachpb.KeyValue) error {
+		if err := rf.ConsumeKVProvider(ctx, &row.KVProvider{KVs: kvs}); err != nil {
+			return err
+		}
+		for {
+			datums, _, err := rf.NextRowDecoded(ctx)
+			if err != nil {
+				return err
+			}
+			if datums == nil {
+				return nil
+			}
+			if err := fn(datums); err != nil {
+				return err
+			}
+		}
+	}
+
+	kvs := make([]roachpb.KeyValue, 0, backupTableReaderBatchSize)
+	var lastRowPrefix roachpb.Key
+	for _, entry := range backup.Entries {
+		entrySpan := entry.Span.Intersect(span)
+		if !entrySpan.Valid() {
+			continue
+		}
+		if err := scanBackupSpanEntry(
+			ctx, flowCtx, entry, entrySpan, backup.EndTime, backup.Encryption,
+			func(kv roachpb.KeyValue) error {
+				// Batches are only cut between rows, since all the column families
+				// of a row must be decoded together.
+				rowPrefix, err := keys.EnsureSafeSplitKey(kv.Key)
+				if err != nil {
+					return err
+				}
+				if len(kvs) >= backupTableReaderBatchSize && !rowPrefix.Equal(lastRowPrefix) {
+					if err := emitRows(kvs); err != nil {
+						return err
+					}
+					kvs = make([]roachpb.KeyValue, 0, backupTableReaderBatchSize)
+				}
+				lastRowPrefix = rowPrefix
+				kvs = append(kvs, kv)
+				return nil
+			},
+		); err != nil {
+			return err
+		}
+	}
+	if len(kvs) > 0 {
+		return emitRows(kvs)
+	}
+	return nil
+}
+
+// scanBackupSpanEntry calls fn with the latest value as of endTime of each key
+// in span, which must be within the span of the restore span entry, read from
+// the entry's files.
+func scanBackupSpanEntry(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	entry execinfrapb.RestoreSpanEntry,
+	span roachpb.Span,
+	endTime hlc.Timestamp,
+	encryption *kvpb.FileEncryptionOptions,
+	fn func(roachpb.KeyValue) error,
+) error {
+	storeFiles := make([]storage.StoreFile, 0, len(entry.Files))
+	defer func() {
+		for _, f := range storeFiles {
+			if err := f.Store.Close(); err != nil {
+				log.Dev.Warningf(ctx, "close export storage failed %v", err)
+			}
+		}
+	}()
+	for _, file := range entry.Files {
+		dir, err := flowCtx.Cfg.ExternalStorage(ctx, file.Dir)
+		if err != nil {
+			return err
+		}
+		storeFiles = append(storeFiles, storage.StoreFile{Store: dir, FilePath: file.Path})
+	}
+
+	iterOpts := storage.IterOptions{
+		RangeKeyMaskingBelow: endTime,
+		KeyTypes:             storage.IterKeyTypePointsAndRanges,
+		LowerBound:           keys.LocalMax,
+		UpperBound:           keys.MaxKey,
+	}
+	sstIter, err := storage.ExternalSSTReader(ctx, storeFiles, encryption, iterOpts)
+	if err != nil {
+		return err
+	}
+	iter := storage.NewReadAsOfIterator(sstIter, endTime)
+	defer iter.Close()
+
+	elidedPrefix, err := backupsink.ElidedPrefix(entry.Span.Key, entry.ElidedPrefix)
+	if err != nil {
+		return err
+	}
+	startKey := storage.MVCCKey{Key: bytes.TrimPrefix(span.Key, elidedPrefix)}
+	for iter.SeekGE(startKey); ; iter.NextKey() {
+		ok, err := iter.Valid()
+		if err != nil {
+			return errors.Join(backupFileReadError, err)
+		}
+		if !ok {
+			return nil
+		}
+		key := iter.UnsafeKey()
+		fullKey := append(append(roachpb.Key(nil), elidedPrefix...), key.Key...)
+		if fullKey.Compare(span.EndKey) >= 0 {
+			return nil
+		}
+		v, err := iter.UnsafeValue()
+		if err != nil {
+			return errors.Join(backupFileReadError, err)
+		}
+		value, err := storage.DecodeValueFromMVCCValue(append([]byte(nil), v...))
+		if err != nil {
+			return errors.Join(backupFileReadError, err)
+		}
+		value.Timestamp = key.Timestamp
+		if err := fn(roachpb.KeyValue{Key: fullKey, Value: value}); err != nil {
+			return err
+		}
+	}
+}
+
+func init() {
+	rowexec.NewBackupTableReaderProcessor = newBackupTableReader
+}
@@ -501,6 +501,12 @@ func GetEncryptionInfoFiles(ctx context.Context, dest cloud.ExternalStorage) ([]
 	return files, err
 }
 
+// IsEncryptionInfoFile returns whether the base name of a file is that of an
+// ENCRYPTION-INFO file, including the versioned ones written by ALTER BACKUP.
+func IsEncryptionInfoFile(basename string) bool {
+	return strings.HasPrefix(basename, backupEncryptionInfoFile)
+}
+
 // WriteEncryptionInfoIfNotExists writes EncryptionInfo to external storage.
 func WriteEncryptionInfoIfNotExists(
 	ctx context.Context, opts *jobspb.EncryptionInfo, dest cloud.ExternalStorage,
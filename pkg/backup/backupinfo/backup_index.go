@@ -90,7 +90,7 @@ func WriteBackupIndexMetadata(
 		return errors.AssertionFailedf("incremental backup details missing a start time")
 	}
 
-	path, err := backuputils.AbsoluteBackupPathInCollectionURI(details.CollectionURI, details.URI)
+	backupPath, err := backuputils.AbsoluteBackupPathInCollectionURI(details.CollectionURI, details.URI)
 	if err != nil {
 		return err
 	}
@@ -101,11 +101,14 @@ func WriteBackupIndexMetadata(
 	metadata := &backuppb.BackupIndexMetadata{
 		StartTime:         details.StartTime,
 		EndTime:           details.EndTime,
-		Path:              path,
+		Path:              backupPath,
 		IsCompacted:       details.Compact,
 		MVCCFilter:        mvccFilter,
 		RevisionStartTime: revisionStartTS,
 	}
+	if details.DedupPool != "" {
+		metadata.DedupPool = strings.TrimPrefix(path.Join(backupPath, details.DedupPool), "/")
+	}
 	metadataBytes, err := protoutil.Marshal(metadata)
 	if err != nil {
 		return errors.Wrapf(err, "marshal backup index metadata")
@@ -803,6 +806,13 @@ func getBackupIndexFilePath(subdir string, startTime, endTime hlc.Timestamp) (st
 	), nil
 }
 
+// IndexFilePath returns the path, relative to the collection URI, of the
+// index file of the backup described by index, in the chain of the full
+// backup at subdir.
+func IndexFilePath(subdir string, index backuppb.BackupIndexMetadata) (string, error) {
+	return getBackupIndexFilePath(subdir, index.StartTime, index.EndTime)
+}
+
 // getBackupIndexFilename generates the filename (including the extension) for a
 // backup index file that represents a backup that starts ad ends at the given
 // timestamps.
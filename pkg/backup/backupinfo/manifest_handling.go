@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/backup/backupbase"
 	"github.com/cockroachdb/cockroach/pkg/backup/backupencryption"
 	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupsign"
 	"github.com/cockroachdb/cockroach/pkg/backup/backuputils"
 	"github.com/cockroachdb/cockroach/pkg/cloud"
 	"github.com/cockroachdb/cockroach/pkg/cloud/cloudpb"
@@ -1934,6 +1935,12 @@ func WriteBackupMetadata(
 		return errors.Wrapf(err, "writing table statistics")
 	}
 
+	if details.SigningKMSURI != "" {
+		if err := writeBackupSignature(ctx, store, details, kmsEnv, backupManifest); err != nil {
+			return errors.Wrap(err, "signing backup")
+		}
+	}
+
 	return errors.Wrapf(
 		WriteBackupIndexMetadata(
 			ctx,
@@ -1947,3 +1954,40 @@ func WriteBackupMetadata(
 		"writing backup index metadata",
 	)
 }
+
+// signedMetadataFiles are the files written by WriteBackupMetadata to the
+// directory of a backup that are covered by its signature.
+var signedMetadataFiles = []string{
+	backupbase.DeprecatedBackupManifestName,
+	backupbase.DeprecatedBackupManifestName + BackupManifestChecksumSuffix,
+	backupbase.BackupMetadataName,
+	backupbase.BackupMetadataName + BackupManifestChecksumSuffix,
+	BackupMetadataFilesListPath,
+	BackupMetadataDescriptorsListPath,
+	BackupStatisticsFileName,
+}
+
+// writeBackupSignature signs the metadata files of a backup, and through the
+// file entries of its manifest its data files, linking the signature to that
+// of the previous backup in the chain.
+func writeBackupSignature(
+	ctx context.Context,
+	store cloud.ExternalStorage,
+	details jobspb.BackupDetails,
+	kmsEnv cloud.KMSEnv,
+	backupManifest *backuppb.BackupManifest,
+) error {
+	kms, err := cloud.KMSFromURI(ctx, details.SigningKMSURI, kmsEnv)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := kms.Close(); err != nil {
+			log.Dev.Infof(ctx, "failed to close KMS: %+v", err)
+		}
+	}()
+	return backupsign.WriteSignature(
+		ctx, store, kms, details.SigningKey, backupManifest.StartTime, backupManifest.EndTime,
+		signedMetadataFiles, details.SigningPreviousMAC,
+	)
+}
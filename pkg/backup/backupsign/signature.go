@@ -0,0 +1,303 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package backupsign signs backups so that tampering with them, or with the
+// chain they form, can be detected when they are inspected or restored.
+//
+// A signed backup has a BACKUP-SIGNATURE file next to its manifest. The
+// signature records the SHA-256 of each of the backup's metadata files, whose
+// file entries in turn record the SHA-256 of each data file, and the MAC of
+// the signature of the backup before it in the chain. The MAC is an
+// HMAC-SHA256 under a signing key which the operator supplies wrapped by a
+// KMS, both when signing and when verifying a backup. The key is unwrapped
+// with the KMS, so only a principal allowed to decrypt with the KMS key can
+// produce or check a signature. The wrapped key is not trusted when it is read
+// back from a backup: a principal which is only allowed to encrypt with the KMS
+// key could wrap a key of its own choosing.
+package backupsign
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"io"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/errors"
+)
+
+// SignatureFileName is the name of the file holding the signature of a backup,
+// in the same directory as its manifest.
+const SignatureFileName = "BACKUP-SIGNATURE"
+
+// MinSigningKeySize is the minimum size of an unwrapped signing key.
+const MinSigningKeySize = 32
+
+// unwrapSigningKey decrypts the signing key wrappedKey with kms.
+func unwrapSigningKey(ctx context.Context, kms cloud.KMS, wrappedKey []byte) ([]byte, error) {
+	if len(wrappedKey) == 0 {
+		return nil, errors.New("no signing key given")
+	}
+	key, err := kms.Decrypt(ctx, wrappedKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "unwrapping signing key")
+	}
+	if len(key) < MinSigningKeySize {
+		return nil, errors.Newf(
+			"signing key is %d bytes long, but must be at least %d bytes long", len(key), MinSigningKeySize,
+		)
+	}
+	return key, nil
+}
+
+// Sign signs payload with the signing key wrappedKey, which is wrapped by kms.
+func Sign(
+	ctx context.Context,
+	kms cloud.KMS,
+	wrappedKey []byte,
+	payload *backuppb.BackupSignature_Payload,
+) (*backuppb.BackupSignature, error) {
+	buf, err := protoutil.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	key, err := unwrapSigningKey(ctx, kms, wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	return &backuppb.BackupSignature{
+		Payload:      buf,
+		MasterKeyID:  kms.MasterKeyID(),
+		EncryptedKey: wrappedKey,
+		MAC:          computeMAC(key, buf),
+	}, nil
+}
+
+// Verify checks that sig was produced with the signing key wrappedKey, which
+// is wrapped by kms, and returns its payload. The wrapped key recorded in sig
+// is only used to explain a mismatch, never to check the MAC.
+func Verify(
+	ctx context.Context, kms cloud.KMS, wrappedKey []byte, sig *backuppb.BackupSignature,
+) (*backuppb.BackupSignature_Payload, error) {
+	if sig.MasterKeyID != kms.MasterKeyID() {
+		return nil, errors.Newf(
+			"signed with KMS key %q, not with the given key %q", sig.MasterKeyID, kms.MasterKeyID(),
+		)
+	}
+	key, err := unwrapSigningKey(ctx, kms, wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(computeMAC(key, sig.Payload), sig.MAC) {
+		if !bytes.Equal(sig.EncryptedKey, wrappedKey) {
+			return nil, errors.New("signature was not produced with the given signing key")
+		}
+		return nil, errors.New("signature does not match its contents")
+	}
+	var payload backuppb.BackupSignature_Payload
+	if err := protoutil.Unmarshal(sig.Payload, &payload); err != nil {
+		return nil, errors.Wrap(err, "decoding signature")
+	}
+	return &payload, nil
+}
+
+func computeMAC(key, payload []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// WriteSignature hashes files, which are relative to store, and writes a
+// signature over them and the backup's interval to store. previousMAC is the
+// MAC of the signature of the previous backup in the chain, if any.
+func WriteSignature(
+	ctx context.Context,
+	store cloud.ExternalStorage,
+	kms cloud.KMS,
+	wrappedKey []byte,
+	startTime, endTime hlc.Timestamp,
+	files []string,
+	previousMAC []byte,
+) error {
+	payload := backuppb.BackupSignature_Payload{
+		StartTime:   startTime,
+		EndTime:     endTime,
+		PreviousMAC: previousMAC,
+	}
+	for _, file := range files {
+		size, sum, err := hashFile(ctx, store, file)
+		if err != nil {
+			return errors.Wrapf(err, "hashing %s", file)
+		}
+		payload.Files = append(payload.Files, backuppb.BackupSignature_File{
+			Path:   file,
+			Size:   size,
+			SHA256: sum,
+		})
+	}
+	sig, err := Sign(ctx, kms, wrappedKey, &payload)
+	if err != nil {
+		return err
+	}
+	buf, err := protoutil.Marshal(sig)
+	if err != nil {
+		return err
+	}
+	return cloud.WriteFile(ctx, store, SignatureFileName, bytes.NewReader(buf))
+}
+
+// ReadSignature reads the signature of the backup in store. The returned error
+// wraps cloud.ErrFileDoesNotExist if the backup is not signed.
+func ReadSignature(
+	ctx context.Context, store cloud.ExternalStorage,
+) (*backuppb.BackupSignature, error) {
+	r, _, err := store.ReadFile(ctx, SignatureFileName, cloud.ReadOptions{NoFileSize: true})
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close(ctx)
+	buf, err := ioctx.ReadAll(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	var sig backuppb.BackupSignature
+	if err := protoutil.Unmarshal(buf, &sig); err != nil {
+		return nil, errors.Wrapf(err, "decoding %s", SignatureFileName)
+	}
+	return &sig, nil
+}
+
+// IsSigned returns whether the backup in store has a signature.
+func IsSigned(ctx context.Context, store cloud.ExternalStorage) (bool, error) {
+	if _, err := store.Size(ctx, SignatureFileName); err != nil {
+		if errors.Is(err, cloud.ErrFileDoesNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// VerifyChain checks the signatures of a chain of backups, ordered from the
+// full backup up, where stores[i] holds the backup described by manifests[i].
+// Each backup must be signed with the signing key wrappedKey, which is wrapped
+// by kms, its signature must cover its own
+// interval and link to the signature of the backup it was taken on top of,
+// the one before it ending at its start time, and each of its metadata files
+// must match the digest recorded in the signature.
+//
+// The data files of the backups are not read; their digests are covered by the
+// signature through the manifests, and are checked with VerifyFile by the
+// processors that read the files.
+func VerifyChain(
+	ctx context.Context,
+	kms cloud.KMS,
+	wrappedKey []byte,
+	stores []cloud.ExternalStorage,
+	manifests []backuppb.BackupManifest,
+) error {
+	if len(stores) != len(manifests) {
+		return errors.AssertionFailedf("%d stores for %d backups", len(stores), len(manifests))
+	}
+	macByEndTime := make(map[hlc.Timestamp][]byte, len(manifests))
+	for i := range manifests {
+		mac, err := verifyLayer(ctx, kms, wrappedKey, stores[i], &manifests[i], macByEndTime, i == 0)
+		if err != nil {
+			return errors.Wrapf(err, "backup ending at %s", manifests[i].EndTime.GoTime())
+		}
+		macByEndTime[manifests[i].EndTime] = mac
+	}
+	return nil
+}
+
+func verifyLayer(
+	ctx context.Context,
+	kms cloud.KMS,
+	wrappedKey []byte,
+	store cloud.ExternalStorage,
+	manifest *backuppb.BackupManifest,
+	macByEndTime map[hlc.Timestamp][]byte,
+	isFirst bool,
+) (mac []byte, _ error) {
+	sig, err := ReadSignature(ctx, store)
+	if err != nil {
+		if errors.Is(err, cloud.ErrFileDoesNotExist) {
+			return nil, errors.Newf("%s is missing", SignatureFileName)
+		}
+		return nil, err
+	}
+	payload, err := Verify(ctx, kms, wrappedKey, sig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s", SignatureFileName)
+	}
+	if !payload.StartTime.Equal(manifest.StartTime) || !payload.EndTime.Equal(manifest.EndTime) {
+		return nil, errors.Newf(
+			"signature covers the interval (%s, %s], but the manifest covers (%s, %s]",
+			payload.StartTime, payload.EndTime, manifest.StartTime, manifest.EndTime,
+		)
+	}
+	previousMAC, hasPrevious := macByEndTime[manifest.StartTime]
+	switch {
+	case manifest.StartTime.IsEmpty():
+		if len(payload.PreviousMAC) != 0 {
+			return nil, errors.New("full backup is signed as an incremental backup")
+		}
+	case !hasPrevious && isFirst:
+		// The chain starts at an incremental backup, whose predecessor is not
+		// being verified, so there is nothing to link it to.
+	case !hasPrevious:
+		return nil, errors.Newf("no backup in the chain ends at its start time %s", manifest.StartTime)
+	case !hmac.Equal(payload.PreviousMAC, previousMAC):
+		return nil, errors.New("signature does not link to the signature of the previous backup in the chain")
+	}
+	for _, f := range payload.Files {
+		if err := VerifyFile(ctx, store, f.Path, f.Size, f.SHA256); err != nil {
+			return nil, err
+		}
+	}
+	return sig.MAC, nil
+}
+
+// VerifyFile checks that file in store has the given size and SHA-256. A
+// negative size is not checked.
+func VerifyFile(
+	ctx context.Context, store cloud.ExternalStorage, file string, size int64, sum []byte,
+) error {
+	gotSize, gotSum, err := hashFile(ctx, store, file)
+	if err != nil {
+		if errors.Is(err, cloud.ErrFileDoesNotExist) {
+			return errors.Wrapf(err, "signed file %s is missing", file)
+		}
+		return errors.Wrapf(err, "reading %s", file)
+	}
+	if size >= 0 && gotSize != size {
+		return errors.Newf("signed file %s has been modified: %d bytes, expected %d", file, gotSize, size)
+	}
+	if !bytes.Equal(gotSum, sum) {
+		return errors.Newf("signed file %s has been modified: sha256 %x, expected %x", file, gotSum, sum)
+	}
+	return nil
+}
+
+func hashFile(
+	ctx context.Context, store cloud.ExternalStorage, file string,
+) (int64, []byte, error) {
+	r, _, err := store.ReadFile(ctx, file, cloud.ReadOptions{NoFileSize: true})
+	if err != nil {
+		return 0, nil, err
+	}
+	defer r.Close(ctx)
+	h := sha256.New()
+	n, err := io.Copy(h, ioctx.ReaderCtxAdapter(ctx, r))
+	if err != nil {
+		return 0, nil, err
+	}
+	return n, h.Sum(nil), nil
+}
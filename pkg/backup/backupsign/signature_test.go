@@ -0,0 +1,121 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backupsign
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKMS "wraps" keys by appending its key ID, which is enough to tell keys
+// wrapped by different KMSes apart.
+type fakeKMS struct {
+	keyID string
+}
+
+var _ cloud.KMS = fakeKMS{}
+
+func (k fakeKMS) MasterKeyID() string { return k.keyID }
+
+func (k fakeKMS) Encrypt(_ context.Context, data []byte) ([]byte, error) {
+	return append(append([]byte(nil), data...), k.keyID...), nil
+}
+
+func (k fakeKMS) Decrypt(_ context.Context, data []byte) ([]byte, error) {
+	if !bytes.HasSuffix(data, []byte(k.keyID)) {
+		return nil, errors.New("wrapped by another key")
+	}
+	return data[:len(data)-len(k.keyID)], nil
+}
+
+func (k fakeKMS) Close() error { return nil }
+
+func (k fakeKMS) wrapShortKey() []byte {
+	return append([]byte{1}, k.keyID...)
+}
+
+// wrapKey returns a signing key wrapped by kms.
+func wrapKey(t *testing.T, kms cloud.KMS, b byte) []byte {
+	wrapped, err := kms.Encrypt(context.Background(), bytes.Repeat([]byte{b}, MinSigningKeySize))
+	require.NoError(t, err)
+	return wrapped
+}
+
+func TestSignVerify(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+
+	kms := fakeKMS{keyID: "a"}
+	key := wrapKey(t, kms, 1)
+	payload := &backuppb.BackupSignature_Payload{
+		EndTime: hlc.Timestamp{WallTime: 10},
+		Files: []backuppb.BackupSignature_File{
+			{Path: "BACKUP_MANIFEST", Size: 3, SHA256: []byte{1, 2, 3}},
+		},
+	}
+	sig, err := Sign(ctx, kms, key, payload)
+	require.NoError(t, err)
+
+	got, err := Verify(ctx, kms, key, sig)
+	require.NoError(t, err)
+	require.Equal(t, payload, got)
+
+	_, err = Verify(ctx, fakeKMS{keyID: "b"}, key, sig)
+	require.ErrorContains(t, err, `signed with KMS key "a"`)
+
+	_, err = Verify(ctx, kms, wrapKey(t, kms, 2), sig)
+	require.ErrorContains(t, err, "signature was not produced with the given signing key")
+
+	_, err = Sign(ctx, kms, kms.wrapShortKey(), payload)
+	require.ErrorContains(t, err, "signing key is 1 bytes long")
+
+	tampered := *sig
+	tampered.Payload = append([]byte(nil), sig.Payload...)
+	tampered.Payload[len(tampered.Payload)-1] ^= 1
+	_, err = Verify(ctx, kms, key, &tampered)
+	require.ErrorContains(t, err, "signature does not match its contents")
+}
+
+// TestForgedSignature checks that a principal which can only encrypt with the
+// KMS key cannot forge a signature by wrapping a key of its own choosing and
+// recording it in the signature.
+func TestForgedSignature(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+
+	kms := fakeKMS{keyID: "a"}
+	key := wrapKey(t, kms, 1)
+	payload := &backuppb.BackupSignature_Payload{EndTime: hlc.Timestamp{WallTime: 10}}
+	buf, err := protoutil.Marshal(payload)
+	require.NoError(t, err)
+
+	forgerKey := bytes.Repeat([]byte{2}, MinSigningKeySize)
+	forgerWrappedKey, err := kms.Encrypt(ctx, forgerKey)
+	require.NoError(t, err)
+	forged := &backuppb.BackupSignature{
+		Payload:      buf,
+		MasterKeyID:  kms.MasterKeyID(),
+		EncryptedKey: forgerWrappedKey,
+		MAC:          computeMAC(forgerKey, buf),
+	}
+	_, err = Verify(ctx, kms, key, forged)
+	require.ErrorContains(t, err, "signature was not produced with the given signing key")
+
+	// Recording the operator's wrapped key in the forged signature does not
+	// help either, since the MAC is checked under the unwrapped key.
+	forged.EncryptedKey = key
+	_, err = Verify(ctx, kms, key, forged)
+	require.ErrorContains(t, err, "signature does not match its contents")
+}
@@ -8,6 +8,10 @@ package backupsink
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"path"
 
 	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
 	"github.com/cockroachdb/cockroach/pkg/base"
@@ -21,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/admission"
 	hlc "github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble/objstorage"
 	gogotypes "github.com/gogo/protobuf/types"
@@ -49,6 +54,16 @@ type SSTSinkConf struct {
 	ID        base.SQLInstanceID
 	Settings  *settings.Values
 	ElideMode execinfrapb.ElidePrefix
+	// ChecksumFiles, if set, records the SHA-256 of each file as written to
+	// the destination in the manifest entries of the file.
+	ChecksumFiles bool
+	// DedupPool, if set, is the directory relative to the destination that
+	// files are written to named by the digest of their contents. Files that
+	// are already in it are not written again.
+	DedupPool string
+	// MemMonitor, if set, accounts for the files that are buffered in memory
+	// until they are flushed because DedupPool is set.
+	MemMonitor *mon.BytesMonitor
 }
 
 type FileSSTSink struct {
@@ -62,6 +77,14 @@ type FileSSTSink struct {
 	ctx     context.Context
 	cancel  func()
 	outName string
+	// outHash, if ChecksumFiles is set, hashes the bytes of the open file as
+	// they are written to the destination.
+	outHash hash.Hash
+	// outBuf, if DedupPool is set, buffers the open file until it is flushed,
+	// since its name depends on its contents.
+	outBuf *storage.MemObject
+	// outBufAcc, if MemMonitor is set, accounts for the memory of outBuf.
+	outBufAcc *mon.BoundAccount
 
 	flushedFiles []backuppb.BackupManifest_File
 	flushedSize  int64
@@ -85,11 +108,14 @@ type FileSSTSink struct {
 	// stats contain statistics about the actions of the FileSSTSink over its
 	// entire lifespan.
 	stats struct {
-		files       int // number of files created.
-		flushes     int // number of flushes.
-		oooFlushes  int // number of out of order flushes.
-		sizeFlushes int // number of flushes due to file exceeding targetFileSize.
-		spanGrows   int // number of times a span was extended.
+		files        int // number of files created.
+		flushes      int // number of flushes.
+		oooFlushes   int // number of out of order flushes.
+		sizeFlushes  int // number of flushes due to file exceeding targetFileSize.
+		dedupFlushes int // number of flushes due to a deduplication boundary.
+		dedupHits    int // number of flushed files already in the dedup pool.
+		memFlushes   int // number of flushes due to the memory budget of outBuf.
+		spanGrows    int // number of times a span was extended.
 	}
 }
 
@@ -99,11 +125,16 @@ var fileSpanByteLimit int64 = 64 << 20
 func MakeFileSSTSink(
 	conf SSTSinkConf, dest cloud.ExternalStorage, pacer *admission.Pacer,
 ) *FileSSTSink {
-	return &FileSSTSink{
+	s := &FileSSTSink{
 		conf:  conf,
 		dest:  dest,
 		pacer: pacer,
 	}
+	if conf.MemMonitor != nil {
+		acc := conf.MemMonitor.MakeBoundAccount()
+		s.outBufAcc = &acc
+	}
+	return s
 }
 
 func (s *FileSSTSink) Write(ctx context.Context, resp ExportedSpan) (roachpb.Key, error) {
@@ -203,6 +234,11 @@ func (s *FileSSTSink) Write(ctx context.Context, resp ExportedSpan) (roachpb.Key
 	s.completedSpans += resp.CompletedSpans
 	s.flushedSize += int64(len(resp.DataSST))
 
+	overBudget, err := s.reserveOutBuf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// If our accumulated SST is now big enough, and we are positioned at the end
 	// of a range flush it.
 	if s.flushedSize > targetFileSize.Get(s.conf.Settings) && !s.midRow {
@@ -211,6 +247,19 @@ func (s *FileSSTSink) Write(ctx context.Context, resp ExportedSpan) (roachpb.Key
 		if err := s.Flush(ctx); err != nil {
 			return nil, err
 		}
+	} else if overBudget {
+		s.stats.memFlushes++
+		log.VEventf(ctx, 2, "flushing backup file %s with size %d since its buffer exceeds the memory budget", s.outName, s.flushedSize)
+		if err := s.Flush(ctx); err != nil {
+			return nil, err
+		}
+	} else if s.conf.DedupPool != "" && !s.midRow &&
+		s.flushedSize > dedupMinFileSize(s.conf.Settings) && isDedupBoundary(span.EndKey) {
+		s.stats.dedupFlushes++
+		log.VEventf(ctx, 2, "flushing backup file %s with size %d at dedup boundary %s", s.outName, s.flushedSize, span.EndKey)
+		if err := s.Flush(ctx); err != nil {
+			return nil, err
+		}
 	} else {
 		log.VEventf(ctx, 3, "continuing to write to backup file %s of size %d", s.outName, s.flushedSize)
 	}
@@ -225,8 +274,12 @@ func (s *FileSSTSink) WriteWithNoData(resp ExportedSpan) {
 
 func (s *FileSSTSink) Close() error {
 	if log.V(1) && s.ctx != nil {
-		log.Dev.Infof(s.ctx, "backup sst sink recv'd %d files, wrote %d (%d due to size, %d due to re-ordering), %d recv files extended prior span",
-			s.stats.files, s.stats.flushes, s.stats.sizeFlushes, s.stats.oooFlushes, s.stats.spanGrows)
+		log.Dev.Infof(s.ctx, "backup sst sink recv'd %d files, wrote %d (%d due to size, %d due to re-ordering, %d due to dedup boundaries, %d due to memory budget, %d already in dedup pool), %d recv files extended prior span",
+			s.stats.files, s.stats.flushes, s.stats.sizeFlushes, s.stats.oooFlushes, s.stats.dedupFlushes, s.stats.memFlushes, s.stats.dedupHits, s.stats.spanGrows)
+	}
+	if s.outBufAcc != nil && s.ctx != nil {
+		s.outBuf = nil
+		s.outBufAcc.Close(s.ctx)
 	}
 	if s.cancel != nil {
 		s.cancel()
@@ -284,8 +337,26 @@ func (s *FileSSTSink) Flush(ctx context.Context) error {
 	s.outName = ""
 	s.isOpen = false
 
+	var sum []byte
+	if s.outHash != nil {
+		sum = s.outHash.Sum(nil)
+		s.outHash = nil
+	}
+	if s.outBuf != nil {
+		pooledSum, err := s.writePooled(ctx)
+		if s.outBufAcc != nil {
+			s.outBufAcc.Clear(ctx)
+		}
+		if err != nil {
+			return err
+		}
+		if s.conf.ChecksumFiles {
+			sum = pooledSum
+		}
+	}
 	for i := range s.flushedFiles {
 		s.flushedFiles[i].BackingFileSize = wroteSize
+		s.flushedFiles[i].SHA256 = sum
 	}
 
 	progDetails := backuppb.BackupManifest_Progress{
@@ -320,11 +391,26 @@ func (s *FileSSTSink) open(ctx context.Context) error {
 		s.ctx, s.cancel = context.WithCancel(ctx)
 	}
 	var w objstorage.Writable
-	w, err := cloud.OpenAbortableWriter(s.ctx, s.dest, s.outName)
-	if err != nil {
-		return err
+	if s.conf.DedupPool != "" {
+		if s.conf.Enc != nil {
+			return errors.AssertionFailedf("encrypted backup files cannot be deduplicated")
+		}
+		// The file is named once its contents are known, in Flush.
+		s.outBuf = &storage.MemObject{}
+		w = s.outBuf
+	} else {
+		var err error
+		w, err = cloud.OpenAbortableWriter(s.ctx, s.dest, s.outName)
+		if err != nil {
+			return err
+		}
+	}
+	if s.conf.ChecksumFiles && s.outBuf == nil {
+		s.outHash = sha256.New()
+		w = &hashingWriter{Writable: w, h: s.outHash}
 	}
 	if s.conf.Enc != nil {
+		var err error
 		w, err = storage.EncryptingWriter(w, s.conf.Enc.Key)
 		if err != nil {
 			return err
@@ -347,6 +433,65 @@ func (s *FileSSTSink) open(ctx context.Context) error {
 	return nil
 }
 
+// reserveOutBuf accounts for the memory of the buffered file, if any, in the
+// memory monitor of the sink. The whole file ends up in the buffer, so the
+// reservation covers the blocks the SST writer hasn't written out yet. If the
+// budget is exhausted, it returns true if the file can be flushed right away to
+// release its buffer, or an error if it can't since the file ends mid-row.
+func (s *FileSSTSink) reserveOutBuf(ctx context.Context) (overBudget bool, _ error) {
+	if s.outBuf == nil || s.outBufAcc == nil {
+		return false, nil
+	}
+	sz := max(int64(s.outBuf.Cap()), int64(s.sst.EstimatedSize()))
+	if err := s.outBufAcc.ResizeTo(ctx, sz); err != nil {
+		if s.midRow {
+			return false, errors.Wrapf(err, "buffering backup file %s for deduplication", s.outName)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// writePooled writes the buffered file to the dedup pool, named by the
+// SHA-256 of its contents, unless the pool already has it. It points the
+// flushed files at the pooled file and returns its digest.
+func (s *FileSSTSink) writePooled(ctx context.Context) ([]byte, error) {
+	data := s.outBuf.Data()
+	s.outBuf = nil
+	sum := sha256.Sum256(data)
+	name := path.Join(s.conf.DedupPool, hex.EncodeToString(sum[:])+".sst")
+	for i := range s.flushedFiles {
+		s.flushedFiles[i].Path = name
+	}
+
+	// Pooled files are never overwritten, so that the pool works with buckets
+	// that do not allow it.
+	if _, err := s.dest.Size(ctx, name); err == nil {
+		s.stats.dedupHits++
+		log.VEventf(ctx, 2, "backup file %s is already in the dedup pool", name)
+		return sum[:], nil
+	} else if !errors.Is(err, cloud.ErrFileDoesNotExist) {
+		return nil, errors.Wrapf(err, "checking dedup pool for %s", name)
+	}
+	if err := cloud.WriteFile(ctx, s.dest, name, bytes.NewReader(data)); err != nil {
+		return nil, errors.Wrapf(err, "writing %s to dedup pool", name)
+	}
+	return sum[:], nil
+}
+
+// hashingWriter hashes the bytes written through it to the wrapped writer.
+type hashingWriter struct {
+	objstorage.Writable
+	h hash.Hash
+}
+
+// Write implements objstorage.Writable. The bytes are hashed before they are
+// handed on, since the wrapped writer may modify them.
+func (w *hashingWriter) Write(p []byte) error {
+	_, _ = w.h.Write(p)
+	return w.Writable.Write(p)
+}
+
 func (s *FileSSTSink) copyPointKeys(ctx context.Context, dataSST []byte) (roachpb.Key, error) {
 	iterOpts := storage.IterOptions{
 		KeyTypes:   storage.IterKeyTypePointsOnly,
@@ -27,6 +27,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble/objstorage"
 	"github.com/gogo/protobuf/types"
@@ -471,6 +472,44 @@ func TestFileSSTSink_CloseLeakRegression(t *testing.T) {
 	_ = sink.Close()
 }
 
+// TestFileSSTSinkDedupMemoryBudget tests that a deduplicating sink accounts
+// for the file it buffers in memory, flushing it early when the buffer exceeds
+// the budget and failing if it can't since the file ends mid-row.
+func TestFileSSTSinkDedupMemoryBudget(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+
+	m := mon.NewMonitor(mon.Options{
+		Name:     mon.MakeName("test-monitor"),
+		Settings: st,
+	})
+	m.Start(ctx, nil, mon.NewStandaloneBudget(16<<10))
+	defer m.Stop(ctx)
+
+	conf, store := sinkTestSetup(t, st, execinfrapb.ElidePrefix_None)
+	conf.DedupPool = "dedup"
+	conf.MemMonitor = m
+	sink := MakeFileSSTSink(conf, store, nil /* pacer */)
+
+	es := newExportedSpanBuilder("a", "b").withKVs([]kvAndTS{{key: "a", timestamp: 10, value: randomValue(64 << 10)}}).build()
+	_, err := sink.Write(ctx, es)
+	require.NoError(t, err)
+	require.Equal(t, 1, sink.stats.memFlushes)
+	require.Equal(t, 1, sink.stats.flushes)
+	require.Zero(t, m.AllocBytes())
+
+	es = newRawExportedSpanBuilder(s2k0("c"), s2k1("c"), s2k1("c")).withKVs([]kvAndTS{{key: "c", timestamp: 10, value: randomValue(64 << 10)}}).build()
+	_, err = sink.Write(ctx, es)
+	require.ErrorContains(t, err, "buffering backup file")
+	require.Equal(t, 1, sink.stats.memFlushes)
+
+	require.NoError(t, sink.Close())
+	require.Zero(t, m.AllocBytes())
+}
+
 func s2k(s string) roachpb.Key {
 	tbl := 1
 	k := []byte(s)
@@ -8,10 +8,12 @@ package backupsink
 import (
 	"bytes"
 	"fmt"
+	"hash/fnv"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/util/unique"
 )
@@ -77,6 +79,28 @@ func adjustFileEndKey(endKey, maxPointKey, maxRangeEnd roachpb.Key) (roachpb.Key
 
 }
 
+// dedupBoundaryMask selects the bits of the hash of a span end key that must
+// all be zero for a deduplicated file to end at it, so that a file ends at one
+// in four span boundaries on average.
+const dedupBoundaryMask = 3
+
+// isDedupBoundary returns true if a deduplicated file may end at key. Files end
+// at keys picked by their content rather than by the size of the data before
+// them, so that a change to the data of one file does not shift the boundaries,
+// and with them the contents, of the files after it.
+func isDedupBoundary(key roachpb.Key) bool {
+	h := fnv.New64a()
+	_, _ = h.Write(key)
+	return h.Sum64()&dedupBoundaryMask == 0
+}
+
+// dedupMinFileSize is the size a deduplicated file must exceed before it can
+// end at a dedup boundary, which keeps small spans from producing many small
+// files.
+func dedupMinFileSize(sv *settings.Values) int64 {
+	return targetFileSize.Get(sv) / 8
+}
+
 func generateUniqueSSTName(nodeID base.SQLInstanceID) string {
 	// The data/ prefix, including a /, is intended to group SSTs in most of the
 	// common file/bucket browse UIs.
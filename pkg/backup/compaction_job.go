@@ -76,6 +76,8 @@ func maybeStartCompactionJob(
 		return 0, errors.New("only scheduled backups can be compacted")
 	case len(triggerJob.SpecificTenantIds) != 0 || triggerJob.IncludeAllSecondaryTenants:
 		return 0, errors.New("backups of tenants not supported for compaction")
+	case triggerJob.SigningKMSURI != "":
+		return 0, errors.New("signed backups not supported for compaction")
 	}
 
 	env := scheduledjobs.ProdJobSchedulerEnv
@@ -0,0 +1,555 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupbase"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupdest"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupencryption"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupinfo"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuputils"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobsprofiler"
+	"github.com/cockroachdb/cockroach/pkg/revlog"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	gogotypes "github.com/gogo/protobuf/types"
+)
+
+const (
+	// copyBackupPlanInfoKey is the job info key under which a COPY BACKUP job
+	// persists the files it copies.
+	copyBackupPlanInfoKey = "copy-backup/plan"
+	// copyBackupCompletedInfoKey is the job info key under which a COPY BACKUP
+	// job checkpoints the files it has copied.
+	copyBackupCompletedInfoKey = "copy-backup/completed"
+)
+
+// copyBackupResumer implements jobs.Resumer for COPY BACKUP jobs.
+type copyBackupResumer struct {
+	job *jobs.Job
+}
+
+var _ jobs.Resumer = (*copyBackupResumer)(nil)
+
+// Resume is part of the jobs.Resumer interface.
+func (r *copyBackupResumer) Resume(ctx context.Context, execCtx interface{}) error {
+	p := execCtx.(sql.JobExecContext)
+	details := r.job.Details().(jobspb.CopyBackupDetails)
+	kmsEnv := backupencryption.MakeBackupKMSEnv(
+		p.ExecCfg().Settings,
+		&p.ExecCfg().ExternalIODirConfig,
+		p.ExecCfg().InternalDB,
+		p.User(),
+	)
+	return runCopyBackupJob(ctx, p, r.job.ID(), &details, &kmsEnv)
+}
+
+// OnFailOrCancel is part of the jobs.Resumer interface. The files which were
+// already copied are left in the destination: no backup, revision log tick or
+// LATEST pointer refers to them until the files committing them are copied,
+// and those are copied last.
+func (r *copyBackupResumer) OnFailOrCancel(
+	ctx context.Context, _ interface{}, jobErr error,
+) error {
+	log.Dev.Warningf(ctx,
+		"COPY BACKUP job %d failed, leaving the files it copied in the destination: %v", r.job.ID(), jobErr,
+	)
+	return nil
+}
+
+// CollectProfile is part of the jobs.Resumer interface.
+func (r *copyBackupResumer) CollectProfile(context.Context, interface{}) error {
+	return nil
+}
+
+// ReportResults implements the jobs.JobResultsReporter interface.
+func (r *copyBackupResumer) ReportResults(ctx context.Context, resultsCh chan<- tree.Datums) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case resultsCh <- tree.Datums{
+		tree.NewDInt(tree.DInt(r.job.ID())),
+		tree.NewDString(string(jobs.StateSucceeded)),
+		tree.NewDFloat(tree.DFloat(1.0)),
+		tree.NewDInt(0),
+	}:
+		return nil
+	}
+}
+
+// runCopyBackupJob runs a COPY BACKUP job. It copies the files of the source
+// in three steps, so that a reader of the destination never sees a backup, a
+// revision log tick or a LATEST pointer before every file it refers to:
+//
+//  1. Data files, which nothing refers to until a later step copies the
+//     files naming them, are copied by processors spread across the cluster.
+//  2. The ENCRYPTION-INFO files of re-encrypted backups are rewritten.
+//  3. Manifests, index files, revision log close markers and LATEST pointers
+//     are copied by the coordinator, in that order.
+//
+// Copied files are checkpointed in the job's info, so that a resumed job does
+// not copy them again.
+func runCopyBackupJob(
+	ctx context.Context,
+	execCtx sql.JobExecContext,
+	jobID jobspb.JobID,
+	details *jobspb.CopyBackupDetails,
+	kmsEnv cloud.KMSEnv,
+) error {
+	execCfg := execCtx.ExecCfg()
+	src, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, details.SourceURI, execCtx.User())
+	if err != nil {
+		return errors.Wrap(err, "opening source collection")
+	}
+	defer src.Close()
+	dst, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, details.DestURI, execCtx.User())
+	if err != nil {
+		return errors.Wrap(err, "opening destination collection")
+	}
+	defer dst.Close()
+
+	plan, completed, err := loadCopyBackupCheckpoint(ctx, execCfg.InternalDB, jobID)
+	if err != nil {
+		return err
+	}
+	if plan == nil {
+		resolved, err := resolveCopyBackupPlan(ctx, src, details)
+		if err != nil {
+			return err
+		}
+		if err := execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+			return jobs.InfoStorageForJob(txn, jobID).WriteProto(ctx, copyBackupPlanInfoKey, &resolved)
+		}); err != nil {
+			return errors.Wrap(err, "persisting copy plan")
+		}
+		plan = &resolved
+	}
+
+	tracker := &copyBackupTracker{
+		db:        execCfg.InternalDB,
+		jobID:     jobID,
+		interval:  func() time.Duration { return BackupCheckpointInterval.Get(&execCfg.Settings.SV) },
+		total:     len(plan.Files) + len(plan.CommitFiles),
+		completed: completed,
+		lastFlush: timeutil.Now(),
+	}
+
+	var pending []string
+	for _, f := range plan.Files {
+		if _, ok := completed[f]; !ok {
+			pending = append(pending, f)
+		}
+	}
+	log.Dev.Infof(ctx, "copying %d of %d backup data files", len(pending), len(plan.Files))
+	if len(pending) > 0 {
+		if err := runCopyBackupFlow(ctx, execCtx, jobID, details, pending, tracker); err != nil {
+			return err
+		}
+		if err := tracker.flush(ctx); err != nil {
+			return err
+		}
+	}
+	if err := execCfg.JobRegistry.CheckPausepoint("copy_backup.after.data_files"); err != nil {
+		return err
+	}
+
+	for _, dir := range plan.EncryptionInfoDirs {
+		if err := copyRewrappedEncryptionInfo(ctx, execCtx, details, dir, kmsEnv); err != nil {
+			return errors.Wrapf(err, "re-encrypting backup %s", dir)
+		}
+	}
+
+	for _, f := range plan.CommitFiles {
+		if _, ok := tracker.completed[f]; ok {
+			continue
+		}
+		n, err := copyBackupFile(ctx, src, dst, f)
+		if err != nil {
+			return err
+		}
+		if err := tracker.add(ctx, backuppb.CopyBackupProgress{CompletedFiles: []string{f}, Bytes: n}); err != nil {
+			return err
+		}
+	}
+	if err := tracker.flush(ctx); err != nil {
+		return err
+	}
+
+	if plan.LatestSubdir != "" {
+		if err := maybeAdvanceCopiedLatest(ctx, execCtx, details.DestURI, plan.LatestSubdir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveCopyBackupPlan lists the files a COPY BACKUP job copies from src.
+func resolveCopyBackupPlan(
+	ctx context.Context, src cloud.ExternalStorage, details *jobspb.CopyBackupDetails,
+) (backuppb.CopyBackupPlan, error) {
+	var plan backuppb.CopyBackupPlan
+	var files []string
+	listInto := func(prefix string) error {
+		return src.List(ctx, prefix, cloud.ListOptions{}, func(name string) error {
+			// Skip the empty objects with a trailing '/' that some tools create
+			// to stand in for directories.
+			if strings.HasSuffix(name, "/") {
+				return nil
+			}
+			files = append(files, strings.TrimPrefix(path.Join(prefix, name), "/"))
+			return nil
+		})
+	}
+
+	if details.Subdir == "" {
+		if err := listInto(""); err != nil {
+			return plan, errors.Wrap(err, "listing source collection")
+		}
+	} else {
+		indexes, err := backupinfo.GetBackupTreeIndexMetadata(ctx, src, details.Subdir)
+		if err != nil {
+			return plan, err
+		}
+		if len(indexes) == 0 {
+			// Backups taken before indexes were written can only be copied whole.
+			if !details.EndTime.IsEmpty() {
+				return plan, errors.Newf(
+					"backup %s has no index; AS OF SYSTEM TIME is not supported when copying it",
+					details.Subdir,
+				)
+			}
+			subdir := strings.Trim(details.Subdir, "/")
+			for _, prefix := range []string{
+				subdir + "/", path.Join(backupbase.DefaultIncrementalsSubdir, subdir) + "/",
+			} {
+				if err := listInto(prefix); err != nil {
+					return plan, errors.Wrapf(err, "listing %s", prefix)
+				}
+			}
+		} else {
+			// The pool generation of a deduplicated backup is copied whole, since
+			// the files it references are only known from its manifest.
+			pools := make(map[string]struct{})
+			for _, index := range selectCopiedIndexes(indexes, details.EndTime) {
+				if err := listInto(strings.Trim(index.Path, "/") + "/"); err != nil {
+					return plan, errors.Wrapf(err, "listing %s", index.Path)
+				}
+				if _, ok := pools[index.DedupPool]; index.DedupPool != "" && !ok {
+					pools[index.DedupPool] = struct{}{}
+					if err := listInto(index.DedupPool + "/"); err != nil {
+						return plan, errors.Wrapf(err, "listing %s", index.DedupPool)
+					}
+				}
+				indexFile, err := backupinfo.IndexFilePath(details.Subdir, index)
+				if err != nil {
+					return plan, err
+				}
+				files = append(files, strings.TrimPrefix(indexFile, "/"))
+			}
+		}
+		plan.LatestSubdir = details.Subdir
+	}
+
+	reencrypt := len(details.NewKMSURIs) > 0
+	var manifests, indexFiles, markers, latest []string
+	for _, f := range files {
+		base := path.Base(f)
+		switch {
+		case reencrypt && backupencryption.IsEncryptionInfoFile(base):
+			plan.EncryptionInfoDirs = append(plan.EncryptionInfoDirs, path.Dir(f))
+		case f == backupbase.LatestFileName || strings.HasPrefix(f, backupbase.LatestHistoryDirectory+"/"):
+			latest = append(latest, f)
+		case strings.HasPrefix(base, backupbase.DeprecatedBackupManifestName) ||
+			strings.HasPrefix(base, backupbase.BackupMetadataName):
+			manifests = append(manifests, f)
+		case strings.HasPrefix(f, backupbase.BackupIndexDirectoryPath):
+			indexFiles = append(indexFiles, f)
+		case strings.HasPrefix(f, revlog.ResolvedRoot):
+			markers = append(markers, f)
+		default:
+			plan.Files = append(plan.Files, f)
+		}
+	}
+	for _, group := range [][]string{manifests, indexFiles, markers, latest} {
+		sort.Strings(group)
+		plan.CommitFiles = append(plan.CommitFiles, group...)
+	}
+	sort.Strings(plan.Files)
+	sort.Strings(plan.EncryptionInfoDirs)
+	return plan, nil
+}
+
+// selectCopiedIndexes returns the backups of a chain that are needed to
+// restore as of endTime: every backup ending at or before the first one that
+// ends at or after it. If endTime is empty, every backup is returned. The
+// indexes must be sorted by end time.
+func selectCopiedIndexes(
+	indexes []backuppb.BackupIndexMetadata, endTime hlc.Timestamp,
+) []backuppb.BackupIndexMetadata {
+	if endTime.IsEmpty() {
+		return indexes
+	}
+	i := sort.Search(len(indexes), func(i int) bool {
+		return endTime.LessEq(indexes[i].EndTime)
+	})
+	if i == len(indexes) {
+		return indexes
+	}
+	cutoff := indexes[i].EndTime
+	for i < len(indexes) && indexes[i].EndTime == cutoff {
+		i++
+	}
+	return indexes[:i]
+}
+
+// copyRewrappedEncryptionInfo writes the ENCRYPTION-INFO of the backup in dir
+// to the destination, with its data key wrapped by the job's new KMS URIs.
+func copyRewrappedEncryptionInfo(
+	ctx context.Context,
+	execCtx sql.JobExecContext,
+	details *jobspb.CopyBackupDetails,
+	dir string,
+	kmsEnv cloud.KMSEnv,
+) error {
+	makeStore := execCtx.ExecCfg().DistSQLSrv.ExternalStorageFromURI
+	srcURI, err := backuputils.AppendPath(details.SourceURI, dir)
+	if err != nil {
+		return err
+	}
+	srcDir, err := makeStore(ctx, srcURI, execCtx.User())
+	if err != nil {
+		return err
+	}
+	defer srcDir.Close()
+	opts, err := backupencryption.ReadEncryptionOptions(ctx, srcDir)
+	if err != nil {
+		return err
+	}
+	info, err := rewrapEncryptionInfo(ctx, opts, details.OldKMSURIs, details.NewKMSURIs, kmsEnv)
+	if err != nil {
+		return err
+	}
+
+	dstURI, err := backuputils.AppendPath(details.DestURI, dir)
+	if err != nil {
+		return err
+	}
+	dstDir, err := makeStore(ctx, dstURI, execCtx.User())
+	if err != nil {
+		return err
+	}
+	defer dstDir.Close()
+	return backupencryption.WriteEncryptionInfoIfNotExists(ctx, info, dstDir)
+}
+
+// maybeAdvanceCopiedLatest points the destination's LATEST file at subdir,
+// unless it already points at it or at a newer full backup.
+func maybeAdvanceCopiedLatest(
+	ctx context.Context, execCtx sql.JobExecContext, destURI string, subdir string,
+) error {
+	makeStore := execCtx.ExecCfg().DistSQLSrv.ExternalStorageFromURI
+	latest, err := backupdest.ReadLatestFile(ctx, destURI, makeStore, execCtx.User())
+	if err != nil && !errors.Is(err, cloud.ErrFileDoesNotExist) {
+		return err
+	}
+	if err == nil && latest >= subdir {
+		return nil
+	}
+	dst, err := makeStore(ctx, destURI, execCtx.User())
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+	return errors.Wrap(
+		backupdest.WriteNewLatestFile(ctx, execCtx.ExecCfg().Settings, dst, subdir),
+		"writing LATEST file",
+	)
+}
+
+// runCopyBackupFlow copies files with processors spread across the cluster.
+func runCopyBackupFlow(
+	ctx context.Context,
+	execCtx sql.JobExecContext,
+	jobID jobspb.JobID,
+	details *jobspb.CopyBackupDetails,
+	files []string,
+	tracker *copyBackupTracker,
+) error {
+	dsp := execCtx.DistSQLPlanner()
+	evalCtx := execCtx.ExtendedEvalContext()
+	planCtx, instanceIDs, err := dsp.SetupAllNodesPlanning(ctx, evalCtx, execCtx.ExecCfg())
+	if err != nil {
+		return errors.Wrap(err, "setting up nodes for backup copy")
+	}
+
+	assignments := make([][]string, len(instanceIDs))
+	for i, f := range files {
+		assignments[i%len(instanceIDs)] = append(assignments[i%len(instanceIDs)], f)
+	}
+	var corePlacements []physicalplan.ProcessorCorePlacement
+	for i, id := range instanceIDs {
+		if len(assignments[i]) == 0 {
+			continue
+		}
+		corePlacements = append(corePlacements, physicalplan.ProcessorCorePlacement{
+			SQLInstanceID: id,
+			Core: execinfrapb.ProcessorCoreUnion{
+				CopyBackup: &execinfrapb.CopyBackupSpec{
+					JobID:     int64(jobID),
+					SourceURI: details.SourceURI,
+					DestURI:   details.DestURI,
+					Files:     assignments[i],
+					UserProto: execCtx.User().EncodeProto(),
+				},
+			},
+		})
+	}
+
+	plan := planCtx.NewPhysicalPlan()
+	plan.AddNoInputStage(
+		corePlacements,
+		execinfrapb.PostProcessSpec{},
+		[]*types.T{},
+		execinfrapb.Ordering{},
+		nil, /* finalizeLastStageCb */
+	)
+	sql.FinalizePlan(ctx, planCtx, plan)
+
+	metaWriter := sql.NewMetadataOnlyMetadataCallbackWriter(
+		func(ctx context.Context, meta *execinfrapb.ProducerMetadata) error {
+			if meta.BulkProcessorProgress == nil {
+				return nil
+			}
+			var prog backuppb.CopyBackupProgress
+			if err := gogotypes.UnmarshalAny(&meta.BulkProcessorProgress.ProgressDetails, &prog); err != nil {
+				return errors.Wrap(err, "decoding copy progress")
+			}
+			return tracker.add(ctx, prog)
+		},
+	)
+	recv := sql.MakeDistSQLReceiver(
+		ctx,
+		metaWriter,
+		tree.Rows,
+		nil, /* rangeCache */
+		nil, /* txn */
+		nil, /* clockUpdater */
+		evalCtx.Tracing,
+	)
+	defer recv.Release()
+
+	jobsprofiler.StorePlanDiagram(
+		ctx, execCtx.ExecCfg().DistSQLSrv.Stopper, plan, execCtx.ExecCfg().InternalDB, jobID,
+	)
+
+	evalCtxCopy := evalCtx.Copy()
+	dsp.Run(ctx, planCtx, nil /* txn */, plan, recv, evalCtxCopy, nil /* finishedSetupFn */)
+	return errors.Wrap(metaWriter.Err(), "running backup copy flow")
+}
+
+// copyBackupTracker accumulates the files a COPY BACKUP job has copied and
+// checkpoints them, along with the job's fraction completed, at most once per
+// checkpoint interval.
+type copyBackupTracker struct {
+	db        isql.DB
+	jobID     jobspb.JobID
+	interval  func() time.Duration
+	total     int
+	completed map[string]struct{}
+	bytes     int64
+	lastFlush time.Time
+}
+
+func (t *copyBackupTracker) add(ctx context.Context, prog backuppb.CopyBackupProgress) error {
+	for _, f := range prog.CompletedFiles {
+		t.completed[f] = struct{}{}
+	}
+	t.bytes += prog.Bytes
+	if timeutil.Since(t.lastFlush) < t.interval() {
+		return nil
+	}
+	return t.flush(ctx)
+}
+
+func (t *copyBackupTracker) flush(ctx context.Context) error {
+	files := make([]string, 0, len(t.completed))
+	for f := range t.completed {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	fraction := float64(1)
+	if t.total > 0 {
+		fraction = float64(len(files)) / float64(t.total)
+	}
+	if err := t.db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		if err := jobs.InfoStorageForJob(txn, t.jobID).WriteProto(
+			ctx, copyBackupCompletedInfoKey, &backuppb.CopyBackupProgress{CompletedFiles: files},
+		); err != nil {
+			return errors.Wrap(err, "writing copied files")
+		}
+		return errors.Wrap(
+			jobs.ProgressStorage(t.jobID).Set(ctx, txn, fraction, hlc.Timestamp{}),
+			"writing job progress",
+		)
+	}); err != nil {
+		return err
+	}
+	t.lastFlush = timeutil.Now()
+	log.Dev.VInfof(ctx, 2, "copied %d of %d backup files (%d bytes this attempt)", len(files), t.total, t.bytes)
+	return nil
+}
+
+// loadCopyBackupCheckpoint reads the plan and copied files persisted by a
+// previous attempt of the job. The plan is nil if none was persisted.
+func loadCopyBackupCheckpoint(
+	ctx context.Context, db isql.DB, jobID jobspb.JobID,
+) (*backuppb.CopyBackupPlan, map[string]struct{}, error) {
+	var plan *backuppb.CopyBackupPlan
+	completed := make(map[string]struct{})
+	if err := db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		plan = nil
+		clear(completed)
+		infoStorage := jobs.InfoStorageForJob(txn, jobID)
+		var p backuppb.CopyBackupPlan
+		ok, err := infoStorage.GetProto(ctx, copyBackupPlanInfoKey, &p)
+		if err != nil {
+			return errors.Wrap(err, "reading copy plan")
+		}
+		if !ok {
+			return nil
+		}
+		plan = &p
+		var prog backuppb.CopyBackupProgress
+		if _, err := infoStorage.GetProto(ctx, copyBackupCompletedInfoKey, &prog); err != nil {
+			return errors.Wrap(err, "reading copied files")
+		}
+		for _, f := range prog.CompletedFiles {
+			completed[f] = struct{}{}
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+	return plan, completed, nil
+}
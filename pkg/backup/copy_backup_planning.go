@@ -0,0 +1,267 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupbase"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupdest"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuputils"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/featureflag"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+)
+
+func copyBackupTypeCheck(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (matched bool, header colinfo.ResultColumns, _ error) {
+	copyStmt, ok := stmt.(*tree.CopyBackup)
+	if !ok {
+		return false, nil, nil
+	}
+	if err := exprutil.TypeCheck(
+		ctx, "COPY BACKUP", p.SemaCtx(),
+		exprutil.Strings{copyStmt.Subdir, copyStmt.From, copyStmt.To},
+		exprutil.StringArrays{
+			tree.Exprs(copyStmt.Options.DecryptionKMSURI),
+			tree.Exprs(copyStmt.Options.NewKMSURI),
+		},
+	); err != nil {
+		return false, nil, err
+	}
+	if copyStmt.Options.Detached {
+		return true, jobs.DetachedJobExecutionResultHeader, nil
+	}
+	return true, jobs.BackupRestoreJobResultHeader, nil
+}
+
+// copyBackupPlanHook implements sql.PlanHookFn for COPY BACKUP.
+func copyBackupPlanHook(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, bool, error) {
+	copyStmt, ok := stmt.(*tree.CopyBackup)
+	if !ok {
+		return nil, nil, false, nil
+	}
+	if err := featureflag.CheckEnabled(
+		ctx,
+		p.ExecCfg(),
+		featureBackupEnabled,
+		"COPY BACKUP",
+	); err != nil {
+		return nil, nil, false, err
+	}
+
+	exprEval := p.ExprEvaluator("COPY BACKUP")
+	var subdir string
+	var err error
+	if copyStmt.Subdir != nil {
+		subdir, err = exprEval.String(ctx, copyStmt.Subdir)
+		if err != nil {
+			return nil, nil, false, err
+		}
+	}
+	from, err := exprEval.String(ctx, copyStmt.From)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	to, err := exprEval.String(ctx, copyStmt.To)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	var oldKMS, newKMS []string
+	if copyStmt.Options.DecryptionKMSURI != nil {
+		oldKMS, err = exprEval.StringArray(ctx, tree.Exprs(copyStmt.Options.DecryptionKMSURI))
+		if err != nil {
+			return nil, nil, false, err
+		}
+	}
+	if copyStmt.Options.NewKMSURI != nil {
+		newKMS, err = exprEval.StringArray(ctx, tree.Exprs(copyStmt.Options.NewKMSURI))
+		if err != nil {
+			return nil, nil, false, err
+		}
+	}
+	if len(newKMS) > 0 && len(oldKMS) == 0 {
+		return nil, nil, false, errors.New("NEW_KMS requires KMS to unwrap the data key of the copied backups")
+	}
+	if len(oldKMS) > 0 && len(newKMS) == 0 {
+		return nil, nil, false, errors.New("KMS is only used with NEW_KMS to re-encrypt the copied backups")
+	}
+	if copyStmt.AsOf.Expr != nil && subdir == "" {
+		return nil, nil, false, errors.New(
+			"AS OF SYSTEM TIME requires naming the backup to copy; a whole collection is always copied in full",
+		)
+	}
+	if err := logAndSanitizeBackupDestinations(ctx, from, to); err != nil {
+		return nil, nil, false, err
+	}
+	if err := logAndSanitizeKmsURIs(ctx, append(oldKMS, newKMS...)...); err != nil {
+		return nil, nil, false, err
+	}
+
+	detached := copyStmt.Options.Detached
+	fn := func(ctx context.Context, resultsCh chan<- tree.Datums) error {
+		ctx, span := tracing.ChildSpan(ctx, stmt.StatementTag())
+		defer span.Finish()
+
+		if !(p.ExtendedEvalContext().TxnIsSingleStmt || detached) {
+			return errors.Errorf("COPY BACKUP cannot be used inside a multi-statement transaction without DETACHED option")
+		}
+		if err := sql.CheckDestinationPrivileges(ctx, p, []string{from, to}); err != nil {
+			return err
+		}
+		if strings.TrimSuffix(from, "/") == strings.TrimSuffix(to, "/") {
+			return errors.New("COPY BACKUP source and destination must differ")
+		}
+
+		details := jobspb.CopyBackupDetails{
+			SourceURI:  from,
+			DestURI:    to,
+			OldKMSURIs: oldKMS,
+			NewKMSURIs: newKMS,
+		}
+		if subdir != "" {
+			if strings.EqualFold(subdir, backupbase.LatestFileName) {
+				subdir, err = backupdest.ReadLatestFile(
+					ctx, from, p.ExecCfg().DistSQLSrv.ExternalStorageFromURI, p.User(),
+				)
+				if err != nil {
+					return errors.Wrap(err, "reading LATEST")
+				}
+			}
+			details.Subdir, err = backuputils.NormalizeSubdir(subdir)
+			if err != nil {
+				return err
+			}
+		}
+		if copyStmt.AsOf.Expr != nil {
+			asOf, err := p.EvalAsOfTimestamp(ctx, copyStmt.AsOf)
+			if err != nil {
+				return err
+			}
+			details.EndTime = asOf.Timestamp
+		}
+
+		description, err := copyBackupJobDescription(p, copyStmt, details, oldKMS, newKMS)
+		if err != nil {
+			return err
+		}
+		jr := jobs.Record{
+			Description: description,
+			Details:     details,
+			Progress:    jobspb.CopyBackupProgress{},
+			Username:    p.User(),
+		}
+		jobID := p.ExecCfg().JobRegistry.MakeJobID()
+		plannerTxn := p.Txn()
+
+		if detached {
+			if _, err := p.ExecCfg().JobRegistry.CreateAdoptableJobWithTxn(
+				ctx, jr, jobID, p.InternalSQLTxn(),
+			); err != nil {
+				return err
+			}
+			resultsCh <- tree.Datums{tree.NewDInt(tree.DInt(jobID))}
+			return nil
+		}
+		var sj *jobs.StartableJob
+		if err := func() (err error) {
+			defer func() {
+				if err == nil || sj == nil {
+					return
+				}
+				if cleanupErr := sj.CleanupOnRollback(ctx); cleanupErr != nil {
+					log.Dev.Errorf(ctx, "failed to cleanup job: %v", cleanupErr)
+				}
+			}()
+			if err := p.ExecCfg().JobRegistry.CreateStartableJobWithTxn(
+				ctx, &sj, jobID, p.InternalSQLTxn(), jr,
+			); err != nil {
+				return err
+			}
+			return plannerTxn.Commit(ctx)
+		}(); err != nil {
+			return err
+		}
+		p.InternalSQLTxn().Descriptors().ReleaseAll(ctx)
+		if err := sj.Start(ctx); err != nil {
+			return err
+		}
+		if err := sj.AwaitCompletion(ctx); err != nil {
+			return err
+		}
+		return sj.ReportExecutionResults(ctx, resultsCh)
+	}
+
+	if detached {
+		return fn, jobs.DetachedJobExecutionResultHeader, false, nil
+	}
+	return fn, jobs.BackupRestoreJobResultHeader, false, nil
+}
+
+// copyBackupJobDescription returns the statement of a COPY BACKUP job with its
+// subdir resolved and the credentials in its URIs redacted.
+func copyBackupJobDescription(
+	p sql.PlanHookState,
+	copyStmt *tree.CopyBackup,
+	details jobspb.CopyBackupDetails,
+	oldKMS, newKMS []string,
+) (string, error) {
+	redacted := *copyStmt
+	uris, err := sanitizeURIList([]string{details.SourceURI, details.DestURI})
+	if err != nil {
+		return "", err
+	}
+	redacted.From, redacted.To = uris[0], uris[1]
+	if details.Subdir != "" {
+		redacted.Subdir = tree.NewDString(details.Subdir)
+	}
+	redactKMS := func(uris []string) (tree.StringOrPlaceholderOptList, error) {
+		var out tree.StringOrPlaceholderOptList
+		for _, uri := range uris {
+			clean, err := cloud.RedactKMSURI(uri)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, tree.NewDString(clean))
+		}
+		return out, nil
+	}
+	if redacted.Options.DecryptionKMSURI, err = redactKMS(oldKMS); err != nil {
+		return "", err
+	}
+	if redacted.Options.NewKMSURI, err = redactKMS(newKMS); err != nil {
+		return "", err
+	}
+
+	ann := p.ExtendedEvalContext().Annotations
+	return tree.AsStringWithFlags(
+		&redacted, tree.FmtAlwaysQualifyNames|tree.FmtShowFullURIs, tree.FmtAnnotations(ann),
+	), nil
+}
+
+func init() {
+	sql.AddPlanHook("copy backup", copyBackupPlanHook, copyBackupTypeCheck)
+	jobs.RegisterConstructor(
+		jobspb.TypeCopyBackup,
+		func(job *jobs.Job, _ *cluster.Settings) jobs.Resumer {
+			return &copyBackupResumer{job: job}
+		},
+		jobs.UsesTenantCostControl,
+	)
+}
@@ -0,0 +1,246 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"hash/crc32"
+	"io"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowexec"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/logtags"
+	gogotypes "github.com/gogo/protobuf/types"
+)
+
+const (
+	copyBackupProcessorName = "copyBackupProcessor"
+)
+
+var copyBackupCRCTable = crc32.MakeTable(crc32.Castagnoli)
+
+// copyBackupProcessor copies the files assigned to it from one backup
+// collection to another, reporting each copied file to the coordinator.
+type copyBackupProcessor struct {
+	execinfra.ProcessorBase
+
+	spec execinfrapb.CopyBackupSpec
+
+	progCh                 chan execinfrapb.RemoteProducerMetadata_BulkProcessorProgress
+	cancelAndWaitForWorker func()
+	copyErr                error
+}
+
+var (
+	_ execinfra.Processor = &copyBackupProcessor{}
+	_ execinfra.RowSource = &copyBackupProcessor{}
+)
+
+func newCopyBackupProcessor(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	spec execinfrapb.CopyBackupSpec,
+	post *execinfrapb.PostProcessSpec,
+) (execinfra.Processor, error) {
+	processor := &copyBackupProcessor{
+		spec:   spec,
+		progCh: make(chan execinfrapb.RemoteProducerMetadata_BulkProcessorProgress),
+	}
+	if err := processor.Init(ctx, processor, post, []*types.T{}, flowCtx, processorID, nil, /* memMonitor */
+		execinfra.ProcStateOpts{
+			InputsToDrain: nil,
+			TrailingMetaCallback: func() []execinfrapb.ProducerMetadata {
+				processor.close()
+				return nil
+			},
+		}); err != nil {
+		return nil, err
+	}
+	return processor, nil
+}
+
+func (p *copyBackupProcessor) Start(ctx context.Context) {
+	ctx = logtags.AddTag(ctx, "job", p.spec.JobID)
+
+	p.StartInternal(ctx, copyBackupProcessorName)
+	ctx, cancel := context.WithCancel(ctx)
+	p.cancelAndWaitForWorker = func() {
+		cancel()
+		for range p.progCh {
+		}
+	}
+	log.Dev.Infof(ctx, "starting copy of %d backup files", len(p.spec.Files))
+	if err := p.FlowCtx.Stopper().RunAsyncTaskEx(ctx, stop.TaskOpts{
+		TaskName: copyBackupProcessorName + ".runCopyBackup",
+		SpanOpt:  stop.ChildSpan,
+	}, func(ctx context.Context) {
+		p.copyErr = p.runCopyBackup(ctx)
+		cancel()
+		close(p.progCh)
+	}); err != nil {
+		p.copyErr = err
+		cancel()
+		close(p.progCh)
+	}
+}
+
+func (p *copyBackupProcessor) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata) {
+	if p.State != execinfra.StateRunning {
+		return nil, p.DrainHelper()
+	}
+
+	prog, ok := <-p.progCh
+	if !ok {
+		p.MoveToDraining(p.copyErr)
+		return nil, p.DrainHelper()
+	}
+	prog.NodeID = p.FlowCtx.NodeID.SQLInstanceID()
+	prog.FlowID = p.FlowCtx.ID
+	return nil, &execinfrapb.ProducerMetadata{BulkProcessorProgress: &prog}
+}
+
+func (p *copyBackupProcessor) close() {
+	if p.Closed {
+		return
+	}
+	if p.cancelAndWaitForWorker != nil {
+		p.cancelAndWaitForWorker()
+	}
+	p.InternalClose()
+}
+
+// ConsumerClosed is part of the RowSource interface. We have to override the
+// implementation provided by ProcessorBase.
+func (p *copyBackupProcessor) ConsumerClosed() {
+	p.close()
+}
+
+// runCopyBackup copies each of the processor's files and sends a progress
+// update naming it once it has been copied and verified.
+func (p *copyBackupProcessor) runCopyBackup(ctx context.Context) error {
+	if len(p.spec.Files) == 0 {
+		return nil
+	}
+	execCfg, ok := p.FlowCtx.Cfg.ExecutorConfig.(*sql.ExecutorConfig)
+	if !ok {
+		return errors.New("executor config is not of type sql.ExecutorConfig")
+	}
+	user := p.spec.User()
+	src, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, p.spec.SourceURI, user)
+	if err != nil {
+		return errors.Wrap(err, "opening source collection")
+	}
+	defer src.Close()
+	dst, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, p.spec.DestURI, user)
+	if err != nil {
+		return errors.Wrap(err, "opening destination collection")
+	}
+	defer dst.Close()
+
+	for _, file := range p.spec.Files {
+		n, err := copyBackupFile(ctx, src, dst, file)
+		if err != nil {
+			return err
+		}
+		details, err := gogotypes.MarshalAny(&backuppb.CopyBackupProgress{
+			CompletedFiles: []string{file},
+			Bytes:          n,
+		})
+		if err != nil {
+			return err
+		}
+		select {
+		case p.progCh <- execinfrapb.RemoteProducerMetadata_BulkProcessorProgress{
+			ProgressDetails: *details,
+		}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// copyBackupFile copies file from src to dst and returns the number of bytes
+// written. A file that already exists at dst with the size and checksum of the
+// source is assumed to have been copied by a previous attempt and is skipped;
+// one that differs, such as a partially written file, is replaced. The copy is
+// verified by reading it back and comparing its size and checksum with what
+// was read from the source.
+func copyBackupFile(
+	ctx context.Context, src, dst cloud.ExternalStorage, file string,
+) (int64, error) {
+	if existingSize, err := dst.Size(ctx, file); err == nil {
+		size, sum, err := checksumBackupFile(ctx, src, file)
+		if err != nil {
+			return 0, err
+		}
+		if existingSize == size {
+			_, existingSum, err := checksumBackupFile(ctx, dst, file)
+			if err != nil {
+				return 0, err
+			}
+			if existingSum == sum {
+				return 0, nil
+			}
+		}
+		log.Dev.Infof(ctx, "replacing %s, which does not match the source", file)
+	}
+
+	r, size, err := src.ReadFile(ctx, file, cloud.ReadOptions{})
+	if err != nil {
+		return 0, errors.Wrapf(err, "reading %s", file)
+	}
+	defer r.Close(ctx)
+	crc := crc32.New(copyBackupCRCTable)
+	if err := cloud.WriteFile(
+		ctx, dst, file, io.TeeReader(ioctx.ReaderCtxAdapter(ctx, r), crc),
+	); err != nil {
+		return 0, errors.Wrapf(err, "writing %s", file)
+	}
+
+	writtenSize, writtenSum, err := checksumBackupFile(ctx, dst, file)
+	if err != nil {
+		return 0, errors.Wrap(err, "reading back copy")
+	}
+	if writtenSize != size || writtenSum != crc.Sum32() {
+		return 0, errors.Newf(
+			"copy of %s does not match the source: %d bytes with checksum %x, expected %d bytes with checksum %x",
+			file, writtenSize, writtenSum, size, crc.Sum32(),
+		)
+	}
+	return size, nil
+}
+
+// checksumBackupFile reads file from es and returns its size and checksum.
+func checksumBackupFile(
+	ctx context.Context, es cloud.ExternalStorage, file string,
+) (int64, uint32, error) {
+	r, size, err := es.ReadFile(ctx, file, cloud.ReadOptions{})
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "reading %s", file)
+	}
+	defer r.Close(ctx)
+	crc := crc32.New(copyBackupCRCTable)
+	if _, err := io.Copy(crc, ioctx.ReaderCtxAdapter(ctx, r)); err != nil {
+		return 0, 0, errors.Wrapf(err, "reading %s", file)
+	}
+	return size, crc.Sum32(), nil
+}
+
+func init() {
+	rowexec.NewCopyBackupProcessor = newCopyBackupProcessor
+}
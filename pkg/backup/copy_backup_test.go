@@ -0,0 +1,216 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuptestutils"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/testutils/jobutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCopyBackup copies a collection, and then a single chain of it, to new
+// locations and checks that both copies can be restored.
+func TestCopyBackup(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numAccounts = 10
+	_, sqlDB, dir, cleanupFn := backupRestoreTestSetup(t, multiNode, numAccounts, InitManualReplication)
+	defer cleanupFn()
+
+	sqlDB.Exec(t, `BACKUP TABLE data.bank INTO $1`, localFoo)
+	sqlDB.Exec(t, `UPDATE data.bank SET balance = balance + 1`)
+	sqlDB.Exec(t, `BACKUP TABLE data.bank INTO LATEST IN $1`, localFoo)
+	expected := sqlDB.QueryStr(t, `SELECT * FROM data.bank ORDER BY id`)
+
+	sqlDB.Exec(t, `COPY BACKUP IN $1 TO $2`, localFoo, "nodelocal://1/copy")
+	sqlDB.Exec(t, `CREATE DATABASE whole`)
+	sqlDB.Exec(t, `RESTORE TABLE data.bank FROM LATEST IN $1 WITH into_db = 'whole'`, "nodelocal://1/copy")
+	sqlDB.CheckQueryResults(t, `SELECT * FROM whole.bank ORDER BY id`, expected)
+
+	// Copying the same collection again skips the files already present, but
+	// replaces a file whose contents differ from the source's despite having
+	// the same size.
+	copyDir := filepath.Join(dir, "copy")
+	modTimes := make(map[string]time.Time)
+	var corrupted string
+	require.NoError(t, filepath.WalkDir(copyDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if corrupted == "" && strings.HasSuffix(p, ".sst") {
+			corrupted = p
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		modTimes[p] = info.ModTime()
+		return nil
+	}))
+	require.NotEmpty(t, corrupted)
+	require.NotEmpty(t, modTimes)
+	contents, err := os.ReadFile(corrupted)
+	require.NoError(t, err)
+	contents[len(contents)-1] ^= 0xff
+	require.NoError(t, os.WriteFile(corrupted, contents, 0644))
+
+	sqlDB.Exec(t, `COPY BACKUP IN $1 TO $2`, localFoo, "nodelocal://1/copy")
+	for p, modTime := range modTimes {
+		info, err := os.Stat(p)
+		require.NoError(t, err)
+		require.Equal(t, modTime, info.ModTime(), "%s was copied again", p)
+	}
+	expectedContents, err := os.ReadFile(filepath.Join(dir, "foo", strings.TrimPrefix(corrupted, copyDir)))
+	require.NoError(t, err)
+	contents, err = os.ReadFile(corrupted)
+	require.NoError(t, err)
+	require.Equal(t, expectedContents, contents)
+
+	var jobID jobspb.JobID
+	sqlDB.QueryRow(t, `COPY BACKUP LATEST IN $1 TO $2 WITH detached`, localFoo, "nodelocal://1/chain").Scan(&jobID)
+	jobutils.WaitForJobToSucceed(t, sqlDB, jobID)
+	sqlDB.Exec(t, `CREATE DATABASE chain`)
+	sqlDB.Exec(t, `RESTORE TABLE data.bank FROM LATEST IN $1 WITH into_db = 'chain'`, "nodelocal://1/chain")
+	sqlDB.CheckQueryResults(t, `SELECT * FROM chain.bank ORDER BY id`, expected)
+
+	sqlDB.ExpectErr(t, "source and destination must differ",
+		`COPY BACKUP IN $1 TO $2`, localFoo, localFoo)
+	sqlDB.ExpectErr(t, "NEW_KMS requires KMS",
+		`COPY BACKUP IN $1 TO $2 WITH new_kms = 'aws:///key'`, localFoo, "nodelocal://1/other")
+	sqlDB.ExpectErr(t, "AS OF SYSTEM TIME requires naming the backup",
+		`COPY BACKUP IN $1 TO $2 AS OF SYSTEM TIME '-1s'`, localFoo, "nodelocal://1/other")
+}
+
+// TestCopyBackupResume pauses a COPY BACKUP job after it copies the data files
+// and checks that the resumed job completes the copy without copying them
+// again.
+func TestCopyBackupResume(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	const numAccounts = 10
+	tc, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, multiNode, numAccounts, InitManualReplication)
+	defer cleanupFn()
+
+	sqlDB.Exec(t, `BACKUP TABLE data.bank INTO $1`, localFoo)
+	expected := sqlDB.QueryStr(t, `SELECT * FROM data.bank ORDER BY id`)
+
+	sqlDB.Exec(t, `SET CLUSTER SETTING jobs.debug.pausepoints = 'copy_backup.after.data_files'`)
+	var jobID jobspb.JobID
+	sqlDB.QueryRow(t, `COPY BACKUP IN $1 TO $2 WITH detached`, localFoo, "nodelocal://1/copy").Scan(&jobID)
+	jobutils.WaitForJobToPause(t, sqlDB, jobID)
+
+	// Every data file was checkpointed before the job paused, so the resumed
+	// job has none left to copy.
+	plan, completed, err := loadCopyBackupCheckpoint(ctx, tc.Servers[0].InternalDB().(isql.DB), jobID)
+	require.NoError(t, err)
+	require.NotNil(t, plan)
+	require.NotEmpty(t, plan.Files)
+	for _, f := range plan.Files {
+		require.Contains(t, completed, f)
+	}
+	for _, f := range plan.CommitFiles {
+		require.NotContains(t, completed, f)
+	}
+
+	// A canceled copy runs the cleanup of the COPY BACKUP job rather than that
+	// of a BACKUP job.
+	var canceledID jobspb.JobID
+	sqlDB.QueryRow(t, `COPY BACKUP IN $1 TO $2 WITH detached`, localFoo, "nodelocal://1/canceled").Scan(&canceledID)
+	jobutils.WaitForJobToPause(t, sqlDB, canceledID)
+	sqlDB.Exec(t, `CANCEL JOB $1`, canceledID)
+	jobutils.WaitForJobToCancel(t, sqlDB, canceledID)
+
+	sqlDB.Exec(t, `RESET CLUSTER SETTING jobs.debug.pausepoints`)
+	sqlDB.Exec(t, `RESUME JOB $1`, jobID)
+	jobutils.WaitForJobToSucceed(t, sqlDB, jobID)
+	sqlDB.CheckQueryResults(t,
+		`SELECT job_id, status FROM [SHOW JOBS] WHERE job_type = 'COPY BACKUP' ORDER BY job_id`,
+		[][]string{{fmt.Sprint(jobID), "succeeded"}, {fmt.Sprint(canceledID), "canceled"}},
+	)
+	sqlDB.Exec(t, `CREATE DATABASE resumed`)
+	sqlDB.Exec(t, `RESTORE TABLE data.bank FROM LATEST IN $1 WITH into_db = 'resumed'`, "nodelocal://1/copy")
+	sqlDB.CheckQueryResults(t, `SELECT * FROM resumed.bank ORDER BY id`, expected)
+}
+
+// TestCopyBackupNewKMS copies an encrypted backup with NEW_KMS and checks that
+// the copy can only be restored with the new KMS.
+func TestCopyBackupNewKMS(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	// Online restore does not support encrypted backups.
+	backuptestutils.DisableFastRestoreForTest(t)
+
+	const numAccounts = 10
+	_, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, InitManualReplication)
+	defer cleanupFn()
+
+	kmsURIs := constructMockKMSURIsWithKeyID([]string{"old", "new"})
+	oldKMS, newKMS := kmsURIs[0], kmsURIs[1]
+	sqlDB.Exec(t, `BACKUP TABLE data.bank INTO $1 WITH kms = $2`, localFoo, oldKMS)
+	sqlDB.Exec(t, `BACKUP TABLE data.bank INTO LATEST IN $1 WITH kms = $2`, localFoo, oldKMS)
+	expected := sqlDB.QueryStr(t, `SELECT * FROM data.bank ORDER BY id`)
+
+	sqlDB.Exec(t, `COPY BACKUP IN $1 TO $2 WITH kms = $3, new_kms = $4`,
+		localFoo, "nodelocal://1/copy", oldKMS, newKMS)
+	sqlDB.Exec(t, `CREATE DATABASE copied`)
+	sqlDB.Exec(t, `RESTORE TABLE data.bank FROM LATEST IN $1 WITH into_db = 'copied', kms = $2`,
+		"nodelocal://1/copy", newKMS)
+	sqlDB.CheckQueryResults(t, `SELECT * FROM copied.bank ORDER BY id`, expected)
+
+	sqlDB.Exec(t, `CREATE DATABASE other`)
+	sqlDB.ExpectErr(t, "one of the provided URIs was not used when encrypting the base BACKUP",
+		`RESTORE TABLE data.bank FROM LATEST IN $1 WITH into_db = 'other', kms = $2`,
+		"nodelocal://1/copy", oldKMS)
+	// The source is unchanged.
+	sqlDB.ExpectErr(t, "one of the provided URIs was not used when encrypting the base BACKUP",
+		`RESTORE TABLE data.bank FROM LATEST IN $1 WITH into_db = 'other', kms = $2`,
+		localFoo, newKMS)
+}
+
+func TestSelectCopiedIndexes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ts := func(wall int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wall} }
+	indexes := []backuppb.BackupIndexMetadata{
+		{EndTime: ts(10)},
+		{StartTime: ts(10), EndTime: ts(20)},
+		{StartTime: ts(20), EndTime: ts(30)},
+		{StartTime: ts(10), EndTime: ts(30), IsCompacted: true},
+		{StartTime: ts(30), EndTime: ts(40)},
+	}
+	for _, tc := range []struct {
+		endTime  hlc.Timestamp
+		expected int
+	}{
+		{endTime: hlc.Timestamp{}, expected: 5},
+		{endTime: ts(5), expected: 1},
+		{endTime: ts(20), expected: 2},
+		{endTime: ts(25), expected: 4},
+		{endTime: ts(50), expected: 5},
+	} {
+		require.Equal(t, indexes[:tc.expected], selectCopiedIndexes(indexes, tc.endTime), "end time %s", tc.endTime)
+	}
+}
@@ -783,6 +783,7 @@ func createBackupScheduleTypeCheck(
 		schedule.BackupOptions.CaptureRevisionHistory,
 		schedule.BackupOptions.IncludeAllSecondaryTenants,
 		schedule.BackupOptions.UpdatesClusterMonitoringMetrics,
+		schedule.BackupOptions.Deduplicate,
 	}
 	if err := exprutil.TypeCheck(
 		ctx, scheduleBackupOp, p.SemaCtx(), stringExprs, bools, stringArrays, opts,
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupsign"
 	"github.com/cockroachdb/cockroach/pkg/backup/backupsink"
 	"github.com/cockroachdb/cockroach/pkg/backup/backuputils"
 	"github.com/cockroachdb/cockroach/pkg/cloud"
@@ -37,6 +38,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/metamorphic"
 	"github.com/cockroachdb/cockroach/pkg/util/pprofutil"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
@@ -81,6 +83,19 @@ type restoreDataProcessor struct {
 	// qp is a MemoryBackedQuotaPool that restricts the amount of memory that
 	// can be used by this processor to open iterators on SSTs.
 	qp *backuputils.MemoryBackedQuotaPool
+
+	// verifiedFiles contains the files of a signed backup whose digests have
+	// been checked, since a file is usually read by several entries.
+	verifiedFiles struct {
+		syncutil.Mutex
+		m map[verifiedFileKey]struct{}
+	}
+}
+
+// verifiedFileKey identifies a file of a restored backup.
+type verifiedFileKey struct {
+	layer int32
+	path  string
 }
 
 var (
@@ -359,6 +374,9 @@ func (rd *restoreDataProcessor) openSSTs(
 			return mergedSST{}, nil, err
 		}
 		dirs = append(dirs, dir)
+		if err := rd.verifyFile(ctx, dir, file); err != nil {
+			return mergedSST{}, nil, err
+		}
 		storeFiles = append(storeFiles, storage.StoreFile{Store: dir, FilePath: file.Path})
 	}
 
@@ -381,6 +399,36 @@ func (rd *restoreDataProcessor) openSSTs(
 	return mSST, res, nil
 }
 
+// verifyFile checks that a file of a signed backup matches the digest recorded
+// in its signed manifest, the first time the processor reads the file. Files of
+// unsigned backups have no digest and are not checked.
+func (rd *restoreDataProcessor) verifyFile(
+	ctx context.Context, dir cloud.ExternalStorage, file execinfrapb.RestoreFileSpec,
+) error {
+	if len(file.SHA256) == 0 {
+		return nil
+	}
+	key := verifiedFileKey{layer: file.Layer, path: file.Path}
+	rd.verifiedFiles.Lock()
+	_, ok := rd.verifiedFiles.m[key]
+	rd.verifiedFiles.Unlock()
+	if ok {
+		return nil
+	}
+	// The backing file size is that of the file before encryption, so only the
+	// digest is checked.
+	if err := backupsign.VerifyFile(ctx, dir, file.Path, -1 /* size */, file.SHA256); err != nil {
+		return errors.Wrap(err, "verifying signed backup")
+	}
+	rd.verifiedFiles.Lock()
+	defer rd.verifiedFiles.Unlock()
+	if rd.verifiedFiles.m == nil {
+		rd.verifiedFiles.m = make(map[verifiedFileKey]struct{})
+	}
+	rd.verifiedFiles.m[key] = struct{}{}
+	return nil
+}
+
 // openSSTsForFiles opens the specified files and returns a multiplexed SST
 // iterator. If emitDeletes is true, the iterator will emit tombstones instead
 // of skipping them (needed when ingesting over linked layers).
@@ -409,6 +457,9 @@ func (rd *restoreDataProcessor) openSSTsForFiles(
 			return mergedSST{}, err
 		}
 		dirs = append(dirs, dir)
+		if err := rd.verifyFile(ctx, dir, file); err != nil {
+			return mergedSST{}, err
+		}
 		storeFiles = append(storeFiles, storage.StoreFile{Store: dir, FilePath: file.Path})
 	}
 
@@ -461,6 +512,13 @@ func (rd *restoreDataProcessor) runRestoreWorkers(
 		if err != nil {
 			return errors.Wrap(err, "creating key rewriter from rekeys")
 		}
+		rowFilter, err := makeRestoreRowFilter(ctx, rd.FlowCtx, &rd.spec)
+		if err != nil {
+			return errors.Wrap(err, "creating row filter")
+		}
+		if rowFilter != nil {
+			defer rowFilter.close(ctx)
+		}
 
 		for {
 			done, err := func() (done bool, _ error) {
@@ -528,7 +586,7 @@ func (rd *restoreDataProcessor) runRestoreWorkers(
 						return done, errors.Wrap(err, "opening SSTs")
 					}
 
-					ingestSummary, err := rd.processRestoreSpanEntry(ctx, kr, sstIter)
+					ingestSummary, err := rd.processRestoreSpanEntry(ctx, kr, rowFilter, sstIter)
 					if err != nil {
 						return done, errors.Wrap(err, "processing restore span entry")
 					}
@@ -701,7 +759,7 @@ func (rd *restoreDataProcessor) linkFiles(
 var backupFileReadError = errors.New("error reading backup file")
 
 func (rd *restoreDataProcessor) processRestoreSpanEntry(
-	ctx context.Context, kr *KeyRewriter, sst mergedSST,
+	ctx context.Context, kr *KeyRewriter, rowFilter *restoreRowFilter, sst mergedSST,
 ) (kvpb.BulkOpSummary, error) {
 	db := rd.FlowCtx.Cfg.DB
 	var summary kvpb.BulkOpSummary
@@ -831,6 +889,14 @@ func (rd *restoreDataProcessor) processRestoreSpanEntry(
 			continue
 		}
 
+		if rowFilter != nil {
+			if filtered, err := rowFilter.add(ctx, batcher, key, value); err != nil {
+				return summary, err
+			} else if filtered {
+				continue
+			}
+		}
+
 		// Rewriting the key means the checksum needs to be updated.
 		value.ClearChecksum()
 		value.InitChecksum(key.Key)
@@ -849,6 +915,11 @@ func (rd *restoreDataProcessor) processRestoreSpanEntry(
 			return summary, errors.Wrapf(err, "adding to batch: %s -> %s", key, value.PrettyPrint())
 		}
 	}
+	if rowFilter != nil {
+		if err := rowFilter.finish(ctx, batcher); err != nil {
+			return summary, err
+		}
+	}
 	// Flush out the last batch.
 	if err := batcher.Flush(ctx); err != nil {
 		return summary, err
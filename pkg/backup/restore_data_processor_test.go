@@ -417,7 +417,7 @@ func runTestIngest(t *testing.T, init func(*cluster.Settings)) {
 			rewriter, err := MakeKeyRewriterFromRekeys(flowCtx.Codec(), mockRestoreDataSpec.TableRekeys,
 				mockRestoreDataSpec.TenantRekeys, false /* restoreTenantFromStream */)
 			require.NoError(t, err)
-			_, err = mockRestoreDataProcessor.processRestoreSpanEntry(ctx, rewriter, sst)
+			_, err = mockRestoreDataProcessor.processRestoreSpanEntry(ctx, rewriter, nil /* rowFilter */, sst)
 			require.NoError(t, err)
 
 			clientKVs, err := kvDB.Scan(ctx, reqStartKey, reqEndKey, 0)
@@ -738,6 +738,7 @@ func restore(
 			exclusiveEndKeys:     true,
 			resumeClusterVersion: resumeClusterVersion,
 			useLink:              useLink,
+			rowFilters:           details.RowFilters,
 		}
 		return errors.Wrap(distRestore(
 			ctx,
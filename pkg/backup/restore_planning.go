@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/backup/backupencryption"
 	"github.com/cockroachdb/cockroach/pkg/backup/backupinfo"
 	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupsign"
 	"github.com/cockroachdb/cockroach/pkg/backup/backuputils"
 	"github.com/cockroachdb/cockroach/pkg/build"
 	"github.com/cockroachdb/cockroach/pkg/cloud"
@@ -30,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/revlog"
 	"github.com/cockroachdb/cockroach/pkg/revlog/restorerevlog"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql"
@@ -45,11 +47,13 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/nstree"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/rewrite"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
@@ -57,6 +61,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/idxtype"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlclustersettings"
@@ -1391,6 +1396,22 @@ func resolveOptionsForRestoreJobDescription(
 		newOpts.NewDBName = tree.NewDString(newDBName)
 	}
 
+	if opts.Where != nil {
+		where, err := exprEval.String(ctx, opts.Where)
+		if err != nil {
+			return tree.RestoreOptions{}, err
+		}
+		newOpts.Where = tree.NewDString(where)
+	}
+
+	if opts.Columns != nil {
+		columns, err := exprEval.String(ctx, opts.Columns)
+		if err != nil {
+			return tree.RestoreOptions{}, err
+		}
+		newOpts.Columns = tree.NewDString(columns)
+	}
+
 	if opts.DecryptionKMSURI != nil {
 		kmsURIs, err := exprEval.StringArray(
 			ctx, tree.Exprs(opts.DecryptionKMSURI),
@@ -1408,6 +1429,21 @@ func resolveOptionsForRestoreJobDescription(
 		}
 	}
 
+	if opts.SigningKMSURI != nil {
+		uri, err := exprEval.String(ctx, opts.SigningKMSURI)
+		if err != nil {
+			return tree.RestoreOptions{}, err
+		}
+		redactedURI, err := cloud.RedactKMSURI(uri)
+		if err != nil {
+			return tree.RestoreOptions{}, err
+		}
+		newOpts.SigningKMSURI = tree.NewDString(redactedURI)
+	}
+	if opts.SigningKey != nil {
+		newOpts.SigningKey = tree.NewDString("redacted")
+	}
+
 	return newOpts, nil
 }
 
@@ -1459,7 +1495,8 @@ func restoreTypeCheck(
 	if !ok {
 		return false, nil, nil
 	}
-	if testFastRestore() && !restoreStmt.Options.ExperimentalCopy && !restoreStmt.Options.ExperimentalOnline {
+	if testFastRestore() && !restoreStmt.Options.ExperimentalCopy && !restoreStmt.Options.ExperimentalOnline &&
+		!restoreStmt.Options.FiltersRows() {
 		restoreStmt.Options.ExperimentalCopy = true
 	}
 	if err := exprutil.TypeCheck(
@@ -1476,6 +1513,10 @@ func restoreTypeCheck(
 			restoreStmt.Options.ForceTenantID,
 			restoreStmt.Options.AsTenant,
 			restoreStmt.Options.ExecutionLocality,
+			restoreStmt.Options.Where,
+			restoreStmt.Options.Columns,
+			restoreStmt.Options.SigningKMSURI,
+			restoreStmt.Options.SigningKey,
 		},
 	); err != nil {
 		return false, nil, err
@@ -1506,7 +1547,8 @@ func restorePlanHook(
 	if !ok {
 		return nil, nil, false, nil
 	}
-	if testFastRestore() && !restoreStmt.Options.ExperimentalCopy && !restoreStmt.Options.ExperimentalOnline {
+	if testFastRestore() && !restoreStmt.Options.ExperimentalCopy && !restoreStmt.Options.ExperimentalOnline &&
+		!restoreStmt.Options.FiltersRows() {
 		restoreStmt.Options.ExperimentalCopy = true
 	}
 
@@ -1583,6 +1625,21 @@ func restorePlanHook(
 		return nil, nil, false, errors.New("cannot run online restore with verify_backup_table_data")
 	}
 
+	if restoreStmt.Options.FiltersRows() {
+		if restoreStmt.DescriptorCoverage != tree.RequestedDescriptors ||
+			restoreStmt.Targets.Databases != nil || len(restoreStmt.Targets.Tables.TablePatterns) != 1 {
+			return nil, nil, false, errors.New(
+				"the where and columns options can only be used for RESTORE TABLE with a single target table",
+			)
+		}
+		if restoreStmt.Options.OnlineImpl() {
+			return nil, nil, false, errors.New("cannot run online restore with the where or columns options")
+		}
+		if restoreStmt.Options.SchemaOnly {
+			return nil, nil, false, errors.New("cannot set the where or columns options with schema_only")
+		}
+	}
+
 	if restoreStmt.Options.Grants {
 		if !p.ExecCfg().Settings.Version.ActiveVersion(ctx).AtLeast(clusterversion.V26_2.Version()) {
 			return nil, nil, false, errors.New(
@@ -1859,6 +1916,64 @@ func checkClusterRegions(
 	return nil
 }
 
+// verifyBackupSignatures checks the signatures of the chain of backups at uris
+// with the signing key signingKey, wrapped by the KMS at signingKMS. If no KMS
+// is given, the chain must not be signed, so that a signed backup is never
+// used without being verified.
+func verifyBackupSignatures(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	user username.SQLUsername,
+	signingKMS string,
+	signingKey []byte,
+	uris []string,
+	manifests []backuppb.BackupManifest,
+	kmsEnv cloud.KMSEnv,
+) error {
+	stores := make([]cloud.ExternalStorage, 0, len(uris))
+	defer func() {
+		for _, store := range stores {
+			if err := store.Close(); err != nil {
+				log.Dev.Warningf(ctx, "close export storage failed %v", err)
+			}
+		}
+	}()
+	for _, uri := range uris {
+		store, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, uri, user)
+		if err != nil {
+			return err
+		}
+		stores = append(stores, store)
+	}
+
+	if signingKMS == "" {
+		signed, err := backupsign.IsSigned(ctx, stores[len(stores)-1])
+		if err != nil {
+			return err
+		}
+		if signed {
+			return errors.WithHint(
+				errors.New("backup is signed and its signature must be verified"),
+				"specify the KMS and the key that signed the backup with the signing_kms and signing_key options",
+			)
+		}
+		return nil
+	}
+
+	kms, err := cloud.KMSFromURI(ctx, signingKMS, kmsEnv)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := kms.Close(); err != nil {
+			log.Dev.Infof(ctx, "failed to close KMS: %+v", err)
+		}
+	}()
+	return errors.Wrap(
+		backupsign.VerifyChain(ctx, kms, signingKey, stores, manifests), "verifying backup signatures",
+	)
+}
+
 // checkBackupManifestVersionCompatability performs various checks to ensure
 // that the manifests we are about to restore are from backups taken on a
 // version compatible with our current version.
@@ -2046,6 +2161,18 @@ func doRestorePlan(
 		return err
 	}
 
+	signingKMS, signingKey, err := evalSigningOptions(
+		ctx, exprEval, restoreStmt.Options.SigningKMSURI, restoreStmt.Options.SigningKey,
+	)
+	if err != nil {
+		return err
+	}
+	if err := verifyBackupSignatures(
+		ctx, p.ExecCfg(), p.User(), signingKMS, signingKey, defaultURIs, mainBackupManifests, &kmsEnv,
+	); err != nil {
+		return err
+	}
+
 	if restoreStmt.Options.OnlineImpl() {
 		if err := checkManifestsForOnlineCompat(ctx, p.ExecCfg().Settings, mainBackupManifests); err != nil {
 			return err
@@ -2054,6 +2181,12 @@ func doRestorePlan(
 			return pgerror.Newf(pgcode.FeatureNotSupported,
 				"experimental online restore: encryption not supported")
 		}
+		// Linked files are never read by the restore, so their digests could
+		// not be checked.
+		if signingKMS != "" {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"experimental online restore: signed backups not supported")
+		}
 	}
 
 	if restoreStmt.DescriptorCoverage == tree.AllDescriptors {
@@ -2340,6 +2473,20 @@ func doRestorePlan(
 		encodedTables[i] = table.TableDesc()
 	}
 
+	var rowFilters []jobspb.RestoreDetails_RowFilter
+	if restoreStmt.Options.FiltersRows() {
+		if len(tables) != 1 {
+			return errors.Newf(
+				"the where and columns options can only be used to restore a single table, "+
+					"but the target matched %d tables", len(tables))
+		}
+		rowFilter, err := planRestoreRowFilter(ctx, p, exprEval, restoreStmt.Options, tables[0])
+		if err != nil {
+			return err
+		}
+		rowFilters = append(rowFilters, rowFilter)
+	}
+
 	restoreDetails := jobspb.RestoreDetails{
 		EndTime:            endTime,
 		DescriptorRewrites: descriptorRewrites,
@@ -2373,6 +2520,7 @@ func doRestorePlan(
 		RevisionLogTimestamp:             revisionLogTimestamp,
 		DefaultCollectionURI:             defaultCollectionURI,
 		RevlogNewTableIDs:                revlogNewTableIDs,
+		RowFilters:                       rowFilters,
 	}
 
 	// Validate that revision log rekeys can be built from the
@@ -2468,6 +2616,120 @@ func doRestorePlan(
 	return sj.ReportExecutionResults(ctx, resultsCh)
 }
 
+// planRestoreRowFilter validates the where and columns options of a RESTORE
+// against the (rewritten) descriptor of the table it restores, and returns the
+// filter which the restore data processors apply to the table's rows.
+func planRestoreRowFilter(
+	ctx context.Context,
+	p sql.PlanHookState,
+	exprEval exprutil.Evaluator,
+	opts tree.RestoreOptions,
+	table *tabledesc.Mutable,
+) (jobspb.RestoreDetails_RowFilter, error) {
+	rowFilter := jobspb.RestoreDetails_RowFilter{TableID: table.GetID()}
+	if len(table.AllMutations()) > 0 {
+		return rowFilter, pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot filter the rows of table %q which has a schema change in progress", table.GetName())
+	}
+	// The row filter is applied by the restore data processors, which rebuild
+	// the secondary indexes of the table from its primary index, so only the
+	// columns stored in the primary index can be decoded, and only columns with
+	// builtin types can be used without a type resolver.
+	for _, col := range table.PublicColumns() {
+		if col.IsVirtual() {
+			return rowFilter, pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot filter the rows of table %q which has virtual column %q", table.GetName(), col.GetName())
+		}
+		if col.GetType().UserDefined() {
+			return rowFilter, pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot filter the rows of table %q which has column %q of user-defined type %s",
+				table.GetName(), col.GetName(), col.GetType().SQLString())
+		}
+	}
+	for _, idx := range table.PublicNonPrimaryIndexes() {
+		if idx.GetType() == idxtype.VECTOR {
+			return rowFilter, pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot filter the rows of table %q which has vector index %q", table.GetName(), idx.GetName())
+		}
+	}
+
+	semaCtx := tree.MakeSemaContext(nil /* resolver */)
+	if opts.Where != nil {
+		where, err := exprEval.String(ctx, opts.Where)
+		if err != nil {
+			return rowFilter, err
+		}
+		if _, _, err := schemaexpr.MakeRowFilterExpr(
+			ctx, where, table.PublicColumns(), table, p.EvalContext(), &semaCtx,
+		); err != nil {
+			return rowFilter, errors.Wrap(err, "invalid where option")
+		}
+		rowFilter.Predicate = where
+	}
+
+	if opts.Columns != nil {
+		columns, err := exprEval.String(ctx, opts.Columns)
+		if err != nil {
+			return rowFilter, err
+		}
+		exprs, err := parser.ParseExprs([]string{columns})
+		if err != nil {
+			return rowFilter, errors.Wrap(err, "invalid columns option")
+		}
+		var kept catalog.TableColSet
+		for _, expr := range exprs {
+			name, ok := expr.(*tree.UnresolvedName)
+			if !ok || name.NumParts != 1 || name.Star {
+				return rowFilter, pgerror.Newf(pgcode.Syntax,
+					"invalid columns option: expected a column name, found %s", expr)
+			}
+			col, err := catalog.MustFindColumnByName(table, name.Parts[0])
+			if err != nil {
+				return rowFilter, err
+			}
+			kept.Add(col.GetID())
+		}
+		var computedRefs catalog.TableColSet
+		for _, col := range table.PublicColumns() {
+			if !col.IsComputed() {
+				continue
+			}
+			expr, err := parser.ParseExpr(col.GetComputeExpr())
+			if err != nil {
+				return rowFilter, err
+			}
+			refs, err := schemaexpr.ExtractColumnIDs(table, expr)
+			if err != nil {
+				return rowFilter, err
+			}
+			computedRefs.UnionWith(refs)
+		}
+		pkCols := table.GetPrimaryIndex().CollectKeyColumnIDs()
+		for _, col := range table.PublicColumns() {
+			if kept.Contains(col.GetID()) {
+				continue
+			}
+			switch {
+			case pkCols.Contains(col.GetID()):
+				return rowFilter, pgerror.Newf(pgcode.InvalidParameterValue,
+					"the columns option must include primary key column %q", col.GetName())
+			case !col.IsNullable():
+				return rowFilter, pgerror.Newf(pgcode.InvalidParameterValue,
+					"the columns option must include non-nullable column %q", col.GetName())
+			case col.IsComputed():
+				return rowFilter, pgerror.Newf(pgcode.InvalidParameterValue,
+					"the columns option must include computed column %q", col.GetName())
+			case computedRefs.Contains(col.GetID()):
+				return rowFilter, pgerror.Newf(pgcode.InvalidParameterValue,
+					"the columns option must include column %q, which is referenced by a computed column",
+					col.GetName())
+			}
+		}
+		rowFilter.ColumnIDs = kept.Ordered()
+	}
+	return rowFilter, nil
+}
+
 func collectRestoreTelemetry(
 	ctx context.Context,
 	jobID jobspb.JobID,
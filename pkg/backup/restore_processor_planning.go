@@ -43,6 +43,8 @@ type restoreJobMetadata struct {
 	// useLink indicates that the restore should link files via LinkExternalSSTable
 	// rather than downloading and ingesting them via AddSSTable.
 	useLink bool
+	// rowFilters restrict the rows and columns restored into tables.
+	rowFilters []jobspb.RestoreDetails_RowFilter
 }
 
 // distRestore plans a 2 stage distSQL flow for a distributed restore. It
@@ -115,6 +117,7 @@ func distRestore(
 			PKIDs:                md.dataToRestore.getPKIDs(),
 			ValidateOnly:         md.dataToRestore.isValidateOnly(),
 			ResumeClusterVersion: md.resumeClusterVersion,
+			RowFilters:           md.rowFilters,
 		}
 
 		// Plan SplitAndScatter on the coordinator node.
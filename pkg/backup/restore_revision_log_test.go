@@ -495,3 +495,90 @@ func TestRestoreFromRevlog(t *testing.T) {
 		"expected no leftover SSTs under %s", jobDir,
 	)
 }
+
+// TestAlterTableRevert reverts a table in place to a time covered by the
+// revision log, with changes both before and after the end of the log's last
+// closed tick, and checks that a table whose schema changed since the target
+// time cannot be reverted.
+func TestAlterTableRevert(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	params := base.TestClusterArgs{
+		ServerArgs: base.TestServerArgs{
+			DefaultTestTenant: base.TestIsSpecificToStorageLayerAndNeedsASystemTenant,
+		},
+	}
+	_, sqlDB, dir, cleanup := backupRestoreTestSetupWithParams(
+		t, singleNode, 0 /* numAccounts */, InitManualReplication, params,
+	)
+	defer cleanup()
+
+	sqlDB.Exec(t, `SET CLUSTER SETTING kv.rangefeed.enabled = true`)
+	sqlDB.Exec(t, `CREATE DATABASE src`)
+	sqlDB.Exec(t, `CREATE TABLE src.foo (k INT PRIMARY KEY, v STRING, INDEX (v))`)
+	sqlDB.Exec(t, `INSERT INTO src.foo VALUES (1, 'alpha'), (2, 'beta'), (3, 'gamma')`)
+	sqlDB.Exec(t, `CREATE TABLE src.bar (k INT PRIMARY KEY)`)
+	sqlDB.Exec(t, `INSERT INTO src.bar VALUES (1)`)
+
+	const destSubdir = "revert-table"
+	dest := "nodelocal://1/" + destSubdir
+	sqlDB.Exec(t, `BACKUP INTO $1 WITH REVISION STREAM`, dest)
+	siblingID := readSiblingJobID(t, filepath.Join(dir, destSubdir))
+	jobutils.WaitForJobToRun(t, sqlDB, siblingID)
+
+	sqlDB.Exec(t, `UPDATE src.foo SET v = 'updated' WHERE k = 1`)
+	target := clusterTimestamp(t, sqlDB)
+	expected := sqlDB.QueryStr(t, `SELECT k, v FROM src.foo ORDER BY k`)
+
+	// Changes captured by the log before the revert...
+	sqlDB.Exec(t, `UPDATE src.foo SET v = 'again' WHERE k = 1`)
+	sqlDB.Exec(t, `DELETE FROM src.foo WHERE k = 2`)
+	sqlDB.Exec(t, `INSERT INTO src.foo VALUES (100, 'new')`)
+	sqlDB.Exec(t, `INSERT INTO src.bar VALUES (2)`)
+	waitForRevlogPastTimestamp(t, sqlDB, siblingID, clusterTimestamp(t, sqlDB))
+	// ...and ones that may not be, which are reverted within the GC window.
+	sqlDB.Exec(t, `UPDATE src.foo SET v = 'late' WHERE k = 3`)
+	sqlDB.Exec(t, `INSERT INTO src.foo VALUES (200, 'late')`)
+
+	sqlDB.Exec(t, fmt.Sprintf(
+		`ALTER TABLE src.foo REVERT TO SYSTEM TIME '%s' FROM '%s'`, target, dest,
+	))
+	sqlDB.CheckQueryResults(t, `SELECT k, v FROM src.foo ORDER BY k`, expected)
+	sqlDB.CheckQueryResults(t, `SELECT k, v FROM src.foo@foo_v_idx ORDER BY k`, expected)
+	// Other tables are left alone.
+	sqlDB.CheckQueryResults(t, `SELECT k FROM src.bar ORDER BY k`, [][]string{{"1"}, {"2"}})
+	// The table is writable again.
+	sqlDB.Exec(t, `INSERT INTO src.foo VALUES (300, 'after')`)
+	sqlDB.CheckQueryResults(t,
+		`SELECT status FROM [SHOW JOBS] WHERE job_type = 'ALTER TABLE REVERT'`, [][]string{{"succeeded"}},
+	)
+
+	// The table is offline while the job runs, and is brought back online if
+	// the job is canceled.
+	sqlDB.Exec(t, `SET CLUSTER SETTING jobs.debug.pausepoints = 'alter_table_revert.before_rewrite'`)
+	sqlDB.ExpectErr(t, "pause point",
+		fmt.Sprintf(`ALTER TABLE src.foo REVERT TO SYSTEM TIME '%s' FROM '%s'`, target, dest),
+	)
+	sqlDB.Exec(t, `RESET CLUSTER SETTING jobs.debug.pausepoints`)
+	var revertJobID jobspb.JobID
+	sqlDB.QueryRow(t,
+		`SELECT job_id FROM [SHOW JOBS] WHERE job_type = 'ALTER TABLE REVERT' AND status = 'paused'`,
+	).Scan(&revertJobID)
+	sqlDB.ExpectErr(t, "offline", `SELECT * FROM src.foo`)
+	sqlDB.Exec(t, `CANCEL JOB $1`, revertJobID)
+	jobutils.WaitForJobToCancel(t, sqlDB, revertJobID)
+	sqlDB.Exec(t, `INSERT INTO src.foo VALUES (400, 'canceled')`)
+
+	sqlDB.ExpectErr(t, "multi-statement transaction",
+		fmt.Sprintf(`BEGIN; ALTER TABLE src.foo REVERT TO SYSTEM TIME '%s' FROM '%s'; COMMIT`, target, dest),
+	)
+
+	sqlDB.Exec(t, `ALTER TABLE src.foo ADD COLUMN w INT`)
+	sqlDB.ExpectErr(t, "its schema",
+		fmt.Sprintf(`ALTER TABLE src.foo REVERT TO SYSTEM TIME '%s' FROM '%s'`, target, dest),
+	)
+
+	sqlDB.Exec(t, `CANCEL JOB $1`, siblingID)
+	jobutils.WaitForJobToCancel(t, sqlDB, siblingID)
+}
@@ -0,0 +1,374 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/metamorphic"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/errors"
+)
+
+// restoreRowFilterIndexBufferSize is the size of the rebuilt secondary index
+// KVs a restoreRowFilter buffers before it adds them to the batcher.
+var restoreRowFilterIndexBufferSize = int64(metamorphic.ConstantWithTestRange(
+	"restore-row-filter-index-buffer-size",
+	32<<20, /* defaultValue */
+	1,      /* metamorphic min */
+	64<<10, /* metamorphic max */
+))
+
+// restoreRowFilter applies the row filters of a RESTORE, as specified by its
+// where and columns options, to the KVs of the filtered tables as they are
+// ingested. The rows of a filtered table are decoded from their primary index
+// KVs, and only the rows which satisfy the filter's predicate are re-encoded,
+// with the excluded columns set to NULL, and ingested. The secondary index KVs
+// of a filtered table in the backup are dropped, and the secondary indexes are
+// rebuilt from the rows which are kept.
+//
+// A restoreRowFilter is not safe for concurrent use; each restore worker uses
+// its own.
+type restoreRowFilter struct {
+	codec   keys.SQLCodec
+	evalCtx *eval.Context
+	tables  map[descpb.ID]*restoreTableRowFilter
+
+	// pending buffers the primary index KVs of the row that is currently being
+	// read, since all the column families of a row must be decoded together.
+	pending struct {
+		table  *restoreTableRowFilter
+		prefix roachpb.Key
+		ts     hlc.Timestamp
+		kvs    []roachpb.KeyValue
+	}
+
+	// indexKVs buffers the secondary index KVs of the rows of the current
+	// restore span entry. They are ingested once all the KVs of the entry have
+	// been, or once they exceed restoreRowFilterIndexBufferSize, since the
+	// batcher requires that keys are added in order.
+	indexKVs []storage.MVCCKeyValue
+
+	// indexAcc accounts for indexKVs in the backup monitor of the node.
+	indexAcc *mon.BoundAccount
+}
+
+// restoreTableRowFilter is the state of a restoreRowFilter for a single table.
+type restoreTableRowFilter struct {
+	desc catalog.TableDescriptor
+	// cols are the columns of the table, in the order of the decoded rows, and
+	// colMap maps their IDs to their ordinals.
+	cols   []catalog.Column
+	colMap catalog.TableColMap
+	// excluded are the ordinals of the columns whose values are not restored.
+	excluded []int
+
+	predicate         tree.TypedExpr
+	partialIndexExprs map[descpb.IndexID]tree.TypedExpr
+	ivars             schemaexpr.RowIndexedVarContainer
+
+	indexes     []catalog.Index
+	keyPrefixes [][]byte
+
+	fetcher row.Fetcher
+}
+
+// makeRestoreRowFilter returns a restoreRowFilter for the row filters in the
+// spec, or nil if there are none.
+func makeRestoreRowFilter(
+	ctx context.Context, flowCtx *execinfra.FlowCtx, spec *execinfrapb.RestoreDataSpec,
+) (*restoreRowFilter, error) {
+	if len(spec.RowFilters) == 0 {
+		return nil, nil
+	}
+	descs := make(map[descpb.ID]catalog.TableDescriptor)
+	for _, rekey := range spec.TableRekeys {
+		if rekey.OldID == 0 {
+			continue
+		}
+		var desc descpb.Descriptor
+		if err := protoutil.Unmarshal(rekey.NewDesc, &desc); err != nil {
+			return nil, errors.Wrapf(err, "unmarshalling rekey descriptor for old table id %d", rekey.OldID)
+		}
+		table, _, _, _, _ := descpb.GetDescriptors(&desc)
+		if table == nil {
+			return nil, errors.New("expected a table descriptor")
+		}
+		descs[table.ID] = tabledesc.NewBuilder(table).BuildImmutableTable()
+	}
+
+	f := &restoreRowFilter{
+		codec:   flowCtx.Codec(),
+		evalCtx: flowCtx.NewEvalCtx(),
+		tables:  make(map[descpb.ID]*restoreTableRowFilter, len(spec.RowFilters)),
+	}
+	indexAcc := flowCtx.Cfg.BackupMonitor.MakeBoundAccount()
+	f.indexAcc = &indexAcc
+	// The types of the columns of filtered tables are checked during planning
+	// not to be user-defined, so no type resolver is needed.
+	semaCtx := tree.MakeSemaContext(nil /* resolver */)
+	for _, filter := range spec.RowFilters {
+		desc, ok := descs[filter.TableID]
+		if !ok {
+			// The filtered table is not restored by this flow, e.g. because it
+			// only restores the system tables.
+			continue
+		}
+		t := &restoreTableRowFilter{
+			desc: desc,
+			cols: desc.PublicColumns(),
+		}
+		colIDs := make([]descpb.ColumnID, len(t.cols))
+		for i, col := range t.cols {
+			colIDs[i] = col.GetID()
+			t.colMap.Set(col.GetID(), i)
+		}
+		if len(filter.ColumnIDs) > 0 {
+			var kept catalog.TableColSet
+			for _, id := range filter.ColumnIDs {
+				kept.Add(id)
+			}
+			for i, col := range t.cols {
+				if !kept.Contains(col.GetID()) {
+					t.excluded = append(t.excluded, i)
+				}
+			}
+		}
+		t.ivars = schemaexpr.RowIndexedVarContainer{Cols: t.cols, Mapping: t.colMap}
+
+		var err error
+		if filter.Predicate != "" {
+			if t.predicate, _, err = schemaexpr.MakeRowFilterExpr(
+				ctx, filter.Predicate, t.cols, desc, f.evalCtx, &semaCtx,
+			); err != nil {
+				return nil, errors.Wrapf(err, "building row filter for table %d", filter.TableID)
+			}
+		}
+		if t.partialIndexExprs, _, err = schemaexpr.MakePartialIndexExprs(
+			ctx, desc.PartialIndexes(), t.cols, desc, f.evalCtx, &semaCtx,
+		); err != nil {
+			return nil, err
+		}
+		t.indexes = desc.PublicNonPrimaryIndexes()
+		t.keyPrefixes = make([][]byte, len(t.indexes))
+		for i, idx := range t.indexes {
+			t.keyPrefixes[i] = rowenc.MakeIndexKeyPrefix(f.codec, desc.GetID(), idx.GetID())
+		}
+
+		var fetchSpec fetchpb.IndexFetchSpec
+		if err := rowenc.InitIndexFetchSpec(
+			&fetchSpec, f.codec, desc, desc.GetPrimaryIndex(), colIDs,
+		); err != nil {
+			return nil, err
+		}
+		if err := t.fetcher.Init(ctx, row.FetcherInitArgs{
+			WillUseKVProvider: true,
+			Alloc:             &tree.DatumAlloc{},
+			Spec:              &fetchSpec,
+		}); err != nil {
+			return nil, err
+		}
+		f.tables[desc.GetID()] = t
+	}
+	if len(f.tables) == 0 {
+		f.close(ctx)
+		return nil, nil
+	}
+	return f, nil
+}
+
+// add is called with each KV being restored, after its key has been
+// rewritten. It returns false if the KV is not in a filtered table, in which
+// case the caller ingests it as is.
+func (f *restoreRowFilter) add(
+	ctx context.Context, batcher SSTBatcherExecutor, key storage.MVCCKey, value roachpb.Value,
+) (bool, error) {
+	var t *restoreTableRowFilter
+	var indexID uint32
+	if _, tableID, idxID, err := f.codec.DecodeIndexPrefix(key.Key); err == nil {
+		t, indexID = f.tables[descpb.ID(tableID)], idxID
+	}
+	var rowPrefix roachpb.Key
+	if t != nil && descpb.IndexID(indexID) == t.desc.GetPrimaryIndexID() {
+		var err error
+		if rowPrefix, err = keys.EnsureSafeSplitKey(key.Key); err != nil {
+			return false, err
+		}
+	}
+	// Any KV which is not in the pending row is after it, so the pending row is
+	// complete.
+	if f.pending.table != nil && (rowPrefix == nil || !rowPrefix.Equal(f.pending.prefix)) {
+		if err := f.flushRow(ctx, batcher); err != nil {
+			return false, err
+		}
+	}
+	if t == nil {
+		return false, nil
+	}
+	if rowPrefix == nil {
+		// The secondary indexes of the table are rebuilt from the kept rows.
+		return true, nil
+	}
+	if f.pending.table == nil {
+		f.pending.table = t
+		f.pending.prefix = append(f.pending.prefix[:0], rowPrefix...)
+	}
+	f.pending.ts.Forward(key.Timestamp)
+	f.pending.kvs = append(f.pending.kvs, roachpb.KeyValue{
+		Key:   key.Key.Clone(),
+		Value: roachpb.Value{RawBytes: append([]byte(nil), value.RawBytes...)},
+	})
+	return true, nil
+}
+
+// flushRow decodes the pending row and, if it satisfies the predicate of its
+// table's filter, ingests its primary index KVs and buffers its secondary
+// index KVs, ingesting the buffered ones if they exceed the buffer size.
+func (f *restoreRowFilter) flushRow(ctx context.Context, batcher SSTBatcherExecutor) error {
+	t, ts := f.pending.table, f.pending.ts
+	kvs := f.pending.kvs
+	f.pending.table = nil
+	f.pending.ts = hlc.Timestamp{}
+	f.pending.kvs = f.pending.kvs[:0]
+
+	if err := t.fetcher.ConsumeKVProvider(ctx, &row.KVProvider{KVs: kvs}); err != nil {
+		return err
+	}
+	datums, _, err := t.fetcher.NextRowDecoded(ctx)
+	if err != nil || datums == nil {
+		return err
+	}
+
+	t.ivars.CurSourceRow = datums
+	f.evalCtx.PushIVarContainer(&t.ivars)
+	defer f.evalCtx.PopIVarContainer()
+	if t.predicate != nil {
+		d, err := eval.Expr(ctx, f.evalCtx, t.predicate)
+		if err != nil {
+			return err
+		}
+		if d != tree.DBoolTrue {
+			return nil
+		}
+	}
+	for _, ord := range t.excluded {
+		datums[ord] = tree.DNull
+	}
+	indexes, keyPrefixes := t.indexes, t.keyPrefixes
+	if t.partialIndexExprs != nil {
+		indexes, keyPrefixes = nil, nil
+		for i, idx := range t.indexes {
+			if expr, ok := t.partialIndexExprs[idx.GetID()]; ok {
+				d, err := eval.Expr(ctx, f.evalCtx, expr)
+				if err != nil {
+					return err
+				}
+				if d != tree.DBoolTrue {
+					continue
+				}
+			}
+			indexes = append(indexes, idx)
+			keyPrefixes = append(keyPrefixes, t.keyPrefixes[i])
+		}
+	}
+
+	primary, err := rowenc.EncodePrimaryIndex(
+		f.codec, t.desc, t.desc.GetPrimaryIndex(), t.colMap, datums, false, /* includeEmpty */
+	)
+	if err != nil {
+		return err
+	}
+	for _, entry := range primary {
+		entry.Value.InitChecksum(entry.Key)
+		if err := batcher.AddMVCCKey(
+			ctx, storage.MVCCKey{Key: entry.Key, Timestamp: ts}, entry.Value.RawBytes,
+		); err != nil {
+			return errors.Wrapf(err, "adding filtered row to batch: %s", entry.Key)
+		}
+	}
+	secondary, _, err := rowenc.EncodeSecondaryIndexes(
+		ctx, f.codec, t.desc, indexes, keyPrefixes, t.colMap, datums,
+		rowenc.EmptyVectorIndexEncodingHelper, nil /* secondaryIndexEntries */, false, /* includeEmpty */
+		f.indexAcc,
+	)
+	if err != nil {
+		return err
+	}
+	for _, entry := range secondary {
+		entry.Value.InitChecksum(entry.Key)
+		f.indexKVs = append(f.indexKVs, storage.MVCCKeyValue{
+			Key:   storage.MVCCKey{Key: entry.Key, Timestamp: ts},
+			Value: entry.Value.RawBytes,
+		})
+	}
+	if f.indexAcc.Used() > restoreRowFilterIndexBufferSize {
+		return f.flushIndexKVs(ctx, batcher)
+	}
+	return nil
+}
+
+// finish is called once all the KVs of a restore span entry have been added.
+// It flushes the pending row and ingests the buffered secondary index KVs.
+func (f *restoreRowFilter) finish(ctx context.Context, batcher SSTBatcherExecutor) error {
+	if f.pending.table != nil {
+		if err := f.flushRow(ctx, batcher); err != nil {
+			return err
+		}
+	}
+	return f.flushIndexKVs(ctx, batcher)
+}
+
+// flushIndexKVs ingests the buffered secondary index KVs.
+func (f *restoreRowFilter) flushIndexKVs(ctx context.Context, batcher SSTBatcherExecutor) error {
+	if len(f.indexKVs) == 0 {
+		return nil
+	}
+	// The secondary index KVs are not ordered with respect to the primary index
+	// KVs which were added before or are added after them, so they are added in
+	// batches of their own.
+	if err := batcher.Flush(ctx); err != nil {
+		return err
+	}
+	sort.Slice(f.indexKVs, func(i, j int) bool {
+		return f.indexKVs[i].Key.Less(f.indexKVs[j].Key)
+	})
+	for _, kv := range f.indexKVs {
+		if err := batcher.AddMVCCKey(ctx, kv.Key, kv.Value); err != nil {
+			return errors.Wrapf(err, "adding rebuilt index entry to batch: %s", kv.Key)
+		}
+	}
+	if err := batcher.Flush(ctx); err != nil {
+		return err
+	}
+	f.indexKVs = f.indexKVs[:0]
+	f.indexAcc.Clear(ctx)
+	return nil
+}
+
+// close releases the resources of the filter.
+func (f *restoreRowFilter) close(ctx context.Context) {
+	for _, t := range f.tables {
+		t.fetcher.Close(ctx)
+	}
+	f.indexAcc.Close(ctx)
+}
@@ -348,6 +348,7 @@ func generateAndSendImportSpans(
 					Layer:                   int32(layer),
 					HasRangeKeys:            f.HasRangeKeys,
 					UseLink:                 canLink,
+					SHA256:                  f.SHA256,
 				}
 				if dir, ok := backupLocalityMap[layer][f.LocalityKV]; ok {
 					fileSpec.Dir = dir
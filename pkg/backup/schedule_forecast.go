@@ -0,0 +1,455 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/errors"
+)
+
+// backupSizeInfoKey is the job info key under which a backup job records its
+// size once it succeeds.
+const backupSizeInfoKey = "backup/size"
+
+// forecastHistoryLimit is the number of most recent backups of a schedule
+// whose sizes a forecast is based on.
+const forecastHistoryLimit = 50
+
+// rangeStatsBatchSize is the number of ranges whose stats are fetched in a
+// single batch.
+const rangeStatsBatchSize = 100
+
+var backupForecastRetention = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"backup.forecast.retention",
+	"the length of time for which backups are assumed to be kept when forecasting the storage used by a backup schedule",
+	30*24*time.Hour,
+	settings.PositiveDuration,
+)
+
+var showBackupScheduleForecastHeader = colinfo.ResultColumns{
+	{Name: "schedule_id", Typ: types.Int},
+	{Name: "label", Typ: types.String},
+	{Name: "observed_backups", Typ: types.Int},
+	{Name: "live_bytes", Typ: types.Int},
+	{Name: "write_bytes_per_second", Typ: types.Float},
+	{Name: "full_backup_bytes", Typ: types.Int},
+	{Name: "incremental_backup_bytes", Typ: types.Int},
+	{Name: "retention", Typ: types.Interval},
+	{Name: "retention_bytes", Typ: types.Int},
+	{Name: "compaction_savings_bytes", Typ: types.Int},
+}
+
+// recordBackupSize persists the size of a completed backup in the info of its
+// job, for forecastBackupSchedule to read.
+func recordBackupSize(
+	ctx context.Context,
+	db isql.DB,
+	jobID jobspb.JobID,
+	manifest *backuppb.BackupManifest,
+	res roachpb.RowCount,
+) error {
+	rec := backuppb.BackupSizeRecord{
+		StartTime: manifest.StartTime,
+		EndTime:   manifest.EndTime,
+		DataSize:  res.DataSize,
+	}
+	return db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		return jobs.InfoStorageForJob(txn, jobID).WriteProto(ctx, backupSizeInfoKey, &rec)
+	})
+}
+
+// backupScheduleForecast is the forecast of the size of the backups taken by
+// a full and incremental pair of backup schedules.
+type backupScheduleForecast struct {
+	// observedBackups is the number of past backups of the schedules whose sizes
+	// the forecast is based on.
+	observedBackups int
+	// liveBytes and writeBytesPerSecond are the live MVCC bytes of, and the
+	// recent rate of bytes written to, the ranges of the backed up spans.
+	liveBytes           int64
+	writeBytesPerSecond float64
+	// fullBytes and incrementalBytes are the forecast sizes of the next full and
+	// incremental backups.
+	fullBytes        int64
+	incrementalBytes int64
+	// retention is the length of time backups are assumed to be kept, and
+	// retentionBytes the forecast size of the backups taken over it.
+	retention      time.Duration
+	retentionBytes int64
+	// compactionSavingsBytes is by how much compactions are forecast to shrink
+	// the backup chains taken over the retention window.
+	compactionSavingsBytes int64
+}
+
+// forecastInputs are what a forecast is computed from.
+type forecastInputs struct {
+	// history holds the sizes of past backups, oldest first.
+	history             []backuppb.BackupSizeRecord
+	liveBytes           int64
+	writeBytesPerSecond float64
+	// fullEvery and incEvery are the periods of the full and incremental
+	// schedules. incEvery is 0 if every backup is a full backup.
+	fullEvery, incEvery time.Duration
+	retention           time.Duration
+	// compactionThreshold is 0 if compactions are disabled.
+	compactionThreshold int
+	compactionWindow    int
+}
+
+// forecast computes a forecast from its inputs.
+//
+// The next full backup is forecast to be as large as the live bytes of its
+// spans, or as the last full backup if that was larger, as it is when the
+// backup includes revision history. Incremental backups are forecast to grow
+// at the rate of bytes per second of time they covered in the past, or, if no
+// incremental backup has completed yet, at the rate of bytes written to the
+// ranges of their spans.
+//
+// Compaction is forecast to replace a window of incremental backups, chosen as
+// it is by minSizeDeltaHeuristic, with a single backup as large as the largest
+// of them, and to run as often as the chain length threshold allows.
+func (in forecastInputs) forecast() backupScheduleForecast {
+	f := backupScheduleForecast{
+		observedBackups:     len(in.history),
+		liveBytes:           in.liveBytes,
+		writeBytesPerSecond: in.writeBytesPerSecond,
+		fullBytes:           in.liveBytes,
+		retention:           in.retention,
+	}
+	var incSizes []int64
+	var incBytes int64
+	var incSeconds float64
+	for _, rec := range in.history {
+		if rec.StartTime.IsEmpty() {
+			// History is oldest first, so this leaves the last full backup.
+			f.fullBytes = max(in.liveBytes, rec.DataSize)
+			continue
+		}
+		incSizes = append(incSizes, rec.DataSize)
+		incBytes += rec.DataSize
+		incSeconds += rec.EndTime.GoTime().Sub(rec.StartTime.GoTime()).Seconds()
+	}
+	if in.fullEvery <= 0 {
+		return f
+	}
+	fulls := int64(math.Ceil(float64(in.retention) / float64(in.fullEvery)))
+	if in.incEvery <= 0 {
+		f.retentionBytes = fulls * f.fullBytes
+		return f
+	}
+
+	incRate := in.writeBytesPerSecond
+	if incSeconds > 0 {
+		incRate = float64(incBytes) / incSeconds
+	}
+	f.incrementalBytes = int64(incRate * in.incEvery.Seconds())
+
+	incs := max(int64(in.retention/in.incEvery)-fulls, 0)
+	f.retentionBytes = fulls*f.fullBytes + incs*f.incrementalBytes
+
+	window := in.compactionWindow
+	if in.compactionThreshold == 0 || window < 2 || len(incSizes) < window {
+		return f
+	}
+	// A chain holds a full backup and the incremental backups taken until the
+	// next full backup. It is compacted once it reaches the threshold, which
+	// shortens it by all but one of the compacted backups.
+	chainLength := max(int(in.fullEvery/in.incEvery), 1)
+	if chainLength < in.compactionThreshold {
+		return f
+	}
+	compactionsPerChain := 1 + (chainLength-in.compactionThreshold)/(window-1)
+	start, end := minDeltaWindow(incSizes, window)
+	var sum int64
+	for _, size := range incSizes[start:end] {
+		sum += size
+	}
+	perCompaction := sum - slices.Max(incSizes[start:end])
+	f.compactionSavingsBytes = perCompaction * int64(compactionsPerChain) * fulls
+	return f
+}
+
+// forecastBackupSchedule forecasts the size of the backups taken by the
+// schedules s.
+func forecastBackupSchedule(
+	ctx context.Context, execCfg *sql.ExecutorConfig, txn isql.Txn, s scheduleDetails,
+) (backupScheduleForecast, error) {
+	var incID jobspb.ScheduleID
+	if s.incJob != nil {
+		incID = s.incJob.ScheduleID()
+	}
+	history, latest, err := loadBackupSizes(ctx, execCfg, txn, s.fullJob.ScheduleID(), incID)
+	if err != nil {
+		return backupScheduleForecast{}, err
+	}
+	if latest == jobspb.InvalidJobID {
+		return backupScheduleForecast{}, errors.Newf(
+			"backup schedule %d has no completed backups to forecast from", s.fullJob.ScheduleID(),
+		)
+	}
+
+	// The spans of the latest backup are those the next one is forecast to back
+	// up.
+	job, err := execCfg.JobRegistry.LoadJobWithTxn(ctx, latest, txn)
+	if err != nil {
+		return backupScheduleForecast{}, err
+	}
+	details, ok := job.Details().(jobspb.BackupDetails)
+	if !ok {
+		return backupScheduleForecast{}, errors.AssertionFailedf(
+			"unexpected job details type %T", job.Details(),
+		)
+	}
+	spans, err := newRevlogScope(execCfg, details).Spans(ctx, execCfg.Clock.Now())
+	if err != nil {
+		return backupScheduleForecast{}, errors.Wrap(err, "resolving backed up spans")
+	}
+	liveBytes, writeBytesPerSecond, err := rangeLoad(ctx, execCfg, spans)
+	if err != nil {
+		return backupScheduleForecast{}, err
+	}
+
+	in := forecastInputs{
+		history:             history,
+		liveBytes:           liveBytes,
+		writeBytesPerSecond: writeBytesPerSecond,
+		retention:           backupForecastRetention.Get(&execCfg.Settings.SV),
+		compactionThreshold: int(backupCompactionThreshold.Get(&execCfg.Settings.SV)),
+		compactionWindow:    int(backupCompactionWindow.Get(&execCfg.Settings.SV)),
+	}
+	if in.fullEvery, err = s.fullJob.Frequency(); err != nil {
+		return backupScheduleForecast{}, err
+	}
+	if s.incJob != nil {
+		if in.incEvery, err = s.incJob.Frequency(); err != nil {
+			return backupScheduleForecast{}, err
+		}
+	}
+	return in.forecast(), nil
+}
+
+// loadBackupSizes returns, oldest first, the sizes recorded by the most recent
+// successful backup jobs of the given schedules, and the ID of the latest of
+// those jobs.
+func loadBackupSizes(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	txn isql.Txn,
+	fullID, incID jobspb.ScheduleID,
+) ([]backuppb.BackupSizeRecord, jobspb.JobID, error) {
+	rows, err := txn.QueryBufferedEx(
+		ctx,
+		"backup-forecast-jobs",
+		txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT id FROM system.jobs
+WHERE created_by_type = $1 AND created_by_id IN ($2, $3) AND status = $4
+ORDER BY created DESC LIMIT $5`,
+		jobs.CreatedByScheduledJobs, fullID, incID, jobs.StateSucceeded, forecastHistoryLimit,
+	)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "listing backups of schedule")
+	}
+	var history []backuppb.BackupSizeRecord
+	var latest jobspb.JobID
+	for _, row := range rows {
+		jobID := jobspb.JobID(tree.MustBeDInt(row[0]))
+		var rec backuppb.BackupSizeRecord
+		// Compactions, revision log jobs and backups that completed before sizes
+		// were recorded have no size.
+		ok, err := jobs.InfoStorageForJob(txn, jobID).GetProto(ctx, backupSizeInfoKey, &rec)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "reading size of backup job %d", jobID)
+		}
+		if !ok {
+			continue
+		}
+		if latest == jobspb.InvalidJobID {
+			latest = jobID
+		}
+		history = append(history, rec)
+	}
+	slices.Reverse(history)
+	return history, latest, nil
+}
+
+// rangeLoad returns the total live bytes of the ranges overlapping spans, and
+// the total rate of bytes written to them.
+func rangeLoad(
+	ctx context.Context, execCfg *sql.ExecutorConfig, spans []roachpb.Span,
+) (liveBytes int64, writeBytesPerSecond float64, _ error) {
+	// A key in every range, within the spans so that a tenant may address it.
+	var keys []roachpb.Key
+	seen := make(map[roachpb.RangeID]struct{})
+	for _, sp := range spans {
+		it, err := execCfg.RangeDescIteratorFactory.NewLazyIterator(ctx, sp, rangeStatsBatchSize)
+		if err != nil {
+			return 0, 0, err
+		}
+		for ; it.Valid(); it.Next() {
+			desc := it.CurRangeDescriptor()
+			if _, ok := seen[desc.RangeID]; ok {
+				continue
+			}
+			seen[desc.RangeID] = struct{}{}
+			key := desc.StartKey.AsRawKey()
+			if key.Compare(sp.Key) < 0 {
+				key = sp.Key
+			}
+			keys = append(keys, key)
+		}
+		if err := it.Error(); err != nil {
+			return 0, 0, err
+		}
+	}
+	for batch := range slices.Chunk(keys, rangeStatsBatchSize) {
+		resps, err := execCfg.RangeStatsFetcher.RangeStats(ctx, batch...)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "fetching range stats")
+		}
+		for _, resp := range resps {
+			liveBytes += resp.MVCCStats.LiveBytes
+			writeBytesPerSecond += resp.WriteBytesPerSecond
+		}
+	}
+	return liveBytes, writeBytesPerSecond, nil
+}
+
+// loadForecastSchedules loads the schedules whose backups are forecast, and
+// checks that the user may see them.
+func loadForecastSchedules(
+	ctx context.Context, p sql.PlanHookState, scheduleID jobspb.ScheduleID,
+) (scheduleDetails, error) {
+	s, err := loadSchedules(ctx, p, scheduleID)
+	if err != nil {
+		return scheduleDetails{}, err
+	}
+	if s.fullJob == nil {
+		return scheduleDetails{}, errors.Newf(
+			"incremental backup schedule %d has no corresponding full backup schedule",
+			s.incJob.ScheduleID(),
+		)
+	}
+	hasPriv, err := p.HasPrivilege(ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.REPAIRCLUSTER, p.User())
+	if err != nil {
+		return scheduleDetails{}, err
+	}
+	isOwner := s.fullJob.Owner() == p.User() && (s.incJob == nil || s.incJob.Owner() == p.User())
+	if !hasPriv && !isOwner {
+		return scheduleDetails{}, pgerror.Newf(pgcode.InsufficientPrivilege, "must be admin or the owner of the "+
+			"schedules being forecast, or have %s privilege", privilege.REPAIRCLUSTER)
+	}
+	return s, nil
+}
+
+// ForecastBackupSchedule returns the forecast of the backups taken by a
+// schedule as a JSON object. It implements
+// crdb_internal.backup_schedule_forecast.
+//
+// Note that planner should be a sql.PlanHookState. Due to import cycles with
+// the sql and builtins package, the interface{} type is used.
+func ForecastBackupSchedule(
+	ctx context.Context, planner interface{}, scheduleID jobspb.ScheduleID,
+) (json.JSON, error) {
+	p, ok := planner.(sql.PlanHookState)
+	if !ok {
+		return nil, errors.New("missing job execution context")
+	}
+	s, err := loadForecastSchedules(ctx, p, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	f, err := forecastBackupSchedule(ctx, p.ExecCfg(), p.InternalSQLTxn(), s)
+	if err != nil {
+		return nil, err
+	}
+	writeRate, err := json.FromFloat64(f.writeBytesPerSecond)
+	if err != nil {
+		return nil, err
+	}
+	b := json.NewObjectBuilder(8 /* numAddsHint */)
+	b.Add("observed_backups", json.FromInt(f.observedBackups))
+	b.Add("live_bytes", json.FromInt64(f.liveBytes))
+	b.Add("write_bytes_per_second", writeRate)
+	b.Add("full_backup_bytes", json.FromInt64(f.fullBytes))
+	b.Add("incremental_backup_bytes", json.FromInt64(f.incrementalBytes))
+	b.Add("retention", json.FromString(f.retention.String()))
+	b.Add("retention_bytes", json.FromInt64(f.retentionBytes))
+	b.Add("compaction_savings_bytes", json.FromInt64(f.compactionSavingsBytes))
+	return b.Build(), nil
+}
+
+func showBackupScheduleForecastTypeCheck(
+	_ context.Context, stmt tree.Statement, _ sql.PlanHookState,
+) (matched bool, header colinfo.ResultColumns, _ error) {
+	if _, ok := stmt.(*tree.ShowBackupScheduleForecast); !ok {
+		return false, nil, nil
+	}
+	return true, showBackupScheduleForecastHeader, nil
+}
+
+func showBackupScheduleForecastHook(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, bool, error) {
+	showStmt, ok := stmt.(*tree.ShowBackupScheduleForecast)
+	if !ok {
+		return nil, nil, false, nil
+	}
+	fn := func(ctx context.Context, resultsCh chan<- tree.Datums) error {
+		s, err := loadForecastSchedules(ctx, p, jobspb.ScheduleID(showStmt.ScheduleID))
+		if err != nil {
+			return err
+		}
+		f, err := forecastBackupSchedule(ctx, p.ExecCfg(), p.InternalSQLTxn(), s)
+		if err != nil {
+			return err
+		}
+		resultsCh <- tree.Datums{
+			tree.NewDInt(tree.DInt(s.fullJob.ScheduleID())),
+			tree.NewDString(s.fullJob.ScheduleLabel()),
+			tree.NewDInt(tree.DInt(f.observedBackups)),
+			tree.NewDInt(tree.DInt(f.liveBytes)),
+			tree.NewDFloat(tree.DFloat(f.writeBytesPerSecond)),
+			tree.NewDInt(tree.DInt(f.fullBytes)),
+			tree.NewDInt(tree.DInt(f.incrementalBytes)),
+			&tree.DInterval{Duration: duration.MakeDuration(f.retention.Nanoseconds(), 0, 0)},
+			tree.NewDInt(tree.DInt(f.retentionBytes)),
+			tree.NewDInt(tree.DInt(f.compactionSavingsBytes)),
+		}
+		return nil
+	}
+	return fn, showBackupScheduleForecastHeader, false, nil
+}
+
+func init() {
+	sql.AddPlanHook(
+		"show backup schedule forecast", showBackupScheduleForecastHook, showBackupScheduleForecastTypeCheck,
+	)
+	builtins.ForecastBackupSchedule = ForecastBackupSchedule
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupScheduleForecast(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	hour := func(h int) hlc.Timestamp {
+		return hlc.Timestamp{WallTime: int64(h) * int64(time.Hour)}
+	}
+	full := func(size int64) backuppb.BackupSizeRecord {
+		return backuppb.BackupSizeRecord{EndTime: hour(1), DataSize: size}
+	}
+	inc := func(h int, size int64) backuppb.BackupSizeRecord {
+		return backuppb.BackupSizeRecord{StartTime: hour(h), EndTime: hour(h + 1), DataSize: size}
+	}
+	history := []backuppb.BackupSizeRecord{
+		full(1000), inc(1, 100), inc(2, 200), inc(3, 150), inc(4, 300),
+	}
+
+	testcases := []struct {
+		name     string
+		in       forecastInputs
+		expected backupScheduleForecast
+	}{
+		{
+			name: "full backups only",
+			in: forecastInputs{
+				liveBytes: 1000,
+				fullEvery: 24 * time.Hour,
+				retention: 72 * time.Hour,
+			},
+			expected: backupScheduleForecast{
+				liveBytes:      1000,
+				fullBytes:      1000,
+				retention:      72 * time.Hour,
+				retentionBytes: 3000,
+			},
+		},
+		{
+			name: "incremental backups from the write rate",
+			in: forecastInputs{
+				history:             []backuppb.BackupSizeRecord{full(1500)},
+				liveBytes:           1000,
+				writeBytesPerSecond: 1,
+				fullEvery:           24 * time.Hour,
+				incEvery:            time.Hour,
+				retention:           48 * time.Hour,
+			},
+			expected: backupScheduleForecast{
+				observedBackups:     1,
+				liveBytes:           1000,
+				writeBytesPerSecond: 1,
+				fullBytes:           1500,
+				incrementalBytes:    3600,
+				retention:           48 * time.Hour,
+				// 2 full and 46 incremental backups.
+				retentionBytes: 2*1500 + 46*3600,
+			},
+		},
+		{
+			name: "compactions disabled",
+			in: forecastInputs{
+				history:             history,
+				liveBytes:           500,
+				writeBytesPerSecond: 1,
+				fullEvery:           24 * time.Hour,
+				incEvery:            time.Hour,
+				retention:           24 * time.Hour,
+				compactionWindow:    3,
+			},
+			expected: backupScheduleForecast{
+				observedBackups:     5,
+				liveBytes:           500,
+				writeBytesPerSecond: 1,
+				fullBytes:           1000,
+				// The incremental backups covered 4h with 750 bytes.
+				incrementalBytes: 187,
+				retention:        24 * time.Hour,
+				retentionBytes:   1000 + 23*187,
+			},
+		},
+		{
+			name: "compactions",
+			in: forecastInputs{
+				history:             history,
+				liveBytes:           500,
+				writeBytesPerSecond: 1,
+				fullEvery:           24 * time.Hour,
+				incEvery:            time.Hour,
+				retention:           24 * time.Hour,
+				compactionThreshold: 4,
+				compactionWindow:    3,
+			},
+			expected: backupScheduleForecast{
+				observedBackups:     5,
+				liveBytes:           500,
+				writeBytesPerSecond: 1,
+				fullBytes:           1000,
+				incrementalBytes:    187,
+				retention:           24 * time.Hour,
+				retentionBytes:      1000 + 23*187,
+				// The window of 100, 200 and 150 compacts into 200 bytes, and a
+				// chain of 24 backups is compacted 11 times.
+				compactionSavingsBytes: 250 * 11,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.in.forecast())
+		})
+	}
+}
+
+func TestShowBackupScheduleForecast(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	th, cleanup := newTestHelper(t)
+	defer cleanup()
+
+	th.sqlDB.Exec(t, `
+CREATE DATABASE db;
+USE db;
+CREATE TABLE t(a int);
+INSERT INTO t SELECT generate_series(1, 1000);
+`)
+
+	schedules, err := th.createBackupSchedule(t,
+		"CREATE SCHEDULE FOR BACKUP TABLE t INTO $1 RECURRING '@hourly' FULL BACKUP '@daily'",
+		"nodelocal://1/backup/forecast")
+	require.NoError(t, err)
+	require.Len(t, schedules, 2)
+	fullID, incID := schedules[0].ScheduleID(), schedules[1].ScheduleID()
+	if schedules[0].IsPaused() {
+		fullID, incID = incID, fullID
+	}
+
+	th.sqlDB.ExpectErr(t, "has no completed backups",
+		fmt.Sprintf(`SHOW BACKUP SCHEDULE %d FORECAST`, incID))
+
+	th.setOverrideAsOfClauseKnob(t)
+	s := th.loadSchedule(t, fullID)
+	s.SetNextRun(th.env.Now().Add(-time.Minute))
+	require.NoError(t, jobs.ScheduledJobDB(th.internalDB()).Update(context.Background(), s))
+	require.NoError(t, th.executeSchedules())
+	th.waitForSuccessfulScheduledJob(t, fullID)
+
+	// Either schedule of the pair reports the forecast of both.
+	for _, id := range []jobspb.ScheduleID{fullID, incID} {
+		var scheduleID jobspb.ScheduleID
+		var observed, fullBytes, retentionBytes int64
+		th.sqlDB.QueryRow(t, fmt.Sprintf(
+			`SELECT schedule_id, observed_backups, full_backup_bytes, retention_bytes
+FROM [SHOW BACKUP SCHEDULE %d FORECAST]`, id),
+		).Scan(&scheduleID, &observed, &fullBytes, &retentionBytes)
+		require.Equal(t, fullID, scheduleID)
+		require.Equal(t, int64(1), observed)
+		require.Greater(t, fullBytes, int64(0))
+		// Backups are kept for 30 days by default, so 30 full backups are kept.
+		require.GreaterOrEqual(t, retentionBytes, 30*fullBytes)
+	}
+
+	var observed int64
+	th.sqlDB.QueryRow(t,
+		`SELECT (crdb_internal.backup_schedule_forecast($1)->>'observed_backups')::INT`, incID,
+	).Scan(&observed)
+	require.Equal(t, int64(1), observed)
+
+	th.sqlDB.Exec(t, `CREATE USER testuser`)
+	testuser := th.server.SQLConn(t, serverutils.User("testuser"))
+	_, err = testuser.Exec(fmt.Sprintf(`SHOW BACKUP SCHEDULE %d FORECAST`, fullID))
+	require.ErrorContains(t, err, "must be admin or the owner")
+}
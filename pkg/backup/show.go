@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/backup/backupencryption"
 	"github.com/cockroachdb/cockroach/pkg/backup/backupinfo"
 	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupsign"
 	"github.com/cockroachdb/cockroach/pkg/backup/backuputils"
 	"github.com/cockroachdb/cockroach/pkg/cloud"
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
@@ -151,6 +152,8 @@ func showBackupTypeCheck(
 			backup.Options.EncryptionPassphrase,
 			backup.Options.CheckConnectionTransferSize,
 			backup.Options.CheckConnectionDuration,
+			backup.Options.SigningKMSURI,
+			backup.Options.SigningKey,
 		},
 		exprutil.StringArrays{
 			tree.Exprs(backup.InCollection),
@@ -159,6 +162,21 @@ func showBackupTypeCheck(
 	); err != nil {
 		return false, nil, err
 	}
+	if (backup.Options.SigningKMSURI != nil || backup.Options.SigningKey != nil) &&
+		!backup.Options.CheckFiles {
+		return false, nil, errors.New("signing_kms and signing_key can only be used with check_files")
+	}
+	switch backup.Details {
+	case tree.BackupTableDetails:
+		return showBackupTableTypeCheck(ctx, backup, p)
+	case tree.BackupDiffDetails:
+		if err := exprutil.TypeCheck(
+			ctx, "SHOW BACKUP", p.SemaCtx(), exprutil.Strings{backup.DiffTo},
+		); err != nil {
+			return false, nil, err
+		}
+		return true, showBackupDiffHeader, nil
+	}
 	infoReader := getBackupInfoReader(p, backup)
 	return true, infoReader.header(), nil
 }
@@ -193,6 +211,17 @@ func showBackupPlanHook(
 		return nil, nil, false, err
 	}
 
+	switch showStmt.Details {
+	case tree.BackupTableDetails:
+		return showBackupTablePlanHook(ctx, showStmt, p, dest, backupToken)
+	case tree.BackupDiffDetails:
+		toToken, err := exprEval.String(ctx, showStmt.DiffTo)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		return showBackupDiffPlanHook(ctx, showStmt, p, dest, backupToken, toToken)
+	}
+
 	infoReader := getBackupInfoReader(p, showStmt)
 	fn := func(ctx context.Context, resultsCh chan<- tree.Datums) error {
 		ctx, span := tracing.ChildSpan(ctx, stmt.StatementTag())
@@ -351,7 +380,15 @@ func collectBackupInfo(
 	info.localityInfo = []jobspb.RestoreDetails_BackupLocalityInfo{localityInfo}
 
 	if stmt.Options.CheckFiles {
-		info.fileSizes, err = checkBackupFiles(ctx, info, p.ExecCfg(), p.User(), encryption, kmsEnv)
+		signingKMS, signingKey, err := evalSigningOptions(
+			ctx, p.ExprEvaluator("SHOW BACKUP"), stmt.Options.SigningKMSURI, stmt.Options.SigningKey,
+		)
+		if err != nil {
+			return backupInfo{}, 0, err
+		}
+		info.fileSizes, err = checkBackupFiles(
+			ctx, info, p.ExecCfg(), p.User(), encryption, signingKMS, signingKey, kmsEnv,
+		)
 		if err != nil {
 			return backupInfo{}, 0, err
 		}
@@ -487,7 +524,15 @@ func legacyCollectBackupInfo(
 		}
 	}
 	if stmt.Options.CheckFiles {
-		fileSizes, err := checkBackupFiles(ctx, info, p.ExecCfg(), p.User(), encryption, kmsEnv)
+		signingKMS, signingKey, err := evalSigningOptions(
+			ctx, p.ExprEvaluator("SHOW BACKUP"), stmt.Options.SigningKMSURI, stmt.Options.SigningKey,
+		)
+		if err != nil {
+			return backupInfo{}, 0, err
+		}
+		fileSizes, err := checkBackupFiles(
+			ctx, info, p.ExecCfg(), p.User(), encryption, signingKMS, signingKey, kmsEnv,
+		)
 		if err != nil {
 			return backupInfo{}, 0, err
 		}
@@ -527,11 +572,23 @@ func checkBackupFiles(
 	execCfg *sql.ExecutorConfig,
 	user username.SQLUsername,
 	encryption *jobspb.BackupEncryptionOptions,
+	signingKMS string,
+	signingKey []byte,
 	kmsEnv cloud.KMSEnv,
 ) ([][]int64, error) {
 	const maxMissingFiles = 10
 	missingFiles := make(map[string]struct{}, maxMissingFiles)
 
+	// Verify the signatures of signed backups, which cover their metadata
+	// files. The digests of the data files, which are recorded in the signed
+	// manifests, are checked against the files as they are found below.
+	if err := verifyBackupSignatures(
+		ctx, execCfg, user, signingKMS, signingKey, info.defaultURIs, info.manifests, kmsEnv,
+	); err != nil {
+		return nil, err
+	}
+	verifiedFiles := make(map[string]struct{})
+
 	checkLayer := func(layer int) ([]int64, error) {
 		// TODO (msbutler): Right now, checkLayer opens stores for each backup layer. In 22.2,
 		// once a backup chain cannot have mixed localities, only create stores for full backup
@@ -609,6 +666,14 @@ func checkBackupFiles(
 				}
 				continue
 			}
+			if signingKMS != "" && len(f.SHA256) > 0 {
+				if _, ok := verifiedFiles[path.Join(uri, f.Path)]; !ok {
+					if err := backupsign.VerifyFile(ctx, store, f.Path, -1 /* size */, f.SHA256); err != nil {
+						return nil, errors.Wrapf(err, "checking backup at %s", strings.Split(uri, "?")[0])
+					}
+					verifiedFiles[path.Join(uri, f.Path)] = struct{}{}
+				}
+			}
 			fileSizes = append(fileSizes, sz)
 		}
 
@@ -0,0 +1,334 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"slices"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+)
+
+// showBackupDiffHeader is the header of SHOW BACKUP DIFF TABLE. The rows are
+// returned as JSON, so that rows can be compared even if the table's columns
+// changed between the two backups.
+var showBackupDiffHeader = colinfo.ResultColumns{
+	{Name: "change_type", Typ: types.String},
+	{Name: "primary_key", Typ: types.Jsonb},
+	{Name: "old_row", Typ: types.Jsonb},
+	{Name: "new_row", Typ: types.Jsonb},
+}
+
+// The change types returned by SHOW BACKUP DIFF TABLE.
+var (
+	backupDiffInsert = tree.NewDString("insert")
+	backupDiffUpdate = tree.NewDString("update")
+	backupDiffDelete = tree.NewDString("delete")
+)
+
+// showBackupDiffBufferSize is the number of rows read ahead from each of the
+// backups compared by SHOW BACKUP DIFF TABLE.
+const showBackupDiffBufferSize = 256
+
+// backupDiffRow is a row of a table in a backup, with its primary key encoded
+// so that the rows of two backups of the table can be merged.
+type backupDiffRow struct {
+	key    roachpb.Key
+	pk     json.JSON
+	values json.JSON
+}
+
+// showBackupDiffPlanHook plans a SHOW BACKUP DIFF TABLE statement, which
+// returns the rows of a table which were inserted, updated or deleted between
+// the backup identified by fromToken and the later backup identified by
+// toToken. The table's primary index is split into spans which are diffed by
+// backupTableReaders on every SQL instance. Each reads both backups' rows in
+// the span from their SSTs in primary key order, and merges them as they are
+// read, so that neither backup is materialized.
+func showBackupDiffPlanHook(
+	ctx context.Context,
+	stmt *tree.ShowBackup,
+	p sql.PlanHookState,
+	dest []string,
+	fromToken string,
+	toToken string,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, bool, error) {
+	fn := func(ctx context.Context, resultsCh chan<- tree.Datums) error {
+		ctx, span := tracing.ChildSpan(ctx, stmt.StatementTag())
+		defer span.Finish()
+
+		if err := sql.CheckDestinationPrivileges(ctx, p, dest); err != nil {
+			return err
+		}
+		mem := p.ExecCfg().RootMemoryMonitor.MakeBoundAccount()
+		defer mem.Close(ctx)
+		from, memReserved, err := resolveBackupTable(ctx, p, &mem, stmt, dest, fromToken)
+		if err != nil {
+			return errors.Wrapf(err, "resolving backup %s", fromToken)
+		}
+		defer mem.Shrink(ctx, memReserved)
+		to, memReserved, err := resolveBackupTable(ctx, p, &mem, stmt, dest, toToken)
+		if err != nil {
+			return errors.Wrapf(err, "resolving backup %s", toToken)
+		}
+		defer mem.Shrink(ctx, memReserved)
+
+		if from.desc.GetID() != to.desc.GetID() {
+			return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"table %s in backup %s is not the same table as in backup %s",
+				tree.ErrString(stmt.Table), fromToken, toToken)
+		}
+		if from.desc.GetPrimaryIndexID() != to.desc.GetPrimaryIndexID() {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"the primary key of table %s changed between backup %s and backup %s",
+				tree.ErrString(stmt.Table), fromToken, toToken)
+		}
+
+		if err := diffBackupTables(ctx, p, from, to, resultsCh); err != nil {
+			return err
+		}
+		telemetry.Count("show-backup.diff")
+		return nil
+	}
+	return fn, showBackupDiffHeader, false, nil
+}
+
+// diffBackupTables diffs the two backups of a table with a backupTableReader
+// on each SQL instance, and sends the rows which differ to resultsCh.
+func diffBackupTables(
+	ctx context.Context,
+	p sql.PlanHookState,
+	from, to *backupTable,
+	resultsCh chan<- tree.Datums,
+) error {
+	dsp := p.DistSQLPlanner()
+	planCtx, instanceIDs, err := dsp.SetupAllNodesPlanning(ctx, p.ExtendedEvalContext(), p.ExecCfg())
+	if err != nil {
+		return err
+	}
+	indexSpan := to.desc.PrimaryIndexSpan(to.codec)
+	fromEntries, err := from.restoreSpanEntries(ctx, p, indexSpan)
+	if err != nil {
+		return errors.Wrap(err, "planning from backup")
+	}
+	toEntries, err := to.restoreSpanEntries(ctx, p, indexSpan)
+	if err != nil {
+		return errors.Wrap(err, "planning to backup")
+	}
+	fromBackup, err := from.readerBackup(ctx, from.diffColumnIDs())
+	if err != nil {
+		return err
+	}
+	toBackup, err := to.readerBackup(ctx, to.diffColumnIDs())
+	if err != nil {
+		return err
+	}
+
+	spans := partitionBackupTableSpan(indexSpan, toEntries, len(instanceIDs))
+	corePlacements := make([]physicalplan.ProcessorCorePlacement, len(spans))
+	for i, sp := range spans {
+		spec := execinfrapb.BackupTableReaderSpec{
+			Span:     sp,
+			Backup:   toBackup,
+			DiffFrom: &execinfrapb.BackupTableReaderSpec_Backup{},
+		}
+		spec.Backup.Entries = overlappingRestoreSpanEntries(toEntries, sp)
+		*spec.DiffFrom = fromBackup
+		spec.DiffFrom.Entries = overlappingRestoreSpanEntries(fromEntries, sp)
+		corePlacements[i] = physicalplan.ProcessorCorePlacement{
+			SQLInstanceID: instanceIDs[i],
+			Core:          execinfrapb.ProcessorCoreUnion{BackupTableReader: &spec},
+		}
+	}
+	outTypes := make([]*types.T, len(showBackupDiffHeader))
+	for i, col := range showBackupDiffHeader {
+		outTypes[i] = col.Typ
+	}
+	return runBackupTableReaders(ctx, p, planCtx, corePlacements, outTypes, resultsCh)
+}
+
+// diffColumnIDs returns the columns of the table which are read to diff it:
+// those returned by SHOW BACKUP TABLE, followed by the columns of the primary
+// key which are not, such as the virtual shard column of a hash-sharded
+// primary key. The key columns are needed to order and identify the rows.
+func (t *backupTable) diffColumnIDs() []descpb.ColumnID {
+	colIDs := make([]descpb.ColumnID, 0, len(t.cols))
+	for _, col := range t.cols {
+		colIDs = append(colIDs, col.GetID())
+	}
+	primaryIndex := t.desc.GetPrimaryIndex()
+	for i := 0; i < primaryIndex.NumKeyColumns(); i++ {
+		if id := primaryIndex.GetKeyColumnID(i); !slices.Contains(colIDs, id) {
+			colIDs = append(colIDs, id)
+		}
+	}
+	return colIDs
+}
+
+// diffBackupTableRows merges the rows in span of the two backups of a table,
+// which are read concurrently, and calls fn with the rows of
+// showBackupDiffHeader for those which differ.
+func diffBackupTableRows(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	from, to backupTableSource,
+	span roachpb.Span,
+	fn func(tree.Datums) error,
+) error {
+	fromCh := make(chan backupDiffRow, showBackupDiffBufferSize)
+	toCh := make(chan backupDiffRow, showBackupDiffBufferSize)
+	g := ctxgroup.WithContext(ctx)
+	g.GoCtx(func(ctx context.Context) error {
+		defer close(fromCh)
+		return errors.Wrap(scanBackupDiffRows(ctx, flowCtx, from, span, fromCh), "reading from backup")
+	})
+	g.GoCtx(func(ctx context.Context) error {
+		defer close(toCh)
+		return errors.Wrap(scanBackupDiffRows(ctx, flowCtx, to, span, toCh), "reading to backup")
+	})
+	g.GoCtx(func(ctx context.Context) error {
+		emit := func(changeType *tree.DString, pk, oldRow, newRow json.JSON) error {
+			res := tree.Datums{changeType, tree.NewDJSON(pk), tree.DNull, tree.DNull}
+			if oldRow != nil {
+				res[2] = tree.NewDJSON(oldRow)
+			}
+			if newRow != nil {
+				res[3] = tree.NewDJSON(newRow)
+			}
+			return fn(res)
+		}
+		fromRow, fromOK := <-fromCh
+		toRow, toOK := <-toCh
+		for fromOK || toOK {
+			var cmp int
+			switch {
+			case !fromOK:
+				cmp = 1
+			case !toOK:
+				cmp = -1
+			default:
+				cmp = bytes.Compare(fromRow.key, toRow.key)
+			}
+			switch {
+			case cmp < 0:
+				if err := emit(backupDiffDelete, fromRow.pk, fromRow.values, nil); err != nil {
+					return err
+				}
+				fromRow, fromOK = <-fromCh
+			case cmp > 0:
+				if err := emit(backupDiffInsert, toRow.pk, nil, toRow.values); err != nil {
+					return err
+				}
+				toRow, toOK = <-toCh
+			default:
+				c, err := fromRow.values.Compare(toRow.values)
+				if err != nil {
+					return err
+				}
+				if c != 0 {
+					if err := emit(backupDiffUpdate, toRow.pk, fromRow.values, toRow.values); err != nil {
+						return err
+					}
+				}
+				fromRow, fromOK = <-fromCh
+				toRow, toOK = <-toCh
+			}
+		}
+		return nil
+	})
+	return g.Wait()
+}
+
+// scanBackupDiffRows reads the rows in span of the table from the backup, and
+// sends them to rowCh in primary key order. The primary key of each row is
+// built from the primary index's key columns, which must be among the columns
+// read; the values of the row are those of the columns which are stored.
+func scanBackupDiffRows(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	src backupTableSource,
+	span roachpb.Span,
+	rowCh chan<- backupDiffRow,
+) error {
+	var colMap catalog.TableColMap
+	for i, col := range src.cols {
+		colMap.Set(col.GetID(), i)
+	}
+	primaryIndex := src.table.GetPrimaryIndex()
+	keyPrefix := rowenc.MakeIndexKeyPrefix(src.codec, src.table.GetID(), primaryIndex.GetID())
+	keyCols := make([]catalog.Column, primaryIndex.NumKeyColumns())
+	for i := range keyCols {
+		col, err := catalog.MustFindColumnByID(src.table, primaryIndex.GetKeyColumnID(i))
+		if err != nil {
+			return err
+		}
+		if _, ok := colMap.Get(col.GetID()); !ok {
+			return errors.AssertionFailedf("primary key column %q is not read", col.GetName())
+		}
+		keyCols[i] = col
+	}
+	// Virtual key columns identify the row, but aren't among its values.
+	var valueCols []catalog.Column
+	for _, col := range src.cols {
+		if !col.IsVirtual() {
+			valueCols = append(valueCols, col)
+		}
+	}
+	sd := flowCtx.EvalCtx.SessionData()
+	toJSON := func(cols []catalog.Column, datums tree.Datums) (json.JSON, error) {
+		b := json.NewObjectBuilder(len(cols))
+		for _, col := range cols {
+			ord, _ := colMap.Get(col.GetID())
+			j, err := tree.AsJSON(datums[ord], sd.DataConversionConfig, sd.GetLocation())
+			if err != nil {
+				return nil, err
+			}
+			b.Add(col.GetName(), j)
+		}
+		return b.Build(), nil
+	}
+
+	return readBackupTableRows(ctx, flowCtx, src, span, func(datums tree.Datums) error {
+		key, _, err := rowenc.EncodeIndexKey(
+			src.table, primaryIndex, colMap, datums, slices.Clip(keyPrefix),
+		)
+		if err != nil {
+			return err
+		}
+		pk, err := toJSON(keyCols, datums)
+		if err != nil {
+			return err
+		}
+		values, err := toJSON(valueCols, datums)
+		if err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case rowCh <- backupDiffRow{key: key, pk: pk, values: values}:
+			return nil
+		}
+	})
+}
@@ -0,0 +1,515 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupdest"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupencryption"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupinfo"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuputils"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/besteffort"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+)
+
+// backupTable is a table in a backup chain, as of some time covered by the
+// chain, whose rows can be read directly out of the backup's SSTs.
+type backupTable struct {
+	// desc is the descriptor of the table in the backup, with the user-defined
+	// types of its columns hydrated from typeDescs.
+	desc      catalog.TableDescriptor
+	typeDescs []*descpb.TypeDescriptor
+	codec     keys.SQLCodec
+	endTime   hlc.Timestamp
+	// cols are the columns of desc returned by SHOW BACKUP TABLE, in the order
+	// they are returned.
+	cols []catalog.Column
+
+	manifests          []backuppb.BackupManifest
+	layerToIterFactory backupinfo.LayerToBackupManifestFileIterFactory
+	localityInfo       []jobspb.RestoreDetails_BackupLocalityInfo
+	encryption         *jobspb.BackupEncryptionOptions
+	kmsEnv             cloud.KMSEnv
+}
+
+// header returns the result columns of SHOW BACKUP TABLE for the table.
+func (t *backupTable) header() colinfo.ResultColumns {
+	header := make(colinfo.ResultColumns, len(t.cols))
+	for i, col := range t.cols {
+		header[i] = colinfo.ResultColumn{
+			Name:   col.GetName(),
+			Typ:    backupTableColumnType(col),
+			Hidden: col.IsHidden(),
+		}
+	}
+	return header
+}
+
+// resolveBackupTable resolves the backup chain identified by the collection
+// URIs and backup token, as it would be by RESTORE, and finds the table named
+// by the SHOW BACKUP TABLE statement in the chain as of the requested time.
+// The returned memory reservation must be released by the caller.
+func resolveBackupTable(
+	ctx context.Context,
+	p sql.PlanHookState,
+	mem *mon.BoundAccount,
+	stmt *tree.ShowBackup,
+	collectionURIs []string,
+	backupToken string,
+) (_ *backupTable, memReserved int64, err error) {
+	var endTime hlc.Timestamp
+	if stmt.AsOf.Expr != nil {
+		asOf, err := p.EvalAsOfTimestamp(ctx, stmt.AsOf)
+		if err != nil {
+			return nil, 0, err
+		}
+		endTime = asOf.Timestamp
+	}
+
+	defaultCollectionURI, _, err := backupdest.GetURIsByLocalityKV(collectionURIs, "")
+	if err != nil {
+		return nil, 0, err
+	}
+	subdir, endTime, err := resolveRestoreSubdirAndEndTime(
+		ctx, p, defaultCollectionURI, backupToken, endTime,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	baseDirs, err := backuputils.AppendPaths(collectionURIs, subdir)
+	if err != nil {
+		return nil, 0, err
+	}
+	incDirs, err := backupdest.ResolveIncrementalsBackupLocation(collectionURIs, subdir)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	mkStore := p.ExecCfg().DistSQLSrv.ExternalStorageFromURI
+	baseStores, cleanupFn, err := backupdest.MakeBackupDestinationStores(
+		ctx, p.User(), mkStore, baseDirs,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer besteffort.Cleanup(ctx, "close-base-stores", cleanupFn)
+
+	ioConf := baseStores[0].ExternalIOConf()
+	kmsEnv := backupencryption.MakeBackupKMSEnv(
+		p.ExecCfg().Settings, &ioConf, p.ExecCfg().InternalDB, p.User(),
+	)
+	encryption, err := backupencryption.ResolveEncryptionOptionsFromExpr(
+		ctx, p, p.ExprEvaluator("SHOW BACKUP"), baseStores[0],
+		stmt.Options.EncryptionPassphrase, tree.Exprs(stmt.Options.DecryptionKMSURI),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	includeCompacted := restoreCompactedBackups.Get(&p.ExecCfg().Settings.SV)
+	_, manifests, localityInfo, memReserved, err := backupdest.ResolveBackupManifests(
+		ctx, p.ExecCfg(), mem, defaultCollectionURI, collectionURIs, mkStore,
+		subdir, baseDirs, incDirs, endTime, encryption, &kmsEnv, p.User(),
+		false /* includeSkipped */, includeCompacted,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		// If there was an error after reading the manifests, release the memory.
+		if err != nil {
+			mem.Shrink(ctx, memReserved)
+		}
+	}()
+	if endTime.IsEmpty() {
+		endTime = manifests[len(manifests)-1].EndTime
+	}
+
+	layerToIterFactory, err := backupinfo.GetBackupManifestIterFactories(
+		ctx, p.ExecCfg().DistSQLSrv.ExternalStorage, manifests, encryption, &kmsEnv,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	codec, err := backupinfo.MakeBackupCodec(manifests)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pattern := stmt.Table.ToUnresolvedName()
+	allDescs, lastManifest, err := backupinfo.LoadSQLDescsFromBackupsAtTime(
+		ctx, manifests, layerToIterFactory, endTime,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	_, _, descsByTablePattern, _, _, err := selectTargetsFromDescs(
+		ctx, p, allDescs, lastManifest,
+		tree.BackupTargetList{Tables: tree.TableAttrs{TablePatterns: tree.TablePatterns{pattern}}},
+		tree.RequestedDescriptors, endTime,
+	)
+	if err != nil {
+		return nil, 0, errors.Wrap(err,
+			"failed to resolve the table in the backup, use SHOW BACKUP to find correct targets")
+	}
+	found, ok := descsByTablePattern[pattern].(catalog.TableDescriptor)
+	if !ok || !found.IsPhysicalTable() {
+		return nil, 0, pgerror.Newf(pgcode.WrongObjectType,
+			"%s is not a table in the backup", tree.ErrString(stmt.Table))
+	}
+	// The user-defined types of the table's columns are those of its database
+	// in the backup, which need not exist in the cluster.
+	var typeDescs []*descpb.TypeDescriptor
+	for _, d := range allDescs {
+		if typ, ok := d.(catalog.TypeDescriptor); ok && typ.GetParentID() == found.GetParentID() {
+			typeDescs = append(typeDescs, typ.TypeDesc())
+		}
+	}
+	desc, err := hydrateBackupTable(ctx, found.TableDesc(), typeDescs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var cols []catalog.Column
+	for _, col := range desc.PublicColumns() {
+		// Virtual columns are not stored, so they cannot be read from the backup.
+		if col.IsVirtual() {
+			continue
+		}
+		cols = append(cols, col)
+	}
+
+	return &backupTable{
+		desc:               desc,
+		typeDescs:          typeDescs,
+		codec:              codec,
+		endTime:            endTime,
+		cols:               cols,
+		manifests:          manifests,
+		layerToIterFactory: layerToIterFactory,
+		localityInfo:       localityInfo,
+		encryption:         encryption,
+		kmsEnv:             &kmsEnv,
+	}, memReserved, nil
+}
+
+// showBackupTableTypeCheck returns the header of a SHOW BACKUP TABLE statement.
+// Since the columns of the result are the columns of the table in the backup,
+// this must resolve the table from the backup.
+func showBackupTableTypeCheck(
+	ctx context.Context, stmt *tree.ShowBackup, p sql.PlanHookState,
+) (matched bool, header colinfo.ResultColumns, _ error) {
+	// The location of the backup must be known to determine the columns of
+	// the result.
+	for _, e := range append(tree.Exprs{stmt.Path}, stmt.InCollection...) {
+		if _, ok := e.(*tree.Placeholder); ok {
+			return false, nil, pgerror.New(pgcode.FeatureNotSupported,
+				"SHOW BACKUP TABLE does not support placeholders in the backup location")
+		}
+	}
+	exprEval := p.ExprEvaluator("SHOW BACKUP")
+	backupToken, err := exprEval.String(ctx, stmt.Path)
+	if err != nil {
+		return false, nil, err
+	}
+	dest, err := exprEval.StringArray(ctx, tree.Exprs(stmt.InCollection))
+	if err != nil {
+		return false, nil, err
+	}
+	header, err = showBackupTableHeader(ctx, p, stmt, dest, backupToken)
+	if err != nil {
+		return false, nil, err
+	}
+	return true, header, nil
+}
+
+func showBackupTableHeader(
+	ctx context.Context,
+	p sql.PlanHookState,
+	stmt *tree.ShowBackup,
+	dest []string,
+	backupToken string,
+) (colinfo.ResultColumns, error) {
+	if err := sql.CheckDestinationPrivileges(ctx, p, dest); err != nil {
+		return nil, err
+	}
+	mem := p.ExecCfg().RootMemoryMonitor.MakeBoundAccount()
+	defer mem.Close(ctx)
+	table, memReserved, err := resolveBackupTable(ctx, p, &mem, stmt, dest, backupToken)
+	if err != nil {
+		return nil, err
+	}
+	defer mem.Shrink(ctx, memReserved)
+	return table.header(), nil
+}
+
+// showBackupTablePlanHook plans a SHOW BACKUP TABLE statement, which returns
+// the rows of a table in a backup chain as of the end time of the chain, or as
+// of the AS OF SYSTEM TIME clause, without restoring the table. The rows are
+// read directly from the backup's SSTs which overlap the table's primary index,
+// by a backupTableReader on each SQL instance, each reading a part of the
+// primary index. The rows are therefore not returned in primary key order, and
+// filters on the result are applied after the whole table has been read.
+func showBackupTablePlanHook(
+	ctx context.Context,
+	stmt *tree.ShowBackup,
+	p sql.PlanHookState,
+	dest []string,
+	backupToken string,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, bool, error) {
+	if err := sql.CheckDestinationPrivileges(ctx, p, dest); err != nil {
+		return nil, nil, false, err
+	}
+	// The table is resolved from the backup once, while planning, since the
+	// header depends on it. The manifests are held until the plan is closed.
+	mem := p.ExecCfg().RootMemoryMonitor.MakeBoundAccount()
+	p.DeferPlanHookCleanup(mem.Close)
+	table, _, err := resolveBackupTable(ctx, p, &mem, stmt, dest, backupToken)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	fn := func(ctx context.Context, resultsCh chan<- tree.Datums) error {
+		ctx, span := tracing.ChildSpan(ctx, stmt.StatementTag())
+		defer span.Finish()
+
+		if err := readBackupTable(ctx, p, table, resultsCh); err != nil {
+			return err
+		}
+		telemetry.Count("show-backup.table")
+		return nil
+	}
+	return fn, table.header(), false, nil
+}
+
+// readBackupTable reads the rows of the table from the backup with a
+// backupTableReader on each SQL instance, and sends them to resultsCh.
+func readBackupTable(
+	ctx context.Context, p sql.PlanHookState, t *backupTable, resultsCh chan<- tree.Datums,
+) error {
+	dsp := p.DistSQLPlanner()
+	planCtx, instanceIDs, err := dsp.SetupAllNodesPlanning(ctx, p.ExtendedEvalContext(), p.ExecCfg())
+	if err != nil {
+		return err
+	}
+	indexSpan := t.desc.PrimaryIndexSpan(t.codec)
+	entries, err := t.restoreSpanEntries(ctx, p, indexSpan)
+	if err != nil {
+		return err
+	}
+	colIDs := make([]descpb.ColumnID, len(t.cols))
+	for i, col := range t.cols {
+		colIDs[i] = col.GetID()
+	}
+	backup, err := t.readerBackup(ctx, colIDs)
+	if err != nil {
+		return err
+	}
+
+	spans := partitionBackupTableSpan(indexSpan, entries, len(instanceIDs))
+	corePlacements := make([]physicalplan.ProcessorCorePlacement, len(spans))
+	for i, sp := range spans {
+		spec := execinfrapb.BackupTableReaderSpec{Span: sp, Backup: backup}
+		spec.Backup.Entries = overlappingRestoreSpanEntries(entries, sp)
+		corePlacements[i] = physicalplan.ProcessorCorePlacement{
+			SQLInstanceID: instanceIDs[i],
+			Core:          execinfrapb.ProcessorCoreUnion{BackupTableReader: &spec},
+		}
+	}
+	outTypes := make([]*types.T, len(t.cols))
+	for i, col := range t.cols {
+		outTypes[i] = backupTableColumnType(col)
+	}
+	return runBackupTableReaders(ctx, p, planCtx, corePlacements, outTypes, resultsCh)
+}
+
+// readerBackup returns the backup of the table from which a backupTableReader
+// reads the given columns, without its restore span entries.
+func (t *backupTable) readerBackup(
+	ctx context.Context, colIDs []descpb.ColumnID,
+) (execinfrapb.BackupTableReaderSpec_Backup, error) {
+	backup := execinfrapb.BackupTableReaderSpec_Backup{
+		Table:     *t.desc.TableDesc(),
+		Types:     t.typeDescs,
+		TenantID:  t.codec.TenantID,
+		ColumnIDs: colIDs,
+		EndTime:   t.endTime,
+	}
+	if t.encryption != nil {
+		key, err := backupencryption.GetEncryptionKey(ctx, t.encryption, t.kmsEnv)
+		if err != nil {
+			return execinfrapb.BackupTableReaderSpec_Backup{}, err
+		}
+		backup.Encryption = &kvpb.FileEncryptionOptions{Key: key}
+	}
+	return backup, nil
+}
+
+// restoreSpanEntries returns the restore span entries of the backup which
+// cover span, in key order. Only the files which overlap span are included.
+func (t *backupTable) restoreSpanEntries(
+	ctx context.Context, p sql.PlanHookState, span roachpb.Span,
+) ([]execinfrapb.RestoreSpanEntry, error) {
+	backupLocalityMap, err := makeBackupLocalityMap(t.localityInfo, p.User())
+	if err != nil {
+		return nil, err
+	}
+	introducedSpanFrontier, err := createIntroducedSpanFrontier(t.manifests, t.endTime)
+	if err != nil {
+		return nil, err
+	}
+	defer introducedSpanFrontier.Release()
+	sv := &p.ExecCfg().Settings.SV
+	requiredSpans := roachpb.Spans{span}
+	filter, err := makeSpanCoveringFilter(
+		requiredSpans,
+		[]jobspb.RestoreProgress_FrontierEntry{},
+		introducedSpanFrontier,
+		targetRestoreSpanSize.Get(sv),
+		maxFileCount.Get(sv),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer filter.close()
+
+	var entries []execinfrapb.RestoreSpanEntry
+	spanCh := make(chan execinfrapb.RestoreSpanEntry, 16)
+	g := ctxgroup.WithContext(ctx)
+	g.GoCtx(func(ctx context.Context) error {
+		defer close(spanCh)
+		return errors.Wrap(generateAndSendImportSpans(
+			ctx,
+			requiredSpans,
+			t.manifests,
+			t.layerToIterFactory,
+			backupLocalityMap,
+			filter,
+			&exclusiveEndKeyComparator{},
+			spanCh,
+			false, /* useLink */
+		), "generate and send import spans")
+	})
+	g.GoCtx(func(ctx context.Context) error {
+		for entry := range spanCh {
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// partitionBackupTableSpan splits span into at most n contiguous spans, each
+// covering about as many of the restore span entries, which must be in key
+// order, as the others.
+func partitionBackupTableSpan(
+	span roachpb.Span, entries []execinfrapb.RestoreSpanEntry, n int,
+) []roachpb.Span {
+	if n > len(entries) {
+		n = len(entries)
+	}
+	if n <= 1 {
+		return []roachpb.Span{span}
+	}
+	spans := make([]roachpb.Span, 0, n)
+	start := span.Key
+	for i := 1; i < n; i++ {
+		split := entries[i*len(entries)/n].Span.Key
+		if split.Compare(start) <= 0 || split.Compare(span.EndKey) >= 0 {
+			continue
+		}
+		spans = append(spans, roachpb.Span{Key: start, EndKey: split})
+		start = split
+	}
+	return append(spans, roachpb.Span{Key: start, EndKey: span.EndKey})
+}
+
+// overlappingRestoreSpanEntries returns the restore span entries, which must
+// be in key order, which overlap span.
+func overlappingRestoreSpanEntries(
+	entries []execinfrapb.RestoreSpanEntry, span roachpb.Span,
+) []execinfrapb.RestoreSpanEntry {
+	var res []execinfrapb.RestoreSpanEntry
+	for _, e := range entries {
+		if e.Span.Overlaps(span) {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// runBackupTableReaders runs a flow with the given backupTableReaders, which
+// return rows of outTypes, and sends their rows to resultsCh.
+func runBackupTableReaders(
+	ctx context.Context,
+	p sql.PlanHookState,
+	planCtx *sql.PlanningCtx,
+	corePlacements []physicalplan.ProcessorCorePlacement,
+	outTypes []*types.T,
+	resultsCh chan<- tree.Datums,
+) error {
+	plan := planCtx.NewPhysicalPlan()
+	plan.AddNoInputStage(
+		corePlacements,
+		execinfrapb.PostProcessSpec{},
+		outTypes,
+		execinfrapb.Ordering{},
+		nil, /* finalizeLastStageCb */
+	)
+	plan.PlanToStreamColMap = make([]int, len(outTypes))
+	for i := range plan.PlanToStreamColMap {
+		plan.PlanToStreamColMap[i] = i
+	}
+	sql.FinalizePlan(ctx, planCtx, plan)
+
+	rowWriter := sql.NewCallbackResultWriter(func(ctx context.Context, row tree.Datums) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case resultsCh <- append(tree.Datums(nil), row...):
+			return nil
+		}
+	})
+	recv := sql.MakeDistSQLReceiver(
+		ctx,
+		rowWriter,
+		tree.Rows,
+		nil, /* rangeCache */
+		nil, /* txn - the flow reads the backup, not the database */
+		nil, /* clockUpdater */
+		p.ExtendedEvalContext().Tracing,
+	)
+	defer recv.Release()
+
+	evalCtxCopy := p.ExtendedEvalContext().Copy()
+	p.DistSQLPlanner().Run(ctx, planCtx, nil /* txn */, plan, recv, evalCtxCopy, nil /* finishedSetupFn */)
+	return rowWriter.Err()
+}
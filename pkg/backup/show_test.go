@@ -25,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/security/securitytest"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/bootstrap"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/desctestutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/jobutils"
@@ -1415,3 +1416,224 @@ func TestShowBackupsSubqueryClose(t *testing.T) {
 		fmt.Sprintf(`SELECT * FROM [SHOW BACKUPS IN 'nodelocal://1/backup'] WHERE %d::TIMESTAMPTZ < backup_time`, ts),
 	)
 }
+
+func TestShowBackupTable(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	_, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, 0, InitManualReplication)
+	defer cleanupFn()
+
+	sqlDB.Exec(t, `CREATE TABLE data.t (k INT PRIMARY KEY, v STRING, w INT AS (k * 2) VIRTUAL, FAMILY (k), FAMILY (v))`)
+	sqlDB.Exec(t, `INSERT INTO data.t (k, v) VALUES (1, 'a'), (2, 'b'), (3, 'c')`)
+	sqlDB.Exec(t, `CREATE TABLE data.nopk (a INT)`)
+	sqlDB.Exec(t, `INSERT INTO data.nopk VALUES (1), (2)`)
+	sqlDB.Exec(t, `CREATE TYPE data.mood AS ENUM ('happy', 'sad')`)
+	sqlDB.Exec(t, `CREATE TABLE data.udt (k INT PRIMARY KEY, m data.mood, ms data.mood[])`)
+	sqlDB.Exec(t, `INSERT INTO data.udt VALUES (1, 'happy', ARRAY['sad', 'happy']), (2, NULL, NULL)`)
+	sqlDB.Exec(t, `BACKUP DATABASE data INTO $1 WITH revision_history`, localFoo)
+	beforeTS := sqlDB.QueryStr(t, `SELECT cluster_logical_timestamp()`)[0][0]
+	sqlDB.Exec(t, `UPDATE data.t SET v = 'z' WHERE k = 2`)
+	sqlDB.Exec(t, `DELETE FROM data.t WHERE k = 3`)
+	sqlDB.Exec(t, `INSERT INTO data.t (k, v) VALUES (4, 'd')`)
+	sqlDB.Exec(t, `BACKUP DATABASE data INTO LATEST IN $1 WITH revision_history`, localFoo)
+
+	// The table has only been backed up, so the live table must not be read.
+	sqlDB.Exec(t, `DELETE FROM data.t WHERE true`)
+
+	showTable := fmt.Sprintf(`SHOW BACKUP TABLE data.t FROM LATEST IN '%s'`, localFoo)
+	sqlDB.CheckQueryResults(t,
+		fmt.Sprintf(`SELECT * FROM [%s] ORDER BY k`, showTable),
+		[][]string{{"1", "a"}, {"2", "z"}, {"4", "d"}},
+	)
+	sqlDB.CheckQueryResults(t,
+		fmt.Sprintf(`SELECT v FROM [%s AS OF SYSTEM TIME %s] WHERE k > 1 ORDER BY k`, showTable, beforeTS),
+		[][]string{{"b"}, {"c"}},
+	)
+
+	// Hidden columns are returned, but not by SELECT *.
+	sqlDB.CheckQueryResults(t,
+		fmt.Sprintf(`SELECT count(rowid), sum(a) FROM [SHOW BACKUP TABLE data.nopk FROM LATEST IN '%s']`, localFoo),
+		[][]string{{"2", "3"}},
+	)
+	sqlDB.CheckQueryResults(t,
+		fmt.Sprintf(`SELECT * FROM [SHOW BACKUP TABLE data.nopk FROM LATEST IN '%s'] ORDER BY a`, localFoo),
+		[][]string{{"1"}, {"2"}},
+	)
+
+	// Values of user-defined types are returned as strings, since the types in
+	// the backup need not exist in the cluster.
+	sqlDB.Exec(t, `DROP TABLE data.udt`)
+	sqlDB.Exec(t, `DROP TYPE data.mood`)
+	sqlDB.CheckQueryResults(t,
+		fmt.Sprintf(`SELECT k, m, ms, pg_typeof(m) FROM [SHOW BACKUP TABLE data.udt FROM LATEST IN '%s'] ORDER BY k`, localFoo),
+		[][]string{{"1", "happy", "{sad,happy}", "text"}, {"2", "NULL", "NULL", "text"}},
+	)
+
+	// Planning without executing the statement releases the memory reserved
+	// for the manifests when the plan is closed.
+	sqlDB.Exec(t, fmt.Sprintf(`EXPLAIN SHOW BACKUP TABLE data.nopk FROM LATEST IN '%s'`, localFoo))
+
+	sqlDB.ExpectErr(t, `failed to resolve the table in the backup`,
+		fmt.Sprintf(`SHOW BACKUP TABLE data.missing FROM LATEST IN '%s'`, localFoo))
+	sqlDB.ExpectErr(t, `does not support placeholders`,
+		`SHOW BACKUP TABLE data.t FROM LATEST IN $1`, localFoo)
+}
+
+// TestShowBackupTableDistributed reads a table whose backup has many restore
+// spans, which are split between the nodes of the cluster.
+func TestShowBackupTableDistributed(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numAccounts = 1000
+	_, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, multiNode, numAccounts, InitManualReplication)
+	defer cleanupFn()
+
+	sqlDB.Exec(t, `ALTER TABLE data.bank SPLIT AT SELECT generate_series(100, 900, 100)`)
+	sqlDB.Exec(t, `ALTER TABLE data.bank SCATTER`)
+	sqlDB.Exec(t, `BACKUP DATABASE data INTO $1`, localFoo)
+	sqlDB.Exec(t, `SET CLUSTER SETTING backup.restore_span.target_size = '1B'`)
+
+	expected := sqlDB.QueryStr(t, `SELECT count(*), sum(id), sum(balance) FROM data.bank`)
+	sqlDB.CheckQueryResults(t,
+		fmt.Sprintf(`SELECT count(*), sum(id), sum(balance) FROM [SHOW BACKUP TABLE data.bank FROM LATEST IN '%s']`, localFoo),
+		expected,
+	)
+}
+
+func TestPartitionBackupTableSpan(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	span := roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("z")}
+	entries := func(keys ...string) []execinfrapb.RestoreSpanEntry {
+		var res []execinfrapb.RestoreSpanEntry
+		for i, k := range keys {
+			end := "z"
+			if i+1 < len(keys) {
+				end = keys[i+1]
+			}
+			res = append(res, execinfrapb.RestoreSpanEntry{
+				Span: roachpb.Span{Key: roachpb.Key(k), EndKey: roachpb.Key(end)},
+			})
+		}
+		return res
+	}
+	spanStrs := func(spans []roachpb.Span) []string {
+		var res []string
+		for _, sp := range spans {
+			res = append(res, string(sp.Key)+"-"+string(sp.EndKey))
+		}
+		return res
+	}
+
+	// The span is split at the start keys of the entries, into at most as many
+	// spans as there are entries.
+	e := entries("a", "c", "e", "g")
+	require.Equal(t, []string{"a-z"}, spanStrs(partitionBackupTableSpan(span, e, 1)))
+	require.Equal(t, []string{"a-e", "e-z"}, spanStrs(partitionBackupTableSpan(span, e, 2)))
+	require.Equal(t, []string{"a-c", "c-e", "e-g", "g-z"}, spanStrs(partitionBackupTableSpan(span, e, 8)))
+	require.Equal(t, []string{"a-z"}, spanStrs(partitionBackupTableSpan(span, nil, 3)))
+
+	// Every entry overlapping a span is read for it.
+	require.Len(t, overlappingRestoreSpanEntries(e, roachpb.Span{Key: roachpb.Key("b"), EndKey: roachpb.Key("f")}), 3)
+}
+
+func TestShowBackupDiff(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	_, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, 0, InitManualReplication)
+	defer cleanupFn()
+	defer setUseBackupsWithIDs(t, sqlDB, true)()
+
+	sqlDB.Exec(t, `CREATE TABLE data.t (k INT PRIMARY KEY, v STRING)`)
+	sqlDB.Exec(t, `INSERT INTO data.t VALUES (1, 'a'), (2, 'b'), (3, 'c')`)
+	sqlDB.Exec(t, `BACKUP DATABASE data INTO $1`, localFoo)
+	sqlDB.Exec(t, `UPDATE data.t SET v = 'z' WHERE k = 2`)
+	sqlDB.Exec(t, `DELETE FROM data.t WHERE k = 3`)
+	sqlDB.Exec(t, `INSERT INTO data.t VALUES (4, 'd')`)
+	sqlDB.Exec(t, `BACKUP DATABASE data INTO LATEST IN $1`, localFoo)
+
+	// Backup IDs are sorted in descending chronological order.
+	backupIDs := sqlDB.QueryStr(t, fmt.Sprintf(`SELECT id FROM [SHOW BACKUPS IN '%s']`, localFoo))
+	require.Len(t, backupIDs, 2)
+	to, from := backupIDs[0][0], backupIDs[1][0]
+
+	showDiff := func(from, to string) string {
+		return fmt.Sprintf(`SELECT change_type, primary_key, old_row, new_row
+FROM [SHOW BACKUP DIFF TABLE data.t FROM '%s' TO '%s' IN '%s']
+ORDER BY (primary_key->>'k')::INT`, from, to, localFoo)
+	}
+	sqlDB.CheckQueryResults(t, showDiff(from, to), [][]string{
+		{"update", `{"k": 2}`, `{"k": 2, "v": "b"}`, `{"k": 2, "v": "z"}`},
+		{"delete", `{"k": 3}`, `{"k": 3, "v": "c"}`, "NULL"},
+		{"insert", `{"k": 4}`, "NULL", `{"k": 4, "v": "d"}`},
+	})
+	sqlDB.CheckQueryResults(t, showDiff(to, to), [][]string{})
+}
+
+// TestShowBackupDiffHashShardedPrimaryKey checks that the primary keys of the
+// rows of a table whose primary key has a virtual column include it.
+func TestShowBackupDiffHashShardedPrimaryKey(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	_, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, 0, InitManualReplication)
+	defer cleanupFn()
+	defer setUseBackupsWithIDs(t, sqlDB, true)()
+
+	sqlDB.Exec(t, `CREATE TABLE data.t (k INT PRIMARY KEY USING HASH WITH (bucket_count = 4), v STRING)`)
+	sqlDB.Exec(t, `INSERT INTO data.t SELECT i, 'a' FROM generate_series(1, 20) AS g(i)`)
+	sqlDB.Exec(t, `BACKUP DATABASE data INTO $1`, localFoo)
+	sqlDB.Exec(t, `UPDATE data.t SET v = 'b' WHERE k IN (2, 7)`)
+	sqlDB.Exec(t, `DELETE FROM data.t WHERE k = 11`)
+	sqlDB.Exec(t, `INSERT INTO data.t VALUES (21, 'c')`)
+	sqlDB.Exec(t, `BACKUP DATABASE data INTO LATEST IN $1`, localFoo)
+
+	backupIDs := sqlDB.QueryStr(t, fmt.Sprintf(`SELECT id FROM [SHOW BACKUPS IN '%s']`, localFoo))
+	require.Len(t, backupIDs, 2)
+	to, from := backupIDs[0][0], backupIDs[1][0]
+
+	sqlDB.CheckQueryResults(t, fmt.Sprintf(`SELECT change_type, primary_key->'k',
+  primary_key ? 'crdb_internal_k_shard_4', old_row, new_row
+FROM [SHOW BACKUP DIFF TABLE data.t FROM '%s' TO '%s' IN '%s']
+ORDER BY (primary_key->>'k')::INT`, from, to, localFoo), [][]string{
+		{"update", "2", "true", `{"k": 2, "v": "a"}`, `{"k": 2, "v": "b"}`},
+		{"update", "7", "true", `{"k": 7, "v": "a"}`, `{"k": 7, "v": "b"}`},
+		{"delete", "11", "true", `{"k": 11, "v": "a"}`, "NULL"},
+		{"insert", "21", "true", "NULL", `{"k": 21, "v": "c"}`},
+	})
+}
+
+func TestShowBackupDiffDistributed(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numAccounts = 1000
+	_, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, multiNode, numAccounts, InitManualReplication)
+	defer cleanupFn()
+	defer setUseBackupsWithIDs(t, sqlDB, true)()
+
+	sqlDB.Exec(t, `ALTER TABLE data.bank SPLIT AT SELECT generate_series(100, 900, 100)`)
+	sqlDB.Exec(t, `ALTER TABLE data.bank SCATTER`)
+	sqlDB.Exec(t, `BACKUP DATABASE data INTO $1`, localFoo)
+	sqlDB.Exec(t, `UPDATE data.bank SET balance = balance + 1 WHERE id % 10 = 0`)
+	sqlDB.Exec(t, `DELETE FROM data.bank WHERE id % 100 = 5`)
+	sqlDB.Exec(t, `INSERT INTO data.bank SELECT i, 0, '' FROM generate_series(1000, 1049) AS g(i)`)
+	sqlDB.Exec(t, `BACKUP DATABASE data INTO LATEST IN $1`, localFoo)
+	sqlDB.Exec(t, `SET CLUSTER SETTING backup.restore_span.target_size = '1B'`)
+
+	backupIDs := sqlDB.QueryStr(t, fmt.Sprintf(`SELECT id FROM [SHOW BACKUPS IN '%s']`, localFoo))
+	require.Len(t, backupIDs, 2)
+	to, from := backupIDs[0][0], backupIDs[1][0]
+
+	sqlDB.CheckQueryResults(t, fmt.Sprintf(`SELECT change_type, count(*), count(DISTINCT primary_key)
+FROM [SHOW BACKUP DIFF TABLE data.bank FROM '%s' TO '%s' IN '%s']
+GROUP BY change_type ORDER BY change_type`, from, to, localFoo), [][]string{
+		{"delete", "10", "10"},
+		{"insert", "50", "50"},
+		{"update", "100", "100"},
+	})
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupinfo"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupsign"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSignedBackup takes a signed backup chain and checks that tampering with
+// its files, or with its signatures, is detected by SHOW BACKUP WITH
+// check_files and by RESTORE.
+func TestSignedBackup(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numAccounts = 10
+	_, sqlDB, dir, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, InitManualReplication)
+	defer cleanupFn()
+
+	kmsURIs := constructMockKMSURIsWithKeyID([]string{"signing", "other"})
+	signingKMS, otherKMS := kmsURIs[0], kmsURIs[1]
+	// The test KMS wraps keys by appending its key ID to them.
+	wrapKey := func(b byte) string {
+		return base64.StdEncoding.EncodeToString(
+			append(bytes.Repeat([]byte{b}, backupsign.MinSigningKeySize), "signing"...),
+		)
+	}
+	signingKey, otherKey := wrapKey(1), wrapKey(2)
+	const collection = "nodelocal://1/signed"
+
+	sqlDB.ExpectErr(t, "signing_kms and signing_key must be specified together",
+		`BACKUP TABLE data.bank INTO $1 WITH signing_kms = $2`, collection, signingKMS)
+	sqlDB.Exec(t, `BACKUP TABLE data.bank INTO $1 WITH signing_kms = $2, signing_key = $3`,
+		collection, signingKMS, signingKey)
+	sqlDB.Exec(t, `UPDATE data.bank SET balance = balance + 1`)
+	sqlDB.ExpectErr(t, "previous backup in the chain is signed",
+		`BACKUP TABLE data.bank INTO LATEST IN $1`, collection)
+	sqlDB.Exec(t, `BACKUP TABLE data.bank INTO LATEST IN $1 WITH signing_kms = $2, signing_key = $3`,
+		collection, signingKMS, signingKey)
+	expected := sqlDB.QueryStr(t, `SELECT * FROM data.bank ORDER BY id`)
+
+	sqlDB.Exec(t, `BACKUP TABLE data.bank INTO $1`, "nodelocal://1/unsigned")
+	sqlDB.ExpectErr(t, "cannot sign an incremental backup on top of an unsigned backup",
+		`BACKUP TABLE data.bank INTO LATEST IN $1 WITH signing_kms = $2, signing_key = $3`,
+		"nodelocal://1/unsigned", signingKMS, signingKey)
+
+	sqlDB.Exec(t, `SHOW BACKUP LATEST IN $1 WITH check_files, signing_kms = $2, signing_key = $3`,
+		collection, signingKMS, signingKey)
+	sqlDB.ExpectErr(t, "backup is signed",
+		`SHOW BACKUP LATEST IN $1 WITH check_files`, collection)
+	sqlDB.ExpectErr(t, "signing_kms and signing_key can only be used with check_files",
+		`SHOW BACKUP LATEST IN $1 WITH signing_kms = $2, signing_key = $3`, collection, signingKMS, signingKey)
+
+	sqlDB.Exec(t, `CREATE DATABASE verified`)
+	sqlDB.Exec(t, `RESTORE TABLE data.bank FROM LATEST IN $1 WITH into_db = 'verified', signing_kms = $2, signing_key = $3`,
+		collection, signingKMS, signingKey)
+	sqlDB.CheckQueryResults(t, `SELECT * FROM verified.bank ORDER BY id`, expected)
+	sqlDB.ExpectErr(t, "backup is signed",
+		`RESTORE TABLE data.bank FROM LATEST IN $1 WITH into_db = 'verified'`, collection)
+	sqlDB.ExpectErr(t, `signed with KMS key "signing"`,
+		`RESTORE TABLE data.bank FROM LATEST IN $1 WITH into_db = 'verified', signing_kms = $2, signing_key = $3`,
+		collection, otherKMS, signingKey)
+	// A key wrapped by the signing KMS, but other than the one the backup was
+	// signed with, is rejected.
+	sqlDB.ExpectErr(t, "signature was not produced with the given signing key",
+		`RESTORE TABLE data.bank FROM LATEST IN $1 WITH into_db = 'verified', signing_kms = $2, signing_key = $3`,
+		collection, signingKMS, otherKey)
+
+	// findFile returns the path of a file of the incremental backup whose name
+	// satisfies match.
+	findFile := func(match func(path string) bool) string {
+		var found string
+		require.NoError(t, filepath.WalkDir(filepath.Join(dir, "signed", "incrementals"),
+			func(path string, d fs.DirEntry, err error) error {
+				if err == nil && !d.IsDir() && found == "" && match(path) {
+					found = path
+				}
+				return err
+			}))
+		require.NotEmpty(t, found)
+		return found
+	}
+	// tamper overwrites the file at path with contents and returns a function
+	// restoring it.
+	tamper := func(path string, contents func([]byte) []byte) func() {
+		orig, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, contents(append([]byte(nil), orig...)), 0644))
+		return func() { require.NoError(t, os.WriteFile(path, orig, 0644)) }
+	}
+
+	dataFile := findFile(func(path string) bool { return strings.HasSuffix(path, ".sst") && strings.Contains(path, "/data/") })
+	undo := tamper(dataFile, func(b []byte) []byte { b[len(b)-1] ^= 1; return b })
+	sqlDB.ExpectErr(t, "signed file data/.* has been modified",
+		`SHOW BACKUP LATEST IN $1 WITH check_files, signing_kms = $2, signing_key = $3`,
+		collection, signingKMS, signingKey)
+	// The data files are not read when the signatures are verified, but by the
+	// restore data processors, which check them against their signed digests.
+	sqlDB.Exec(t, `CREATE DATABASE tampered`)
+	sqlDB.ExpectErr(t, "verifying signed backup: signed file data/.* has been modified",
+		`RESTORE TABLE data.bank FROM LATEST IN $1 WITH into_db = 'tampered', signing_kms = $2, signing_key = $3`,
+		collection, signingKMS, signingKey)
+	undo()
+
+	statsFile := findFile(func(path string) bool {
+		return filepath.Base(path) == backupinfo.BackupStatisticsFileName
+	})
+	undo = tamper(statsFile, func(b []byte) []byte { return append(b, 0) })
+	sqlDB.ExpectErr(t, "signed file BACKUP-STATISTICS has been modified",
+		`RESTORE TABLE data.bank FROM LATEST IN $1 WITH into_db = 'verified', signing_kms = $2, signing_key = $3`,
+		collection, signingKMS, signingKey)
+	undo()
+
+	sigFile := findFile(func(path string) bool { return filepath.Base(path) == backupsign.SignatureFileName })
+	undo = tamper(sigFile, func(b []byte) []byte { b[len(b)-1] ^= 1; return b })
+	sqlDB.ExpectErr(t, "invalid BACKUP-SIGNATURE",
+		`RESTORE TABLE data.bank FROM LATEST IN $1 WITH into_db = 'verified', signing_kms = $2, signing_key = $3`,
+		collection, signingKMS, signingKey)
+	undo()
+	require.NoError(t, os.Remove(sigFile))
+	sqlDB.ExpectErr(t, "BACKUP-SIGNATURE is missing",
+		`RESTORE TABLE data.bank FROM LATEST IN $1 WITH into_db = 'verified', signing_kms = $2, signing_key = $3`,
+		collection, signingKMS, signingKey)
+}
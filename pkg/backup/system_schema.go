@@ -977,6 +977,9 @@ var systemTableBackupConfiguration = map[string]systemBackupConfiguration{
 		shouldIncludeInClusterBackup: optInToClusterBackup,
 		customRestoreFunc:            statementsRestoreFunc,
 	},
+	systemschema.ResourceGroupsTable.GetName(): {
+		shouldIncludeInClusterBackup: optInToClusterBackup, // No desc ID columns.
+	},
 }
 
 func rekeySystemTable(
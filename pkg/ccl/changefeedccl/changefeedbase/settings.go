@@ -244,6 +244,15 @@ var BulkDelivery = settings.RegisterBoolSetting(
 		"if false, rangefeed events are delivered individually",
 	metamorphic.ConstantWithTestBool("changefeed.bulk_delivery.enabled", true))
 
+// SharedRangefeedsEnabled enables sharing rangefeeds between the changefeeds
+// running on a node which watch the same spans with compatible options.
+var SharedRangefeedsEnabled = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"changefeed.shared_rangefeeds.enabled",
+	"if true, changefeeds on a node which watch the same spans with the same "+
+		"options share a single rangefeed and catch-up scan",
+	metamorphic.ConstantWithTestBool("changefeed.shared_rangefeeds.enabled", false))
+
 // MaxProtectedTimestampAge controls the frequency of protected timestamp record updates
 var MaxProtectedTimestampAge = settings.RegisterDurationSetting(
 	settings.ApplicationLevel,
@@ -160,6 +160,7 @@ func Run(ctx context.Context, cfg Config) (retErr error) {
 		cfg.SchemaFeed,
 		sc, pff, bf, cfg.Targets, cfg.ScopedTimers, cfg.Knobs)
 	f.onBackfillCallback = cfg.MonitoringCfg.OnBackfillCallback
+	f.withSharing = changefeedbase.SharedRangefeedsEnabled.Get(&cfg.Settings.SV)
 
 	g.GoCtx(cfg.SchemaFeed.Run)
 	g.GoCtx(f.run)
@@ -223,6 +224,7 @@ type kvFeed struct {
 	withFiltering        bool
 	withInitialBackfill  bool
 	withBulkDelivery     bool
+	withSharing          bool
 	consumerID           int64
 	initialHighWater     hlc.Timestamp
 	initialSpanTimePairs []kvcoord.SpanTimePair
@@ -580,6 +582,7 @@ func (f *kvFeed) runUntilTableEvent(ctx context.Context, resumeFrontier span.Fro
 		WithFiltering:        f.withFiltering,
 		WithFrontierQuantize: f.withFrontierQuantize,
 		WithBulkDelivery:     f.withBulkDelivery,
+		WithSharing:          f.withSharing,
 		ConsumerID:           f.consumerID,
 		Knobs:                f.knobs,
 		Timers:               f.timers,
@@ -35,6 +35,7 @@ type rangeFeedConfig struct {
 	WithFiltering        bool
 	WithFrontierQuantize time.Duration
 	WithBulkDelivery     bool
+	WithSharing          bool
 	ConsumerID           int64
 	RangeObserver        kvcoord.RangeObserver
 	Knobs                TestingKnobs
@@ -114,6 +115,11 @@ func (p rangefeedFactory) Run(ctx context.Context, sink kvevent.Writer, cfg rang
 	if cfg.ConsumerID != 0 {
 		rfOpts = append(rfOpts, kvcoord.WithConsumerID(cfg.ConsumerID))
 	}
+	// Sharing a rangefeed with the other changefeeds on the node which watch
+	// the same spans saves the catch-up scan and the server-side registrations.
+	if cfg.WithSharing {
+		rfOpts = append(rfOpts, kvcoord.WithSharing())
+	}
 	if len(cfg.Knobs.RangefeedOptions) != 0 {
 		rfOpts = append(rfOpts, cfg.Knobs.RangefeedOptions...)
 	}
@@ -327,6 +327,9 @@ func (w *Watcher) Start(ctx context.Context, initialTS hlc.Timestamp) (retErr er
 		rangefeed.WithConsumerID(mkConsumerID(w.id)),
 		rangefeed.WithInvoker(func(fn func() error) error { return fn() }),
 		rangefeed.WithFiltering(false),
+		// The watchers of all the changefeeds on the node watch the namespace
+		// table, so they can share a rangefeed.
+		rangefeed.WithSharing(changefeedbase.SharedRangefeedsEnabled.Get(&w.execCfg.Settings.SV)),
 	}
 
 	// Start rangefeed.
@@ -1473,6 +1473,24 @@ func init() {
 
 	DebugCmd.AddCommand(debugJobTraceFromClusterCmd)
 	DebugCmd.AddCommand(debugJobCleanupInfoRows)
+
+	debugRevlogCmd.AddCommand(debugRevlogTicksCmd, debugRevlogValidateCmd, debugRevlogGapsCmd, debugRevlogEventsCmd)
+	DebugCmd.AddCommand(debugRevlogCmd)
+	for _, c := range []*cobra.Command{debugRevlogTicksCmd, debugRevlogEventsCmd} {
+		f := c.Flags()
+		f.StringVar(&debugRevlogOpts.startTime, "start-time", "",
+			"exclusive start of the time window, as an HLC decimal or RFC 3339 time")
+		f.StringVar(&debugRevlogOpts.endTime, "end-time", "",
+			"inclusive end of the time window, as an HLC decimal or RFC 3339 time; defaults to now")
+	}
+	for _, c := range []*cobra.Command{debugRevlogTicksCmd, debugRevlogValidateCmd, debugRevlogGapsCmd} {
+		c.Flags().BoolVar(&debugRevlogOpts.verifyData, "verify-data", false,
+			"read and decode every data file listed by a closed tick")
+	}
+	f = debugRevlogEventsCmd.Flags()
+	cliflagcfg.VarFlag(f, (*mvccKey)(&debugCtx.startKey), cliflags.From)
+	cliflagcfg.VarFlag(f, (*mvccKey)(&debugCtx.endKey), cliflags.To)
+	cliflagcfg.IntFlag(f, &debugCtx.maxResults, cliflags.Limit)
 	f = debugJobCleanupInfoRows.PersistentFlags()
 	f.IntVar(&jobCleanupInfoRowOpts.PageSize, "page-size", jobCleanupInfoRowOpts.PageSize,
 		"number of deletes to perform per query",
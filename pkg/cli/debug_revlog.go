@@ -0,0 +1,300 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/cli/clierrorplus"
+	"github.com/cockroachdb/cockroach/pkg/cli/clisqlexec"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/cloud/nodelocal"
+	"github.com/cockroachdb/cockroach/pkg/revlog"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+)
+
+var debugRevlogCmd = &cobra.Command{
+	Use:   "revlog [command]",
+	Short: "inspect the revision log of a backup collection",
+	Long: `
+Commands for inspecting the revision log (log/) written under a backup
+collection. Each command takes the collection root, given either as a
+local directory or as an external storage URI, e.g.
+
+  cockroach debug revlog ticks /mnt/backups/collection
+  cockroach debug revlog validate 's3://bucket/collection?AUTH=implicit'
+`,
+	RunE: UsageAndErr,
+}
+
+var debugRevlogTicksCmd = &cobra.Command{
+	Use:   "ticks <collection>",
+	Short: "list the closed ticks of a revision log and their coverage",
+	Args:  cobra.ExactArgs(1),
+	RunE:  clierrorplus.MaybeDecorateError(runDebugRevlogTicks),
+}
+
+var debugRevlogValidateCmd = &cobra.Command{
+	Use:   "validate <collection>",
+	Short: "check a revision log for framing and manifest invariant violations",
+	Long: `
+Checks every close marker, data file, coverage epoch and descriptor change of
+a revision log against the invariants of the log format, and lists the
+problems found. The command fails if there are any.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: clierrorplus.MaybeDecorateError(runDebugRevlogValidate),
+}
+
+var debugRevlogGapsCmd = &cobra.Command{
+	Use:   "gaps <collection>",
+	Short: "report gaps between ticks and late-arriving or orphaned data files",
+	Long: `
+Reports the gaps and overlaps between consecutive closed ticks, data files
+written after their tick was closed, data files of ticks that were never
+closed, and the ticks that are still open.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: clierrorplus.MaybeDecorateError(runDebugRevlogGaps),
+}
+
+var debugRevlogEventsCmd = &cobra.Command{
+	Use:   "events <collection>",
+	Short: "decode the events of a revision log in a key span and time window",
+	Long: `
+Decodes the events of the closed ticks that overlap the time window
+(--start-time, --end-time], restricted to the key span [--from, --to).
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: clierrorplus.MaybeDecorateError(runDebugRevlogEvents),
+}
+
+var debugRevlogOpts = struct {
+	startTime, endTime string
+	verifyData         bool
+}{}
+
+// openRevlogStorage opens the collection root named by a debug revlog
+// argument: either an external storage URI or a local directory.
+func openRevlogStorage(ctx context.Context, dest string) (cloud.ExternalStorage, error) {
+	if !strings.Contains(dest, "://") {
+		return nodelocal.MakeLocalDirStorage(dest, cluster.MakeClusterSettings())
+	}
+	return cloud.ExternalStorageFromURI(
+		ctx,
+		dest,
+		base.ExternalIODirConfig{},
+		cluster.MakeClusterSettings(),
+		nil, /* blobClientFactory: */
+		username.RootUserName(),
+		nil, /* db */
+		nil, /* limiters */
+		cloud.NilMetrics,
+	)
+}
+
+// parseRevlogTime parses a --start-time or --end-time flag, given either
+// as an HLC decimal (as printed by cluster_logical_timestamp()) or as an
+// RFC 3339 time.
+func parseRevlogTime(s string, def hlc.Timestamp) (hlc.Timestamp, error) {
+	if s == "" {
+		return def, nil
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return hlc.ParseHLC(s)
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return hlc.Timestamp{}, errors.Wrapf(err, "parsing %q as an HLC decimal or RFC 3339 time", s)
+	}
+	return hlc.Timestamp{WallTime: t.UnixNano()}, nil
+}
+
+// revlogTimeWindow returns the (start, end] window selected by the time
+// flags. By default it spans the whole log up to now.
+func revlogTimeWindow() (start, end hlc.Timestamp, _ error) {
+	start, err := parseRevlogTime(debugRevlogOpts.startTime, hlc.Timestamp{})
+	if err != nil {
+		return hlc.Timestamp{}, hlc.Timestamp{}, err
+	}
+	end, err = parseRevlogTime(debugRevlogOpts.endTime, hlc.Timestamp{WallTime: timeutil.Now().UnixNano()})
+	if err != nil {
+		return hlc.Timestamp{}, hlc.Timestamp{}, err
+	}
+	if !start.Less(end) {
+		return hlc.Timestamp{}, hlc.Timestamp{}, errors.Newf(
+			"--start-time %s must be before --end-time %s", start, end)
+	}
+	return start, end, nil
+}
+
+func inspectRevlog(ctx context.Context, dest string) (revlog.Report, error) {
+	es, err := openRevlogStorage(ctx, dest)
+	if err != nil {
+		return revlog.Report{}, err
+	}
+	defer es.Close()
+	return revlog.Inspect(ctx, es, revlog.InspectOptions{VerifyData: debugRevlogOpts.verifyData})
+}
+
+func runDebugRevlogTicks(_ *cobra.Command, args []string) error {
+	ctx := context.Background()
+	start, end, err := revlogTimeWindow()
+	if err != nil {
+		return err
+	}
+	r, err := inspectRevlog(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"tick", "tick_start", "tick_end", "files", "present", "inline", "keys", "coverage"}
+	var rows [][]string
+	for _, t := range r.Ticks {
+		if t.Err != nil {
+			if start.Less(t.EndTime) && t.EndTime.LessEq(end) {
+				rows = append(rows, []string{
+					revlog.FormatTickEnd(t.EndTime), "", t.EndTime.String(), "", strconv.Itoa(len(t.DataFiles)),
+					"", "", fmt.Sprintf("error: %v", t.Err),
+				})
+			}
+			continue
+		}
+		m := &t.Manifest
+		if !m.TickStart.Less(end) || !start.Less(m.TickEnd) {
+			continue
+		}
+		coverage := "none"
+		if t.Coverage != nil {
+			coverage = fmt.Sprintf("%s (%d spans, from %s)", t.Coverage.Scope, len(t.Coverage.Spans), t.Coverage.EffectiveFrom)
+		}
+		rows = append(rows, []string{
+			revlog.FormatTickEnd(t.EndTime),
+			m.TickStart.String(),
+			m.TickEnd.String(),
+			strconv.Itoa(len(m.Files)),
+			strconv.Itoa(len(t.DataFiles)),
+			strconv.Itoa(len(m.InlineTail)),
+			strconv.FormatInt(m.Stats.KeyCount, 10),
+			coverage,
+		})
+	}
+	return sqlExecCtx.PrintQueryOutput(os.Stdout, stderr, headers, clisqlexec.NewRowSliceIter(rows, "lllrrrrl"))
+}
+
+func printRevlogProblems(problems []revlog.Problem) error {
+	headers := []string{"kind", "path", "problem"}
+	rows := make([][]string, 0, len(problems))
+	for _, p := range problems {
+		rows = append(rows, []string{string(p.Kind), p.Path, p.Msg})
+	}
+	return sqlExecCtx.PrintQueryOutput(os.Stdout, stderr, headers, clisqlexec.NewRowSliceIter(rows, "lll"))
+}
+
+func runDebugRevlogValidate(_ *cobra.Command, args []string) error {
+	ctx := context.Background()
+	r, err := inspectRevlog(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	if err := printRevlogProblems(r.Problems); err != nil {
+		return err
+	}
+	fmt.Fprintf(stderr, "checked %d ticks, %d open ticks, %d coverage epochs, %d descriptor changes\n",
+		len(r.Ticks), len(r.OpenTicks), len(r.Coverage), r.SchemaChanges)
+	if len(r.Problems) > 0 {
+		return errors.Newf("found %d problems", len(r.Problems))
+	}
+	return nil
+}
+
+func runDebugRevlogGaps(_ *cobra.Command, args []string) error {
+	ctx := context.Background()
+	r, err := inspectRevlog(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	var problems []revlog.Problem
+	for _, p := range r.Problems {
+		switch p.Kind {
+		case revlog.ProblemGap, revlog.ProblemOverlap, revlog.ProblemMissingFile,
+			revlog.ProblemLateFile, revlog.ProblemOrphanTick:
+			problems = append(problems, p)
+		}
+	}
+	// Open ticks are not problems, but a restore cannot read past the
+	// first of them, so they are listed too.
+	for _, t := range r.OpenTicks {
+		problems = append(problems, revlog.Problem{
+			Kind: "open-tick",
+			Path: revlog.DataDirPath(t.EndTime),
+			Msg:  fmt.Sprintf("%d data files, not yet closed", len(t.DataFiles)),
+		})
+	}
+	return printRevlogProblems(problems)
+}
+
+func runDebugRevlogEvents(_ *cobra.Command, args []string) error {
+	ctx := context.Background()
+	start, end, err := revlogTimeWindow()
+	if err != nil {
+		return err
+	}
+	var spans []roachpb.Span
+	if len(debugCtx.startKey.Key) > 0 || len(debugCtx.endKey.Key) > 0 {
+		span := roachpb.Span{Key: debugCtx.startKey.Key, EndKey: debugCtx.endKey.Key}
+		if len(span.EndKey) == 0 {
+			span.EndKey = roachpb.KeyMax
+		}
+		spans = []roachpb.Span{span}
+	}
+
+	es, err := openRevlogStorage(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer es.Close()
+
+	headers := []string{"key", "timestamp", "value", "prev_value"}
+	var rows [][]string
+	lr := revlog.NewLogReader(es)
+ticks:
+	for t, err := range lr.Ticks(ctx, start, end) {
+		if err != nil {
+			return err
+		}
+		for ev, err := range lr.GetTickReader(ctx, t, spans).Events(ctx) {
+			if err != nil {
+				return err
+			}
+			// Ticks at the edges of the window can hold events outside it.
+			if ev.Timestamp.LessEq(start) || end.Less(ev.Timestamp) {
+				continue
+			}
+			prev := ""
+			if len(ev.PrevValue.RawBytes) > 0 {
+				prev = ev.PrevValue.PrettyPrint()
+			}
+			rows = append(rows, []string{ev.Key.String(), ev.Timestamp.String(), ev.Value.PrettyPrint(), prev})
+			if debugCtx.maxResults > 0 && len(rows) >= debugCtx.maxResults {
+				break ticks
+			}
+		}
+	}
+	return sqlExecCtx.PrintQueryOutput(os.Stdout, stderr, headers, clisqlexec.NewRowSliceIter(rows, "llll"))
+}
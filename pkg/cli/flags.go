@@ -951,6 +951,10 @@ func init() {
 			debugListFilesCmd,
 			debugJobTraceFromClusterCmd,
 			debugZipCmd,
+			debugRevlogTicksCmd,
+			debugRevlogValidateCmd,
+			debugRevlogGapsCmd,
+			debugRevlogEventsCmd,
 		},
 		demoCmd.Commands()...)
 	tableOutputCommands = append(tableOutputCommands, nodeCmds...)
@@ -1536,6 +1536,15 @@ var zipSystemTables = DebugZipTableRegistry{
 			"generated",
 		},
 	},
+	"system.resource_groups": {
+		nonSensitiveCols: NonSensitiveColumns{
+			"id",
+			"cpu_weight",
+			"cpu_limit",
+			"io_weight",
+			"io_limit",
+		},
+	},
 	"system.role_id_seq": {
 		nonSensitiveCols: NonSensitiveColumns{
 			"last_value",
@@ -47,6 +47,10 @@ func (m *ExternalStorage) AccessIsWithExplicitAuth() bool {
 		// Arbitrary network endpoints may be accessible only via the node and thus
 		// make use of its implicit access to them.
 		return false
+	case ExternalStorageProvider_sftp:
+		// Like http, the server may be reachable only via the node's network,
+		// even though the user must provide credentials to access it.
+		return false
 	case ExternalStorageProvider_nodelocal:
 		// The node's local filesystem is obviously accessed implicitly as the node.
 		return false
@@ -11,7 +11,7 @@ import "github.com/cockroachdb/errors"
 func (d *ConnectionDetails) Type() ConnectionType {
 	switch d.Provider {
 	case ConnectionProvider_nodelocal, ConnectionProvider_s3, ConnectionProvider_userfile,
-		ConnectionProvider_gs, ConnectionProvider_azure_storage:
+		ConnectionProvider_gs, ConnectionProvider_azure_storage, ConnectionProvider_sftp:
 		return TypeStorage
 	case ConnectionProvider_gcp_kms, ConnectionProvider_aws_kms, ConnectionProvider_azure_kms:
 		return TypeKMS
@@ -16,5 +16,6 @@ import (
 	_ "github.com/cockroachdb/cockroach/pkg/cloud/azure"
 	_ "github.com/cockroachdb/cockroach/pkg/cloud/gcp"
 	_ "github.com/cockroachdb/cockroach/pkg/cloud/nodelocal"
+	_ "github.com/cockroachdb/cockroach/pkg/cloud/sftp"
 	_ "github.com/cockroachdb/cockroach/pkg/cloud/userfile"
 )
@@ -141,6 +141,22 @@ func makeLocalFileStorage(
 	}, nil
 }
 
+// MakeLocalDirStorage returns an ExternalStorage rooted at the given local
+// directory which reads and writes it directly rather than through a node's
+// blob service. It is meant for offline tools, such as the debug commands of
+// the cockroach binary, that inspect files copied out of a cluster.
+func MakeLocalDirStorage(dir string, settings *cluster.Settings) (cloud.ExternalStorage, error) {
+	client, err := blobs.NewLocalClient(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &localFileStorage{
+		ioConf:     base.ExternalIODirConfig{},
+		blobClient: client,
+		settings:   settings,
+	}, nil
+}
+
 func (l *localFileStorage) Conf() cloudpb.ExternalStorage {
 	return cloudpb.ExternalStorage{
 		Provider:        cloudpb.ExternalStorageProvider_nodelocal,
@@ -0,0 +1,123 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sftp
+
+import (
+	"context"
+	"io"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/util/log/logcrash"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/errors/oserror"
+	"github.com/cockroachdb/logtags"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// posixRenameExtension is the OpenSSH extension which renames a file over an
+// existing file, which the SSH_FXP_RENAME request of version 3 does not allow.
+const posixRenameExtension = "posix-rename@openssh.com"
+
+// errConnectionLost marks the errors of requests which failed because the
+// connection to the server failed.
+var errConnectionLost = errors.New("sftp connection lost")
+
+// sftpClient is an SFTP session over an SSH connection to the server.
+type sftpClient struct {
+	*sftp.Client
+	sshClient *ssh.Client
+	// done is closed once the session has ended, because the connection to the
+	// server failed or because the client was closed.
+	done chan struct{}
+}
+
+// newSFTPClient starts the sftp subsystem in a new session of the SSH client.
+// The SSH client is closed when the returned client is closed.
+func newSFTPClient(
+	ctx context.Context, sv *settings.Values, sshClient *ssh.Client,
+) (*sftpClient, error) {
+	client, err := sftp.NewClient(sshClient)
+	if err != nil {
+		return nil, err
+	}
+	c := &sftpClient{Client: client, sshClient: sshClient, done: make(chan struct{})}
+	// The session outlives the operation which dialed it, so only its log tags
+	// are kept.
+	go c.waitForSessionEnd(logtags.AddTags(context.Background(), logtags.FromContext(ctx)), sv)
+	return c, nil
+}
+
+// waitForSessionEnd closes done once the session has ended. It is run in its
+// own goroutine for the lifetime of the session.
+func (c *sftpClient) waitForSessionEnd(ctx context.Context, sv *settings.Values) {
+	defer logcrash.RecoverAndReportPanic(ctx, sv)
+	defer close(c.done)
+	_ = c.Client.Wait()
+}
+
+// broken returns true if the connection to the server failed.
+func (c *sftpClient) broken() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close closes the session and the SSH connection.
+func (c *sftpClient) Close() error {
+	err := c.Client.Close()
+	return errors.CombineErrors(err, c.sshClient.Close())
+}
+
+// rename renames oldPath to newPath, replacing newPath if it exists.
+func (c *sftpClient) rename(oldPath, newPath string) error {
+	if _, ok := c.HasExtension(posixRenameExtension); ok {
+		return c.PosixRename(oldPath, newPath)
+	}
+	// Without the extension, the rename fails if newPath exists, so it is
+	// removed first. Readers may briefly observe that the file does not exist.
+	if err := c.Remove(newPath); err != nil && !oserror.IsNotExist(err) {
+		return err
+	}
+	return c.Rename(oldPath, newPath)
+}
+
+// sftpFileReader reads a file of the given size sequentially.
+type sftpFileReader struct {
+	c    *sftpClient
+	f    *sftp.File
+	pos  int64
+	size int64
+}
+
+var _ io.ReadCloser = &sftpFileReader{}
+
+// Read implements io.Reader.
+func (r *sftpFileReader) Read(p []byte) (int, error) {
+	n, err := r.f.Read(p)
+	r.pos += int64(n)
+	switch {
+	case err == nil:
+	case errors.Is(err, io.EOF):
+		// The requests in flight when the connection fails end with io.EOF,
+		// which must not be mistaken for the end of the file.
+		if r.pos < r.size {
+			err = errors.Mark(errors.Newf("sftp: read ended at offset %d of %d", r.pos, r.size),
+				errConnectionLost)
+		}
+	case r.c.broken():
+		err = errors.Mark(err, errConnectionLost)
+	}
+	return n, err
+}
+
+// Close implements io.Closer.
+func (r *sftpFileReader) Close() error {
+	return r.f.Close()
+}
@@ -0,0 +1,178 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sftp
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// testSFTPExtensions are the extensions announced by the SFTP server of the
+// library by default.
+var testSFTPExtensions = []string{
+	"hardlink@openssh.com", posixRenameExtension, "statvfs@openssh.com",
+}
+
+// testSFTPExtensionsWithoutPosixRename are the extensions announced by servers
+// which do not support the posix-rename extension.
+var testSFTPExtensionsWithoutPosixRename = []string{
+	"hardlink@openssh.com", "statvfs@openssh.com",
+}
+
+// testSFTPServer is an SSH server which serves the sftp subsystem from an
+// in-memory filesystem, which is shared by all of its sessions.
+type testSFTPServer struct {
+	listener net.Listener
+	hostKey  ssh.PublicKey
+	config   *ssh.ServerConfig
+	handlers sftp.Handlers
+	// posixRename is whether the server supports the posix-rename extension.
+	posixRename bool
+
+	wg sync.WaitGroup
+	mu struct {
+		sync.Mutex
+		conns []net.Conn
+	}
+}
+
+type testSFTPServerOptions struct {
+	user          string
+	password      string
+	authorizedKey ssh.PublicKey
+	posixRename   bool
+}
+
+// startTestSFTPServer starts a server on a local port. It must be stopped with
+// stop.
+func startTestSFTPServer(t *testing.T, opts testSFTPServerOptions) *testSFTPServer {
+	_, hostPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hostSigner, err := ssh.NewSignerFromKey(hostPriv)
+	require.NoError(t, err)
+
+	config := &ssh.ServerConfig{}
+	if opts.password != "" {
+		config.PasswordCallback = func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == opts.user && string(password) == opts.password {
+				return nil, nil
+			}
+			return nil, errors.New("invalid password")
+		}
+	}
+	if opts.authorizedKey != nil {
+		config.PublicKeyCallback = func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if conn.User() == opts.user && bytes.Equal(key.Marshal(), opts.authorizedKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, errors.New("unauthorized key")
+		}
+	}
+	config.AddHostKey(hostSigner)
+
+	// The extensions announced by the server can only be set globally, so they
+	// are restored once the server is stopped.
+	if !opts.posixRename {
+		require.NoError(t, sftp.SetSFTPExtensions(testSFTPExtensionsWithoutPosixRename...))
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &testSFTPServer{
+		listener:    listener,
+		hostKey:     hostSigner.PublicKey(),
+		config:      config,
+		handlers:    sftp.InMemHandler(),
+		posixRename: opts.posixRename,
+	}
+	s.wg.Add(1)
+	go s.serve()
+	return s
+}
+
+func (s *testSFTPServer) addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *testSFTPServer) stop() {
+	_ = s.listener.Close()
+	s.closeConns()
+	s.wg.Wait()
+	if !s.posixRename {
+		_ = sftp.SetSFTPExtensions(testSFTPExtensions...)
+	}
+}
+
+// closeConns closes the connections of all clients.
+func (s *testSFTPServer) closeConns() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.mu.conns {
+		_ = conn.Close()
+	}
+	s.mu.conns = nil
+}
+
+func (s *testSFTPServer) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.mu.conns = append(s.mu.conns, conn)
+		s.mu.Unlock()
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.serveConn(conn)
+		}()
+	}
+}
+
+func (s *testSFTPServer) serveConn(conn net.Conn) {
+	defer conn.Close()
+	_, chans, reqs, err := ssh.NewServerConn(conn, s.config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer channel.Close()
+			for req := range requests {
+				ok := req.Type == "subsystem" && string(req.Payload[4:]) == "sftp"
+				_ = req.Reply(ok, nil)
+				if ok {
+					server := sftp.NewRequestServer(channel, s.handlers)
+					_ = server.Serve()
+					_ = server.Close()
+					return
+				}
+			}
+		}()
+	}
+}
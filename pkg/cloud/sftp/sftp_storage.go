@@ -0,0 +1,502 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sftp
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/cloud/cloudpb"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/errors/oserror"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// PrivateKeyParam is the query parameter for the base64-encoded contents of
+	// the PEM private key used to authenticate to the SFTP server.
+	PrivateKeyParam = "SFTP_PRIVATE_KEY"
+	// PrivateKeyPassphraseParam is the query parameter for the passphrase of
+	// the private key, if it is encrypted.
+	PrivateKeyPassphraseParam = "SFTP_PRIVATE_KEY_PASSPHRASE"
+	// HostKeyParam is the query parameter for the host key the SFTP server
+	// must present, either as a public key in authorized_keys format or as its
+	// SHA256 fingerprint as printed by `ssh-keygen -l`.
+	HostKeyParam = "SFTP_HOST_KEY"
+	// InsecureSkipHostKeyVerifyParam is the query parameter for connecting to
+	// the SFTP server without verifying its host key.
+	InsecureSkipHostKeyVerifyParam = "SFTP_INSECURE_SKIP_HOST_KEY_VERIFY"
+
+	scheme      = "sftp"
+	defaultPort = "22"
+)
+
+func parseSFTPURL(uri *url.URL) (cloudpb.ExternalStorage, error) {
+	sftpURL := cloud.ConsumeURL{URL: uri}
+	conf := cloudpb.ExternalStorage{}
+	conf.Provider = cloudpb.ExternalStorageProvider_sftp
+	conf.URI = uri.String()
+
+	if uri.Hostname() == "" {
+		return cloudpb.ExternalStorage{}, errors.New("sftp URI must specify a host")
+	}
+	if uri.User == nil || uri.User.Username() == "" {
+		return cloudpb.ExternalStorage{}, errors.New("sftp URI must specify a user")
+	}
+	host := uri.Host
+	if uri.Port() == "" {
+		host = net.JoinHostPort(uri.Hostname(), defaultPort)
+	}
+	password, _ := uri.User.Password()
+
+	var privateKey string
+	if encodedKey := sftpURL.ConsumeParam(PrivateKeyParam); encodedKey != "" {
+		key, err := base64.StdEncoding.DecodeString(encodedKey)
+		if err != nil {
+			return cloudpb.ExternalStorage{}, errors.Wrapf(err, "decoding value of %s", PrivateKeyParam)
+		}
+		privateKey = string(key)
+	}
+	skipHostKeyVerifyStr := sftpURL.ConsumeParam(InsecureSkipHostKeyVerifyParam)
+	skipHostKeyVerify := false
+	if skipHostKeyVerifyStr != "" {
+		var err error
+		skipHostKeyVerify, err = strconv.ParseBool(skipHostKeyVerifyStr)
+		if err != nil {
+			return cloudpb.ExternalStorage{}, errors.Wrapf(err, "cannot parse %s as bool", InsecureSkipHostKeyVerifyParam)
+		}
+	}
+	conf.SFTPConfig = &cloudpb.ExternalStorage_SFTP{
+		Host:                      host,
+		Path:                      uri.Path,
+		User:                      uri.User.Username(),
+		Password:                  password,
+		PrivateKey:                privateKey,
+		PrivateKeyPassphrase:      sftpURL.ConsumeParam(PrivateKeyPassphraseParam),
+		HostKey:                   sftpURL.ConsumeParam(HostKeyParam),
+		InsecureSkipHostKeyVerify: skipHostKeyVerify,
+	}
+
+	// Validate that all the passed in parameters are supported.
+	if unknownParams := sftpURL.RemainingQueryParams(); len(unknownParams) > 0 {
+		return cloudpb.ExternalStorage{}, errors.Errorf(
+			`unknown SFTP query parameters: %s`, strings.Join(unknownParams, ", "))
+	}
+	if password == "" && privateKey == "" {
+		return cloudpb.ExternalStorage{}, errors.Errorf(
+			"sftp URI must specify a password or a private key with %s", PrivateKeyParam)
+	}
+	if conf.SFTPConfig.HostKey == "" && !skipHostKeyVerify {
+		return cloudpb.ExternalStorage{}, errors.Errorf(
+			"sftp URI must specify the host key of the server with %s, or set %s",
+			HostKeyParam, InsecureSkipHostKeyVerifyParam)
+	}
+	return conf, nil
+}
+
+type sftpStorage struct {
+	conf       *cloudpb.ExternalStorage_SFTP
+	sshConfig  *ssh.ClientConfig
+	ioConf     base.ExternalIODirConfig
+	settings   *cluster.Settings
+	metrics    *cloud.Metrics
+	clientName string
+	prefix     string
+	uri        string // original URI used to construct this storage
+
+	mu struct {
+		syncutil.Mutex
+		// client is the connection to the server, which is established on first
+		// use and re-established if it fails.
+		client *sftpClient
+	}
+}
+
+var _ cloud.ExternalStorage = &sftpStorage{}
+
+// MakeSFTPStorage returns an instance of an SFTP ExternalStorage.
+func MakeSFTPStorage(
+	ctx context.Context, args cloud.EarlyBootExternalStorageContext, dest cloudpb.ExternalStorage,
+) (cloud.ExternalStorage, error) {
+	telemetry.Count("external-io.sftp")
+	conf := dest.SFTPConfig
+	if conf == nil {
+		return nil, errors.Errorf("sftp upload requested but info missing")
+	}
+	sshConfig, err := makeSSHClientConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+	return &sftpStorage{
+		conf:       conf,
+		sshConfig:  sshConfig,
+		ioConf:     args.IOConf,
+		settings:   args.Settings,
+		metrics:    args.MetricsRecorder,
+		clientName: args.ExternalStorageOptions().ClientName,
+		prefix:     conf.Path,
+		uri:        dest.URI,
+	}, nil
+}
+
+// makeSSHClientConfig returns the configuration used to connect to the SFTP
+// server, which authenticates with the private key and the password, if they
+// are set, and pins the host key of the server.
+func makeSSHClientConfig(conf *cloudpb.ExternalStorage_SFTP) (*ssh.ClientConfig, error) {
+	cfg := &ssh.ClientConfig{User: conf.User}
+	if conf.PrivateKey != "" {
+		var signer ssh.Signer
+		var err error
+		if conf.PrivateKeyPassphrase != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase(
+				[]byte(conf.PrivateKey), []byte(conf.PrivateKeyPassphrase))
+		} else {
+			signer, err = ssh.ParsePrivateKey([]byte(conf.PrivateKey))
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing value of %s", PrivateKeyParam)
+		}
+		cfg.Auth = append(cfg.Auth, ssh.PublicKeys(signer))
+	}
+	if conf.Password != "" {
+		cfg.Auth = append(cfg.Auth, ssh.Password(conf.Password))
+	}
+
+	if conf.InsecureSkipHostKeyVerify {
+		cfg.HostKeyCallback = ssh.InsecureIgnoreHostKey()
+		return cfg, nil
+	}
+	// Host keys given in authorized_keys format are compared by their
+	// fingerprint, like host keys given as a fingerprint.
+	want := conf.HostKey
+	if !strings.HasPrefix(want, "SHA256:") {
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(want))
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing value of %s", HostKeyParam)
+		}
+		want = ssh.FingerprintSHA256(key)
+	}
+	cfg.HostKeyCallback = func(hostname string, _ net.Addr, key ssh.PublicKey) error {
+		if got := ssh.FingerprintSHA256(key); got != want {
+			return errors.Errorf("sftp server %s presented %s host key %s, which does not match %s",
+				hostname, key.Type(), got, HostKeyParam)
+		}
+		return nil
+	}
+	return cfg, nil
+}
+
+func (s *sftpStorage) Conf() cloudpb.ExternalStorage {
+	return cloudpb.ExternalStorage{
+		Provider:   cloudpb.ExternalStorageProvider_sftp,
+		SFTPConfig: s.conf,
+		URI:        s.uri,
+	}
+}
+
+func (s *sftpStorage) ExternalIOConf() base.ExternalIODirConfig {
+	return s.ioConf
+}
+
+func (s *sftpStorage) RequiresExternalIOAccounting() bool { return true }
+
+func (s *sftpStorage) Settings() *cluster.Settings {
+	return s.settings
+}
+
+// client returns the connection to the server, connecting to it if there is
+// no connection yet or if the previous connection failed.
+func (s *sftpStorage) client(ctx context.Context) (*sftpClient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c := s.mu.client; c != nil {
+		if !c.broken() {
+			return c, nil
+		}
+		_ = c.Close()
+		s.mu.client = nil
+	}
+	c, err := s.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.client = c
+	return c, nil
+}
+
+func (s *sftpStorage) dial(ctx context.Context) (*sftpClient, error) {
+	var dialer net.Dialer
+	dial := dialer.DialContext
+	if s.metrics != nil {
+		dial = s.metrics.NetMetrics.Wrap(dial, "sftp", s.conf.Host, s.clientName)
+	}
+	conn, err := dial(ctx, "tcp", s.conf.Host)
+	if err != nil {
+		return nil, errors.Wrapf(err, "dialing sftp server %s", s.conf.Host)
+	}
+	// The SSH handshake does not observe ctx, so it is bounded by the timeout
+	// of cloud storage operations instead.
+	if err := conn.SetDeadline(timeutil.Now().Add(cloud.Timeout.Get(&s.settings.SV))); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, s.conf.Host, s.sshConfig)
+	if err != nil {
+		_ = conn.Close()
+		return nil, errors.Wrapf(err, "connecting to sftp server %s", s.conf.Host)
+	}
+	sshClient := ssh.NewClient(sshConn, chans, reqs)
+	c, err := newSFTPClient(ctx, &s.settings.SV, sshClient)
+	if err != nil {
+		_ = sshClient.Close()
+		return nil, errors.Wrapf(err, "starting sftp session with %s", s.conf.Host)
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *sftpStorage) ReadFile(
+	ctx context.Context, basename string, opts cloud.ReadOptions,
+) (_ ioctx.ReadCloserCtx, fileSize int64, _ error) {
+	p := path.Join(s.prefix, basename)
+	r, size, err := s.openReader(ctx, p, opts.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	opener := func(ctx context.Context, pos int64) (io.ReadCloser, int64, error) {
+		return s.openReader(ctx, p, pos)
+	}
+	// Reads which fail because the connection was lost are resumed on a new
+	// connection.
+	retryOnErr := cloud.ResumingReaderRetryOnErrFnForSettings(ctx, s.settings)
+	return cloud.NewResumingReader(ctx, opener, r, opts.Offset, size, p,
+		func(err error) bool {
+			return errors.Is(err, errConnectionLost) || retryOnErr(err)
+		}, nil), size, nil
+}
+
+func (s *sftpStorage) openReader(
+	ctx context.Context, p string, pos int64,
+) (io.ReadCloser, int64, error) {
+	c, err := s.client(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	f, err := c.Open(p)
+	if err != nil {
+		if oserror.IsNotExist(err) {
+			return nil, 0, cloud.WrapErrFileDoesNotExist(err, "sftp file does not exist")
+		}
+		return nil, 0, errors.Wrapf(err, "opening %s", p)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, 0, errors.Wrapf(err, "reading size of %s", p)
+	}
+	if _, err := f.Seek(pos, io.SeekStart); err != nil {
+		_ = f.Close()
+		return nil, 0, errors.Wrapf(err, "seeking to %d in %s", pos, p)
+	}
+	return &sftpFileReader{c: c, f: f, pos: pos, size: info.Size()}, info.Size(), nil
+}
+
+func (s *sftpStorage) Writer(ctx context.Context, basename string) (io.WriteCloser, error) {
+	p := path.Join(s.prefix, basename)
+	return cloud.BackgroundPipe(ctx, func(ctx context.Context, r io.Reader) error {
+		return s.writeFile(ctx, p, r)
+	}), nil
+}
+
+// writeFile writes the contents of r to a temporary file in the directory of
+// p, which is moved to p once it is complete so that readers never observe a
+// partially written file.
+func (s *sftpStorage) writeFile(ctx context.Context, p string, r io.Reader) error {
+	c, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	tmp := fmt.Sprintf("%s.%s.tmp", p, uuid.MakeV4().Short())
+	const flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	f, err := c.OpenFile(tmp, flags)
+	if oserror.IsNotExist(err) {
+		if err := c.MkdirAll(path.Dir(p)); err != nil {
+			return errors.Wrapf(err, "creating directory of %s", p)
+		}
+		f, err = c.OpenFile(tmp, flags)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "creating temporary file %s", tmp)
+	}
+
+	_, err = io.Copy(f, r)
+	err = errors.CombineErrors(err, f.Close())
+	if err == nil {
+		err = errors.Wrapf(c.rename(tmp, p), "moving temporary file to %s", p)
+	}
+	if err != nil {
+		if rmErr := c.Remove(tmp); rmErr != nil && !oserror.IsNotExist(rmErr) {
+			err = errors.CombineErrors(err, errors.Wrap(rmErr, "cleaning up"))
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *sftpStorage) List(
+	ctx context.Context, prefix string, opts cloud.ListOptions, fn cloud.ListingFn,
+) error {
+	dest := cloud.JoinPathPreservingTrailingSlash(s.prefix, prefix)
+	afterKey := opts.CanonicalAfterKey(s.prefix)
+
+	c, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	// SFTP can only list directories, so if dest is not a directory its parent
+	// directory is listed, skipping the files which do not start with dest.
+	root := dest
+	if info, err := c.Stat(dest); err != nil || !info.IsDir() {
+		root = path.Dir(dest)
+	}
+	var res []string
+	if err := walkSFTPDir(c, root, dest, &res); err != nil {
+		return errors.Wrap(err, "unable to list files in sftp directory")
+	}
+
+	// Sort results so that we can group as we go.
+	sort.Strings(res)
+	var prevPrefix string
+	for _, f := range res {
+		f = strings.TrimPrefix(f, dest)
+		if opts.Delimiter != "" {
+			if i := strings.Index(f, opts.Delimiter); i >= 0 {
+				f = f[:i+len(opts.Delimiter)]
+			}
+			if f == prevPrefix {
+				continue
+			}
+			prevPrefix = f
+		}
+
+		// afterKey is a full key so we must compare against the full file key.
+		if dest+f <= afterKey {
+			continue
+		}
+
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkSFTPDir appends the paths of the files in dir and its subdirectories
+// which start with dest to res.
+func walkSFTPDir(c *sftpClient, dir string, dest string, res *[]string) error {
+	entries, err := c.ReadDir(dir)
+	if err != nil {
+		if oserror.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "listing %s", dir)
+	}
+	for _, e := range entries {
+		p := path.Join(dir, e.Name())
+		if !e.IsDir() {
+			if strings.HasPrefix(p, dest) {
+				*res = append(*res, p)
+			}
+			continue
+		}
+		// Only descend into directories which may contain files under dest.
+		if strings.HasPrefix(p+"/", dest) || strings.HasPrefix(dest, p+"/") {
+			if err := walkSFTPDir(c, p, dest, res); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *sftpStorage) Delete(ctx context.Context, basename string) error {
+	return timeutil.RunWithTimeout(ctx, "delete sftp file", cloud.Timeout.Get(&s.settings.SV),
+		func(ctx context.Context) error {
+			c, err := s.client(ctx)
+			if err != nil {
+				return err
+			}
+			if err := c.Remove(path.Join(s.prefix, basename)); err != nil && !oserror.IsNotExist(err) {
+				return err
+			}
+			return nil
+		})
+}
+
+func (s *sftpStorage) Size(ctx context.Context, basename string) (int64, error) {
+	var size int64
+	if err := timeutil.RunWithTimeout(ctx, "size sftp file", cloud.Timeout.Get(&s.settings.SV),
+		func(ctx context.Context) error {
+			c, err := s.client(ctx)
+			if err != nil {
+				return err
+			}
+			info, err := c.Stat(path.Join(s.prefix, basename))
+			if err != nil {
+				if oserror.IsNotExist(err) {
+					return cloud.WrapErrFileDoesNotExist(err, "sftp file does not exist")
+				}
+				return err
+			}
+			size = info.Size()
+			return nil
+		}); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+func (s *sftpStorage) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.mu.client == nil {
+		return nil
+	}
+	err := s.mu.client.Close()
+	s.mu.client = nil
+	return err
+}
+
+func init() {
+	cloud.RegisterExternalStorageProvider(cloudpb.ExternalStorageProvider_sftp,
+		cloud.RegisteredProvider{
+			EarlyBootParseFn:     parseSFTPURL,
+			EarlyBootConstructFn: MakeSFTPStorage,
+			RedactedParams:       cloud.RedactedParams(PrivateKeyParam, PrivateKeyPassphraseParam),
+			Schemes:              []string{scheme},
+		})
+}
@@ -285,6 +285,14 @@ const (
 	// to fingerprint_id and drops the legacy id column.
 	V26_3_AlterStatementsTablePK
 
+	// V26_3_AddResourceGroupsTable adds the system.resource_groups table for
+	// storing the resource groups defined with CREATE RESOURCE GROUP.
+	V26_3_AddResourceGroupsTable
+
+	// V26_3_ColumnarIndexes is the version after which CREATE INDEX ... USING
+	// COLUMNAR can be used.
+	V26_3_ColumnarIndexes
+
 	// *************************************************
 	// Step (1) Add new versions above this comment.
 	// Do not add new versions to a patch release.
@@ -373,6 +381,10 @@ var versionTable = [numKeys]roachpb.Version{
 	V26_3_AddAdvisoryLocksTable: {Major: 26, Minor: 2, Internal: 6},
 
 	V26_3_AlterStatementsTablePK: {Major: 26, Minor: 2, Internal: 8},
+
+	V26_3_AddResourceGroupsTable: {Major: 26, Minor: 2, Internal: 10},
+
+	V26_3_ColumnarIndexes: {Major: 26, Minor: 2, Internal: 12},
 	// *************************************************
 	// Step (2): Add new versions above this comment.
 	// *************************************************
@@ -54,6 +54,7 @@ DOCS_SRCS = [
     "//docs/generated/sql/bnf:alter_proc_owner_stmt.bnf",
     "//docs/generated/sql/bnf:alter_proc_rename_stmt.bnf",
     "//docs/generated/sql/bnf:alter_proc_set_schema_stmt.bnf",
+    "//docs/generated/sql/bnf:alter_resource_group_stmt.bnf",
     "//docs/generated/sql/bnf:alter_range.bnf",
     "//docs/generated/sql/bnf:alter_range_relocate_stmt.bnf",
     "//docs/generated/sql/bnf:alter_rename_view_stmt.bnf",
@@ -70,6 +71,8 @@ DOCS_SRCS = [
     "//docs/generated/sql/bnf:alter_table_cmds.bnf",
     "//docs/generated/sql/bnf:alter_table_locality_stmt.bnf",
     "//docs/generated/sql/bnf:alter_table_logged_stmt.bnf",
+    "//docs/generated/sql/bnf:alter_table_read_only_stmt.bnf",
+    "//docs/generated/sql/bnf:alter_table_revert_stmt.bnf",
     "//docs/generated/sql/bnf:alter_table_owner_stmt.bnf",
     "//docs/generated/sql/bnf:alter_table_partition_by.bnf",
     "//docs/generated/sql/bnf:alter_table_reset_storage_param.bnf",
@@ -111,6 +114,7 @@ DOCS_SRCS = [
     "//docs/generated/sql/bnf:comment.bnf",
     "//docs/generated/sql/bnf:commit_prepared_stmt.bnf",
     "//docs/generated/sql/bnf:commit_transaction.bnf",
+    "//docs/generated/sql/bnf:copy_backup_stmt.bnf",
     "//docs/generated/sql/bnf:copy_stmt.bnf",
     "//docs/generated/sql/bnf:copy_to_stmt.bnf",
     "//docs/generated/sql/bnf:create_as_col_qual_list.bnf",
@@ -128,6 +132,7 @@ DOCS_SRCS = [
     "//docs/generated/sql/bnf:create_logical_replication_stream_stmt.bnf",
     "//docs/generated/sql/bnf:create_policy_stmt.bnf",
     "//docs/generated/sql/bnf:create_proc.bnf",
+    "//docs/generated/sql/bnf:create_resource_group_stmt.bnf",
     "//docs/generated/sql/bnf:create_role_stmt.bnf",
     "//docs/generated/sql/bnf:create_schedule_for_backup_stmt.bnf",
     "//docs/generated/sql/bnf:create_schedule_for_changefeed_stmt.bnf",
@@ -158,6 +163,7 @@ DOCS_SRCS = [
     "//docs/generated/sql/bnf:drop_owned_by_stmt.bnf",
     "//docs/generated/sql/bnf:drop_policy_stmt.bnf",
     "//docs/generated/sql/bnf:drop_proc.bnf",
+    "//docs/generated/sql/bnf:drop_resource_group_stmt.bnf",
     "//docs/generated/sql/bnf:drop_provisioned_roles_stmt.bnf",
     "//docs/generated/sql/bnf:drop_role_stmt.bnf",
     "//docs/generated/sql/bnf:drop_schedule_stmt.bnf",
@@ -286,10 +292,12 @@ DOCS_SRCS = [
     "//docs/generated/sql/bnf:show_locality.bnf",
     "//docs/generated/sql/bnf:show_locality_stmt.bnf",
     "//docs/generated/sql/bnf:show_partitions_stmt.bnf",
+    "//docs/generated/sql/bnf:show_plan_baselines_stmt.bnf",
     "//docs/generated/sql/bnf:show_procedures_stmt.bnf",
     "//docs/generated/sql/bnf:show_range_for_row_stmt.bnf",
     "//docs/generated/sql/bnf:show_ranges_stmt.bnf",
     "//docs/generated/sql/bnf:show_regions.bnf",
+    "//docs/generated/sql/bnf:show_resource_groups_stmt.bnf",
     "//docs/generated/sql/bnf:show_roles_stmt.bnf",
     "//docs/generated/sql/bnf:show_savepoint_status.bnf",
     "//docs/generated/sql/bnf:show_schedules.bnf",
@@ -76,6 +76,11 @@ const (
 	// KeyDistSQLDrainingPrefix is the key prefix for each node's DistSQL
 	// draining state.
 	KeyDistSQLDrainingPrefix = "distsql-draining"
+
+	// KeyTenantStorageUsagePrefix is the key prefix for gossiping the logical
+	// bytes stored by tenants with a storage quota in the ranges for which a
+	// node holds the lease. The suffix is a node ID.
+	KeyTenantStorageUsagePrefix = "tenant-storage-usage"
 )
 
 // MakeKey creates a canonical key under which to gossip a piece of
@@ -155,6 +160,12 @@ func MakeDistSQLDrainingKey(instanceID base.SQLInstanceID) string {
 	return MakeKey(KeyDistSQLDrainingPrefix, instanceID.String())
 }
 
+// MakeTenantStorageUsageKey returns the gossip key under which the given node
+// gossips the storage usage of tenants.
+func MakeTenantStorageUsageKey(nodeID roachpb.NodeID) string {
+	return MakeKey(KeyTenantStorageUsagePrefix, nodeID.String())
+}
+
 // removePrefixFromKey removes the key prefix and separator and returns what's
 // left. Returns an error if the key doesn't have this prefix.
 func removePrefixFromKey(key, prefix string) (string, error) {
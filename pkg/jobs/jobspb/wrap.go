@@ -50,6 +50,9 @@ var (
 	_ Details = HotRangesLoggerDetails{}
 	_ Details = InspectDetails{}
 	_ Details = FingerprintDetails{}
+	_ Details = IncrementalViewMaintenanceDetails{}
+	_ Details = AlterTableRevertDetails{}
+	_ Details = CopyBackupDetails{}
 )
 
 // ProgressDetails is a marker interface for job progress details proto structs.
@@ -84,6 +87,9 @@ var (
 	_ ProgressDetails = HotRangesLoggerProgress{}
 	_ ProgressDetails = InspectProgress{}
 	_ ProgressDetails = FingerprintProgress{}
+	_ ProgressDetails = IncrementalViewMaintenanceProgress{}
+	_ ProgressDetails = AlterTableRevertProgress{}
+	_ ProgressDetails = CopyBackupProgress{}
 )
 
 // Type returns the payload's job type and panics if the type is invalid.
@@ -253,6 +259,12 @@ func DetailsType(d isPayload_Details) (Type, error) {
 		return TypeInspect, nil
 	case *Payload_FingerprintDetails:
 		return TypeFingerprint, nil
+	case *Payload_IncrementalViewMaintenanceDetails:
+		return TypeIncrementalViewMaintenance, nil
+	case *Payload_AlterTableRevertDetails:
+		return TypeAlterTableRevert, nil
+	case *Payload_CopyBackupDetails:
+		return TypeCopyBackup, nil
 	default:
 		return TypeUnspecified, errors.Newf("Payload.Type called on a payload with an unknown details type: %T", d)
 	}
@@ -310,6 +322,9 @@ var JobDetailsForEveryJobType = map[Type]Details{
 	TypeHotRangesLogger:              HotRangesLoggerDetails{},
 	TypeInspect:                      InspectDetails{},
 	TypeFingerprint:                  FingerprintDetails{},
+	TypeIncrementalViewMaintenance:   IncrementalViewMaintenanceDetails{},
+	TypeAlterTableRevert:             AlterTableRevertDetails{},
+	TypeCopyBackup:                   CopyBackupDetails{},
 }
 
 // WrapProgressDetails wraps a ProgressDetails object in the protobuf wrapper
@@ -385,6 +400,12 @@ func WrapProgressDetails(details ProgressDetails) interface {
 		return &Progress_Inspect{Inspect: &d}
 	case FingerprintProgress:
 		return &Progress_Fingerprint{Fingerprint: &d}
+	case IncrementalViewMaintenanceProgress:
+		return &Progress_IncrementalViewMaintenance{IncrementalViewMaintenance: &d}
+	case AlterTableRevertProgress:
+		return &Progress_AlterTableRevert{AlterTableRevert: &d}
+	case CopyBackupProgress:
+		return &Progress_CopyBackup{CopyBackup: &d}
 	default:
 		panic(errors.AssertionFailedf("WrapProgressDetails: unknown progress type %T", d))
 	}
@@ -458,6 +479,12 @@ func (p *Payload) UnwrapDetails() Details {
 		return *d.InspectDetails
 	case *Payload_FingerprintDetails:
 		return *d.FingerprintDetails
+	case *Payload_IncrementalViewMaintenanceDetails:
+		return *d.IncrementalViewMaintenanceDetails
+	case *Payload_AlterTableRevertDetails:
+		return *d.AlterTableRevertDetails
+	case *Payload_CopyBackupDetails:
+		return *d.CopyBackupDetails
 	default:
 		return nil
 	}
@@ -531,6 +558,12 @@ func (p *Progress) UnwrapDetails() ProgressDetails {
 		return d.Inspect
 	case *Progress_Fingerprint:
 		return d.Fingerprint
+	case *Progress_IncrementalViewMaintenance:
+		return *d.IncrementalViewMaintenance
+	case *Progress_AlterTableRevert:
+		return *d.AlterTableRevert
+	case *Progress_CopyBackup:
+		return *d.CopyBackup
 	default:
 		return nil
 	}
@@ -628,6 +661,12 @@ func WrapPayloadDetails(details Details) interface {
 		return &Payload_InspectDetails{InspectDetails: &d}
 	case FingerprintDetails:
 		return &Payload_FingerprintDetails{FingerprintDetails: &d}
+	case IncrementalViewMaintenanceDetails:
+		return &Payload_IncrementalViewMaintenanceDetails{IncrementalViewMaintenanceDetails: &d}
+	case AlterTableRevertDetails:
+		return &Payload_AlterTableRevertDetails{AlterTableRevertDetails: &d}
+	case CopyBackupDetails:
+		return &Payload_CopyBackupDetails{CopyBackupDetails: &d}
 	default:
 		panic(errors.AssertionFailedf("jobs.WrapPayloadDetails: unknown details type %T", d))
 	}
@@ -663,7 +702,7 @@ const (
 func (Type) SafeValue() {}
 
 // NumJobTypes is the number of jobs types.
-const NumJobTypes = 35
+const NumJobTypes = 38
 
 // ChangefeedDetailsMarshaler allows for dependency injection of
 // cloud.SanitizeExternalStorageURI to avoid the dependency from this
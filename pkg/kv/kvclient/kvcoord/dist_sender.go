@@ -816,6 +816,10 @@ type DistSender struct {
 
 	// Currently executing range feeds.
 	activeRangeFeeds syncutil.Set[*rangeFeedRegistry]
+
+	// sharedRangeFeeds dedupes the range feeds which opted into sharing with
+	// WithSharing.
+	sharedRangeFeeds *sharedRangeFeeds
 }
 
 var _ kv.Sender = &DistSender{}
@@ -1000,6 +1004,8 @@ func NewDistSender(ctx context.Context, cfg DistSenderConfig) *DistSender {
 		}
 	}
 
+	ds.sharedRangeFeeds = newSharedRangeFeeds(ds.rangeFeed, ds.stopper, ds.st)
+
 	nanos := randomizeLeaseholderOnContextErrorDuration.Get(&ds.st.SV).Nanoseconds()
 	ds.randomizeLeaseholderOnCtxErrorNanos.Store(nanos)
 	randomizeLeaseholderOnContextErrorDuration.SetOnChange(&ds.st.SV, func(ctx context.Context) {
@@ -70,6 +70,7 @@ type rangeFeedConfig struct {
 	rangeObserver         RangeObserver
 	consumerID            int64
 	bulkDelivery          bool
+	withSharing           bool
 
 	knobs struct {
 		// onRangefeedEvent invoked on each rangefeed event.
@@ -195,6 +196,18 @@ func (ds *DistSender) RangeFeed(
 	for _, opt := range opts {
 		opt.set(&cfg)
 	}
+	if cfg.withSharing {
+		if key, startAfter, ok := makeSharedRangeFeedKey(&cfg, spans); ok {
+			return ds.sharedRangeFeeds.run(ctx, key, startAfter, cfg, spans, eventCh)
+		}
+	}
+	return ds.rangeFeed(ctx, cfg, spans, eventCh)
+}
+
+// rangeFeed runs a rangefeed which isn't shared with other consumers.
+func (ds *DistSender) rangeFeed(
+	ctx context.Context, cfg rangeFeedConfig, spans []SpanTimePair, eventCh chan<- RangeFeedMessage,
+) error {
 	ctx = ds.AnnotateCtx(ctx)
 	ctx, sp := tracing.EnsureChildSpan(ctx, ds.AmbientContext.Tracer, "dist sender")
 	defer sp.Finish()
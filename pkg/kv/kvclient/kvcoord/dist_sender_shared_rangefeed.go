@@ -0,0 +1,506 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package kvcoord
+
+import (
+	"context"
+	"encoding/binary"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// sharedRangeFeedConsumerBudget limits the size of the events queued for each
+// consumer of a shared rangefeed.
+var sharedRangeFeedConsumerBudget = settings.RegisterByteSizeSetting(
+	settings.ApplicationLevel,
+	"kv.rangefeed.shared.consumer_budget",
+	"the maximum size of the events queued for a consumer of a shared rangefeed; "+
+		"a consumer which falls further behind is detached from the shared rangefeed",
+	64<<20,
+	settings.PositiveInt,
+)
+
+// errSharedRangeFeedConsumerDetached is returned to a consumer of a shared
+// rangefeed which fell too far behind the rangefeed.
+var errSharedRangeFeedConsumerDetached = errors.New(
+	"consumer fell behind the shared rangefeed and was detached from it",
+)
+
+// WithSharing allows the rangefeed to share a single rangefeed, and hence a
+// single catch-up scan, with the other rangefeeds of the DistSender which
+// opted into sharing and watch the same spans with the same options.
+//
+// Events are shared between the consumers of a shared rangefeed and must not
+// be mutated. A shared rangefeed never blocks on its consumers: it queues the
+// events of each consumer until the consumer delivers them, and detaches a
+// consumer whose queued events exceed kv.rangefeed.shared.consumer_budget. The
+// rangefeed of a detached consumer fails, and the consumer restarts it like
+// after any other rangefeed error.
+//
+// Rangefeeds whose spans start at different times, or which use a range
+// observer or testing knobs, are not shared.
+func WithSharing() RangeFeedOption {
+	return optionFunc(func(c *rangeFeedConfig) {
+		c.withSharing = true
+	})
+}
+
+// sharedRangeFeeds dedupes the rangefeeds of a DistSender which opted into
+// sharing with WithSharing. Consumers which watch the same spans with the same
+// options share a single rangefeed, whose events are fanned out in-process to
+// every consumer.
+//
+// A consumer can join a running rangefeed as long as it does not need any of
+// the events already fanned out, i.e. if it starts at or after the timestamp
+// of every value emitted so far. The values at or below its start time are
+// not delivered to it. Consumers which can't join any running rangefeed start
+// a new one, which later consumers can join in turn.
+type sharedRangeFeeds struct {
+	// rangeFeed runs an unshared rangefeed.
+	rangeFeed func(
+		ctx context.Context, cfg rangeFeedConfig, spans []SpanTimePair, eventCh chan<- RangeFeedMessage,
+	) error
+	stopper *stop.Stopper
+	st      *cluster.Settings
+
+	mu struct {
+		syncutil.Mutex
+		feeds map[sharedRangeFeedKey][]*sharedRangeFeed
+	}
+}
+
+func newSharedRangeFeeds(
+	rangeFeed func(
+		ctx context.Context, cfg rangeFeedConfig, spans []SpanTimePair, eventCh chan<- RangeFeedMessage,
+	) error,
+	stopper *stop.Stopper,
+	st *cluster.Settings,
+) *sharedRangeFeeds {
+	s := &sharedRangeFeeds{
+		rangeFeed: rangeFeed,
+		stopper:   stopper,
+		st:        st,
+	}
+	s.mu.feeds = make(map[sharedRangeFeedKey][]*sharedRangeFeed)
+	return s
+}
+
+// sharedRangeFeedKey identifies the rangefeeds which a consumer may share. The
+// start time is not part of the key, see sharedRangeFeed.tryJoin. Neither is
+// the consumer ID: a shared rangefeed uses the ID of the consumer which
+// started it.
+type sharedRangeFeedKey struct {
+	// spans is a canonical encoding of the sorted spans watched by the feed.
+	spans           string
+	overSystemTable bool
+	withDiff        bool
+	withFiltering   bool
+	withMetadata    bool
+	bulkDelivery    bool
+	// originIDs is an encoding of withMatchingOriginIDs.
+	originIDs string
+}
+
+// makeSharedRangeFeedKey returns the key of the rangefeeds which the given
+// rangefeed can share, along with the start time of its spans, or false if it
+// can't be shared.
+func makeSharedRangeFeedKey(
+	cfg *rangeFeedConfig, spans []SpanTimePair,
+) (sharedRangeFeedKey, hlc.Timestamp, bool) {
+	if cfg.rangeObserver != nil || cfg.knobs.onRangefeedEvent != nil || cfg.knobs.metrics != nil ||
+		cfg.knobs.captureMuxRangeFeedRequestSender != nil || cfg.knobs.beforeSendRequest != nil ||
+		cfg.knobs.afterRoutingReset != nil {
+		return sharedRangeFeedKey{}, hlc.Timestamp{}, false
+	}
+	startAfter := spans[0].StartAfter
+	sorted := make([]roachpb.Span, len(spans))
+	for i := range spans {
+		if spans[i].StartAfter != startAfter {
+			// The spans start at different times, e.g. because the consumer is
+			// resuming from a partial checkpoint.
+			return sharedRangeFeedKey{}, hlc.Timestamp{}, false
+		}
+		sorted[i] = spans[i].Span
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Key.Compare(sorted[j].Key) < 0 })
+	var b strings.Builder
+	for _, sp := range sorted {
+		b.Write(sp.Key)
+		b.WriteByte(0)
+		b.Write(sp.EndKey)
+		b.WriteByte(0)
+	}
+	var originIDs []byte
+	for _, id := range cfg.withMatchingOriginIDs {
+		originIDs = binary.BigEndian.AppendUint32(originIDs, id)
+	}
+	return sharedRangeFeedKey{
+		spans:           b.String(),
+		overSystemTable: cfg.overSystemTable,
+		withDiff:        cfg.withDiff,
+		withFiltering:   cfg.withFiltering,
+		withMetadata:    cfg.withMetadata,
+		bulkDelivery:    cfg.bulkDelivery,
+		originIDs:       string(originIDs),
+	}, startAfter, true
+}
+
+// run runs a consumer of a shared rangefeed until ctx is canceled or the
+// rangefeed fails. The consumer's events are delivered to eventCh, in the
+// consumer's goroutine and under the consumer's context.
+func (s *sharedRangeFeeds) run(
+	ctx context.Context,
+	key sharedRangeFeedKey,
+	startAfter hlc.Timestamp,
+	cfg rangeFeedConfig,
+	spans []SpanTimePair,
+	eventCh chan<- RangeFeedMessage,
+) error {
+	c := &sharedRangeFeedConsumer{
+		startAfter: startAfter,
+		notify:     make(chan struct{}, 1),
+		errCh:      make(chan error, 1),
+	}
+	f, err := s.register(ctx, key, cfg, spans, c)
+	if err != nil {
+		return err
+	}
+	defer s.unregister(f, c)
+
+	var events []RangeFeedMessage
+	for {
+		select {
+		case <-c.notify:
+			events = c.take(events[:0])
+			for _, e := range events {
+				select {
+				case eventCh <- e:
+				case err := <-c.errCh:
+					return err
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		case err := <-c.errCh:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// register adds the consumer to a running rangefeed which it can join, or
+// starts a new rangefeed for it.
+func (s *sharedRangeFeeds) register(
+	ctx context.Context,
+	key sharedRangeFeedKey,
+	cfg rangeFeedConfig,
+	spans []SpanTimePair,
+	c *sharedRangeFeedConsumer,
+) (*sharedRangeFeed, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, f := range s.mu.feeds[key] {
+		if f.tryJoin(c) {
+			log.VEventf(ctx, 1, "joined shared rangefeed started after %s", f.startAfter)
+			return f, nil
+		}
+	}
+
+	f := &sharedRangeFeed{
+		feeds:      s,
+		key:        key,
+		startAfter: c.startAfter,
+	}
+	f.mu.consumers = map[*sharedRangeFeedConsumer]struct{}{c: {}}
+
+	// The rangefeed outlives the consumer which started it, so it runs in its
+	// own task which is canceled once the last consumer leaves.
+	feedCtx, cancel := s.stopper.WithCancelOnQuiesce(context.Background())
+	f.cancel = cancel
+	cfg.withSharing = false
+	spans = append([]SpanTimePair(nil), spans...)
+	if err := s.stopper.RunAsyncTask(feedCtx, "dist-sender-shared-rangefeed", func(ctx context.Context) {
+		f.run(ctx, cfg, spans)
+	}); err != nil {
+		cancel()
+		return nil, err
+	}
+	s.mu.feeds[key] = append(s.mu.feeds[key], f)
+	return f, nil
+}
+
+// unregister removes the consumer from the rangefeed, stopping the rangefeed
+// if it was the last consumer.
+func (s *sharedRangeFeeds) unregister(f *sharedRangeFeed, c *sharedRangeFeedConsumer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.mu.consumers, c)
+	if len(f.mu.consumers) == 0 && !f.mu.done {
+		f.mu.done = true
+		s.removeLocked(f)
+		f.cancel()
+	}
+}
+
+// removeLocked removes the rangefeed so that no new consumers join it.
+func (s *sharedRangeFeeds) removeLocked(f *sharedRangeFeed) {
+	feeds := s.mu.feeds[f.key]
+	for i := range feeds {
+		if feeds[i] == f {
+			feeds = append(feeds[:i], feeds[i+1:]...)
+			break
+		}
+	}
+	if len(feeds) == 0 {
+		delete(s.mu.feeds, f.key)
+	} else {
+		s.mu.feeds[f.key] = feeds
+	}
+}
+
+// numFeeds returns the number of running shared rangefeeds.
+func (s *sharedRangeFeeds) numFeeds() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var n int
+	for _, feeds := range s.mu.feeds {
+		n += len(feeds)
+	}
+	return n
+}
+
+// sharedRangeFeed is a rangefeed shared by one or more consumers.
+type sharedRangeFeed struct {
+	feeds      *sharedRangeFeeds
+	key        sharedRangeFeedKey
+	startAfter hlc.Timestamp
+	cancel     context.CancelFunc
+
+	mu struct {
+		syncutil.Mutex
+		consumers map[*sharedRangeFeedConsumer]struct{}
+		// maxEmitted is the highest timestamp of the values fanned out so far.
+		maxEmitted hlc.Timestamp
+		// done is set once the rangefeed stopped or is stopping, after which no
+		// consumers can join it.
+		done bool
+	}
+}
+
+// tryJoin adds the consumer to the rangefeed if it starts late enough to not
+// need any of the values which have already been fanned out.
+func (f *sharedRangeFeed) tryJoin(c *sharedRangeFeedConsumer) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.mu.done || c.startAfter.Less(f.startAfter) || c.startAfter.Less(f.mu.maxEmitted) {
+		return false
+	}
+	f.mu.consumers[c] = struct{}{}
+	return true
+}
+
+func (f *sharedRangeFeed) run(ctx context.Context, cfg rangeFeedConfig, spans []SpanTimePair) {
+	eventCh := make(chan RangeFeedMessage, sharedRangeFeedEventChSize)
+	g := ctxgroup.WithContext(ctx)
+	g.GoCtx(func(ctx context.Context) error {
+		for {
+			select {
+			case e := <-eventCh:
+				if err := f.fanOut(ctx, e); err != nil {
+					return err
+				}
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	})
+	g.GoCtx(func(ctx context.Context) error {
+		return f.feeds.rangeFeed(ctx, cfg, spans, eventCh)
+	})
+	err := g.Wait()
+	if err == nil {
+		err = errors.AssertionFailedf("shared rangefeed exited with no error")
+	}
+	f.finish(err)
+}
+
+// fanOut queues the event for every consumer which needs it. A consumer whose
+// queue would exceed its budget is detached from the rangefeed instead.
+func (f *sharedRangeFeed) fanOut(ctx context.Context, e RangeFeedMessage) error {
+	budget := sharedRangeFeedConsumerBudget.Get(&f.feeds.st.SV)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	// The high watermark is advanced before the event is queued, so that any
+	// consumer which joins from now on doesn't miss it.
+	f.mu.maxEmitted.Forward(maxValueTimestamp(e.RangeFeedEvent))
+	for c := range f.mu.consumers {
+		ce, ok := c.filter(e)
+		if !ok {
+			continue
+		}
+		if !c.push(ce, budget) {
+			log.VEventf(ctx, 1, "detaching consumer of shared rangefeed with %d bytes queued", c.queuedBytes())
+			delete(f.mu.consumers, c)
+			c.fail(errors.Wrapf(errSharedRangeFeedConsumerDetached, "budget of %d bytes exceeded", budget))
+		}
+	}
+	return nil
+}
+
+// finish hands the error which stopped the rangefeed to all its consumers.
+func (f *sharedRangeFeed) finish(err error) {
+	f.feeds.mu.Lock()
+	defer f.feeds.mu.Unlock()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.mu.done {
+		f.mu.done = true
+		f.feeds.removeLocked(f)
+	}
+	for c := range f.mu.consumers {
+		c.fail(err)
+	}
+	f.cancel()
+}
+
+// maxValueTimestamp returns the highest timestamp of the values in the event.
+func maxValueTimestamp(e *kvpb.RangeFeedEvent) hlc.Timestamp {
+	switch t := e.GetValue().(type) {
+	case *kvpb.RangeFeedValue:
+		return t.Value.Timestamp
+	case *kvpb.RangeFeedBulkEvents:
+		var ts hlc.Timestamp
+		for _, e := range t.Events {
+			ts.Forward(maxValueTimestamp(e))
+		}
+		return ts
+	case *kvpb.RangeFeedDeleteRange:
+		return t.Timestamp
+	case *kvpb.RangeFeedSSTable:
+		return t.WriteTS
+	}
+	return hlc.Timestamp{}
+}
+
+// sharedRangeFeedEventChSize is the size of the channel on which the
+// underlying rangefeed of a shared rangefeed emits its events.
+const sharedRangeFeedEventChSize = 128
+
+// sharedRangeFeedConsumer is a consumer of a sharedRangeFeed.
+type sharedRangeFeedConsumer struct {
+	// startAfter is the consumer's exclusive start time. Values at or below it
+	// are not delivered to the consumer.
+	startAfter hlc.Timestamp
+	// notify receives a signal when events are queued for the consumer.
+	notify chan struct{}
+	// errCh receives the error which stopped the rangefeed.
+	errCh chan error
+
+	mu struct {
+		syncutil.Mutex
+		// events queues the events of the rangefeed until the consumer
+		// delivers them.
+		events []RangeFeedMessage
+		// bytes is the size of the queued events.
+		bytes int64
+	}
+}
+
+// push queues the event for the consumer, unless the queued events would then
+// exceed the budget. An event is always queued if the queue is empty, so that
+// a single large event can't detach a consumer which keeps up.
+func (c *sharedRangeFeedConsumer) push(e RangeFeedMessage, budget int64) bool {
+	sz := int64(e.RangeFeedEvent.Size())
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.mu.events) > 0 && c.mu.bytes+sz > budget {
+		return false
+	}
+	c.mu.events = append(c.mu.events, e)
+	c.mu.bytes += sz
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// take appends the queued events to buf, and removes them from the queue.
+func (c *sharedRangeFeedConsumer) take(buf []RangeFeedMessage) []RangeFeedMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	buf = append(buf, c.mu.events...)
+	clear(c.mu.events)
+	c.mu.events = c.mu.events[:0]
+	c.mu.bytes = 0
+	return buf
+}
+
+// queuedBytes returns the size of the queued events.
+func (c *sharedRangeFeedConsumer) queuedBytes() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.mu.bytes
+}
+
+// filter returns the event without the values, range deletions and SSTables
+// at or below the consumer's start time, or false if there is nothing left to
+// deliver to the consumer.
+func (c *sharedRangeFeedConsumer) filter(e RangeFeedMessage) (RangeFeedMessage, bool) {
+	switch t := e.GetValue().(type) {
+	case *kvpb.RangeFeedValue, *kvpb.RangeFeedDeleteRange, *kvpb.RangeFeedSSTable:
+		return e, c.startAfter.Less(maxValueTimestamp(e.RangeFeedEvent))
+	case *kvpb.RangeFeedBulkEvents:
+		skip := func(ev *kvpb.RangeFeedEvent) bool {
+			switch ev.GetValue().(type) {
+			case *kvpb.RangeFeedValue, *kvpb.RangeFeedDeleteRange, *kvpb.RangeFeedSSTable:
+				return maxValueTimestamp(ev).LessEq(c.startAfter)
+			}
+			return false
+		}
+		// The events are shared with the other consumers, so they are only
+		// copied if some of them must be skipped.
+		if !slices.ContainsFunc(t.Events, skip) {
+			return e, true
+		}
+		events := make([]*kvpb.RangeFeedEvent, 0, len(t.Events))
+		for _, ev := range t.Events {
+			if !skip(ev) {
+				events = append(events, ev)
+			}
+		}
+		if len(events) == 0 {
+			return RangeFeedMessage{}, false
+		}
+		return RangeFeedMessage{
+			RangeFeedEvent: &kvpb.RangeFeedEvent{BulkEvents: &kvpb.RangeFeedBulkEvents{Events: events}},
+			RegisteredSpan: e.RegisteredSpan,
+		}, true
+	}
+	return e, true
+}
+
+func (c *sharedRangeFeedConsumer) fail(err error) {
+	select {
+	case c.errCh <- err:
+	default:
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package kvcoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharedRangeFeeds(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+	st := cluster.MakeTestingClusterSettings()
+	sharedRangeFeedConsumerBudget.Override(ctx, &st.SV, 1<<10)
+
+	// Each underlying rangefeed publishes its event channel, so that the test
+	// controls which events it emits.
+	feeds := make(chan chan<- RangeFeedMessage)
+	s := newSharedRangeFeeds(func(
+		ctx context.Context, _ rangeFeedConfig, _ []SpanTimePair, eventCh chan<- RangeFeedMessage,
+	) error {
+		select {
+		case feeds <- eventCh:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	}, stopper, st)
+
+	sp := roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("b")}
+	ts := func(wallTime int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wallTime} }
+
+	type consumer struct {
+		eventCh chan RangeFeedMessage
+		cancel  context.CancelFunc
+		g       ctxgroup.Group
+	}
+	start := func(startAfter hlc.Timestamp, opts ...RangeFeedOption) *consumer {
+		c := &consumer{eventCh: make(chan RangeFeedMessage)}
+		var cfg rangeFeedConfig
+		for _, opt := range append(opts, WithSharing()) {
+			opt.set(&cfg)
+		}
+		spans := []SpanTimePair{{Span: sp, StartAfter: startAfter}}
+		key, startAfter, ok := makeSharedRangeFeedKey(&cfg, spans)
+		require.True(t, ok)
+		var cctx context.Context
+		cctx, c.cancel = context.WithCancel(ctx)
+		c.g = ctxgroup.WithContext(cctx)
+		c.g.GoCtx(func(ctx context.Context) error {
+			return s.run(ctx, key, startAfter, cfg, spans, c.eventCh)
+		})
+		return c
+	}
+	stopConsumer := func(c *consumer) {
+		c.cancel()
+		require.True(t, errors.Is(c.g.Wait(), context.Canceled))
+	}
+	numConsumers := func() int {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		var n int
+		for _, fs := range s.mu.feeds {
+			for _, f := range fs {
+				f.mu.Lock()
+				n += len(f.mu.consumers)
+				f.mu.Unlock()
+			}
+		}
+		return n
+	}
+	waitForConsumers := func(n int) {
+		testutils.SucceedsSoon(t, func() error {
+			if actual := numConsumers(); actual != n {
+				return errors.Newf("expected %d consumers, found %d", n, actual)
+			}
+			return nil
+		})
+	}
+	value := func(wallTime int64) *kvpb.RangeFeedEvent {
+		return &kvpb.RangeFeedEvent{Val: &kvpb.RangeFeedValue{
+			Key:   roachpb.Key("a"),
+			Value: roachpb.Value{Timestamp: ts(wallTime)},
+		}}
+	}
+	emit := func(feed chan<- RangeFeedMessage, wallTime int64) {
+		feed <- RangeFeedMessage{RangeFeedEvent: value(wallTime)}
+	}
+	expectValue := func(c *consumer, wallTime int64) {
+		e := <-c.eventCh
+		require.NotNil(t, e.Val)
+		require.Equal(t, ts(wallTime), e.Val.Value.Timestamp)
+	}
+
+	// Two consumers of the same spans, starting at the same time, share one
+	// rangefeed.
+	a := start(ts(10))
+	feed1 := <-feeds
+	b := start(ts(10))
+	waitForConsumers(2)
+	require.Equal(t, 1, s.numFeeds())
+	emit(feed1, 15)
+	expectValue(a, 15)
+	expectValue(b, 15)
+
+	// A consumer which needs values that were already emitted starts its own
+	// rangefeed, as does a consumer with different options.
+	c := start(ts(12))
+	feed2 := <-feeds
+	d := start(ts(20), WithDiff())
+	feed3 := <-feeds
+	waitForConsumers(4)
+	require.Equal(t, 3, s.numFeeds())
+
+	// A consumer which starts after all the values emitted so far joins the
+	// first rangefeed, and only sees the values after its start time.
+	e := start(ts(20))
+	waitForConsumers(5)
+	require.Equal(t, 3, s.numFeeds())
+	emit(feed1, 18)
+	emit(feed1, 25)
+	expectValue(a, 18)
+	expectValue(b, 18)
+	expectValue(a, 25)
+	expectValue(b, 25)
+	expectValue(e, 25)
+	emit(feed2, 13)
+	expectValue(c, 13)
+	emit(feed3, 21)
+	expectValue(d, 21)
+
+	// Bulk events are filtered per consumer.
+	feed1 <- RangeFeedMessage{RangeFeedEvent: &kvpb.RangeFeedEvent{
+		BulkEvents: &kvpb.RangeFeedBulkEvents{Events: []*kvpb.RangeFeedEvent{value(19), value(26)}},
+	}}
+	for _, c := range []*consumer{a, b} {
+		ev := <-c.eventCh
+		require.Len(t, ev.BulkEvents.Events, 2)
+	}
+	ev := <-e.eventCh
+	require.Len(t, ev.BulkEvents.Events, 1)
+	require.Equal(t, ts(26), ev.BulkEvents.Events[0].Val.Value.Timestamp)
+
+	// So are range deletions and SSTables.
+	feed1 <- RangeFeedMessage{RangeFeedEvent: &kvpb.RangeFeedEvent{
+		DeleteRange: &kvpb.RangeFeedDeleteRange{Span: sp, Timestamp: ts(19)},
+	}}
+	feed1 <- RangeFeedMessage{RangeFeedEvent: &kvpb.RangeFeedEvent{
+		SST: &kvpb.RangeFeedSSTable{Span: sp, WriteTS: ts(20)},
+	}}
+	feed1 <- RangeFeedMessage{RangeFeedEvent: &kvpb.RangeFeedEvent{
+		SST: &kvpb.RangeFeedSSTable{Span: sp, WriteTS: ts(27)},
+	}}
+	for _, c := range []*consumer{a, b} {
+		require.Equal(t, ts(19), (<-c.eventCh).DeleteRange.Timestamp)
+		require.Equal(t, ts(20), (<-c.eventCh).SST.WriteTS)
+		require.Equal(t, ts(27), (<-c.eventCh).SST.WriteTS)
+	}
+	require.Equal(t, ts(27), (<-e.eventCh).SST.WriteTS)
+	feed1 <- RangeFeedMessage{RangeFeedEvent: &kvpb.RangeFeedEvent{
+		BulkEvents: &kvpb.RangeFeedBulkEvents{Events: []*kvpb.RangeFeedEvent{
+			{DeleteRange: &kvpb.RangeFeedDeleteRange{Span: sp, Timestamp: ts(20)}},
+			{DeleteRange: &kvpb.RangeFeedDeleteRange{Span: sp, Timestamp: ts(28)}},
+		}},
+	}}
+	for _, c := range []*consumer{a, b} {
+		require.Len(t, (<-c.eventCh).BulkEvents.Events, 2)
+	}
+	ev = <-e.eventCh
+	require.Len(t, ev.BulkEvents.Events, 1)
+	require.Equal(t, ts(28), ev.BulkEvents.Events[0].DeleteRange.Timestamp)
+
+	// A consumer which doesn't read its events doesn't keep the others from
+	// receiving theirs, and is detached from the rangefeed once its queued
+	// events exceed its budget.
+	for i := int64(0); i < 200; i++ {
+		emit(feed1, 30+i)
+		expectValue(a, 30+i)
+		expectValue(b, 30+i)
+	}
+	waitForConsumers(4)
+	require.True(t, errors.Is(e.g.Wait(), errSharedRangeFeedConsumerDetached))
+	e.cancel()
+	require.Equal(t, 3, s.numFeeds())
+
+	// The rangefeed keeps running for the remaining consumers when one of them
+	// leaves, and stops when the last one leaves.
+	stopConsumer(a)
+	emit(feed1, 300)
+	expectValue(b, 300)
+	stopConsumer(b)
+	require.Equal(t, 2, s.numFeeds())
+	stopConsumer(c)
+	stopConsumer(d)
+	require.Equal(t, 0, s.numFeeds())
+}
+
+func TestSharedRangeFeedKey(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	spA := roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("b")}
+	spC := roachpb.Span{Key: roachpb.Key("c"), EndKey: roachpb.Key("d")}
+	key := func(spans []SpanTimePair, opts ...RangeFeedOption) (sharedRangeFeedKey, bool) {
+		var cfg rangeFeedConfig
+		for _, opt := range opts {
+			opt.set(&cfg)
+		}
+		k, _, ok := makeSharedRangeFeedKey(&cfg, spans)
+		return k, ok
+	}
+
+	// The order of the spans and the consumer ID don't matter.
+	k1, ok := key([]SpanTimePair{{Span: spA}, {Span: spC}}, WithConsumerID(1))
+	require.True(t, ok)
+	k2, ok := key([]SpanTimePair{{Span: spC}, {Span: spA}}, WithConsumerID(2))
+	require.True(t, ok)
+	require.Equal(t, k1, k2)
+
+	// The options do.
+	k3, ok := key([]SpanTimePair{{Span: spA}, {Span: spC}}, WithDiff())
+	require.True(t, ok)
+	require.NotEqual(t, k1, k3)
+	k4, ok := key([]SpanTimePair{{Span: spA}, {Span: spC}}, WithMatchingOriginIDs(1))
+	require.True(t, ok)
+	require.NotEqual(t, k1, k4)
+
+	// Spans starting at different times, or range observers, prevent sharing.
+	_, ok = key([]SpanTimePair{{Span: spA}, {Span: spC, StartAfter: hlc.Timestamp{WallTime: 1}}})
+	require.False(t, ok)
+	_, ok = key([]SpanTimePair{{Span: spA}}, WithRangeObserver(func(ForEachRangeFn) {}))
+	require.False(t, ok)
+}
@@ -1695,6 +1695,13 @@ func (tc *TxnCoordSender) HasBufferedWrites() bool {
 	return tc.hasBufferedWritesLocked()
 }
 
+// WriteBufferStats is part of the TxnSender interface.
+func (tc *TxnCoordSender) WriteBufferStats() kv.WriteBufferStats {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	return tc.interceptorAlloc.txnWriteBuffer.stats
+}
+
 // TestingShouldRetry is part of the TxnSender interface.
 func (tc *TxnCoordSender) TestingShouldRetry() bool {
 	tc.mu.Lock()
@@ -15,6 +15,7 @@ import (
 	"strings"
 	"unsafe"
 
+	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvnemesis/kvnemesisutil"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
@@ -227,6 +228,9 @@ type txnWriteBuffer struct {
 	wrapped    lockedSender
 	txnMetrics *TxnMetrics
 
+	// stats records how effective write buffering was for the transaction.
+	stats kv.WriteBufferStats
+
 	// testingOverrideCPutEvalFn is used to mock the evaluation function for
 	// conditional puts. Intended only for tests.
 	testingOverrideCPutEvalFn func(expBytes []byte, actVal *roachpb.Value, actValPresent bool, allowNoExisting bool) *kvpb.ConditionFailedError
@@ -262,7 +266,7 @@ func (twb *txnWriteBuffer) SendLocked(
 ) (_ *kvpb.BatchResponse, pErr *kvpb.Error) {
 	if twb.flushOnNextBatch {
 		twb.flushOnNextBatch = false
-		return twb.flushBufferAndSendBatch(ctx, ba)
+		return twb.flushBufferAndSendBatch(ctx, ba, kv.WriteBufferFlushedDisabled)
 	}
 
 	if !twb.shouldBuffer() {
@@ -284,7 +288,7 @@ func (twb *txnWriteBuffer) SendLocked(
 			// anything.
 			return twb.wrapped.SendLocked(ctx, ba)
 		}
-		return twb.flushBufferAndSendBatch(ctx, ba)
+		return twb.flushBufferAndSendBatch(ctx, ba, kv.WriteBufferFlushedOnCommit)
 	}
 
 	// We check if scan transforms are enabled once and use that answer until the
@@ -295,7 +299,7 @@ func (twb *txnWriteBuffer) SendLocked(
 	}
 
 	if twb.batchRequiresFlush(ctx, ba, cfg) {
-		return twb.flushBufferAndSendBatch(ctx, ba)
+		return twb.flushBufferAndSendBatch(ctx, ba, kv.WriteBufferFlushedUnsupportedRequest)
 	}
 
 	// Check if buffering writes from the supplied batch will run us over
@@ -311,7 +315,7 @@ func (twb *txnWriteBuffer) SendLocked(
 		log.VEventf(ctx, 2, "flushing buffer because buffer size (%s) exceeds max size (%s)",
 			humanizeutil.IBytes(bufSize),
 			humanizeutil.IBytes(maxSize))
-		return twb.flushBufferAndSendBatch(ctx, ba)
+		return twb.flushBufferAndSendBatch(ctx, ba, kv.WriteBufferFlushedMemoryLimit)
 	}
 
 	if err := twb.validateBatch(ba); err != nil {
@@ -960,6 +964,9 @@ func (twb *txnWriteBuffer) applyTransformations(
 			// If the key is in the buffer, we must serve the read from the buffer.
 			// The actual serving of the read will happen on the response path though.
 			_, lockStr, served := twb.maybeServeRead(t.Key, t.Sequence)
+			if served {
+				twb.stats.ReadHits++
+			}
 
 			requiresAdditionalLocking := t.KeyLockingStrength > lockStr
 			requiresLockTransform := IsReplicatedLockingRequest(t)
@@ -1684,6 +1691,7 @@ func (twb *txnWriteBuffer) addToBuffer(
 			}
 		}
 		twb.bufferSize += val.size()
+		twb.stats.BufferedBytes += val.size()
 	} else {
 		twb.bufferIDAlloc++
 		bw := &bufferedWrite{
@@ -1697,7 +1705,10 @@ func (twb *txnWriteBuffer) addToBuffer(
 		}
 		twb.buffer.Set(bw)
 		twb.bufferSize += bw.size()
+		twb.stats.BufferedBytes += bw.size()
 	}
+	twb.stats.BufferedWrites++
+	twb.stats.PeakBufferBytes = max(twb.stats.PeakBufferBytes, twb.bufferSize)
 }
 
 func IsReplicatedLockingRequest(req kvpb.Request) bool {
@@ -1749,13 +1760,14 @@ func (twb *txnWriteBuffer) removeFromBuffer(bw *bufferedWrite) {
 
 // flushBufferAndSendBatch flushes all buffered writes when sending the supplied
 // batch request to the KV layer. This is done by pre-pending the buffered
-// writes to the requests in the batch.
+// writes to the requests in the batch. The reason for the flush is recorded in
+// the transaction's write buffer stats.
 //
 // The response is transformed to hide the fact that requests were added to the
 // batch to flush the buffer. Upper layers remain oblivious to the flush and any
 // buffering in general.
 func (twb *txnWriteBuffer) flushBufferAndSendBatch(
-	ctx context.Context, ba *kvpb.BatchRequest,
+	ctx context.Context, ba *kvpb.BatchRequest, reason kv.WriteBufferFlushReason,
 ) (*kvpb.BatchResponse, *kvpb.Error) {
 	defer func() {
 		assertTrue(twb.buffer.Len() == 0, "buffer should be empty after flush")
@@ -1775,6 +1787,9 @@ func (twb *txnWriteBuffer) flushBufferAndSendBatch(
 		defer twb.pipelineEnabler.enableImplicitPipelining()
 	}
 	twb.flushed = true
+	if twb.stats.FlushReason == kv.WriteBufferNotFlushed {
+		twb.stats.FlushReason = reason
+	}
 
 	numKeysBuffered := twb.buffer.Len()
 	if numKeysBuffered == 0 {
@@ -1821,6 +1836,7 @@ func (twb *txnWriteBuffer) flushBufferAndSendBatch(
 		}
 	}
 	twb.resetBuffer()
+	twb.stats.FlushedWrites += int64(numRevisionsBuffered)
 
 	// Layers below us expect that writes inside a batch are in sequence number
 	// order but the iterator above returns data in key order. Here we re-sort it
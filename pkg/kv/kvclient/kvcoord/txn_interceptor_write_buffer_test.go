@@ -13,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -1643,6 +1644,8 @@ func TestTxnWriteBufferFlushesWhenOverBudget(t *testing.T) {
 	require.IsType(t, &kvpb.DeleteResponse{}, br.Responses[0].GetInner())
 	require.Equal(t, int64(1), twb.txnMetrics.TxnWriteBufferDisabledAfterBuffering.Count())
 	require.Equal(t, int64(1), twb.txnMetrics.TxnWriteBufferMemoryLimitExceeded.Count())
+	require.Equal(t, kv.WriteBufferFlushedMemoryLimit, twb.stats.FlushReason)
+	require.Equal(t, int64(1), twb.stats.FlushedWrites)
 
 	// Ensure the buffer is empty at this point.
 	require.Equal(t, 0, len(twb.testingBufferedWritesAsSlice()))
@@ -2925,7 +2928,7 @@ func BenchmarkTxnWriteBuffer(b *testing.B) {
 				// All requests correspond to writes that will be stored in the buffer.
 				twb := makeBuffer(kvSize, &txn, numRequests)
 				twb.flushOnNextBatch = true
-				_, pErr := twb.flushBufferAndSendBatch(ctx, ba)
+				_, pErr := twb.flushBufferAndSendBatch(ctx, ba, kv.WriteBufferFlushedDisabled)
 				if pErr != nil {
 					b.Fatal(pErr)
 				}
@@ -4187,3 +4190,70 @@ needs to be accounted for in the following functions:
 		}
 	}
 }
+
+// TestTxnWriteBufferStats verifies that the txnWriteBuffer records how many
+// writes it buffered and flushed, how many reads it served from the buffer, and
+// why the buffer was flushed.
+func TestTxnWriteBufferStats(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	twb, mockSender, _ := makeMockTxnWriteBuffer(ctx)
+
+	txn := makeTxnProto()
+	txn.Sequence = 10
+	keyA, keyB, keyC := roachpb.Key("a"), roachpb.Key("b"), roachpb.Key("c")
+
+	// Buffer two writes to keyA and one to keyB.
+	ba := &kvpb.BatchRequest{}
+	ba.Header = kvpb.Header{Txn: &txn}
+	ba.Add(putArgs(keyA, "valA", txn.Sequence), putArgs(keyB, "valB", txn.Sequence))
+	_, pErr := twb.SendLocked(ctx, ba)
+	require.Nil(t, pErr)
+	ba = &kvpb.BatchRequest{}
+	ba.Header = kvpb.Header{Txn: &txn}
+	ba.Add(putArgs(keyA, "valA2", txn.Sequence+1))
+	_, pErr = twb.SendLocked(ctx, ba)
+	require.Nil(t, pErr)
+
+	stats := twb.stats
+	require.Equal(t, int64(3), stats.BufferedWrites)
+	require.Equal(t, int64(0), stats.FlushedWrites)
+	require.Equal(t, twb.bufferSize, stats.PeakBufferBytes)
+	require.Equal(t, twb.bufferSize, stats.BufferedBytes)
+	require.Equal(t, kv.WriteBufferNotFlushed, stats.FlushReason)
+
+	// A read of keyA is served from the buffer, while a read of keyC is not.
+	ba = &kvpb.BatchRequest{}
+	ba.Header = kvpb.Header{Txn: &txn}
+	ba.Add(getArgs(keyA), getArgs(keyC))
+	mockSender.MockSend(func(ba *kvpb.BatchRequest) (*kvpb.BatchResponse, *kvpb.Error) {
+		require.Len(t, ba.Requests, 1)
+		br := ba.CreateReply()
+		br.Txn = ba.Txn
+		return br, nil
+	})
+	_, pErr = twb.SendLocked(ctx, ba)
+	require.Nil(t, pErr)
+	require.Equal(t, int64(1), twb.stats.ReadHits)
+
+	// Committing flushes every buffered revision at the final sequence number,
+	// so the writes to keyA are coalesced.
+	ba = &kvpb.BatchRequest{}
+	ba.Header = kvpb.Header{Txn: &txn}
+	ba.Add(&kvpb.EndTxnRequest{Commit: true})
+	mockSender.MockSend(func(ba *kvpb.BatchRequest) (*kvpb.BatchResponse, *kvpb.Error) {
+		br := ba.CreateReply()
+		br.Txn = ba.Txn
+		return br, nil
+	})
+	_, pErr = twb.SendLocked(ctx, ba)
+	require.Nil(t, pErr)
+
+	stats = twb.stats
+	require.Equal(t, int64(3), stats.BufferedWrites)
+	require.Equal(t, int64(2), stats.FlushedWrites)
+	require.Equal(t, kv.WriteBufferFlushedOnCommit, stats.FlushReason)
+	require.False(t, stats.FlushReason.Forced())
+	require.Equal(t, "commit", stats.FlushReason.String())
+}
@@ -38,6 +38,7 @@ type config struct {
 
 	withDiff              bool
 	withFiltering         bool
+	withSharing           bool
 	withMatchingOriginIDs []uint32
 	consumerID            int64
 	onUnrecoverableError  OnUnrecoverableError
@@ -154,6 +155,15 @@ func WithFiltering(withFiltering bool) Option {
 	})
 }
 
+// WithSharing makes an option to set whether the rangefeed may share a single
+// rangefeed, and catch-up scan, with the other rangefeeds on the node which
+// watch the same spans with the same options. See kvcoord.WithSharing.
+func WithSharing(withSharing bool) Option {
+	return optionFunc(func(c *config) {
+		c.withSharing = withSharing
+	})
+}
+
 func WithOriginIDsMatching(originIDs ...uint32) Option {
 	return optionFunc(func(c *config) {
 		c.withMatchingOriginIDs = originIDs
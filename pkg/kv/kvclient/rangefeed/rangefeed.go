@@ -349,6 +349,9 @@ func (f *RangeFeed) run(ctx context.Context, frontier span.Frontier, resumeWithF
 	if f.withFiltering {
 		rangefeedOpts = append(rangefeedOpts, kvcoord.WithFiltering())
 	}
+	if f.withSharing {
+		rangefeedOpts = append(rangefeedOpts, kvcoord.WithSharing())
+	}
 	if len(f.withMatchingOriginIDs) != 0 {
 		rangefeedOpts = append(rangefeedOpts, kvcoord.WithMatchingOriginIDs(f.withMatchingOriginIDs...))
 	}
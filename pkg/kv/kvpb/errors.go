@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"reflect"
 	"slices"
+	"strconv"
 
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
@@ -1799,6 +1800,67 @@ func NewKeyCollisionError(key roachpb.Key, value []byte) error {
 	return ret
 }
 
+// ReadOnlySpanError is returned when a request attempts to write to a span
+// whose span config marks it read-only, e.g. the span of a table which has been
+// set READ ONLY.
+type ReadOnlySpanError struct {
+	Span roachpb.Span
+}
+
+// Format implements fmt.Formatter.
+func (e *ReadOnlySpanError) Format(s fmt.State, verb rune) {
+	errors.FormatError(e, s, verb)
+}
+
+func (e *ReadOnlySpanError) SafeFormatError(p errors.Printer) (next error) {
+	p.Printf("cannot write to read-only span %s", e.Span)
+	return nil
+}
+
+func (e *ReadOnlySpanError) Error() string {
+	return fmt.Sprint(e)
+}
+
+// NewReadOnlySpanError constructs a ReadOnlySpanError, copying its input.
+func NewReadOnlySpanError(span roachpb.Span) error {
+	return &ReadOnlySpanError{Span: span.Clone()}
+}
+
+// TenantStorageQuotaExceededError is returned when a tenant whose logical
+// bytes exceed its storage quota attempts to write data to its keyspace.
+type TenantStorageQuotaExceededError struct {
+	TenantID     roachpb.TenantID
+	LogicalBytes int64
+	QuotaBytes   int64
+}
+
+// NewTenantStorageQuotaExceededError creates a new
+// TenantStorageQuotaExceededError.
+func NewTenantStorageQuotaExceededError(
+	tenantID roachpb.TenantID, logicalBytes, quotaBytes int64,
+) *TenantStorageQuotaExceededError {
+	return &TenantStorageQuotaExceededError{
+		TenantID:     tenantID,
+		LogicalBytes: logicalBytes,
+		QuotaBytes:   quotaBytes,
+	}
+}
+
+// Format implements fmt.Formatter.
+func (e *TenantStorageQuotaExceededError) Format(s fmt.State, verb rune) {
+	errors.FormatError(e, s, verb)
+}
+
+func (e *TenantStorageQuotaExceededError) SafeFormatError(p errors.Printer) (next error) {
+	p.Printf("tenant %s has exceeded its storage quota: %d logical bytes stored, quota is %d bytes",
+		e.TenantID, e.LogicalBytes, e.QuotaBytes)
+	return nil
+}
+
+func (e *TenantStorageQuotaExceededError) Error() string {
+	return fmt.Sprint(e)
+}
+
 // NewExclusionViolationError creates a new ExclusionViolationError. This error
 // is returned by requests that encounter an existing value written at a
 // timestamp at which they expected to have an exclusive lock on the key. This
@@ -1894,6 +1956,79 @@ func decodeKeyCollisionError(_ context.Context, _ string, _ []string, payload pr
 	}
 }
 
+func encodeReadOnlySpanError(
+	_ context.Context, err error,
+) (msgPrefix string, safe []string, details proto.Message) {
+	t := err.(*ReadOnlySpanError)
+	details = &errorspb.StringsPayload{
+		Details: []string{
+			base64.StdEncoding.EncodeToString(t.Span.Key),
+			base64.StdEncoding.EncodeToString(t.Span.EndKey),
+		},
+	}
+	msgPrefix = "cannot write to read-only span"
+	return msgPrefix, nil, details
+}
+
+func decodeReadOnlySpanError(
+	_ context.Context, _ string, _ []string, payload proto.Message,
+) error {
+	m, ok := payload.(*errorspb.StringsPayload)
+	if !ok || len(m.Details) < 2 {
+		return nil
+	}
+	key, decodeErr := base64.StdEncoding.DecodeString(m.Details[0])
+	if decodeErr != nil {
+		return nil //nolint:returnerrcheck
+	}
+	endKey, decodeErr := base64.StdEncoding.DecodeString(m.Details[1])
+	if decodeErr != nil {
+		return nil //nolint:returnerrcheck
+	}
+	return &ReadOnlySpanError{Span: roachpb.Span{Key: key, EndKey: endKey}}
+}
+
+func encodeTenantStorageQuotaExceededError(
+	_ context.Context, err error,
+) (msgPrefix string, safe []string, details proto.Message) {
+	t := err.(*TenantStorageQuotaExceededError)
+	details = &errorspb.StringsPayload{
+		Details: []string{
+			strconv.FormatUint(t.TenantID.ToUint64(), 10),
+			strconv.FormatInt(t.LogicalBytes, 10),
+			strconv.FormatInt(t.QuotaBytes, 10),
+		},
+	}
+	msgPrefix = "tenant has exceeded its storage quota"
+	return msgPrefix, nil, details
+}
+
+func decodeTenantStorageQuotaExceededError(
+	_ context.Context, _ string, _ []string, payload proto.Message,
+) error {
+	m, ok := payload.(*errorspb.StringsPayload)
+	if !ok || len(m.Details) < 3 {
+		return nil
+	}
+	tenantID, err := strconv.ParseUint(m.Details[0], 10, 64)
+	if err != nil {
+		return nil //nolint:returnerrcheck
+	}
+	logicalBytes, err := strconv.ParseInt(m.Details[1], 10, 64)
+	if err != nil {
+		return nil //nolint:returnerrcheck
+	}
+	quotaBytes, err := strconv.ParseInt(m.Details[2], 10, 64)
+	if err != nil {
+		return nil //nolint:returnerrcheck
+	}
+	return &TenantStorageQuotaExceededError{
+		TenantID:     roachpb.MustMakeTenantID(tenantID),
+		LogicalBytes: logicalBytes,
+		QuotaBytes:   quotaBytes,
+	}
+}
+
 func init() {
 	errors.RegisterLeafDecoder(errors.GetTypeKey((*MissingRecordError)(nil)), func(_ context.Context, _ string, _ []string, _ proto.Message) error {
 		return &MissingRecordError{}
@@ -1901,6 +2036,12 @@ func init() {
 	collisionErrorKey := errors.GetTypeKey((*KeyCollisionError)(nil))
 	errors.RegisterLeafEncoder(collisionErrorKey, encodeKeyCollisionError)
 	errors.RegisterLeafDecoder(collisionErrorKey, decodeKeyCollisionError)
+	readOnlySpanErrorKey := errors.GetTypeKey((*ReadOnlySpanError)(nil))
+	errors.RegisterLeafEncoder(readOnlySpanErrorKey, encodeReadOnlySpanError)
+	errors.RegisterLeafDecoder(readOnlySpanErrorKey, decodeReadOnlySpanError)
+	quotaErrorKey := errors.GetTypeKey((*TenantStorageQuotaExceededError)(nil))
+	errors.RegisterLeafEncoder(quotaErrorKey, encodeTenantStorageQuotaExceededError)
+	errors.RegisterLeafDecoder(quotaErrorKey, decodeTenantStorageQuotaExceededError)
 	errorutilpath := reflect.TypeOf(errorutil.TempSentinel{}).PkgPath()
 	errors.RegisterTypeMigration(errorutilpath, "*errorutil.descriptorNotFound", &DescNotFoundError{})
 }
@@ -1942,4 +2083,6 @@ var _ errors.SafeFormatter = &UnhandledRetryableError{}
 var _ errors.SafeFormatter = &ReplicaUnavailableError{}
 var _ errors.SafeFormatter = &ProxyFailedError{}
 var _ errors.SafeFormatter = &KeyCollisionError{}
+var _ errors.SafeFormatter = &ReadOnlySpanError{}
+var _ errors.SafeFormatter = &TenantStorageQuotaExceededError{}
 var _ errors.SafeFormatter = &ExclusionViolationError{}
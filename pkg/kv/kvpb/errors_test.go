@@ -442,3 +442,30 @@ func TestProxyFailedError(t *testing.T) {
 
 	require.Equal(t, `foo`, string(redact.Sprint(internalErr).Redact()))
 }
+
+// TestReadOnlySpanError validates that ReadOnlySpanErrors can be cleanly
+// encoded and decoded.
+func TestReadOnlySpanError(t *testing.T) {
+	ctx := context.Background()
+	span := roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("b")}
+	err := NewReadOnlySpanError(span)
+	require.Contains(t, err.Error(), `cannot write to read-only span`)
+
+	decodedErr := errors.DecodeError(ctx, errors.EncodeError(ctx, err))
+	var roErr *ReadOnlySpanError
+	require.True(t, errors.As(decodedErr, &roErr))
+	require.Equal(t, span, roErr.Span)
+	require.Equal(t, err.Error(), decodedErr.Error())
+}
+
+func TestTenantStorageQuotaExceededError(t *testing.T) {
+	ctx := context.Background()
+	err := NewTenantStorageQuotaExceededError(roachpb.MustMakeTenantID(10), 2048, 1024)
+	require.Equal(t, `tenant 10 has exceeded its storage quota: 2048 logical bytes stored, quota is 1024 bytes`, err.Error())
+
+	decodedErr := errors.DecodeError(ctx, errors.EncodeError(ctx, err))
+	var quotaErr *TenantStorageQuotaExceededError
+	require.True(t, errors.As(decodedErr, &quotaErr))
+	require.Equal(t, err, quotaErr)
+	require.Equal(t, err.Error(), decodedErr.Error())
+}
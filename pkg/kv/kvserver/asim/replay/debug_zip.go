@@ -0,0 +1,138 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package replay
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/server/status/statuspb"
+	"github.com/cockroachdb/errors"
+)
+
+// The files written per node by `cockroach debug zip` which are read to
+// construct a snapshot.
+const (
+	nodeStatusFileName = "status.json"
+	rangesFileName     = "ranges.json"
+)
+
+// ReadDebugZip returns a snapshot of the cluster captured in the extracted
+// debug zip rooted at dir. Stores and their localities are read from each
+// node's status.json, while range placement and load are read from each node's
+// ranges.json. The snapshot time is the latest node status update time.
+//
+// Every replica of a range reports the range descriptor, but only the
+// leaseholder reports the load served by the range, so the leaseholder's view
+// of each range is preferred.
+func ReadDebugZip(dir string) (Snapshot, error) {
+	var snap Snapshot
+	nodeDirs, err := filepath.Glob(filepath.Join(dir, "nodes", "*"))
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if len(nodeDirs) == 0 {
+		return Snapshot{}, errors.Newf("no nodes found in %s", dir)
+	}
+	sort.Strings(nodeDirs)
+
+	ranges := map[roachpb.RangeID]serverpb.RangeInfo{}
+	for _, nodeDir := range nodeDirs {
+		var status statuspb.NodeStatus
+		if err := readJSON(filepath.Join(nodeDir, nodeStatusFileName), &status); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				// The node may not have responded when the zip was taken.
+				continue
+			}
+			return Snapshot{}, err
+		}
+		if updatedAt := time.Unix(0, status.UpdatedAt); updatedAt.After(snap.Time) {
+			snap.Time = updatedAt
+		}
+		for _, ss := range status.StoreStatuses {
+			snap.Stores = append(snap.Stores, Store{
+				StoreID:       ss.Desc.StoreID,
+				NodeID:        status.Desc.NodeID,
+				Locality:      status.Desc.Locality,
+				CapacityBytes: ss.Desc.Capacity.Capacity,
+				CPUCores:      float64(status.NumCpus),
+			})
+		}
+
+		var infos []serverpb.RangeInfo
+		if err := readJSON(filepath.Join(nodeDir, rangesFileName), &infos); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return Snapshot{}, err
+		}
+		for _, info := range infos {
+			if info.State.Desc == nil {
+				continue
+			}
+			if existing, ok := ranges[info.State.Desc.RangeID]; ok && existing.IsLeaseholder {
+				continue
+			}
+			ranges[info.State.Desc.RangeID] = info
+		}
+	}
+
+	for _, info := range ranges {
+		r := Range{
+			RangeID:  info.State.Desc.RangeID,
+			StartKey: info.State.Desc.StartKey,
+			Replicas: info.State.Desc.InternalReplicas,
+			Load: Load{
+				QueriesPerSecond:    info.Stats.QueriesPerSecond,
+				WritesPerSecond:     info.Stats.WritesPerSecond,
+				ReadBytesPerSecond:  info.Stats.ReadBytesPerSecond,
+				WriteBytesPerSecond: info.Stats.WriteBytesPerSecond,
+				CPUNanosPerSecond:   info.Stats.CPUTimePerSecond,
+			},
+		}
+		if info.State.Lease != nil {
+			r.Leaseholder = info.State.Lease.Replica.StoreID
+		} else if len(r.Replicas) > 0 {
+			r.Leaseholder = r.Replicas[0].StoreID
+		}
+		if info.State.Stats != nil {
+			r.LogicalBytes = info.State.Stats.Total()
+		}
+		snap.Ranges = append(snap.Ranges, r)
+	}
+	sort.Slice(snap.Ranges, func(i, j int) bool {
+		return snap.Ranges[i].RangeID < snap.Ranges[j].RangeID
+	})
+	return snap, nil
+}
+
+// ReadDebugZips returns a scenario from the extracted debug zips rooted at the
+// given directories, e.g. zips taken every few minutes while a problem was
+// ongoing.
+func ReadDebugZips(dirs ...string) (*Scenario, error) {
+	snapshots := make([]Snapshot, 0, len(dirs))
+	for _, dir := range dirs {
+		snap, err := ReadDebugZip(dir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading debug zip %s", dir)
+		}
+		snapshots = append(snapshots, snap)
+	}
+	return NewScenario(snapshots...)
+}
+
+func readJSON(path string, v interface{}) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(json.Unmarshal(b, v), "decoding %s", path)
+}
@@ -0,0 +1,356 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package replay converts the recorded state of a real cluster into an
+// allocation simulation. A Scenario holds one or more snapshots of a cluster's
+// stores, ranges and per-range load, typically read from debug zips taken at
+// different times. The first snapshot determines the initial cluster and range
+// placement, while the load recorded in every snapshot is replayed over the
+// course of the simulation.
+package replay
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/config"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/gen"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/state"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/workload"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/errors"
+)
+
+// keySpacing is the distance between the simulator keys assigned to
+// consecutive ranges. Simulator keys are int64s, so the real range boundaries
+// are mapped onto keys spaced out by keySpacing, in key order.
+const keySpacing = 1000
+
+// Store is the recorded state of a single store.
+type Store struct {
+	StoreID       roachpb.StoreID
+	NodeID        roachpb.NodeID
+	Locality      roachpb.Locality
+	CapacityBytes int64
+	// CPUCores is the number of CPUs available to the store's node. When zero,
+	// the simulator default is used.
+	CPUCores float64
+}
+
+// Range is the recorded state and load of a single range.
+type Range struct {
+	RangeID     roachpb.RangeID
+	StartKey    roachpb.RKey
+	Replicas    []roachpb.ReplicaDescriptor
+	Leaseholder roachpb.StoreID
+	// LogicalBytes is the logical size of the range's data.
+	LogicalBytes int64
+	Load         Load
+}
+
+// Load is the recorded load of a range, as rates per second.
+type Load struct {
+	QueriesPerSecond    float64
+	WritesPerSecond     float64
+	ReadBytesPerSecond  float64
+	WriteBytesPerSecond float64
+	// CPUNanosPerSecond is the CPU time spent serving the range, in
+	// nanoseconds per second.
+	CPUNanosPerSecond float64
+}
+
+// Snapshot is the state of a cluster at a point in time.
+type Snapshot struct {
+	// Time is the wall time at which the snapshot was taken.
+	Time   time.Time
+	Stores []Store
+	Ranges []Range
+}
+
+// Scenario is a sequence of snapshots of the same cluster, ordered by time.
+type Scenario struct {
+	Snapshots []Snapshot
+	// keys maps the start key of each range in the first snapshot to the
+	// simulator key assigned to it.
+	keys []mappedKey
+	// stores maps recorded store IDs to simulator store IDs.
+	stores map[roachpb.StoreID]state.StoreID
+	info   state.ClusterInfo
+}
+
+type mappedKey struct {
+	start roachpb.RKey
+	key   state.Key
+}
+
+// NewScenario returns a scenario built from the given snapshots. The stores and
+// ranges of the first snapshot (by time) are used as the initial simulation
+// state.
+func NewScenario(snapshots ...Snapshot) (*Scenario, error) {
+	if len(snapshots) == 0 {
+		return nil, errors.New("no snapshots provided")
+	}
+	s := &Scenario{Snapshots: append([]Snapshot(nil), snapshots...)}
+	sort.SliceStable(s.Snapshots, func(i, j int) bool {
+		return s.Snapshots[i].Time.Before(s.Snapshots[j].Time)
+	})
+	initial := s.Snapshots[0]
+	if len(initial.Stores) == 0 {
+		return nil, errors.New("initial snapshot has no stores")
+	}
+	if len(initial.Ranges) == 0 {
+		return nil, errors.New("initial snapshot has no ranges")
+	}
+	if int64(len(initial.Ranges))*keySpacing > int64(state.MaxKey) {
+		return nil, errors.Newf("too many ranges to simulate: %d", len(initial.Ranges))
+	}
+	s.info, s.stores = clusterInfo(initial.Stores)
+
+	ranges := append([]Range(nil), initial.Ranges...)
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].StartKey.Less(ranges[j].StartKey) })
+	s.keys = make([]mappedKey, len(ranges))
+	for i, r := range ranges {
+		s.keys[i] = mappedKey{start: r.StartKey, key: state.Key(int64(i) * keySpacing)}
+	}
+	return s, nil
+}
+
+// clusterInfo returns the cluster info which, when loaded, creates one node per
+// recorded node with the same locality and store count. It also returns the
+// mapping from recorded to simulated store IDs, relying on the simulator
+// assigning node and store IDs sequentially in the order they are loaded.
+func clusterInfo(stores []Store) (state.ClusterInfo, map[roachpb.StoreID]state.StoreID) {
+	type node struct {
+		id       roachpb.NodeID
+		region   string
+		zone     string
+		cpuCores float64
+		stores   []roachpb.StoreID
+	}
+	nodesByID := map[roachpb.NodeID]*node{}
+	var nodes []*node
+	var info state.ClusterInfo
+	for _, st := range stores {
+		n, ok := nodesByID[st.NodeID]
+		if !ok {
+			region, _ := st.Locality.Find("region")
+			zone, _ := st.Locality.Find("zone")
+			n = &node{id: st.NodeID, region: region, zone: zone, cpuCores: st.CPUCores}
+			nodesByID[st.NodeID] = n
+			nodes = append(nodes, n)
+		}
+		n.stores = append(n.stores, st.StoreID)
+		info.StoreDiskCapacityBytes = max(info.StoreDiskCapacityBytes, st.CapacityBytes)
+	}
+	if info.StoreDiskCapacityBytes == 0 {
+		info.StoreDiskCapacityBytes = config.DefaultStoreDiskCapacityBytes
+	}
+	// Group nodes by region and zone, keeping the recorded node order within
+	// each group so that the resulting IDs are deterministic.
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].region != nodes[j].region {
+			return nodes[i].region < nodes[j].region
+		}
+		if nodes[i].zone != nodes[j].zone {
+			return nodes[i].zone < nodes[j].zone
+		}
+		return nodes[i].id < nodes[j].id
+	})
+
+	storeMap := map[roachpb.StoreID]state.StoreID{}
+	nextStoreID := state.StoreID(1)
+	for _, n := range nodes {
+		sort.Slice(n.stores, func(i, j int) bool { return n.stores[i] < n.stores[j] })
+		for _, id := range n.stores {
+			storeMap[id] = nextStoreID
+			nextStoreID++
+		}
+		cpu := uint64(config.DefaultNodeCPURateCapacityNanos)
+		if n.cpuCores > 0 {
+			cpu = uint64(n.cpuCores * 1e9)
+		}
+		info.NodeCPURateCapacityNanos = append(info.NodeCPURateCapacityNanos, cpu)
+
+		// A zone may only have a single stores per node count, so nodes with
+		// differing store counts in the same zone are split into separate zones
+		// with the same name.
+		if len(info.Regions) == 0 || info.Regions[len(info.Regions)-1].Name != n.region {
+			info.Regions = append(info.Regions, state.Region{Name: n.region})
+		}
+		region := &info.Regions[len(info.Regions)-1]
+		if len(region.Zones) == 0 ||
+			region.Zones[len(region.Zones)-1].Name != n.zone ||
+			region.Zones[len(region.Zones)-1].StoresPerNode != len(n.stores) {
+			region.Zones = append(region.Zones, state.NewZone(n.zone, 0, len(n.stores)))
+		}
+		region.Zones[len(region.Zones)-1].NodeCount++
+	}
+	return info, storeMap
+}
+
+// keyFor returns the simulator key for the range in the initial snapshot which
+// contains the given recorded key.
+func (s *Scenario) keyFor(key roachpb.RKey) state.Key {
+	idx := sort.Search(len(s.keys), func(i int) bool {
+		return bytes.Compare(s.keys[i].start, key) > 0
+	})
+	if idx == 0 {
+		return s.keys[0].key
+	}
+	return s.keys[idx-1].key
+}
+
+// ClusterInfo returns the cluster info of the initial snapshot.
+func (s *Scenario) ClusterInfo() state.ClusterInfo {
+	return s.info
+}
+
+// RangesInfo returns the ranges of the initial snapshot, with their replicas
+// and leaseholders placed on the corresponding simulated stores.
+func (s *Scenario) RangesInfo() (state.RangesInfo, error) {
+	ranges := s.Snapshots[0].Ranges
+	ret := make(state.RangesInfo, 0, len(ranges))
+	for _, r := range ranges {
+		var voters, nonVoters []state.StoreID
+		for _, repl := range r.Replicas {
+			storeID, ok := s.stores[repl.StoreID]
+			if !ok {
+				return nil, errors.Newf("r%d: replica on unknown store s%d", r.RangeID, repl.StoreID)
+			}
+			if repl.Type == roachpb.NON_VOTER {
+				nonVoters = append(nonVoters, storeID)
+			} else {
+				voters = append(voters, storeID)
+			}
+		}
+		leaseholder, ok := s.stores[r.Leaseholder]
+		if !ok {
+			return nil, errors.Newf("r%d: leaseholder on unknown store s%d", r.RangeID, r.Leaseholder)
+		}
+		spanConfig := state.DefaultSpanConfigWithRF(len(voters))
+		spanConfig.NumReplicas = int32(len(voters) + len(nonVoters))
+		ri := state.RangeInfoWithReplicas(s.keyFor(r.StartKey), voters, nonVoters, leaseholder, &spanConfig)
+		ri.Size = r.LogicalBytes
+		ret = append(ret, ri)
+	}
+	return ret, nil
+}
+
+// RateSamples returns the recorded load of every snapshot as rate samples
+// against the simulated keys. Load recorded on ranges which did not exist in
+// the initial snapshot, e.g. because of a split, is attributed to the initial
+// range containing its start key.
+//
+// Recorded queries are replayed as writes up to the recorded number of keys
+// written, with the remainder replayed as reads. The simulator splits CPU
+// between request and raft CPU, which isn't recorded separately, so all
+// recorded CPU is replayed as request CPU.
+func (s *Scenario) RateSamples() []workload.RateSample {
+	start := s.Snapshots[0].Time
+	var ret []workload.RateSample
+	for _, snap := range s.Snapshots {
+		byKey := map[state.Key]*workload.RateSample{}
+		var keys []state.Key
+		for _, r := range snap.Ranges {
+			key := s.keyFor(r.StartKey)
+			sample, ok := byKey[key]
+			if !ok {
+				sample = &workload.RateSample{Offset: snap.Time.Sub(start), Key: int64(key)}
+				byKey[key] = sample
+				keys = append(keys, key)
+			}
+			writes := min(r.Load.WritesPerSecond, r.Load.QueriesPerSecond)
+			sample.WritesPerSecond += writes
+			sample.ReadsPerSecond += r.Load.QueriesPerSecond - writes
+			sample.ReadBytesPerSecond += r.Load.ReadBytesPerSecond
+			sample.WriteBytesPerSecond += r.Load.WriteBytesPerSecond
+			sample.RequestCPUPerSecond += r.Load.CPUNanosPerSecond
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		for _, key := range keys {
+			ret = append(ret, *byKey[key])
+		}
+	}
+	return ret
+}
+
+// Generate returns a simulator for the scenario, running for the given
+// duration with the given settings.
+func (s *Scenario) Generate(
+	duration time.Duration, settings *config.SimulationSettings, buf *strings.Builder,
+) (*asim.Simulator, error) {
+	rangesInfo, err := s.RangesInfo()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		buf = &strings.Builder{}
+	}
+	return gen.GenerateSimulation(
+		duration,
+		gen.LoadedCluster{Info: s.ClusterInfo()},
+		RangeGen{Info: rangesInfo},
+		LoadGen{Samples: s.RateSamples()},
+		gen.StaticSettings{Settings: settings},
+		gen.NewStaticEventsWithNoEvents(),
+		settings.Seed,
+		buf,
+		"\t",
+	), nil
+}
+
+// RangeGen implements the gen.RangeGen interface, loading a fixed set of
+// ranges.
+type RangeGen struct {
+	Info state.RangesInfo
+}
+
+var _ gen.RangeGen = RangeGen{}
+
+func (rg RangeGen) String() string {
+	return fmt.Sprintf("replayed ranges=%d", len(rg.Info))
+}
+
+// Generate returns the state loaded with the replayed ranges. There is no
+// randomness in range generation.
+func (rg RangeGen) Generate(
+	tag string, seed int64, settings *config.SimulationSettings, s state.State,
+) (state.State, string) {
+	state.LoadRangeInfo(s, rg.Info...)
+	return s, fmt.Sprintf("%s%s", tag, rg)
+}
+
+// LoadGen implements the gen.LoadGen interface, replaying recorded rate
+// samples.
+type LoadGen struct {
+	Samples []workload.RateSample
+}
+
+var _ gen.LoadGen = LoadGen{}
+
+// StringWithTag returns a summary of the replayed load.
+func (lg LoadGen) StringWithTag(tag string) string {
+	var maxOffset time.Duration
+	keys := map[int64]struct{}{}
+	for _, s := range lg.Samples {
+		keys[s.Key] = struct{}{}
+		maxOffset = max(maxOffset, s.Offset)
+	}
+	return fmt.Sprintf("%sreplay: %d samples over %d keys, last sample at %s",
+		tag, len(lg.Samples), len(keys), maxOffset)
+}
+
+// Generate returns a replay generator for the samples. There is no
+// randomness in the replayed load.
+func (lg LoadGen) Generate(seed int64, settings *config.SimulationSettings) []workload.Generator {
+	if len(lg.Samples) == 0 {
+		return []workload.Generator{}
+	}
+	return []workload.Generator{workload.NewReplayGenerator(settings.StartTime, lg.Samples)}
+}
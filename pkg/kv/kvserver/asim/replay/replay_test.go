@@ -0,0 +1,167 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/config"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/state"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/workload"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/server/status/statuspb"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
+	"github.com/stretchr/testify/require"
+)
+
+// writeDebugZip writes the subset of an extracted debug zip read by
+// ReadDebugZip into dir. Each node has a single store with the same ID as the
+// node, and every range has a replica on each node.
+func writeDebugZip(
+	t *testing.T, dir string, at time.Time, localities []string, ranges []serverpb.RangeInfo,
+) {
+	for i, loc := range localities {
+		nodeID := roachpb.NodeID(i + 1)
+		nodeDir := filepath.Join(dir, "nodes", fmt.Sprint(nodeID))
+		require.NoError(t, os.MkdirAll(nodeDir, 0755))
+
+		var locality roachpb.Locality
+		require.NoError(t, locality.Set(loc))
+		status := statuspb.NodeStatus{
+			Desc:      roachpb.NodeDescriptor{NodeID: nodeID, Locality: locality},
+			UpdatedAt: at.UnixNano(),
+			NumCpus:   16,
+			StoreStatuses: []statuspb.StoreStatus{{
+				Desc: roachpb.StoreDescriptor{
+					StoreID:  roachpb.StoreID(nodeID),
+					Capacity: roachpb.StoreCapacity{Capacity: 512 << 30},
+				},
+			}},
+		}
+		// Every node reports every range, but only the leaseholder reports load.
+		var nodeRanges []serverpb.RangeInfo
+		for _, r := range ranges {
+			r.IsLeaseholder = r.State.Lease.Replica.NodeID == nodeID
+			if !r.IsLeaseholder {
+				r.Stats = serverpb.RangeStatistics{}
+			}
+			nodeRanges = append(nodeRanges, r)
+		}
+		for name, v := range map[string]interface{}{
+			nodeStatusFileName: status,
+			rangesFileName:     nodeRanges,
+		} {
+			b, err := json.Marshal(v)
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filepath.Join(nodeDir, name), b, 0644))
+		}
+	}
+}
+
+func makeRangeInfo(
+	rangeID roachpb.RangeID, startKey string, leaseholder roachpb.NodeID, qps, wps float64,
+) serverpb.RangeInfo {
+	desc := &roachpb.RangeDescriptor{RangeID: rangeID, StartKey: roachpb.RKey(startKey)}
+	for n := roachpb.NodeID(1); n <= 3; n++ {
+		desc.InternalReplicas = append(desc.InternalReplicas, roachpb.ReplicaDescriptor{
+			NodeID: n, StoreID: roachpb.StoreID(n), ReplicaID: roachpb.ReplicaID(n),
+		})
+	}
+	var info serverpb.RangeInfo
+	info.State.Desc = desc
+	info.State.Lease = &roachpb.Lease{Replica: desc.InternalReplicas[leaseholder-1]}
+	info.State.Stats = &enginepb.MVCCStats{KeyBytes: 1 << 20, ValBytes: 3 << 20}
+	info.Stats = serverpb.RangeStatistics{
+		QueriesPerSecond:    qps,
+		WritesPerSecond:     wps,
+		WriteBytesPerSecond: wps * 100,
+		CPUTimePerSecond:    qps * 1000,
+	}
+	return info
+}
+
+func TestReplayDebugZips(t *testing.T) {
+	start := time.Date(2022, 03, 21, 11, 0, 0, 0, time.UTC)
+	localities := []string{"region=b,zone=b1", "region=a,zone=a1", "region=a,zone=a2"}
+	first, second := t.TempDir(), t.TempDir()
+	writeDebugZip(t, first, start, localities, []serverpb.RangeInfo{
+		makeRangeInfo(1, "", 1, 100, 10),
+		makeRangeInfo(2, "b", 2, 200, 50),
+		makeRangeInfo(3, "a", 3, 10, 20),
+	})
+	// By the time of the second zip, r2 split off r4 and its load moved there.
+	writeDebugZip(t, second, start.Add(10*time.Minute), localities, []serverpb.RangeInfo{
+		makeRangeInfo(1, "", 1, 100, 10),
+		makeRangeInfo(2, "b", 2, 0, 0),
+		makeRangeInfo(3, "a", 3, 10, 20),
+		makeRangeInfo(4, "c", 2, 1000, 0),
+	})
+
+	// Pass the zips out of order, the scenario orders snapshots by time.
+	sc, err := ReadDebugZips(second, first)
+	require.NoError(t, err)
+	require.Len(t, sc.Snapshots, 2)
+	require.Equal(t, start, sc.Snapshots[0].Time.UTC())
+
+	// Nodes are grouped by locality, so n2 and n3 in region a come first.
+	require.Equal(t, map[roachpb.StoreID]state.StoreID{2: 1, 3: 2, 1: 3}, sc.stores)
+	info := sc.ClusterInfo()
+	require.Equal(t, []state.Region{
+		{Name: "a", Zones: []state.Zone{state.NewZone("a1", 1, 1), state.NewZone("a2", 1, 1)}},
+		{Name: "b", Zones: []state.Zone{state.NewZone("b1", 1, 1)}},
+	}, info.Regions)
+	require.Equal(t, int64(512<<30), info.StoreDiskCapacityBytes)
+	require.Equal(t, state.NodeCPURateCapacities{16e9, 16e9, 16e9}, info.NodeCPURateCapacityNanos)
+
+	// Ranges are assigned simulator keys in key order.
+	rangesInfo, err := sc.RangesInfo()
+	require.NoError(t, err)
+	require.Len(t, rangesInfo, 3)
+	for i, expected := range []struct {
+		key         state.Key
+		leaseholder state.StoreID
+	}{
+		{key: 0, leaseholder: 3},
+		{key: 2 * keySpacing, leaseholder: 1},
+		{key: keySpacing, leaseholder: 2},
+	} {
+		require.Equal(t, expected.key, state.ToKey(rangesInfo[i].Descriptor.StartKey.AsRawKey()))
+		require.Equal(t, expected.leaseholder, rangesInfo[i].Leaseholder)
+		require.Equal(t, int64(4<<20), rangesInfo[i].Size)
+	}
+
+	// The load of r4, which didn't exist initially, is attributed to r2.
+	require.Equal(t, []workload.RateSample{
+		{Key: 0, ReadsPerSecond: 90, WritesPerSecond: 10, WriteBytesPerSecond: 1000, RequestCPUPerSecond: 100000},
+		{Key: keySpacing, WritesPerSecond: 10, WriteBytesPerSecond: 2000, RequestCPUPerSecond: 10000},
+		{Key: 2 * keySpacing, ReadsPerSecond: 150, WritesPerSecond: 50, WriteBytesPerSecond: 5000, RequestCPUPerSecond: 200000},
+		{Offset: 10 * time.Minute, Key: 0, ReadsPerSecond: 90, WritesPerSecond: 10, WriteBytesPerSecond: 1000, RequestCPUPerSecond: 100000},
+		{Offset: 10 * time.Minute, Key: keySpacing, WritesPerSecond: 10, WriteBytesPerSecond: 2000, RequestCPUPerSecond: 10000},
+		{Offset: 10 * time.Minute, Key: 2 * keySpacing, ReadsPerSecond: 1000, RequestCPUPerSecond: 1000000},
+	}, sc.RateSamples())
+
+	// The scenario can be simulated, and the replayed load shows up in the
+	// recorded history.
+	settings := config.DefaultSimulationSettings()
+	settings.StartTime = start
+	sim, err := sc.Generate(20*time.Minute, settings, nil /* buf */)
+	require.NoError(t, err)
+	sim.RunSim(context.Background())
+	h := sim.History()
+	require.NotEmpty(t, h.Recorded)
+	var qps float64
+	for _, v := range h.PerStoreValuesAt(len(h.Recorded)-1, "qps") {
+		qps += v
+	}
+	require.Greater(t, qps, 0.0)
+}
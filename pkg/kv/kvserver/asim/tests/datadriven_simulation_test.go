@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/event"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/gen"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/history"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/replay"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/scheduled"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/state"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
@@ -83,6 +84,13 @@ var runAsimTests = envutil.EnvOrDefaultBool("COCKROACH_RUN_ASIM_TESTS", false)
 //     regions having 3 zones. complex: 28 nodes, 3 regions with a skewed
 //     number of nodes per region.
 //
+//   - "replay_debug_zips" zips=(<string>,...)
+//     Load the cluster, ranges and load recorded in the extracted debug zips
+//     found under testdata/replay/<zip>. On the next call to eval, the initial
+//     state is the one recorded in the earliest zip and the recorded load of
+//     every zip is replayed, replacing any cluster, ranges and load declared
+//     before. The stores, ranges and replayed load are printed.
+//
 //   - "gen_ranges" [ranges=<int>]
 //     [placement_type=(even|skewed|weighted|replica_placement)]
 //     [repl_factor=<int>] [min_key=<int>] [max_key=<int>] [bytes_mib=<int>]
@@ -213,6 +221,11 @@ func TestDataDriven(t *testing.T) {
 			loadGen := gen.MultiLoad{}
 			var clusterGen gen.ClusterGen
 			var rangeGen gen.MultiRanges
+			// replayRangeGen and replayLoadGen are set by replay_debug_zips and take
+			// the place of the ranges and load declared with gen_ranges and
+			// gen_load.
+			var replayRangeGen gen.RangeGen
+			var replayLoadGen gen.LoadGen
 			settingsGen := gen.StaticSettings{Settings: config.DefaultSimulationSettings()}
 			var events []scheduled.ScheduledEvent
 			type trackedAssertion struct {
@@ -420,6 +433,21 @@ func TestDataDriven(t *testing.T) {
 					scanMustExist(t, d, "config", &cfg)
 					clusterGen = loadClusterInfo(cfg)
 					return ""
+				case "replay_debug_zips":
+					var zips []string
+					scanMustExist(t, d, "zips", &zips)
+					dirs := make([]string, len(zips))
+					for i, zip := range zips {
+						dirs[i] = datapathutils.TestDataPath(t, "replay", zip)
+					}
+					sc, err := replay.ReadDebugZips(dirs...)
+					require.NoError(t, err)
+					rangesInfo, err := sc.RangesInfo()
+					require.NoError(t, err)
+					clusterGen = gen.LoadedCluster{Info: sc.ClusterInfo()}
+					replayRangeGen = replay.RangeGen{Info: rangesInfo}
+					replayLoadGen = replay.LoadGen{Samples: sc.RateSamples()}
+					return replayString(sc, rangesInfo)
 				case "add_node":
 					var delay time.Duration
 					var numStores = 1
@@ -630,7 +658,13 @@ func TestDataDriven(t *testing.T) {
 						metricsMap[s] = struct{}{}
 					}
 
-					require.NotZero(t, rangeGen)
+					var evalRangeGen gen.RangeGen = rangeGen
+					var evalLoadGen gen.LoadGen = loadGen
+					if replayRangeGen != nil {
+						evalRangeGen, evalLoadGen = replayRangeGen, replayLoadGen
+					} else {
+						require.NotZero(t, rangeGen)
+					}
 
 					knownConfigurations := map[string]func(eg *gen.StaticEvents){
 						"sma-count": func(eg *gen.StaticEvents) {
@@ -717,7 +751,7 @@ func TestDataDriven(t *testing.T) {
 									tmpStrB = &strings.Builder{}
 								}
 								simulator := gen.GenerateSimulation(
-									duration, clusterGen, rangeGen, loadGen,
+									duration, clusterGen, evalRangeGen, evalLoadGen,
 									settingsGen, eventGen, seedGen.Int63(), tmpStrB, "\t",
 								)
 								if stateStrForOnce == "" {
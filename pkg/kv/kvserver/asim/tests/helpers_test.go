@@ -7,11 +7,17 @@ package tests
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/assertion"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/config"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/gen"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/replay"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/state"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/liveness/livenesspb"
 	"github.com/cockroachdb/datadriven"
 	"github.com/stretchr/testify/require"
@@ -109,3 +115,61 @@ func scanThreshold(t *testing.T, d *datadriven.TestData) (th assertion.Threshold
 	th.ThresholdType = assertion.LowerBound
 	return th
 }
+
+// replayString returns the stores and ranges of the initial state generated
+// from a replayed scenario, followed by the load replayed against each
+// simulator key, so that testdata can assert them against the recorded debug
+// zips.
+func replayString(sc *replay.Scenario, rangesInfo state.RangesInfo) string {
+	settings := config.DefaultSimulationSettings()
+	s := gen.LoadedCluster{Info: sc.ClusterInfo()}.Generate(0 /* seed */, settings)
+	s, _ = replay.RangeGen{Info: rangesInfo}.Generate("", 0 /* seed */, settings, s)
+	rate := func(v float64) string {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	var buf strings.Builder
+	_, _ = fmt.Fprintf(&buf, "snapshots=%d stores=%d ranges=%d\n",
+		len(sc.Snapshots), len(s.Stores()), s.RangeCount())
+	for _, store := range s.Stores() {
+		replicas := s.Replicas(store.StoreID())
+		var leases int
+		for _, repl := range replicas {
+			if repl.HoldsLease() {
+				leases++
+			}
+		}
+		_, _ = fmt.Fprintf(&buf, "s%d: n%d %s replicas=%d leases=%d\n", store.StoreID(), store.NodeID(),
+			s.Node(store.NodeID()).Descriptor().Locality, len(replicas), leases)
+	}
+
+	keys := make([]state.Key, len(rangesInfo))
+	for i, ri := range rangesInfo {
+		keys[i] = state.ToKey(ri.Descriptor.StartKey.AsRawKey())
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	for _, key := range keys {
+		rng := s.RangeFor(key)
+		var storeIDs []state.StoreID
+		for _, repl := range rng.Replicas() {
+			storeIDs = append(storeIDs, repl.StoreID())
+		}
+		sort.Slice(storeIDs, func(i, j int) bool { return storeIDs[i] < storeIDs[j] })
+		stores := make([]string, len(storeIDs))
+		for i, storeID := range storeIDs {
+			stores[i] = fmt.Sprintf("s%d", storeID)
+		}
+		leaseholder, _ := s.LeaseholderStore(rng.RangeID())
+		_, _ = fmt.Fprintf(&buf, "key=%d bytes=%d replicas=(%s) leaseholder=s%d\n",
+			key, rng.Size(), strings.Join(stores, ","), leaseholder.StoreID())
+	}
+
+	for _, sample := range sc.RateSamples() {
+		_, _ = fmt.Fprintf(&buf,
+			"offset=%s key=%d reads=%s writes=%s read_bytes=%s write_bytes=%s request_cpu=%s\n",
+			sample.Offset, sample.Key, rate(sample.ReadsPerSecond), rate(sample.WritesPerSecond),
+			rate(sample.ReadBytesPerSecond), rate(sample.WriteBytesPerSecond),
+			rate(sample.RequestCPUPerSecond))
+	}
+	return buf.String()
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package workload
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// RateSample is a recorded load rate against a single key. The rate applies
+// from Offset, relative to the start of the replay, until the next sample for
+// the same key. The last sample for a key applies indefinitely.
+//
+// The samples with the same Offset are a snapshot of the load at that offset.
+// A key which has no sample in a snapshot, e.g. because its range was merged
+// away, has no load from that snapshot until its next sample.
+type RateSample struct {
+	Offset              time.Duration
+	Key                 int64
+	ReadsPerSecond      float64
+	WritesPerSecond     float64
+	ReadBytesPerSecond  float64
+	WriteBytesPerSecond float64
+	// RequestCPUPerSecond and RaftCPUPerSecond are measured in nanoseconds of
+	// CPU time consumed per second.
+	RequestCPUPerSecond float64
+	RaftCPUPerSecond    float64
+}
+
+// replayAccumulator tracks the fractional load that has not yet been emitted
+// for a key, so that rates which produce less than one unit per tick are not
+// lost to rounding.
+type replayAccumulator struct {
+	reads, writes, readBytes, writeBytes, requestCPU, raftCPU float64
+}
+
+// take returns the whole part of v and leaves the fractional remainder in v.
+func take(v *float64) int64 {
+	whole := math.Floor(*v)
+	*v -= whole
+	return int64(whole)
+}
+
+// ReplayGenerator is a Generator which replays recorded per-key load rates,
+// typically exported from a production cluster, rather than generating random
+// operations.
+type ReplayGenerator struct {
+	start   time.Time
+	lastRun time.Time
+	// keys is the sorted list of keys with at least one sample.
+	keys []int64
+	// samples contains the samples for each key, sorted by offset.
+	samples map[int64][]RateSample
+	acc     map[int64]*replayAccumulator
+}
+
+// NewReplayGenerator returns a generator that replays the given rate samples,
+// with offsets interpreted relative to start.
+func NewReplayGenerator(start time.Time, samples []RateSample) Generator {
+	return newReplayGenerator(start, samples)
+}
+
+func newReplayGenerator(start time.Time, samples []RateSample) *ReplayGenerator {
+	rg := &ReplayGenerator{
+		start:   start,
+		lastRun: start,
+		samples: make(map[int64][]RateSample),
+		acc:     make(map[int64]*replayAccumulator),
+	}
+	for _, s := range samples {
+		if _, ok := rg.samples[s.Key]; !ok {
+			rg.keys = append(rg.keys, s.Key)
+			rg.acc[s.Key] = &replayAccumulator{}
+		}
+		rg.samples[s.Key] = append(rg.samples[s.Key], s)
+	}
+	sort.Slice(rg.keys, func(i, j int) bool { return rg.keys[i] < rg.keys[j] })
+	var offsets []time.Duration
+	seen := make(map[time.Duration]bool)
+	for _, s := range samples {
+		if !seen[s.Offset] {
+			seen[s.Offset] = true
+			offsets = append(offsets, s.Offset)
+		}
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+	for key, ss := range rg.samples {
+		sort.SliceStable(ss, func(i, j int) bool { return ss[i].Offset < ss[j].Offset })
+		rg.samples[key] = withZeroRateSamples(key, ss, offsets)
+	}
+	return rg
+}
+
+// withZeroRateSamples returns the samples of the key, sorted by offset, with a
+// zero rate sample added at each of the offsets after the key's first sample
+// at which the key has no sample. A zero rate sample is not added if the rate
+// of the key is already zero.
+func withZeroRateSamples(
+	key int64, samples []RateSample, offsets []time.Duration,
+) []RateSample {
+	ret := make([]RateSample, 0, len(samples))
+	first := samples[0].Offset
+	for _, offset := range offsets {
+		if offset < first {
+			continue
+		}
+		if len(samples) > 0 && samples[0].Offset == offset {
+			// A key may have several samples at the same offset, the last of
+			// which applies.
+			for len(samples) > 0 && samples[0].Offset == offset {
+				ret = append(ret, samples[0])
+				samples = samples[1:]
+			}
+			continue
+		}
+		zero := RateSample{Offset: offset, Key: key}
+		if last := ret[len(ret)-1]; last != (RateSample{Offset: last.Offset, Key: key}) {
+			ret = append(ret, zero)
+		}
+	}
+	return ret
+}
+
+// Tick returns the load events up till time tick, from the last time the
+// workload generator was called. The load for each key is the integral of its
+// recorded rates over the elapsed interval.
+func (rg *ReplayGenerator) Tick(maxTime time.Time) LoadBatch {
+	if !maxTime.After(rg.lastRun) {
+		return LoadBatch{}
+	}
+	from, to := rg.lastRun.Sub(rg.start), maxTime.Sub(rg.start)
+	ret := LoadBatch{}
+	for _, key := range rg.keys {
+		acc := rg.acc[key]
+		samples := rg.samples[key]
+		for i, s := range samples {
+			// The sample is active over [s.Offset, end).
+			end := time.Duration(math.MaxInt64)
+			if i+1 < len(samples) {
+				end = samples[i+1].Offset
+			}
+			overlap := min(end, to) - max(s.Offset, from)
+			if overlap <= 0 {
+				continue
+			}
+			secs := overlap.Seconds()
+			acc.reads += s.ReadsPerSecond * secs
+			acc.writes += s.WritesPerSecond * secs
+			acc.readBytes += s.ReadBytesPerSecond * secs
+			acc.writeBytes += s.WriteBytesPerSecond * secs
+			acc.requestCPU += s.RequestCPUPerSecond * secs
+			acc.raftCPU += s.RaftCPUPerSecond * secs
+		}
+		event := LoadEvent{
+			Key:        key,
+			Reads:      take(&acc.reads),
+			Writes:     take(&acc.writes),
+			ReadSize:   take(&acc.readBytes),
+			WriteSize:  take(&acc.writeBytes),
+			RequestCPU: take(&acc.requestCPU),
+			RaftCPU:    take(&acc.raftCPU),
+		}
+		if event == (LoadEvent{Key: key}) {
+			continue
+		}
+		ret = append(ret, event)
+	}
+	rg.lastRun = maxTime
+	return ret
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package workload
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestReplayGenerator asserts that the replay generator emits the integral of
+// the recorded rates over each tick, switching rates at sample boundaries and
+// carrying fractional load across ticks. Keys which have no sample in a later
+// snapshot have no load from that snapshot.
+func TestReplayGenerator(t *testing.T) {
+	start := time.Date(2022, 03, 21, 11, 0, 0, 0, time.UTC)
+	samples := []RateSample{
+		// Out of order on purpose, the generator sorts by key and offset.
+		{Offset: time.Minute, Key: 10, ReadsPerSecond: 100, ReadBytesPerSecond: 1000},
+		{Offset: 0, Key: 10, ReadsPerSecond: 10, WritesPerSecond: 1, WriteBytesPerSecond: 100},
+		{Offset: 0, Key: 5, WritesPerSecond: 0.5, RaftCPUPerSecond: 1000, RequestCPUPerSecond: 2000},
+	}
+	rg := newReplayGenerator(start, samples)
+
+	// Nothing has elapsed, so nothing is emitted.
+	require.Empty(t, rg.Tick(start))
+
+	// The first second only sees the first samples. Key 5 writes at half a
+	// write per second and so emits no writes yet.
+	require.Equal(t, LoadBatch{
+		{Key: 5, RequestCPU: 2000, RaftCPU: 1000},
+		{Key: 10, Reads: 10, Writes: 1, WriteSize: 100},
+	}, rg.Tick(start.Add(time.Second)))
+
+	// The carried half write is emitted on the next tick.
+	require.Equal(t, LoadBatch{
+		{Key: 5, Writes: 1, RequestCPU: 2000, RaftCPU: 1000},
+		{Key: 10, Reads: 10, Writes: 1, WriteSize: 100},
+	}, rg.Tick(start.Add(2*time.Second)))
+
+	// A tick which straddles the snapshot at one minute sees one second of
+	// each rate of key 10. Key 5 has no sample in the snapshot, so it only
+	// sees one second of its rate.
+	rg.lastRun = start.Add(59 * time.Second)
+	require.Equal(t, LoadBatch{
+		{Key: 5, RequestCPU: 2000, RaftCPU: 1000},
+		{Key: 10, Reads: 10 + 100, Writes: 1, ReadSize: 1000, WriteSize: 100},
+	}, rg.Tick(start.Add(61*time.Second)))
+
+	// The last sample applies indefinitely. Key 5 has no load, and its carried
+	// half write is not emitted.
+	require.Equal(t, LoadBatch{
+		{Key: 10, Reads: 100 * 10, ReadSize: 1000 * 10},
+	}, rg.Tick(start.Add(71*time.Second)))
+
+	// A key which is absent from a snapshot resumes at its next sample, and
+	// a key which is absent from several snapshots in a row has a single zero
+	// rate sample.
+	rg = newReplayGenerator(start, []RateSample{
+		{Offset: 0, Key: 1, ReadsPerSecond: 1},
+		{Offset: 0, Key: 2, ReadsPerSecond: 2},
+		{Offset: time.Minute, Key: 2, ReadsPerSecond: 2},
+		{Offset: 2 * time.Minute, Key: 2, ReadsPerSecond: 2},
+		{Offset: 3 * time.Minute, Key: 1, ReadsPerSecond: 3},
+		{Offset: 3 * time.Minute, Key: 2, ReadsPerSecond: 2},
+	})
+	require.Equal(t, []RateSample{
+		{Offset: 0, Key: 1, ReadsPerSecond: 1},
+		{Offset: time.Minute, Key: 1},
+		{Offset: 3 * time.Minute, Key: 1, ReadsPerSecond: 3},
+	}, rg.samples[1])
+	require.Equal(t, LoadBatch{
+		{Key: 1, Reads: 60 + 3*60},
+		{Key: 2, Reads: 2 * 4 * 60},
+	}, rg.Tick(start.Add(4*time.Minute)))
+}
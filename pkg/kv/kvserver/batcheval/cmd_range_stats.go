@@ -61,6 +61,7 @@ func RangeStats(
 	}
 
 	reply.MaxQueriesPerSecondSet = true
+	reply.WriteBytesPerSecond = cArgs.EvalCtx.GetWriteBytesPerSecond()
 	reply.RangeInfo = cArgs.EvalCtx.GetRangeInfo(ctx)
 	return result.Result{}, nil
 }
@@ -111,6 +111,10 @@ type EvalContext interface {
 	// is disabled.
 	GetMaxSplitCPU(context.Context) (float64, bool)
 
+	// GetWriteBytesPerSecond returns the Replica's average rate of bytes
+	// written.
+	GetWriteBytesPerSecond() float64
+
 	GetGCThreshold() hlc.Timestamp
 	ExcludeDataFromBackup(context.Context, roachpb.Span) (bool, error)
 	GetLastReplicaGCTimestamp(context.Context) (hlc.Timestamp, error)
@@ -183,6 +187,7 @@ type MockEvalCtx struct {
 	Stats                  enginepb.MVCCStats
 	QPS                    float64
 	CPU                    float64
+	WriteBytesPerSecond    float64
 	AbortSpan              *abortspan.AbortSpan
 	GCThreshold            hlc.Timestamp
 	Term                   kvpb.RaftTerm
@@ -283,6 +288,9 @@ func (m *mockEvalCtxImpl) GetMaxSplitQPS(context.Context) (float64, bool) {
 func (m *mockEvalCtxImpl) GetMaxSplitCPU(context.Context) (float64, bool) {
 	return m.CPU, true
 }
+func (m *mockEvalCtxImpl) GetWriteBytesPerSecond() float64 {
+	return m.WriteBytesPerSecond
+}
 func (m *mockEvalCtxImpl) CanCreateTxnRecord(
 	context.Context, uuid.UUID, []byte, hlc.Timestamp,
 ) (bool, kvpb.TransactionAbortedReason) {
@@ -631,8 +631,16 @@ func workInfoForBatch(
 		// of zero CreateTime needs to be revisited. It should use high priority.
 		createTime = timeutil.Now().UnixNano()
 	}
+	// Resource groups are defined in the system tenant's
+	// system.resource_groups table, so the IDs sent by other tenants don't
+	// refer to them.
+	var resourceGroupID admissionpb.ResourceGroupID
+	if requestTenantID.IsSystem() {
+		resourceGroupID = admissionpb.ResourceGroupID(ba.AdmissionHeader.ResourceGroupID)
+	}
 	admissionInfo := admission.WorkInfo{
 		TenantID:        tenantID,
+		ResourceGroupID: resourceGroupID,
 		Priority:        admissionpb.WorkPriority(ba.AdmissionHeader.Priority),
 		CreateTime:      createTime,
 		BypassAdmission: bypassAdmission,
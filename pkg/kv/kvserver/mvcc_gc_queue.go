@@ -261,6 +261,12 @@ func (mgcq *mvccGCQueue) shouldQueue(
 		log.VErrEventf(ctx, 2, "failed to load span config: %v", err)
 		return false, 0
 	}
+	// The data of a read-only range must be left untouched, and nothing in it
+	// can become garbage after it was made read-only.
+	if conf.ReadOnly {
+		log.VEventf(ctx, 2, "shouldQueue=false: read-only range")
+		return false, 0
+	}
 	canGC, gcTimestamp, oldThreshold, newThreshold, err := repl.checkProtectedTimestampsForGC(ctx, conf.TTL())
 	if err != nil {
 		log.VErrEventf(ctx, 2, "failed to check protected timestamp for gc: %v", err)
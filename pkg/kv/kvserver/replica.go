@@ -421,6 +421,11 @@ type Replica struct {
 	// replica.mu lock. All updates to state.Desc should be duplicated here.
 	isInitialized atomic.Bool
 
+	// readOnlyConf mirrors the ReadOnly field of the range's span config. It
+	// can be accessed without acquiring the replica.mu lock, and is updated
+	// whenever mu.conf is.
+	readOnlyConf atomic.Bool
+
 	// connectionClass controls the ConnectionClass used to send raft messages.
 	connectionClass atomicConnectionClass
 
@@ -1183,6 +1188,7 @@ func (r *Replica) SetSpanConfig(conf roachpb.SpanConfig) bool {
 	}
 	r.mu.conf = conf
 	r.mu.spanConfigExplicitlySet = true
+	r.readOnlyConf.Store(conf.ReadOnly)
 	r.store.policyRefresher.EnqueueReplicaForRefresh(r)
 	// Inform mma when the span config changes.
 	(*mmaReplica)(r).markSpanConfigNeedsUpdateLocked()
@@ -1802,6 +1808,12 @@ func (r *Replica) GetMaxSplitCPU(ctx context.Context) (float64, bool) {
 	return snap.Max, snap.Ok
 }
 
+// GetWriteBytesPerSecond returns the Replica's average rate of bytes written,
+// as recorded in its load stats.
+func (r *Replica) GetWriteBytesPerSecond() float64 {
+	return r.loadStats.Stats().WriteBytesPerSecond
+}
+
 // ContainsKey returns whether this range contains the specified key.
 //
 // TODO(bdarnell): This is not the same as RangeDescriptor.ContainsKey.
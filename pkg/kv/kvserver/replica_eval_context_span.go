@@ -144,6 +144,11 @@ func (rec SpanSetReplicaEvalContext) GetMaxSplitCPU(ctx context.Context) (float6
 	return rec.i.GetMaxSplitCPU(ctx)
 }
 
+// GetWriteBytesPerSecond returns the Replica's average rate of bytes written.
+func (rec SpanSetReplicaEvalContext) GetWriteBytesPerSecond() float64 {
+	return rec.i.GetWriteBytesPerSecond()
+}
+
 // CanCreateTxnRecord determines whether a transaction record can be created
 // for the provided transaction information. See Replica.CanCreateTxnRecord
 // for details about its arguments, return values, and preconditions.
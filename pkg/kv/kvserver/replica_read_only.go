@@ -0,0 +1,43 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package kvserver
+
+import "github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+
+// writesUserData returns whether the request modifies the MVCC data in its
+// span. Requests which only maintain transaction records, resolve existing
+// intents, acquire locks or change range metadata (e.g. leases, splits) do not
+// write user data.
+func writesUserData(req kvpb.Request) bool {
+	if kvpb.IsIntentWrite(req) || kvpb.CanBackpressure(req) {
+		return true
+	}
+	switch req.Method() {
+	case kvpb.ClearRange, kvpb.RevertRange, kvpb.Excise:
+		return true
+	}
+	return false
+}
+
+// checkWriteAllowedByReadOnlyConf returns a ReadOnlySpanError if the range's
+// span config marks it read-only, e.g. because it contains the data of a table
+// which has been set READ ONLY, and the batch would write user data.
+//
+// The span config is applied asynchronously, so writes which race with a table
+// being made read-only may still be accepted until the replica learns of the
+// new config.
+func (r *Replica) checkWriteAllowedByReadOnlyConf(ba *kvpb.BatchRequest) error {
+	if !r.readOnlyConf.Load() {
+		return nil
+	}
+	for _, ru := range ba.Requests {
+		req := ru.GetInner()
+		if writesUserData(req) {
+			return kvpb.NewReadOnlySpanError(req.Header().Span())
+		}
+	}
+	return nil
+}
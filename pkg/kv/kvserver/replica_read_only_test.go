@@ -0,0 +1,99 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package kvserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReplicaReadOnlySpanConfig verifies that a replica whose span config is
+// read-only rejects writes to user data and stops fortifying its leader, but
+// still serves reads, and accepts writes again once the span config is no
+// longer read-only.
+func TestReplicaReadOnlySpanConfig(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	tc := testContext{}
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+	tc.Start(ctx, t, stopper)
+
+	k := roachpb.Key("a")
+	pArgs := putArgs(k, []byte("value"))
+	_, pErr := tc.SendWrapped(&pArgs)
+	require.NoError(t, pErr.GoError())
+
+	setReadOnly := func(readOnly bool) {
+		_, conf := tc.repl.DescAndSpanConfig()
+		newConf := *conf
+		newConf.ReadOnly = readOnly
+		tc.repl.SetSpanConfig(newConf)
+	}
+	setReadOnly(true)
+
+	// Read-only ranges don't fortify their leader, so that they use leases
+	// which allow them to quiesce.
+	desc := tc.repl.Desc()
+	require.False(t, tc.repl.SupportFromEnabled(desc))
+	require.NotEqual(t, roachpb.LeaseLeader, tc.repl.desiredLeaseType(desc))
+
+	for _, req := range []kvpb.Request{
+		&pArgs,
+		&kvpb.DeleteRequest{RequestHeader: kvpb.RequestHeader{Key: k}},
+		&kvpb.ClearRangeRequest{RequestHeader: kvpb.RequestHeader{Key: k, EndKey: k.Next()}},
+	} {
+		_, pErr = tc.SendWrapped(req)
+		var roErr *kvpb.ReadOnlySpanError
+		require.True(t, errors.As(pErr.GoError(), &roErr), "%s: %v", req.Method(), pErr)
+	}
+
+	gArgs := getArgs(k)
+	resp, pErr := tc.SendWrapped(&gArgs)
+	require.NoError(t, pErr.GoError())
+	v, err := resp.(*kvpb.GetResponse).Value.GetBytes()
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), v)
+
+	setReadOnly(false)
+	_, pErr = tc.SendWrapped(&pArgs)
+	require.NoError(t, pErr.GoError())
+}
+
+func TestWritesUserData(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	for _, tc := range []struct {
+		req      kvpb.Request
+		expected bool
+	}{
+		{&kvpb.PutRequest{}, true},
+		{&kvpb.ConditionalPutRequest{}, true},
+		{&kvpb.IncrementRequest{}, true},
+		{&kvpb.DeleteRequest{}, true},
+		{&kvpb.DeleteRangeRequest{}, true},
+		{&kvpb.AddSSTableRequest{}, true},
+		{&kvpb.ClearRangeRequest{}, true},
+		{&kvpb.RevertRangeRequest{}, true},
+		{&kvpb.GetRequest{}, false},
+		{&kvpb.ScanRequest{}, false},
+		{&kvpb.EndTxnRequest{}, false},
+		{&kvpb.HeartbeatTxnRequest{}, false},
+		{&kvpb.ResolveIntentRequest{}, false},
+		{&kvpb.GCRequest{}, false},
+		{&kvpb.AdminSplitRequest{}, false},
+	} {
+		require.Equal(t, tc.expected, writesUserData(tc.req), "%s", tc.req.Method())
+	}
+}
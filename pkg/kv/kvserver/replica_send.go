@@ -73,6 +73,9 @@ var optimisticEvalLimitedScans = settings.RegisterBoolSetting(
 //	Replica.maybeRateLimitBatch (tenant rate limits)
 //	                       │
 //	                       ▼
+//	  TenantStorageQuota.CheckBatch (tenant storage quotas)
+//	                       │
+//	                       ▼
 //	  Replica.maybeCommitWaitBeforeCommitTrigger (if committing with commit-trigger)
 //	                       │
 // read-write ◄────────────┴──────────────────────────────────► read-only
@@ -166,6 +169,9 @@ func (r *Replica) SendWithWorkStats(
 	if err := r.maybeRateLimitBatch(ctx, ba, tenantIDOrZero); err != nil {
 		return nil, kvpb.NewError(err)
 	}
+	if err := r.store.cfg.TenantStorageQuota.CheckBatch(ctx, tenantIDOrZero, ba); err != nil {
+		return nil, kvpb.NewError(err)
+	}
 	if err := r.maybeCommitWaitBeforeCommitTrigger(ctx, ba); err != nil {
 		return nil, kvpb.NewError(err)
 	}
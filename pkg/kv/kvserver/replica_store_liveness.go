@@ -109,6 +109,13 @@ func (r *Replica) SupportFromEnabled(desc *roachpb.RangeDescriptor) bool {
 		// ranges.
 		return false
 	}
+	if r.readOnlyConf.Load() {
+		// Ranges whose span config marks them read-only don't receive writes, so
+		// they should quiesce rather than keep heartbeating through store
+		// liveness. Only epoch-based leases support quiescence, so don't fortify
+		// the leader and, in turn, don't acquire a leader lease.
+		return false
+	}
 
 	fracEnabled := RaftLeaderFortificationFractionEnabled.Get(&r.store.ClusterSettings().SV)
 	fortifyEnabled := raftFortificationEnabledForRangeID(fracEnabled, r.RangeID)
@@ -119,6 +119,11 @@ func (r *Replica) executeWriteBatch(
 		return nil, g, kvpb.NewError(err)
 	}
 
+	// Reject writes to user data if the range has been made read-only.
+	if err := r.checkWriteAllowedByReadOnlyConf(ba); err != nil {
+		return nil, g, kvpb.NewError(err)
+	}
+
 	// Check the breaker. Note that we do this after
 	// checkExecutionCanProceedBeforeStorageSnapshot, so that NotLeaseholderError
 	// has precedence.
@@ -60,6 +60,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/rditer"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/storeliveness"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tenantrate"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tenantstoragequota"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tscache"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/txnrecovery"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/txnwait"
@@ -1311,6 +1312,10 @@ type StoreConfig struct {
 	// shared.Storage instance and can accept shared snapshots.
 	SharedStorageEnabled bool
 
+	// TenantStorageQuota enforces the storage quotas of tenants on their
+	// writes. If nil, storage quotas are not enforced.
+	TenantStorageQuota *tenantstoragequota.Tracker
+
 	// KVAdmissionController is used for admission control.
 	KVAdmissionController kvadmission.Controller
 	// KVFlowAdmittedPiggybacker is used for replication AC (flow control) v2.
@@ -0,0 +1,57 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tenantstoragequota
+
+import "github.com/cockroachdb/cockroach/pkg/util/metric"
+
+// Metrics is a metric.Struct for the Tracker.
+type Metrics struct {
+	TenantsOverQuota *metric.Gauge
+	RejectedBatches  *metric.Counter
+	ThrottledBatches *metric.Counter
+	RefreshErrors    *metric.Counter
+}
+
+var _ metric.Struct = (*Metrics)(nil)
+
+var (
+	metaTenantsOverQuota = metric.Metadata{
+		Name:        "kv.tenant_storage_quota.tenants_over_quota",
+		Help:        "Number of tenants whose logical bytes exceed their storage quota",
+		Measurement: "Tenants",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaRejectedBatches = metric.Metadata{
+		Name:        "kv.tenant_storage_quota.rejected_batches",
+		Help:        "Number of write batches rejected because the tenant exceeded its storage quota",
+		Measurement: "Batches",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaThrottledBatches = metric.Metadata{
+		Name:        "kv.tenant_storage_quota.throttled_batches",
+		Help:        "Number of write batches delayed because the tenant exceeded its storage quota",
+		Measurement: "Batches",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaRefreshErrors = metric.Metadata{
+		Name:        "kv.tenant_storage_quota.refresh_errors",
+		Help:        "Number of errors encountered refreshing the storage usage of tenants",
+		Measurement: "Errors",
+		Unit:        metric.Unit_COUNT,
+	}
+)
+
+func makeMetrics() Metrics {
+	return Metrics{
+		TenantsOverQuota: metric.NewGauge(metaTenantsOverQuota),
+		RejectedBatches:  metric.NewCounter(metaRejectedBatches),
+		ThrottledBatches: metric.NewCounter(metaThrottledBatches),
+		RefreshErrors:    metric.NewCounter(metaRefreshErrors),
+	}
+}
+
+// MetricStruct indicates that Metrics is a metric.Struct.
+func (m *Metrics) MetricStruct() {}
@@ -0,0 +1,54 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tenantstoragequota
+
+import (
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+)
+
+// EnforcementMode controls what happens to writes from a tenant which has
+// exceeded its storage quota.
+type EnforcementMode int64
+
+const (
+	// EnforcementReject rejects writes with a
+	// kvpb.TenantStorageQuotaExceededError.
+	EnforcementReject EnforcementMode = iota
+	// EnforcementThrottle delays writes, slowing down the tenant's ingest
+	// without failing its queries.
+	EnforcementThrottle
+)
+
+var enforcementMode = settings.RegisterEnumSetting(
+	settings.SystemOnly,
+	"kv.tenant_storage_quota.enforcement_mode",
+	"how writes are handled once a tenant exceeds its storage quota; "+
+		"'reject' fails the writes, 'throttle' delays them",
+	"reject",
+	map[EnforcementMode]string{
+		EnforcementReject:   "reject",
+		EnforcementThrottle: "throttle",
+	},
+)
+
+var refreshInterval = settings.RegisterDurationSetting(
+	settings.SystemOnly,
+	"kv.tenant_storage_quota.refresh_interval",
+	"the interval at which the storage usage of tenants with a storage quota is refreshed",
+	30*time.Second,
+	settings.PositiveDuration,
+)
+
+var throttleDelay = settings.RegisterDurationSetting(
+	settings.SystemOnly,
+	"kv.tenant_storage_quota.throttle_delay",
+	"the delay applied to each write batch from a tenant which has exceeded its "+
+		"storage quota when the enforcement mode is 'throttle'",
+	100*time.Millisecond,
+	settings.PositiveDuration,
+)
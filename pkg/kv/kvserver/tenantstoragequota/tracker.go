@@ -0,0 +1,301 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package tenantstoragequota enforces the storage quotas granted to tenants
+// through the storage_quota_bytes capability.
+//
+// Each node periodically sums the logical bytes of the tenant ranges for which
+// it holds the lease, from the MVCC stats of its local replicas, and gossips
+// them. A tenant's usage is the sum of the usage gossiped by every node, so
+// computing it does not require any RPCs. It is approximate: a range whose
+// lease moves between two refreshes may be counted twice or not at all until
+// the next refresh. Writes which add data on behalf of a tenant whose
+// usage exceeds its quota are rejected or throttled, depending on the
+// kv.tenant_storage_quota.enforcement_mode cluster setting. Requests which
+// only delete data are always admitted, so that a tenant over its quota can
+// get back under it.
+package tenantstoragequota
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/gossip"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/multitenant/tenantcapabilities"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// UsageFetcher returns the logical bytes stored by each tenant in the ranges
+// for which the local node holds the lease.
+type UsageFetcher func(ctx context.Context) (map[roachpb.TenantID]int64, error)
+
+// Usage is the storage usage of a tenant.
+type Usage struct {
+	// LogicalBytes is the number of logical bytes stored in the tenant's
+	// keyspace, as of the last refresh.
+	LogicalBytes int64
+	// QuotaBytes is the tenant's storage quota. Zero means that the tenant
+	// does not have a quota.
+	QuotaBytes int64
+}
+
+// Exceeded returns whether the usage is above the quota.
+func (u Usage) Exceeded() bool {
+	return u.QuotaBytes > 0 && u.LogicalBytes > u.QuotaBytes
+}
+
+// Tracker tracks the storage usage of tenants with a storage quota and
+// enforces the quota on their writes. A nil *Tracker admits all writes.
+type Tracker struct {
+	st      *cluster.Settings
+	reader  tenantcapabilities.Reader
+	gossip  *gossip.Gossip
+	metrics Metrics
+
+	mu struct {
+		syncutil.RWMutex
+		fetchUsage UsageFetcher
+		// logicalBytes is the logical bytes stored by each tenant which had a
+		// storage quota as of the last refresh.
+		logicalBytes map[roachpb.TenantID]int64
+	}
+}
+
+// NewTracker constructs a new Tracker which reads tenant storage quotas from
+// the supplied capabilities reader and exchanges storage usage with the other
+// nodes through gossip.
+func NewTracker(
+	st *cluster.Settings, reader tenantcapabilities.Reader, g *gossip.Gossip,
+) *Tracker {
+	t := &Tracker{
+		st:      st,
+		reader:  reader,
+		gossip:  g,
+		metrics: makeMetrics(),
+	}
+	t.mu.logicalBytes = make(map[roachpb.TenantID]int64)
+	return t
+}
+
+// BindUsageFetcher sets the function used to compute the storage usage of
+// tenants on the local node. It must be called before Start; until it is, no tenant is
+// considered to be over its quota.
+func (t *Tracker) BindUsageFetcher(fetch UsageFetcher) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.mu.fetchUsage = fetch
+}
+
+// Metrics returns the Tracker's metrics.
+func (t *Tracker) Metrics() *Metrics {
+	return &t.metrics
+}
+
+// Start starts an async task which refreshes the storage usage of tenants
+// with a storage quota every kv.tenant_storage_quota.refresh_interval.
+func (t *Tracker) Start(ctx context.Context, stopper *stop.Stopper) error {
+	intervalChanged := make(chan struct{}, 1)
+	refreshInterval.SetOnChange(&t.st.SV, func(ctx context.Context) {
+		select {
+		case intervalChanged <- struct{}{}:
+		default:
+		}
+	})
+	return stopper.RunAsyncTask(ctx, "tenant-storage-quota-refresh", func(ctx context.Context) {
+		ctx, cancel := stopper.WithCancelOnQuiesce(ctx)
+		defer cancel()
+		var timer timeutil.Timer
+		defer timer.Stop()
+		for {
+			timer.Reset(refreshInterval.Get(&t.st.SV))
+			select {
+			case <-timer.C:
+				t.Refresh(ctx)
+			case <-intervalChanged:
+				// Wait for the new interval from now on.
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// Refresh gossips the storage usage of tenants with a storage quota on the
+// local node, and recomputes the usage of every such tenant from the usage
+// gossiped by all nodes. If the local usage cannot be computed, tenants retain
+// their previous usage.
+func (t *Tracker) Refresh(ctx context.Context) {
+	t.mu.RLock()
+	fetch := t.mu.fetchUsage
+	t.mu.RUnlock()
+	if fetch == nil {
+		return
+	}
+
+	quotas := make(map[roachpb.TenantID]int64)
+	for tenID, caps := range t.reader.GetGlobalCapabilityState() {
+		if tenID.IsSystem() || caps.StorageQuotaBytes <= 0 {
+			continue
+		}
+		quotas[tenID] = caps.StorageQuotaBytes
+	}
+
+	local, err := fetch(ctx)
+	if err != nil {
+		t.metrics.RefreshErrors.Inc(1)
+		log.KvExec.Warningf(ctx, "unable to compute local storage usage of tenants: %v", err)
+		return
+	}
+	var buf []byte
+	for tenID, n := range local {
+		if _, ok := quotas[tenID]; ok {
+			buf = encoding.EncodeUvarintAscending(buf, tenID.ToUint64())
+			buf = encoding.EncodeVarintAscending(buf, n)
+		}
+	}
+	// The usage expires if the node stops refreshing it, so that the usage of
+	// a dead node is eventually ignored. Its leases move elsewhere by then.
+	ttl := 3 * refreshInterval.Get(&t.st.SV)
+	key := gossip.MakeTenantStorageUsageKey(t.gossip.GetNodeID())
+	if err := t.gossip.AddInfo(key, buf, ttl); err != nil {
+		t.metrics.RefreshErrors.Inc(1)
+		log.KvExec.Warningf(ctx, "unable to gossip storage usage of tenants: %v", err)
+	}
+
+	logicalBytes := make(map[roachpb.TenantID]int64, len(quotas))
+	for tenID := range quotas {
+		logicalBytes[tenID] = 0
+	}
+	now := timeutil.Now().UnixNano()
+	if err := t.gossip.IterateInfos(gossip.KeyTenantStorageUsagePrefix, func(k string, info gossip.Info) error {
+		if info.TTLStamp <= now {
+			return nil
+		}
+		buf, err := info.Value.GetBytes()
+		if err != nil {
+			return errors.Wrapf(err, "decoding %q", k)
+		}
+		return errors.Wrapf(decodeUsage(buf, func(tenID roachpb.TenantID, n int64) {
+			if _, ok := logicalBytes[tenID]; ok {
+				logicalBytes[tenID] += n
+			}
+		}), "decoding %q", k)
+	}); err != nil {
+		t.metrics.RefreshErrors.Inc(1)
+		log.KvExec.Warningf(ctx, "unable to compute storage usage of tenants: %v", err)
+		return
+	}
+
+	var overQuota int64
+	for tenID, n := range logicalBytes {
+		if (Usage{LogicalBytes: n, QuotaBytes: quotas[tenID]}).Exceeded() {
+			overQuota++
+		}
+	}
+	t.metrics.TenantsOverQuota.Update(overQuota)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.mu.logicalBytes = logicalBytes
+}
+
+// decodeUsage decodes the storage usage gossiped by a node, calling visit with
+// the logical bytes of each tenant.
+func decodeUsage(buf []byte, visit func(roachpb.TenantID, int64)) error {
+	for len(buf) > 0 {
+		var id uint64
+		var n int64
+		var err error
+		if buf, id, err = encoding.DecodeUvarintAscending(buf); err != nil {
+			return err
+		}
+		if buf, n, err = encoding.DecodeVarintAscending(buf); err != nil {
+			return err
+		}
+		tenID, err := roachpb.MakeTenantID(id)
+		if err != nil {
+			return err
+		}
+		visit(tenID, n)
+	}
+	return nil
+}
+
+// GetUsage returns the storage usage of the given tenant. The quota reflects
+// the tenant's current capabilities, while the logical bytes are as of the
+// last refresh. The usage is only found for tenants which had a storage quota
+// as of the last refresh.
+func (t *Tracker) GetUsage(tenID roachpb.TenantID) (_ Usage, found bool) {
+	if t == nil {
+		return Usage{}, false
+	}
+	caps, found := t.reader.GetCapabilities(tenID)
+	if !found || caps.StorageQuotaBytes <= 0 {
+		return Usage{}, false
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	logicalBytes, found := t.mu.logicalBytes[tenID]
+	if !found {
+		return Usage{}, false
+	}
+	return Usage{LogicalBytes: logicalBytes, QuotaBytes: caps.StorageQuotaBytes}, true
+}
+
+// CheckBatch enforces the storage quota of the given tenant on the batch. If
+// the tenant has exceeded its quota and the batch adds data, the batch is
+// either rejected with a kvpb.TenantStorageQuotaExceededError or delayed,
+// depending on the enforcement mode.
+func (t *Tracker) CheckBatch(
+	ctx context.Context, tenID roachpb.TenantID, ba *kvpb.BatchRequest,
+) error {
+	if t == nil || !tenID.IsSet() || tenID.IsSystem() || !addsData(ba) {
+		return nil
+	}
+	usage, found := t.GetUsage(tenID)
+	if !found || !usage.Exceeded() {
+		return nil
+	}
+	switch enforcementMode.Get(&t.st.SV) {
+	case EnforcementThrottle:
+		t.metrics.ThrottledBatches.Inc(1)
+		var timer timeutil.Timer
+		defer timer.Stop()
+		timer.Reset(throttleDelay.Get(&t.st.SV))
+		select {
+		case <-timer.C:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	default:
+		t.metrics.RejectedBatches.Inc(1)
+		return kvpb.NewTenantStorageQuotaExceededError(tenID, usage.LogicalBytes, usage.QuotaBytes)
+	}
+}
+
+// addsData returns whether the batch contains a request which may increase
+// the amount of data stored. Deletions are admitted even when a tenant is over
+// its quota, so that it can free up space.
+func addsData(ba *kvpb.BatchRequest) bool {
+	for _, ru := range ba.Requests {
+		req := ru.GetInner()
+		switch req.Method() {
+		case kvpb.Delete, kvpb.DeleteRange, kvpb.ClearRange:
+			continue
+		}
+		if kvpb.IsIntentWrite(req) || kvpb.CanBackpressure(req) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tenantstoragequota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/gossip"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/multitenant/tenantcapabilities"
+	"github.com/cockroachdb/cockroach/pkg/multitenant/tenantcapabilitiespb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type testReader map[roachpb.TenantID]*tenantcapabilitiespb.TenantCapabilities
+
+var _ tenantcapabilities.Reader = testReader{}
+
+func (r testReader) GetInfo(
+	id roachpb.TenantID,
+) (_ tenantcapabilities.Entry, _ <-chan struct{}, found bool) {
+	caps, found := r[id]
+	return tenantcapabilities.Entry{TenantID: id, TenantCapabilities: caps}, nil, found
+}
+
+func (r testReader) GetCapabilities(
+	id roachpb.TenantID,
+) (_ *tenantcapabilitiespb.TenantCapabilities, found bool) {
+	caps, found := r[id]
+	return caps, found
+}
+
+func (r testReader) GetGlobalCapabilityState() map[roachpb.TenantID]*tenantcapabilitiespb.TenantCapabilities {
+	return r
+}
+
+func TestTracker(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+	g := gossip.NewTest(1, stopper, metric.NewRegistry())
+
+	overQuota := roachpb.MustMakeTenantID(10)
+	underQuota := roachpb.MustMakeTenantID(11)
+	noQuota := roachpb.MustMakeTenantID(12)
+	reader := testReader{
+		overQuota:  {StorageQuotaBytes: 100},
+		underQuota: {StorageQuotaBytes: 1000},
+		noQuota:    {},
+	}
+	tr := NewTracker(st, reader, g)
+	tr.BindUsageFetcher(func(context.Context) (map[roachpb.TenantID]int64, error) {
+		return map[roachpb.TenantID]int64{overQuota: 150, underQuota: 100, noQuota: 1 << 30}, nil
+	})
+	// Another node gossips its share of the usage of the tenants.
+	var remote []byte
+	remote = encoding.EncodeUvarintAscending(remote, overQuota.ToUint64())
+	remote = encoding.EncodeVarintAscending(remote, 50)
+	remote = encoding.EncodeUvarintAscending(remote, underQuota.ToUint64())
+	remote = encoding.EncodeVarintAscending(remote, 100)
+	require.NoError(t, g.AddInfo(gossip.MakeTenantStorageUsageKey(2), remote, time.Hour))
+
+	put := &kvpb.BatchRequest{}
+	put.Add(kvpb.NewPut(roachpb.Key("a"), roachpb.Value{}))
+	del := &kvpb.BatchRequest{}
+	del.Add(kvpb.NewDelete(roachpb.Key("a"), false /* mustAcquireExclusiveLock */))
+	get := &kvpb.BatchRequest{}
+	get.Add(kvpb.NewGet(roachpb.Key("a")))
+
+	// Until the first refresh, usage is unknown and all writes are admitted.
+	require.NoError(t, tr.CheckBatch(ctx, overQuota, put))
+
+	tr.Refresh(ctx)
+	require.Equal(t, int64(1), tr.Metrics().TenantsOverQuota.Value())
+	u, found := tr.GetUsage(overQuota)
+	require.True(t, found)
+	require.Equal(t, Usage{LogicalBytes: 200, QuotaBytes: 100}, u)
+	u, found = tr.GetUsage(underQuota)
+	require.True(t, found)
+	require.Equal(t, Usage{LogicalBytes: 200, QuotaBytes: 1000}, u)
+	_, found = tr.GetUsage(noQuota)
+	require.False(t, found)
+
+	// Only the usage of tenants with a storage quota is gossiped.
+	local, err := g.GetInfo(gossip.MakeTenantStorageUsageKey(1))
+	require.NoError(t, err)
+	require.NoError(t, decodeUsage(local, func(tenID roachpb.TenantID, _ int64) {
+		require.NotEqual(t, noQuota, tenID)
+	}))
+
+	err = tr.CheckBatch(ctx, overQuota, put)
+	var quotaErr *kvpb.TenantStorageQuotaExceededError
+	require.True(t, errors.As(err, &quotaErr), "%v", err)
+	require.Equal(t, int64(1), tr.Metrics().RejectedBatches.Count())
+	// Reads and deletions are admitted for a tenant over its quota.
+	require.NoError(t, tr.CheckBatch(ctx, overQuota, del))
+	require.NoError(t, tr.CheckBatch(ctx, overQuota, get))
+	// Other tenants, and the system tenant, are unaffected.
+	require.NoError(t, tr.CheckBatch(ctx, underQuota, put))
+	require.NoError(t, tr.CheckBatch(ctx, noQuota, put))
+	require.NoError(t, tr.CheckBatch(ctx, roachpb.SystemTenantID, put))
+
+	// In throttle mode, writes are delayed rather than rejected.
+	enforcementMode.Override(ctx, &st.SV, EnforcementThrottle)
+	require.NoError(t, tr.CheckBatch(ctx, overQuota, put))
+	require.Equal(t, int64(1), tr.Metrics().ThrottledBatches.Count())
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(t, tr.CheckBatch(cancelCtx, overQuota, put), context.Canceled)
+
+	// Raising the quota takes effect without waiting for a refresh.
+	enforcementMode.Override(ctx, &st.SV, EnforcementReject)
+	reader[overQuota] = &tenantcapabilitiespb.TenantCapabilities{StorageQuotaBytes: 1000}
+	require.NoError(t, tr.CheckBatch(ctx, overQuota, put))
+
+	// A nil Tracker admits everything.
+	require.NoError(t, (*Tracker)(nil).CheckBatch(ctx, overQuota, put))
+}
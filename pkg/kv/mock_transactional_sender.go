@@ -287,6 +287,11 @@ func (m *MockTransactionalSender) HasBufferedWrites() bool {
 	return false
 }
 
+// WriteBufferStats is part of TxnSenderFactory.
+func (m *MockTransactionalSender) WriteBufferStats() WriteBufferStats {
+	return WriteBufferStats{}
+}
+
 // TestingShouldRetry is part of TxnSenderFactory.
 func (m *MockTransactionalSender) TestingShouldRetry() bool {
 	return false
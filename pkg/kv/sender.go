@@ -385,6 +385,10 @@ type TxnSender interface {
 	// transaction's current epoch.
 	HasBufferedWrites() bool
 
+	// WriteBufferStats returns statistics about the transaction's use of
+	// client-side write buffering.
+	WriteBufferStats() WriteBufferStats
+
 	// TestingShouldRetry returns true if transaction retry errors should be
 	// randomly returned to callers. Note that it is the responsibility of
 	// (*kv.DB).Txn() to return the retries. This lives here since the
@@ -474,6 +474,13 @@ func (txn *Txn) SetWorkloadInfo(
 	txn.workloadType = workloadType
 }
 
+// SetResourceGroupID sets the ID of the resource group that the work done in
+// the context of this transaction belongs to. It is propagated to all
+// BatchRequests and leaf transactions through the admission header.
+func (txn *Txn) SetResourceGroupID(id admissionpb.ResourceGroupID) {
+	txn.admissionHeader.ResourceGroupID = uint64(id)
+}
+
 // SetBufferedWritesEnabled toggles whether the writes are buffered on the
 // gateway node until the commit time. Buffered writes cannot be enabled on a
 // txn that performed any requests. When disabling buffered writes, if there are
@@ -1922,6 +1929,14 @@ func (txn *Txn) HasBufferedWrites() bool {
 	return txn.mu.sender.HasBufferedWrites()
 }
 
+// WriteBufferStats returns statistics about the transaction's use of
+// client-side write buffering.
+func (txn *Txn) WriteBufferStats() WriteBufferStats {
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.WriteBufferStats()
+}
+
 // AdmissionHeader returns the admission header for work done in the context
 // of this transaction.
 func (txn *Txn) AdmissionHeader() kvpb.AdmissionHeader {
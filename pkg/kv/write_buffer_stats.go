@@ -0,0 +1,116 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package kv
+
+import "github.com/cockroachdb/redact"
+
+// WriteBufferFlushReason describes why a transaction's write buffer was
+// flushed. Once the buffer has been flushed, write buffering is disabled for
+// the remainder of the transaction, so each transaction records at most one
+// reason.
+type WriteBufferFlushReason int8
+
+const (
+	// WriteBufferNotFlushed indicates that the buffer has not been flushed.
+	WriteBufferNotFlushed WriteBufferFlushReason = iota
+	// WriteBufferFlushedOnCommit indicates that the buffer was flushed as part
+	// of committing the transaction. This is the expected case.
+	WriteBufferFlushedOnCommit
+	// WriteBufferFlushedMemoryLimit indicates that the buffer was flushed
+	// because it would have exceeded
+	// kv.transaction.write_buffering.max_buffer_size.
+	WriteBufferFlushedMemoryLimit
+	// WriteBufferFlushedUnsupportedRequest indicates that the buffer was
+	// flushed because the transaction issued a request which can't be
+	// buffered, such as a DeleteRange.
+	WriteBufferFlushedUnsupportedRequest
+	// WriteBufferFlushedDisabled indicates that the buffer was flushed because
+	// write buffering was disabled mid-transaction, e.g. by a DDL statement.
+	WriteBufferFlushedDisabled
+)
+
+// String implements the fmt.Stringer interface.
+func (r WriteBufferFlushReason) String() string {
+	return redact.StringWithoutMarkers(r)
+}
+
+// SafeFormat implements the redact.SafeFormatter interface.
+func (r WriteBufferFlushReason) SafeFormat(w redact.SafePrinter, _ rune) {
+	switch r {
+	case WriteBufferNotFlushed:
+		w.SafeString("not flushed")
+	case WriteBufferFlushedOnCommit:
+		w.SafeString("commit")
+	case WriteBufferFlushedMemoryLimit:
+		w.SafeString("memory limit")
+	case WriteBufferFlushedUnsupportedRequest:
+		w.SafeString("unsupported request")
+	case WriteBufferFlushedDisabled:
+		w.SafeString("buffering disabled")
+	default:
+		w.Printf("unknown(%d)", int8(r))
+	}
+}
+
+// Forced returns whether the flush happened before the transaction committed,
+// which loses the benefits of write buffering for the rest of the transaction.
+func (r WriteBufferFlushReason) Forced() bool {
+	return r != WriteBufferNotFlushed && r != WriteBufferFlushedOnCommit
+}
+
+// WriteBufferStats describes how effective client-side write buffering was for
+// a transaction. The stats accumulate across the epochs of the transaction.
+type WriteBufferStats struct {
+	// BufferedWrites is the number of writes which were buffered.
+	BufferedWrites int64
+	// FlushedWrites is the number of buffered writes which were sent to KV when
+	// the buffer was flushed. Writes to the same key may have been coalesced,
+	// so this may be lower than BufferedWrites.
+	FlushedWrites int64
+	// ReadHits is the number of point reads which found the key in the buffer
+	// and were served, at least in part, without reading from KV.
+	ReadHits int64
+	// BufferedBytes is the total size of the writes which were buffered.
+	BufferedBytes int64
+	// PeakBufferBytes is the largest size the buffer reached.
+	PeakBufferBytes int64
+	// FlushReason is the reason the buffer was flushed, if it was.
+	FlushReason WriteBufferFlushReason
+}
+
+// Sub returns the stats accumulated since other was recorded, for use in
+// attributing a transaction's stats to a statement. PeakBufferBytes is not
+// cumulative and is taken from s, while FlushReason is only retained if the
+// buffer was flushed since other was recorded.
+func (s WriteBufferStats) Sub(other WriteBufferStats) WriteBufferStats {
+	s.BufferedWrites -= other.BufferedWrites
+	s.FlushedWrites -= other.FlushedWrites
+	s.ReadHits -= other.ReadHits
+	s.BufferedBytes -= other.BufferedBytes
+	if s.FlushReason == other.FlushReason {
+		s.FlushReason = WriteBufferNotFlushed
+	}
+	return s
+}
+
+// Add accumulates other, which was recorded after s, into s.
+func (s *WriteBufferStats) Add(other WriteBufferStats) {
+	s.BufferedWrites += other.BufferedWrites
+	s.FlushedWrites += other.FlushedWrites
+	s.ReadHits += other.ReadHits
+	s.BufferedBytes += other.BufferedBytes
+	if other.PeakBufferBytes > s.PeakBufferBytes {
+		s.PeakBufferBytes = other.PeakBufferBytes
+	}
+	if other.FlushReason != WriteBufferNotFlushed {
+		s.FlushReason = other.FlushReason
+	}
+}
+
+// Empty returns whether no writes were buffered.
+func (s WriteBufferStats) Empty() bool {
+	return s.BufferedWrites == 0 && s.FlushedWrites == 0 && s.ReadHits == 0
+}
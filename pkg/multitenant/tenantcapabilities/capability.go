@@ -32,6 +32,7 @@ type TypedCapability[T any] interface {
 
 type (
 	BoolCapability             = TypedCapability[bool]
+	Int64Capability            = TypedCapability[int64]
 	SpanConfigBoundsCapability = TypedCapability[*spanconfigbounds.Bounds]
 )
 
@@ -46,6 +47,17 @@ func (b boolCapability) Value(t *tenantcapabilitiespb.TenantCapabilities) BoolVa
 	return MustGetValueByID(t, b.ID()).(BoolValue)
 }
 
+type int64Capability tenantcapabilitiespb.ID
+
+func (b int64Capability) String() string { return tenantcapabilitiespb.ID(b).String() }
+func (b int64Capability) SafeFormat(s interfaces.SafePrinter, verb rune) {
+	s.Print(tenantcapabilitiespb.ID(b))
+}
+func (b int64Capability) ID() tenantcapabilitiespb.ID { return tenantcapabilitiespb.ID(b) }
+func (b int64Capability) Value(t *tenantcapabilitiespb.TenantCapabilities) Int64Value {
+	return MustGetValueByID(t, b.ID()).(Int64Value)
+}
+
 type spanConfigBoundsCapability tenantcapabilitiespb.ID
 
 func (b spanConfigBoundsCapability) String() string { return tenantcapabilitiespb.ID(b).String() }
@@ -60,6 +72,7 @@ func (b spanConfigBoundsCapability) Value(
 }
 
 var _ TypedCapability[bool] = boolCapability(0)
+var _ TypedCapability[int64] = int64Capability(0)
 
 // FromName looks up a capability by name.
 func FromName(s string) (Capability, bool) {
@@ -91,6 +104,7 @@ var capabilities = [tenantcapabilitiespb.MaxCapabilityID + 1]Capability{
 	tenantcapabilitiespb.CanDebugProcess:        boolCapability(tenantcapabilitiespb.CanDebugProcess),
 	tenantcapabilitiespb.CanViewAllMetrics:      boolCapability(tenantcapabilitiespb.CanViewAllMetrics),
 	tenantcapabilitiespb.CanPrepareTxns:         boolCapability(tenantcapabilitiespb.CanPrepareTxns),
+	tenantcapabilitiespb.StorageQuotaBytes:      int64Capability(tenantcapabilitiespb.StorageQuotaBytes),
 }
 
 // EnableAll enables maximum access to services.
@@ -105,6 +119,10 @@ func EnableAll(t *tenantcapabilitiespb.TenantCapabilities) {
 			// Access to the service is enabled.
 			v.Set(true)
 
+		case TypedValue[int64]:
+			// No storage quota.
+			v.Set(0)
+
 		case TypedValue[*spanconfigbounds.Bounds]:
 			// No bound.
 			v.Set(nil)
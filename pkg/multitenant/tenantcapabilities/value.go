@@ -34,6 +34,7 @@ type TypedValue[T any] interface {
 
 type (
 	BoolValue            = TypedValue[bool]
+	Int64Value           = TypedValue[int64]
 	SpanConfigBoundValue = TypedValue[*spanconfigbounds.Bounds]
 )
 
@@ -62,6 +63,19 @@ func (b *invertedBoolValue) SafeFormat(p redact.SafePrinter, verb rune) {
 	p.Print(bool(!*b))
 }
 
+// int64Value is a wrapper around int64 that ensures that values can be
+// included in reportables.
+type int64Value int64
+
+var _ Int64Value = (*int64Value)(nil)
+
+func (i *int64Value) Get() int64     { return int64(*i) }
+func (i *int64Value) Set(val int64)  { *i = int64Value(val) }
+func (i *int64Value) String() string { return strconv.FormatInt(int64(*i), 10) }
+func (i *int64Value) SafeFormat(p redact.SafePrinter, verb rune) {
+	p.Print(int64(*i))
+}
+
 type spanConfigBoundsValue struct {
 	// Double-indirection is used because the Set method will overwrite the
 	// pointer with a new pointer.
@@ -29,6 +29,15 @@ func MustGetBoolByID(t *tenantcapabilitiespb.TenantCapabilities, id tenantcapabi
 	return MustGetValueByID(t, id).(BoolValue).Get()
 }
 
+// MustGetInt64ByID will get the int64 value for the capability corresponding
+// to the requested ID. If the ID is not valid or the capability is not an
+// int64 capability, this function will panic.
+func MustGetInt64ByID(
+	t *tenantcapabilitiespb.TenantCapabilities, id tenantcapabilitiespb.ID,
+) int64 {
+	return MustGetValueByID(t, id).(Int64Value).Get()
+}
+
 // GetValueByID looks up the capability value by ID. It returns an
 // error if the ID is not valid.
 func GetValueByID(
@@ -61,6 +70,8 @@ func GetValueByID(
 		return (*boolValue)(&t.CanViewAllMetrics), nil
 	case tenantcapabilitiespb.CanPrepareTxns:
 		return (*boolValue)(&t.CanPrepareTxns), nil
+	case tenantcapabilitiespb.StorageQuotaBytes:
+		return (*int64Value)(&t.StorageQuotaBytes), nil
 	default:
 		return nil, errors.AssertionFailedf("unknown capability: %q", id.String())
 	}
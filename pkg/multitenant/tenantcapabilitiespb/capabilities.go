@@ -91,6 +91,11 @@ const (
 	// part of the XA two-phase commit protocol.
 	CanPrepareTxns // can_prepare_txns
 
+	// StorageQuotaBytes describes the maximum logical bytes the tenant may
+	// store before its writes are rejected or throttled. Zero means that the
+	// tenant has no storage quota.
+	StorageQuotaBytes // storage_quota_bytes
+
 	MaxCapabilityID ID = iota - 1
 )
 
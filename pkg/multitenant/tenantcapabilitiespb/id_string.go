@@ -26,7 +26,8 @@ func _() {
 	_ = x[CanDebugProcess-11]
 	_ = x[CanViewAllMetrics-12]
 	_ = x[CanPrepareTxns-13]
-	_ = x[MaxCapabilityID-13]
+	_ = x[StorageQuotaBytes-14]
+	_ = x[MaxCapabilityID-14]
 }
 
 func (i ID) String() string {
@@ -57,6 +58,8 @@ func (i ID) String() string {
 		return "can_view_all_metrics"
 	case CanPrepareTxns:
 		return "can_prepare_txns"
+	case StorageQuotaBytes:
+		return "storage_quota_bytes"
 	default:
 		return "ID(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
@@ -76,7 +79,8 @@ var stringToCapabilityIDMap = map[string]ID{
 	"can_debug_process":         11,
 	"can_view_all_metrics":      12,
 	"can_prepare_txns":          13,
-	"MaxCapabilityID":           13,
+	"storage_quota_bytes":       14,
+	"MaxCapabilityID":           14,
 }
 
 var IDs = []ID{
@@ -92,5 +96,6 @@ var IDs = []ID{
 	CanViewNodeInfo,
 	CanViewTSDBMetrics,
 	ExemptFromRateLimiting,
+	StorageQuotaBytes,
 	TenantSpanConfigBounds,
 }
@@ -0,0 +1,440 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package revlog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/revlog/revlogpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+// ProblemKind classifies a Problem found by Inspect.
+type ProblemKind string
+
+const (
+	// ProblemCorrupt is an object that cannot be read, fails its
+	// framing check, or does not decode.
+	ProblemCorrupt ProblemKind = "corrupt"
+	// ProblemManifest is a manifest that decodes but violates one of
+	// the format's invariants (e.g. a TickEnd that disagrees with its
+	// path, or an unsorted inline tail).
+	ProblemManifest ProblemKind = "manifest"
+	// ProblemGap is a hole between two consecutive closed ticks: the
+	// later tick's TickStart is past the earlier tick's TickEnd, so
+	// changes in between are not in the log.
+	ProblemGap ProblemKind = "gap"
+	// ProblemOverlap is a pair of consecutive closed ticks whose
+	// coverage intervals overlap.
+	ProblemOverlap ProblemKind = "overlap"
+	// ProblemMissingFile is a data file listed in a manifest that is
+	// not present in storage.
+	ProblemMissingFile ProblemKind = "missing-file"
+	// ProblemLateFile is a data file PUT under a closed tick that its
+	// manifest does not list. Readers ignore such files.
+	ProblemLateFile ProblemKind = "late-file"
+	// ProblemOrphanTick is a data directory with no close marker for
+	// a tick that ends before the last closed tick. The tick was
+	// abandoned and readers ignore its files.
+	ProblemOrphanTick ProblemKind = "orphan-tick"
+	// ProblemUncovered is a closed tick that ends before the log's
+	// first coverage epoch.
+	ProblemUncovered ProblemKind = "uncovered"
+	// ProblemStray is an object under log/ whose name does not follow
+	// the format's layout.
+	ProblemStray ProblemKind = "stray"
+)
+
+// Problem is one finding reported by Inspect.
+type Problem struct {
+	Kind ProblemKind
+	// Path is the object the problem was found at, relative to the
+	// storage root.
+	Path string
+	Msg  string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s: %s", p.Kind, p.Path, p.Msg)
+}
+
+// InspectedTick is one close marker found by Inspect.
+type InspectedTick struct {
+	Tick
+	// Err is set if the marker could not be read or decoded, in which
+	// case Manifest is empty.
+	Err error
+	// DataFiles are the IDs of the data files present under the
+	// tick's data directory, sorted.
+	DataFiles []int64
+	// Coverage is the coverage epoch in effect at the tick's end, or
+	// nil if the tick precedes the first epoch.
+	Coverage *revlogpb.Coverage
+}
+
+// InspectedCoverage is one coverage epoch found by Inspect.
+type InspectedCoverage struct {
+	Path     string
+	Coverage revlogpb.Coverage
+	// Err is set if the object could not be read or decoded.
+	Err error
+}
+
+// OpenTick is a tick with data files but no close marker that ends
+// after the last closed tick, i.e. a tick that is still being
+// written.
+type OpenTick struct {
+	EndTime   hlc.Timestamp
+	DataFiles []int64
+}
+
+// Report is the result of Inspect.
+type Report struct {
+	// Ticks are the closed ticks, in tick-end order.
+	Ticks []InspectedTick
+	// Coverage are the coverage epochs, in effective-from order.
+	Coverage []InspectedCoverage
+	// OpenTicks are the ticks past the last closed tick that have
+	// data files, in tick-end order.
+	OpenTicks []OpenTick
+	// SchemaChanges is the number of descriptor-change objects.
+	SchemaChanges int
+	// Problems are the invariant violations found, grouped by the
+	// order in which Inspect checks them.
+	Problems []Problem
+}
+
+// InspectOptions configures Inspect.
+type InspectOptions struct {
+	// VerifyData, if set, reads and decodes every data file listed by
+	// a closed tick instead of only checking that it exists.
+	VerifyData bool
+}
+
+// Inspect walks the whole log in es — every close marker, data file,
+// coverage epoch and descriptor-change object — and checks them
+// against the invariants of revlog-format.md. It is meant for
+// offline debugging tools rather than the restore path, so unlike
+// LogReader it does not stop at the first bad object: per-object
+// failures are recorded in the returned Report, and only errors
+// listing the storage are returned.
+func Inspect(ctx context.Context, es cloud.ExternalStorage, opts InspectOptions) (Report, error) {
+	var r Report
+	if err := r.inspectCoverage(ctx, es); err != nil {
+		return Report{}, err
+	}
+	dataFiles, err := r.listDataFiles(ctx, es)
+	if err != nil {
+		return Report{}, err
+	}
+	if err := r.inspectTicks(ctx, es, dataFiles); err != nil {
+		return Report{}, err
+	}
+	r.checkTickChain()
+	r.checkDataFiles(dataFiles)
+	if opts.VerifyData {
+		r.verifyData(ctx, es)
+	}
+	if err := r.inspectSchema(ctx, es); err != nil {
+		return Report{}, err
+	}
+	return r, nil
+}
+
+func (r *Report) addProblem(kind ProblemKind, path string, format string, args ...interface{}) {
+	r.Problems = append(r.Problems, Problem{Kind: kind, Path: path, Msg: fmt.Sprintf(format, args...)})
+}
+
+// listRel lists everything under root and returns the names relative
+// to root, sorted. See the matching normalization in reader.go's
+// Ticks for why a leading slash and root itself are stripped.
+func listRel(ctx context.Context, es cloud.ExternalStorage, root string) ([]string, error) {
+	var names []string
+	err := es.List(ctx, root, cloud.ListOptions{}, func(name string) error {
+		rel := strings.TrimPrefix(name, "/")
+		names = append(names, strings.TrimPrefix(rel, root))
+		return nil
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "listing %s", root)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+func (r *Report) inspectCoverage(ctx context.Context, es cloud.ExternalStorage) error {
+	names, err := listRel(ctx, es, CoverageRoot)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		path := CoverageRoot + name
+		effectiveFrom, err := ParseHLCName(name)
+		if err != nil {
+			r.addProblem(ProblemStray, path, "%v", err)
+			continue
+		}
+		c, err := ReadCoverage(ctx, es, path)
+		if err != nil {
+			r.Coverage = append(r.Coverage, InspectedCoverage{Path: path, Err: err})
+			r.addProblem(ProblemCorrupt, path, "%v", err)
+			continue
+		}
+		if c.EffectiveFrom != effectiveFrom {
+			r.addProblem(ProblemManifest, path,
+				"effective_from %s does not match path HLC %s", c.EffectiveFrom, effectiveFrom)
+		}
+		r.Coverage = append(r.Coverage, InspectedCoverage{Path: path, Coverage: c})
+	}
+	return nil
+}
+
+// listDataFiles returns the IDs of the data files under log/data/,
+// keyed by formatted tick-end.
+func (r *Report) listDataFiles(
+	ctx context.Context, es cloud.ExternalStorage,
+) (map[string][]int64, error) {
+	root := dataDir + "/"
+	names, err := listRel(ctx, es, root)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string][]int64)
+	for _, name := range names {
+		// name = "<tick-end>/<file_id>.sst", where tick-end itself
+		// contains a "/".
+		slash := strings.LastIndexByte(name, '/')
+		var fileID int64
+		var err error
+		if slash < 0 || !strings.HasSuffix(name, sstExt) {
+			err = errors.Newf("not a data file")
+		} else if _, err = ParseTickEnd(name[:slash]); err == nil {
+			fileID, err = strconv.ParseInt(strings.TrimSuffix(name[slash+1:], sstExt), 10, 64)
+		}
+		if err != nil {
+			r.addProblem(ProblemStray, root+name, "%v", err)
+			continue
+		}
+		files[name[:slash]] = append(files[name[:slash]], fileID)
+	}
+	for _, ids := range files {
+		slices.Sort(ids)
+	}
+	return files, nil
+}
+
+func (r *Report) inspectTicks(
+	ctx context.Context, es cloud.ExternalStorage, dataFiles map[string][]int64,
+) error {
+	names, err := listRel(ctx, es, ResolvedRoot)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		path := ResolvedRoot + name
+		fragment := strings.TrimSuffix(name, markerExt)
+		tickEnd, err := ParseTickEnd(fragment)
+		if fragment == name || err != nil {
+			r.addProblem(ProblemStray, path, "not a tick close marker")
+			continue
+		}
+		t := InspectedTick{Tick: Tick{EndTime: tickEnd}, DataFiles: dataFiles[fragment]}
+		t.Coverage = r.coverageAt(tickEnd)
+		m, err := readManifest(ctx, es, path)
+		if err != nil {
+			t.Err = err
+			r.Ticks = append(r.Ticks, t)
+			r.addProblem(ProblemCorrupt, path, "%v", err)
+			continue
+		}
+		t.Manifest = m
+		r.Ticks = append(r.Ticks, t)
+		r.checkManifest(path, fragment, m)
+		if t.Coverage == nil && len(r.Coverage) > 0 {
+			r.addProblem(ProblemUncovered, path,
+				"tick ends before the first coverage epoch (%s)", r.Coverage[0].Coverage.EffectiveFrom)
+		}
+	}
+	return nil
+}
+
+// coverageAt returns the readable coverage epoch with the largest
+// effective_from <= ts, or nil.
+func (r *Report) coverageAt(ts hlc.Timestamp) *revlogpb.Coverage {
+	for i := len(r.Coverage) - 1; i >= 0; i-- {
+		c := &r.Coverage[i]
+		if c.Err == nil && c.Coverage.EffectiveFrom.LessEq(ts) {
+			return &c.Coverage
+		}
+	}
+	return nil
+}
+
+// checkManifest checks the invariants of one decoded manifest.
+func (r *Report) checkManifest(path, fragment string, m revlogpb.Manifest) {
+	if got := FormatTickEnd(m.TickEnd); got != fragment {
+		r.addProblem(ProblemManifest, path, "tick_end %s (%s) does not match path", m.TickEnd, got)
+	}
+	if !m.TickStart.Less(m.TickEnd) {
+		r.addProblem(ProblemManifest, path, "tick_start %s is not before tick_end %s", m.TickStart, m.TickEnd)
+	}
+	seen := make(map[int64]struct{}, len(m.Files))
+	for _, f := range m.Files {
+		if _, ok := seen[f.FileID]; ok {
+			r.addProblem(ProblemManifest, path, "file %d listed more than once", f.FileID)
+		}
+		seen[f.FileID] = struct{}{}
+	}
+	for i := range m.InlineTail {
+		e := &m.InlineTail[i]
+		if e.MvccTs.LessEq(m.TickStart) || m.TickEnd.Less(e.MvccTs) {
+			r.addProblem(ProblemManifest, path,
+				"inline tail entry %d at %s is outside the tick (%s, %s]", i, e.MvccTs, m.TickStart, m.TickEnd)
+		}
+		if i == 0 {
+			continue
+		}
+		prev := &m.InlineTail[i-1]
+		if c := bytes.Compare(prev.UserKey, e.UserKey); c > 0 || (c == 0 && !prev.MvccTs.Less(e.MvccTs)) {
+			r.addProblem(ProblemManifest, path, "inline tail entry %d is out of order", i)
+		}
+	}
+}
+
+// checkTickChain reports gaps and overlaps between consecutive closed
+// ticks.
+func (r *Report) checkTickChain() {
+	var prev *InspectedTick
+	for i := range r.Ticks {
+		t := &r.Ticks[i]
+		if t.Err != nil {
+			continue
+		}
+		if prev != nil {
+			path := MarkerPath(t.EndTime)
+			switch prevEnd := prev.Manifest.TickEnd; {
+			case prevEnd.Less(t.Manifest.TickStart):
+				r.addProblem(ProblemGap, path, "(%s, %s] is not covered by any tick", prevEnd, t.Manifest.TickStart)
+			case t.Manifest.TickStart.Less(prevEnd):
+				r.addProblem(ProblemOverlap, path,
+					"tick_start %s is before the previous tick's tick_end %s", t.Manifest.TickStart, prevEnd)
+			}
+		}
+		prev = t
+	}
+}
+
+// checkDataFiles reconciles the data files in storage with the
+// manifests that list them.
+func (r *Report) checkDataFiles(dataFiles map[string][]int64) {
+	closed := make(map[string]struct{}, len(r.Ticks))
+	var frontier hlc.Timestamp
+	for i := range r.Ticks {
+		t := &r.Ticks[i]
+		closed[FormatTickEnd(t.EndTime)] = struct{}{}
+		frontier.Forward(t.EndTime)
+		if t.Err != nil {
+			continue
+		}
+		listed := make(map[int64]struct{}, len(t.Manifest.Files))
+		for _, f := range t.Manifest.Files {
+			listed[f.FileID] = struct{}{}
+			if _, found := slices.BinarySearch(t.DataFiles, f.FileID); !found {
+				r.addProblem(ProblemMissingFile, DataFilePath(t.EndTime, f.FileID), "listed by %s", MarkerPath(t.EndTime))
+			}
+		}
+		for _, id := range t.DataFiles {
+			if _, ok := listed[id]; !ok {
+				r.addProblem(ProblemLateFile, DataFilePath(t.EndTime, id),
+					"not listed by %s; readers ignore it", MarkerPath(t.EndTime))
+			}
+		}
+	}
+
+	unsealed := make([]string, 0, len(dataFiles))
+	for fragment := range dataFiles {
+		if _, ok := closed[fragment]; !ok {
+			unsealed = append(unsealed, fragment)
+		}
+	}
+	slices.Sort(unsealed)
+	for _, fragment := range unsealed {
+		// The fragment was parsed by listDataFiles.
+		tickEnd, _ := ParseTickEnd(fragment)
+		if frontier.Less(tickEnd) {
+			r.OpenTicks = append(r.OpenTicks, OpenTick{EndTime: tickEnd, DataFiles: dataFiles[fragment]})
+			continue
+		}
+		r.addProblem(ProblemOrphanTick, DataDirPath(tickEnd),
+			"%d data files but no close marker, and a later tick is closed", len(dataFiles[fragment]))
+	}
+}
+
+// verifyData decodes every data file of every readable closed tick.
+func (r *Report) verifyData(ctx context.Context, es cloud.ExternalStorage) {
+	lr := NewLogReader(es)
+	for i := range r.Ticks {
+		t := &r.Ticks[i]
+		if t.Err != nil {
+			continue
+		}
+		for _, f := range t.Manifest.Files {
+			if _, found := slices.BinarySearch(t.DataFiles, f.FileID); !found {
+				// Already reported as missing.
+				continue
+			}
+			one := Tick{EndTime: t.EndTime, Manifest: revlogpb.Manifest{Files: []revlogpb.File{f}}}
+			for ev, err := range lr.GetTickReader(ctx, one, nil /* spans */).Events(ctx) {
+				if err != nil {
+					r.addProblem(ProblemCorrupt, DataFilePath(t.EndTime, f.FileID), "%v", err)
+					break
+				}
+				if ev.Timestamp.LessEq(t.Manifest.TickStart) || t.Manifest.TickEnd.Less(ev.Timestamp) {
+					r.addProblem(ProblemManifest, DataFilePath(t.EndTime, f.FileID),
+						"event %s at %s is outside the tick (%s, %s]",
+						ev.Key, ev.Timestamp, t.Manifest.TickStart, t.Manifest.TickEnd)
+					break
+				}
+			}
+		}
+	}
+}
+
+func (r *Report) inspectSchema(ctx context.Context, es cloud.ExternalStorage) error {
+	names, err := listRel(ctx, es, SchemaDescsRoot)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		path := SchemaDescsRoot + name
+		// name = "<HLC-name>/<desc-id>.pb"
+		slash := strings.IndexByte(name, '/')
+		if slash < 0 || !strings.HasSuffix(name, markerExt) {
+			r.addProblem(ProblemStray, path, "not a descriptor change")
+			continue
+		}
+		if _, err := ParseHLCName(name[:slash]); err != nil {
+			r.addProblem(ProblemStray, path, "%v", err)
+			continue
+		}
+		if _, err := ReadSchemaDesc(ctx, es, path); err != nil {
+			r.addProblem(ProblemCorrupt, path, "%v", err)
+			continue
+		}
+		r.SchemaChanges++
+	}
+	return nil
+}
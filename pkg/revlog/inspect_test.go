@@ -0,0 +1,136 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package revlog_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/revlog"
+	"github.com/cockroachdb/cockroach/pkg/revlog/revlogpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+// problemKinds returns the (kind, path) pairs of a report's problems.
+func problemKinds(r revlog.Report) [][2]string {
+	var out [][2]string
+	for _, p := range r.Problems {
+		out = append(out, [2]string{string(p.Kind), p.Path})
+	}
+	return out
+}
+
+// TestInspect builds a well-formed log, checks that Inspect finds no
+// problems in it, then damages it in several ways and checks that
+// each is reported.
+func TestInspect(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	es := newTestStorage(t)
+	defer es.Close()
+
+	t10 := tickEnd(2026, 4, 20, 15, 30, 10)
+	t20 := tickEnd(2026, 4, 20, 15, 30, 20)
+	t30 := tickEnd(2026, 4, 20, 15, 30, 30)
+	t40 := tickEnd(2026, 4, 20, 15, 30, 40)
+	t50 := tickEnd(2026, 4, 20, 15, 30, 50)
+
+	require.NoError(t, revlog.WriteCoverage(ctx, es, revlogpb.Coverage{
+		EffectiveFrom: t10.AddDuration(-testTickWidth),
+		Scope:         "cluster",
+		Spans:         []roachpb.Span{{Key: key("a"), EndKey: key("z")}},
+	}))
+	for i, te := range []hlc.Timestamp{t10, t20} {
+		f := writeTick(t, ctx, es, te, int64(i+1), 0, []revlog.Event{
+			ev("k", te.WallTime, 0, "v", ""),
+		})
+		sealTick(t, ctx, es, te, []revlogpb.File{f})
+	}
+
+	r, err := revlog.Inspect(ctx, es, revlog.InspectOptions{VerifyData: true})
+	require.NoError(t, err)
+	require.Empty(t, r.Problems)
+	require.Len(t, r.Ticks, 2)
+	require.Len(t, r.Coverage, 1)
+	for _, tk := range r.Ticks {
+		require.NoError(t, tk.Err)
+		require.Len(t, tk.DataFiles, 1)
+		require.NotNil(t, tk.Coverage)
+		require.Equal(t, "cluster", tk.Coverage.Scope)
+	}
+
+	// A file PUT under t20 after it was sealed.
+	writeTick(t, ctx, es, t20, 9, 1, []revlog.Event{ev("k", t20.WallTime, 1, "late", "")})
+	// t30 was written but never sealed, and t40 skips it, leaving a
+	// gap. t40 also lists a file that was never written.
+	writeTick(t, ctx, es, t30, 3, 0, []revlog.Event{ev("k", t30.WallTime, 0, "v", "")})
+	f4 := writeTick(t, ctx, es, t40, 4, 0, []revlog.Event{ev("k", t40.WallTime, 0, "v", "")})
+	require.NoError(t, revlog.WriteTickManifest(ctx, es, revlogpb.Manifest{
+		TickStart: t30,
+		TickEnd:   t40,
+		Files:     []revlogpb.File{f4, {FileID: 5}},
+	}))
+	// t50 is still open.
+	writeTick(t, ctx, es, t50, 6, 0, []revlog.Event{ev("k", t50.WallTime, 0, "v", "")})
+	// A descriptor change that fails its framing check.
+	schemaPath := revlog.SchemaDescPath(t10, 104)
+	require.NoError(t, cloud.WriteFile(ctx, es, schemaPath, bytes.NewReader([]byte("junk"))))
+
+	r, err = revlog.Inspect(ctx, es, revlog.InspectOptions{VerifyData: true})
+	require.NoError(t, err)
+	require.Equal(t, [][2]string{
+		{"gap", revlog.MarkerPath(t40)},
+		{"late-file", revlog.DataFilePath(t20, 9)},
+		{"missing-file", revlog.DataFilePath(t40, 5)},
+		{"orphan-tick", revlog.DataDirPath(t30)},
+		{"corrupt", schemaPath},
+	}, problemKinds(r))
+	require.Len(t, r.Ticks, 3)
+	require.Equal(t, []revlog.OpenTick{{EndTime: t50, DataFiles: []int64{6}}}, r.OpenTicks)
+}
+
+// TestInspectManifestInvariants checks the per-manifest invariants.
+func TestInspectManifestInvariants(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	es := newTestStorage(t)
+	defer es.Close()
+
+	t10 := tickEnd(2026, 4, 20, 15, 30, 10)
+	t20 := tickEnd(2026, 4, 20, 15, 30, 20)
+
+	require.NoError(t, revlog.WriteTickManifest(ctx, es, revlogpb.Manifest{
+		TickStart: t10.AddDuration(-testTickWidth),
+		TickEnd:   t10,
+		InlineTail: []revlogpb.FlushEntry{
+			{UserKey: key("b"), MvccTs: t10},
+			{UserKey: key("a"), MvccTs: t10},
+		},
+	}))
+	// A tick that starts before the previous one ended, with a file
+	// listed twice and an inline tail entry outside the tick.
+	f := writeTick(t, ctx, es, t20, 1, 0, nil)
+	require.NoError(t, revlog.WriteTickManifest(ctx, es, revlogpb.Manifest{
+		TickStart:  t10.AddDuration(-1),
+		TickEnd:    t20,
+		Files:      []revlogpb.File{f, f},
+		InlineTail: []revlogpb.FlushEntry{{UserKey: key("a"), MvccTs: t20.Next()}},
+	}))
+
+	r, err := revlog.Inspect(ctx, es, revlog.InspectOptions{})
+	require.NoError(t, err)
+	require.Equal(t, [][2]string{
+		{"manifest", revlog.MarkerPath(t10)},
+		{"manifest", revlog.MarkerPath(t20)},
+		{"manifest", revlog.MarkerPath(t20)},
+		{"overlap", revlog.MarkerPath(t20)},
+	}, problemKinds(r))
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package restorerevlog
+
+import (
+	"context"
+	"slices"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/revlog"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/errors"
+)
+
+// ResolvedAfter checks that the revision log can yield the values as of asOf
+// of the keys in spans, and returns the end of its last closed tick, through
+// which ValuesAsOf reads it. The log must not have any gap between logStart,
+// the end of the backup the log continues, and the returned time, which must
+// not be before asOf, and it must have covered spans since asOf. Spans must be
+// sorted and non-overlapping.
+func ResolvedAfter(
+	ctx context.Context, es cloud.ExternalStorage, spans []roachpb.Span, logStart, asOf hlc.Timestamp,
+) (resolved hlc.Timestamp, _ error) {
+	if asOf.Less(logStart) {
+		return hlc.Timestamp{}, errors.AssertionFailedf(
+			"revision log start %s is after the target time %s", logStart, asOf,
+		)
+	}
+	if err := checkCoverage(ctx, es, spans, asOf); err != nil {
+		return hlc.Timestamp{}, err
+	}
+	resolved = logStart
+	for tick, err := range revlog.NewLogReader(es).Ticks(ctx, logStart, hlc.MaxTimestamp) {
+		if err != nil {
+			return hlc.Timestamp{}, errors.Wrap(err, "listing revision log ticks")
+		}
+		if resolved.Less(tick.Manifest.TickStart) {
+			return hlc.Timestamp{}, errors.Newf(
+				"revision log has no closed tick between %s and %s", resolved, tick.Manifest.TickStart,
+			)
+		}
+		resolved = tick.EndTime
+	}
+	if resolved.Less(asOf) {
+		return hlc.Timestamp{}, errors.Newf(
+			"revision log has only resolved through %s, before the target time %s", resolved, asOf,
+		)
+	}
+	if err := checkCoverage(ctx, es, spans, resolved); err != nil {
+		return hlc.Timestamp{}, err
+	}
+	return resolved, nil
+}
+
+// ValuesAsOf calls fn with the value as of asOf of every key in spans that the
+// revision log records as changed after asOf and at or before resolved, which
+// must have been returned by ResolvedAfter. A key that did not exist at asOf is
+// passed with an empty value. The keys are passed in pages of at most pageSize
+// keys, in the order of their first change after asOf, and fn must not retain
+// a page after it returns.
+//
+// The value of a key as of asOf is the prior value carried by its first event
+// after asOf, so only the ticks after asOf are read. The keys already passed
+// to fn are remembered to skip their later events, and are accounted for in
+// acc along with the page being built.
+func ValuesAsOf(
+	ctx context.Context,
+	es cloud.ExternalStorage,
+	spans []roachpb.Span,
+	logStart, asOf, resolved hlc.Timestamp,
+	acc *mon.BoundAccount,
+	pageSize int,
+	fn func(page []roachpb.KeyValue) error,
+) error {
+	lr := revlog.NewLogReader(es)
+	seen := make(map[string]struct{})
+	var page []roachpb.KeyValue
+	var pageBytes int64
+	flush := func() error {
+		if len(page) == 0 {
+			return nil
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+		acc.Shrink(ctx, pageBytes)
+		page, pageBytes = page[:0], 0
+		return nil
+	}
+	for tick, err := range lr.Ticks(ctx, logStart, hlc.MaxTimestamp) {
+		if err != nil {
+			return errors.Wrap(err, "listing revision log ticks")
+		}
+		if resolved.Less(tick.EndTime) {
+			break
+		}
+		if !asOf.Less(tick.EndTime) {
+			// The tick only holds changes at or before asOf.
+			continue
+		}
+		for ev, err := range lr.GetTickReader(ctx, tick, spans).Events(ctx) {
+			if err != nil {
+				return errors.Wrapf(err, "reading tick ending at %s", tick.EndTime)
+			}
+			if !asOf.Less(ev.Timestamp) {
+				continue
+			}
+			if _, ok := seen[string(ev.Key)]; ok {
+				continue
+			}
+			if err := acc.Grow(ctx, int64(len(ev.Key))); err != nil {
+				return err
+			}
+			seen[string(ev.Key)] = struct{}{}
+			sz := int64(len(ev.Key) + len(ev.PrevValue.RawBytes))
+			if err := acc.Grow(ctx, sz); err != nil {
+				return err
+			}
+			pageBytes += sz
+			page = append(page, roachpb.KeyValue{
+				Key:   slices.Clone(ev.Key),
+				Value: roachpb.Value{RawBytes: slices.Clone(ev.PrevValue.RawBytes)},
+			})
+			if len(page) >= pageSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return flush()
+}
+
+// checkCoverage checks that the revision log was tracking spans at ts.
+func checkCoverage(
+	ctx context.Context, es cloud.ExternalStorage, spans []roachpb.Span, ts hlc.Timestamp,
+) error {
+	c, ok, err := revlog.CoverageAt(ctx, es, ts)
+	if err != nil {
+		return err
+	}
+	var covered roachpb.SpanGroup
+	if ok {
+		covered.Add(c.Spans...)
+	}
+	if !covered.Encloses(spans...) {
+		return errors.Newf("revision log does not cover the requested spans at %s", ts)
+	}
+	return nil
+}
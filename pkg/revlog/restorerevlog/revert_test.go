@@ -0,0 +1,97 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package restorerevlog
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/revlog"
+	"github.com/cockroachdb/cockroach/pkg/revlog/revlogpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/stretchr/testify/require"
+)
+
+// testDiffEvent returns an event changing k from prev to v at the given
+// second of the test ticks.
+func testDiffEvent(k string, sec int, v, prev string) revlog.Event {
+	ev := revlog.Event{Key: roachpb.Key(k), Timestamp: testTickEnd(sec).Add(0, 1)}
+	if v != "" {
+		ev.Value.RawBytes = []byte(v)
+	}
+	if prev != "" {
+		ev.PrevValue.RawBytes = []byte(prev)
+	}
+	return ev
+}
+
+func TestValuesAsOf(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	es := newTestStorage(t)
+	defer es.Close()
+
+	span := roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("z")}
+	require.NoError(t, revlog.WriteCoverage(ctx, es, revlogpb.Coverage{
+		EffectiveFrom: testTickEnd(0),
+		Spans:         []roachpb.Span{span},
+	}))
+	writeTestTick(t, ctx, es, testTickEnd(10), 1, []revlog.Event{
+		testDiffEvent("a", 5, "a1", ""),
+		testDiffEvent("b", 6, "b1", ""),
+	})
+	writeTestTick(t, ctx, es, testTickEnd(20), 2, []revlog.Event{
+		testDiffEvent("a", 12, "a2", "a1"),
+		testDiffEvent("a", 15, "a3", "a2"),
+		testDiffEvent("c", 14, "c1", ""),
+	})
+	writeTestTick(t, ctx, es, testTickEnd(30), 3, []revlog.Event{
+		testDiffEvent("a", 26, "a4", "a3"),
+		testDiffEvent("b", 25, "", "b1"),
+		testDiffEvent("zz", 27, "zz1", ""),
+	})
+
+	acc := mon.NewStandaloneUnlimitedAccount()
+	resolved, err := ResolvedAfter(ctx, es, []roachpb.Span{span}, testTickEnd(0), testTickEnd(13))
+	require.NoError(t, err)
+	require.Equal(t, testTickEnd(30), resolved)
+	var got []string
+	var pages int
+	require.NoError(t, ValuesAsOf(
+		ctx, es, []roachpb.Span{span}, testTickEnd(0), testTickEnd(13), resolved, acc, 2, /* pageSize */
+		func(page []roachpb.KeyValue) error {
+			require.LessOrEqual(t, len(page), 2)
+			pages++
+			for _, kv := range page {
+				got = append(got, string(kv.Key)+"="+string(kv.Value.RawBytes))
+			}
+			return nil
+		},
+	))
+	require.Equal(t, 2, pages)
+	sort.Strings(got)
+	// a was last changed before 13s to a2, b was unchanged since it was
+	// written, and c did not exist yet.
+	require.Equal(t, []string{"a=a2", "b=b1", "c="}, got)
+
+	_, err = ResolvedAfter(ctx, es, []roachpb.Span{span}, testTickEnd(0), testTickEnd(35))
+	require.ErrorContains(t, err, "only resolved through")
+
+	_, err = ResolvedAfter(
+		ctx, es, []roachpb.Span{{Key: roachpb.Key("a"), EndKey: roachpb.Key("zzz")}},
+		testTickEnd(0), testTickEnd(13),
+	)
+	require.ErrorContains(t, err, "does not cover")
+
+	// A missing tick leaves a gap in the log.
+	require.NoError(t, es.Delete(ctx, revlog.MarkerPath(testTickEnd(20))))
+	_, err = ResolvedAfter(ctx, es, []roachpb.Span{span}, testTickEnd(0), testTickEnd(13))
+	require.ErrorContains(t, err, "no closed tick between")
+}
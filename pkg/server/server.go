@@ -64,6 +64,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/rangelog"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/reports"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/storeliveness"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tenantstoragequota"
 	"github.com/cockroachdb/cockroach/pkg/multitenant/tenantcapabilities"
 	"github.com/cockroachdb/cockroach/pkg/multitenant/tenantcapabilities/tenantcapabilitiesauthorizer"
 	"github.com/cockroachdb/cockroach/pkg/multitenant/tenantcapabilities/tenantcapabilitieswatcher"
@@ -218,6 +219,7 @@ type topLevelServer struct {
 	spanConfigReporter   spanconfig.Reporter
 
 	tenantCapabilitiesWatcher *tenantcapabilitieswatcher.Watcher
+	tenantStorageQuota        *tenantstoragequota.Tracker
 
 	// pgL is the SQL listener for pgwire connections coming over the network.
 	pgL net.Listener
@@ -851,6 +853,27 @@ func NewServer(cfg Config, stopper *stop.Stopper) (serverctl.ServerStartupInterf
 		tenantCapabilitiesTestingKnobs,
 	)
 
+	tenantStorageQuota := tenantstoragequota.NewTracker(st, tenantCapabilitiesWatcher, g)
+	nodeRegistry.AddMetricStruct(tenantStorageQuota.Metrics())
+	// The local storage usage of tenants is computed from the MVCC stats of the
+	// replicas for which this node holds the lease, so that each range is
+	// counted by a single node.
+	tenantStorageQuota.BindUsageFetcher(func(ctx context.Context) (map[roachpb.TenantID]int64, error) {
+		logicalBytes := make(map[roachpb.TenantID]int64)
+		now := clock.NowAsClockTimestamp()
+		err := stores.VisitStores(func(s *kvserver.Store) error {
+			s.VisitReplicas(func(r *kvserver.Replica) (wantMore bool) {
+				if tenID, ok := r.TenantID(); ok && !tenID.IsSystem() && r.OwnsValidLease(ctx, now) {
+					stats := r.GetMVCCStats()
+					logicalBytes[tenID] += stats.Total()
+				}
+				return true
+			})
+			return nil
+		})
+		return logicalBytes, err
+	})
+
 	var spanConfig struct {
 		// kvAccessor powers the span configuration RPCs and the host tenant's
 		// reconciliation job.
@@ -1006,6 +1029,7 @@ func NewServer(cfg Config, stopper *stop.Stopper) (serverctl.ServerStartupInterf
 		SystemConfigProvider:         systemConfigWatcher,
 		SpanConfigSubscriber:         spanConfig.subscriber,
 		RangeLogWriter:               rangeLogWriter,
+		TenantStorageQuota:           tenantStorageQuota,
 		KVAdmissionController:        admissionControl.kvAdmissionController,
 		KVFlowAdmittedPiggybacker:    admittedPiggybacker,
 		KVFlowStreamTokenProvider:    streamTokenCounterProvider,
@@ -1258,8 +1282,10 @@ func NewServer(cfg Config, stopper *stop.Stopper) (serverctl.ServerStartupInterf
 			externalStorageFromURI:   externalStorageFromURI,
 			isMeta1Leaseholder:       node.stores.IsMeta1Leaseholder,
 			sqlSQLResponseAdmissionQ: gcoords.RegularCPU.GetSQLWorkQueue(admission.SQLSQLResponseWork),
+			resourceGroupApplier:     gcoords.RegularCPU,
 			spanConfigKVAccessor:     spanConfig.kvAccessorForTenantRecords,
 			kvStoresIterator:         kvserver.MakeStoresIterator(node.stores),
+			tenantStorageQuota:       tenantStorageQuota,
 			inspectzServer:           inspectzServer,
 
 			notifyChangeToSystemVisibleSettings: tenantSettingsWatcher.SetAlternateDefaults,
@@ -1467,6 +1493,7 @@ func NewServer(cfg Config, stopper *stop.Stopper) (serverctl.ServerStartupInterf
 		spanConfigSubscriber:      spanConfig.subscriber,
 		spanConfigReporter:        spanConfig.reporter,
 		tenantCapabilitiesWatcher: tenantCapabilitiesWatcher,
+		tenantStorageQuota:        tenantStorageQuota,
 		pgPreServer:               pgPreServer,
 		sqlServer:                 sqlServer,
 		serverController:          sc,
@@ -2384,6 +2411,9 @@ func (s *topLevelServer) PreStart(ctx context.Context) error {
 	// the Reader to the TenantRPCAuthorizer, so that it has a handle into the
 	// global tenant capabilities state.
 	s.rpcContext.TenantRPCAuthorizer.BindReader(s.tenantCapabilitiesWatcher)
+	if err := s.tenantStorageQuota.Start(workersCtx, s.stopper); err != nil {
+		return errors.Wrap(err, "starting tenant storage quota tracker")
+	}
 
 	if err := s.kvProber.Start(workersCtx, s.stopper); err != nil {
 		return errors.Wrapf(err, "failed to start KV prober")
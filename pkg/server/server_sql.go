@@ -41,6 +41,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/liveness/livenesspb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tenantstoragequota"
 	"github.com/cockroachdb/cockroach/pkg/multitenant"
 	"github.com/cockroachdb/cockroach/pkg/multitenant/mtinfopb"
 	"github.com/cockroachdb/cockroach/pkg/multitenant/tenantcapabilities"
@@ -79,6 +80,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/hydrateddesccache"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/lease"
 	"github.com/cockroachdb/cockroach/pkg/sql/colexec"
+	"github.com/cockroachdb/cockroach/pkg/sql/columnar"
 	"github.com/cockroachdb/cockroach/pkg/sql/consistencychecker"
 	"github.com/cockroachdb/cockroach/pkg/sql/contention"
 	"github.com/cockroachdb/cockroach/pkg/sql/distsql"
@@ -94,6 +96,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
 	"github.com/cockroachdb/cockroach/pkg/sql/rangeprober"
 	"github.com/cockroachdb/cockroach/pkg/sql/regions"
+	"github.com/cockroachdb/cockroach/pkg/sql/resourcegroups"
+	"github.com/cockroachdb/cockroach/pkg/sql/resultcache"
 	"github.com/cockroachdb/cockroach/pkg/sql/rolemembershipcache"
 	"github.com/cockroachdb/cockroach/pkg/sql/scheduledlogging"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scdeps"
@@ -176,6 +180,7 @@ type SQLServer struct {
 	statsRefresher                 *stats.Refresher
 	clusterMetricsWriter           *cmwriter.Writer
 	temporaryObjectCleaner         *sql.TemporaryObjectCleaner
+	columnarCompactor              *columnar.Compactor
 	stmtDiagnosticsRegistry        *stmtdiagnostics.Registry
 	txnDiagnosticsRegistry         *stmtdiagnostics.TxnRegistry
 	sqlLivenessSessionID           sqlliveness.SessionID
@@ -253,12 +258,20 @@ type sqlServerOptionalKVArgs struct {
 	// The admission queue to use for SQLSQLResponseWork.
 	sqlSQLResponseAdmissionQ *admission.WorkQueue
 
+	// Installs the configuration of the resource groups into the CPU admission
+	// queue of the KV node, and reports their usage.
+	resourceGroupApplier resourcegroups.Applier
+
 	// Used when creating and deleting tenant records.
 	spanConfigKVAccessor spanconfig.KVAccessor
 	// kvStores is used by crdb_internal builtins to access the stores on this
 	// node.
 	kvStoresIterator kvserverbase.StoresIterator
 
+	// Used by SHOW VIRTUAL CLUSTER ... WITH STORAGE STATUS to report the
+	// storage usage that tenant storage quotas are enforced against.
+	tenantStorageQuota *tenantstoragequota.Tracker
+
 	// inspectzServer is used to power various crdb_internal vtables, exposing
 	// the equivalent of /inspectz but through SQL.
 	inspectzServer inspectzpb.InspectzServer
@@ -810,6 +823,8 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 	vecIndexManager := vecindex.NewManager(ctx, cfg.stopper, &cfg.Settings.SV, codec, cfg.internalDB)
 	cfg.registry.AddMetricStruct(vecIndexManager.Metrics())
 
+	columnarCompactor := columnar.NewCompactor(cfg.Settings, codec, cfg.internalDB)
+
 	// Set up the DistSQL server.
 	distSQLCfg := execinfra.ServerConfig{
 		AmbientContext:   cfg.AmbientCtx,
@@ -860,6 +875,7 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 		JobRegistry:               jobRegistry,
 		Gossip:                    cfg.gossip,
 		SQLInstanceDialer:         cfg.sqlInstanceDialer,
+		RuntimeFilters:            execinfra.NewRuntimeFilterRegistry(),
 		LeaseManager:              leaseMgr,
 
 		ExternalStorage:        cfg.externalStorage,
@@ -877,6 +893,7 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 		ExecutorConfig:           execCfg,
 		RootSQLMemoryPoolSize:    cfg.MemoryPoolSize,
 		VecIndexManager:          vecIndexManager,
+		ColumnarIndexCompactor:   columnarCompactor,
 	}
 	cfg.TempStorageConfig.Mon.SetMetrics(distSQLMetrics.CurDiskBytesCount, distSQLMetrics.MaxDiskBytesHist)
 	if codec.ForSystemTenant() {
@@ -1024,6 +1041,7 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 		TraceCollector:              traceCollector,
 		TenantUsageServer:           cfg.tenantUsageServer,
 		KVStoresIterator:            cfg.kvStoresIterator,
+		TenantStorageQuota:          cfg.tenantStorageQuota,
 		InspectzServer:              cfg.inspectzServer,
 		RangeDescIteratorFactory:    cfg.rangeDescIteratorFactory,
 		SyntheticPrivilegeCache: syntheticprivilegecache.New(
@@ -1062,6 +1080,12 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 		StatementHintsCache: hints.NewStatementHintsCache(
 			cfg.clock, cfg.rangeFeedFactory, cfg.stopper, codec, cfg.internalDB, cfg.Settings,
 		),
+		ResourceGroupsCache: resourcegroups.NewCache(
+			cfg.clock, cfg.rangeFeedFactory, cfg.stopper, codec, cfg.internalDB, cfg.resourceGroupApplier,
+		),
+		ResultCache: resultcache.NewCache(
+			ctx, cfg.AmbientCtx, cfg.Settings, cfg.rangeFeedFactory, cfg.stopper, codec, serverCacheMemoryMonitor,
+		),
 		VecIndexManager:            vecIndexManager,
 		RowMetrics:                 &rowMetrics,
 		InternalRowMetrics:         &internalRowMetrics,
@@ -1474,6 +1498,7 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 		statsRefresher:                 statsRefresher,
 		clusterMetricsWriter:           clusterMetricsWriter,
 		temporaryObjectCleaner:         temporaryObjectCleaner,
+		columnarCompactor:              columnarCompactor,
 		stmtDiagnosticsRegistry:        stmtDiagnosticsRegistry,
 		txnDiagnosticsRegistry:         txnDiagnosticsRegistry,
 		sqlLivenessProvider:            cfg.sqlLivenessProvider,
@@ -1688,6 +1713,7 @@ func (s *SQLServer) preStart(
 
 	s.execCfg.GCJobNotifier.Start(ctx)
 	s.temporaryObjectCleaner.Start(ctx, stopper)
+	s.columnarCompactor.Start(ctx, stopper)
 	s.startOrphanedBulkFileCleanup(ctx, stopper)
 	s.distSQLServer.Start()
 	s.pgServer.Start(ctx, stopper)
@@ -1818,6 +1844,9 @@ func (s *SQLServer) preStart(
 	if err = s.execCfg.StatementHintsCache.Start(ctx, s.execCfg.SystemTableIDResolver); err != nil {
 		return err
 	}
+	if err = s.execCfg.ResourceGroupsCache.Start(ctx, s.execCfg.SystemTableIDResolver); err != nil {
+		return err
+	}
 
 	scheduledlogging.Start(
 		ctx, stopper, s.execCfg.InternalDB, s.execCfg.Settings,
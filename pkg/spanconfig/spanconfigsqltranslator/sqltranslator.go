@@ -367,6 +367,10 @@ func (s *SQLTranslator) generateSpanConfigurationsForTable(
 	// backups.
 	tableSpanConfig.ExcludeDataFromBackup = table.GetExcludeDataFromBackup()
 
+	// Set whether the table's row data has been made read-only. Dropped tables
+	// are never read-only, so that their data can be cleared.
+	tableSpanConfig.ReadOnly = table.GetReadOnly() && !table.Dropped()
+
 	records := make([]spanconfig.Record, 0)
 	if table.GetID() == keys.DescriptorTableID {
 		// We have named ranges preceding `system.descriptor`.
@@ -463,6 +467,7 @@ func (s *SQLTranslator) generateSpanConfigurationsForTable(
 		// SubzoneSpanConfig.
 		subzoneSpanConfig.GCPolicy.ProtectionPolicies = tableSpanConfig.GCPolicy.ProtectionPolicies[:]
 		subzoneSpanConfig.ExcludeDataFromBackup = tableSpanConfig.ExcludeDataFromBackup
+		subzoneSpanConfig.ReadOnly = tableSpanConfig.ReadOnly
 		if isSystemDesc { // same as above
 			subzoneSpanConfig.RangefeedEnabled = true
 			subzoneSpanConfig.GCPolicy.IgnoreStrictEnforcement = true
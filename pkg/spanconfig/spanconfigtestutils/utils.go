@@ -664,6 +664,9 @@ func PrintSpanConfigDiffedAgainstDefaults(conf roachpb.SpanConfig) string {
 	if conf.ExcludeDataFromBackup != defaultConf.ExcludeDataFromBackup {
 		diffs = append(diffs, fmt.Sprintf("exclude_data_from_backup=%v", conf.ExcludeDataFromBackup))
 	}
+	if conf.ReadOnly != defaultConf.ReadOnly {
+		diffs = append(diffs, fmt.Sprintf("read_only=%v", conf.ReadOnly))
+	}
 
 	return strings.Join(diffs, " ")
 }
@@ -56,6 +56,13 @@ func (p *planner) AlterPrimaryKey(
 	alterPKNode tree.AlterTableAlterPrimaryKey,
 	alterPrimaryKeyLocalitySwap *alterPrimaryKeyLocalitySwap,
 ) error {
+	if catalog.FindNonDropIndex(tableDesc, func(idx catalog.Index) bool {
+		return idx.GetType() == idxtype.COLUMNAR
+	}) != nil {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"cannot alter the primary key of a table with a columnar index")
+	}
+
 	// Check if sql_safe_updates is enabled and the table has vector indexes
 	if len(tableDesc.VectorIndexes()) > 0 {
 		if p.EvalContext().SessionData().SafeUpdates {
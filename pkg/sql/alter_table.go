@@ -1669,9 +1669,8 @@ func convertColumnNamesToIDs(
 	return columnIDs, nil
 }
 
-// statsTypeCheck checks that the type matches.
-// TODO(49698): When we support multi-column histograms this check will need
-// adjustment.
+// statsTypeCheck checks that the types of the histogram or of the most common
+// values of a multi-column statistic match the column types.
 func statsTypeCheck(
 	desc catalog.TableDescriptor, s *stats.JSONStatistic, h *stats.HistogramData,
 ) error {
@@ -1685,6 +1684,21 @@ func statsTypeCheck(
 				return pgerror.WithCandidateCode(err, pgcode.DatatypeMismatch)
 			}
 		}
+		return nil
+	}
+	colTypes := make([]*types.T, len(s.Columns))
+	for i, colName := range s.Columns {
+		col := catalog.FindColumnByName(desc, colName)
+		if col == nil {
+			// Ignore dropped columns (they are handled below).
+			return nil
+		}
+		colTypes[i] = col.GetType()
+	}
+	if err := h.TypeCheckMultiColumn(
+		colTypes, desc.GetName(), s.Columns, stats.TSFromString(s.CreatedAt),
+	); err != nil {
+		return pgerror.WithCandidateCode(err, pgcode.DatatypeMismatch)
 	}
 	return nil
 }
@@ -0,0 +1,152 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type alterTableSetReadOnlyNode struct {
+	zeroInputPlanNode
+	desc   *tabledesc.Mutable
+	n      *tree.AlterTableSetReadOnly
+	prefix catalog.ResolvedObjectPrefix
+}
+
+// AlterTableSetReadOnly makes a table read-only, or read-write again. The
+// read-only state is recorded in the table descriptor, from which it is
+// propagated to the table's span configs and enforced by the replicas of the
+// table's ranges, so that no client can write to the table.
+// Privileges: CREATE on table.
+func (p *planner) AlterTableSetReadOnly(
+	ctx context.Context, n *tree.AlterTableSetReadOnly,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER TABLE SET READ ONLY",
+	); err != nil {
+		return nil, err
+	}
+	// Nodes running an older binary ignore the read_only field of the table
+	// descriptor, and would keep accepting writes to the table.
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V26_3) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"ALTER TABLE SET READ ONLY is not supported until the cluster version is finalized")
+	}
+
+	tn := n.Name.ToTableName()
+	prefix, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &tn, !n.IfExists, tree.ResolveRequireTableDesc)
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc == nil {
+		// Noop.
+		return newZeroNode(nil /* columns */), nil
+	}
+	if tableDesc.IsVirtualTable() || tableDesc.GetExternal() != nil {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"%q is not a physical table", tableDesc.GetName())
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	if n.ReadOnly && len(tableDesc.AllMutations()) > 0 {
+		return nil, pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"cannot set table %q read-only while a schema change is in progress",
+			tableDesc.GetName())
+	}
+	return &alterTableSetReadOnlyNode{
+		desc:   tableDesc,
+		n:      n,
+		prefix: prefix,
+	}, nil
+}
+
+func (n *alterTableSetReadOnlyNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeAlterCounterWithExtra("table", "set_read_only"))
+	p := params.p
+	if n.desc.ReadOnly == n.n.ReadOnly {
+		return nil
+	}
+	n.desc.ReadOnly = n.n.ReadOnly
+	if err := p.writeSchemaChange(
+		params.ctx, n.desc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+	tn := tree.MakeTableNameWithSchema(
+		tree.Name(n.prefix.Database.GetName()),
+		tree.Name(n.prefix.Schema.GetName()),
+		tree.Name(n.desc.GetName()),
+	)
+	return p.logEvent(params.ctx, n.desc.ID, &eventpb.AlterTable{
+		TableName: tn.FQString(),
+	})
+}
+
+func (n *alterTableSetReadOnlyNode) Next(runParams) (bool, error) { return false, nil }
+func (n *alterTableSetReadOnlyNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *alterTableSetReadOnlyNode) Close(context.Context)        {}
+
+// checkNoReadOnlyTablesModified returns an error if the schema change
+// statement would modify a table which has been set READ ONLY, either by
+// changing its schema or by dropping or rewriting its data. The table must be
+// set READ WRITE before it can be changed.
+func (p *planner) checkNoReadOnlyTablesModified(ctx context.Context, stmt tree.Statement) error {
+	var names []*tree.UnresolvedObjectName
+	switch n := stmt.(type) {
+	case *tree.AlterTable:
+		names = append(names, n.Table)
+	case *tree.DropTable:
+		for i := range n.Names {
+			names = append(names, n.Names[i].ToUnresolvedObjectName())
+		}
+	case *tree.Truncate:
+		for i := range n.Tables {
+			names = append(names, n.Tables[i].ToUnresolvedObjectName())
+		}
+	case *tree.CreateIndex:
+		names = append(names, n.Table.ToUnresolvedObjectName())
+	case *tree.DropIndex:
+		for _, idx := range n.IndexList {
+			if idx.Table.ObjectName != "" {
+				names = append(names, idx.Table.ToUnresolvedObjectName())
+			}
+		}
+	default:
+		return nil
+	}
+	for _, name := range names {
+		desc, err := p.ResolveExistingObjectEx(ctx, name, false /* required */, tree.ResolveAnyTableKind)
+		if err != nil || desc == nil {
+			// Resolution errors are reported by the statement itself.
+			continue //nolint:returnerrcheck
+		}
+		if desc.GetReadOnly() {
+			return errors.WithHint(
+				pgerror.Newf(pgcode.ReadOnlySQLTransaction,
+					"cannot %s read-only table %q", errors.Safe(stmt.StatementTag()), desc.GetName()),
+				"use ALTER TABLE ... SET READ WRITE to allow changes to the table",
+			)
+		}
+	}
+	return nil
+}
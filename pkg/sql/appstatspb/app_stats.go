@@ -133,6 +133,7 @@ func (t *TransactionStatistics) Add(other *TransactionStatistics) {
 	t.KVCPUTimeNanos.Add(other.KVCPUTimeNanos, transactionStatsCount, other.Count)
 
 	t.ExecStats.Add(other.ExecStats)
+	t.WriteBufferStats.Add(other.WriteBufferStats)
 
 	t.Count += other.Count
 }
@@ -221,9 +222,13 @@ func (s *StatementStatistics) Add(other *StatementStatistics) {
 	s.FailureCount += other.FailureCount
 	s.GenericCount += other.GenericCount
 	s.StmtHintsCount += other.StmtHintsCount
+	s.ReoptimizedCount += other.ReoptimizedCount
+	s.ResultCacheHitCount += other.ResultCacheHitCount
+	s.ResultCacheMissCount += other.ResultCacheMissCount
 
 	s.CanaryStats.Add(other.CanaryStats)
 	s.StableStats.Add(other.StableStats)
+	s.WriteBufferStats.Add(other.WriteBufferStats)
 }
 
 // AlmostEqual compares two StatementStatistics and their contained NumericStats
@@ -244,7 +249,8 @@ func (s *StatementStatistics) AlmostEqual(other *StatementStatistics, eps float6
 		s.RowsRead.AlmostEqual(other.RowsRead, eps) &&
 		s.RowsWritten.AlmostEqual(other.RowsWritten, eps) &&
 		s.CanaryStats.AlmostEqual(other.CanaryStats, eps) &&
-		s.StableStats.AlmostEqual(other.StableStats, eps)
+		s.StableStats.AlmostEqual(other.StableStats, eps) &&
+		s.WriteBufferStats.AlmostEqual(other.WriteBufferStats, eps)
 	// s.ExecStats are deliberately ignored since they are subject to sampling
 	// probability and are not fully deterministic (e.g. the number of network
 	// messages depends on the range cache state).
@@ -268,6 +274,34 @@ func (e *ExperimentStatsInfo) AlmostEqual(other ExperimentStatsInfo, eps float64
 		e.PlanLat.AlmostEqual(other.PlanLat, eps)
 }
 
+// Add combines other into this WriteBufferStatistics.
+func (w *WriteBufferStatistics) Add(other WriteBufferStatistics) {
+	statsCount := w.Count
+	if statsCount == 0 && other.Count == 0 {
+		statsCount = 1
+	}
+	w.BufferedWrites.Add(other.BufferedWrites, statsCount, other.Count)
+	w.FlushedWrites.Add(other.FlushedWrites, statsCount, other.Count)
+	w.ReadHits.Add(other.ReadHits, statsCount, other.Count)
+	w.BufferedBytes.Add(other.BufferedBytes, statsCount, other.Count)
+	w.MemoryLimitFlushCount += other.MemoryLimitFlushCount
+	w.UnsupportedRequestFlushCount += other.UnsupportedRequestFlushCount
+	w.DisabledFlushCount += other.DisabledFlushCount
+	w.Count += other.Count
+}
+
+// AlmostEqual compares two WriteBufferStatistics within a window of size eps.
+func (w *WriteBufferStatistics) AlmostEqual(other WriteBufferStatistics, eps float64) bool {
+	return w.Count == other.Count &&
+		w.BufferedWrites.AlmostEqual(other.BufferedWrites, eps) &&
+		w.FlushedWrites.AlmostEqual(other.FlushedWrites, eps) &&
+		w.ReadHits.AlmostEqual(other.ReadHits, eps) &&
+		w.BufferedBytes.AlmostEqual(other.BufferedBytes, eps) &&
+		w.MemoryLimitFlushCount == other.MemoryLimitFlushCount &&
+		w.UnsupportedRequestFlushCount == other.UnsupportedRequestFlushCount &&
+		w.DisabledFlushCount == other.DisabledFlushCount
+}
+
 // Add combines other into this ExecStats.
 func (s *ExecStats) Add(other ExecStats) {
 	// Execution stats collected using a sampling approach.
@@ -123,6 +123,9 @@ func indexForDisplay(
 		f.WriteString(" ON ")
 		f.FormatNode(tableName)
 	}
+	if index.Type == idxtype.COLUMNAR && !f.HasFlags(tree.FmtPGCatalog) {
+		f.WriteString(" USING COLUMNAR")
+	}
 
 	if f.HasFlags(tree.FmtPGCatalog) {
 		f.WriteString(" USING")
@@ -131,6 +134,8 @@ func indexForDisplay(
 			f.WriteString(" gin")
 		case idxtype.VECTOR:
 			f.WriteString(" cspann")
+		case idxtype.COLUMNAR:
+			f.WriteString(" columnar")
 		default:
 			f.WriteString(" btree")
 		}
@@ -146,7 +151,9 @@ func indexForDisplay(
 		f.WriteString(" USING HASH")
 	}
 
-	if !isPrimary && len(index.StoreColumnNames) > 0 {
+	// The stored columns of a columnar index are the columns listed in its
+	// definition, so they were already formatted above.
+	if !isPrimary && len(index.StoreColumnNames) > 0 && index.Type != idxtype.COLUMNAR {
 		// PostgreSQL spells covering-index columns as INCLUDE; emit that
 		// spelling under FmtPGCatalog so pg_get_indexdef output matches
 		// what real PG would return. CockroachDB's parser accepts both.
@@ -235,6 +242,18 @@ func FormatIndexElements(
 		}
 	}
 
+	// A columnar index is keyed by the primary key, which is implied; its
+	// definition only lists the columns it stores.
+	if index.Type == idxtype.COLUMNAR {
+		for i := range index.StoreColumnNames {
+			if i > 0 {
+				f.WriteString(", ")
+			}
+			f.FormatNameP(&index.StoreColumnNames[i])
+		}
+		return nil
+	}
+
 	startIdx := index.ExplicitColumnStartIdx()
 	for i, n := startIdx, len(index.KeyColumnIDs); i < n; i++ {
 		col, err := catalog.MustFindColumnByID(table, index.KeyColumnIDs[i])
@@ -192,6 +192,22 @@ var ShowFingerprintsColumns = ResultColumns{
 	{Name: "fingerprint", Typ: types.String},
 }
 
+// ShowPlanBaselinesColumns are the result columns of a
+// SHOW PLAN BASELINES statement.
+var ShowPlanBaselinesColumns = ResultColumns{
+	{Name: "row_id", Typ: types.Int},
+	{Name: "fingerprint", Typ: types.String},
+	{Name: "database", Typ: types.String},
+	{Name: "state", Typ: types.String},
+	{Name: "plan_gist", Typ: types.String},
+	{Name: "executions", Typ: types.Int},
+	{Name: "mean_latency", Typ: types.Float},
+	{Name: "regressed_plan_gist", Typ: types.String},
+	{Name: "regressed_executions", Typ: types.Int},
+	{Name: "regressed_mean_latency", Typ: types.Float},
+	{Name: "pin_hint_id", Typ: types.Int},
+}
+
 // ShowStatementHintsColumns are the result columns of a
 // SHOW STATEMENT HINTS statement.
 var ShowStatementHintsColumns = ResultColumns{
@@ -354,6 +370,13 @@ var TenantColumnsWithCapabilities = ResultColumns{
 	{Name: "capability_value", Typ: types.String},
 }
 
+// TenantColumnsWithStorageStatus is appended to the SHOW VIRTUAL CLUSTER
+// columns for SHOW VIRTUAL CLUSTER ... WITH STORAGE STATUS queries.
+var TenantColumnsWithStorageStatus = ResultColumns{
+	{Name: "logical_bytes", Typ: types.Int},
+	{Name: "storage_quota_bytes", Typ: types.Int},
+}
+
 // RangesNoLeases is the schema for crdb_internal.ranges_no_leases.
 var RangesNoLeases = ResultColumns{
 	{Name: "range_id", Typ: types.Int},
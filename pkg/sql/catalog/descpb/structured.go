@@ -266,7 +266,7 @@ func (desc *TableDescriptor) IsSecurityInvoker() bool {
 
 // IsReadOnly implements the TableDescriptor interface.
 func (desc *TableDescriptor) IsReadOnly() bool {
-	return desc.IsMaterializedView || desc.GetExternal() != nil
+	return desc.IsMaterializedView || desc.GetExternal() != nil || desc.ReadOnly
 }
 
 // IsPhysicalTable implements the TableDescriptor interface.
@@ -375,8 +375,8 @@ type TableDescriptor interface {
 	// IsSecurityInvoker returns true if security_invoker option is set to true.
 	// The function should only be called on a view descriptor.
 	IsSecurityInvoker() bool
-	// IsReadOnly returns if this table descriptor has external data, and cannot
-	// be written to.
+	// IsReadOnly returns if this table descriptor has external data, is a
+	// materialized view or has been set READ ONLY, and cannot be written to.
 	IsReadOnly() bool
 	// IsAs returns true if the TableDescriptor describes a Table that was created
 	// with a CREATE TABLE AS command.
@@ -819,6 +819,12 @@ type TableDescriptor interface {
 	// GetExcludeDataFromBackup returns true if the table's row data is configured
 	// to be excluded during backup.
 	GetExcludeDataFromBackup() bool
+	// GetReadOnly returns true if the table has been set READ ONLY, in which
+	// case its row data cannot be modified until it is set READ WRITE again.
+	GetReadOnly() bool
+	// GetIncrementalRefresh returns how a materialized view is incrementally
+	// maintained, or nil if it is refreshed with REFRESH MATERIALIZED VIEW.
+	GetIncrementalRefresh() *descpb.TableDescriptor_IncrementalRefresh
 	// GetStorageParams returns a list of storage parameters for the table.
 	GetStorageParams(spaceBetweenEqual bool) ([]string, error)
 	// GetViewOptions returns a list of options for the view.
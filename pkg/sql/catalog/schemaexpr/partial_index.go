@@ -179,18 +179,41 @@ func makePartialIndexHelper(
 	}
 }
 
+// MakeRowFilterExpr turns a filter on the rows of a table from a string to a
+// boolean TypedExpr whose column references are IndexedVars over cols, so that
+// it can be evaluated over the rows of the table in the same way as a partial
+// index predicate. It also returns the columns referenced by the filter.
+func MakeRowFilterExpr(
+	ctx context.Context,
+	filter string,
+	cols []catalog.Column,
+	tableDesc catalog.TableDescriptor,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+) (tree.TypedExpr, catalog.TableColSet, error) {
+	h := makePartialIndexHelper(tableDesc, cols, evalCtx, semaCtx)
+	return h.makeBoolExpr(ctx, filter)
+}
+
 // makePartialIndexExpr turns an index's partial index predicate from a string to
 // a TypedExpr.
 func (pi partialIndexHelper) makePartialIndexExpr(
 	ctx context.Context, idx catalog.Index,
 ) (tree.TypedExpr, catalog.TableColSet, error) {
-	expr, err := parserutils.ParseExpr(string(idx.GetPredicate()))
+	return pi.makeBoolExpr(ctx, string(idx.GetPredicate()))
+}
+
+// makeBoolExpr turns a boolean expression over the columns of the table from a
+// string to a TypedExpr.
+func (pi partialIndexHelper) makeBoolExpr(
+	ctx context.Context, exprStr string,
+) (tree.TypedExpr, catalog.TableColSet, error) {
+	expr, err := parserutils.ParseExpr(exprStr)
 	if err != nil {
 		return nil, catalog.TableColSet{}, err
 	}
 
-	// Collect all column IDs that are referenced in the partial index
-	// predicate expression.
+	// Collect all column IDs that are referenced in the expression.
 	colIDs, err := ExtractColumnIDs(pi.tableDesc, expr)
 	if err != nil {
 		return nil, catalog.TableColSet{}, err
@@ -1387,6 +1387,31 @@ CREATE TABLE system.advisory_locks (
     FAMILY "primary" (database_id, lock_type, lock_key)
 );`
 
+	// ResourceGroupsTableSchema defines the schema for the
+	// system.resource_groups table, which stores the admission control resource
+	// groups defined with CREATE RESOURCE GROUP.
+	//
+	// * name: the name of the resource group; primary key.
+	// * id: the admission control ID of the resource group.
+	// * cpu_weight, cpu_limit: the CPU share and cap of the resource group.
+	// * io_weight, io_limit: the IO share and cap of the resource group.
+	// * roles, application_names, databases: the mapping rules that route the
+	//   work of sessions to the resource group.
+	ResourceGroupsTableSchema = `
+CREATE TABLE system.resource_groups (
+    name              STRING NOT NULL,
+    id                INT8 NOT NULL,
+    cpu_weight        INT8 NOT NULL,
+    cpu_limit         FLOAT8 NOT NULL,
+    io_weight         INT8 NOT NULL,
+    io_limit          FLOAT8 NOT NULL,
+    roles             STRING[] NOT NULL,
+    application_names STRING[] NOT NULL,
+    databases         STRING[] NOT NULL,
+    CONSTRAINT "primary" PRIMARY KEY (name ASC),
+    FAMILY "primary" (name, id, cpu_weight, cpu_limit, io_weight, io_limit, roles, application_names, databases)
+);`
+
 	// StatementsTableSchema defines the schema for the system.statements table
 	// which stores information about executed statements.
 	//
@@ -1454,7 +1479,7 @@ const SystemDatabaseName = catconstants.SystemDatabaseName
 // release version).
 //
 // NB: Don't set this to clusterversion.Latest; use a specific version instead.
-var SystemDatabaseSchemaBootstrapVersion = clusterversion.V26_3_AlterStatementsTablePK.Version()
+var SystemDatabaseSchemaBootstrapVersion = clusterversion.V26_3_AddResourceGroupsTable.Version()
 
 // MakeSystemDatabaseDesc constructs a copy of the system database
 // descriptor.
@@ -1658,6 +1683,7 @@ func MakeSystemTables() []SystemTable {
 		TableStatisticsLocksTable,
 		AdvisoryLocksTable,
 		StatementsTable,
+		ResourceGroupsTable,
 	}
 }
 
@@ -5554,6 +5580,34 @@ var (
 			},
 		),
 	)
+
+	ResourceGroupsTable = makeSystemTable(
+		ResourceGroupsTableSchema,
+		systemTable(
+			catconstants.ResourceGroupsTableName,
+			descpb.InvalidID, // dynamically assigned
+			[]descpb.ColumnDescriptor{
+				{Name: "name", ID: 1, Type: types.String},
+				{Name: "id", ID: 2, Type: types.Int},
+				{Name: "cpu_weight", ID: 3, Type: types.Int},
+				{Name: "cpu_limit", ID: 4, Type: types.Float},
+				{Name: "io_weight", ID: 5, Type: types.Int},
+				{Name: "io_limit", ID: 6, Type: types.Float},
+				{Name: "roles", ID: 7, Type: types.StringArray},
+				{Name: "application_names", ID: 8, Type: types.StringArray},
+				{Name: "databases", ID: 9, Type: types.StringArray},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"name", "id", "cpu_weight", "cpu_limit", "io_weight", "io_limit", "roles", "application_names", "databases"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5, 6, 7, 8, 9},
+				},
+			},
+			pk("name"),
+		),
+	)
 )
 
 // SpanConfigurationsTableName represents system.span_configurations.
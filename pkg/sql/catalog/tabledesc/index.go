@@ -473,8 +473,11 @@ func (w index) UseDeletePreservingEncoding() bool {
 // key at the same time; we have to materialize the new columns and make them
 // available as the public primary index on the table before proceeding to
 // populate the new primary index with the new key structure.
+//
+// Columnar indexes always use Put, since a row's delta entry may overwrite a
+// tombstone left by an earlier delete of the same primary key.
 func (w index) ForcePut() bool {
-	return w.mutationForcePutForIndexWrites
+	return w.mutationForcePutForIndexWrites || w.desc.Type == idxtype.COLUMNAR
 }
 
 func (w index) CreatedAt() time.Time {
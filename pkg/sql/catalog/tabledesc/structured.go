@@ -2572,6 +2572,16 @@ func (desc *wrapper) GetExcludeDataFromBackup() bool {
 	return desc.ExcludeDataFromBackup
 }
 
+// GetReadOnly implements the TableDescriptor interface.
+func (desc *wrapper) GetReadOnly() bool {
+	return desc.ReadOnly
+}
+
+// GetIncrementalRefresh implements the TableDescriptor interface.
+func (desc *wrapper) GetIncrementalRefresh() *descpb.TableDescriptor_IncrementalRefresh {
+	return desc.IncrementalRefresh
+}
+
 // GetStorageParams implements the TableDescriptor interface.
 func (desc *wrapper) GetStorageParams(spaceBetweenEqual bool) ([]string, error) {
 	var storageParams []string
@@ -2706,6 +2716,15 @@ func (desc *wrapper) GetViewOptions(spaceBetweenEqual bool) ([]string, error) {
 			appendViewOptions(`security_invoker`, `false`)
 		}
 	}
+	if ir := desc.IncrementalRefresh; ir != nil {
+		switch ir.Mode {
+		case descpb.TableDescriptor_IncrementalRefresh_SYNC:
+			appendViewOptions(`incremental`, `'sync'`)
+		case descpb.TableDescriptor_IncrementalRefresh_ASYNC:
+			appendViewOptions(`incremental`, `'async'`)
+			appendViewOptions(`max_staleness`, fmt.Sprintf(`'%s'`, ir.MaxStaleness))
+		}
+	}
 	return viewOptions, nil
 }
 
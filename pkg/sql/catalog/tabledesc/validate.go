@@ -847,6 +847,17 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			"CREATE TABLE ... AS but does not have a CreateAsOfTime set"))
 	}
 
+	// Only tables can be set READ ONLY.
+	if desc.ReadOnly && !desc.IsTable() {
+		vea.Report(errors.AssertionFailedf("non-table %q is set READ ONLY", desc.GetName()))
+	}
+
+	// Only materialized views can be maintained incrementally.
+	if desc.IncrementalRefresh != nil && !desc.MaterializedView() {
+		vea.Report(errors.AssertionFailedf(
+			"%q is not a materialized view but has an incremental refresh", desc.GetName()))
+	}
+
 	// VirtualTables have their privileges stored in system.privileges which
 	// is validated outside of the descriptor.
 	if !desc.IsVirtualTable() {
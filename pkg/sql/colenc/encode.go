@@ -453,6 +453,10 @@ func (b *BatchEncoder) encodeSecondaryIndex(ctx context.Context, ind catalog.Ind
 		// by vector indexes needing output from the mutation search operator, which will
 		// need to be plumbed through to the encoder.
 		return errors.AssertionFailedf("vector indexes not supported")
+	} else if ind.GetType() == idxtype.COLUMNAR {
+		// The vectorized encoder is never used for tables with columnar
+		// indexes since their entries are always written with ForcePut.
+		return errors.AssertionFailedf("columnar indexes not supported")
 	} else {
 		keyAndSuffixCols := b.rh.TableDesc.IndexFetchSpecKeyAndSuffixColumns(ind)
 		keyCols := keyAndSuffixCols[:ind.NumKeyColumns()]
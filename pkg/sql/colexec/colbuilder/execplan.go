@@ -362,6 +362,10 @@ func canWrap(mode sessiondatapb.VectorizeExecMode, core *execinfrapb.ProcessorCo
 		return errCoreNotWorthWrapping
 	case core.TxnLdrDepResolver != nil:
 		return errCoreNotWorthWrapping
+	case core.BackupTableReader != nil:
+		return errCoreNotWorthWrapping
+	case core.CopyBackup != nil:
+		return errCoreNotWorthWrapping
 	default:
 		err := errors.AssertionFailedf("unexpected processor core %q", core)
 		if buildutil.CrdbTestBuild {
@@ -899,7 +903,16 @@ func NewColOperator(
 			estimatedRowCount := spec.EstimatedRowCount
 			var scanOp colfetcher.ScanOperator
 			var resultTypes []*types.T
-			if flowCtx.EvalCtx.SessionData().DirectColumnarScansEnabled {
+			if core.TableReader.FetchSpec.IsColumnarIndex {
+				scanOp, resultTypes, err = colfetcher.NewColumnarScan(
+					ctx, colmem.NewAllocator(ctx, accounts[0], factory), accounts[1],
+					flowCtx, spec.ProcessorID, spec.StageID, core.TableReader, post,
+					args.TypeResolver,
+				)
+				if err != nil {
+					return r, err
+				}
+			} else if flowCtx.EvalCtx.SessionData().DirectColumnarScansEnabled {
 				canUseDirectScan := func() bool {
 					// txnWriteBuffer currently doesn't support
 					// COL_BATCH_RESPONSE scan format, so if buffered writes are
@@ -1289,6 +1302,16 @@ func NewColOperator(
 				args.CloserRegistry.AddCloser(result.Root.(colexecop.Closer))
 				result.MetadataSources = append(result.MetadataSources, result.Root.(colexecop.MetadataSource))
 			} else {
+				var filterBuilder *colexecjoin.RuntimeFilterBuilder
+				if rf := core.HashJoiner.RuntimeFilter; rf != nil {
+					// Build the part of the runtime filter from the right
+					// input before it is consumed by the hash joiner.
+					filterBuilder = newRuntimeFilterBuilder(
+						flowCtx, inputs[1].Root, spec.Input[1].ColumnTypes,
+						core.HashJoiner.RightEqColumns, rf,
+					)
+					inputs[1].Root = filterBuilder
+				}
 				opName := redact.SafeString("hash-joiner")
 				hjArgs, hashJoinerMemMonitorName := makeNewHashJoinerArgs(
 					ctx,
@@ -1309,6 +1332,13 @@ func NewColOperator(
 						inputs[0].Root, inputs[1].Root, inMemoryHashJoiner.(colexecop.BufferingInMemoryOperator),
 						[2]mon.Name{hashJoinerMemMonitorName, mon.EmptyName},
 						func(inputOne, inputTwo colexecop.Operator) colexecop.Operator {
+							if filterBuilder != nil {
+								// The external hash joiner reads both inputs at
+								// the same time, so the table readers of the
+								// left input can't wait for the complete
+								// filter.
+								filterBuilder.Abandon()
+							}
 							opName := redact.SafeString("external-hash-joiner")
 							accounts := args.MonitorRegistry.CreateUnlimitedMemAccounts(
 								ctx, flowCtx, opName, spec.ProcessorID, 2, /* numAccounts */
@@ -1907,6 +1937,10 @@ func NewColOperator(
 		r.Root, r.ColumnTypes = addProjection(r.Root, r.ColumnTypes, projection)
 	}
 
+	if tr := core.TableReader; tr != nil && len(tr.RuntimeFilters) > 0 {
+		r.Root = planRuntimeFilters(flowCtx, args, r.Root, tr.RuntimeFilters)
+	}
+
 	takeOverMetaInfo(&result.OpWithMetaInfo, inputs)
 	if buildutil.CrdbTestBuild {
 		// Plan an invariants checker if it isn't already the root of the
@@ -0,0 +1,112 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package colbuilder
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/rpc/rpcbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecargs"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecjoin"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// newRuntimeFilterBuilder returns an operator that passes through the build
+// input of a hash joiner while building the part of the runtime filter
+// described by spec, and that pushes the part to the SQL instances running the
+// table readers that consume the filter.
+func newRuntimeFilterBuilder(
+	flowCtx *execinfra.FlowCtx,
+	input colexecop.Operator,
+	inputTypes []*types.T,
+	keyCols []uint32,
+	spec *execinfrapb.RuntimeFilterProducerSpec,
+) *colexecjoin.RuntimeFilterBuilder {
+	return colexecjoin.NewRuntimeFilterBuilder(
+		input, inputTypes, keyCols, spec.BloomFilterBits,
+		func(ctx context.Context, filter *execinfrapb.RuntimeFilter) {
+			pushRuntimeFilter(ctx, flowCtx, spec, filter)
+		},
+	)
+}
+
+// pushRuntimeFilter delivers a part of a runtime filter to all of its target
+// SQL instances. The delivery to remote instances is asynchronous and best
+// effort: if it fails, the consumers stop waiting for the filter after a
+// timeout.
+func pushRuntimeFilter(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	spec *execinfrapb.RuntimeFilterProducerSpec,
+	filter *execinfrapb.RuntimeFilter,
+) {
+	localID := flowCtx.NodeID.SQLInstanceID()
+	for _, target := range spec.TargetSQLInstanceIDs {
+		if target == localID {
+			if registry := flowCtx.Cfg.RuntimeFilters; registry != nil {
+				registry.Push(flowCtx.ID, spec.FilterID, filter)
+			}
+			continue
+		}
+		req := &execinfrapb.PushRuntimeFilterRequest{
+			FlowID:   flowCtx.ID,
+			FilterID: spec.FilterID,
+			Filter:   *filter,
+		}
+		if err := flowCtx.Cfg.Stopper.RunAsyncTask(ctx, "push-runtime-filter", func(ctx context.Context) {
+			client, err := execinfrapb.DialDistSQLClient(
+				flowCtx.Cfg.SQLInstanceDialer, ctx, roachpb.NodeID(target), rpcbase.DefaultClass,
+			)
+			if err == nil {
+				_, err = client.PushRuntimeFilter(ctx, req)
+			}
+			if err != nil {
+				log.VEventf(ctx, 1, "failed to push runtime filter %d to n%d: %v", spec.FilterID, target, err)
+			}
+		}); err != nil {
+			log.VEventf(ctx, 1, "failed to push runtime filter %d to n%d: %v", spec.FilterID, target, err)
+		}
+	}
+}
+
+// planRuntimeFilters plans the operators that apply the given runtime filters
+// to the output of a table reader.
+func planRuntimeFilters(
+	flowCtx *execinfra.FlowCtx,
+	args *colexecargs.NewColOperatorArgs,
+	input colexecop.Operator,
+	specs []execinfrapb.RuntimeFilterConsumerSpec,
+) colexecop.Operator {
+	registry := flowCtx.Cfg.RuntimeFilters
+	if registry == nil {
+		return input
+	}
+	for i := range specs {
+		spec := &specs[i]
+		op := colexecjoin.NewRuntimeFilterOp(
+			input, spec.KeyColumns,
+			func(ctx context.Context) ([]*execinfrapb.RuntimeFilter, error) {
+				timeout := execinfra.RuntimeFilterWaitTimeout.Get(&flowCtx.Cfg.Settings.SV)
+				parts, err := registry.Wait(ctx, flowCtx.ID, spec.FilterID, int(spec.NumProducers), timeout)
+				if err == nil && parts == nil {
+					log.VEventf(ctx, 1, "timed out waiting for runtime filter %d", spec.FilterID)
+				}
+				return parts, err
+			},
+			func() {
+				registry.Release(flowCtx.ID, spec.FilterID, spec.NumLocalConsumers)
+			},
+		)
+		args.CloserRegistry.AddCloser(op)
+		input = op
+	}
+	return input
+}
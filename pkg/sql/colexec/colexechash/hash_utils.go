@@ -220,3 +220,47 @@ func (d *TupleHashDistributor) ResetNumOutputs(numOutputs int) {
 	d.selections = make([][]int, numOutputs)
 	copy(d.selections, oldSelections)
 }
+
+// TupleHasher is a helper struct that computes the hash values of tuples from
+// batches. The hash values of equal tuples are the same on all nodes, so they
+// can be compared across the flows of a distributed query.
+type TupleHasher struct {
+	// InitHashValue is the value used to initialize the hash values. Different
+	// values can be used to define different hash functions.
+	InitHashValue uint64
+	// hashes will contain the computed hash value of a group of columns with
+	// the same index in the current batch.
+	hashes []uint64
+	// cancelChecker is used during the hashing of the rows to check for query
+	// cancellation.
+	cancelChecker colexecutils.CancelChecker
+	datumAlloc    tree.DatumAlloc
+}
+
+// Init initializes the TupleHasher. Second, third, etc calls are noops.
+func (h *TupleHasher) Init(ctx context.Context) {
+	h.cancelChecker.Init(ctx)
+}
+
+// Hash returns the hash values of the tuples in b computed on hashCols. The
+// i-th hash value corresponds to the i-th tuple of the batch, taking the
+// selection vector into account. The returned slice is only valid until the
+// next call to Hash.
+// NOTE: b is assumed to be non-zero batch.
+// NOTE: the hasher *must* be initialized before the first use.
+func (h *TupleHasher) Hash(b coldata.Batch, hashCols []uint32) []uint64 {
+	n := b.Length()
+	if cap(h.hashes) < n {
+		h.hashes = make([]uint64, n)
+	} else {
+		h.hashes = h.hashes[:n]
+	}
+	initHash64(h.hashes, n, h.InitHashValue)
+	if n > h.datumAlloc.DefaultAllocSize {
+		h.datumAlloc.DefaultAllocSize = n
+	}
+	for _, i := range hashCols {
+		rehash(h.hashes, b.ColVec(int(i)), n, b.Selection(), h.cancelChecker, &h.datumAlloc)
+	}
+	return h.hashes
+}
@@ -0,0 +1,410 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package colexecjoin
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexechash"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// Runtime filters allow the table readers of the left (probe) input of a hash
+// join to skip the rows that cannot match any row of the right (build) input
+// before the rows are sent to the hash joiners over the network.
+//
+// Every hash joiner of a join stage builds a part of the filter from the rows
+// it receives on its build input: a bloom filter on the hash values of the
+// equality columns and the bounds of the integer equality columns. The table
+// readers wait for the parts of all hash joiners, combine them, and only output
+// the rows that pass the combined filter. Since a hash joiner always consumes
+// its build input before its probe input, the filter is complete by the time
+// the probe rows are needed.
+//
+// Runtime filters are only used for the join types that don't output unmatched
+// rows of the left input, and that don't consider NULLs equal.
+
+// runtimeFilterInitHashValue is the initial hash value of the hash function
+// used by runtime filters. It differs from the one used by the hash routers so
+// that the bloom filter bits don't correlate with the hash joiner that built
+// them.
+const runtimeFilterInitHashValue = 0x5bd1e995
+
+// runtimeFilterNumHashes is the number of bits set in the bloom filter for
+// each key.
+const runtimeFilterNumHashes = 3
+
+// runtimeFilterMaxKeysPerBit is the maximum ratio of the number of keys to the
+// number of bits of a bloom filter. Above it, the false positive rate of the
+// bloom filter is too high for it to be useful, so it is dropped from the
+// filter.
+const runtimeFilterMaxKeysPerBit = 0.25
+
+// bloomFilterBit returns the position of the i-th bit for the given hash value
+// in a bloom filter with mask+1 bits.
+func bloomFilterBit(hash uint64, i int, mask uint64) uint64 {
+	// Mix the bits of the hash value since the hash functions of
+	// colexechash are not designed to produce uniformly distributed low bits
+	// for multiple columns.
+	hash ^= hash >> 33
+	hash *= 0xff51afd7ed558ccd
+	hash ^= hash >> 33
+	hash *= 0xc4ceb9fe1a85ec53
+	hash ^= hash >> 33
+	h1, h2 := hash&0xffffffff, hash>>32|1
+	return (h1 + uint64(i)*h2) & mask
+}
+
+// getInt returns the value of the integer vector at the given index.
+func getInt(vec *coldata.Vec, idx int) int64 {
+	switch vec.Type().Width() {
+	case 16:
+		return int64(vec.Int16().Get(idx))
+	case 32:
+		return int64(vec.Int32().Get(idx))
+	default:
+		return vec.Int64().Get(idx)
+	}
+}
+
+// tracksBounds returns whether the bounds of a key column of the given type are
+// included in runtime filters.
+func tracksBounds(t *types.T) bool {
+	return t.Family() == types.IntFamily
+}
+
+// RuntimeFilterBuilder is an operator that passes through the batches of the
+// build input of a hash joiner while building a part of a runtime filter on
+// its key columns. Once the input is exhausted, the filter is published.
+type RuntimeFilterBuilder struct {
+	colexecop.OneInputHelper
+
+	inputTypes []*types.T
+	keyCols    []uint32
+	hasher     colexechash.TupleHasher
+	// bloom contains the bits of the bloom filter. It is nil if the filter
+	// doesn't include a bloom filter.
+	bloom   []uint64
+	bounds  []execinfrapb.RuntimeFilter_Bounds
+	numKeys int
+	// publish is called with the filter once it is built or abandoned.
+	publish   func(context.Context, *execinfrapb.RuntimeFilter)
+	published bool
+}
+
+var _ colexecop.Operator = &RuntimeFilterBuilder{}
+
+// NewRuntimeFilterBuilder returns a new RuntimeFilterBuilder that builds a part
+// of a runtime filter with a bloom filter of bloomFilterBits bits (which must
+// be zero or a power of two) on the keyCols columns of the input.
+func NewRuntimeFilterBuilder(
+	input colexecop.Operator,
+	inputTypes []*types.T,
+	keyCols []uint32,
+	bloomFilterBits uint32,
+	publish func(context.Context, *execinfrapb.RuntimeFilter),
+) *RuntimeFilterBuilder {
+	if bloomFilterBits&(bloomFilterBits-1) != 0 {
+		colexecerror.InternalError(errors.AssertionFailedf(
+			"bloom filter size %d is not a power of two", bloomFilterBits,
+		))
+	}
+	b := &RuntimeFilterBuilder{
+		OneInputHelper: colexecop.MakeOneInputHelper(input),
+		inputTypes:     inputTypes,
+		keyCols:        keyCols,
+		hasher:         colexechash.TupleHasher{InitHashValue: runtimeFilterInitHashValue},
+		bounds:         make([]execinfrapb.RuntimeFilter_Bounds, len(keyCols)),
+		publish:        publish,
+	}
+	if bloomFilterBits >= 64 {
+		b.bloom = make([]uint64, bloomFilterBits/64)
+	}
+	for i, col := range keyCols {
+		if tracksBounds(inputTypes[col]) {
+			b.bounds[i].Empty = true
+		} else {
+			b.bounds[i].Unbounded = true
+		}
+	}
+	return b
+}
+
+// Init implements the colexecop.Operator interface.
+func (b *RuntimeFilterBuilder) Init(ctx context.Context) {
+	if !b.InitHelper.Init(ctx) {
+		return
+	}
+	b.Input.Init(b.Ctx)
+	b.hasher.Init(b.Ctx)
+}
+
+// Next implements the colexecop.Operator interface.
+func (b *RuntimeFilterBuilder) Next() (coldata.Batch, *execinfrapb.ProducerMetadata) {
+	batch, meta := b.Input.Next()
+	if meta != nil || b.published {
+		return batch, meta
+	}
+	n := batch.Length()
+	if n == 0 {
+		b.publishFilter()
+		return batch, nil
+	}
+	sel := batch.Selection()
+	for i, col := range b.keyCols {
+		if b.bounds[i].Unbounded {
+			continue
+		}
+		vec := batch.ColVec(int(col))
+		nulls := vec.Nulls()
+		bounds := &b.bounds[i]
+		for j := 0; j < n; j++ {
+			idx := j
+			if sel != nil {
+				idx = sel[j]
+			}
+			if nulls.NullAt(idx) {
+				continue
+			}
+			v := getInt(vec, idx)
+			if bounds.Empty {
+				bounds.Min, bounds.Max, bounds.Empty = v, v, false
+			} else if v < bounds.Min {
+				bounds.Min = v
+			} else if v > bounds.Max {
+				bounds.Max = v
+			}
+		}
+	}
+	if b.bloom != nil {
+		b.numKeys += n
+		if float64(b.numKeys) > runtimeFilterMaxKeysPerBit*float64(len(b.bloom)*64) {
+			b.bloom = nil
+		} else {
+			mask := uint64(len(b.bloom)*64 - 1)
+			for _, hash := range b.hasher.Hash(batch, b.keyCols) {
+				for i := 0; i < runtimeFilterNumHashes; i++ {
+					bit := bloomFilterBit(hash, i, mask)
+					b.bloom[bit/64] |= 1 << (bit % 64)
+				}
+			}
+		}
+	}
+	return batch, nil
+}
+
+func (b *RuntimeFilterBuilder) publishFilter() {
+	filter := &execinfrapb.RuntimeFilter{Bounds: b.bounds}
+	if b.bloom != nil {
+		filter.BloomFilter = make([]byte, len(b.bloom)*8)
+		for i, word := range b.bloom {
+			binary.LittleEndian.PutUint64(filter.BloomFilter[i*8:], word)
+		}
+		b.bloom = nil
+	}
+	b.published = true
+	b.publish(b.Ctx, filter)
+}
+
+// Abandon publishes a filter that doesn't exclude any rows if the filter hasn't
+// been published yet. It must be called when the hash joiner no longer consumes
+// its build input before its probe input, for example when it spills to disk.
+func (b *RuntimeFilterBuilder) Abandon() {
+	if b.published {
+		return
+	}
+	b.bloom = nil
+	b.published = true
+	b.publish(b.EnsureCtx(), &execinfrapb.RuntimeFilter{PassThrough: true})
+}
+
+// runtimeFilterOp is an operator that only outputs the tuples of its input
+// that pass a runtime filter. It waits for the parts of the filter before
+// reading its input.
+type runtimeFilterOp struct {
+	colexecop.OneInputHelper
+	colexecop.CloserHelper
+
+	keyCols []uint32
+	// wait returns the parts of the filter, or nil if the filter should not be
+	// applied.
+	wait func(context.Context) ([]*execinfrapb.RuntimeFilter, error)
+	// release is called once the filter is no longer needed.
+	release func()
+	waited  bool
+	// passThrough is true if the filter doesn't exclude any tuples.
+	passThrough bool
+	// bloom contains the bits of the combined bloom filter. It is nil if the
+	// filter doesn't include a bloom filter.
+	bloom  []uint64
+	bounds []execinfrapb.RuntimeFilter_Bounds
+	hasher colexechash.TupleHasher
+}
+
+var _ colexecop.ClosableOperator = &runtimeFilterOp{}
+
+// NewRuntimeFilterOp returns a new operator that filters the tuples of the
+// input using the runtime filter on the keyCols columns. Before reading its
+// input, the operator calls wait to get the parts of the filter, and it calls
+// release once it is closed.
+func NewRuntimeFilterOp(
+	input colexecop.Operator,
+	keyCols []uint32,
+	wait func(context.Context) ([]*execinfrapb.RuntimeFilter, error),
+	release func(),
+) colexecop.ClosableOperator {
+	return &runtimeFilterOp{
+		OneInputHelper: colexecop.MakeOneInputHelper(input),
+		keyCols:        keyCols,
+		wait:           wait,
+		release:        release,
+		hasher:         colexechash.TupleHasher{InitHashValue: runtimeFilterInitHashValue},
+	}
+}
+
+// Init implements the colexecop.Operator interface.
+func (f *runtimeFilterOp) Init(ctx context.Context) {
+	if !f.InitHelper.Init(ctx) {
+		return
+	}
+	f.Input.Init(f.Ctx)
+	f.hasher.Init(f.Ctx)
+}
+
+// combine sets up the filter from its parts.
+func (f *runtimeFilterOp) combine(parts []*execinfrapb.RuntimeFilter) {
+	if parts == nil {
+		f.passThrough = true
+		return
+	}
+	f.bounds = make([]execinfrapb.RuntimeFilter_Bounds, len(f.keyCols))
+	for i := range f.bounds {
+		f.bounds[i].Empty = true
+	}
+	hasBloom := true
+	for _, part := range parts {
+		if part.PassThrough || len(part.Bounds) != len(f.keyCols) {
+			f.passThrough = true
+			return
+		}
+		if len(part.BloomFilter) == 0 || len(part.BloomFilter)%8 != 0 ||
+			(f.bloom != nil && len(part.BloomFilter) != len(f.bloom)*8) {
+			hasBloom = false
+		} else if hasBloom {
+			if f.bloom == nil {
+				f.bloom = make([]uint64, len(part.BloomFilter)/8)
+			}
+			for i := range f.bloom {
+				f.bloom[i] |= binary.LittleEndian.Uint64(part.BloomFilter[i*8:])
+			}
+		}
+		for i := range f.bounds {
+			b, pb := &f.bounds[i], &part.Bounds[i]
+			switch {
+			case b.Unbounded || pb.Empty:
+			case pb.Unbounded:
+				b.Unbounded = true
+			case b.Empty:
+				b.Min, b.Max, b.Empty = pb.Min, pb.Max, false
+			default:
+				b.Min = min(b.Min, pb.Min)
+				b.Max = max(b.Max, pb.Max)
+			}
+		}
+	}
+	if !hasBloom || len(f.bloom)&(len(f.bloom)-1) != 0 {
+		f.bloom = nil
+	}
+}
+
+// Next implements the colexecop.Operator interface.
+func (f *runtimeFilterOp) Next() (coldata.Batch, *execinfrapb.ProducerMetadata) {
+	if !f.waited {
+		f.waited = true
+		parts, err := f.wait(f.Ctx)
+		if err != nil {
+			colexecerror.ExpectedError(err)
+		}
+		f.combine(parts)
+	}
+	for {
+		batch, meta := f.Input.Next()
+		if meta != nil {
+			return nil, meta
+		}
+		n := batch.Length()
+		if n == 0 || f.passThrough {
+			return batch, nil
+		}
+		var hashes []uint64
+		if f.bloom != nil {
+			hashes = f.hasher.Hash(batch, f.keyCols)
+		}
+		sel := batch.Selection()
+		if sel == nil {
+			batch.SetSelection(true)
+			sel = batch.Selection()
+			for i := range sel[:n] {
+				sel[i] = i
+			}
+		}
+		var idx int
+		for i, selIdx := range sel[:n] {
+			if f.matches(batch, selIdx, hashes, i) {
+				sel[idx] = selIdx
+				idx++
+			}
+		}
+		if idx > 0 {
+			batch.SetLength(idx)
+			return batch, nil
+		}
+	}
+}
+
+// matches returns whether the tuple at index selIdx of the batch, which is
+// the i-th tuple of the batch, passes the filter.
+func (f *runtimeFilterOp) matches(batch coldata.Batch, selIdx int, hashes []uint64, i int) bool {
+	for j, col := range f.keyCols {
+		vec := batch.ColVec(int(col))
+		if vec.Nulls().NullAt(selIdx) {
+			// NULLs never match.
+			return false
+		}
+		if b := &f.bounds[j]; !b.Unbounded {
+			if b.Empty {
+				return false
+			}
+			if v := getInt(vec, selIdx); v < b.Min || v > b.Max {
+				return false
+			}
+		}
+	}
+	if hashes != nil {
+		mask := uint64(len(f.bloom)*64 - 1)
+		for k := 0; k < runtimeFilterNumHashes; k++ {
+			bit := bloomFilterBit(hashes[i], k, mask)
+			if f.bloom[bit/64]&(1<<(bit%64)) == 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Close implements the colexecop.Closer interface.
+func (f *runtimeFilterOp) Close(context.Context) error {
+	if f.CloserHelper.Close() && f.release != nil {
+		f.release()
+	}
+	return nil
+}
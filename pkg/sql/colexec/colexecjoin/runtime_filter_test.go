@@ -0,0 +1,148 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package colexecjoin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexectestutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeFilter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	typs := []*types.T{types.Int, types.String}
+	// The build input is split between two hash joiners.
+	buildInputs := []colexectestutils.Tuples{
+		{{1, "a"}, {3, "c"}},
+		{{7, "g"}, {nil, "z"}},
+	}
+	probe := colexectestutils.Tuples{
+		{0, "x"}, {1, "a"}, {2, "b"}, {3, "c"}, {3, "x"}, {7, "g"}, {8, "h"}, {nil, "z"},
+	}
+
+	// buildParts builds a part of the runtime filter from each build input.
+	buildParts := func(keyCols []uint32, bloomFilterBits uint32) []*execinfrapb.RuntimeFilter {
+		var parts []*execinfrapb.RuntimeFilter
+		for _, tups := range buildInputs {
+			input := colexectestutils.NewOpTestInput(testAllocator, coldata.BatchSize(), tups, typs)
+			b := NewRuntimeFilterBuilder(
+				input, typs, keyCols, bloomFilterBits,
+				func(_ context.Context, part *execinfrapb.RuntimeFilter) {
+					parts = append(parts, part)
+				},
+			)
+			b.Init(ctx)
+			numTuples := 0
+			for batch := colexecop.NextNoMeta(b); batch.Length() > 0; batch = colexecop.NextNoMeta(b) {
+				numTuples += batch.Length()
+			}
+			// The builder must pass through all tuples.
+			require.Equal(t, len(tups), numTuples)
+			// Abandoning a published filter is a noop.
+			b.Abandon()
+		}
+		require.Len(t, parts, len(buildInputs))
+		return parts
+	}
+
+	for _, tc := range []struct {
+		name            string
+		keyCols         []uint32
+		bloomFilterBits uint32
+		parts           func() []*execinfrapb.RuntimeFilter
+		expected        colexectestutils.Tuples
+	}{
+		{
+			name:            "int key",
+			keyCols:         []uint32{0},
+			bloomFilterBits: 1024,
+			expected:        colexectestutils.Tuples{{1, "a"}, {3, "c"}, {3, "x"}, {7, "g"}},
+		},
+		{
+			name:     "int key without bloom filter",
+			keyCols:  []uint32{0},
+			expected: colexectestutils.Tuples{{1, "a"}, {2, "b"}, {3, "c"}, {3, "x"}, {7, "g"}},
+		},
+		{
+			name:            "string key",
+			keyCols:         []uint32{1},
+			bloomFilterBits: 1024,
+			expected:        colexectestutils.Tuples{{1, "a"}, {3, "c"}, {7, "g"}, {nil, "z"}},
+		},
+		{
+			name:            "multiple keys",
+			keyCols:         []uint32{0, 1},
+			bloomFilterBits: 1024,
+			expected:        colexectestutils.Tuples{{1, "a"}, {3, "c"}, {7, "g"}},
+		},
+		{
+			name:    "abandoned",
+			keyCols: []uint32{0},
+			parts: func() []*execinfrapb.RuntimeFilter {
+				var parts []*execinfrapb.RuntimeFilter
+				input := colexectestutils.NewOpTestInput(testAllocator, coldata.BatchSize(), buildInputs[0], typs)
+				b := NewRuntimeFilterBuilder(
+					input, typs, []uint32{0}, 1024,
+					func(_ context.Context, part *execinfrapb.RuntimeFilter) {
+						parts = append(parts, part)
+					},
+				)
+				b.Init(ctx)
+				b.Abandon()
+				return append(parts, buildParts([]uint32{0}, 1024)...)
+			},
+			expected: probe,
+		},
+		{
+			name:    "timed out",
+			keyCols: []uint32{0},
+			parts: func() []*execinfrapb.RuntimeFilter {
+				return nil
+			},
+			expected: probe,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			parts := tc.parts
+			if parts == nil {
+				parts = func() []*execinfrapb.RuntimeFilter {
+					return buildParts(tc.keyCols, tc.bloomFilterBits)
+				}
+			}
+			// NULL keys never pass the filter, so the all NULLs injection
+			// would change the output.
+			colexectestutils.RunTestsWithoutAllNullsInjection(
+				t, testAllocator, []colexectestutils.Tuples{probe}, [][]*types.T{typs},
+				tc.expected, colexectestutils.OrderedVerifier,
+				func(inputs []colexecop.Operator) (colexecop.Operator, error) {
+					released := false
+					op := NewRuntimeFilterOp(
+						inputs[0], tc.keyCols,
+						func(context.Context) ([]*execinfrapb.RuntimeFilter, error) {
+							return parts(), nil
+						},
+						func() {
+							require.False(t, released)
+							released = true
+						},
+					)
+					return op, nil
+				},
+			)
+		})
+	}
+}
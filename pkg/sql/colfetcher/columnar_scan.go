@@ -0,0 +1,585 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package colfetcher
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/colencoding"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/columnarenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/errors"
+)
+
+// ColumnarScan is the colexecop.Operator implementation of TableReader for
+// columnar indexes. It merges the blocks of the index with the deltas that
+// apply to them (see the columnarenc package), only decompressing the columns
+// that are fetched and skipping the blocks whose minimum and maximum values
+// show that none of their rows can pass the block filters of the spec.
+//
+// Since the deltas that apply to a block must be read along with the block,
+// a columnar index is always read by a single ColumnarScan that covers the
+// whole index.
+type ColumnarScan struct {
+	*colBatchScanBase
+	fetcher   *row.KVFetcher
+	tableArgs *cFetcherTableArgs
+	allocator *colmem.Allocator
+
+	accountingHelper colmem.SetAccountingHelper
+	typs             []*types.T
+	// colIdxMap maps the IDs of the fetched columns to their ordinals in typs.
+	colIdxMap catalog.TableColMap
+	// filters are the decoded block filters of the spec.
+	filters []columnarBlockFilter
+	batch   coldata.Batch
+	vecs    coldata.TypedVecs
+	da      tree.DatumAlloc
+	cmpCtx  tree.CompareContext
+	// seen is scratch space used to find the columns that are NULL in a
+	// delta.
+	seen []bool
+
+	// kv is the next KV to process if haveKV is true. exhausted is set once
+	// the fetcher has returned all KVs.
+	kv        roachpb.KeyValue
+	haveKV    bool
+	exhausted bool
+
+	group columnarGroup
+	// numDeltas is the number of deltas read so far. It is reported to the
+	// compactor when the scan is closed.
+	numDeltas int
+}
+
+var _ ScanOperator = &ColumnarScan{}
+
+// columnarBlockFilter is a decoded execinfrapb.ColumnarBlockFilter.
+type columnarBlockFilter struct {
+	colID          descpb.ColumnID
+	lower, upper   tree.Datum
+	lowerInclusive bool
+	upperInclusive bool
+}
+
+// columnarDelta is a delta read from a columnar index.
+type columnarDelta struct {
+	rowKey []byte
+	// columns are the value-encoded columns of the row. It is only set if
+	// live is true.
+	columns []byte
+	live    bool
+}
+
+// columnarGroup is a block together with the deltas that follow it, or a run
+// of deltas that don't apply to any block.
+type columnarGroup struct {
+	block *columnarenc.Block
+	// skipped is true if the block filters showed that none of the rows of the
+	// block can pass them. Only the live deltas of the group are emitted in
+	// that case.
+	skipped bool
+	// values[i] contains the value encoding of the i-th fetched column for
+	// every row of the block, and bufs[i] is the scratch space that it
+	// aliases.
+	values [][][]byte
+	bufs   [][]byte
+	deltas []columnarDelta
+	// blockIdx and deltaIdx are the positions of the next block row and the
+	// next delta to emit.
+	blockIdx, deltaIdx int
+}
+
+func (g *columnarGroup) reset() {
+	g.block = nil
+	g.skipped = false
+	for i := range g.values {
+		g.values[i] = g.values[i][:0]
+	}
+	g.deltas = g.deltas[:0]
+	g.blockIdx, g.deltaIdx = 0, 0
+}
+
+// next returns the next row of the group, which is either the row of the block
+// with the given index (if delta is nil) or a live delta. ok is false once the
+// group is exhausted.
+func (g *columnarGroup) next() (blockIdx int, delta *columnarDelta, ok bool) {
+	for {
+		haveBlockRow := g.block != nil && !g.skipped && g.blockIdx < g.block.NumRows()
+		haveDelta := g.deltaIdx < len(g.deltas)
+		if !haveBlockRow && !haveDelta {
+			return 0, nil, false
+		}
+		if haveDelta {
+			d := &g.deltas[g.deltaIdx]
+			c := -1
+			if haveBlockRow {
+				c = bytes.Compare(d.rowKey, g.block.RowKey(g.blockIdx))
+			}
+			if c <= 0 {
+				g.deltaIdx++
+				if c == 0 {
+					// The delta overrides the row of the block.
+					g.blockIdx++
+				}
+				if !d.live {
+					continue
+				}
+				return 0, d, true
+			}
+		}
+		g.blockIdx++
+		return g.blockIdx - 1, nil, true
+	}
+}
+
+// Init initializes a ColumnarScan.
+func (s *ColumnarScan) Init(ctx context.Context) {
+	if !s.InitHelper.Init(ctx) {
+		return
+	}
+	s.Ctx, s.tracingSpan = execinfra.ProcessorSpan(
+		s.Ctx, s.flowCtx, "columnarscan", s.processorID,
+		&s.ContentionEventsListener, &s.ScanStatsListener, &s.TenantConsumptionListener,
+	)
+	if err := s.fetcher.SetupNextFetch(
+		s.Ctx, s.Spans, nil /* spanIDs */, s.batchBytesLimit,
+		0 /* firstBatchKeyLimit */, false, /* spansCanOverlap */
+	); err != nil {
+		colexecerror.InternalError(err)
+	}
+}
+
+// Next is part of the colexecop.Operator interface.
+func (s *ColumnarScan) Next() (coldata.Batch, *execinfrapb.ProducerMetadata) {
+	// Check if it is time to emit a progress update.
+	if s.getRowsReadSinceLastMeta() >= scanProgressFrequency {
+		meta := execinfrapb.GetProducerMeta()
+		meta.Metrics = execinfrapb.GetMetricsMeta()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		meta.Metrics.RowsRead = s.mu.rowsReadSinceLastMeta
+		meta.Metrics.StageID = s.stageID
+		s.mu.rowsReadSinceLastMeta = 0
+		return nil, meta
+	}
+
+	var reallocated bool
+	s.batch, reallocated = s.accountingHelper.ResetMaybeReallocate(
+		s.typs, s.batch, coldata.BatchSize(), /* tuplesToBeSet */
+	)
+	if reallocated {
+		s.vecs.SetBatch(s.batch)
+	}
+	rowIdx := 0
+	for {
+		blockIdx, delta, ok := s.group.next()
+		if !ok {
+			if ok, err := s.nextGroup(s.Ctx); err != nil {
+				colexecerror.InternalError(err)
+			} else if !ok {
+				break
+			}
+			continue
+		}
+		var err error
+		if delta != nil {
+			err = s.setDeltaRow(rowIdx, delta)
+		} else {
+			err = s.setBlockRow(rowIdx, blockIdx)
+		}
+		if err != nil {
+			colexecerror.InternalError(err)
+		}
+		batchDone := s.accountingHelper.AccountForSet(rowIdx)
+		rowIdx++
+		if batchDone {
+			break
+		}
+	}
+	s.batch.SetLength(rowIdx)
+	s.mu.Lock()
+	s.mu.rowsRead += int64(rowIdx)
+	s.mu.rowsReadSinceLastMeta += int64(rowIdx)
+	s.mu.Unlock()
+	return s.batch, nil
+}
+
+// setBlockRow sets the row with the given index of the current block as the
+// rowIdx-th row of the batch.
+func (s *ColumnarScan) setBlockRow(rowIdx, blockIdx int) error {
+	for vecIdx := range s.typs {
+		enc := s.group.values[vecIdx][blockIdx]
+		if enc == nil {
+			s.vecs.Nulls[vecIdx].SetNull(rowIdx)
+			continue
+		}
+		_, dataOffset, _, typ, err := encoding.DecodeValueTag(enc)
+		if err != nil {
+			return err
+		}
+		if _, err = colencoding.DecodeTableValueToCol(
+			&s.da, &s.vecs, vecIdx, rowIdx, typ, dataOffset, s.typs[vecIdx], enc,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setDeltaRow sets the row stored in the given delta as the rowIdx-th row of
+// the batch.
+func (s *ColumnarScan) setDeltaRow(rowIdx int, delta *columnarDelta) error {
+	for i := range s.seen {
+		s.seen[i] = false
+	}
+	var lastColID descpb.ColumnID
+	buf := delta.columns
+	for len(buf) > 0 {
+		_, dataOffset, colIDDelta, typ, err := encoding.DecodeValueTag(buf)
+		if err != nil {
+			return err
+		}
+		colID := lastColID + descpb.ColumnID(colIDDelta)
+		lastColID = colID
+		vecIdx, ok := s.colIdxMap.Get(colID)
+		if !ok {
+			// The column is not needed, so skip its value.
+			l, err := encoding.PeekValueLengthWithOffsetsAndType(buf, dataOffset, typ)
+			if err != nil {
+				return err
+			}
+			buf = buf[l:]
+			continue
+		}
+		buf, err = colencoding.DecodeTableValueToCol(
+			&s.da, &s.vecs, vecIdx, rowIdx, typ, dataOffset, s.typs[vecIdx], buf,
+		)
+		if err != nil {
+			return err
+		}
+		s.seen[vecIdx] = true
+	}
+	// NULL values are omitted from deltas.
+	for vecIdx, seen := range s.seen {
+		if !seen {
+			s.vecs.Nulls[vecIdx].SetNull(rowIdx)
+		}
+	}
+	return nil
+}
+
+// peekKV returns the next KV to process without consuming it.
+func (s *ColumnarScan) peekKV(ctx context.Context) (roachpb.KeyValue, bool, error) {
+	if !s.haveKV && !s.exhausted {
+		// Note that the KVs returned by the KVFetcher are stable, so blocks and
+		// deltas can alias them.
+		ok, _, kv, err := s.fetcher.NextKV(ctx, storage.MVCCDecodingNotRequired)
+		if err != nil {
+			return roachpb.KeyValue{}, false, err
+		}
+		if !ok {
+			s.exhausted = true
+		} else {
+			s.kv, s.haveKV = kv, true
+			if s.flowCtx.TraceKV {
+				log.VEventf(ctx, 2, "fetched: %s", kv.Key)
+			}
+		}
+	}
+	return s.kv, s.haveKV, nil
+}
+
+// nextGroup reads the next group of the index. It returns false once the index
+// is exhausted.
+func (s *ColumnarScan) nextGroup(ctx context.Context) (bool, error) {
+	s.group.reset()
+	for {
+		kv, ok, err := s.peekKV(ctx)
+		if err != nil || !ok {
+			return s.group.block != nil || len(s.group.deltas) > 0, err
+		}
+		rowKey, isBlock, err := columnarenc.DecodeKey(kv.Key)
+		if err != nil {
+			return false, err
+		}
+		if isBlock {
+			if s.group.block != nil || len(s.group.deltas) > 0 {
+				return true, nil
+			}
+			s.haveKV = false
+			if err := s.setBlock(ctx, kv.Value); err != nil {
+				return false, err
+			}
+			continue
+		}
+		if b := s.group.block; b != nil && bytes.Compare(rowKey, b.LastRowKey()) > 0 {
+			// The delta belongs to a row that follows the block.
+			return true, nil
+		}
+		if s.group.block == nil && len(s.group.deltas) >= coldata.BatchSize() {
+			// Limit the number of deltas that don't apply to any block that we
+			// buffer.
+			return true, nil
+		}
+		s.haveKV = false
+		s.numDeltas++
+		columns, live, err := columnarenc.DecodeDeltaValue(kv.Value)
+		if err != nil {
+			return false, err
+		}
+		s.group.deltas = append(s.group.deltas, columnarDelta{
+			rowKey:  rowKey,
+			columns: columns,
+			live:    live,
+		})
+	}
+}
+
+// setBlock makes the given block the block of the current group, decompressing
+// its fetched columns unless the block filters allow skipping it.
+func (s *ColumnarScan) setBlock(ctx context.Context, v roachpb.Value) error {
+	b, err := columnarenc.DecodeBlock(v)
+	if err != nil {
+		return err
+	}
+	s.group.block = b
+	if s.group.skipped, err = s.canSkipBlock(ctx, b); err != nil || s.group.skipped {
+		return err
+	}
+	for i := range s.typs {
+		id := s.tableArgs.spec.FetchedColumns[i].ColumnID
+		s.group.values[i], s.group.bufs[i], err = b.ColumnValues(id, s.group.bufs[i], s.group.values[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// canSkipBlock returns whether the minimum and maximum values of the columns of
+// the block show that none of its rows can pass the block filters.
+func (s *ColumnarScan) canSkipBlock(ctx context.Context, b *columnarenc.Block) (bool, error) {
+	for i := range s.filters {
+		f := &s.filters[i]
+		vecIdx, _ := s.colIdxMap.Get(f.colID)
+		minVal, maxVal, _, ok := b.ColumnBounds(f.colID)
+		if !ok {
+			continue
+		}
+		if len(minVal) == 0 {
+			// All values of the column are NULL, so no comparison can be true.
+			return true, nil
+		}
+		if f.lower != nil {
+			maxDatum, _, err := valueside.Decode(&s.da, s.typs[vecIdx], maxVal)
+			if err != nil {
+				return false, err
+			}
+			c, err := maxDatum.Compare(ctx, s.cmpCtx, f.lower)
+			if err != nil {
+				return false, err
+			}
+			if c < 0 || (c == 0 && !f.lowerInclusive) {
+				return true, nil
+			}
+		}
+		if f.upper != nil {
+			minDatum, _, err := valueside.Decode(&s.da, s.typs[vecIdx], minVal)
+			if err != nil {
+				return false, err
+			}
+			c, err := minDatum.Compare(ctx, s.cmpCtx, f.upper)
+			if err != nil {
+				return false, err
+			}
+			if c > 0 || (c == 0 && !f.upperInclusive) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// DrainMeta is part of the colexecop.MetadataSource interface.
+func (s *ColumnarScan) DrainMeta() []execinfrapb.ProducerMetadata {
+	trailingMeta := s.colBatchScanBase.drainMeta()
+	meta := execinfrapb.GetProducerMeta()
+	meta.Metrics = execinfrapb.GetMetricsMeta()
+	meta.Metrics.BytesRead = s.GetBytesRead()
+	meta.Metrics.RowsRead = s.getRowsReadSinceLastMeta()
+	meta.Metrics.KVCPUTime = s.GetKVResponseCPUTime()
+	meta.Metrics.StageID = s.stageID
+	trailingMeta = append(trailingMeta, *meta)
+	return trailingMeta
+}
+
+// GetBytesRead is part of the colexecop.KVReader interface.
+func (s *ColumnarScan) GetBytesRead() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetcher.GetBytesRead()
+}
+
+// GetKVPairsRead is part of the colexecop.KVReader interface.
+func (s *ColumnarScan) GetKVPairsRead() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetcher.GetKVPairsRead()
+}
+
+// GetKVResponseCPUTime is part of the colexecop.KVReader interface.
+func (s *ColumnarScan) GetKVResponseCPUTime() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetcher.GetKVCPUTime()
+}
+
+// GetBatchRequestsIssued is part of the colexecop.KVReader interface.
+func (s *ColumnarScan) GetBatchRequestsIssued() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetcher.GetBatchRequestsIssued()
+}
+
+// GetLocalKVCPUTime is part of the colexecop.KVReader interface.
+func (s *ColumnarScan) GetLocalKVCPUTime() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetcher.GetLocalKVCPUTime()
+}
+
+// Release implements the execreleasable.Releasable interface.
+func (s *ColumnarScan) Release() {
+	s.colBatchScanBase.Release()
+	s.accountingHelper.Release()
+	s.tableArgs.Release()
+	*s = ColumnarScan{}
+}
+
+// Close implements the colexecop.Closer interface.
+func (s *ColumnarScan) Close(context.Context) error {
+	// Note that we're using the context of the ColumnarScan rather than the
+	// argument of Close() because the ColumnarScan derives its own tracing
+	// span.
+	ctx := s.EnsureCtx()
+	if s.fetcher != nil {
+		s.fetcher.Close(ctx)
+		s.fetcher = nil
+	}
+	s.accountingHelper.ReleaseMemory()
+	if c := s.flowCtx.Cfg.ColumnarIndexCompactor; c != nil && s.numDeltas > 0 {
+		c.NotifyDeltas(s.tableArgs.spec.TableID, s.tableArgs.spec.IndexID, s.numDeltas)
+		s.numDeltas = 0
+	}
+	return s.colBatchScanBase.close()
+}
+
+// NewColumnarScan creates a new ColumnarScan operator.
+//
+// It also returns a slice of resulting column types from this operator.
+func NewColumnarScan(
+	ctx context.Context,
+	allocator *colmem.Allocator,
+	kvFetcherMemAcc *mon.BoundAccount,
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	stageID int32,
+	spec *execinfrapb.TableReaderSpec,
+	post *execinfrapb.PostProcessSpec,
+	typeResolver *descs.DistSQLTypeResolver,
+) (*ColumnarScan, []*types.T, error) {
+	if spec.Reverse {
+		return nil, nil, errors.AssertionFailedf("columnar indexes cannot be scanned in reverse")
+	}
+	base, bsHeader, tableArgs, err := newColBatchScanBase(
+		ctx, kvFetcherMemAcc, flowCtx, processorID, stageID, spec, post, typeResolver,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	s := &ColumnarScan{
+		colBatchScanBase: base,
+		tableArgs:        tableArgs,
+		allocator:        allocator,
+		typs:             tableArgs.typs,
+		cmpCtx:           flowCtx.EvalCtx,
+		seen:             make([]bool, len(tableArgs.typs)),
+	}
+	for i := range tableArgs.spec.FetchedColumns {
+		colID := tableArgs.spec.FetchedColumns[i].ColumnID
+		if colinfo.IsColIDSystemColumn(colID) {
+			s.Release()
+			return nil, nil, errors.AssertionFailedf(
+				"system columns cannot be fetched from columnar indexes")
+		}
+		s.colIdxMap.Set(colID, i)
+	}
+	for _, f := range spec.ColumnarBlockFilters {
+		vecIdx, ok := s.colIdxMap.Get(f.ColumnID)
+		if !ok {
+			s.Release()
+			return nil, nil, errors.AssertionFailedf(
+				"block filter on column %d which is not fetched", f.ColumnID)
+		}
+		filter := columnarBlockFilter{
+			colID:          f.ColumnID,
+			lowerInclusive: f.LowerInclusive,
+			upperInclusive: f.UpperInclusive,
+		}
+		if len(f.Lower) > 0 {
+			if filter.lower, _, err = valueside.Decode(&s.da, s.typs[vecIdx], f.Lower); err != nil {
+				s.Release()
+				return nil, nil, err
+			}
+		}
+		if len(f.Upper) > 0 {
+			if filter.upper, _, err = valueside.Decode(&s.da, s.typs[vecIdx], f.Upper); err != nil {
+				s.Release()
+				return nil, nil, err
+			}
+		}
+		s.filters = append(s.filters, filter)
+	}
+	s.group.values = make([][][]byte, len(s.typs))
+	s.group.bufs = make([][]byte, len(s.typs))
+	s.accountingHelper.Init(allocator, execinfra.GetWorkMemLimit(flowCtx), s.typs, false /* alwaysReallocate */)
+	s.fetcher = row.NewKVFetcher(
+		flowCtx.Txn,
+		bsHeader,
+		false, /* reverse */
+		false, /* rawMVCCValues */
+		spec.LockingStrength,
+		spec.LockingWaitPolicy,
+		spec.LockingDurability,
+		flowCtx.EvalCtx.SessionData().LockTimeout,
+		flowCtx.EvalCtx.SessionData().DeadlockTimeout,
+		kvFetcherMemAcc,
+		flowCtx.EvalCtx.TestingKnobs.ForceProductionValues,
+		spec.FetchSpec.External,
+		flowCtx.EvalCtx.WorkloadID,
+		flowCtx.EvalCtx.WorkloadType,
+	)
+	return s, s.typs, nil
+}
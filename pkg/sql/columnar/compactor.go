@@ -0,0 +1,419 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package columnar implements the compaction of columnar secondary indexes.
+//
+// Writes to a columnar index only add deltas (see the columnarenc package).
+// Scans report the number of deltas that they observe to the Compactor, which
+// periodically rewrites the blocks and deltas of the indexes with too many
+// deltas into new blocks.
+//
+// Compaction is only triggered by scans: the deltas of an index that is
+// written but not read accumulate until the index is scanned again, and each
+// node only compacts the indexes that were scanned on it. Until then, the
+// deltas take up space, and every scan of the index merges them with the
+// blocks.
+package columnar
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/columnarenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/idxtype"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// CompactionEnabled controls whether columnar indexes are compacted in the
+// background.
+var CompactionEnabled = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"sql.columnar_index.compaction.enabled",
+	"if enabled, the deltas of columnar indexes are compacted into blocks in the background",
+	true,
+)
+
+// CompactionInterval is the interval at which the Compactor compacts the
+// indexes that need it.
+var CompactionInterval = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.columnar_index.compaction.interval",
+	"how often to compact the columnar indexes with too many deltas",
+	10*time.Second,
+	settings.PositiveDuration,
+)
+
+// CompactionDeltaThreshold is the number of deltas that a scan of a columnar
+// index must observe for the index to be compacted.
+var CompactionDeltaThreshold = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"sql.columnar_index.compaction.delta_threshold",
+	"the number of deltas that a scan of a columnar index must observe for the index to be compacted",
+	1024,
+	settings.PositiveInt,
+)
+
+// BlockRows is the number of rows in the blocks written by the Compactor.
+var BlockRows = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"sql.columnar_index.compaction.block_rows",
+	"the number of rows in each block of a columnar index",
+	1024,
+	settings.IntInRange(1, 1<<16),
+)
+
+// compactionBatchBytes and compactionBatchKeys limit the size of the part of
+// an index that is rewritten by each compaction transaction.
+const (
+	compactionBatchBytes = 4 << 20 // 4 MiB
+	compactionBatchKeys  = 10000
+)
+
+type indexKey struct {
+	tableID descpb.ID
+	indexID descpb.IndexID
+}
+
+// Compactor compacts the deltas of columnar indexes into blocks. It implements
+// execinfra.ColumnarIndexCompactor.
+type Compactor struct {
+	settings *cluster.Settings
+	codec    keys.SQLCodec
+	db       descs.DB
+	mu       struct {
+		syncutil.Mutex
+		// dirty is the set of indexes that need to be compacted.
+		dirty map[indexKey]struct{}
+	}
+}
+
+// NewCompactor returns a new Compactor.
+func NewCompactor(settings *cluster.Settings, codec keys.SQLCodec, db descs.DB) *Compactor {
+	c := &Compactor{
+		settings: settings,
+		codec:    codec,
+		db:       db,
+	}
+	c.mu.dirty = make(map[indexKey]struct{})
+	return c
+}
+
+// NotifyDeltas is part of the execinfra.ColumnarIndexCompactor interface.
+func (c *Compactor) NotifyDeltas(tableID descpb.ID, indexID descpb.IndexID, numDeltas int) {
+	if int64(numDeltas) < CompactionDeltaThreshold.Get(&c.settings.SV) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mu.dirty[indexKey{tableID: tableID, indexID: indexID}] = struct{}{}
+}
+
+// Start starts the background task that compacts the indexes reported by
+// NotifyDeltas.
+func (c *Compactor) Start(ctx context.Context, stopper *stop.Stopper) {
+	_ = stopper.RunAsyncTask(ctx, "columnar-index-compactor", func(ctx context.Context) {
+		ctx, cancel := stopper.WithCancelOnQuiesce(ctx)
+		defer cancel()
+		for {
+			select {
+			case <-time.After(CompactionInterval.Get(&c.settings.SV)):
+			case <-stopper.ShouldQuiesce():
+				return
+			}
+			if !CompactionEnabled.Get(&c.settings.SV) {
+				continue
+			}
+			c.mu.Lock()
+			dirty := c.mu.dirty
+			c.mu.dirty = make(map[indexKey]struct{})
+			c.mu.Unlock()
+			for k := range dirty {
+				if err := c.CompactIndex(ctx, k.tableID, k.indexID); err != nil {
+					log.Dev.Warningf(ctx, "failed to compact columnar index %d of table %d: %v",
+						k.indexID, k.tableID, err)
+				}
+			}
+		}
+	})
+}
+
+// CompactIndex compacts the blocks and deltas of the given columnar index into
+// new blocks. The index is compacted in a sequence of transactions, each of
+// which replaces a contiguous part of the index.
+//
+// Every transaction maintains the invariant that readers rely on: no block
+// starts within the key range of another block. This is ensured by starting
+// each transaction at the last block written by the previous one, which might
+// not have been full and which might cover rows whose deltas haven't been
+// read yet.
+func (c *Compactor) CompactIndex(
+	ctx context.Context, tableID descpb.ID, indexID descpb.IndexID,
+) error {
+	start := c.codec.IndexPrefix(uint32(tableID), uint32(indexID))
+	end := start.PrefixEnd()
+	for start != nil {
+		var next roachpb.Key
+		if err := c.db.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) (err error) {
+			if err := txn.KV().SetUserPriority(roachpb.MinUserPriority); err != nil {
+				return err
+			}
+			tbl, err := txn.Descriptors().ByIDWithLeased(txn.KV()).WithoutNonPublic().Get().Table(ctx, tableID)
+			if err != nil {
+				return err
+			}
+			idx := catalog.FindIndexByID(tbl, indexID)
+			if idx == nil || !idx.Public() || idx.GetType() != idxtype.COLUMNAR {
+				// The index has been dropped or isn't ready yet.
+				next = nil
+				return nil
+			}
+			next, err = c.compactBatch(ctx, txn.KV(), tbl, idx, start, end)
+			return err
+		}, isql.WithPriority(admissionpb.BulkLowPri)); err != nil {
+			return err
+		}
+		start = next
+	}
+	return nil
+}
+
+// compactedRow is a row of a columnar index whose values are ordered like the
+// result of columnarenc.IndexColumnIDs.
+type compactedRow struct {
+	key    roachpb.Key
+	values tree.Datums
+}
+
+// scanBatch reads the next batch of KVs of an index.
+func scanBatch(
+	ctx context.Context, txn *kv.Txn, start, end roachpb.Key, targetBytes int64, maxKeys int64,
+) ([]kv.KeyValue, *roachpb.Span, error) {
+	b := txn.NewBatch()
+	b.Header.TargetBytes = targetBytes
+	b.Header.MaxSpanRequestKeys = maxKeys
+	b.Scan(start, end)
+	if err := txn.Run(ctx, b); err != nil {
+		return nil, nil, err
+	}
+	return b.Results[0].Rows, b.Results[0].ResumeSpan, nil
+}
+
+// compactBatch rewrites the blocks and deltas in the part of the given index
+// that starts at start. It returns the key at which the next batch should
+// start, or nil if the end of the index was reached.
+//
+// Full blocks that no delta applies to are left untouched. Every other block
+// is merged with its deltas, and the resulting rows, along with the rows of the
+// deltas that don't apply to any block, are written into new blocks.
+func (c *Compactor) compactBatch(
+	ctx context.Context,
+	txn *kv.Txn,
+	tbl catalog.TableDescriptor,
+	idx catalog.Index,
+	start, end roachpb.Key,
+) (next roachpb.Key, _ error) {
+	kvs, resumeSpan, err := scanBatch(ctx, txn, start, end, compactionBatchBytes, compactionBatchKeys)
+	if err != nil {
+		return nil, err
+	}
+	if resumeSpan != nil && len(kvs) < 2 {
+		// A single block exceeded the byte limit. Read the next key as well so
+		// that every batch makes progress.
+		if kvs, resumeSpan, err = scanBatch(ctx, txn, start, end, 0 /* targetBytes */, 2 /* maxKeys */); err != nil {
+			return nil, err
+		}
+	}
+
+	colIDs := columnarenc.IndexColumnIDs(idx)
+	typs := make([]*types.T, len(colIDs))
+	var colMap catalog.TableColMap
+	for i, id := range colIDs {
+		col := catalog.FindColumnByID(tbl, id)
+		if col == nil {
+			return nil, errors.AssertionFailedf("column %d of columnar index %q not found", id, idx.GetName())
+		}
+		typs[i] = col.GetType()
+		colMap.Set(id, i)
+	}
+
+	blockRows := int(BlockRows.Get(&c.settings.SV))
+	bb := columnarenc.MakeBlockBuilder(colIDs)
+	// keep is the set of keys that must not be deleted, either because they
+	// are blocks that are left untouched, or because they are overwritten by
+	// new blocks.
+	keep := make(map[string]struct{})
+	wb := txn.NewBatch()
+	var cmpCtx *eval.Context // A nil context compares datums in UTC.
+	// rows are the merged rows that haven't been written into new blocks yet.
+	var rows []compactedRow
+	flush := func() error {
+		for i := range rows {
+			bb.Add(rows[i].key, rows[i].values)
+			if bb.Len() == blockRows || i == len(rows)-1 {
+				key, value, err := bb.Finish(ctx, cmpCtx)
+				if err != nil {
+					return err
+				}
+				keep[string(key)] = struct{}{}
+				wb.Put(key, &value)
+				next = key
+			}
+		}
+		rows = rows[:0]
+		return nil
+	}
+	// find returns the position of the row with the given key in rows, or the
+	// position at which it should be inserted. Rows that come from deltas that
+	// don't override a block are usually appended at the end, so search from
+	// there.
+	find := func(key roachpb.Key) (int, bool) {
+		i := len(rows)
+		for i > 0 && bytes.Compare(rows[i-1].key, key) >= 0 {
+			i--
+		}
+		return i, i < len(rows) && rows[i].key.Equal(key)
+	}
+	var da tree.DatumAlloc
+	var buf []byte
+	var values [][]byte
+	for i, entry := range kvs {
+		rowKey, isBlock, err := columnarenc.DecodeKey(entry.Key)
+		if err != nil {
+			return nil, err
+		}
+		if isBlock {
+			block, err := columnarenc.DecodeBlock(*entry.Value)
+			if err != nil {
+				return nil, err
+			}
+			if block.NumRows() >= blockRows && i+1 < len(kvs) {
+				if _, nextIsBlock, err := columnarenc.DecodeKey(kvs[i+1].Key); err != nil {
+					return nil, err
+				} else if nextIsBlock {
+					// The block is full and no delta applies to it.
+					if err := flush(); err != nil {
+						return nil, err
+					}
+					keep[string(entry.Key)] = struct{}{}
+					continue
+				}
+			}
+			// Merge the block with the deltas that follow it. Since the deltas
+			// take precedence, this amounts to overwriting its rows.
+			merged := make([]compactedRow, block.NumRows())
+			for j := range merged {
+				merged[j] = compactedRow{key: block.RowKey(j), values: make(tree.Datums, len(colIDs))}
+			}
+			for ord, id := range colIDs {
+				values, buf, err = block.ColumnValues(id, buf, values[:0])
+				if err != nil {
+					return nil, err
+				}
+				for j, enc := range values {
+					d := tree.Datum(tree.DNull)
+					if enc != nil {
+						if d, _, err = valueside.Decode(&da, typs[ord], enc); err != nil {
+							return nil, err
+						}
+					}
+					merged[j].values[ord] = d
+				}
+			}
+			rows = append(rows, merged...)
+			continue
+		}
+		columns, live, err := columnarenc.DecodeDeltaValue(*entry.Value)
+		if err != nil {
+			return nil, err
+		}
+		j, found := find(rowKey)
+		if !live {
+			if found {
+				rows = append(rows[:j], rows[j+1:]...)
+			}
+			continue
+		}
+		row, err := decodeDelta(&da, colMap, typs, columns)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			rows[j].values = row
+			continue
+		}
+		rows = append(rows, compactedRow{})
+		copy(rows[j+1:], rows[j:])
+		rows[j] = compactedRow{key: rowKey, values: row}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	for _, entry := range kvs {
+		if _, ok := keep[string(entry.Key)]; !ok {
+			wb.Del(entry.Key)
+		}
+	}
+	if err := txn.Run(ctx, wb); err != nil {
+		return nil, err
+	}
+	if resumeSpan == nil {
+		return nil, nil
+	}
+	if next == nil {
+		// All rows that were read were either deleted or left untouched, so
+		// none of the remaining deltas apply to a block that was read.
+		return resumeSpan.Key, nil
+	}
+	// The last new block might not be full, and deltas that haven't been read
+	// yet might apply to it, so the next batch starts at it.
+	return next, nil
+}
+
+// decodeDelta decodes the columns of a live delta into a row whose values are
+// ordered like typs.
+func decodeDelta(
+	da *tree.DatumAlloc, colMap catalog.TableColMap, typs []*types.T, columns []byte,
+) (tree.Datums, error) {
+	row := make(tree.Datums, len(typs))
+	for i := range row {
+		row[i] = tree.DNull
+	}
+	var lastColID descpb.ColumnID
+	for len(columns) > 0 {
+		_, _, colIDDelta, _, err := encoding.DecodeValueTag(columns)
+		if err != nil {
+			return nil, err
+		}
+		colID := lastColID + descpb.ColumnID(colIDDelta)
+		lastColID = colID
+		ord, ok := colMap.Get(colID)
+		if !ok {
+			return nil, errors.AssertionFailedf("unexpected column %d in columnar index delta", colID)
+		}
+		if row[ord], columns, err = valueside.Decode(da, typs[ord], columns); err != nil {
+			return nil, err
+		}
+	}
+	return row, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package columnar_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/columnar"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactIndex(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	s := srv.ApplicationLayer()
+	db := sqlutils.MakeSQLRunner(sqlDB)
+
+	// Compactions are triggered explicitly below.
+	db.Exec(t, `SET CLUSTER SETTING sql.columnar_index.compaction.enabled = false`)
+	db.Exec(t, `SET CLUSTER SETTING sql.columnar_index.compaction.block_rows = 100`)
+	db.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v INT, s STRING)`)
+	db.Exec(t, `CREATE INDEX c ON t USING COLUMNAR (v, s)`)
+	db.Exec(t, `INSERT INTO t SELECT i, i % 7, 'v' || i::STRING FROM generate_series(1, 1000) AS g(i)`)
+
+	var tableID descpb.ID
+	var indexID descpb.IndexID
+	db.QueryRow(t, `
+SELECT descriptor_id, index_id FROM crdb_internal.table_indexes
+ WHERE descriptor_name = 't' AND index_name = 'c'`,
+	).Scan(&tableID, &indexID)
+	indexPrefix := s.Codec().IndexPrefix(uint32(tableID), uint32(indexID))
+	numKeys := func() int {
+		kvs, err := s.DB().Scan(ctx, indexPrefix, indexPrefix.PrefixEnd(), 0 /* maxRows */)
+		require.NoError(t, err)
+		return len(kvs)
+	}
+
+	const query = `SELECT count(*), sum(v), max(s), count(s) FROM t@c`
+	const primaryQuery = `SELECT count(*), sum(v), max(s), count(s) FROM t@t_pkey`
+	checkIndex := func() {
+		db.CheckQueryResults(t, query, db.QueryStr(t, primaryQuery))
+		db.CheckQueryResults(t, `SELECT k, v FROM t@c WHERE v = 3 ORDER BY k`,
+			db.QueryStr(t, `SELECT k, v FROM t@t_pkey WHERE v = 3 ORDER BY k`))
+	}
+
+	compactor := columnar.NewCompactor(s.ClusterSettings(), s.Codec(), s.InternalDB().(descs.DB))
+
+	// Every row has a delta before the first compaction.
+	require.Equal(t, 1000, numKeys())
+	checkIndex()
+	require.NoError(t, compactor.CompactIndex(ctx, tableID, indexID))
+	require.Equal(t, 10, numKeys())
+	checkIndex()
+
+	// Updates and deletes add deltas that override the rows of the blocks.
+	db.Exec(t, `UPDATE t SET v = v + 1, s = NULL WHERE k % 10 = 0`)
+	db.Exec(t, `DELETE FROM t WHERE k % 10 = 5`)
+	db.Exec(t, `INSERT INTO t VALUES (2000, 3, 'new')`)
+	require.Equal(t, 10+100+100+1, numKeys())
+	checkIndex()
+
+	require.NoError(t, compactor.CompactIndex(ctx, tableID, indexID))
+	// 901 rows remain.
+	require.Equal(t, 10, numKeys())
+	checkIndex()
+
+	// Compacting an index without deltas doesn't change it.
+	require.NoError(t, compactor.CompactIndex(ctx, tableID, indexID))
+	require.Equal(t, 10, numKeys())
+	checkIndex()
+}
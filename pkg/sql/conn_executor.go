@@ -1858,6 +1858,11 @@ type connExecutor struct {
 		// The map key is the sequence descpb.ID.
 		createdSequences map[descpb.ID]struct{}
 
+		// resultCacheWrites is the set of tables that may have been written to
+		// by the current transaction. The results in the query result cache that
+		// depend on them are invalidated when the transaction commits.
+		resultCacheWrites catalog.DescriptorIDSet
+
 		// shouldLogToTelemetry indicates if the current transaction should be
 		// logged to telemetry. It is used in telemetry transaction sampling
 		// mode to emit all statement events for a particular transaction.
@@ -2235,6 +2240,16 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent, pay
 	ex.extraTxnState.upgradedToSerializable = false
 	ex.extraTxnState.hasAdminRoleCache = HasAdminRoleCache{}
 	ex.extraTxnState.createdSequences = nil
+	// On restarts, the tables written to by the previous attempt are kept
+	// around, since invalidating too many results is harmless.
+	if ev.eventType != txnRestart && !ex.extraTxnState.resultCacheWrites.Empty() {
+		if ev.eventType == txnCommit {
+			ex.server.cfg.ResultCache.Invalidate(
+				ex.extraTxnState.resultCacheWrites.Ordered(), ev.commitTimestamp,
+			)
+		}
+		ex.extraTxnState.resultCacheWrites = catalog.DescriptorIDSet{}
+	}
 
 	if ex.extraTxnState.skipResettingSchemaObjects {
 		if ex.extraTxnState.shouldResetSyntheticDescriptors {
@@ -4651,6 +4666,11 @@ func (ex *connExecutor) serialize() serverpb.Session {
 	if txn != nil {
 		id := txn.ID()
 		elapsedTime := crtime.MonoFromTime(timeNow).Sub(ex.state.mu.txnStart)
+		writeBufferStats := txn.WriteBufferStats()
+		var flushReason string
+		if writeBufferStats.FlushReason != kv.WriteBufferNotFlushed {
+			flushReason = writeBufferStats.FlushReason.String()
+		}
 		activeTxnInfo = &serverpb.TxnInfo{
 			ID:                    id,
 			Start:                 timeNow.Add(-elapsedTime),
@@ -4660,15 +4680,19 @@ func (ex *connExecutor) serialize() serverpb.Session {
 			NumAutoRetries:        ex.state.mu.autoRetryCounter,
 			TxnDescription:        txn.String(),
 			// TODO(yuzefovich): this seems like not a concurrency safe call.
-			Implicit:            ex.implicitTxn(),
-			AllocBytes:          ex.state.txnMon.AllocBytes(),
-			MaxAllocBytes:       ex.state.txnMon.MaximumBytes(),
-			IsHistorical:        ex.state.isHistorical.Load(),
-			ReadOnly:            ex.state.readOnly.Load(),
-			Priority:            ex.state.mu.priority.String(),
-			QualityOfService:    sessiondatapb.ToQoSLevelString(txn.AdmissionHeader().Priority),
-			LastAutoRetryReason: autoRetryReasonStr,
-			IsolationLevel:      tree.FromKVIsoLevel(ex.state.mu.isolationLevel).String(),
+			Implicit:               ex.implicitTxn(),
+			AllocBytes:             ex.state.txnMon.AllocBytes(),
+			MaxAllocBytes:          ex.state.txnMon.MaximumBytes(),
+			IsHistorical:           ex.state.isHistorical.Load(),
+			ReadOnly:               ex.state.readOnly.Load(),
+			Priority:               ex.state.mu.priority.String(),
+			QualityOfService:       sessiondatapb.ToQoSLevelString(txn.AdmissionHeader().Priority),
+			LastAutoRetryReason:    autoRetryReasonStr,
+			IsolationLevel:         tree.FromKVIsoLevel(ex.state.mu.isolationLevel).String(),
+			BufferedWrites:         writeBufferStats.BufferedWrites,
+			FlushedWrites:          writeBufferStats.FlushedWrites,
+			WriteBufferReadHits:    writeBufferStats.ReadHits,
+			WriteBufferFlushReason: flushReason,
 		}
 	}
 
@@ -47,6 +47,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
 	"github.com/cockroachdb/cockroach/pkg/sql/prep"
 	"github.com/cockroachdb/cockroach/pkg/sql/regions"
+	"github.com/cockroachdb/cockroach/pkg/sql/resultcache"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
@@ -671,6 +672,7 @@ func (ex *connExecutor) execStmtInOpenState(
 		p.txn.SetWorkloadInfo(
 			uint64(ih.fingerprintId), appNameID, workloadid.WorkloadTypeStatement,
 		)
+		p.txn.SetResourceGroupID(p.resourceGroupID())
 	}
 
 	// Note that here we always unconditionally defer a function that takes care
@@ -1764,6 +1766,7 @@ func (ex *connExecutor) execStmtInOpenStateWithPausablePortal(
 		p.txn.SetWorkloadInfo(
 			uint64(ih.fingerprintId), appNameID2, workloadid.WorkloadTypeStatement,
 		)
+		p.txn.SetResourceGroupID(p.resourceGroupID())
 	}
 
 	if buildutil.CrdbTestBuild {
@@ -3140,6 +3143,20 @@ func (ex *connExecutor) dispatchToExecutionEngine(
 		return nil
 	}
 
+	// Serve the results from the query result cache if possible. Otherwise,
+	// keep a copy of the results to store them in the cache.
+	resultCacheKey, resultCacheTables, useResultCache := ex.resultCacheKey(planner)
+	var resultCacheRes *resultCacheWriter
+	if useResultCache {
+		rc := ex.server.cfg.ResultCache
+		if rows, ok := rc.Get(resultCacheKey, planner.Txn().ReadTimestamp()); ok {
+			return ex.serveFromResultCache(ctx, planner, res, rows)
+		}
+		planner.curPlan.flags.Set(planFlagResultCacheMiss)
+		resultCacheRes = newResultCacheWriter(res, resultcache.MaxEntrySize.Get(ex.server.cfg.SV()))
+		res = resultCacheRes
+	}
+
 	ex.sessionTracing.TracePlanCheckStart(ctx)
 
 	var afterGetPlanDistribution func()
@@ -3224,6 +3241,13 @@ func (ex *connExecutor) dispatchToExecutionEngine(
 	// https://github.com/cockroachdb/cockroach/issues/99410
 	ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.PlannerEndExecStmt, crtime.NowMono())
 
+	if resultCacheRes != nil && err == nil {
+		resultCacheRes.maybePut(ctx, planner, ex.server.cfg.ResultCache, resultCacheKey, resultCacheTables)
+	}
+	if planner.curPlan.flags.IsSet(planFlagContainsMutation) {
+		ex.recordResultCacheWrites(planner)
+	}
+
 	ex.extraTxnState.rowsRead += stats.rowsRead
 	ex.extraTxnState.bytesRead += stats.bytesRead
 	ex.extraTxnState.rowsWritten += stats.rowsWritten
@@ -3631,6 +3655,9 @@ type topLevelQueryStats struct {
 	clientTime time.Duration
 	// kvCPUTimeNanos is the CPU time consumed by KV operations during query execution.
 	kvCPUTimeNanos time.Duration
+	// writeBufferStats describes the query's use of the transaction's
+	// client-side write buffer.
+	writeBufferStats kv.WriteBufferStats
 	// NB: when adding another field here, consider whether
 	// forwardInnerQueryStats method needs an adjustment.
 }
@@ -3644,6 +3671,7 @@ func (s *topLevelQueryStats) add(other *topLevelQueryStats) {
 	s.networkEgressEstimate += other.networkEgressEstimate
 	s.clientTime += other.clientTime
 	s.kvCPUTimeNanos += other.kvCPUTimeNanos
+	s.writeBufferStats.Add(other.writeBufferStats)
 }
 
 // execWithDistSQLEngine converts a plan to a distributed SQL physical plan and
@@ -3677,6 +3705,9 @@ func (ex *connExecutor) execWithDistSQLEngine(
 	defer recv.Release()
 
 	var err error
+	// The write buffer is owned by the transaction, so attribute to the query
+	// only the buffering which happened while it ran.
+	writeBufferStatsBefore := planner.txn.WriteBufferStats()
 
 	if planner.hasFlowForPausablePortal() {
 		err = planner.resumeFlowForPausablePortal(recv)
@@ -3713,6 +3744,7 @@ func (ex *connExecutor) execWithDistSQLEngine(
 	if err == nil && res.Err() == nil {
 		recv.handleMisestimates(ctx, planner)
 	}
+	recv.stats.writeBufferStats = planner.txn.WriteBufferStats().Sub(writeBufferStatsBefore)
 	return recv.stats, err
 }
 
@@ -4652,6 +4684,7 @@ func (ex *connExecutor) recordTransactionFinish(
 		RowsWritten:             ex.extraTxnState.rowsWritten,
 		BytesRead:               ex.extraTxnState.bytesRead,
 		KVCPUTimeNanos:          ex.extraTxnState.kvCPUTimeNanos,
+		WriteBufferStats:        ex.state.finishedTxnWriteBufferStats,
 		Priority:                ex.state.mu.priority,
 		// TODO(107318): add isolation level
 		// TODO(107318): add qos
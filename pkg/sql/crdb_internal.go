@@ -2140,7 +2140,11 @@ CREATE TABLE crdb_internal.%s (
   last_auto_retry_reason STRING,   -- the error causing the last automatic retry for this txn
   isolation_level STRING,          -- the isolation level of the transaction
   priority STRING,                 -- the priority of the transaction
-  quality_of_service STRING        -- the quality of service of the transaction
+  quality_of_service STRING,       -- the quality of service of the transaction
+  buffered_writes INT,             -- the number of writes buffered client-side
+  flushed_writes INT,              -- the number of buffered writes flushed to KV
+  write_buffer_read_hits INT,      -- the number of reads served from the write buffer
+  write_buffer_flush_reason STRING -- the reason the write buffer was flushed, if it was
 )`
 
 var crdbInternalLocalTxnsTable = virtualSchemaTable{
@@ -2213,6 +2217,10 @@ func populateTransactionsTable(
 				tree.NewDString(txn.IsolationLevel),
 				tree.NewDString(txn.Priority),
 				tree.NewDString(txn.QualityOfService),
+				tree.NewDInt(tree.DInt(txn.BufferedWrites)),
+				tree.NewDInt(tree.DInt(txn.FlushedWrites)),
+				tree.NewDInt(tree.DInt(txn.WriteBufferReadHits)),
+				tree.NewDString(txn.WriteBufferFlushReason),
 			); err != nil {
 				return err
 			}
@@ -2237,6 +2245,10 @@ func populateTransactionsTable(
 				tree.DNull,                             // IsolationLevel
 				tree.DNull,                             // Priority
 				tree.DNull,                             // QualityOfService
+				tree.DNull,                             // BufferedWrites
+				tree.DNull,                             // FlushedWrites
+				tree.DNull,                             // WriteBufferReadHits
+				tree.DNull,                             // WriteBufferFlushReason
 			); err != nil {
 				return err
 			}
@@ -60,6 +60,11 @@ func (p *planner) CreateIndex(ctx context.Context, n *tree.CreateIndex) (planNod
 		return nil, err
 	}
 
+	if n.Type == idxtype.COLUMNAR {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"columnar indexes can only be created by the declarative schema changer")
+	}
+
 	// Check if sql_safe_updates is enabled and this is a vector index
 	if n.Type == idxtype.VECTOR {
 		if p.EvalContext().SessionData().SafeUpdates {
@@ -86,6 +86,17 @@ func (n *createViewNode) startExec(params runParams) error {
 			"security invoker views are not supported")
 	}
 
+	incrementalRefresh, err := makeIncrementalRefresh(params.ctx, params.p, createView)
+	if err != nil {
+		return err
+	}
+	var incrementalTableIDs descpb.IDs
+	if incrementalRefresh != nil {
+		if incrementalTableIDs, err = n.analyzeIncrementalView(params); err != nil {
+			return err
+		}
+	}
+
 	tableType := tree.GetTableType(
 		false /* isSequence */, true /* isView */, createView.Materialized,
 	)
@@ -292,6 +303,12 @@ func (n *createViewNode) startExec(params runParams) error {
 						desc.SetTableLocalityGlobal()
 						applyGlobalMultiRegionZoneConfig = true
 					}
+					// Incrementally maintained views are kept up to date by a job,
+					// which is created along with the view.
+					if incrementalRefresh != nil {
+						incrementalRefresh.JobID = catpb.JobID(params.ExecCfg().JobRegistry.MakeJobID())
+						desc.IncrementalRefresh = incrementalRefresh
+					}
 				}
 
 				// Set view options if specified.
@@ -329,6 +346,14 @@ func (n *createViewNode) startExec(params runParams) error {
 				); err != nil {
 					return err
 				}
+
+				if newDesc.IncrementalRefresh != nil {
+					if err := params.p.createIncrementalViewMaintenanceJob(
+						params.ctx, newDesc, incrementalTableIDs,
+					); err != nil {
+						return err
+					}
+				}
 			}
 
 			// Persist the back-references in all referenced table descriptors.
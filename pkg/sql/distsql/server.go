@@ -857,6 +857,23 @@ func (ds *ServerImpl) CancelDeadFlows(
 	return &execinfrapb.SimpleResponse{}, nil
 }
 
+// PushRuntimeFilter is part of the execinfrapb.DRPCDistSQLServer interface.
+func (ds *drpcServerImpl) PushRuntimeFilter(
+	ctx context.Context, req *execinfrapb.PushRuntimeFilterRequest,
+) (*execinfrapb.SimpleResponse, error) {
+	return (*ServerImpl)(ds).PushRuntimeFilter(ctx, req)
+}
+
+// PushRuntimeFilter is part of the execinfrapb.DistSQLServer interface.
+func (ds *ServerImpl) PushRuntimeFilter(
+	ctx context.Context, req *execinfrapb.PushRuntimeFilterRequest,
+) (*execinfrapb.SimpleResponse, error) {
+	if ds.ServerConfig.RuntimeFilters != nil {
+		ds.ServerConfig.RuntimeFilters.Push(req.FlowID, req.FilterID, &req.Filter)
+	}
+	return &execinfrapb.SimpleResponse{}, nil
+}
+
 func (ds *ServerImpl) flowStreamInt(
 	ctx context.Context, stream execinfrapb.RPCDistSQL_FlowStreamStream,
 ) error {
@@ -284,6 +284,10 @@ func planSafeForReducedLeaf(processors []physicalplan.Processor) bool {
 			return unsafeCore
 		case core.CompactBackups != nil:
 			return unoptimizedProcessor
+		case core.BackupTableReader != nil:
+			return unoptimizedProcessor
+		case core.CopyBackup != nil:
+			return unoptimizedProcessor
 		default:
 			if buildutil.CrdbTestBuild {
 				panic(errors.AssertionFailedf("unknown processor core"))
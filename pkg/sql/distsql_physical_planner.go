@@ -54,6 +54,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlinstance"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/sql/vecindex/vecstore"
+	"github.com/cockroachdb/cockroach/pkg/util/admission"
 	"github.com/cockroachdb/cockroach/pkg/util/buildutil"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -504,6 +505,10 @@ type PlanningCtx struct {
 	// OverridePlannerExecMon, if set, will be used instead of the
 	// Planner.ExecMon() as the parent monitor for the DistSQL flow.
 	OverridePlannerExecMon *mon.BytesMonitor
+
+	// numRuntimeFilters is the number of runtime filters planned so far. It is
+	// used to assign unique IDs to the runtime filters of the flows.
+	numRuntimeFilters int32
 }
 
 var _ physicalplan.ExprContext = &PlanningCtx{}
@@ -1810,10 +1815,36 @@ var localScansConcurrencyLimit = settings.RegisterIntSetting(
 	settings.NonNegativeInt,
 )
 
+// maxLocalScansConcurrency is the maximum number of TableReaders that a single
+// scan in a local plan is split into.
+const maxLocalScansConcurrency = 64
+
+// localScanParallelism returns the number of partitions into which the scan
+// described by info should be split by range boundaries if the plan is local,
+// as determined by the parallelism session variable. 0 is returned if the scan
+// should only be split by leaseholder. This is also the case for tables with
+// multiple column families, whose spans might need to be adjusted so that they
+// don't split rows, and when work is waiting for CPU time tokens in admission
+// control, since additional concurrency would only compete with it.
+func localScanParallelism(
+	ctx context.Context, planCtx *PlanningCtx, info *tableReaderPlanningInfo,
+) int {
+	sd := planCtx.ExtendedEvalCtx.SessionData()
+	if sd.Parallelism <= 1 || info.desc.NumFamilies() > 1 {
+		return 0
+	}
+	if admission.SQLCPUHandleFromContext(ctx).HasWaitingWork() {
+		log.VEventf(ctx, 2, "not splitting local scan by range since work is waiting for CPU")
+		return 0
+	}
+	return int(sd.Parallelism)
+}
+
 // maybeParallelizeLocalScans check whether we are planning such a TableReader
 // for the local flow that would benefit (and is safe) to parallelize.
+// parallelism is the result of localScanParallelism.
 func (dsp *DistSQLPlanner) maybeParallelizeLocalScans(
-	ctx context.Context, planCtx *PlanningCtx, info *tableReaderPlanningInfo,
+	ctx context.Context, planCtx *PlanningCtx, info *tableReaderPlanningInfo, parallelism int,
 ) (spanPartitions []SpanPartition, parallelizeLocal bool) {
 	// For local plans, if:
 	// - there is no required ordering,
@@ -1821,7 +1852,10 @@ func (dsp *DistSQLPlanner) maybeParallelizeLocalScans(
 	// - the parallelization of scans in local flows is allowed,
 	// - there is still quota for running more parallel local TableReaders,
 	// then we will split all spans according to the leaseholder boundaries and
-	// will create a separate TableReader for each node.
+	// will create a separate TableReader for each node. If parallelism is
+	// positive, we instead split the spans according to the range boundaries
+	// into at most parallelism partitions, which allows using multiple
+	// TableReaders even when all leaseholders are on the same node.
 	sd := planCtx.ExtendedEvalCtx.SessionData()
 	// If we have locality optimized search enabled and we won't use the
 	// vectorized engine, using the parallel scans might actually be
@@ -1833,6 +1867,7 @@ func (dsp *DistSQLPlanner) maybeParallelizeLocalScans(
 		info.parallelize &&
 		planCtx.parallelizeScansIfLocal &&
 		!prohibitParallelScans &&
+		sd.Parallelism != 1 &&
 		dsp.parallelLocalScansSem.ApproximateQuota() > 0 &&
 		planCtx.spanIter != nil { // This condition can only be false in tests.
 		// Do a quick check whether we will touch at least two ranges. If we
@@ -1860,16 +1895,20 @@ func (dsp *DistSQLPlanner) maybeParallelizeLocalScans(
 			return spanPartitions, parallelizeLocal
 		}
 		parallelizeLocal = true
-		// Temporarily unset isLocal so that PartitionSpans divides all spans
-		// according to the respective leaseholders.
-		planCtx.isLocal = false
-		bound := PartitionSpansBoundDefault
-		if info.desc.NumFamilies() > 1 {
-			bound = PartitionSpansBoundCFWithinRow
-		}
 		var err error
-		spanPartitions, err = dsp.PartitionSpans(ctx, planCtx, info.spans, bound)
-		planCtx.isLocal = true
+		if parallelism > 0 {
+			spanPartitions, err = dsp.partitionSpansByRange(ctx, planCtx, info.spans, parallelism)
+		} else {
+			// Temporarily unset isLocal so that PartitionSpans divides all
+			// spans according to the respective leaseholders.
+			planCtx.isLocal = false
+			bound := PartitionSpansBoundDefault
+			if info.desc.NumFamilies() > 1 {
+				bound = PartitionSpansBoundCFWithinRow
+			}
+			spanPartitions, err = dsp.PartitionSpans(ctx, planCtx, info.spans, bound)
+			planCtx.isLocal = true
+		}
 		if err != nil {
 			// For some reason we couldn't partition the spans - fallback to
 			// having a single TableReader.
@@ -1881,20 +1920,20 @@ func (dsp *DistSQLPlanner) maybeParallelizeLocalScans(
 			spanPartitions[i].SQLInstanceID = dsp.gatewaySQLInstanceID
 		}
 		if len(spanPartitions) > 1 {
-			// We're touching ranges that have leaseholders on multiple nodes,
-			// so it'd be beneficial to parallelize such a scan.
+			// We're touching ranges that have leaseholders on multiple nodes
+			// (or multiple ranges, if we split by range boundaries), so it'd
+			// be beneficial to parallelize such a scan.
 			//
 			// Determine the desired concurrency. The concurrency is limited by
-			// the number of partitions as well as maxConcurrency constant (the
-			// upper bound). We then try acquiring the quota for all additional
-			// goroutines, and if the quota isn't available, we reduce the
-			// proposed concurrency by 1. If in the end we didn't manage to
-			// acquire the quota even for a single additional goroutine, we
-			// won't have parallel TableReaders.
-			const maxConcurrency = 64
+			// the number of partitions as well as maxLocalScansConcurrency
+			// constant (the upper bound). We then try acquiring the quota for
+			// all additional goroutines, and if the quota isn't available, we
+			// reduce the proposed concurrency by 1. If in the end we didn't
+			// manage to acquire the quota even for a single additional
+			// goroutine, we won't have parallel TableReaders.
 			actualConcurrency := len(spanPartitions)
-			if actualConcurrency > maxConcurrency {
-				actualConcurrency = maxConcurrency
+			if actualConcurrency > maxLocalScansConcurrency {
+				actualConcurrency = maxLocalScansConcurrency
 			}
 			if quota := int(dsp.parallelLocalScansSem.ApproximateQuota()); actualConcurrency > quota {
 				actualConcurrency = quota
@@ -1941,18 +1980,89 @@ func (dsp *DistSQLPlanner) maybeParallelizeLocalScans(
 	return spanPartitions, parallelizeLocal
 }
 
+// partitionSpansByRange splits the given spans at the range boundaries and
+// assigns the resulting pieces to at most n partitions, all of which are
+// planned on the gateway. Consecutive pieces are assigned to the same
+// partition, so that each partition scans a contiguous part of the keyspace.
+// Range boundaries never fall within a SQL row, so the pieces can be scanned
+// independently.
+func (dsp *DistSQLPlanner) partitionSpansByRange(
+	ctx context.Context, planCtx *PlanningCtx, spans roachpb.Spans, n int,
+) ([]SpanPartition, error) {
+	it := planCtx.spanIter
+	var pieces roachpb.Spans
+	for _, span := range spans {
+		if len(span.EndKey) == 0 {
+			// A point lookup always falls within a single range.
+			pieces = append(pieces, span)
+			continue
+		}
+		rSpan, err := keys.SpanAddr(span)
+		if err != nil {
+			return nil, err
+		}
+		lastKey := rSpan.Key
+		for it.Seek(ctx, span, kvcoord.Ascending); ; it.Next(ctx) {
+			if !it.Valid() {
+				return nil, it.Error()
+			}
+			// Limit the end key to the end of the span we are resolving.
+			endKey := it.Desc().EndKey
+			if rSpan.EndKey.Less(endKey) {
+				endKey = rSpan.EndKey
+			}
+			pieces = append(pieces, roachpb.Span{Key: lastKey.AsRawKey(), EndKey: endKey.AsRawKey()})
+			if !it.NeedAnother() {
+				break
+			}
+			lastKey = endKey
+		}
+	}
+	if n > len(pieces) {
+		n = len(pieces)
+	}
+	partitions := make([]SpanPartition, n)
+	for i := range partitions {
+		partitions[i].SQLInstanceID = dsp.gatewaySQLInstanceID
+	}
+	for i, sp := range pieces {
+		p := &partitions[i*n/len(pieces)]
+		p.Spans = append(p.Spans, sp)
+	}
+	return partitions, nil
+}
+
 func (dsp *DistSQLPlanner) planTableReaders(
 	ctx context.Context, planCtx *PlanningCtx, p *PhysicalPlan, info *tableReaderPlanningInfo,
 ) error {
 	var (
 		spanPartitions         []SpanPartition
 		parallelizeLocal       bool
+		localParallelism       int
 		ignoreMisplannedRanges bool
 		err                    error
 	)
 	sd := planCtx.ExtendedEvalCtx.SessionData()
-	if planCtx.isLocal {
-		spanPartitions, parallelizeLocal = dsp.maybeParallelizeLocalScans(ctx, planCtx, info)
+	if info.spec.FetchSpec.IsColumnarIndex {
+		// The deltas of a columnar index must be read along with the blocks
+		// that they apply to, which might live in other ranges, so a columnar
+		// index is always read by a single TableReader. The scan is neither
+		// distributed nor split into parallel local scans, however large the
+		// index is.
+		//
+		// TODO(sql-queries): split the spans at block boundaries, which would
+		// allow reading each partition with its own TableReader.
+		sqlInstanceID := dsp.gatewaySQLInstanceID
+		if !planCtx.isLocal {
+			if sqlInstanceID, err = dsp.getInstanceIDForScan(ctx, planCtx, info.spans, info.reverse); err != nil {
+				return err
+			}
+		}
+		spanPartitions = []SpanPartition{{SQLInstanceID: sqlInstanceID, Spans: info.spans}}
+		ignoreMisplannedRanges = true
+	} else if planCtx.isLocal {
+		localParallelism = localScanParallelism(ctx, planCtx, info)
+		spanPartitions, parallelizeLocal = dsp.maybeParallelizeLocalScans(ctx, planCtx, info, localParallelism)
 	} else if info.post.Limit == 0 && (info.spec.LimitHint == 0 || !sd.DistSQLPreventPartitioningSoftLimitedScans) {
 		// No limits - plan all table readers where their data live.
 		bound := PartitionSpansBoundDefault
@@ -2039,9 +2149,14 @@ func (dsp *DistSQLPlanner) planTableReaders(
 	p.PlanToStreamColMap = identityMap(make([]int, len(typs)), len(typs))
 	p.SetMergeOrdering(dsp.convertOrdering(info.reqOrdering, p.PlanToStreamColMap))
 
-	if parallelizeLocal {
+	if parallelizeLocal && localParallelism == 0 {
 		// If we planned multiple table readers, we need to merge the streams
-		// into one.
+		// into one. However, if the scan was split according to the
+		// parallelism session variable, we keep the streams so that the
+		// stages above it (e.g. aggregations and sorts) are planned on each
+		// stream and run concurrently too. The streams are merged by the
+		// first stage that requires a single stream, or at the end of the
+		// plan.
 		p.AddSingleGroupStage(
 			ctx, dsp.gatewaySQLInstanceID, execinfrapb.ProcessorCoreUnion{Noop: &execinfrapb.NoopCoreSpec{}},
 			execinfrapb.PostProcessSpec{}, p.GetResultTypes(), info.finalizeLastStageCb,
@@ -2384,12 +2499,15 @@ func (dsp *DistSQLPlanner) planAggregators(
 
 	// We either have a local stage on each stream followed by a final stage, or
 	// just a final stage. We only use a local stage if:
-	//  - the previous stage is distributed on multiple nodes, and
+	//  - the previous stage is distributed on multiple nodes, or it consists
+	//    of multiple streams on the gateway in a local plan (which is the case
+	//    when local scans are split according to the parallelism session
+	//    variable), and
 	//  - all aggregation functions support it, and
 	//  - no function is performing distinct aggregation.
 	//  TODO(radu): we could relax this by splitting the aggregation into two
 	//  different paths and joining on the results.
-	multiStage := prevStageNode == 0
+	multiStage := prevStageNode == 0 || (planCtx.isLocal && len(p.ResultRouters) > 1)
 	if multiStage {
 		for _, e := range info.aggregations {
 			if e.Distinct {
@@ -2798,9 +2916,14 @@ func (dsp *DistSQLPlanner) planAggregators(
 			}
 		}
 
-		// We have multiple streams, so we definitely have a processor planned
-		// on a remote node.
-		stageID := p.NewStage(true /* containsRemoteProcessor */, info.allowPartialDistribution)
+		// We have multiple streams, so we have a processor planned on a
+		// remote node unless all streams are on the gateway, in which case
+		// the streams are hash-routed to final aggregators running
+		// concurrently on the gateway.
+		stageID := p.NewStage(
+			prevStageNode != dsp.gatewaySQLInstanceID, /* containsRemoteProcessor */
+			info.allowPartialDistribution,
+		)
 
 		// We have one final stage processor for each result router. This is a
 		// somewhat arbitrary decision; we could have a different number of nodes
@@ -3575,6 +3698,7 @@ func (dsp *DistSQLPlanner) planJoiners(
 		}
 	}
 
+	joinersStart := physicalplan.ProcessorIdx(len(p.Processors))
 	p.AddJoinStage(
 		ctx, sqlInstances, info.makeCoreSpec(), info.post,
 		info.leftEqCols, info.rightEqCols,
@@ -3582,6 +3706,16 @@ func (dsp *DistSQLPlanner) planJoiners(
 		info.leftMergeOrd, info.rightMergeOrd,
 		leftRouters, rightRouters, info.joinResultTypes, info.finalizeLastStageCb,
 	)
+	if len(info.leftEqCols) != 0 {
+		joiners := make([]physicalplan.ProcessorIdx, len(sqlInstances))
+		for i := range joiners {
+			joiners[i] = joinersStart + physicalplan.ProcessorIdx(i)
+		}
+		dsp.maybePlanRuntimeFilter(
+			planCtx, p, leftRouters, info.leftPlan.GetResultTypes(),
+			info.rightPlan.GetResultTypes(), joiners,
+		)
+	}
 
 	p.PlanToStreamColMap = info.joinToStreamColMap
 
@@ -3675,6 +3809,9 @@ func (dsp *DistSQLPlanner) createPhysPlanForPlanNode(
 			return nil, err
 		}
 
+		if _, ok := n.input.(*scanNode); ok {
+			maybeAddColumnarBlockFilters(plan, n.filter)
+		}
 		if err = plan.AddFilter(ctx, n.filter, planCtx, plan.PlanToStreamColMap, planCtx.associateWithPlanNode(n)); err != nil {
 			return nil, err
 		}
@@ -4131,7 +4268,10 @@ func (dsp *DistSQLPlanner) addDistinctProcessors(
 
 	// Add distinct processors local to each existing current result processor.
 	plan.AddNoGroupingStage(distinctSpec, execinfrapb.PostProcessSpec{}, plan.GetResultTypes(), plan.MergeOrdering, finalizeLastStageCb)
-	if !plan.IsLastStageDistributed() {
+	// A local plan can still have multiple streams on the gateway when local
+	// scans are split according to the parallelism session variable, in which
+	// case we need a final distinct stage too.
+	if !plan.IsLastStageDistributed() && len(plan.ResultRouters) == 1 {
 		return
 	}
 
@@ -0,0 +1,108 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+)
+
+// maybeAddColumnarBlockFilters derives block filters from the given filter
+// expression and adds them to the TableReader of p if p is a scan of a
+// columnar index. The filters let the scan skip the blocks whose minimum and
+// maximum values show that none of their rows can pass the filter. Since the
+// filter is still evaluated on every row that is scanned, it is only necessary
+// for the block filters to be implied by the filter.
+//
+// filter must refer to the columns of the plan via indexed vars.
+func maybeAddColumnarBlockFilters(p *PhysicalPlan, filter tree.TypedExpr) {
+	if len(p.ResultRouters) != 1 {
+		return
+	}
+	proc := &p.Processors[p.ResultRouters[0]]
+	tr := proc.Spec.Core.TableReader
+	if tr == nil || !tr.FetchSpec.IsColumnarIndex ||
+		proc.Spec.Post.Projection || len(proc.Spec.Post.RenderExprs) > 0 {
+		return
+	}
+	var visit func(expr tree.TypedExpr)
+	visit = func(expr tree.TypedExpr) {
+		switch t := expr.(type) {
+		case *tree.AndExpr:
+			visit(t.TypedLeft())
+			visit(t.TypedRight())
+		case *tree.ComparisonExpr:
+			if f, ok := makeColumnarBlockFilter(p, tr, t); ok {
+				tr.ColumnarBlockFilters = append(tr.ColumnarBlockFilters, f)
+			}
+		}
+	}
+	visit(filter)
+}
+
+// makeColumnarBlockFilter converts a comparison between a column of the
+// columnar index scanned by tr and a constant into a block filter.
+func makeColumnarBlockFilter(
+	p *PhysicalPlan, tr *execinfrapb.TableReaderSpec, cmp *tree.ComparisonExpr,
+) (execinfrapb.ColumnarBlockFilter, bool) {
+	op := cmp.Operator.Symbol
+	left, right := cmp.TypedLeft(), cmp.TypedRight()
+	if _, ok := left.(tree.Datum); ok {
+		// Normalize the comparison so that the constant is on the right.
+		left, right = right, left
+		switch op {
+		case treecmp.LT:
+			op = treecmp.GT
+		case treecmp.LE:
+			op = treecmp.GE
+		case treecmp.GT:
+			op = treecmp.LT
+		case treecmp.GE:
+			op = treecmp.LE
+		}
+	}
+	ivar, ok := left.(*tree.IndexedVar)
+	if !ok {
+		return execinfrapb.ColumnarBlockFilter{}, false
+	}
+	d, ok := right.(tree.Datum)
+	if !ok || d == tree.DNull {
+		return execinfrapb.ColumnarBlockFilter{}, false
+	}
+	if ivar.Idx >= len(p.PlanToStreamColMap) {
+		return execinfrapb.ColumnarBlockFilter{}, false
+	}
+	streamCol := p.PlanToStreamColMap[ivar.Idx]
+	if streamCol < 0 || streamCol >= len(tr.FetchSpec.FetchedColumns) {
+		return execinfrapb.ColumnarBlockFilter{}, false
+	}
+	col := &tr.FetchSpec.FetchedColumns[streamCol]
+	// Blocks store the bounds of their columns as datums of the column's type,
+	// so the constant must have the same type in order for the comparison of
+	// the bounds to match the comparison in the filter.
+	if !d.ResolvedType().Identical(col.Type) {
+		return execinfrapb.ColumnarBlockFilter{}, false
+	}
+	enc, err := valueside.Encode(nil /* appendTo */, valueside.NoColumnID, d)
+	if err != nil {
+		return execinfrapb.ColumnarBlockFilter{}, false
+	}
+	f := execinfrapb.ColumnarBlockFilter{ColumnID: col.ColumnID}
+	switch op {
+	case treecmp.EQ:
+		f.Lower, f.LowerInclusive = enc, true
+		f.Upper, f.UpperInclusive = enc, true
+	case treecmp.LT, treecmp.LE:
+		f.Upper, f.UpperInclusive = enc, op == treecmp.LE
+	case treecmp.GT, treecmp.GE:
+		f.Lower, f.LowerInclusive = enc, op == treecmp.GE
+	default:
+		return execinfrapb.ColumnarBlockFilter{}, false
+	}
+	return f, true
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"math/bits"
+	"slices"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+var runtimeFiltersEnabled = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"sql.distsql.runtime_filters.enabled",
+	"if enabled, the hash joiners of distributed hash joins build bloom and "+
+		"min/max filters from their right inputs that are used by the table readers "+
+		"of their left inputs to skip the rows that cannot match",
+	false,
+)
+
+var runtimeFilterBloomFilterSize = settings.RegisterByteSizeSetting(
+	settings.ApplicationLevel,
+	"sql.distsql.runtime_filters.bloom_filter_size",
+	"size of the bloom filter built by each hash joiner for a runtime filter; "+
+		"it is rounded down to a power of two",
+	64<<10, /* 64 KiB */
+	settings.IntInRange(0, 64<<20),
+)
+
+// maybePlanRuntimeFilter sets up a runtime filter for the hash joiners of a
+// join stage if the rows of the left input are read by table readers and sent
+// over the network to the hash joiners. The hash joiners build parts of the
+// filter from their right inputs, and the table readers skip the rows that
+// don't pass the combined filter. See colexecjoin.RuntimeFilterBuilder for
+// details.
+func (dsp *DistSQLPlanner) maybePlanRuntimeFilter(
+	planCtx *PlanningCtx,
+	p *PhysicalPlan,
+	leftRouters []physicalplan.ProcessorIdx,
+	leftTypes, rightTypes []*types.T,
+	joiners []physicalplan.ProcessorIdx,
+) {
+	evalCtx := planCtx.ExtendedEvalCtx
+	if evalCtx == nil || evalCtx.Settings == nil || !runtimeFiltersEnabled.Get(&evalCtx.Settings.SV) ||
+		evalCtx.SessionData().VectorizeMode == sessiondatapb.VectorizeOff {
+		return
+	}
+	hj := p.Processors[joiners[0]].Spec.Core.HashJoiner
+	if hj == nil || len(hj.LeftEqColumns) == 0 {
+		return
+	}
+	switch hj.Type {
+	case descpb.InnerJoin, descpb.RightOuterJoin, descpb.LeftSemiJoin, descpb.RightSemiJoin:
+		// These join types never output unmatched rows of the left input.
+	default:
+		return
+	}
+	if !hj.OnExpr.Empty() && hj.Type != descpb.InnerJoin {
+		// These joins are not supported by the vectorized engine, so the hash
+		// joiner wouldn't build the filter.
+		return
+	}
+	for i, left := range hj.LeftEqColumns {
+		if !leftTypes[left].Identical(rightTypes[hj.RightEqColumns[i]]) {
+			// Equal values of different types might hash differently.
+			return
+		}
+	}
+	// Only plan a filter if the left rows are sent over the network.
+	remote := len(joiners) > 1
+	localConsumers := make(map[base.SQLInstanceID]int32)
+	for _, idx := range leftRouters {
+		proc := &p.Processors[idx]
+		if proc.Spec.Core.TableReader == nil {
+			return
+		}
+		remote = remote || proc.SQLInstanceID != p.Processors[joiners[0]].SQLInstanceID
+		localConsumers[proc.SQLInstanceID]++
+	}
+	if !remote {
+		return
+	}
+
+	planCtx.numRuntimeFilters++
+	filterID := planCtx.numRuntimeFilters
+	var bloomFilterBits uint32
+	if size := runtimeFilterBloomFilterSize.Get(&evalCtx.Settings.SV); size > 0 {
+		bloomFilterBits = 1 << (bits.Len64(uint64(size)*8) - 1)
+	}
+	producer := &execinfrapb.RuntimeFilterProducerSpec{
+		FilterID:        filterID,
+		BloomFilterBits: bloomFilterBits,
+	}
+	for _, idx := range leftRouters {
+		id := p.Processors[idx].SQLInstanceID
+		if !slices.Contains(producer.TargetSQLInstanceIDs, id) {
+			producer.TargetSQLInstanceIDs = append(producer.TargetSQLInstanceIDs, id)
+		}
+	}
+	for _, idx := range leftRouters {
+		proc := &p.Processors[idx]
+		tr := proc.Spec.Core.TableReader
+		tr.RuntimeFilters = append(tr.RuntimeFilters, execinfrapb.RuntimeFilterConsumerSpec{
+			FilterID:          filterID,
+			NumProducers:      int32(len(joiners)),
+			NumLocalConsumers: localConsumers[proc.SQLInstanceID],
+			KeyColumns:        hj.LeftEqColumns,
+		})
+	}
+	// All hash joiners of the stage share the same spec.
+	hjSpec := *hj
+	hjSpec.RuntimeFilter = producer
+	for _, idx := range joiners {
+		p.Processors[idx].Spec.Core.HashJoiner = &hjSpec
+	}
+}
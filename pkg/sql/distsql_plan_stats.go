@@ -42,8 +42,11 @@ type requestedStat struct {
 	columns             []descpb.ColumnID
 	histogram           bool
 	histogramMaxBuckets uint32
-	name                string
-	inverted            bool
+	// mcvMaxCount is the maximum number of most common values collected for a
+	// multi-column statistic, or zero if they are not collected.
+	mcvMaxCount uint32
+	name        string
+	inverted    bool
 }
 
 // histogramSamples is the number of sample rows to be collected for histogram
@@ -189,7 +192,7 @@ func (dsp *DistSQLPlanner) createAndAttachSamplers(
 	// For partial statistics this loop should only iterate once since we only
 	// support one reqStat at a time.
 	for _, s := range reqStats {
-		if s.histogram {
+		if s.histogram || s.mcvMaxCount > 0 {
 			var histogramSamplesCount uint32
 			if tableSampleCount, ok := desc.HistogramSamplesCount(); ok {
 				histogramSamplesCount = tableSampleCount
@@ -682,6 +685,7 @@ func (dsp *DistSQLPlanner) createStatsPlan(
 		spec := execinfrapb.SketchSpec{
 			GenerateHistogram:   s.histogram,
 			HistogramMaxBuckets: s.histogramMaxBuckets,
+			MCVMaxCount:         s.mcvMaxCount,
 			Columns:             make([]uint32, len(s.columns)),
 			StatName:            s.name,
 		}
@@ -758,6 +762,7 @@ func (dsp *DistSQLPlanner) createPlanForCreateStats(
 	histogramCollectionEnabled := stats.HistogramClusterMode.Get(&dsp.st.SV)
 	tableDesc := tabledesc.NewBuilder(&details.Table).BuildImmutableTable()
 	defaultHistogramBuckets := stats.GetDefaultHistogramBuckets(&dsp.st.SV, tableDesc)
+	mcvMaxCount := uint32(stats.MultiColumnMCVsMaxCount.Get(&dsp.st.SV))
 	for i := 0; i < len(reqStats); i++ {
 		histogram := details.ColumnStats[i].HasHistogram && histogramCollectionEnabled
 		var histogramMaxBuckets = defaultHistogramBuckets
@@ -774,6 +779,12 @@ func (dsp *DistSQLPlanner) createPlanForCreateStats(
 			name:                details.Name,
 			inverted:            details.ColumnStats[i].Inverted,
 		}
+		// Most common values are only collected for full multi-column
+		// statistics.
+		if len(details.ColumnStats[i].ColumnIDs) > 1 && !details.ColumnStats[i].Inverted &&
+			!details.UsingExtremes && details.WhereClause == "" {
+			reqStats[i].mcvMaxCount = mcvMaxCount
+		}
 	}
 
 	if len(reqStats) == 0 {
@@ -2088,6 +2088,9 @@ func (dsp *DistSQLPlanner) PlanAndRunAll(
 		) {
 			return recv.commErr
 		}
+		if planCtx.getPortalPauseInfo() == nil {
+			planner.maybeReoptimizeMainQuery(ctx)
+		}
 	}
 	recv.discardRows = planner.instrumentation.ShouldDiscardRows()
 	func() {
@@ -2635,7 +2638,19 @@ func (dsp *DistSQLPlanner) PlanAndRunPostQueries(
 				// No rows were actually modified.
 				continue
 			}
-			if log.ExpensiveLogEnabled(ctx, 2) {
+			if trigger.IncrementalView != nil {
+				log.VEventf(ctx, 2, "executing maintenance of incremental view %s",
+					trigger.IncrementalView.Name())
+
+				// The view maintenance reads the base tables of the view, so we place
+				// a sequence point before it to observe the writes of the mutation.
+				// As with cascades, the external read timestamp is not allowed to
+				// advance.
+				if err := planner.Txn().Step(ctx, false /* allowReadTimestampStep */); err != nil {
+					recv.SetError(err)
+					return false
+				}
+			} else if log.ExpensiveLogEnabled(ctx, 2) {
 				names := make([]string, len(trigger.Triggers))
 				for j := range trigger.Triggers {
 					names[j] = string(trigger.Triggers[j].Name())
@@ -41,6 +41,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed/rangefeedcache"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tenantstoragequota"
 	"github.com/cockroachdb/cockroach/pkg/multitenant"
 	"github.com/cockroachdb/cockroach/pkg/multitenant/tenantcapabilities"
 	clustermetricutils "github.com/cockroachdb/cockroach/pkg/obs/clustermetrics/utils"
@@ -82,6 +83,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
 	plpgsqlparser "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
+	"github.com/cockroachdb/cockroach/pkg/sql/resourcegroups"
+	"github.com/cockroachdb/cockroach/pkg/sql/resultcache"
 	"github.com/cockroachdb/cockroach/pkg/sql/rolemembershipcache"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
@@ -1761,6 +1764,8 @@ type ExecutorConfig struct {
 	StatsRefresher      *stats.Refresher
 	QueryCache          *querycache.C
 	StatementHintsCache *hints.StatementHintsCache
+	ResourceGroupsCache *resourcegroups.Cache
+	ResultCache         *resultcache.Cache
 	VecIndexManager     *vecindex.Manager
 
 	SchemaChangerMetrics *SchemaChangerMetrics
@@ -1899,6 +1904,10 @@ type ExecutorConfig struct {
 	// access stores on this node.
 	KVStoresIterator kvserverbase.StoresIterator
 
+	// TenantStorageQuota reports the storage usage that tenant storage quotas
+	// are enforced against. It is nil for secondary tenants.
+	TenantStorageQuota *tenantstoragequota.Tracker
+
 	// InspectzServer is used to power various crdb_internal vtables, exposing
 	// the equivalent of /inspectz but through SQL.
 	InspectzServer inspectzpb.InspectzServer
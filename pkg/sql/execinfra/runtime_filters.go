@@ -0,0 +1,160 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package execinfra
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// RuntimeFilterWaitTimeout is the maximum amount of time that a table reader
+// waits for the parts of a runtime filter before it gives up on the filter.
+var RuntimeFilterWaitTimeout = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.distsql.runtime_filters.wait_timeout",
+	"maximum amount of time that a table reader waits for the runtime filters "+
+		"built by hash joiners before it outputs its rows without filtering them",
+	10*time.Second,
+	settings.NonNegativeDuration,
+)
+
+// runtimeFilterTTL is the amount of time after which the parts of a runtime
+// filter that were never released by their consumers are removed from the
+// registry. This can happen when a part arrives after the consumers gave up
+// waiting for it, or when the consuming flow failed to be set up.
+const runtimeFilterTTL = 10 * time.Minute
+
+type runtimeFilterKey struct {
+	flowID   execinfrapb.FlowID
+	filterID int32
+}
+
+type runtimeFilterEntry struct {
+	created time.Time
+	parts   []*execinfrapb.RuntimeFilter
+	// released is the number of consumers that no longer need the filter.
+	released int32
+	// changed is closed (and replaced) whenever a part is added to the entry.
+	changed chan struct{}
+}
+
+// RuntimeFilterRegistry stores the parts of the runtime filters built by the
+// hash joiners of the flows until the table readers running on this node
+// consume them. The parts are pushed either locally or via the
+// PushRuntimeFilter RPC, and they may arrive before the consuming flow has
+// been set up.
+type RuntimeFilterRegistry struct {
+	mu struct {
+		syncutil.Mutex
+		filters   map[runtimeFilterKey]*runtimeFilterEntry
+		lastSweep time.Time
+	}
+}
+
+// NewRuntimeFilterRegistry creates a new RuntimeFilterRegistry.
+func NewRuntimeFilterRegistry() *RuntimeFilterRegistry {
+	r := &RuntimeFilterRegistry{}
+	r.mu.filters = make(map[runtimeFilterKey]*runtimeFilterEntry)
+	return r
+}
+
+// getLocked returns the entry for the given key, creating it if it doesn't
+// exist. r.mu must be held.
+func (r *RuntimeFilterRegistry) getLocked(key runtimeFilterKey) *runtimeFilterEntry {
+	e, ok := r.mu.filters[key]
+	if !ok {
+		e = &runtimeFilterEntry{created: timeutil.Now(), changed: make(chan struct{})}
+		r.mu.filters[key] = e
+	}
+	return e
+}
+
+// sweepLocked removes the entries that are older than runtimeFilterTTL. r.mu
+// must be held.
+func (r *RuntimeFilterRegistry) sweepLocked() {
+	now := timeutil.Now()
+	if now.Sub(r.mu.lastSweep) < time.Minute {
+		return
+	}
+	r.mu.lastSweep = now
+	for key, e := range r.mu.filters {
+		if now.Sub(e.created) > runtimeFilterTTL {
+			delete(r.mu.filters, key)
+		}
+	}
+}
+
+// Push adds a part of the given runtime filter of the given flow.
+func (r *RuntimeFilterRegistry) Push(
+	flowID execinfrapb.FlowID, filterID int32, part *execinfrapb.RuntimeFilter,
+) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sweepLocked()
+	e := r.getLocked(runtimeFilterKey{flowID: flowID, filterID: filterID})
+	e.parts = append(e.parts, part)
+	close(e.changed)
+	e.changed = make(chan struct{})
+}
+
+// Wait blocks until numParts parts of the given runtime filter of the given
+// flow have been pushed, and returns them. If the parts don't arrive within
+// the timeout, nil is returned, in which case the filter should not be
+// applied.
+func (r *RuntimeFilterRegistry) Wait(
+	ctx context.Context,
+	flowID execinfrapb.FlowID,
+	filterID int32,
+	numParts int,
+	timeout time.Duration,
+) ([]*execinfrapb.RuntimeFilter, error) {
+	key := runtimeFilterKey{flowID: flowID, filterID: filterID}
+	var timer timeutil.Timer
+	defer timer.Stop()
+	timer.Reset(timeout)
+	for {
+		r.mu.Lock()
+		e := r.getLocked(key)
+		if len(e.parts) >= numParts {
+			parts := e.parts
+			r.mu.Unlock()
+			return parts, nil
+		}
+		changed := e.changed
+		r.mu.Unlock()
+		select {
+		case <-changed:
+		case <-timer.C:
+			return nil, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Release notifies the registry that a consumer of the given runtime filter no
+// longer needs it. The filter is removed once all numConsumers consumers on
+// this node have released it.
+func (r *RuntimeFilterRegistry) Release(
+	flowID execinfrapb.FlowID, filterID int32, numConsumers int32,
+) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := runtimeFilterKey{flowID: flowID, filterID: filterID}
+	e, ok := r.mu.filters[key]
+	if !ok {
+		return
+	}
+	e.released++
+	if e.released >= numConsumers {
+		delete(r.mu.filters, key)
+	}
+}
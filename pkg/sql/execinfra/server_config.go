@@ -29,6 +29,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/rpc"
 	"github.com/cockroachdb/cockroach/pkg/rpc/nodedialer"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
@@ -161,6 +162,10 @@ type ServerConfig struct {
 	// Dialer for communication between SQL instances.
 	SQLInstanceDialer *nodedialer.Dialer
 
+	// RuntimeFilters stores the parts of the runtime filters built by hash
+	// joiners until the table readers running on this node consume them.
+	RuntimeFilters *RuntimeFilterRegistry
+
 	ExternalStorage        cloud.ExternalStorageFactory
 	ExternalStorageFromURI cloud.ExternalStorageFromURIFactory
 
@@ -225,6 +230,10 @@ type ServerConfig struct {
 	// for operations on a vector index. It's stored as an `interface{}` due to
 	// package dependency cycles
 	VecIndexManager interface{}
+
+	// ColumnarIndexCompactor is notified about the deltas observed by scans of
+	// columnar indexes, so that they can be compacted into blocks.
+	ColumnarIndexCompactor ColumnarIndexCompactor
 }
 
 // RuntimeStats is an interface through which the rowexec layer can get
@@ -235,6 +244,13 @@ type RuntimeStats interface {
 	GetCPUCombinedPercentNorm() float64
 }
 
+// ColumnarIndexCompactor compacts the deltas of columnar indexes into blocks.
+type ColumnarIndexCompactor interface {
+	// NotifyDeltas reports that a scan of the given columnar index observed the
+	// given number of deltas.
+	NotifyDeltas(tableID descpb.ID, indexID descpb.IndexID, numDeltas int)
+}
+
 // TestingKnobs are the testing knobs.
 type TestingKnobs struct {
 	// RunBeforeBackfillChunk is called before executing each chunk of a
@@ -96,3 +96,8 @@ func (m *GenerativeSplitAndScatterSpec) User() username.SQLUsername {
 func (m *CompactBackupsSpec) User() username.SQLUsername {
 	return m.UserProto.Decode()
 }
+
+// User accesses the user field.
+func (m *CopyBackupSpec) User() username.SQLUsername {
+	return m.UserProto.Decode()
+}
@@ -223,6 +223,12 @@ func (tr *TableReaderSpec) summary() (string, []string) {
 		))
 	}
 
+	for _, rf := range tr.RuntimeFilters {
+		details = append(details, fmt.Sprintf(
+			"Runtime filter %d on %s", rf.FilterID, colListStr(rf.KeyColumns),
+		))
+	}
+
 	return "TableReader", details
 }
 
@@ -284,6 +290,9 @@ func (hj *HashJoinerSpec) summary() (string, []string) {
 	if !hj.OnExpr.Empty() {
 		details = append(details, fmt.Sprintf("ON %s", hj.OnExpr))
 	}
+	if hj.RuntimeFilter != nil {
+		details = append(details, fmt.Sprintf("Builds runtime filter %d", hj.RuntimeFilter.FilterID))
+	}
 
 	return name, details
 }
@@ -846,6 +855,20 @@ func (m *TxnLDRDepResolverSpec) summary() (string, []string) {
 	return "TxnLDRDepResolver", nil
 }
 
+func (m *BackupTableReaderSpec) summary() (string, []string) {
+	details := []string{
+		fmt.Sprintf("%s: %d spans", m.Backup.Table.Name, len(m.Backup.Entries)),
+	}
+	if m.DiffFrom != nil {
+		details = append(details, fmt.Sprintf("diff from: %d spans", len(m.DiffFrom.Entries)))
+	}
+	return "BackupTableReader", details
+}
+
+func (m *CopyBackupSpec) summary() (string, []string) {
+	return "CopyBackup", []string{fmt.Sprintf("%d files", len(m.Files))}
+}
+
 type diagramCell struct {
 	Title   string   `json:"title"`
 	Details []string `json:"details"`
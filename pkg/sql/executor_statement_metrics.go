@@ -215,6 +215,7 @@ func (ex *connExecutor) recordStatementSummary(
 			LatencyRecorder(ex.statsCollector).
 			QueryLevelStats(stats.bytesRead, stats.rowsRead, stats.rowsWritten, stats.kvCPUTimeNanos.Nanoseconds()).
 			ExecStats(queryLevelStats).
+			WriteBufferStats(stats.writeBufferStats).
 			// TODO(mgartner): Use a slice of struct{uint64, uint64} instead of
 			// converting to strings.
 			Indexes(planner.instrumentation.indexesUsed.Strings()).
@@ -232,6 +233,16 @@ func (ex *connExecutor) recordStatementSummary(
 			b.AppliedStatementHints()
 		}
 
+		if flags.IsSet(planFlagReoptimized) {
+			b.Reoptimized()
+		}
+
+		if flags.IsSet(planFlagResultCacheHit) {
+			b.ResultCache(sqlstats.ResultCacheHit)
+		} else if flags.IsSet(planFlagResultCacheMiss) {
+			b.ResultCache(sqlstats.ResultCacheMiss)
+		}
+
 		if flags.IsSet(planFlagCanaryAndStableStatsDiffer) {
 			b.CanaryStatsRollout(planner.EvalContext().StatsRollout)
 		}
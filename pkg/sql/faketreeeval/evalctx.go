@@ -639,6 +639,13 @@ func (ep *DummyEvalPlanner) SetStatementHintEnabled(
 	return 0, nil
 }
 
+// ResolvePlanBaseline is part of the eval.Planner interface.
+func (ep *DummyEvalPlanner) ResolvePlanBaseline(
+	ctx context.Context, statementFingerprint string, optDatabase string, accept bool,
+) (bool, int64, error) {
+	return false, 0, nil
+}
+
 // ValidateSessionVariableHint is part of the eval.Planner interface.
 func (ep *DummyEvalPlanner) ValidateSessionVariableHint(
 	ctx context.Context, varName, varValue string, safeUpdates bool,
@@ -343,6 +343,7 @@ func NewFlowBase(
 		h := flowCtx.Txn.AdmissionHeader()
 		admissionInfo.Priority = admissionpb.WorkPriority(h.Priority)
 		admissionInfo.CreateTime = h.CreateTime
+		admissionInfo.ResourceGroupID = admissionpb.ResourceGroupID(h.ResourceGroupID)
 	}
 	return &FlowBase{
 		FlowCtx:               flowCtx,
@@ -776,6 +777,7 @@ func MakeCPUHandle(
 ) (context.Context, *admission.SQLCPUHandle, *admission.GoroutineCPUHandle, error) {
 	var priority admissionpb.WorkPriority
 	var createTime int64
+	var resourceGroupID admissionpb.ResourceGroupID
 	if txn == nil {
 		priority = admissionpb.NormalPri
 		createTime = timeutil.Now().UnixNano()
@@ -783,14 +785,16 @@ func MakeCPUHandle(
 		h := txn.AdmissionHeader()
 		priority = admissionpb.WorkPriority(h.Priority)
 		createTime = h.CreateTime
+		resourceGroupID = admissionpb.ResourceGroupID(h.ResourceGroupID)
 	}
 	cpuHandle := provider.GetHandle(admission.WorkInfo{
-		TenantID:      tenantID,
-		Priority:      priority,
-		CreateTime:    createTime,
-		WorkloadID:    workloadID,
-		AppNameID:     appNameID,
-		GatewayNodeID: gatewayNodeID,
+		TenantID:        tenantID,
+		ResourceGroupID: resourceGroupID,
+		Priority:        priority,
+		CreateTime:      createTime,
+		WorkloadID:      workloadID,
+		AppNameID:       appNameID,
+		GatewayNodeID:   gatewayNodeID,
 	}, atGateway)
 	newCtx := admission.ContextWithSQLCPUHandle(ctx, cpuHandle)
 	gh := cpuHandle.RegisterGoroutine()
@@ -96,6 +96,13 @@ func (ds *MockDistSQLServer) CancelDeadFlows(
 	return nil, nil
 }
 
+// PushRuntimeFilter is part of the DistSQLServer interface.
+func (ds *MockDistSQLServer) PushRuntimeFilter(
+	_ context.Context, req *execinfrapb.PushRuntimeFilterRequest,
+) (*execinfrapb.SimpleResponse, error) {
+	return nil, nil
+}
+
 // FlowStream is part of the DistSQLServer interface.
 func (ds *MockDistSQLServer) FlowStream(stream execinfrapb.DistSQL_FlowStreamServer) error {
 	donec := make(chan error)
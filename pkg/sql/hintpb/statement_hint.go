@@ -58,6 +58,10 @@ const (
 	// HintTypeSetVariable is used for hints that override a session variable
 	// for the duration of a single statement.
 	HintTypeSetVariable = "SET VARIABLE"
+	// HintTypePlanBaseline is used for the plan baselines that record the
+	// accepted plan of a statement fingerprint. They are not applied to
+	// statements.
+	HintTypePlanBaseline = "PLAN BASELINE"
 )
 
 // HintType returns the string representation of the type of the given hint,
@@ -68,6 +72,8 @@ func (hint *StatementHintUnion) HintType() string {
 		return HintTypeRewriteInlineHints
 	case *SessionVariableHint:
 		return HintTypeSetVariable
+	case *PlanBaseline:
+		return HintTypePlanBaseline
 	default:
 		return HintTypeEmpty
 	}
@@ -113,6 +119,8 @@ func (h *StatementHintUnion) Details() (json.JSON, error) {
 		wrapped = t
 	case *SessionVariableHint:
 		wrapped = t
+	case *PlanBaseline:
+		wrapped = t
 	default:
 		return nil, errors.New("unknown hint type")
 	}
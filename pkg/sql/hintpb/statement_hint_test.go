@@ -26,6 +26,11 @@ func TestHintType(t *testing.T) {
 	var sessionHint StatementHintUnion
 	sessionHint.SetValue(&SessionVariableHint{VariableName: "distsql", VariableValue: "on"})
 	require.Equal(t, HintTypeSetVariable, sessionHint.HintType())
+
+	// Test PlanBaseline returns PLAN BASELINE.
+	var baselineHint StatementHintUnion
+	baselineHint.SetValue(&PlanBaseline{PlanGist: "AgHQAQIAAwIAAAcKBQoh0AEAAA=="})
+	require.Equal(t, HintTypePlanBaseline, baselineHint.HintType())
 }
 
 func TestRecreateStmt(t *testing.T) {
@@ -159,6 +159,11 @@ func GetStatementHintsFromDB(
 			break
 		}
 		hintID, fingerprint, hint := parseHint(it.Cur(), fingerprintFlags)
+		if hint.PlanBaseline != nil {
+			// Plan baselines only record plan history, and are never applied to
+			// statements.
+			continue
+		}
 		if hint.Err != nil {
 			// Do not return the error. Instead, we'll simply execute the query
 			// without this hint.
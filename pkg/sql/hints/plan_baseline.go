@@ -0,0 +1,265 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package hints
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/hintpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// PlanBaselinesEnabled controls whether plan baselines are captured from SQL
+// statistics, and whether regressions are rolled back automatically.
+var PlanBaselinesEnabled = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"sql.hints.plan_baselines.enabled",
+	"when true, the accepted plan of each statement fingerprint is recorded from SQL statistics, "+
+		"and the accepted plan is pinned when a new plan is materially slower",
+	false,
+)
+
+// planBaselineRegressionThreshold is the ratio of mean latencies above which
+// a new plan is considered to be a regression.
+var planBaselineRegressionThreshold = settings.RegisterFloatSetting(
+	settings.ApplicationLevel,
+	"sql.hints.plan_baselines.regression_threshold",
+	"the ratio between the mean latency of a new plan and that of the accepted plan "+
+		"above which the new plan is considered to be a regression",
+	2.0,
+	settings.FloatWithMinimum(1),
+)
+
+// planBaselineMinExecutions is the number of executions of a plan that are
+// needed before its latency is compared to that of the accepted plan.
+var planBaselineMinExecutions = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"sql.hints.plan_baselines.min_executions",
+	"the minimum number of executions of a plan before it can be accepted or "+
+		"considered to be a regression",
+	30,
+	settings.PositiveInt,
+)
+
+// PlanStats is the latency of one plan of a statement fingerprint, as recorded
+// by SQL statistics.
+type PlanStats struct {
+	Gist    string
+	Hash    uint64
+	Latency hintpb.PlanLatency
+}
+
+// BaselineAction is the change to a plan baseline that EvaluatePlanBaseline
+// decides on.
+type BaselineAction int
+
+const (
+	// BaselineNoop leaves the plan baseline unchanged.
+	BaselineNoop BaselineAction = iota
+	// BaselineAccept records the plan as the accepted plan of the fingerprint.
+	BaselineAccept
+	// BaselineRegress pins the accepted plan and records the plan as a
+	// regressed candidate.
+	BaselineRegress
+)
+
+// EvaluatePlanBaseline compares the plans of a statement fingerprint against
+// its plan baseline, which is nil if none was recorded yet. A plan is only
+// considered once it has been executed sql.hints.plan_baselines.min_executions
+// times. The most executed plan is accepted when there is no baseline. A plan
+// that is materially slower than the accepted plan is a regression, and one
+// that is faster becomes the accepted plan. Baselines with a pending or
+// rejected regression are left alone.
+func EvaluatePlanBaseline(
+	sv *settings.Values, baseline *hintpb.PlanBaseline, plans []PlanStats,
+) (BaselineAction, PlanStats) {
+	minExecutions := planBaselineMinExecutions.Get(sv)
+	threshold := planBaselineRegressionThreshold.Get(sv)
+
+	var qualified []PlanStats
+	for _, p := range plans {
+		if p.Latency.Count >= minExecutions {
+			qualified = append(qualified, p)
+		}
+	}
+	if len(qualified) == 0 {
+		return BaselineNoop, PlanStats{}
+	}
+	sort.SliceStable(qualified, func(i, j int) bool {
+		return qualified[i].Latency.Count > qualified[j].Latency.Count
+	})
+	if baseline == nil {
+		return BaselineAccept, qualified[0]
+	}
+	if baseline.State != hintpb.PlanBaseline_ACCEPTED {
+		return BaselineNoop, PlanStats{}
+	}
+
+	// Regressions take precedence over faster plans.
+	for _, p := range qualified {
+		if p.Hash != baseline.PlanHash && IsPlanRegression(baseline.Latency, p.Latency, threshold) {
+			return BaselineRegress, p
+		}
+	}
+	for _, p := range qualified {
+		if p.Hash != baseline.PlanHash && p.Latency.Mean < baseline.Latency.Mean {
+			return BaselineAccept, p
+		}
+	}
+	return BaselineNoop, PlanStats{}
+}
+
+// IsPlanRegression returns whether the latency of a candidate plan is
+// materially worse than that of the accepted plan: its mean must exceed the
+// accepted mean by the given ratio, and the difference of the means must be
+// larger than twice its standard error, so that noisy latencies of rarely
+// executed plans don't trigger a rollback.
+func IsPlanRegression(accepted, candidate hintpb.PlanLatency, threshold float64) bool {
+	if candidate.Mean <= accepted.Mean*threshold {
+		return false
+	}
+	stdErr := math.Sqrt(latencyVariance(accepted)/float64(accepted.Count) +
+		latencyVariance(candidate)/float64(candidate.Count))
+	return candidate.Mean-accepted.Mean > 2*stdErr
+}
+
+func latencyVariance(l hintpb.PlanLatency) float64 {
+	if l.Count < 2 {
+		return 0
+	}
+	return l.SquaredDiffs / float64(l.Count-1)
+}
+
+// PlanBaselineRow is a plan baseline stored in system.statement_hints.
+type PlanBaselineRow struct {
+	HintID      int64
+	Fingerprint string
+	Database    string
+	Baseline    hintpb.PlanBaseline
+}
+
+// GetPlanBaselinesFromDB returns all plan baselines in
+// system.statement_hints. Baselines that cannot be decoded are skipped.
+func GetPlanBaselinesFromDB(ctx context.Context, txn isql.Txn) ([]PlanBaselineRow, error) {
+	const opName = "get-plan-baselines"
+	rows, err := txn.QueryBufferedEx(
+		ctx, opName, txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`SELECT "row_id", "fingerprint", "database", "hint" FROM system.statement_hints
+WHERE "hint_type" = $1 ORDER BY "fingerprint", "database"`,
+		hintpb.HintTypePlanBaseline,
+	)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]PlanBaselineRow, 0, len(rows))
+	for _, row := range rows {
+		hintID := int64(tree.MustBeDInt(row[0]))
+		hint, err := hintpb.ParseHintProto([]byte(tree.MustBeDBytes(row[3])))
+		if err != nil || hint.PlanBaseline == nil {
+			log.Dev.Warningf(ctx, "could not decode plan baseline %d: %v", hintID, err)
+			continue
+		}
+		r := PlanBaselineRow{
+			HintID:      hintID,
+			Fingerprint: string(tree.MustBeDString(row[1])),
+			Baseline:    *hint.PlanBaseline,
+		}
+		if row[2] != tree.DNull {
+			r.Database = string(tree.MustBeDString(row[2]))
+		}
+		res = append(res, r)
+	}
+	return res, nil
+}
+
+// UpdatePlanBaselineInDB replaces the plan baseline stored with the given hint
+// ID. Plan baselines are not applied to statements, so unlike
+// SetHintEnabledInDB this does not need to trigger invalidation of the hint
+// cache.
+func UpdatePlanBaselineInDB(
+	ctx context.Context, txn isql.Txn, hintID int64, baseline *hintpb.PlanBaseline,
+) error {
+	const opName = "update-plan-baseline"
+	var hint hintpb.StatementHintUnion
+	hint.SetValue(baseline)
+	hintBytes, err := hintpb.ToBytes(hint)
+	if err != nil {
+		return err
+	}
+	n, err := txn.ExecEx(
+		ctx, opName, txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`UPDATE system.statement_hints SET "hint" = $2 WHERE "row_id" = $1`,
+		hintID, hintBytes,
+	)
+	if err != nil {
+		return err
+	}
+	if n != 1 {
+		return errors.Newf("plan baseline %d not found", hintID)
+	}
+	return nil
+}
+
+// ResolvePlanBaselineInDB accepts or rejects the regressed plan of the plan
+// baseline for the given fingerprint and database. Accepting the regressed
+// plan makes it the accepted plan and deletes the hint that pins the previous
+// plan, while rejecting it keeps the previous plan pinned. It returns false if
+// the baseline has no regressed plan awaiting review, and otherwise the ID of
+// the pinning hint.
+func ResolvePlanBaselineInDB(
+	ctx context.Context, txn isql.Txn, fingerprint string, optDatabase string, accept bool,
+) (resolved bool, pinHintID int64, _ error) {
+	const opName = "resolve-plan-baseline"
+	var database interface{}
+	if optDatabase != "" {
+		database = optDatabase
+	}
+	row, err := txn.QueryRowEx(
+		ctx, opName, txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`SELECT "row_id", "hint" FROM system.statement_hints
+WHERE "fingerprint" = $1 AND "hint_type" = $2 AND "database" IS NOT DISTINCT FROM $3
+ORDER BY "created_at" DESC, "row_id" DESC LIMIT 1`,
+		fingerprint, hintpb.HintTypePlanBaseline, database,
+	)
+	if err != nil || row == nil {
+		return false, 0, err
+	}
+	hintID := int64(tree.MustBeDInt(row[0]))
+	hint, err := hintpb.ParseHintProto([]byte(tree.MustBeDBytes(row[1])))
+	if err != nil {
+		return false, 0, err
+	}
+	baseline := hint.PlanBaseline
+	if baseline == nil || baseline.State != hintpb.PlanBaseline_CANDIDATE {
+		return false, 0, nil
+	}
+	pinHintID = baseline.PinHintID
+	if accept {
+		if pinHintID != 0 {
+			if _, _, _, err := DeleteHintFromDB(ctx, txn, pinHintID, "" /* fingerprint */, "" /* optDatabase */); err != nil {
+				return false, 0, err
+			}
+		}
+		*baseline = hintpb.PlanBaseline{
+			PlanGist: baseline.RegressedPlanGist,
+			PlanHash: baseline.RegressedPlanHash,
+			Latency:  baseline.RegressedLatency,
+		}
+	} else {
+		baseline.State = hintpb.PlanBaseline_PINNED
+	}
+	if err := UpdatePlanBaselineInDB(ctx, txn, hintID, baseline); err != nil {
+		return false, 0, err
+	}
+	return true, pinHintID, nil
+}
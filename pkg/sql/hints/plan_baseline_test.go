@@ -0,0 +1,106 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package hints_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/hintpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/hints"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluatePlanBaseline(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	st := cluster.MakeTestingClusterSettings()
+	latency := func(count int64, mean, stddev float64) hintpb.PlanLatency {
+		return hintpb.PlanLatency{
+			Count: count, Mean: mean, SquaredDiffs: stddev * stddev * float64(count-1),
+		}
+	}
+	fast := hints.PlanStats{Gist: "fast", Hash: 1, Latency: latency(100, 0.01, 0.002)}
+	slow := hints.PlanStats{Gist: "slow", Hash: 2, Latency: latency(100, 0.05, 0.01)}
+	noisy := hints.PlanStats{Gist: "noisy", Hash: 3, Latency: latency(40, 0.05, 0.5)}
+	rare := hints.PlanStats{Gist: "rare", Hash: 4, Latency: latency(5, 1, 0.01)}
+	faster := hints.PlanStats{Gist: "faster", Hash: 5, Latency: latency(50, 0.005, 0.001)}
+	accepted := &hintpb.PlanBaseline{PlanGist: fast.Gist, PlanHash: fast.Hash, Latency: fast.Latency}
+
+	for _, tc := range []struct {
+		name     string
+		baseline *hintpb.PlanBaseline
+		plans    []hints.PlanStats
+		action   hints.BaselineAction
+		plan     string
+	}{
+		{name: "no plans", plans: nil, action: hints.BaselineNoop},
+		{name: "too few executions", plans: []hints.PlanStats{rare}, action: hints.BaselineNoop},
+		{
+			name:   "first baseline uses most executed plan",
+			plans:  []hints.PlanStats{rare, faster, slow},
+			action: hints.BaselineAccept,
+			plan:   "slow",
+		},
+		{
+			name:     "unchanged plan",
+			baseline: accepted,
+			plans:    []hints.PlanStats{fast},
+			action:   hints.BaselineNoop,
+		},
+		{
+			name:     "regression",
+			baseline: accepted,
+			plans:    []hints.PlanStats{fast, slow},
+			action:   hints.BaselineRegress,
+			plan:     "slow",
+		},
+		{
+			name:     "noisy latency is not a regression",
+			baseline: accepted,
+			plans:    []hints.PlanStats{fast, noisy},
+			action:   hints.BaselineNoop,
+		},
+		{
+			name:     "rarely executed plan is not a regression",
+			baseline: accepted,
+			plans:    []hints.PlanStats{fast, rare},
+			action:   hints.BaselineNoop,
+		},
+		{
+			name:     "faster plan is accepted",
+			baseline: accepted,
+			plans:    []hints.PlanStats{fast, faster},
+			action:   hints.BaselineAccept,
+			plan:     "faster",
+		},
+		{
+			name:     "regression takes precedence over faster plan",
+			baseline: accepted,
+			plans:    []hints.PlanStats{faster, slow},
+			action:   hints.BaselineRegress,
+			plan:     "slow",
+		},
+		{
+			name: "pending candidate is left alone",
+			baseline: &hintpb.PlanBaseline{
+				PlanGist: fast.Gist, PlanHash: fast.Hash, Latency: fast.Latency,
+				State: hintpb.PlanBaseline_CANDIDATE,
+			},
+			plans:  []hints.PlanStats{fast, slow},
+			action: hints.BaselineNoop,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			action, plan := hints.EvaluatePlanBaseline(&st.SV, tc.baseline, tc.plans)
+			require.Equal(t, tc.action, action)
+			require.Equal(t, tc.plan, plan.Gist)
+		})
+	}
+}
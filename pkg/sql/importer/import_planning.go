@@ -671,6 +671,23 @@ func importPlanHook(
 			return errors.Newf("cannot run an import on table %s which is apart of a Logical Data Replication stream", table)
 		}
 
+		if found.GetReadOnly() {
+			return errors.WithHint(
+				pgerror.Newf(pgcode.ReadOnlySQLTransaction,
+					"cannot IMPORT INTO read-only table %q", found.GetName()),
+				"use ALTER TABLE ... SET READ WRITE to allow writes to the table",
+			)
+		}
+
+		// The rows ingested by IMPORT INTO are not seen by the maintenance of
+		// incrementally maintained views, and a failed import is rolled back
+		// without them either.
+		if err := sql.CheckIncrementalViewDependents(
+			ctx, p.Txn(), p.Descriptors(), found, "IMPORT INTO", false, /* allowAsync */
+		); err != nil {
+			return err
+		}
+
 		// Import into an RLS table is blocked, unless this is the admin. It is
 		// allowed for admins since they are exempt from RLS policies and have
 		// unrestricted read/write access.
@@ -0,0 +1,863 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/ivm"
+	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/regions"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// defaultIncrementalViewMaxStaleness is the max_staleness of an asynchronously
+// maintained view that does not specify one.
+const defaultIncrementalViewMaxStaleness = 10 * time.Second
+
+// incrementalViewCatchUpInterval is the interval at which the job of a
+// synchronously maintained view checks whether it has caught up with the
+// changes made while the view was being created.
+const incrementalViewCatchUpInterval = time.Second
+
+var incrementalViewMaxDeltaRows = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"sql.incremental_view_maintenance.max_delta_rows",
+	"the maximum number of changed base table rows that the maintenance job of an "+
+		"incrementally maintained materialized view applies as a delta; larger batches "+
+		"of changes are applied by recomputing the entire view",
+	10000,
+	settings.PositiveInt,
+)
+
+// incrementalViewMaintenanceOverride is the session data override of the
+// statements that the maintenance job runs, which are allowed to modify the
+// view.
+var incrementalViewMaintenanceOverride = sessiondata.InternalExecutorOverride{
+	User:                          username.NodeUserName(),
+	AllowMaterializedViewMutation: true,
+}
+
+// makeIncrementalRefresh returns the IncrementalRefresh of a materialized view
+// created with the given storage parameters, or nil if the view is not
+// incrementally maintained.
+func makeIncrementalRefresh(
+	ctx context.Context, p *planner, cv *tree.CreateView,
+) (*descpb.TableDescriptor_IncrementalRefresh, error) {
+	if len(cv.StorageParams) == 0 {
+		return nil, nil
+	}
+	// Nodes running an older binary do not know the maintenance job, and would
+	// not maintain the view in the statements that modify its base tables.
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V26_3) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"incremental materialized views are not supported until the cluster version is finalized")
+	}
+	eval := func(sp tree.StorageParam) (tree.TypedExpr, error) {
+		defer p.semaCtx.Properties.Restore(p.semaCtx.Properties)
+		p.semaCtx.Properties.Require("materialized view storage parameters", tree.RejectSubqueries)
+		return tree.TypeCheck(ctx, paramparse.UnresolvedNameToStrVal(sp.Value), &p.semaCtx, types.AnyElement)
+	}
+	var ir descpb.TableDescriptor_IncrementalRefresh
+	var hasMode, hasMaxStaleness bool
+	for _, sp := range cv.StorageParams {
+		switch sp.Key {
+		case ivm.IncrementalParam:
+			hasMode = true
+			if sp.Value == nil {
+				ir.Mode = descpb.TableDescriptor_IncrementalRefresh_SYNC
+				continue
+			}
+			typedExpr, err := eval(sp)
+			if err != nil {
+				return nil, err
+			}
+			mode, err := paramparse.DatumAsString(ctx, p.EvalContext(), sp.Key, typedExpr)
+			if err != nil {
+				return nil, err
+			}
+			switch strings.ToLower(mode) {
+			case "sync":
+				ir.Mode = descpb.TableDescriptor_IncrementalRefresh_SYNC
+			case "async":
+				ir.Mode = descpb.TableDescriptor_IncrementalRefresh_ASYNC
+			default:
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"invalid value for %s: %q, expected 'sync' or 'async'", sp.Key, mode)
+			}
+		case ivm.MaxStalenessParam:
+			if sp.Value == nil {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"storage parameter %q requires a value", sp.Key)
+			}
+			typedExpr, err := eval(sp)
+			if err != nil {
+				return nil, err
+			}
+			d, err := paramparse.DatumAsDuration(ctx, p.EvalContext(), sp.Key, typedExpr)
+			if err != nil {
+				return nil, err
+			}
+			if d <= 0 {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"value of %s must be positive", sp.Key)
+			}
+			ir.MaxStaleness = d
+			hasMaxStaleness = true
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid storage parameter %q", sp.Key)
+		}
+	}
+	if !hasMode {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+			"storage parameter %q requires %q", ivm.MaxStalenessParam, ivm.IncrementalParam)
+	}
+	if ir.Mode == descpb.TableDescriptor_IncrementalRefresh_ASYNC {
+		if !hasMaxStaleness {
+			ir.MaxStaleness = defaultIncrementalViewMaxStaleness
+		}
+	} else if hasMaxStaleness {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+			"storage parameter %q is only supported for 'async' incremental views", ivm.MaxStalenessParam)
+	}
+	if !cv.WithData {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"incremental materialized views cannot be created WITH NO DATA")
+	}
+	return &ir, nil
+}
+
+// makeIncrementalViewTable returns the description of the given base table of
+// an incrementally maintained view.
+func makeIncrementalViewTable(desc catalog.TableDescriptor) ivm.Table {
+	t := ivm.Table{ID: desc.GetID()}
+	for _, col := range desc.AccessibleColumns() {
+		t.Columns = append(t.Columns, col.ColName())
+	}
+	pk := desc.GetPrimaryIndex()
+	for i := 0; i < pk.NumKeyColumns(); i++ {
+		t.PrimaryKey = append(t.PrimaryKey, tree.Name(pk.GetKeyColumnName(i)))
+	}
+	return t
+}
+
+// analyzeIncrementalView checks that the query of the incrementally maintained
+// view being created is supported, and returns the IDs of its base tables.
+func (n *createViewNode) analyzeIncrementalView(params runParams) (descpb.IDs, error) {
+	var tableIDs descpb.IDs
+	_, err := ivm.Analyze(params.ctx, n.viewQuery, func(
+		ctx context.Context, tn *tree.TableName,
+	) (ivm.Table, error) {
+		_, desc, err := resolver.ResolveExistingTableObject(ctx, params.p, tn, tree.ObjectLookupFlags{
+			Required:             true,
+			DesiredObjectKind:    tree.TableObject,
+			DesiredTableDescKind: tree.ResolveRequireTableDesc,
+		})
+		if err != nil {
+			return ivm.Table{}, err
+		}
+		if desc.IsVirtualTable() {
+			return ivm.Table{}, pgerror.Newf(pgcode.FeatureNotSupported,
+				"incremental materialized views do not support virtual table %s", tn.ObjectName)
+		}
+		if _, ok := n.planDeps[desc.GetID()]; !ok {
+			return ivm.Table{}, errors.AssertionFailedf("table %s is not a dependency of the view", tn)
+		}
+		tableIDs = append(tableIDs, desc.GetID())
+		return makeIncrementalViewTable(desc), nil
+	})
+	return tableIDs, err
+}
+
+// CheckIncrementalViewDependents returns an error if the given table is a base
+// table of an incrementally maintained view that the given operation would
+// leave out of date. The statements that modify the base tables of a SYNC view
+// maintain it row by row, so operations like TRUNCATE and IMPORT INTO, which do
+// not go through them, are rejected. If allowAsync is set, the operation is
+// allowed for ASYNC views, whose maintenance job recomputes the view when it
+// notices the operation.
+func CheckIncrementalViewDependents(
+	ctx context.Context,
+	txn *kv.Txn,
+	col *descs.Collection,
+	tab catalog.TableDescriptor,
+	op string,
+	allowAsync bool,
+) error {
+	for _, ref := range tab.GetDependedOnBy() {
+		view, err := col.ByIDWithoutLeased(txn).Get().Table(ctx, ref.ID)
+		if err != nil {
+			return err
+		}
+		ir := view.GetIncrementalRefresh()
+		if view.Dropped() || ir == nil {
+			continue
+		}
+		if allowAsync && ir.Mode == descpb.TableDescriptor_IncrementalRefresh_ASYNC {
+			continue
+		}
+		return errors.WithHint(
+			pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"cannot %s table %q, which is a base table of incrementally maintained materialized view %q",
+				errors.Safe(op), tab.GetName(), view.GetName()),
+			"drop the materialized view first, and recreate it after the operation",
+		)
+	}
+	return nil
+}
+
+// createIncrementalViewMaintenanceJob creates the job that maintains the given
+// incrementally maintained view, whose JobID must already be allocated.
+func (p *planner) createIncrementalViewMaintenanceJob(
+	ctx context.Context, view *tabledesc.Mutable, tableIDs descpb.IDs,
+) error {
+	ir := view.IncrementalRefresh
+	record := jobs.Record{
+		JobID:         jobspb.JobID(ir.JobID),
+		Description:   fmt.Sprintf("maintaining materialized view %s", view.GetName()),
+		Username:      p.User(),
+		DescriptorIDs: descpb.IDs{view.GetID()},
+		Details: jobspb.IncrementalViewMaintenanceDetails{
+			ViewID:   view.GetID(),
+			TableIDs: tableIDs,
+			Sync:     ir.Mode == descpb.TableDescriptor_IncrementalRefresh_SYNC,
+		},
+		Progress:      jobspb.IncrementalViewMaintenanceProgress{},
+		NonCancelable: true,
+	}
+	_, err := p.ExecCfg().JobRegistry.CreateAdoptableJobWithTxn(ctx, record, record.JobID, p.InternalSQLTxn())
+	return err
+}
+
+// incrementalViewMaintenanceResumer implements the job that applies the
+// changes of the base tables of an incrementally maintained view to the view.
+//
+// The job follows the changes with a rangefeed over the primary indexes of the
+// base tables. At each flush, it computes the keys of the view rows that each
+// changed row contributed to before and after each of its changes, deletes the
+// view rows with those keys, and inserts them again recomputed from the current
+// contents of the base tables. Recomputing a view row is idempotent, so the
+// view rows that were already maintained by a statement are not affected.
+//
+// An ASYNC view is flushed every max_staleness until it is dropped, and the
+// high-water of the job is the time as of which the view reflects all changes
+// of the base tables. A SYNC view is maintained by the job only until the
+// statements that modify the base tables maintain it: the job marks the
+// references of the base tables to the view, waits until the new descriptors
+// are in use, catches up with the changes made until then, and completes.
+type incrementalViewMaintenanceResumer struct {
+	job *jobs.Job
+}
+
+var _ jobs.Resumer = (*incrementalViewMaintenanceResumer)(nil)
+
+// Resume is part of the jobs.Resumer interface.
+func (r *incrementalViewMaintenanceResumer) Resume(ctx context.Context, execCtx interface{}) error {
+	execCfg := execCtx.(JobExecContext).ExecCfg()
+	details := r.job.Details().(jobspb.IncrementalViewMaintenanceDetails)
+
+	// The view is backfilled by the schema changer after the job is created, so
+	// wait until it is public.
+	m, err := waitForIncrementalView(ctx, execCfg, r.job.ID(), details)
+	if err != nil || m == nil {
+		return err
+	}
+
+	var hw hlc.Timestamp
+	if err := execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		_, hw, _, err = r.job.ProgressStorage().Get(ctx, txn)
+		return err
+	}); err != nil {
+		return err
+	}
+	if hw.IsEmpty() {
+		// The view was backfilled as of its creation time.
+		hw = m.createAsOf
+	}
+
+	interval := m.maxStaleness
+	var until hlc.Timestamp
+	if details.Sync {
+		if err := m.enableSyncMaintenance(ctx); err != nil {
+			return err
+		}
+		interval = incrementalViewCatchUpInterval
+		until = execCfg.Clock.Now()
+	}
+
+	for {
+		hw, err = m.follow(ctx, hw, until, interval)
+		if errors.Is(err, errIncrementalViewDropped) {
+			return nil
+		}
+		if errors.Is(err, errIncrementalViewPrimaryIndexChanged) {
+			// The rows of a base table were rewritten into a new primary index,
+			// e.g. by TRUNCATE, which the rangefeed does not see, so follow the
+			// new primary indexes and recompute the view.
+			cur, loadErr := loadIncrementalViewMaintainer(ctx, execCfg, r.job.ID(), details)
+			if loadErr != nil || cur == nil {
+				return loadErr
+			}
+			m = cur
+		} else if !errors.HasType(err, (*kvpb.BatchTimestampBeforeGCError)(nil)) {
+			return err
+		}
+		// The changes since the high-water are no longer available, so recompute
+		// the view and follow the changes from now on.
+		log.Dev.Infof(ctx, "recomputing materialized view %d: %v", m.viewID, err)
+		hw = execCfg.Clock.Now()
+		if err := m.recompute(ctx); err != nil {
+			return err
+		}
+		if err := m.setHighWater(ctx, hw); err != nil {
+			return err
+		}
+		if until.IsSet() && until.LessEq(hw) {
+			return nil
+		}
+	}
+}
+
+// OnFailOrCancel is part of the jobs.Resumer interface.
+func (r *incrementalViewMaintenanceResumer) OnFailOrCancel(
+	context.Context, interface{}, error,
+) error {
+	return nil
+}
+
+// CollectProfile is part of the jobs.Resumer interface.
+func (r *incrementalViewMaintenanceResumer) CollectProfile(context.Context, interface{}) error {
+	return nil
+}
+
+// errIncrementalViewDropped is returned by the maintainer of an incrementally
+// maintained view when the view has been dropped.
+var errIncrementalViewDropped = errors.New("materialized view has been dropped")
+
+// errIncrementalViewPrimaryIndexChanged is returned by the maintainer of an
+// incrementally maintained view when the primary index of a base table has
+// changed since the maintainer was loaded.
+var errIncrementalViewPrimaryIndexChanged = errors.New("primary index of a base table has changed")
+
+// incrementalViewMaintainer applies the changes of the base tables of an
+// incrementally maintained view to the view.
+type incrementalViewMaintainer struct {
+	execCfg *ExecutorConfig
+	jobID   jobspb.JobID
+	details jobspb.IncrementalViewMaintenanceDetails
+
+	viewID       descpb.ID
+	public       bool
+	createAsOf   hlc.Timestamp
+	maxStaleness time.Duration
+	// viewCols are the names of the visible columns of the view.
+	viewCols tree.NameList
+	def      *ivm.Definition
+	// tables contains the descriptor of each source of the view.
+	tables []catalog.TableDescriptor
+}
+
+// loadIncrementalViewMaintainer reads the descriptors of the given view and its
+// base tables. It returns nil if the view has been dropped.
+func loadIncrementalViewMaintainer(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	jobID jobspb.JobID,
+	details jobspb.IncrementalViewMaintenanceDetails,
+) (*incrementalViewMaintainer, error) {
+	m := &incrementalViewMaintainer{
+		execCfg: execCfg,
+		jobID:   jobID,
+		details: details,
+		viewID:  details.ViewID,
+	}
+	err := execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		view, err := txn.Descriptors().ByIDWithoutLeased(txn.KV()).WithoutDropped().Get().Table(ctx, details.ViewID)
+		if err != nil {
+			return err
+		}
+		ir := view.GetIncrementalRefresh()
+		if ir == nil {
+			return errors.AssertionFailedf("view %d is not incrementally maintained", details.ViewID)
+		}
+		m.public = view.Public()
+		m.createAsOf = view.GetCreateAsOfTime()
+		m.maxStaleness = ir.MaxStaleness
+		m.viewCols = m.viewCols[:0]
+		for _, col := range view.VisibleColumns() {
+			m.viewCols = append(m.viewCols, col.ColName())
+		}
+		m.tables = m.tables[:0]
+		m.def, err = ivm.Analyze(ctx, view.GetViewQuery(), func(
+			ctx context.Context, tn *tree.TableName,
+		) (ivm.Table, error) {
+			_, tab, err := descs.PrefixAndTable(ctx, txn.Descriptors().ByName(txn.KV()).Get(), tn)
+			if err != nil {
+				return ivm.Table{}, err
+			}
+			m.tables = append(m.tables, tab)
+			return makeIncrementalViewTable(tab), nil
+		})
+		return err
+	})
+	if errors.Is(err, catalog.ErrDescriptorNotFound) || errors.Is(err, catalog.ErrDescriptorDropped) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// waitForIncrementalView waits until the given view is public, and returns its
+// maintainer. It returns nil if the view has been dropped.
+func waitForIncrementalView(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	jobID jobspb.JobID,
+	details jobspb.IncrementalViewMaintenanceDetails,
+) (*incrementalViewMaintainer, error) {
+	opts := retry.Options{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+	}
+	for r := retry.StartWithCtx(ctx, opts); r.Next(); {
+		m, err := loadIncrementalViewMaintainer(ctx, execCfg, jobID, details)
+		if err != nil || m == nil || m.public {
+			return m, err
+		}
+	}
+	return nil, ctx.Err()
+}
+
+// enableSyncMaintenance marks the references of the base tables to the view, so
+// that the statements that modify the tables also update the view, and waits
+// until all statements use the new table descriptors.
+func (m *incrementalViewMaintainer) enableSyncMaintenance(ctx context.Context) error {
+	if err := m.execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		for _, id := range m.details.TableIDs {
+			tab, err := txn.Descriptors().MutableByID(txn.KV()).Table(ctx, id)
+			if err != nil {
+				return err
+			}
+			changed := false
+			for i := range tab.DependedOnBy {
+				if ref := &tab.DependedOnBy[i]; ref.ID == m.viewID && !ref.IncrementalView {
+					ref.IncrementalView = true
+					changed = true
+				}
+			}
+			if changed {
+				if err := txn.Descriptors().WriteDesc(ctx, false /* kvTrace */, tab, txn.KV()); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	cachedRegions, err := regions.NewCachedDatabaseRegions(ctx, m.execCfg.InternalDB.KV(), m.execCfg.LeaseManager)
+	if err != nil {
+		return err
+	}
+	for _, id := range m.details.TableIDs {
+		if _, err := WaitToUpdateLeases(ctx, m.execCfg.LeaseManager, cachedRegions, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// follow applies the changes of the base tables after the high-water hw to the
+// view every interval, until the changes up to until have been applied, if it
+// is set, or the view is dropped. It returns the new high-water.
+func (m *incrementalViewMaintainer) follow(
+	ctx context.Context, hw, until hlc.Timestamp, interval time.Duration,
+) (hlc.Timestamp, error) {
+	buf := incrementalViewChangeBuffer{codec: m.execCfg.Codec}
+	buf.mu.frontier = hw
+	buf.mu.changes = make(map[incrementalViewChange]struct{})
+	spans := make([]roachpb.Span, len(m.tables))
+	for i, tab := range m.tables {
+		spans[i] = tab.PrimaryIndexSpan(m.execCfg.Codec)
+		buf.tableIDs = append(buf.tableIDs, tab.GetID())
+	}
+	errCh := make(chan error, 1)
+	rf, err := m.execCfg.RangeFeedFactory.RangeFeed(
+		ctx,
+		"incremental-view-maintenance",
+		spans,
+		hw,
+		buf.onValue,
+		rangefeed.WithOnFrontierAdvance(buf.onFrontierAdvance),
+		rangefeed.WithOnSSTable(func(
+			ctx context.Context, sst *kvpb.RangeFeedSSTable, _ roachpb.Span,
+		) {
+			buf.invalidate(sst.WriteTS)
+		}),
+		rangefeed.WithOnDeleteRange(func(ctx context.Context, ev *kvpb.RangeFeedDeleteRange) {
+			buf.invalidate(ev.Timestamp)
+		}),
+		rangefeed.WithOnInternalError(func(ctx context.Context, err error) {
+			select {
+			case errCh <- err:
+			default:
+			}
+		}),
+	)
+	if err != nil {
+		return hw, err
+	}
+	defer rf.Close()
+
+	var t timeutil.Timer
+	defer t.Stop()
+	for {
+		t.Reset(interval)
+		select {
+		case <-ctx.Done():
+			return hw, ctx.Err()
+		case err := <-errCh:
+			return hw, err
+		case <-t.C:
+		}
+
+		// Stop if the view has been dropped, or if the primary index of a base
+		// table has changed, since the rangefeed would miss its changes.
+		cur, err := loadIncrementalViewMaintainer(ctx, m.execCfg, m.jobID, m.details)
+		if err != nil {
+			return hw, err
+		}
+		if cur == nil {
+			return hw, errIncrementalViewDropped
+		}
+		for i := range m.tables {
+			if cur.tables[i].GetPrimaryIndexID() != m.tables[i].GetPrimaryIndexID() {
+				return hw, errors.Wrapf(errIncrementalViewPrimaryIndexChanged,
+					"table %s", m.tables[i].GetName())
+			}
+		}
+
+		frontier, changes, invalidated := buf.take(
+			int(incrementalViewMaxDeltaRows.Get(&m.execCfg.Settings.SV)),
+		)
+		if frontier.LessEq(hw) {
+			continue
+		}
+		if invalidated {
+			err = m.recompute(ctx)
+		} else {
+			err = m.apply(ctx, changes)
+		}
+		if err != nil {
+			return hw, err
+		}
+		hw = frontier
+		if err := m.setHighWater(ctx, hw); err != nil {
+			return hw, err
+		}
+		if until.IsSet() && until.LessEq(hw) {
+			return hw, nil
+		}
+	}
+}
+
+// setHighWater records that the view reflects all changes of the base tables
+// up to the given time.
+func (m *incrementalViewMaintainer) setHighWater(ctx context.Context, hw hlc.Timestamp) error {
+	return m.execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		return jobs.ProgressStorage(m.jobID).SetResolved(ctx, txn, hw)
+	})
+}
+
+// apply applies the given changes of the base tables to the view.
+func (m *incrementalViewMaintainer) apply(
+	ctx context.Context, changes []incrementalViewChange,
+) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	// Group the changed rows by source and timestamp, and compute the keys of
+	// the view rows they contributed to before and after each change.
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].source != changes[j].source {
+			return changes[i].source < changes[j].source
+		}
+		return changes[i].ts.Less(changes[j].ts)
+	})
+	viewKeys := make([]map[string]tree.Datums, m.def.SourceCount())
+	var alloc tree.DatumAlloc
+	for i := 0; i < len(changes); {
+		j := i + 1
+		for j < len(changes) && changes[j].source == changes[i].source && changes[j].ts == changes[i].ts {
+			j++
+		}
+		source := changes[i].source
+		pks := make([]tree.Datums, 0, j-i)
+		for _, c := range changes[i:j] {
+			pk, err := m.decodePrimaryKey(source, roachpb.Key(c.rowKey), &alloc)
+			if err != nil {
+				// Some primary keys cannot be decoded from the index key, e.g. if
+				// they have a composite encoding.
+				log.Dev.Infof(ctx, "recomputing materialized view %d: %v", m.viewID, err)
+				return m.recompute(ctx)
+			}
+			pks = append(pks, pk)
+		}
+		if viewKeys[source] == nil {
+			viewKeys[source] = make(map[string]tree.Datums)
+		}
+		stmt := tree.AsStringWithFlags(m.def.KeysFromPrimaryKeys(source, pks), tree.FmtParsable)
+		for _, ts := range []hlc.Timestamp{changes[i].ts.Prev(), changes[i].ts} {
+			if err := m.queryKeys(ctx, ts, stmt, viewKeys[source]); err != nil {
+				return err
+			}
+		}
+		i = j
+	}
+
+	// Delete the affected view rows and insert them again.
+	return m.execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		for source, keys := range viewKeys {
+			if len(keys) == 0 {
+				continue
+			}
+			values := &tree.ValuesClause{Rows: make([]tree.Exprs, 0, len(keys))}
+			for _, key := range keys {
+				row := make(tree.Exprs, len(key))
+				for k := range key {
+					row[k] = key[k]
+				}
+				values.Rows = append(values.Rows, row)
+			}
+			sel := &tree.Select{Select: values}
+			for _, stmt := range []tree.Statement{
+				m.def.DeleteForKeys(source, m.viewID, m.viewCols, sel),
+				m.def.InsertForKeys(source, m.viewID, sel),
+			} {
+				if _, err := txn.ExecEx(
+					ctx, "incremental-view-maintenance", txn.KV(), incrementalViewMaintenanceOverride,
+					tree.AsStringWithFlags(stmt, tree.FmtParsable),
+				); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// queryKeys runs the given query for the keys of the view rows that some rows
+// of a base table contribute to as of the given time, and adds the keys to the
+// given set.
+func (m *incrementalViewMaintainer) queryKeys(
+	ctx context.Context, ts hlc.Timestamp, stmt string, keys map[string]tree.Datums,
+) error {
+	return m.execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		if err := txn.KV().SetFixedTimestamp(ctx, ts); err != nil {
+			return err
+		}
+		rows, err := txn.QueryBufferedEx(
+			ctx, "incremental-view-keys", txn.KV(), incrementalViewMaintenanceOverride, stmt,
+		)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			keys[tree.AsStringWithFlags(&row, tree.FmtParsable)] = row
+		}
+		return nil
+	})
+}
+
+// recompute replaces the contents of the view with the result of the view
+// query.
+func (m *incrementalViewMaintainer) recompute(ctx context.Context) error {
+	return m.execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		for _, stmt := range []tree.Statement{m.def.DeleteAll(m.viewID), m.def.InsertAll(m.viewID)} {
+			if _, err := txn.ExecEx(
+				ctx, "incremental-view-recompute", txn.KV(), incrementalViewMaintenanceOverride,
+				tree.AsStringWithFlags(stmt, tree.FmtParsable),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// decodePrimaryKey decodes the primary key of a row of the ith source from one
+// of its keys in the primary index.
+func (m *incrementalViewMaintainer) decodePrimaryKey(
+	i int, key roachpb.Key, alloc *tree.DatumAlloc,
+) (tree.Datums, error) {
+	tab := m.tables[i]
+	pk := tab.GetPrimaryIndex()
+	vals := make([]rowenc.EncDatum, pk.NumKeyColumns())
+	dirs := make([]catenumpb.IndexColumn_Direction, pk.NumKeyColumns())
+	for j := range dirs {
+		dirs[j] = pk.GetKeyColumnDirection(j)
+	}
+	if _, err := rowenc.DecodeIndexKey(m.execCfg.Codec, vals, dirs, key); err != nil {
+		return nil, err
+	}
+	res := make(tree.Datums, len(vals))
+	for j := range vals {
+		col, err := catalog.MustFindColumnByID(tab, pk.GetKeyColumnID(j))
+		if err != nil {
+			return nil, err
+		}
+		if colinfo.CanHaveCompositeKeyEncoding(col.GetType()) {
+			return nil, errors.Newf("column %s of table %s has a composite encoding", col.GetName(), tab.GetName())
+		}
+		if err := vals[j].EnsureDecoded(col.GetType(), alloc); err != nil {
+			return nil, err
+		}
+		res[j] = vals[j].Datum
+	}
+	return res, nil
+}
+
+// incrementalViewChange is a change of a row of a base table of an
+// incrementally maintained view.
+type incrementalViewChange struct {
+	// source is the ordinal of the table among the sources of the view.
+	source int
+	ts     hlc.Timestamp
+	// rowKey is the prefix of the keys of the row in the primary index.
+	rowKey string
+}
+
+// incrementalViewChangeBuffer buffers the changes of the base tables of an
+// incrementally maintained view that are received by a rangefeed.
+type incrementalViewChangeBuffer struct {
+	codec keys.SQLCodec
+	// tableIDs contains the ID of each source of the view.
+	tableIDs []descpb.ID
+
+	mu struct {
+		syncutil.Mutex
+		frontier hlc.Timestamp
+		changes  map[incrementalViewChange]struct{}
+		// invalidatedAt is the earliest time at which the base tables were
+		// changed in a way that cannot be applied as a delta, e.g. by ingesting
+		// SSTables.
+		invalidatedAt hlc.Timestamp
+	}
+}
+
+func (b *incrementalViewChangeBuffer) onValue(ctx context.Context, ev *kvpb.RangeFeedValue) {
+	_, tableID, err := b.codec.DecodeTablePrefix(ev.Key)
+	if err != nil {
+		b.invalidate(ev.Value.Timestamp)
+		return
+	}
+	n, err := keys.GetRowPrefixLength(ev.Key)
+	if err != nil {
+		b.invalidate(ev.Value.Timestamp)
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, id := range b.tableIDs {
+		if id == descpb.ID(tableID) {
+			b.mu.changes[incrementalViewChange{
+				source: i,
+				ts:     ev.Value.Timestamp,
+				rowKey: string(ev.Key[:n]),
+			}] = struct{}{}
+			return
+		}
+	}
+}
+
+func (b *incrementalViewChangeBuffer) onFrontierAdvance(ctx context.Context, ts hlc.Timestamp) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.mu.frontier.Forward(ts)
+}
+
+// invalidate records that the base tables were changed at the given time in a
+// way that requires the view to be recomputed.
+func (b *incrementalViewChangeBuffer) invalidate(ts hlc.Timestamp) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.mu.invalidatedAt.IsEmpty() || ts.Less(b.mu.invalidatedAt) {
+		b.mu.invalidatedAt = ts
+	}
+}
+
+// take removes the changes up to the frontier from the buffer and returns them
+// along with the frontier. It returns invalidated=true instead of the changes
+// if the view must be recomputed, either because of a change that cannot be
+// applied as a delta or because there are more than maxChanges changes.
+func (b *incrementalViewChangeBuffer) take(
+	maxChanges int,
+) (frontier hlc.Timestamp, changes []incrementalViewChange, invalidated bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	frontier = b.mu.frontier
+	for c := range b.mu.changes {
+		if c.ts.LessEq(frontier) {
+			changes = append(changes, c)
+			delete(b.mu.changes, c)
+		}
+	}
+	if b.mu.invalidatedAt.IsSet() && b.mu.invalidatedAt.LessEq(frontier) {
+		b.mu.invalidatedAt = hlc.Timestamp{}
+		return frontier, nil, true
+	}
+	if len(changes) > maxChanges {
+		return frontier, nil, true
+	}
+	return frontier, changes, false
+}
+
+func init() {
+	jobs.RegisterConstructor(
+		jobspb.TypeIncrementalViewMaintenance,
+		func(job *jobs.Job, _ *cluster.Settings) jobs.Resumer {
+			return &incrementalViewMaintenanceResumer{job: job}
+		},
+		jobs.UsesTenantCostControl,
+	)
+}
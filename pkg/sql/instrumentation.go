@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
@@ -180,6 +181,10 @@ type instrumentationHelper struct {
 	indexRecs []indexrec.Rec
 	// explainIndexRecs contains index recommendations for EXPLAIN statements.
 	explainIndexRecs []indexrec.Rec
+	// explainHypotheticalIndexes contains the hypothetical indexes and the
+	// estimated costs with and without them for EXPLAIN (HYPOTHETICAL INDEXES
+	// ...) statements.
+	explainHypotheticalIndexes *explainHypotheticalIndexes
 
 	// maxFullScanRows is the maximum number of rows scanned by a full scan, as
 	// estimated by the optimizer.
@@ -248,6 +253,10 @@ type instrumentationHelper struct {
 	// schemachangerMode indicates which schema changer mode was used to execute
 	// the query.
 	schemaChangerMode schemaChangerMode
+
+	// reoptimizations describes the materialized CTEs whose row counts caused
+	// the main query to be re-optimized, if it was.
+	reoptimizations []reoptimization
 }
 
 // outputMode indicates how the statement output needs to be populated (for
@@ -894,6 +903,9 @@ func (ih *instrumentationHelper) emitExplainAnalyzePlanToOutputBuilder(
 	ob.AddDistribution(ih.distribution.String())
 	ob.AddVectorized(ih.vectorized)
 	ob.AddPlanType(ih.generic, ih.optimized)
+	for _, r := range ih.reoptimizations {
+		ob.AddReoptimization(r.label, r.estimatedRowCount, uint64(r.actualRowCount))
+	}
 	ob.AddTableStatsMode(ih.tableStatsRollout.String())
 	ob.AddStmtHintCount(ih.stmtHints, ih.runtimeHintErrors)
 	ob.AddRetryCount("transaction", ih.retryCount)
@@ -934,6 +946,13 @@ func (ih *instrumentationHelper) emitExplainAnalyzePlanToOutputBuilder(
 		if grunning.Supported {
 			ob.AddKVCPUTime(ih.topLevelStats.kvCPUTimeNanos)
 		}
+		if wb := ih.topLevelStats.writeBufferStats; !wb.Empty() {
+			var flushReason string
+			if wb.FlushReason != kv.WriteBufferNotFlushed {
+				flushReason = wb.FlushReason.String()
+			}
+			ob.AddWriteBufferStats(wb.BufferedWrites, wb.FlushedWrites, wb.ReadHits, wb.BufferedBytes, flushReason)
+		}
 		if !ih.containsMutation && ih.vectorized && grunning.Supported {
 			// Currently we cannot separate SQL CPU time from local KV CPU time for
 			// mutations, since they do not collect statistics. Additionally, CPU time
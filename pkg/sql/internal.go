@@ -993,6 +993,9 @@ func applyOverrides(o sessiondata.InternalExecutorOverride, sd *sessiondata.Sess
 	if o.NewSchemaChangerMode != nil {
 		sd.NewSchemaChangerMode = *o.NewSchemaChangerMode
 	}
+	if o.AllowMaterializedViewMutation {
+		sd.AllowMaterializedViewMutation = true
+	}
 	// For 25.2, we're being conservative and explicitly disabling buffered
 	// writes for the internal executor.
 	// TODO(yuzefovich): remove this for 25.3.
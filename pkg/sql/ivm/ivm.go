@@ -0,0 +1,491 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package ivm contains the analysis of the queries of incrementally maintained
+// materialized views, and generates the statements that apply changes of the
+// base tables to such views.
+//
+// A view is maintained by recomputing the view rows that are affected by a
+// change to one of its base tables. Every view row is identified by a key:
+// the grouping expressions for a view with a GROUP BY, and the primary key of
+// the changed base table for a view that only selects, projects, and joins.
+// When rows of a base table change, the keys of the view rows that the old and
+// new versions of the rows contribute to are collected, the view rows with
+// those keys are deleted, and the rows for those keys are recomputed from the
+// base tables and inserted. Recomputing a key is idempotent, so it is always
+// safe to process more keys than strictly necessary.
+//
+// The supported queries are a single SELECT over base tables that are
+// combined with inner or cross joins, optionally filtered with WHERE and
+// grouped with GROUP BY using the sum, count, min, and max aggregates.
+package ivm
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// IncrementalParam is the parameter of CREATE MATERIALIZED VIEW that makes
+	// the view incrementally maintained. Its value is either 'sync' or 'async'.
+	IncrementalParam = "incremental"
+
+	// MaxStalenessParam is the parameter of CREATE MATERIALIZED VIEW that bounds
+	// the staleness of an asynchronously maintained view.
+	MaxStalenessParam = "max_staleness"
+)
+
+// IsIncremental returns true if the given parameters of a materialized view
+// make it incrementally maintained.
+func IsIncremental(params tree.StorageParams) bool {
+	for i := range params {
+		if params[i].Key == IncrementalParam {
+			return true
+		}
+	}
+	return false
+}
+
+// Table describes a base table of an incrementally maintained view.
+type Table struct {
+	ID descpb.ID
+
+	// Columns contains the names of the columns of the table that the view
+	// query can reference.
+	Columns []tree.Name
+
+	// PrimaryKey contains the names of the primary key columns of the table.
+	PrimaryKey []tree.Name
+}
+
+func (t *Table) hasColumn(name tree.Name) bool {
+	for _, col := range t.Columns {
+		if col == name {
+			return true
+		}
+	}
+	return false
+}
+
+// TableResolver returns the description of the table with the given name.
+type TableResolver func(ctx context.Context, tn *tree.TableName) (Table, error)
+
+// Source is a reference to a base table in the FROM clause of a view query.
+type Source struct {
+	Table
+
+	// Alias is the name by which the view query refers to the table.
+	Alias tree.Name
+
+	// keyOrds contains the ordinals of the view columns that hold the keys of
+	// the view rows that the rows of the table contribute to.
+	keyOrds []int
+}
+
+// Definition is the analyzed query of an incrementally maintained view.
+type Definition struct {
+	// query is the normalized view query, in which every column reference is
+	// qualified with the alias of its source.
+	query string
+
+	sources []Source
+
+	// aggregate is true if the query has a GROUP BY clause.
+	aggregate bool
+}
+
+// SourceCount returns the number of base tables referenced by the view query.
+func (d *Definition) SourceCount() int {
+	return len(d.sources)
+}
+
+// Source returns the ith base table referenced by the view query.
+func (d *Definition) Source(i int) *Source {
+	return &d.sources[i]
+}
+
+// SourceByTableID returns the ordinal of the source for the table with the
+// given ID, or -1 if the view query does not reference the table.
+func (d *Definition) SourceByTableID(id descpb.ID) int {
+	for i := range d.sources {
+		if d.sources[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsAggregate returns true if the view query has a GROUP BY clause.
+func (d *Definition) IsAggregate() bool {
+	return d.aggregate
+}
+
+// Query returns the normalized view query.
+func (d *Definition) Query() string {
+	return d.query
+}
+
+// supportedAggregates are the aggregate functions whose results can be
+// recomputed for a subset of the groups of a view.
+var supportedAggregates = map[string]struct{}{
+	"count": {},
+	"max":   {},
+	"min":   {},
+	"sum":   {},
+}
+
+func unsupportedf(format string, args ...interface{}) error {
+	return errors.WithHint(
+		pgerror.Newf(pgcode.FeatureNotSupported,
+			"incremental materialized views do not support "+format, args...),
+		"Incremental materialized views support selections, projections, inner "+
+			"joins, and GROUP BY with the sum, count, min, and max aggregates.",
+	)
+}
+
+// Analyze checks that the given view query can be maintained incrementally,
+// and returns its definition. The query must only reference tables with
+// fully-resolved names, as stored in the view descriptor.
+func Analyze(ctx context.Context, query string, resolve TableResolver) (*Definition, error) {
+	sel, sc, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if sc.Distinct || sc.DistinctOn != nil {
+		return nil, unsupportedf("DISTINCT")
+	}
+	if len(sc.Window) > 0 {
+		return nil, unsupportedf("window functions")
+	}
+	if sc.TableSelect {
+		return nil, unsupportedf("TABLE statements")
+	}
+
+	d := &Definition{aggregate: len(sc.GroupBy) > 0}
+	for _, te := range sc.From.Tables {
+		if err := d.collectSources(ctx, te, resolve); err != nil {
+			return nil, err
+		}
+	}
+	if len(d.sources) == 0 {
+		return nil, unsupportedf("queries without a FROM clause")
+	}
+
+	// Qualify all column references, so that the statements generated from the
+	// query can substitute the sources and add correlated subqueries.
+	var hasAggregates bool
+	for i := range sc.Exprs {
+		if sc.Exprs[i].Expr, err = d.normalizeExpr(sc.Exprs[i].Expr, &hasAggregates); err != nil {
+			return nil, err
+		}
+	}
+	var noAggregates bool
+	if sc.Where != nil {
+		if sc.Where.Expr, err = d.normalizeExpr(sc.Where.Expr, &noAggregates); err != nil {
+			return nil, err
+		}
+	}
+	if err := d.normalizeJoinConds(sc.From.Tables, &noAggregates); err != nil {
+		return nil, err
+	}
+	if sc.Having != nil {
+		if sc.Having.Expr, err = d.normalizeExpr(sc.Having.Expr, &hasAggregates); err != nil {
+			return nil, err
+		}
+	}
+	if noAggregates {
+		return nil, pgerror.Newf(pgcode.Grouping,
+			"aggregate functions are not allowed in WHERE or JOIN conditions")
+	}
+	if hasAggregates && !d.aggregate {
+		return nil, unsupportedf("aggregates without GROUP BY")
+	}
+
+	if d.aggregate {
+		// Every grouping expression must be a column of the view, since the
+		// grouping expressions form the key of the view rows.
+		keyOrds := make([]int, len(sc.GroupBy))
+		for i, e := range sc.GroupBy {
+			ord, err := groupByOrdinal(sc, e)
+			if err != nil {
+				return nil, err
+			}
+			if ord < 0 {
+				if e, err = d.normalizeExpr(e, &noAggregates); err != nil {
+					return nil, err
+				}
+				ord = findSelectExpr(sc, e)
+			}
+			if ord < 0 {
+				return nil, unsupportedf(
+					"GROUP BY expressions that are not in the select list: %s", tree.AsString(e))
+			}
+			keyOrds[i] = ord
+			sc.GroupBy[i] = sc.Exprs[ord].Expr
+		}
+		for i := range d.sources {
+			d.sources[i].keyOrds = keyOrds
+		}
+	} else {
+		// Every row of the view is identified by the primary keys of the rows
+		// it was produced from.
+		for i := range d.sources {
+			src := &d.sources[i]
+			src.keyOrds = make([]int, len(src.PrimaryKey))
+			for j, col := range src.PrimaryKey {
+				ord := findSelectExpr(sc, tree.NewUnresolvedName(string(src.Alias), string(col)))
+				if ord < 0 {
+					return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+						"incremental materialized views without GROUP BY must include the "+
+							"primary key column %s of %s", col, src.Alias)
+				}
+				src.keyOrds[j] = ord
+			}
+		}
+	}
+
+	d.query = tree.AsStringWithFlags(sel, tree.FmtParsable)
+	return d, nil
+}
+
+// parseQuery parses the given view query, and returns the query along with
+// its single SELECT clause.
+func parseQuery(query string) (*tree.Select, *tree.SelectClause, error) {
+	stmt, err := parser.ParseOne(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	sel, ok := stmt.AST.(*tree.Select)
+	if !ok {
+		return nil, nil, errors.AssertionFailedf("expected SELECT, found %s", stmt.AST.StatementTag())
+	}
+	for {
+		if sel.With != nil {
+			return nil, nil, unsupportedf("WITH clauses")
+		}
+		if len(sel.OrderBy) > 0 || sel.Limit != nil {
+			return nil, nil, unsupportedf("ORDER BY or LIMIT")
+		}
+		if len(sel.Locking) > 0 {
+			return nil, nil, unsupportedf("locking clauses")
+		}
+		switch t := sel.Select.(type) {
+		case *tree.SelectClause:
+			return sel, t, nil
+		case *tree.ParenSelect:
+			sel = t.Select
+		case *tree.UnionClause:
+			return nil, nil, unsupportedf("%s", t.Type)
+		default:
+			return nil, nil, unsupportedf("%s", stmt.AST.StatementTag())
+		}
+	}
+}
+
+// collectSources adds the tables referenced by the given FROM clause entry to
+// the sources of the definition.
+func (d *Definition) collectSources(
+	ctx context.Context, te tree.TableExpr, resolve TableResolver,
+) error {
+	switch t := te.(type) {
+	case *tree.AliasedTableExpr:
+		tn, ok := t.Expr.(*tree.TableName)
+		if !ok {
+			return unsupportedf("subqueries or functions in the FROM clause")
+		}
+		if t.Ordinality || t.Lateral || len(t.As.Cols) > 0 {
+			return unsupportedf("WITH ORDINALITY, LATERAL, or column aliases")
+		}
+		alias := t.As.Alias
+		if alias == "" {
+			alias = tn.ObjectName
+		}
+		tab, err := resolve(ctx, tn)
+		if err != nil {
+			return err
+		}
+		for i := range d.sources {
+			if d.sources[i].ID == tab.ID {
+				return unsupportedf("multiple references to table %s", tn.ObjectName)
+			}
+			if d.sources[i].Alias == alias {
+				return pgerror.Newf(pgcode.DuplicateAlias,
+					"source name %q specified more than once", alias)
+			}
+		}
+		d.sources = append(d.sources, Source{Table: tab, Alias: alias})
+		return nil
+
+	case *tree.TableName:
+		return d.collectSources(ctx, &tree.AliasedTableExpr{Expr: t}, resolve)
+
+	case *tree.ParenTableExpr:
+		return d.collectSources(ctx, t.Expr, resolve)
+
+	case *tree.JoinTableExpr:
+		switch t.JoinType {
+		case "", tree.AstInner, tree.AstCross:
+		default:
+			return unsupportedf("%s JOIN", t.JoinType)
+		}
+		switch t.Cond.(type) {
+		case nil, *tree.OnJoinCond:
+		default:
+			return unsupportedf("NATURAL or USING join conditions")
+		}
+		if err := d.collectSources(ctx, t.Left, resolve); err != nil {
+			return err
+		}
+		return d.collectSources(ctx, t.Right, resolve)
+
+	default:
+		return unsupportedf("%s in the FROM clause", tree.AsString(te))
+	}
+}
+
+// normalizeJoinConds normalizes the ON conditions of the joins in the given
+// FROM clause.
+func (d *Definition) normalizeJoinConds(tables tree.TableExprs, hasAggregates *bool) error {
+	for _, te := range tables {
+		var err error
+		switch t := te.(type) {
+		case *tree.ParenTableExpr:
+			err = d.normalizeJoinConds(tree.TableExprs{t.Expr}, hasAggregates)
+		case *tree.JoinTableExpr:
+			if on, ok := t.Cond.(*tree.OnJoinCond); ok {
+				if on.Expr, err = d.normalizeExpr(on.Expr, hasAggregates); err != nil {
+					return err
+				}
+			}
+			err = d.normalizeJoinConds(tree.TableExprs{t.Left, t.Right}, hasAggregates)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeExpr qualifies the column references in the given expression with
+// the aliases of their sources, and checks that the expression only uses
+// supported functions. hasAggregates is set if the expression contains an
+// aggregate function.
+func (d *Definition) normalizeExpr(expr tree.Expr, hasAggregates *bool) (tree.Expr, error) {
+	return tree.SimpleVisit(expr, func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		switch t := expr.(type) {
+		case *tree.Subquery:
+			return false, nil, unsupportedf("subqueries")
+
+		case *tree.UnresolvedName:
+			if t.Star {
+				return false, nil, unsupportedf("star expansions")
+			}
+			col := tree.Name(t.Parts[0])
+			if t.NumParts > 1 {
+				// Drop the schema and database qualifications, which are redundant
+				// since a table can be referenced only once.
+				for i := range d.sources {
+					if string(d.sources[i].Alias) == t.Parts[1] {
+						return false, tree.NewUnresolvedName(t.Parts[1], t.Parts[0]), nil
+					}
+				}
+				return false, expr, nil
+			}
+			var alias tree.Name
+			for i := range d.sources {
+				if d.sources[i].hasColumn(col) {
+					if alias != "" {
+						return false, nil, pgerror.Newf(pgcode.AmbiguousColumn,
+							"column reference %q is ambiguous", col)
+					}
+					alias = d.sources[i].Alias
+				}
+			}
+			if alias == "" {
+				// This may be a reference to a column of the view in GROUP BY or
+				// HAVING; leave it to the optimizer to resolve.
+				return false, expr, nil
+			}
+			return false, tree.NewUnresolvedName(string(alias), string(col)), nil
+
+		case *tree.FuncExpr:
+			if t.WindowDef != nil {
+				return false, nil, unsupportedf("window functions")
+			}
+			un, ok := t.Func.FunctionReference.(*tree.UnresolvedName)
+			if !ok {
+				return true, expr, nil
+			}
+			fn, err := un.ToRoutineName()
+			if err != nil {
+				return false, nil, err
+			}
+			name := fn.Object()
+			if _, ok := supportedAggregates[name]; ok {
+				if t.Type == tree.DistinctFuncType || t.Filter != nil || len(t.OrderBy) > 0 {
+					return false, nil, unsupportedf("DISTINCT, FILTER, or ORDER BY in aggregates")
+				}
+				*hasAggregates = true
+				return true, expr, nil
+			}
+			if def, ok := tree.FunDefs[name]; ok {
+				for _, o := range def.Definition {
+					switch o.Class {
+					case tree.AggregateClass:
+						return false, nil, unsupportedf("aggregate function %s", name)
+					case tree.WindowClass, tree.GeneratorClass:
+						return false, nil, unsupportedf("function %s", name)
+					}
+				}
+			}
+		}
+		return true, expr, nil
+	})
+}
+
+// groupByOrdinal returns the ordinal of the select expression that the given
+// GROUP BY expression refers to by position or by alias, or -1 if it is not
+// such a reference.
+func groupByOrdinal(sc *tree.SelectClause, e tree.Expr) (int, error) {
+	switch t := e.(type) {
+	case *tree.NumVal:
+		pos, err := t.AsInt64()
+		if err != nil {
+			return -1, err
+		}
+		if pos < 1 || pos > int64(len(sc.Exprs)) {
+			return -1, pgerror.Newf(pgcode.InvalidColumnReference,
+				"GROUP BY position %d is not in select list", pos)
+		}
+		return int(pos - 1), nil
+	case *tree.UnresolvedName:
+		if t.NumParts == 1 && !t.Star {
+			for i := range sc.Exprs {
+				if string(sc.Exprs[i].As) == t.Parts[0] {
+					return i, nil
+				}
+			}
+		}
+	}
+	return -1, nil
+}
+
+// findSelectExpr returns the ordinal of the first select expression that is
+// equal to the given expression, or -1 if there is none.
+func findSelectExpr(sc *tree.SelectClause, e tree.Expr) int {
+	s := tree.AsStringWithFlags(e, tree.FmtParsable)
+	for i := range sc.Exprs {
+		if tree.AsStringWithFlags(sc.Exprs[i].Expr, tree.FmtParsable) == s {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package ivm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	_ "github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func testResolver(ctx context.Context, tn *tree.TableName) (Table, error) {
+	switch tn.ObjectName {
+	case "t":
+		return Table{
+			ID:         100,
+			Columns:    []tree.Name{"k", "g", "v"},
+			PrimaryKey: []tree.Name{"k"},
+		}, nil
+	case "u":
+		return Table{
+			ID:         101,
+			Columns:    []tree.Name{"a", "b", "w"},
+			PrimaryKey: []tree.Name{"a", "b"},
+		}, nil
+	}
+	return Table{}, errors.Newf("table %s not found", tn.ObjectName)
+}
+
+func TestAnalyze(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	for _, tc := range []struct {
+		query     string
+		aggregate bool
+		err       string
+	}{
+		{query: "SELECT k, v FROM db.public.t WHERE v > 0"},
+		{query: "SELECT t.k, u.a, u.b, w FROM db.public.t JOIN db.public.u ON t.k = u.a"},
+		{query: "SELECT k, x.a, x.b FROM db.public.t, db.public.u AS x"},
+		{query: "SELECT g, sum(v), count(*) FROM db.public.t GROUP BY g", aggregate: true},
+		{query: "SELECT g AS grp, max(w) FROM db.public.t JOIN db.public.u ON k = a GROUP BY 1", aggregate: true},
+		{query: "SELECT g AS grp, min(v) FROM db.public.t GROUP BY grp HAVING min(v) > 1", aggregate: true},
+		{query: "SELECT v FROM db.public.t", err: "must include the primary key column k of t"},
+		{query: "SELECT k, a FROM db.public.t JOIN db.public.u ON k = a", err: "primary key column b of u"},
+		{query: "SELECT DISTINCT k FROM db.public.t", err: "do not support DISTINCT"},
+		{query: "SELECT k FROM db.public.t LEFT JOIN db.public.u ON k = a", err: "do not support LEFT JOIN"},
+		{query: "SELECT k FROM db.public.t JOIN db.public.u USING (k)", err: "NATURAL or USING"},
+		{query: "SELECT sum(v) FROM db.public.t", err: "aggregates without GROUP BY"},
+		{query: "SELECT sum(v) FROM db.public.t GROUP BY g", err: "GROUP BY expressions that are not in the select list"},
+		{query: "SELECT g, avg(v) FROM db.public.t GROUP BY g", err: "do not support aggregate function avg"},
+		{query: "SELECT g, count(DISTINCT v) FROM db.public.t GROUP BY g", err: "DISTINCT, FILTER, or ORDER BY"},
+		{query: "SELECT k FROM db.public.t WHERE v IN (SELECT w FROM db.public.u)", err: "subqueries"},
+		{query: "SELECT k FROM db.public.t ORDER BY k", err: "ORDER BY or LIMIT"},
+		{query: "SELECT k FROM db.public.t UNION SELECT a FROM db.public.u", err: "do not support UNION"},
+		{query: "SELECT x.k FROM db.public.t AS x, db.public.t AS y", err: "multiple references to table t"},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			d, err := Analyze(ctx, tc.query, testResolver)
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.aggregate, d.IsAggregate())
+		})
+	}
+
+	// The error for unsupported queries is a user error.
+	_, err := Analyze(ctx, "SELECT DISTINCT k FROM db.public.t", testResolver)
+	require.Equal(t, "0A000", string(pgerror.GetPGCode(err)))
+}
+
+func TestStatements(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	const view = descpb.ID(110)
+
+	t.Run("aggregate", func(t *testing.T) {
+		d, err := Analyze(ctx, "SELECT g, sum(v) AS s FROM db.public.t GROUP BY g", testResolver)
+		require.NoError(t, err)
+		require.Equal(t, "SELECT t.g, sum(t.v) AS s FROM db.public.t GROUP BY t.g", d.Query())
+		require.Equal(t, 0, d.SourceByTableID(100))
+		require.Equal(t, -1, d.SourceByTableID(101))
+
+		delta := tree.NewUnqualifiedTableName("crdb_internal_ivm_delta")
+		keys := d.KeysFromRows(0, delta)
+		require.Equal(t,
+			"SELECT DISTINCT t.g AS key_1 FROM crdb_internal_ivm_delta AS t",
+			tree.AsString(keys),
+		)
+		require.Equal(t,
+			"DELETE FROM [110 AS crdb_internal_ivm_view] WHERE EXISTS ("+
+				"SELECT 1 FROM (SELECT DISTINCT t.g AS key_1 FROM crdb_internal_ivm_delta AS t) "+
+				"AS crdb_internal_ivm_keys (key_1) "+
+				"WHERE crdb_internal_ivm_view.g IS NOT DISTINCT FROM crdb_internal_ivm_keys.key_1)",
+			tree.AsString(d.DeleteForKeys(0, view, tree.NameList{"g", "s"}, keys)),
+		)
+		require.Equal(t,
+			"INSERT INTO [110 AS crdb_internal_ivm_view] "+
+				"SELECT t.g, sum(t.v) AS s FROM db.public.t WHERE EXISTS ("+
+				"SELECT 1 FROM (SELECT DISTINCT t.g AS key_1 FROM crdb_internal_ivm_delta AS t) "+
+				"AS crdb_internal_ivm_keys (key_1) "+
+				"WHERE t.g IS NOT DISTINCT FROM crdb_internal_ivm_keys.key_1) GROUP BY t.g",
+			tree.AsString(d.InsertForKeys(0, view, keys)),
+		)
+	})
+
+	t.Run("join", func(t *testing.T) {
+		d, err := Analyze(ctx,
+			"SELECT k, a, b, w FROM db.public.t JOIN db.public.u ON k = a WHERE v > 0", testResolver,
+		)
+		require.NoError(t, err)
+		require.Equal(t, 2, d.SourceCount())
+		require.Equal(t, 1, d.SourceByTableID(101))
+
+		pks := []tree.Datums{
+			{tree.NewDInt(1), tree.NewDInt(2)},
+			{tree.NewDInt(3), tree.NewDInt(4)},
+		}
+		require.Equal(t,
+			"SELECT DISTINCT u.a AS key_1, u.b AS key_2 "+
+				"FROM db.public.t JOIN db.public.u ON t.k = u.a "+
+				"WHERE (t.v > 0) AND ((u.a, u.b) IN ((1, 2), (3, 4)))",
+			tree.AsString(d.KeysFromPrimaryKeys(1, pks)),
+		)
+		require.Equal(t,
+			"SELECT DISTINCT t.k AS key_1 "+
+				"FROM crdb_internal_ivm_delta AS t JOIN db.public.u ON t.k = u.a WHERE t.v > 0",
+			tree.AsString(d.KeysFromRows(0, tree.NewUnqualifiedTableName("crdb_internal_ivm_delta"))),
+		)
+		require.Equal(t,
+			"DELETE FROM [110 AS crdb_internal_ivm_view]",
+			tree.AsString(d.DeleteAll(view)),
+		)
+		require.Equal(t,
+			"INSERT INTO [110 AS crdb_internal_ivm_view] "+
+				"SELECT t.k, u.a, u.b, u.w FROM db.public.t JOIN db.public.u ON t.k = u.a WHERE t.v > 0",
+			tree.AsString(d.InsertAll(view)),
+		)
+	})
+}
@@ -0,0 +1,245 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package ivm
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// keysAlias is the alias of the set of keys in the generated statements.
+	keysAlias = "crdb_internal_ivm_keys"
+
+	// viewAlias is the alias of the view in the generated statements.
+	viewAlias = "crdb_internal_ivm_view"
+)
+
+// keyColName returns the name of the ith column of a set of keys.
+func keyColName(i int) tree.Name {
+	return tree.Name(fmt.Sprintf("key_%d", i+1))
+}
+
+// parse returns a new copy of the normalized view query, which the statement
+// generators can modify.
+func (d *Definition) parse() (*tree.Select, *tree.SelectClause) {
+	sel, sc, err := parseQuery(d.query)
+	if err != nil {
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "failed to parse %s", d.query))
+	}
+	return sel, sc
+}
+
+// keysSelect turns the given copy of the view query into a query that returns
+// the distinct keys of the view rows that the rows of the ith source
+// contribute to.
+func (d *Definition) keysSelect(i int, sc *tree.SelectClause) {
+	keyOrds := d.sources[i].keyOrds
+	exprs := make(tree.SelectExprs, len(keyOrds))
+	for j, ord := range keyOrds {
+		exprs[j] = tree.SelectExpr{Expr: sc.Exprs[ord].Expr, As: tree.UnrestrictedName(keyColName(j))}
+	}
+	sc.Exprs = exprs
+	sc.Distinct = true
+	sc.GroupBy = nil
+	sc.Having = nil
+}
+
+// KeysFromRows returns a query that produces the keys of the view rows that the
+// given rows of the ith source contribute to. The rows must have the columns
+// of the source table, and may be either old or new versions of changed rows.
+func (d *Definition) KeysFromRows(i int, rows tree.TableExpr) *tree.Select {
+	sel, sc := d.parse()
+	found := replaceSource(sc.From.Tables, d.sources[i].Alias, rows)
+	if !found {
+		panic(errors.AssertionFailedf("source %s not found in %s", d.sources[i].Alias, d.query))
+	}
+	d.keysSelect(i, sc)
+	return sel
+}
+
+// KeysFromPrimaryKeys returns a query that produces the keys of the view rows
+// that the rows of the ith source with the given primary keys contribute to.
+// The query reads the current version of the rows, so it must be run as of the
+// time of the version of interest.
+func (d *Definition) KeysFromPrimaryKeys(i int, pks []tree.Datums) *tree.Select {
+	sel, sc := d.parse()
+	src := &d.sources[i]
+	var left tree.Expr
+	right := make(tree.Exprs, len(pks))
+	if len(src.PrimaryKey) == 1 {
+		left = tree.NewUnresolvedName(string(src.Alias), string(src.PrimaryKey[0]))
+		for j := range pks {
+			right[j] = pks[j][0]
+		}
+	} else {
+		cols := make(tree.Exprs, len(src.PrimaryKey))
+		for j, col := range src.PrimaryKey {
+			cols[j] = tree.NewUnresolvedName(string(src.Alias), string(col))
+		}
+		left = &tree.Tuple{Exprs: cols}
+		for j := range pks {
+			vals := make(tree.Exprs, len(pks[j]))
+			for k := range pks[j] {
+				vals[k] = pks[j][k]
+			}
+			right[j] = &tree.Tuple{Exprs: vals}
+		}
+	}
+	addFilter(sc, &tree.ComparisonExpr{
+		Operator: treecmp.MakeComparisonOperator(treecmp.In),
+		Left:     left,
+		Right:    &tree.Tuple{Exprs: right},
+	})
+	d.keysSelect(i, sc)
+	return sel
+}
+
+// RowsForKeys returns a query that recomputes the view rows with the keys
+// produced by the given query, which must have been generated for the ith
+// source.
+func (d *Definition) RowsForKeys(i int, keys *tree.Select) *tree.Select {
+	sel, sc := d.parse()
+	keyOrds := d.sources[i].keyOrds
+	keyExprs := make(tree.Exprs, len(keyOrds))
+	for j, ord := range keyOrds {
+		keyExprs[j] = sc.Exprs[ord].Expr
+	}
+	addFilter(sc, keysExist(keyExprs, keys))
+	return sel
+}
+
+// DeleteForKeys returns a statement that deletes the rows of the given view
+// with the keys produced by the given query, which must have been generated
+// for the ith source. viewCols are the names of the columns of the view.
+func (d *Definition) DeleteForKeys(
+	i int, view descpb.ID, viewCols tree.NameList, keys *tree.Select,
+) *tree.Delete {
+	keyOrds := d.sources[i].keyOrds
+	keyExprs := make(tree.Exprs, len(keyOrds))
+	for j, ord := range keyOrds {
+		keyExprs[j] = tree.NewUnresolvedName(viewAlias, string(viewCols[ord]))
+	}
+	return &tree.Delete{
+		Table:     viewRef(view),
+		Where:     tree.NewWhere(tree.AstWhere, keysExist(keyExprs, keys)),
+		Returning: tree.AbsentReturningClause,
+	}
+}
+
+// InsertForKeys returns a statement that inserts the recomputed rows of the
+// given view with the keys produced by the given query, which must have been
+// generated for the ith source.
+func (d *Definition) InsertForKeys(i int, view descpb.ID, keys *tree.Select) *tree.Insert {
+	return &tree.Insert{
+		Table:     viewRef(view),
+		Rows:      d.RowsForKeys(i, keys),
+		Returning: tree.AbsentReturningClause,
+	}
+}
+
+// DeleteAll returns a statement that deletes all rows of the given view.
+func (d *Definition) DeleteAll(view descpb.ID) *tree.Delete {
+	return &tree.Delete{Table: viewRef(view), Returning: tree.AbsentReturningClause}
+}
+
+// InsertAll returns a statement that inserts all rows of the view query into
+// the given view.
+func (d *Definition) InsertAll(view descpb.ID) *tree.Insert {
+	sel, _ := d.parse()
+	return &tree.Insert{Table: viewRef(view), Rows: sel, Returning: tree.AbsentReturningClause}
+}
+
+func viewRef(view descpb.ID) *tree.TableRef {
+	return &tree.TableRef{TableID: int64(view), As: tree.AliasClause{Alias: viewAlias}}
+}
+
+// keysExist returns an EXISTS subquery that checks whether the given key
+// expressions match one of the keys produced by the given query. The keys
+// are compared with IS NOT DISTINCT FROM, since NULL is a valid grouping key.
+func keysExist(keyExprs tree.Exprs, keys *tree.Select) tree.Expr {
+	cols := make(tree.ColumnDefList, len(keyExprs))
+	var cond tree.Expr
+	for j := range keyExprs {
+		cols[j].Name = keyColName(j)
+		eq := &tree.ComparisonExpr{
+			Operator: treecmp.MakeComparisonOperator(treecmp.IsNotDistinctFrom),
+			Left:     keyExprs[j],
+			Right:    tree.NewUnresolvedName(keysAlias, string(keyColName(j))),
+		}
+		if cond == nil {
+			cond = eq
+		} else {
+			cond = &tree.AndExpr{Left: cond, Right: eq}
+		}
+	}
+	return &tree.Subquery{
+		Exists: true,
+		Select: &tree.ParenSelect{Select: &tree.Select{
+			Select: &tree.SelectClause{
+				Exprs: tree.SelectExprs{{Expr: tree.NewDInt(1)}},
+				From: tree.From{Tables: tree.TableExprs{&tree.AliasedTableExpr{
+					Expr: &tree.Subquery{Select: &tree.ParenSelect{Select: keys}},
+					As:   tree.AliasClause{Alias: keysAlias, Cols: cols},
+				}}},
+				Where: tree.NewWhere(tree.AstWhere, cond),
+			},
+		}},
+	}
+}
+
+// addFilter adds the given condition to the WHERE clause of the given query.
+func addFilter(sc *tree.SelectClause, cond tree.Expr) {
+	if sc.Where == nil {
+		sc.Where = tree.NewWhere(tree.AstWhere, cond)
+		return
+	}
+	sc.Where.Expr = &tree.AndExpr{
+		Left:  &tree.ParenExpr{Expr: sc.Where.Expr},
+		Right: cond,
+	}
+}
+
+// replaceSource replaces the table with the given alias in the given FROM
+// clause with the given rows, and returns whether the table was found.
+func replaceSource(tables tree.TableExprs, alias tree.Name, rows tree.TableExpr) bool {
+	for i, te := range tables {
+		switch t := te.(type) {
+		case *tree.AliasedTableExpr:
+			tn, ok := t.Expr.(*tree.TableName)
+			if !ok {
+				continue
+			}
+			if t.As.Alias == alias || (t.As.Alias == "" && tn.ObjectName == alias) {
+				t.Expr = rows
+				t.As.Alias = alias
+				return true
+			}
+		case *tree.TableName:
+			if t.ObjectName == alias {
+				tables[i] = &tree.AliasedTableExpr{Expr: rows, As: tree.AliasClause{Alias: alias}}
+				return true
+			}
+		case *tree.ParenTableExpr:
+			exprs := tree.TableExprs{t.Expr}
+			if replaceSource(exprs, alias, rows) {
+				t.Expr = exprs[0]
+				return true
+			}
+		case *tree.JoinTableExpr:
+			exprs := tree.TableExprs{t.Left, t.Right}
+			if replaceSource(exprs, alias, rows) {
+				t.Left, t.Right = exprs[0], exprs[1]
+				return true
+			}
+		}
+	}
+	return false
+}
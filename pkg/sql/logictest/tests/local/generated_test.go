@@ -505,6 +505,13 @@ func TestLogic_column_families(
 	runLogicTest(t, "column_families")
 }
 
+func TestLogic_columnar_index(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "columnar_index")
+}
+
 func TestLogic_comment_on(
 	t *testing.T,
 ) {
@@ -2185,6 +2192,13 @@ func TestLogic_reset(
 	runLogicTest(t, "reset")
 }
 
+func TestLogic_resource_groups(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "resource_groups")
+}
+
 func TestLogic_restore(
 	t *testing.T,
 ) {
@@ -2192,6 +2206,13 @@ func TestLogic_restore(
 	runLogicTest(t, "restore")
 }
 
+func TestLogic_result_cache(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "result_cache")
+}
+
 func TestLogic_retry(
 	t *testing.T,
 ) {
@@ -48,6 +48,9 @@ func buildOpaque(
 		if err := p.checkNoConflictingCursors(stmt); err != nil {
 			return nil, err
 		}
+		if err := p.checkNoReadOnlyTablesModified(ctx, stmt); err != nil {
+			return nil, err
+		}
 		var err error
 		plan, err = p.SchemaChange(ctx, stmt)
 		if err != nil {
@@ -108,6 +111,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 			"See: https://github.com/cockroachdb/cockroach/issues/27796")
 	case *tree.AlterExternalConnection:
 		return p.AlterExternalConnection(ctx, n)
+	case *tree.AlterResourceGroup:
+		return p.AlterResourceGroup(ctx, n)
 	case *tree.AlterFunctionOptions:
 		return p.AlterFunctionOptions(ctx, n)
 	case *tree.AlterRoutineRename:
@@ -136,6 +141,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.AlterTableOwner(ctx, n)
 	case *tree.AlterTableSetLogged:
 		return p.AlterTableSetLogged(ctx, n)
+	case *tree.AlterTableSetReadOnly:
+		return p.AlterTableSetReadOnly(ctx, n)
 	case *tree.AlterTableSetSchema:
 		return p.AlterTableSetSchema(ctx, n)
 	case *tree.AlterViewSetOptions:
@@ -203,12 +210,16 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.CreateLanguage(ctx, n)
 	case *tree.CreateExternalConnection:
 		return p.CreateExternalConnection(ctx, n)
+	case *tree.CreateResourceGroup:
+		return p.CreateResourceGroup(ctx, n)
 	case *tree.CreateTenant:
 		return p.CreateTenantNode(ctx, n)
 	case *tree.CheckExternalConnection:
 		return p.CheckExternalConnection(ctx, n)
 	case *tree.DropExternalConnection:
 		return p.DropExternalConnection(ctx, n)
+	case *tree.DropResourceGroup:
+		return p.DropResourceGroup(ctx, n)
 	case *tree.Deallocate:
 		return p.Deallocate(ctx, n)
 	case *tree.DeclareCursor:
@@ -311,6 +322,10 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.ShowZoneConfig(ctx, n)
 	case *tree.ShowFingerprints:
 		return p.ShowFingerprints(ctx, n)
+	case *tree.ShowPlanBaselines:
+		return p.ShowPlanBaselines(ctx, n)
+	case *tree.ShowResourceGroups:
+		return p.ShowResourceGroups(ctx, n)
 	case *tree.ShowStatementHints:
 		return p.ShowStatementHints(ctx, n)
 	case *tree.ShowTransactionStatus:
@@ -379,6 +394,7 @@ func init() {
 		&tree.AlterTableLocality{},
 		&tree.AlterTableOwner{},
 		&tree.AlterTableSetLogged{},
+		&tree.AlterTableSetReadOnly{},
 		&tree.AlterTableSetSchema{},
 		&tree.AlterViewSetOptions{},
 		&tree.AlterViewResetOptions{},
@@ -405,6 +421,8 @@ func init() {
 		&tree.CreateLanguage{},
 		&tree.CreateExternalConnection{},
 		&tree.AlterExternalConnection{},
+		&tree.CreateResourceGroup{},
+		&tree.AlterResourceGroup{},
 		&tree.CreateTenant{},
 		&tree.CreateIndex{},
 		&tree.CreatePolicy{},
@@ -418,6 +436,7 @@ func init() {
 		&tree.Discard{},
 		&tree.DropDatabase{},
 		&tree.DropExternalConnection{},
+		&tree.DropResourceGroup{},
 		&tree.DropRoutine{},
 		&tree.DropTrigger{},
 		&tree.DropIndex{},
@@ -465,6 +484,8 @@ func init() {
 		&tree.ShowTraceForSession{},
 		&tree.ShowZoneConfig{},
 		&tree.ShowFingerprints{},
+		&tree.ShowPlanBaselines{},
+		&tree.ShowResourceGroups{},
 		&tree.ShowStatementHints{},
 		&tree.ShowVar{},
 		&tree.ShowTransactionStatus{},
@@ -483,10 +504,13 @@ func init() {
 		// These also use the planHook.
 		&tree.AlterBackup{},
 		&tree.AlterBackupSchedule{},
+		&tree.AlterTableRevert{},
 		&tree.AlterTenantReplication{},
 		&tree.AlterTenantReset{},
 		&tree.Backup{},
+		&tree.CopyBackup{},
 		&tree.ShowBackup{},
+		&tree.ShowBackupScheduleForecast{},
 		&tree.Restore{},
 		&tree.CreateChangefeed{},
 		&tree.ScheduledChangefeed{},
@@ -47,6 +47,10 @@ type Table interface {
 	// that they cannot be mutated.
 	IsMaterializedView() bool
 
+	// IsReadOnly returns true if the table has been set READ ONLY, in which
+	// case it cannot be mutated.
+	IsReadOnly() bool
+
 	// LookupColumnOrdinal returns the ordinal of the column with the given ID.
 	LookupColumnOrdinal(colID descpb.ColumnID) (int, error)
 
@@ -200,6 +204,19 @@ type Table interface {
 	// Trigger returns the ith trigger, where i < TriggerCount.
 	Trigger(i int) Trigger
 
+	// IncrementalViewCount returns the number of incrementally maintained
+	// materialized views that must be updated by the statements that modify
+	// this table.
+	IncrementalViewCount() int
+
+	// IncrementalView returns the ID of the ith incrementally maintained
+	// materialized view, where i < IncrementalViewCount.
+	IncrementalView(i int) StableID
+
+	// MaterializedViewQuery returns the query of a materialized view, or the
+	// empty string if the table is not a materialized view.
+	MaterializedViewQuery() string
+
 	// IsRowLevelSecurityEnabled is true if policies should be applied during the query.
 	IsRowLevelSecurityEnabled() bool
 
@@ -291,6 +308,17 @@ type TableStatistic interface {
 	// inverted index histograms, this will always return types.Bytes.
 	HistogramType() *types.T
 
+	// MostCommonValues returns the most frequent tuples of values of the
+	// columns of a multi-column statistic, sorted by descending frequency. It
+	// is only used for multi-column stats (i.e., when ColumnCount() > 1), and
+	// it is empty if the most common values were not collected.
+	MostCommonValues() []MostCommonValue
+
+	// Dependencies returns the degrees of the functional dependencies between
+	// the columns of a multi-column statistic. It is empty if they were not
+	// collected.
+	Dependencies() []ColumnDependency
+
 	// IsPartial returns true if this statistic was collected with USING EXTREMES
 	// or with a WHERE clause.
 	IsPartial() bool
@@ -327,6 +355,29 @@ type HistogramBucket struct {
 	UpperBound tree.Datum
 }
 
+// MostCommonValue contains a frequent tuple of values of the columns of a
+// multi-column statistic.
+type MostCommonValue struct {
+	// NumEq is the estimated number of rows equal to Values.
+	NumEq float64
+
+	// Values contains the value of each column of the statistic, in the same
+	// order as the columns of the statistic. None of the values are NULL.
+	Values tree.Datums
+}
+
+// ColumnDependency contains the degree of a functional dependency between two
+// columns of a multi-column statistic.
+type ColumnDependency struct {
+	// From and To identify the determinant and dependent columns by their
+	// position in the statistic (see TableStatistic.ColumnOrdinal).
+	From, To int
+
+	// Degree is the fraction of rows, between 0 and 1, for which the value of
+	// the From column determines the value of the To column.
+	Degree float64
+}
+
 // ForeignKeyConstraint represents a foreign key constraint. A foreign key
 // constraint has an origin (or referencing) side and a referenced side. For
 // example:
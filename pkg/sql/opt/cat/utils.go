@@ -136,6 +136,8 @@ func formatCatalogIndex(tab Table, ord int, tp treeprinter.Node, redactableValue
 			indexType = "INVERTED "
 		case idxtype.VECTOR:
 			indexType = "VECTOR "
+		case idxtype.COLUMNAR:
+			indexType = "COLUMNAR "
 		}
 	}
 	mutation := ""
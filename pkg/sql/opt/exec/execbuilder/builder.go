@@ -448,6 +448,74 @@ func (b *Builder) findBuiltWithExpr(id opt.WithID) *builtWithExpr {
 	return nil
 }
 
+// Checkpoint describes a With expression at the root of the built expression.
+// The binding of such an expression is executed as a subquery ahead of the
+// main query, so the number of rows it produces is known before the main query
+// starts executing. This allows the main query to be re-optimized with the
+// observed row count when it differs significantly from the estimate.
+type Checkpoint struct {
+	// ID is the ID of the With expression.
+	ID opt.WithID
+	// Label describes the buffer of the With expression in EXPLAIN output.
+	Label string
+	// SubqueryIdx is the index of the subquery that executes the binding.
+	SubqueryIdx int
+	// EstimatedRowCount is the number of rows that the optimizer estimated the
+	// binding would produce.
+	EstimatedRowCount float64
+
+	outputCols colOrdMap
+	bufferNode exec.Node
+}
+
+// Checkpoints returns the checkpoints of the built plan. It returns nil if the
+// plan has subqueries other than the bindings of the With expressions at the
+// root, in which case the main query cannot be re-optimized on its own. It
+// must be called after Build.
+func (b *Builder) Checkpoints() []Checkpoint {
+	var checkpoints []Checkpoint
+	for e := b.e; ; {
+		with, ok := e.(*memo.WithExpr)
+		if !ok {
+			break
+		}
+		built := b.findBuiltWithExpr(with.ID)
+		if built == nil {
+			return nil
+		}
+		subqueryIdx := slices.IndexFunc(b.subqueries, func(s exec.Subquery) bool {
+			return s.Root == built.bufferNode
+		})
+		if subqueryIdx < 0 {
+			return nil
+		}
+		checkpoints = append(checkpoints, Checkpoint{
+			ID:                with.ID,
+			Label:             withBufferLabel(with),
+			SubqueryIdx:       subqueryIdx,
+			EstimatedRowCount: with.Binding.Relational().Statistics().RowCount,
+			outputCols:        built.outputCols,
+			bufferNode:        built.bufferNode,
+		})
+		e = with.Main
+	}
+	if len(checkpoints) != len(b.subqueries) {
+		return nil
+	}
+	return checkpoints
+}
+
+// AddCheckpoints makes the buffers of the given checkpoints, which were built
+// by another Builder, available to the WithScan expressions built by this
+// Builder. It is used to build a re-optimized main query that reads the rows
+// produced by the bindings which have already been executed. It must be called
+// before Build.
+func (b *Builder) AddCheckpoints(checkpoints []Checkpoint) {
+	for i := range checkpoints {
+		b.addBuiltWithExpr(checkpoints[i].ID, checkpoints[i].outputCols, checkpoints[i].bufferNode)
+	}
+}
+
 // boundedStaleness returns true if this query uses bounded staleness.
 func (b *Builder) boundedStaleness() bool {
 	return b.evalCtx != nil && b.evalCtx.BoundedStaleness()
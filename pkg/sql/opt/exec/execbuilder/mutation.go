@@ -141,7 +141,8 @@ func (b *Builder) buildInsert(ins *memo.InsertExpr) (_ execPlan, outputCols colO
 		checkOrds,
 		ins.UniqueWithTombstoneIndexes,
 		b.allowAutoCommit && len(ins.UniqueChecks) == 0 &&
-			len(ins.FKChecks) == 0 && len(ins.FKCascades) == 0 && ins.AfterTriggers == nil,
+			len(ins.FKChecks) == 0 && len(ins.FKCascades) == 0 && ins.AfterTriggers == nil &&
+			len(ins.IncrementalViews) == 0,
 		ins.VectorInsert,
 	)
 	if err != nil {
@@ -165,6 +166,10 @@ func (b *Builder) buildInsert(ins *memo.InsertExpr) (_ execPlan, outputCols colO
 		return execPlan{}, colOrdMap{}, err
 	}
 
+	if err := b.buildIncrementalViews(ins.WithID, ins.IncrementalViews); err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+
 	return ep, outputCols, nil
 }
 
@@ -188,8 +193,9 @@ func (b *Builder) tryBuildFastPathInsert(
 	if len(ins.UniqueChecks) != len(ins.FastPathUniqueChecks) {
 		return execPlan{}, colOrdMap{}, false, nil
 	}
-	// Do not attempt the fast path if there are any triggers.
-	if ins.AfterTriggers != nil {
+	// Do not attempt the fast path if there are any triggers or incremental
+	// views to maintain.
+	if ins.AfterTriggers != nil || len(ins.IncrementalViews) > 0 {
 		return execPlan{}, colOrdMap{}, false, nil
 	}
 	// Do not attempt the fast path for a vectorized insert.
@@ -464,7 +470,8 @@ func (b *Builder) buildUpdate(upd *memo.UpdateExpr) (_ execPlan, outputCols colO
 	}
 
 	allowAutoCommit := b.allowAutoCommit && len(upd.UniqueChecks) == 0 &&
-		len(upd.FKChecks) == 0 && len(upd.FKCascades) == 0 && upd.AfterTriggers == nil
+		len(upd.FKChecks) == 0 && len(upd.FKCascades) == 0 && upd.AfterTriggers == nil &&
+		len(upd.IncrementalViews) == 0
 	var node exec.Node
 	if upd.Swap {
 		if !checkOrds.Empty() || len(upd.UniqueWithTombstoneIndexes) != 0 {
@@ -514,6 +521,10 @@ func (b *Builder) buildUpdate(upd *memo.UpdateExpr) (_ execPlan, outputCols colO
 		return execPlan{}, colOrdMap{}, err
 	}
 
+	if err := b.buildIncrementalViews(upd.WithID, upd.IncrementalViews); err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+
 	// Construct the output column map.
 	ep := execPlan{root: node}
 	if upd.NeedResults() {
@@ -572,7 +583,8 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (_ execPlan, outputCols colO
 		ups.UniqueWithTombstoneIndexes,
 		lockedIndexes,
 		b.allowAutoCommit && len(ups.UniqueChecks) == 0 &&
-			len(ups.FKChecks) == 0 && len(ups.FKCascades) == 0 && ups.AfterTriggers == nil,
+			len(ups.FKChecks) == 0 && len(ups.FKCascades) == 0 && ups.AfterTriggers == nil &&
+			len(ups.IncrementalViews) == 0,
 	)
 	if err != nil {
 		return execPlan{}, colOrdMap{}, err
@@ -594,6 +606,10 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (_ execPlan, outputCols colO
 		return execPlan{}, colOrdMap{}, err
 	}
 
+	if err := b.buildIncrementalViews(ups.WithID, ups.IncrementalViews); err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+
 	// If UPSERT returns rows, they contain all non-mutation columns from the
 	// table, in the same order they're defined in the table. Each output column
 	// value is taken from an insert, fetch, or update column, depending on the
@@ -643,7 +659,7 @@ func (b *Builder) buildDelete(del *memo.DeleteExpr) (_ execPlan, outputCols colO
 	}
 
 	allowAutoCommit := b.allowAutoCommit && len(del.FKChecks) == 0 &&
-		len(del.FKCascades) == 0 && del.AfterTriggers == nil
+		len(del.FKCascades) == 0 && del.AfterTriggers == nil && len(del.IncrementalViews) == 0
 	var node exec.Node
 	if del.Swap {
 		node, err = b.factory.ConstructDeleteSwap(
@@ -682,6 +698,10 @@ func (b *Builder) buildDelete(del *memo.DeleteExpr) (_ execPlan, outputCols colO
 		return execPlan{}, colOrdMap{}, err
 	}
 
+	if err := b.buildIncrementalViews(del.WithID, del.IncrementalViews); err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+
 	// Construct the output column map.
 	ep := execPlan{root: node}
 	if del.NeedResults() {
@@ -746,6 +766,9 @@ func (b *Builder) tryBuildDeleteRange(del *memo.DeleteExpr) (_ execPlan, ok bool
 	if err := b.buildAfterTriggers(del.WithID, del.AfterTriggers); err != nil {
 		return execPlan{}, false, err
 	}
+	if err := b.buildIncrementalViews(del.WithID, del.IncrementalViews); err != nil {
+		return execPlan{}, false, err
+	}
 	return ep, true, nil
 }
 
@@ -778,7 +801,8 @@ func (b *Builder) buildDeleteRange(del *memo.DeleteExpr) (execPlan, error) {
 				autoCommit = true
 			}
 		}
-		if len(del.FKChecks) > 0 || len(del.FKCascades) > 0 || del.AfterTriggers != nil {
+		if len(del.FKChecks) > 0 || len(del.FKCascades) > 0 || del.AfterTriggers != nil ||
+			len(del.IncrementalViews) > 0 {
 			autoCommit = false
 		}
 	}
@@ -1176,6 +1200,20 @@ func (b *Builder) buildAfterTriggers(withID opt.WithID, triggers *memo.AfterTrig
 	return nil
 }
 
+func (b *Builder) buildIncrementalViews(withID opt.WithID, views memo.IncrementalViews) error {
+	if len(views) == 0 {
+		return nil
+	}
+	vb, err := makePostQueryBuilder(b, withID)
+	if err != nil {
+		return err
+	}
+	for i := range views {
+		b.triggers = append(b.triggers, vb.setupIncrementalView(&views[i])...)
+	}
+	return nil
+}
+
 // forUpdateLocking is the row-level locking mode implicitly used by mutations
 // during their initial row scan, when such locking is deemed desirable. The
 // locking mode is equivalent to that used by a SELECT FOR UPDATE statement,
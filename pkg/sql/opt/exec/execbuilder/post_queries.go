@@ -195,6 +195,35 @@ func (cb *postQueryBuilder) setupTriggers(triggers *memo.AfterTriggers) exec.Pos
 	}
 }
 
+// setupIncrementalView fills in the exec.PostQuery structs that update the
+// given incrementally maintained materialized view. The first deletes the view
+// rows that depend on the modified rows, and the second inserts the recomputed
+// rows.
+func (cb *postQueryBuilder) setupIncrementalView(view *memo.IncrementalView) []exec.PostQuery {
+	setup := func(builder memo.PostQueryBuilder) exec.PostQuery {
+		return exec.PostQuery{
+			IncrementalView: view.View,
+			Buffer:          cb.mutationBuffer,
+			PlanFn: func(
+				ctx context.Context,
+				semaCtx *tree.SemaContext,
+				evalCtx *eval.Context,
+				execFactory exec.Factory,
+				bufferRef exec.Node,
+				numBufferedRows int,
+				allowAutoCommit bool,
+			) (exec.Plan, error) {
+				const actionName redact.SafeString = "incremental view maintenance"
+				return cb.planPostQuery(
+					ctx, semaCtx, evalCtx, execFactory, bufferRef, numBufferedRows, allowAutoCommit,
+					builder, actionName,
+				)
+			},
+		}
+	}
+	return []exec.PostQuery{setup(view.DeleteBuilder), setup(view.InsertBuilder)}
+}
+
 // planPostQuery is used to plan a cascade query or AFTER-trigger. It is NOT run
 // while planning the query; it is run by the execution logic (through
 // exec.PostQuery.PlanFn) after the main query was executed.
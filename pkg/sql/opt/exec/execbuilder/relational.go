@@ -3501,13 +3501,7 @@ func (b *Builder) buildWith(with *memo.WithExpr) (_ execPlan, outputCols colOrdM
 		return execPlan{}, colOrdMap{}, err
 	}
 
-	var label bytes.Buffer
-	fmt.Fprintf(&label, "buffer %d", with.ID)
-	if with.Name != "" {
-		fmt.Fprintf(&label, " (%s)", with.Name)
-	}
-
-	buffer, err := b.factory.ConstructBuffer(value.root, label.String())
+	buffer, err := b.factory.ConstructBuffer(value.root, withBufferLabel(with))
 	if err != nil {
 		return execPlan{}, colOrdMap{}, err
 	}
@@ -3528,6 +3522,17 @@ func (b *Builder) buildWith(with *memo.WithExpr) (_ execPlan, outputCols colOrdM
 	return b.buildRelational(with.Main)
 }
 
+// withBufferLabel returns the label of the buffer that stores the rows of the
+// binding of the given With expression.
+func withBufferLabel(with *memo.WithExpr) string {
+	var label bytes.Buffer
+	fmt.Fprintf(&label, "buffer %d", with.ID)
+	if with.Name != "" {
+		fmt.Fprintf(&label, " (%s)", with.Name)
+	}
+	return label.String()
+}
+
 func (b *Builder) buildRecursiveCTE(
 	rec *memo.RecursiveCTEExpr,
 ) (_ execPlan, outputCols colOrdMap, err error) {
@@ -164,7 +164,9 @@ func (b *Builder) buildExplainOpt(
 func (b *Builder) buildExplain(
 	explainExpr *memo.ExplainExpr,
 ) (_ execPlan, outputCols colOrdMap, err error) {
-	if explainExpr.Options.Mode == tree.ExplainOpt {
+	// Plans that use hypothetical indexes cannot be built into executable
+	// plans, so they are shown in the format of EXPLAIN (OPT).
+	if explainExpr.Options.Mode == tree.ExplainOpt || len(explainExpr.Options.HypotheticalIndexes) > 0 {
 		return b.buildExplainOpt(explainExpr)
 	}
 
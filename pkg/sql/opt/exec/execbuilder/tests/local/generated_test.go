@@ -524,6 +524,13 @@ func TestExecBuild_scalar(
 	runExecBuildLogicTest(t, "scalar")
 }
 
+func TestExecBuild_scan_parallel_local(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runExecBuildLogicTest(t, "scan_parallel_local")
+}
+
 func TestExecBuild_schema_change_in_txn(
 	t *testing.T,
 ) {
@@ -203,7 +203,12 @@ func emitInternal(
 		ob.LeaveNode()
 	}
 	for _, afterTriggers := range plan.Triggers {
-		ob.EnterMetaNode("after-triggers")
+		if view := afterTriggers.IncrementalView; view != nil {
+			ob.EnterMetaNode("incremental-view-maintenance")
+			ob.Attr("view", view.Name())
+		} else {
+			ob.EnterMetaNode("after-triggers")
+		}
 		for _, trigger := range afterTriggers.Triggers {
 			ob.Attr("trigger", trigger.Name())
 		}
@@ -8,6 +8,7 @@ package explain
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -329,6 +330,21 @@ func (ob *OutputBuilder) AddPlanType(generic, optimized bool) {
 	}
 }
 
+// AddReoptimization adds a top-level field indicating that the main query was
+// re-optimized after the buffer with the given label produced a number of rows
+// that differed significantly from its estimate. Cannot be called while inside
+// a node.
+func (ob *OutputBuilder) AddReoptimization(
+	label string, estimatedRowCount float64, actualRowCount uint64,
+) {
+	ob.AddTopLevelField("re-optimized after", fmt.Sprintf(
+		"%s (estimated row count: %s, actual row count: %s)",
+		label,
+		humanizeutil.Count(uint64(math.Round(estimatedRowCount))),
+		humanizeutil.Count(actualRowCount),
+	))
+}
+
 // AddStmtHintCount adds a top-level field displaying a summary of the
 // statement hints loaded for the query (applied and skipped). Cannot be called
 // while inside a node.
@@ -511,6 +527,27 @@ func (ob *OutputBuilder) AddKVCPUTime(kvCPUTime time.Duration) {
 	}
 }
 
+// AddWriteBufferStats adds top-level fields describing the query's use of the
+// transaction's client-side write buffer. Whether writes are buffered depends
+// on cluster settings which are randomized in tests, so the fields are omitted
+// when volatile fields are deflaked.
+func (ob *OutputBuilder) AddWriteBufferStats(
+	bufferedWrites, flushedWrites, readHits, bufferedBytes int64, flushReason string,
+) {
+	if ob.flags.Deflake.HasAny(DeflakeVolatile) {
+		return
+	}
+	ob.AddTopLevelField("buffered writes", fmt.Sprintf(
+		"%s (%s, %s read hits)", humanizeutil.Count(uint64(bufferedWrites)),
+		humanizeutil.IBytes(bufferedBytes), humanizeutil.Count(uint64(readHits)),
+	))
+	if flushReason != "" {
+		ob.AddTopLevelField("write buffer flushed", fmt.Sprintf(
+			"%s (%s writes)", flushReason, humanizeutil.Count(uint64(flushedWrites)),
+		))
+	}
+}
+
 // AddRUEstimate adds a top-level field for the estimated number of RUs consumed
 // by the query.
 func (ob *OutputBuilder) AddRUEstimate(ru float64) {
@@ -521,6 +521,10 @@ func (u *unknownTable) IsMaterializedView() bool {
 	return false
 }
 
+func (u *unknownTable) IsReadOnly() bool {
+	return false
+}
+
 func (u *unknownTable) LookupColumnOrdinal(descpb.ColumnID) (int, error) {
 	panic(errors.AssertionFailedf("not implemented"))
 }
@@ -664,6 +668,21 @@ func (u *unknownTable) Trigger(i int) cat.Trigger {
 	panic(errors.AssertionFailedf("not implemented"))
 }
 
+// IncrementalViewCount is part of the cat.Table interface.
+func (u *unknownTable) IncrementalViewCount() int {
+	return 0
+}
+
+// IncrementalView is part of the cat.Table interface.
+func (u *unknownTable) IncrementalView(i int) cat.StableID {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
+// MaterializedViewQuery is part of the cat.Table interface.
+func (u *unknownTable) MaterializedViewQuery() string {
+	return ""
+}
+
 // IsRowLevelSecurityEnabled is part of the cat.Table interface
 func (u *unknownTable) IsRowLevelSecurityEnabled() bool { return false }
 
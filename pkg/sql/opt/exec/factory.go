@@ -315,18 +315,25 @@ type ApplyJoinPlanRightSideFn func(ctx context.Context, ef Factory, leftRow tree
 // purposes.
 type ApplyJoinRightSideForExplainFn func(redactableValues bool) string
 
-// PostQuery describes a cascading query or an AFTER trigger action. The query
-// uses a node created by ConstructBuffer as an input; it should only be
-// triggered if this buffer is not empty.
+// PostQuery describes a cascading query, an AFTER trigger action, or an update
+// of an incrementally maintained materialized view. The query uses a node
+// created by ConstructBuffer as an input; it should only be triggered if this
+// buffer is not empty.
 type PostQuery struct {
 	// FKConstraint is used for logging and EXPLAIN purposes. It is nil if this
-	// PostQuery describes a set of AFTER triggers.
+	// PostQuery describes a set of AFTER triggers or an incremental view update.
 	FKConstraint cat.ForeignKeyConstraint
 
 	// Triggers is used for logging and EXPLAIN purposes. It is nil if this
 	// PostQuery describes a foreign-key cascade action.
 	Triggers []cat.Trigger
 
+	// IncrementalView is used for logging and EXPLAIN purposes. It is set if
+	// this PostQuery updates an incrementally maintained materialized view after
+	// a mutation of one of its base tables. Such post-queries are queued with the
+	// AFTER triggers.
+	IncrementalView cat.Table
+
 	// Buffer is the Node returned by ConstructBuffer which stores the input to
 	// the mutation. It is nil if the cascade does not require a buffer.
 	Buffer Node
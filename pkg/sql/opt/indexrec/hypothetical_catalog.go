@@ -0,0 +1,62 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package indexrec
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+)
+
+// HypotheticalCatalog is a wrapper around cat.Catalog that resolves tables to
+// their HypotheticalTables, so that a statement can be built and optimized as
+// if the hypothetical indexes of the tables existed. Unlike updating the table
+// metadata of an already built memo, this allows the predicates of partial
+// hypothetical indexes to be built along with the statement.
+type HypotheticalCatalog struct {
+	cat.Catalog
+	hypTables map[cat.StableID]cat.Table
+}
+
+var _ cat.Catalog = &HypotheticalCatalog{}
+
+// NewHypotheticalCatalog returns a HypotheticalCatalog that resolves the
+// tables in hypTables to their HypotheticalTables, and all other data sources
+// using the given catalog.
+func NewHypotheticalCatalog(
+	c cat.Catalog, hypTables map[cat.StableID]cat.Table,
+) *HypotheticalCatalog {
+	return &HypotheticalCatalog{Catalog: c, hypTables: hypTables}
+}
+
+// ResolveDataSource is part of the cat.Catalog interface.
+func (hc *HypotheticalCatalog) ResolveDataSource(
+	ctx context.Context, flags cat.Flags, name *cat.DataSourceName,
+) (cat.DataSource, cat.DataSourceName, error) {
+	ds, resName, err := hc.Catalog.ResolveDataSource(ctx, flags, name)
+	if err != nil {
+		return nil, cat.DataSourceName{}, err
+	}
+	return hc.hypotheticalDataSource(ds), resName, nil
+}
+
+// ResolveDataSourceByID is part of the cat.Catalog interface.
+func (hc *HypotheticalCatalog) ResolveDataSourceByID(
+	ctx context.Context, flags cat.Flags, id cat.StableID,
+) (_ cat.DataSource, isAdding bool, _ error) {
+	ds, isAdding, err := hc.Catalog.ResolveDataSourceByID(ctx, flags, id)
+	if err != nil {
+		return nil, isAdding, err
+	}
+	return hc.hypotheticalDataSource(ds), isAdding, nil
+}
+
+func (hc *HypotheticalCatalog) hypotheticalDataSource(ds cat.DataSource) cat.DataSource {
+	if hypTable, ok := hc.hypTables[ds.ID()]; ok {
+		return hypTable
+	}
+	return ds
+}
@@ -49,6 +49,10 @@ type hypotheticalIndex struct {
 	// vectorConfig stores the vector index configuration, including the distance
 	// metric. Only set for vector indexes.
 	vectorConfig *vecpb.Config
+
+	// predicate stores the serialized predicate of a partial index. It is empty
+	// if the index is not a partial index.
+	predicate string
 }
 
 var _ cat.Index = &hypotheticalIndex{}
@@ -196,7 +200,7 @@ func (hi *hypotheticalIndex) VectorColumn() cat.IndexColumn {
 
 // Predicate is part of the cat.Index interface.
 func (hi *hypotheticalIndex) Predicate() (string, bool) {
-	return "", false
+	return hi.predicate, hi.predicate != ""
 }
 
 // Zone is part of the cat.Index interface.
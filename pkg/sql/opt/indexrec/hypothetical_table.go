@@ -8,13 +8,17 @@ package indexrec
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/idxtype"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/sql/vecindex/vecpb"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 )
 
@@ -79,6 +83,126 @@ func BuildOptAndHypTableMaps(
 	return optTables, hypTables
 }
 
+// BuildHypTablesFromIndexDefs builds a HypotheticalTable for each table that
+// is indexed by the given CREATE INDEX statements, with a hypothetical index for
+// each statement. It returns a map from each table's cat.StableID to its
+// HypotheticalTable, which can be used to plan a query as if the indexes
+// existed. Partial and inverted indexes are supported, but unique, sharded,
+// partitioned, vector, and expression indexes are not.
+func BuildHypTablesFromIndexDefs(
+	ctx context.Context, c cat.Catalog, defs []*tree.CreateIndex,
+) (map[cat.StableID]cat.Table, error) {
+	hypTables := make(map[cat.StableID]cat.Table, len(defs))
+	for _, def := range defs {
+		ds, _, err := c.ResolveDataSource(ctx, cat.Flags{}, &def.Table)
+		if err != nil {
+			return nil, err
+		}
+		t, ok := ds.(cat.Table)
+		if !ok || t.IsVirtualTable() {
+			return nil, pgerror.Newf(pgcode.WrongObjectType,
+				"cannot create a hypothetical index on %q", tree.ErrString(&def.Table))
+		}
+		hypTable, ok := hypTables[t.ID()].(*HypotheticalTable)
+		if !ok {
+			hypTable = &HypotheticalTable{}
+			hypTable.init(c, t)
+			hypTables[t.ID()] = hypTable
+		}
+		if err := hypTable.addHypIndexFromDef(def); err != nil {
+			return nil, err
+		}
+	}
+	return hypTables, nil
+}
+
+// addHypIndexFromDef adds a hypothetical index defined by a CREATE INDEX
+// statement to the HypotheticalTable. Unlike the hypothetical indexes of index
+// recommendations, it only stores the columns in the STORING clause. If the
+// definition has no name, it is given one.
+func (ht *HypotheticalTable) addHypIndexFromDef(def *tree.CreateIndex) error {
+	switch {
+	case def.Unique:
+		return unimplemented.New("hypothetical unique index", "hypothetical unique indexes are not supported")
+	case def.Type == idxtype.VECTOR:
+		return unimplemented.New("hypothetical vector index", "hypothetical vector indexes are not supported")
+	case def.Sharded != nil:
+		return unimplemented.New("hypothetical sharded index", "hypothetical hash-sharded indexes are not supported")
+	case def.PartitionByIndex != nil:
+		return unimplemented.New("hypothetical partitioned index", "hypothetical partitioned indexes are not supported")
+	}
+
+	indexCols := make([]cat.IndexColumn, len(def.Columns))
+	for i := range def.Columns {
+		elem := &def.Columns[i]
+		if elem.Expr != nil {
+			return unimplemented.New("hypothetical expression index", "hypothetical expression indexes are not supported")
+		}
+		col, err := ht.findColumn(elem.Column)
+		if err != nil {
+			return err
+		}
+		indexCols[i] = cat.IndexColumn{Column: col, Descending: elem.Direction == tree.Descending}
+	}
+	if def.Type == idxtype.INVERTED {
+		lastKeyCol := indexCols[len(indexCols)-1]
+		indexCols[len(indexCols)-1] = cat.IndexColumn{Column: ht.addInvertedCol(lastKeyCol.Column)}
+	}
+
+	if !def.Type.SupportsStoring() && len(def.Storing) > 0 {
+		return pgerror.Newf(pgcode.InvalidSQLStatementName,
+			"%s indexes don't support stored columns", strings.ToLower(def.Type.String()))
+	}
+	var storedCols []cat.IndexColumn
+	for _, name := range def.Storing {
+		col, err := ht.findColumn(name)
+		if err != nil {
+			return err
+		}
+		storedCols = append(storedCols, cat.IndexColumn{Column: col})
+	}
+
+	indexOrd := ht.Table.IndexCount() + len(ht.hypotheticalIndexes)
+	if def.Name == "" {
+		// Name the index in the definition, so that it can be matched with the
+		// index in the plan.
+		def.Name = tree.Name(fmt.Sprintf("_hyp_%d", indexOrd))
+	}
+	var hypIndex hypotheticalIndex
+	hypIndex.init(ht, def.Name, indexCols, indexOrd, def.Type, ht.Table.Zone(), nil /* vecConfig */)
+	if def.Type.SupportsStoring() {
+		// Only store the columns in the STORING clause that are not already key
+		// columns.
+		var keyColOrds intsets.Fast
+		for i := 0; i < hypIndex.KeyColumnCount(); i++ {
+			keyColOrds.Add(hypIndex.Column(i).Ordinal())
+		}
+		hypIndex.storedCols = hypIndex.storedCols[:0]
+		for _, col := range storedCols {
+			if !keyColOrds.Contains(col.Ordinal()) {
+				keyColOrds.Add(col.Ordinal())
+				hypIndex.storedCols = append(hypIndex.storedCols, col)
+			}
+		}
+	}
+	if def.Predicate != nil {
+		hypIndex.predicate = tree.Serialize(def.Predicate)
+	}
+	ht.hypotheticalIndexes = append(ht.hypotheticalIndexes, hypIndex)
+	return nil
+}
+
+// findColumn returns the ordinary column of the HypotheticalTable with the
+// given name.
+func (ht *HypotheticalTable) findColumn(name tree.Name) (*cat.Column, error) {
+	for i, n := 0, ht.Table.ColumnCount(); i < n; i++ {
+		if col := ht.Table.Column(i); col.Kind() == cat.Ordinary && col.ColName() == name {
+			return col, nil
+		}
+	}
+	return nil, pgerror.Newf(pgcode.UndefinedColumn, "column %q does not exist", tree.ErrString(&name))
+}
+
 // maybeAddHypIndex creates a hypothetical index and appends it to hypIndexes
 // if it is not redundant with an existing index on the table. The vecConfig
 // parameter provides vector index configuration; it is nil for non-vector
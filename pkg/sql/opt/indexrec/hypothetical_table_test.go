@@ -5,7 +5,16 @@
 
 package indexrec
 
-import "testing"
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils/testcat"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/idxtype"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
 
 func TestBuildOptAndHypTableMaps(t *testing.T) {
 	tables, indexCols := testTablesAndIndexCols()
@@ -43,3 +52,91 @@ func TestBuildOptAndHypTableMaps(t *testing.T) {
 		)
 	}
 }
+
+func TestBuildHypTablesFromIndexDefs(t *testing.T) {
+	ctx := context.Background()
+	catalog := testcat.New()
+	if _, err := catalog.ExecuteDDL(
+		"CREATE TABLE t (k INT PRIMARY KEY, a INT, b INT, c INT, j JSON, INDEX (c))",
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	buildHypTable := func(defs ...string) (*HypotheticalTable, error) {
+		var createIndexes []*tree.CreateIndex
+		for _, def := range defs {
+			stmt, err := parser.ParseOne(def)
+			if err != nil {
+				t.Fatal(err)
+			}
+			createIndexes = append(createIndexes, stmt.AST.(*tree.CreateIndex))
+		}
+		hypTables, err := BuildHypTablesFromIndexDefs(ctx, catalog, createIndexes)
+		if err != nil {
+			return nil, err
+		}
+		if len(hypTables) != 1 {
+			t.Fatalf("expected 1 hypothetical table, got %d", len(hypTables))
+		}
+		for _, hypTable := range hypTables {
+			return hypTable.(*HypotheticalTable), nil
+		}
+		return nil, nil
+	}
+
+	hypTable, err := buildHypTable(
+		"CREATE INDEX ON t (a DESC) STORING (b)",
+		"CREATE INDEX idx ON t (b) WHERE a > 0",
+		"CREATE INVERTED INDEX ON t (a, j)",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	existing := hypTable.Table.IndexCount()
+	if hypTable.IndexCount() != existing+3 {
+		t.Fatalf("expected %d indexes, got %d", existing+3, hypTable.IndexCount())
+	}
+
+	// The forward index only stores the columns in the STORING clause.
+	forward := hypTable.Index(existing)
+	if forward.Name() != tree.Name("_hyp_2") {
+		t.Errorf("expected index name _hyp_2, got %s", forward.Name())
+	}
+	if !forward.Column(0).Descending || forward.Column(0).ColName() != "a" {
+		t.Errorf("expected first index column a DESC, got %+v", forward.Column(0))
+	}
+	if forward.KeyColumnCount() != 2 || forward.ColumnCount() != 3 {
+		t.Errorf("expected 2 key columns and 1 stored column, got %d and %d",
+			forward.KeyColumnCount(), forward.ColumnCount()-forward.KeyColumnCount())
+	}
+
+	// The partial index has a predicate.
+	partial := hypTable.Index(existing + 1)
+	if pred, ok := partial.Predicate(); !ok || pred != "a > 0" {
+		t.Errorf("expected predicate a > 0, got %q", pred)
+	}
+	if _, ok := forward.Predicate(); ok {
+		t.Errorf("expected %s not to be a partial index", forward.Name())
+	}
+
+	// The inverted index has a prefix column and a new inverted column.
+	inverted := hypTable.Index(existing + 2)
+	if inverted.Type() != idxtype.INVERTED || inverted.PrefixColumnCount() != 1 {
+		t.Errorf("expected inverted index with 1 prefix column")
+	}
+	if invCol := inverted.InvertedColumn(); invCol.Kind() != cat.Inverted ||
+		hypTable.Column(invCol.InvertedSourceColumnOrdinal()).ColName() != "j" {
+		t.Errorf("expected inverted column on j, got %+v", invCol)
+	}
+
+	for _, def := range []string{
+		"CREATE UNIQUE INDEX ON t (a)",
+		"CREATE INDEX ON t ((a + b))",
+		"CREATE INDEX ON t (missing)",
+		"CREATE INVERTED INDEX ON t (j) STORING (a)",
+	} {
+		if _, err := buildHypTable(def); err == nil {
+			t.Errorf("expected %q to fail", def)
+		}
+	}
+}
@@ -1405,6 +1405,31 @@ type AfterTriggers struct {
 	WithID opt.WithID
 }
 
+// IncrementalViews stores metadata necessary for maintaining the incrementally
+// maintained materialized views that depend on the mutated table.
+type IncrementalViews []IncrementalView
+
+// IncrementalView stores metadata necessary for updating an incrementally
+// maintained materialized view after a mutation of one of its base tables. The
+// view is updated by two post-queries: the first deletes the view rows that
+// depend on the modified rows, and the second recomputes and inserts them.
+type IncrementalView struct {
+	// View is the incrementally maintained materialized view.
+	View cat.Table
+
+	// DeleteBuilder is an object that can be used as the "optbuilder" for the
+	// query that deletes the affected view rows.
+	DeleteBuilder PostQueryBuilder
+
+	// InsertBuilder is an object that can be used as the "optbuilder" for the
+	// query that inserts the recomputed view rows.
+	InsertBuilder PostQueryBuilder
+
+	// WithID identifies the buffer for the mutation input in the original
+	// expression tree. It is always nonzero.
+	WithID opt.WithID
+}
+
 // PostQueryBuilder is an interface used to construct either a cascading query
 // for a specific FK relation, or an AFTER trigger action. For example: if we
 // are deleting rows from a parent table, after deleting the rows from the
@@ -1537,6 +1537,8 @@ func (f *ExprFmtCtx) formatIndex(tabID opt.TableID, idxOrd cat.IndexOrdinal, rev
 		f.Buffer.WriteString(",inverted")
 	case idxtype.VECTOR:
 		f.Buffer.WriteString(",vector")
+	case idxtype.COLUMNAR:
+		f.Buffer.WriteString(",columnar")
 	}
 	if _, isPartial := index.Predicate(); isPartial {
 		f.Buffer.WriteString(",partial")
@@ -1704,6 +1706,12 @@ func (f *ExprFmtCtx) formatMutationCommon(tp treeprinter.Node, p *MutationPrivat
 			c.Child(p.AfterTriggers.Triggers[i].Name().Normalize())
 		}
 	}
+	if len(p.IncrementalViews) > 0 {
+		c := tp.Childf("incremental-views")
+		for i := range p.IncrementalViews {
+			c.Child(string(p.IncrementalViews[i].View.Name()))
+		}
+	}
 }
 
 // formatBeforeTriggers displays the names of BEFORE triggers that will be
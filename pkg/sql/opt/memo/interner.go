@@ -542,6 +542,13 @@ func (h *hasher) HashAfterTriggers(val *AfterTriggers) {
 	}
 }
 
+func (h *hasher) HashIncrementalViews(val IncrementalViews) {
+	for i := range val {
+		h.HashUint64(uint64(reflect.ValueOf(val[i].DeleteBuilder).Pointer()))
+		h.HashUint64(uint64(reflect.ValueOf(val[i].InsertBuilder).Pointer()))
+	}
+}
+
 func (h *hasher) HashExplainOptions(val tree.ExplainOptions) {
 	h.HashUint64(uint64(val.Mode))
 	hash := h.hash
@@ -552,6 +559,9 @@ func (h *hasher) HashExplainOptions(val tree.ExplainOptions) {
 		}
 	}
 	h.hash = hash
+	for _, idx := range val.HypotheticalIndexes {
+		h.HashString(idx)
+	}
 }
 
 func (h *hasher) HashStatementReturnType(val tree.StatementReturnType) {
@@ -1035,8 +1045,30 @@ func (h *hasher) IsAfterTriggersEqual(l, r *AfterTriggers) bool {
 	return l.Builder == r.Builder
 }
 
+func (h *hasher) IsIncrementalViewsEqual(l, r IncrementalViews) bool {
+	if len(l) != len(r) {
+		return false
+	}
+	for i := range l {
+		// It's sufficient to compare the builder instances.
+		if l[i].DeleteBuilder != r[i].DeleteBuilder || l[i].InsertBuilder != r[i].InsertBuilder {
+			return false
+		}
+	}
+	return true
+}
+
 func (h *hasher) IsExplainOptionsEqual(l, r tree.ExplainOptions) bool {
-	return l == r
+	if l.Mode != r.Mode || l.Flags != r.Flags ||
+		len(l.HypotheticalIndexes) != len(r.HypotheticalIndexes) {
+		return false
+	}
+	for i := range l.HypotheticalIndexes {
+		if l.HypotheticalIndexes[i] != r.HypotheticalIndexes[i] {
+			return false
+		}
+	}
+	return true
 }
 
 func (h *hasher) IsStatementReturnTypeEqual(l, r tree.StatementReturnType) bool {
@@ -881,6 +881,20 @@ func TestInterner(t *testing.T) {
 			{val1: &AfterTriggers{Builder: postQueryBuilder1}, val2: &AfterTriggers{Builder: postQueryBuilder1}, equal: true},
 			{val1: &AfterTriggers{Builder: postQueryBuilder1}, val2: &AfterTriggers{Builder: postQueryBuilder2}, equal: false},
 		}},
+
+		{hashFn: in.hasher.HashIncrementalViews, eqFn: in.hasher.IsIncrementalViewsEqual, variations: []testVariation{
+			{val1: IncrementalViews(nil), val2: IncrementalViews{}, equal: true},
+			{
+				val1:  IncrementalViews{{DeleteBuilder: postQueryBuilder1, InsertBuilder: postQueryBuilder2}},
+				val2:  IncrementalViews{{DeleteBuilder: postQueryBuilder1, InsertBuilder: postQueryBuilder2}},
+				equal: true,
+			},
+			{
+				val1:  IncrementalViews{{DeleteBuilder: postQueryBuilder1, InsertBuilder: postQueryBuilder2}},
+				val2:  IncrementalViews{{DeleteBuilder: postQueryBuilder2, InsertBuilder: postQueryBuilder1}},
+				equal: false,
+			},
+		}},
 	}
 
 	computeHashValue := func(hashFn reflect.Value, val interface{}) internHash {
@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/buildutil"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/errors"
@@ -754,6 +755,21 @@ func (sb *statisticsBuilder) makeTableStatistics(tabID opt.TableID) *props.Stati
 			}
 
 			colStat, ok := stats.ColStats.Add(cols)
+			if ok && cols.Len() > 1 &&
+				(len(stat.MostCommonValues()) > 0 || len(stat.Dependencies()) > 0) {
+				// Keep the most common values and dependency degrees of the most
+				// recent multi-column statistic on these columns.
+				mcv := props.MultiColValues{
+					Cols:             make(opt.ColList, stat.ColumnCount()),
+					RowCount:         float64(stat.RowCount()),
+					MostCommonValues: stat.MostCommonValues(),
+					Dependencies:     stat.Dependencies(),
+				}
+				for i := range mcv.Cols {
+					mcv.Cols[i] = tabID.ColumnID(stat.ColumnOrdinal(i))
+				}
+				stats.MultiColValues = append(stats.MultiColValues, mcv)
+			}
 			if ok || (colStat.Histogram == nil && !invertedStatistic && seenInvertedStat) {
 				// Set the statistic if either:
 				// 1. We have no statistic for the current colset at all
@@ -3456,8 +3472,15 @@ func (sb *statisticsBuilder) filterRelExpr(
 
 	// Calculate row count and selectivity
 	// -----------------------------------
-	corr := sb.correlationFromMultiColDistinctCounts(constrainedCols, e, s)
-	s.ApplySelectivity(sb.selectivityFromConstrainedCols(constrainedCols, histCols, maxFreqCols, e, s, corr))
+	// Columns held constant that are covered by the most common values of a
+	// multi-column statistic are estimated separately.
+	mcvCols, mcvSel := sb.selectivityFromMultiColValues(filters, constrainedCols, e, s)
+	s.ApplySelectivity(mcvSel)
+	otherCols := constrainedCols.Difference(mcvCols)
+	corr := sb.correlationFromMultiColDistinctCounts(otherCols, e, s)
+	s.ApplySelectivity(sb.selectivityFromConstrainedCols(
+		otherCols, histCols.Difference(mcvCols), maxFreqCols.Difference(mcvCols), e, s, corr,
+	))
 	s.ApplySelectivity(sb.selectivityFromEquivalencies(equivReps, &relProps.FuncDeps, e, s))
 	s.ApplySelectivity(sb.selectivityFromUnappliedConjuncts(unapplied))
 	s.ApplySelectivity(sb.selectivityFromNullsRemoved(e, notNullCols, constrainedCols))
@@ -4403,6 +4426,129 @@ func (sb *statisticsBuilder) selectivityFromMultiColDistinctCounts(
 		))
 }
 
+// selectivityFromMultiColValues calculates the selectivity of the filters that
+// hold the columns of a multi-column statistic equal to constant values, using
+// the most common values and functional dependency degrees of the statistic
+// (similar to the "mcv" and "dependencies" kinds of Postgres extended
+// statistics). It returns the columns whose selectivity was calculated, which
+// is empty if no multi-column statistic applies.
+//
+// If the constant tuple is one of the most common values, the selectivity is
+// its frequency. Otherwise, the selectivity is calculated from the
+// single-column selectivities, adjusted by the dependency degrees. For the
+// filter a=x AND b=y with a dependency a => b of degree f, this is:
+//
+//	selectivity = sel(a=x) * (f + (1-f) * sel(b=y))
+//
+// which is sel(a=x) when b is fully determined by a, and the product of the
+// single-column selectivities when a and b are independent. As the tuple is not
+// one of the most common values, the selectivity is capped at the frequency of
+// the least common of the most common values.
+func (sb *statisticsBuilder) selectivityFromMultiColValues(
+	filters FiltersExpr, constrainedCols opt.ColSet, e RelExpr, s *props.Statistics,
+) (cols opt.ColSet, selectivity props.Selectivity) {
+	selectivity = props.OneSelectivity
+	if !sb.evalCtx.SessionData().OptimizerUseMultiColStats || constrainedCols.Len() < 2 {
+		return opt.ColSet{}, selectivity
+	}
+	// Find the multi-column statistics of the tables of the constrained
+	// columns.
+	var candidates []props.MultiColValues
+	var tabIDs intsets.Fast
+	for col, ok := constrainedCols.Next(0); ok; col, ok = constrainedCols.Next(col + 1) {
+		tabID := sb.md.ColumnMeta(col).Table
+		if tabID == 0 || tabIDs.Contains(int(tabID)) {
+			continue
+		}
+		tabIDs.Add(int(tabID))
+		if tabStats, ok := GetTableStats(sb.md, tabID); ok {
+			candidates = append(candidates, tabStats.MultiColValues...)
+		}
+	}
+	if len(candidates) == 0 {
+		return opt.ColSet{}, selectivity
+	}
+
+	// Find the constant, non-NULL values of the constrained columns.
+	var consts map[opt.ColumnID]tree.Datum
+	for i := range filters {
+		scalarProps := filters[i].ScalarProps()
+		if !scalarProps.TightConstraints || scalarProps.Constraints == nil {
+			continue
+		}
+		cs := scalarProps.Constraints
+		constCols := cs.ExtractConstCols(sb.ctx, sb.evalCtx).Intersection(constrainedCols)
+		for col, ok := constCols.Next(0); ok; col, ok = constCols.Next(col + 1) {
+			if d := cs.ExtractValueForConstCol(sb.ctx, sb.evalCtx, col); d != nil && d != tree.DNull {
+				if consts == nil {
+					consts = make(map[opt.ColumnID]tree.Datum)
+				}
+				consts[col] = d
+			}
+		}
+	}
+
+	// Use the statistic covering the most constant columns.
+	var mcv *props.MultiColValues
+	for i := range candidates {
+		candidate := &candidates[i]
+		if candidate.RowCount <= 0 || (mcv != nil && len(candidate.Cols) <= len(mcv.Cols)) {
+			continue
+		}
+		covered := true
+		for _, col := range candidate.Cols {
+			if _, ok := consts[col]; !ok {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			mcv = candidate
+		}
+	}
+	if mcv == nil {
+		return opt.ColSet{}, selectivity
+	}
+	cols = mcv.Cols.ToSet()
+
+	// If the tuple is one of the most common values, use its frequency.
+	minFreq := 1.0
+EachValue:
+	for i := range mcv.MostCommonValues {
+		freq := mcv.MostCommonValues[i].NumEq / mcv.RowCount
+		minFreq = min(minFreq, freq)
+		for j, col := range mcv.Cols {
+			cmp, err := consts[col].Compare(sb.ctx, sb.evalCtx, mcv.MostCommonValues[i].Values[j])
+			if err != nil || cmp != 0 {
+				continue EachValue
+			}
+		}
+		return cols, props.MakeSelectivity(freq)
+	}
+
+	// Otherwise, combine the single-column selectivities using the strongest
+	// dependency on each column from the columns before it.
+	for j, col := range mcv.Cols {
+		colStat, ok := s.ColStats.LookupSingleton(col)
+		if !ok {
+			return opt.ColSet{}, props.OneSelectivity
+		}
+		inputColStat, inputStats := sb.colStatFromInput(colStat.Cols, e)
+		colSel := sb.selectivityFromDistinctCount(colStat, inputColStat, inputStats.RowCount).AsFloat()
+		var degree float64
+		for _, dep := range mcv.Dependencies {
+			if dep.To == j && dep.From < j {
+				degree = max(degree, dep.Degree)
+			}
+		}
+		selectivity.Multiply(props.MakeSelectivity(degree + (1-degree)*colSel))
+	}
+	if len(mcv.MostCommonValues) > 0 {
+		selectivity = props.MinSelectivity(selectivity, props.MakeSelectivity(minFreq))
+	}
+	return cols, selectivity
+}
+
 // correlationFromMultiColDistinctCounts returns the correlation between the
 // given set of columns, as indicated by multi-column stats. It is a number
 // between 0 and 1, where 0 means the columns are completely independent, and 1
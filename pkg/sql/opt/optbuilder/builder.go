@@ -119,6 +119,11 @@ type Builder struct {
 	// be used with care.
 	skipSelectPrivilegeChecks bool
 
+	// If set, the builder is building the statements that update an
+	// incrementally maintained materialized view. Such statements may modify the
+	// view, and are not subject to privilege checks.
+	maintainingIncrementalView bool
+
 	// views contains a cache of views that have already been parsed, in case they
 	// are referenced multiple times in the same query.
 	views map[cat.View]*tree.Select
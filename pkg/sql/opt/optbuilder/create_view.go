@@ -6,7 +6,10 @@
 package optbuilder
 
 import (
+	"github.com/cockroachdb/cockroach/pkg/sql/ivm"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/util"
@@ -62,6 +65,15 @@ func (b *Builder) buildCreateView(cv *tree.CreateView, inScope *scope) (outScope
 
 	defScope := b.buildStmtAtRoot(cv.AsSource, nil /* desiredTypes */)
 
+	// The rows of an incrementally maintained view are recomputed when the
+	// base tables change, so the view query must not depend on anything else.
+	if cv.Materialized && ivm.IsIncremental(cv.StorageParams) {
+		if vs := defScope.expr.Relational().VolatilitySet; vs.HasStable() || vs.HasVolatile() {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"incremental materialized views do not support stable or volatile expressions"))
+		}
+	}
+
 	p := defScope.makePhysicalProps().Presentation
 	if len(cv.ColumnNames) != 0 {
 		if len(p) != len(cv.ColumnNames) {
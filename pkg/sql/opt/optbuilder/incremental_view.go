@@ -0,0 +1,287 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package optbuilder
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/ivm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// incrementalViewDeltaName is the name of the CTE that contains the old and new
+// versions of the modified rows in the statements that update an incrementally
+// maintained view.
+const incrementalViewDeltaName = "crdb_internal_ivm_delta"
+
+// buildIncrementalViews plans the updates of the incrementally maintained
+// materialized views that depend on the mutated table. Like AFTER triggers, the
+// updates are post-queries that read the buffered mutation input, so they are
+// stored on mutationBuilder instead of being built as part of the mutation.
+//
+// Each view is updated by two statements: the first deletes the view rows that
+// the old or new versions of the modified rows contribute to, and the second
+// recomputes those rows from the base tables and inserts them. See the ivm
+// package for details.
+func (mb *mutationBuilder) buildIncrementalViews(mutation opt.Operator) {
+	if mb.tab.IncrementalViewCount() == 0 {
+		return
+	}
+	mb.ensureWithID()
+
+	// Collect the columns of the table that can be referenced by a view query,
+	// along with the old and new values of each modified row.
+	var names []tree.Name
+	var fetchCols, updateCols, insertCols opt.ColList
+	hasOld := mutation == opt.DeleteOp || mutation == opt.UpdateOp || mb.canaryColID != 0
+	hasUpdate := mutation == opt.UpdateOp || mb.canaryColID != 0
+	hasInsert := mutation == opt.InsertOp
+	newCol := func(cols opt.OptionalColList, i int) opt.ColumnID {
+		col := cols[i]
+		if col == 0 {
+			col = mb.fetchColIDs[i]
+		}
+		if col == 0 {
+			panic(errors.AssertionFailedf("col is 0"))
+		}
+		mb.triggerColIDs.Add(col)
+		return col
+	}
+	for i, n := 0, mb.tab.ColumnCount(); i < n; i++ {
+		col := mb.tab.Column(i)
+		if col.Kind() != cat.Ordinary || col.Visibility() == cat.Inaccessible {
+			continue
+		}
+		names = append(names, col.ColName())
+		if hasOld {
+			if mb.fetchColIDs[i] == 0 {
+				panic(errors.AssertionFailedf("fetchColID is 0"))
+			}
+			mb.triggerColIDs.Add(mb.fetchColIDs[i])
+			fetchCols = append(fetchCols, mb.fetchColIDs[i])
+		}
+		if hasUpdate {
+			updateCols = append(updateCols, newCol(mb.updateColIDs, i))
+		}
+		if hasInsert {
+			// For UPSERT and INSERT with ON CONFLICT, the insert columns of rows
+			// that conflict are not the new values of the rows. We include them
+			// anyway, since updating a view row that was not affected by the
+			// mutation has no effect.
+			insertCols = append(insertCols, newCol(mb.insertColIDs, i))
+		}
+	}
+
+	for i, n := 0, mb.tab.IncrementalViewCount(); i < n; i++ {
+		viewID := mb.tab.IncrementalView(i)
+		ds, _, err := mb.b.catalog.ResolveDataSourceByID(mb.b.ctx, cat.Flags{}, viewID)
+		if err != nil {
+			panic(err)
+		}
+		view, ok := ds.(cat.Table)
+		if !ok || !view.IsMaterializedView() {
+			panic(errors.AssertionFailedf("incremental view %d is not a materialized view", viewID))
+		}
+		// The plan must be invalidated if the view changes.
+		mb.md.AddDependency(opt.DepByID(viewID), view, 0 /* priv */, mb.b.privilegeDependencyUser())
+
+		def, err := ivm.Analyze(mb.b.ctx, view.MaterializedViewQuery(), mb.b.resolveIncrementalViewTable)
+		if err != nil {
+			panic(err)
+		}
+		source := def.SourceByTableID(descpb.ID(mb.tab.ID()))
+		if source < 0 {
+			panic(errors.AssertionFailedf(
+				"table %s is not a source of incremental view %s", mb.tab.Name(), view.Name(),
+			))
+		}
+		var viewCols tree.NameList
+		for j, m := 0, view.ColumnCount(); j < m; j++ {
+			if col := view.Column(j); col.Kind() == cat.Ordinary && col.Visibility() == cat.Visible {
+				viewCols = append(viewCols, col.ColName())
+			}
+		}
+		newBuilder := func(insert bool) *incrementalViewBuilder {
+			return &incrementalViewBuilder{
+				def:            def,
+				source:         source,
+				view:           view,
+				viewCols:       viewCols,
+				insert:         insert,
+				stmtTreeInitFn: mb.b.stmtTree.GetInitFnForPostQuery(),
+				names:          names,
+				fetchCols:      fetchCols,
+				updateCols:     updateCols,
+				insertCols:     insertCols,
+			}
+		}
+		mb.incrementalViews = append(mb.incrementalViews, memo.IncrementalView{
+			View:          view,
+			DeleteBuilder: newBuilder(false /* insert */),
+			InsertBuilder: newBuilder(true /* insert */),
+			WithID:        mb.withID,
+		})
+	}
+}
+
+// resolveIncrementalViewTable is an ivm.TableResolver that resolves the base
+// tables of an incrementally maintained view using the catalog.
+func (b *Builder) resolveIncrementalViewTable(
+	ctx context.Context, tn *tree.TableName,
+) (ivm.Table, error) {
+	ds, _, err := b.catalog.ResolveDataSource(ctx, cat.Flags{}, tn)
+	if err != nil {
+		return ivm.Table{}, err
+	}
+	tab, ok := ds.(cat.Table)
+	if !ok {
+		return ivm.Table{}, errors.AssertionFailedf("%s is not a table", tn)
+	}
+	res := ivm.Table{ID: descpb.ID(tab.ID())}
+	for i, n := 0, tab.ColumnCount(); i < n; i++ {
+		if col := tab.Column(i); col.Kind() == cat.Ordinary && col.Visibility() != cat.Inaccessible {
+			res.Columns = append(res.Columns, col.ColName())
+		}
+	}
+	pk := tab.Index(cat.PrimaryIndex)
+	for i, n := 0, pk.KeyColumnCount(); i < n; i++ {
+		res.PrimaryKey = append(res.PrimaryKey, pk.Column(i).ColName())
+	}
+	return res, nil
+}
+
+// incrementalViewBuilder is a memo.PostQueryBuilder implementation for the
+// statements that update an incrementally maintained view after a mutation of
+// one of its base tables.
+type incrementalViewBuilder struct {
+	def *ivm.Definition
+	// source is the ordinal of the mutated table among the sources of the view.
+	source int
+	view   cat.Table
+	// viewCols are the names of the visible columns of the view.
+	viewCols tree.NameList
+	// insert is true if the builder builds the statement that inserts the
+	// recomputed view rows, and false if it builds the statement that deletes
+	// the affected view rows.
+	insert bool
+
+	// stmtTreeInitFn returns a statementTree that tracks the mutations in
+	// ancestor statements. It may be unset if there are no ancestor statements.
+	stmtTreeInitFn func() statementTree
+
+	// names are the names of the columns of the mutated table that can be
+	// referenced by the view query.
+	names []tree.Name
+
+	// The following lists, if set, contain one column from the mutation input
+	// per name. The columns must be remapped to the new memo when the statement
+	// is built.
+	//
+	// fetchCols are the old values of the modified rows.
+	fetchCols opt.ColList
+	// updateCols are the new values of the updated rows.
+	updateCols opt.ColList
+	// insertCols are the new values of the inserted rows.
+	insertCols opt.ColList
+}
+
+var _ memo.PostQueryBuilder = &incrementalViewBuilder{}
+
+// Build is part of the memo.PostQueryBuilder interface.
+func (vb *incrementalViewBuilder) Build(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *eval.Context,
+	catalog cat.Catalog,
+	factoryI interface{},
+	binding opt.WithID,
+	bindingProps *props.Relational,
+	colMap opt.ColMap,
+) (_ memo.RelExpr, err error) {
+	return buildTriggerCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, vb.stmtTreeInitFn,
+		func(b *Builder) memo.RelExpr {
+			f := b.factory
+			md := f.Metadata()
+			md.AddWithBinding(binding, f.ConstructFakeRel(&memo.FakeRelPrivate{
+				Props: bindingProps,
+			}))
+
+			// Build the union of the old and new versions of the modified rows.
+			var delta memo.RelExpr
+			var deltaCols opt.ColList
+			addCols := func() opt.ColList {
+				cols := make(opt.ColList, len(vb.names))
+				for i := range cols {
+					cols[i] = md.AddColumn(string(vb.names[i]), md.ColumnMeta(deltaCols[i]).Type)
+				}
+				return cols
+			}
+			for _, cols := range []opt.ColList{vb.fetchCols, vb.updateCols, vb.insertCols} {
+				if cols == nil {
+					continue
+				}
+				inCols := cols.RemapColumns(colMap)
+				outCols := make(opt.ColList, len(inCols))
+				for i, col := range inCols {
+					outCols[i] = md.AddColumn(string(vb.names[i]), md.ColumnMeta(col).Type)
+				}
+				rows := f.ConstructWithScan(&memo.WithScanPrivate{
+					With:    binding,
+					InCols:  inCols,
+					OutCols: outCols,
+					ID:      md.NextUniqueID(),
+				})
+				if delta == nil {
+					delta, deltaCols = rows, outCols
+					continue
+				}
+				unionCols := addCols()
+				delta = f.ConstructUnionAll(delta, rows, &memo.SetPrivate{
+					LeftCols:  deltaCols,
+					RightCols: outCols,
+					OutCols:   unionCols,
+				})
+				deltaCols = unionCols
+			}
+
+			// Make the modified rows available to the statement as a CTE.
+			id := f.Memo().NextWithID()
+			md.AddWithBinding(id, delta)
+			presentation := make(physical.Presentation, len(deltaCols))
+			for i, col := range deltaCols {
+				presentation[i] = opt.AliasedColumn{Alias: string(vb.names[i]), ID: col}
+			}
+			cte := &cteSource{
+				name: tree.AliasClause{Alias: incrementalViewDeltaName},
+				cols: presentation,
+				expr: delta,
+				id:   id,
+				mtr:  tree.CTEMaterializeAlways,
+			}
+			inScope := b.allocScope()
+			inScope.ctes = map[string]*cteSource{incrementalViewDeltaName: cte}
+
+			viewID := descpb.ID(vb.view.ID())
+			keys := vb.def.KeysFromRows(vb.source, tree.NewUnqualifiedTableName(incrementalViewDeltaName))
+			var stmt tree.Statement
+			if vb.insert {
+				stmt = vb.def.InsertForKeys(vb.source, viewID, keys)
+			} else {
+				stmt = vb.def.DeleteForKeys(vb.source, viewID, vb.viewCols, keys)
+			}
+			b.maintainingIncrementalView = true
+			outScope := b.buildStmtAtRootWithScope(stmt, nil /* desiredTypes */, inScope)
+			return b.buildWiths(outScope.expr, cteSources{cte})
+		})
+}
@@ -822,6 +822,8 @@ func (mb *mutationBuilder) buildInsert(
 
 	mb.buildRowLevelAfterTriggers(opt.InsertOp)
 
+	mb.buildIncrementalViews(opt.InsertOp)
+
 	private := mb.makeMutationPrivate(returning != nil, vectorInsert)
 	mb.outScope.expr = mb.b.factory.ConstructInsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fastPathUniqueChecks, mb.fkChecks, private,
@@ -1059,6 +1061,8 @@ func (mb *mutationBuilder) buildUpsert(returning *tree.ReturningExprs) {
 
 	mb.buildRowLevelAfterTriggers(opt.InsertOp)
 
+	mb.buildIncrementalViews(opt.InsertOp)
+
 	private := mb.makeMutationPrivate(returning != nil, false /* vectorInsert */)
 	mb.outScope.expr = mb.b.factory.ConstructUpsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
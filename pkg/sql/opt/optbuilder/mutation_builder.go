@@ -168,7 +168,7 @@ type mutationBuilder struct {
 	// triggerColIDs is the set of column IDs used to project the OLD and NEW rows
 	// for row-level AFTER triggers, and possibly also contains the canary column.
 	// It is only populated if the mutation statement has row-level AFTER
-	// triggers.
+	// triggers or incrementally maintained views to update.
 	//
 	// NOTE: triggerColIDs may contain columns both contained and not contained in
 	// the lists above.
@@ -226,6 +226,10 @@ type mutationBuilder struct {
 	// afterTriggers contains AFTER triggers; see buildRowLevelAfterTriggers.
 	afterTriggers *memo.AfterTriggers
 
+	// incrementalViews contains the incrementally maintained materialized views
+	// to update; see buildIncrementalViews.
+	incrementalViews memo.IncrementalViews
+
 	// withID is nonzero if we need to buffer the input for FK or uniqueness
 	// checks.
 	withID opt.WithID
@@ -1845,6 +1849,7 @@ func (mb *mutationBuilder) makeMutationPrivate(
 		TriggerCols:                    mb.triggerColIDs,
 		FKCascades:                     mb.cascades,
 		AfterTriggers:                  mb.afterTriggers,
+		IncrementalViews:               mb.incrementalViews,
 		UniqueWithTombstoneIndexes:     mb.uniqueWithTombstoneIndexes.Ordered(),
 		VectorInsert:                   vectorInsert,
 	}
@@ -1852,7 +1857,7 @@ func (mb *mutationBuilder) makeMutationPrivate(
 	// If we didn't actually plan any checks, cascades, or triggers, don't buffer
 	// the input.
 	if len(mb.uniqueChecks) > 0 || len(mb.fkChecks) > 0 ||
-		len(mb.cascades) > 0 || mb.afterTriggers != nil {
+		len(mb.cascades) > 0 || mb.afterTriggers != nil || len(mb.incrementalViews) > 0 {
 		private.WithID = mb.withID
 	}
 
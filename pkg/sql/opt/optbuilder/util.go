@@ -631,11 +631,23 @@ func (b *Builder) resolveTableForMutation(
 		alias = *outerAlias
 	}
 
-	// We can't mutate materialized views.
-	if tab.IsMaterializedView() {
+	// We can't mutate materialized views, except to update incrementally
+	// maintained views.
+	if tab.IsMaterializedView() && !b.maintainingIncrementalView &&
+		!b.evalCtx.SessionData().AllowMaterializedViewMutation {
 		panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate materialized view %q", tab.Name()))
 	}
 
+	// Tables which have been set READ ONLY can't be mutated. The replicas of
+	// the table's ranges reject writes as well, but only once they learn of
+	// the table's span config, which happens asynchronously.
+	if tab.IsReadOnly() {
+		panic(errors.WithHint(
+			pgerror.Newf(pgcode.ReadOnlySQLTransaction, "cannot modify read-only table %q", tab.Name()),
+			"use ALTER TABLE ... SET READ WRITE to allow writes to the table",
+		))
+	}
+
 	return tab, depName, alias, columns
 }
 
@@ -736,6 +748,12 @@ func (b *Builder) resolveDataSourceRef(
 // privilege check is raised.
 func (b *Builder) checkPrivilege(name opt.MDDepName, ds cat.DataSource, privs ...privilege.Kind) {
 	priv := privs[0]
+	if b.maintainingIncrementalView {
+		// Incrementally maintained views are updated by the system on behalf of
+		// the statement that modified a base table, so no privileges are needed.
+		b.factory.Metadata().AddDependency(name, ds, 0, b.privilegeDependencyUser())
+		return
+	}
 	if priv == privilege.SELECT && b.skipSelectPrivilegeChecks {
 		// The check is skipped, so don't recheck when dependencies are checked.
 		b.factory.Metadata().AddDependency(name, ds, 0, b.privilegeDependencyUser())
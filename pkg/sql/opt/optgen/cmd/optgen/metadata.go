@@ -203,6 +203,7 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"WindowFrame":          {fullName: "memo.WindowFrame", passByVal: true},
 		"FKCascades":           {fullName: "memo.FKCascades", passByVal: true},
 		"AfterTriggers":        {fullName: "memo.AfterTriggers", isPointer: true},
+		"IncrementalViews":     {fullName: "memo.IncrementalViews", passByVal: true},
 		"ExplainOptions":       {fullName: "tree.ExplainOptions", passByVal: true},
 		"StatementReturnType":  {fullName: "tree.StatementReturnType", passByVal: true},
 		"StatementType":        {fullName: "tree.StatementType", passByVal: true},
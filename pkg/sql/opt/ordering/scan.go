@@ -9,6 +9,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/idxtype"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/errors"
 )
@@ -99,6 +100,13 @@ func ScanPrivateCanProvide(
 		}
 	}
 	index := md.Table(s.Table).Index(s.Index)
+	if index.Type() == idxtype.COLUMNAR {
+		// Columnar indexes can only be scanned in the forward direction.
+		if direction == ReverseDirection {
+			return false, false
+		}
+		direction = ForwardDirection
+	}
 	for left, right := 0, 0; right < len(required.Columns); {
 		if left >= index.KeyColumnCount() {
 			return false, false
@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/olekukonko/tablewriter"
 )
@@ -88,6 +89,31 @@ type Statistics struct {
 	// size of the column with ordinal i in its table. AvgSize is only non-nil
 	// when the statistics are built from a table.
 	AvgColSizes []uint64
+
+	// MultiColValues contains the most common values and functional dependency
+	// degrees of the multi-column statistics of a table. MultiColValues is only
+	// non-nil when the statistics are built from a table.
+	MultiColValues []MultiColValues
+}
+
+// MultiColValues contains the most common values and the functional
+// dependency degrees of a multi-column table statistic.
+type MultiColValues struct {
+	// Cols contains the columns of the statistic, in the same order as the
+	// values of each of the most common values.
+	Cols opt.ColList
+
+	// RowCount is the number of rows in the table when the statistic was
+	// collected.
+	RowCount float64
+
+	// MostCommonValues are the most frequent tuples of values of Cols, sorted
+	// by descending frequency.
+	MostCommonValues []cat.MostCommonValue
+
+	// Dependencies are the degrees of the functional dependencies between
+	// Cols. The From and To fields are indexes into Cols.
+	Dependencies []cat.ColumnDependency
 }
 
 // Init initializes the data members of Statistics.
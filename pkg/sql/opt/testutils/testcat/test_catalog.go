@@ -999,6 +999,11 @@ func (tt *Table) IsMaterializedView() bool {
 	return false
 }
 
+// IsReadOnly is part of the cat.Table interface.
+func (tt *Table) IsReadOnly() bool {
+	return false
+}
+
 // ColumnCount is part of the cat.Table interface.
 func (tt *Table) ColumnCount() int {
 	return len(tt.Columns)
@@ -1237,6 +1242,21 @@ func (tt *Table) Trigger(i int) cat.Trigger {
 	return &tt.Triggers[i]
 }
 
+// IncrementalViewCount is a part of the cat.Table interface.
+func (tt *Table) IncrementalViewCount() int {
+	return 0
+}
+
+// IncrementalView is a part of the cat.Table interface.
+func (tt *Table) IncrementalView(i int) cat.StableID {
+	panic(errors.AssertionFailedf("no incremental views"))
+}
+
+// MaterializedViewQuery is a part of the cat.Table interface.
+func (tt *Table) MaterializedViewQuery() string {
+	return ""
+}
+
 // IsRowLevelSecurityEnabled is part of the cat.Table interface.
 func (tt *Table) IsRowLevelSecurityEnabled() bool { return tt.rlsEnabled }
 
@@ -1600,6 +1620,7 @@ type TableStat struct {
 	evalCtx       *eval.Context
 	histogram     []cat.HistogramBucket
 	histogramType *types.T
+	mcvs          []cat.MostCommonValue
 	tc            *Catalog
 }
 
@@ -1714,6 +1735,56 @@ func (ts *TableStat) HistogramType() *types.T {
 	return ts.histogramType
 }
 
+// MostCommonValues is part of the cat.TableStatistic interface.
+func (ts *TableStat) MostCommonValues() []cat.MostCommonValue {
+	if ts.mcvs != nil || len(ts.js.MostCommonValues) == 0 {
+		return ts.mcvs
+	}
+	evalCtx := ts.evalCtx
+	if evalCtx == nil {
+		evalCtxVal := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+		evalCtx = &evalCtxVal
+	}
+	colTypes := make([]*types.T, len(ts.js.MCVColumnTypes))
+	for i, typStr := range ts.js.MCVColumnTypes {
+		colTypeRef, err := parser.GetTypeFromValidSQLSyntax(typStr)
+		if err != nil {
+			panic(err)
+		}
+		colTypes[i], err = tree.ResolveType(context.Background(), colTypeRef, ts.tc)
+		if err != nil {
+			panic(err)
+		}
+	}
+	ts.mcvs = make([]cat.MostCommonValue, len(ts.js.MostCommonValues))
+	for i := range ts.js.MostCommonValues {
+		mcv := &ts.js.MostCommonValues[i]
+		values := make(tree.Datums, len(mcv.Values))
+		for j := range mcv.Values {
+			datum, err := rowenc.ParseDatumStringAs(context.Background(), colTypes[j], mcv.Values[j], evalCtx, nil /* semaCtx */)
+			if err != nil {
+				panic(err)
+			}
+			values[j] = datum
+		}
+		ts.mcvs[i] = cat.MostCommonValue{NumEq: float64(mcv.NumEq), Values: values}
+	}
+	return ts.mcvs
+}
+
+// Dependencies is part of the cat.TableStatistic interface.
+func (ts *TableStat) Dependencies() []cat.ColumnDependency {
+	deps := make([]cat.ColumnDependency, len(ts.js.Dependencies))
+	for i := range ts.js.Dependencies {
+		deps[i] = cat.ColumnDependency{
+			From:   int(ts.js.Dependencies[i].FromColumn),
+			To:     int(ts.js.Dependencies[i].ToColumn),
+			Degree: ts.js.Dependencies[i].Degree,
+		}
+	}
+	return deps
+}
+
 // IsPartial is part of the cat.TableStatistic interface.
 func (ts *TableStat) IsPartial() bool {
 	return ts.js.IsPartial()
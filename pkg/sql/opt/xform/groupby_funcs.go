@@ -545,7 +545,7 @@ func (c *CustomFuncs) GenerateLimitedGroupByScans(
 	var iter scanIndexIter
 	var sb indexScanBuilder
 	sb.Init(c, sp.Table)
-	reject := rejectPrimaryIndex | rejectInvertedIndexes | rejectVectorIndexes
+	reject := rejectPrimaryIndex | rejectInvertedIndexes | rejectVectorIndexes | rejectColumnarIndexes
 	iter.Init(c.e.evalCtx, c.e, c.e.mem, &c.im, sp, nil /* filters */, reject)
 	iter.ForEach(func(index cat.Index, filters memo.FiltersExpr, indexCols opt.ColSet, isCovering bool, constProj memo.ProjectionsExpr) {
 		// The iterator only produces pseudo-partial indexes (the predicate is
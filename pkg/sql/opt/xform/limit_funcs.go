@@ -123,7 +123,7 @@ func (c *CustomFuncs) GenerateLimitedScans(
 	// Iterate over all non-inverted, non-partial, non-vector indexes, looking for
 	// those that can be limited.
 	var iter scanIndexIter
-	reject := rejectInvertedIndexes | rejectPartialIndexes | rejectVectorIndexes
+	reject := rejectInvertedIndexes | rejectPartialIndexes | rejectVectorIndexes | rejectColumnarIndexes
 	iter.Init(c.e.evalCtx, c.e, c.e.mem, &c.im, scanPrivate, nil /* filters */, reject)
 	iter.ForEach(func(index cat.Index, filters memo.FiltersExpr, indexCols opt.ColSet, isCovering bool, constProj memo.ProjectionsExpr) {
 		// The iterator rejects partial indexes because there are no filters to
@@ -293,7 +293,7 @@ func (c *CustomFuncs) GenerateLimitedTopKScans(
 	var iter scanIndexIter
 	var sb indexScanBuilder
 	sb.Init(c, sp.Table)
-	reject := rejectPrimaryIndex | rejectInvertedIndexes | rejectVectorIndexes
+	reject := rejectPrimaryIndex | rejectInvertedIndexes | rejectVectorIndexes | rejectColumnarIndexes
 	iter.Init(c.e.evalCtx, c.e, c.e.mem, &c.im, sp, nil /* filters */, reject)
 	iter.ForEach(func(index cat.Index, filters memo.FiltersExpr, indexCols opt.ColSet, isCovering bool, constProj memo.ProjectionsExpr) {
 		// The iterator only produces pseudo-partial indexes (the predicate is
@@ -15,6 +15,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/errors"
 )
@@ -43,6 +44,12 @@ func (c *CustomFuncs) GenerateIndexScans(
 	var pkCols opt.ColSet
 	var iter scanIndexIter
 	reject := rejectPrimaryIndex | rejectInvertedIndexes | rejectVectorIndexes
+	if c.e.evalCtx.SessionData().VectorizeMode == sessiondatapb.VectorizeOff ||
+		scanPrivate.Locking.IsLocking() {
+		// Columnar indexes can only be read by the vectorized engine, and their
+		// keys don't correspond to the rows that a locking scan must lock.
+		reject |= rejectColumnarIndexes
+	}
 	iter.Init(c.e.evalCtx, c.e, c.e.mem, &c.im, scanPrivate, nil /* filters */, reject)
 	iter.ForEach(func(index cat.Index, filters memo.FiltersExpr, indexCols opt.ColSet, isCovering bool, constProj memo.ProjectionsExpr) {
 		// The iterator only produces pseudo-partial indexes (the predicate is
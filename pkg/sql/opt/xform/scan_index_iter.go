@@ -46,6 +46,11 @@ const (
 
 	// rejectNonVectorIndexes excludes any non-vector indexes during iteration.
 	rejectNonVectorIndexes
+
+	// rejectColumnarIndexes excludes any columnar indexes during iteration.
+	// Columnar indexes can only be scanned in full, since the block holding a
+	// row is keyed by the first row of the block rather than by the row itself.
+	rejectColumnarIndexes
 )
 
 // scanIndexIter is a helper struct that facilitates iteration over the indexes
@@ -254,6 +259,11 @@ func (it *scanIndexIter) ForEachStartingAfter(ord int, f enumerateIndexFunc) {
 			continue
 		}
 
+		// Skip over columnar indexes if rejectColumnarIndexes is set.
+		if it.hasRejectFlags(rejectColumnarIndexes) && index.Type() == idxtype.COLUMNAR {
+			continue
+		}
+
 		pred, isPartialIndex := it.tabMeta.PartialIndexPredicate(ord)
 
 		// Skip over partial indexes if rejectPartialIndexes is set.
@@ -104,7 +104,7 @@ func (c *CustomFuncs) GeneratePartialIndexScans(
 	// Iterate over all partial indexes.
 	var pkCols opt.ColSet
 	var iter scanIndexIter
-	reject := rejectNonPartialIndexes | rejectInvertedIndexes | rejectVectorIndexes
+	reject := rejectNonPartialIndexes | rejectInvertedIndexes | rejectVectorIndexes | rejectColumnarIndexes
 	iter.Init(c.e.evalCtx, c.e, c.e.mem, &c.im, scanPrivate, filters, reject)
 	iter.ForEach(func(index cat.Index, remainingFilters memo.FiltersExpr, indexCols opt.ColSet, isCovering bool, constProj memo.ProjectionsExpr) {
 		var sb indexScanBuilder
@@ -439,7 +439,7 @@ func (c *CustomFuncs) GenerateConstrainedScans(
 
 	// Iterate over all non-inverted, non-vector indexes.
 	var iter scanIndexIter
-	reject := rejectInvertedIndexes | rejectVectorIndexes
+	reject := rejectInvertedIndexes | rejectVectorIndexes | rejectColumnarIndexes
 	iter.Init(c.e.evalCtx, c.e, c.e.mem, &c.im, scanPrivate, explicitFilters, reject)
 	iter.ForEach(func(index cat.Index, filters memo.FiltersExpr, indexCols opt.ColSet, isCovering bool, constProj memo.ProjectionsExpr) {
 
@@ -1354,7 +1354,7 @@ func (c *CustomFuncs) GenerateZigzagJoins(
 	// TODO(mgartner): We should consider primary indexes when it has multiple
 	// columns and only the first is being constrained.
 	var iter scanIndexIter
-	reject := rejectPrimaryIndex | rejectInvertedIndexes | rejectVectorIndexes
+	reject := rejectPrimaryIndex | rejectInvertedIndexes | rejectVectorIndexes | rejectColumnarIndexes
 	iter.Init(c.e.evalCtx, c.e, c.e.mem, &c.im, scanPrivate, filters, reject)
 	iter.ForEach(func(leftIndex cat.Index, outerFilters memo.FiltersExpr, leftCols opt.ColSet, _ bool, _ memo.ProjectionsExpr) {
 		leftFixed := c.indexConstrainedCols(leftIndex, scanPrivate.Table, fixedCols)
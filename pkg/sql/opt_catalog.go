@@ -407,6 +407,8 @@ func getDescFromCatalogObjectForPermissions(o cat.Object) (catalog.Descriptor, e
 		return t.getDescriptorForPermissionsCheck(), nil
 	case *optTable:
 		return t.desc, nil
+	case *indexrec.HypotheticalTable:
+		return convertTableToOptTable(t).desc, nil
 	case *optVirtualTable:
 		return t.desc, nil
 	case *optView:
@@ -422,6 +424,8 @@ func getDescForDataSource(o cat.DataSource) (catalog.TableDescriptor, error) {
 	switch t := o.(type) {
 	case *optTable:
 		return t.desc, nil
+	case *indexrec.HypotheticalTable:
+		return convertTableToOptTable(t).desc, nil
 	case *optVirtualTable:
 		return t.desc, nil
 	case *optView:
@@ -973,6 +977,10 @@ type optTable struct {
 
 	triggers []optTrigger
 
+	// incrementalViews are the IDs of the incrementally maintained materialized
+	// views that are updated by the statements that modify the table.
+	incrementalViews []cat.StableID
+
 	// canaryAndStableStatsDiffer is true when the canary (newest) and stable
 	// (second-newest) statistics for this table genuinely differ within the
 	// canary window.
@@ -1373,6 +1381,23 @@ func newOptTable(
 	// Move all triggers into the opt table.
 	ot.triggers = getOptTriggers(desc.GetTriggers())
 
+	// Collect the incrementally maintained materialized views that must be
+	// updated by mutations of the table. A view may have several references to
+	// the table, but must only be updated once.
+	for i := range desc.TableDesc().DependedOnBy {
+		ref := &desc.TableDesc().DependedOnBy[i]
+		if !ref.IncrementalView {
+			continue
+		}
+		found := false
+		for _, id := range ot.incrementalViews {
+			found = found || id == cat.StableID(ref.ID)
+		}
+		if !found {
+			ot.incrementalViews = append(ot.incrementalViews, cat.StableID(ref.ID))
+		}
+	}
+
 	// Add stats last, now that other metadata is initialized.
 	if stats != nil {
 		ot.stats = make([]optTableStat, len(stats))
@@ -1499,6 +1524,11 @@ func (ot *optTable) IsMaterializedView() bool {
 	return ot.desc.MaterializedView()
 }
 
+// IsReadOnly implements the cat.Table interface.
+func (ot *optTable) IsReadOnly() bool {
+	return ot.desc.GetReadOnly()
+}
+
 // ColumnCount is part of the cat.Table interface.
 func (ot *optTable) ColumnCount() int {
 	return len(ot.columns)
@@ -1717,6 +1747,24 @@ func (ot *optTable) Trigger(i int) cat.Trigger {
 	return &ot.triggers[i]
 }
 
+// IncrementalViewCount is part of the cat.Table interface.
+func (ot *optTable) IncrementalViewCount() int {
+	return len(ot.incrementalViews)
+}
+
+// IncrementalView is part of the cat.Table interface.
+func (ot *optTable) IncrementalView(i int) cat.StableID {
+	return ot.incrementalViews[i]
+}
+
+// MaterializedViewQuery is part of the cat.Table interface.
+func (ot *optTable) MaterializedViewQuery() string {
+	if !ot.desc.MaterializedView() {
+		return ""
+	}
+	return ot.desc.GetViewQuery()
+}
+
 // IsRowLevelSecurityEnabled is part of the cat.Table interface.
 func (ot *optTable) IsRowLevelSecurityEnabled() bool { return ot.rlsEnabled }
 
@@ -2185,29 +2233,39 @@ func (os *optTableStat) init(
 		}
 	}
 
-	// Verify that histogram column type matches table column type.
-	// TODO(49698): When we support multi-column histograms this check will need
-	// adjustment.
+	// Verify that histogram column types match table column types.
+	var err error
 	if len(os.columnOrdinals) == 1 {
 		col := tab.getCol(os.columnOrdinals[0])
-		if err := stat.HistogramData.TypeCheck(
+		err = stat.HistogramData.TypeCheck(
 			col.GetType(), string(tab.Name()), col.GetName(), stats.TSFromTime(stat.CreatedAt),
-		); err != nil {
-			// Column type in the histogram differs from column type in the
-			// table. This can happen after a metadata-only ALTER COLUMN TYPE that
-			// changes the type family (e.g., TIMESTAMPTZ to TIMESTAMP) without
-			// rewriting data or invalidating histograms.
-			if buildutil.CrdbTestBuild {
-				return false, errors.NewAssertionErrorWithWrappedErrf(
-					err, "type check failed while initializing stat %d", stat.StatisticID,
-				)
-			}
-			// For release builds, skip over the stat and log a warning.
-			if statFailedTypeCheckLogLimiter.ShouldLog() {
-				log.Dev.Warningf(ctx, "skipping stat %d due to failed type check: %v", stat.StatisticID, err)
-			}
-			return false, nil
+		)
+	} else if stat.HistogramData != nil && len(stat.HistogramData.MCVColumnTypes) > 0 {
+		colTypes := make([]*types.T, len(os.columnOrdinals))
+		colNames := make([]string, len(os.columnOrdinals))
+		for i, ord := range os.columnOrdinals {
+			col := tab.getCol(ord)
+			colTypes[i], colNames[i] = col.GetType(), col.GetName()
 		}
+		err = stat.HistogramData.TypeCheckMultiColumn(
+			colTypes, string(tab.Name()), colNames, stats.TSFromTime(stat.CreatedAt),
+		)
+	}
+	if err != nil {
+		// Column type in the histogram differs from column type in the
+		// table. This can happen after a metadata-only ALTER COLUMN TYPE that
+		// changes the type family (e.g., TIMESTAMPTZ to TIMESTAMP) without
+		// rewriting data or invalidating histograms.
+		if buildutil.CrdbTestBuild {
+			return false, errors.NewAssertionErrorWithWrappedErrf(
+				err, "type check failed while initializing stat %d", stat.StatisticID,
+			)
+		}
+		// For release builds, skip over the stat and log a warning.
+		if statFailedTypeCheckLogLimiter.ShouldLog() {
+			log.Dev.Warningf(ctx, "skipping stat %d due to failed type check: %v", stat.StatisticID, err)
+		}
+		return false, nil
 	}
 
 	return true, nil
@@ -2272,6 +2330,16 @@ func (os *optTableStat) HistogramType() *types.T {
 	return os.stat.HistogramData.ColumnType
 }
 
+// MostCommonValues is part of the cat.TableStatistic interface.
+func (os *optTableStat) MostCommonValues() []cat.MostCommonValue {
+	return os.stat.MostCommonValues
+}
+
+// Dependencies is part of the cat.TableStatistic interface.
+func (os *optTableStat) Dependencies() []cat.ColumnDependency {
+	return os.stat.Dependencies
+}
+
 // IsPartial is part of the cat.TableStatistic interface.
 func (os *optTableStat) IsPartial() bool {
 	return os.stat.IsPartial()
@@ -2719,6 +2787,11 @@ func (ot *optVirtualTable) IsMaterializedView() bool {
 	return false
 }
 
+// IsReadOnly implements the cat.Table interface.
+func (ot *optVirtualTable) IsReadOnly() bool {
+	return false
+}
+
 // ColumnCount is part of the cat.Table interface.
 func (ot *optVirtualTable) ColumnCount() int {
 	return len(ot.columns)
@@ -2904,6 +2977,21 @@ func (ot *optVirtualTable) Trigger(i int) cat.Trigger {
 	panic(errors.AssertionFailedf("no triggers"))
 }
 
+// IncrementalViewCount is part of the cat.Table interface.
+func (ot *optVirtualTable) IncrementalViewCount() int {
+	return 0
+}
+
+// IncrementalView is part of the cat.Table interface.
+func (ot *optVirtualTable) IncrementalView(i int) cat.StableID {
+	panic(errors.AssertionFailedf("no incremental views"))
+}
+
+// MaterializedViewQuery is part of the cat.Table interface.
+func (ot *optVirtualTable) MaterializedViewQuery() string {
+	return ""
+}
+
 // IsRowLevelSecurityEnabled is part of the cat.Table interface.
 func (ot *optVirtualTable) IsRowLevelSecurityEnabled() bool { return false }
 
@@ -1312,6 +1312,12 @@ func (ef *execFactory) ConstructExplainOpt(
 
 	var rows [][]tree.TypedExpr
 	ss := strings.Split(strings.Trim(planText, "\n"), "\n")
+	// Add the hypothetical indexes and their effect on the estimated cost to
+	// the output, if they exist.
+	if hypIndexes := ef.planner.instrumentation.explainHypotheticalIndexes; hypIndexes != nil {
+		ss = append(ss, "")
+		ss = append(ss, hypIndexes.format()...)
+	}
 	for _, line := range ss {
 		rows = append(rows, []tree.TypedExpr{tree.NewDString(line)})
 	}
@@ -36,6 +36,8 @@ func TestContextualHelp(t *testing.T) {
 		{`ALTER CHANGEFEED 123 ADD ??`, `ALTER CHANGEFEED`},
 		{`ALTER CHANGEFEED 123 DROP ??`, `ALTER CHANGEFEED`},
 		{`ALTER EXTERNAL CONNECTION ??`, `ALTER EXTERNAL CONNECTION`},
+		{`ALTER RESOURCE GROUP ??`, `ALTER RESOURCE GROUP`},
+		{`ALTER RESOURCE GROUP foo SET ??`, `ALTER RESOURCE GROUP`},
 		{`ALTER BACKUP foo ADD NEW_KMS=bar WITH OLD_KMS=foobar ??`, `ALTER BACKUP`},
 
 		{`ALTER JOB ??`, `ALTER JOB`},
@@ -49,6 +51,7 @@ func TestContextualHelp(t *testing.T) {
 		{`ALTER TABLE blah RENAME TO ??`, `ALTER TABLE`},
 		{`ALTER TABLE blah RENAME TO blih ??`, `ALTER TABLE`},
 		{`ALTER TABLE blah SPLIT AT (SELECT 1) ??`, `ALTER TABLE`},
+		{`ALTER TABLE blah REVERT TO SYSTEM TIME ??`, `ALTER TABLE`},
 
 		{`ALTER VIRTUAL CLUSTER 1 ??`, `ALTER VIRTUAL CLUSTER`},
 		{`ALTER VIRTUAL CLUSTER 1 SET ??`, `ALTER VIRTUAL CLUSTER`},
@@ -174,6 +177,9 @@ func TestContextualHelp(t *testing.T) {
 
 		{`COMMIT PREPARED 'foo' ??`, `COMMIT PREPARED`},
 
+		{`COPY BACKUP IN 'foo' TO 'bar' ??`, `COPY BACKUP`},
+		{`COPY BACKUP LATEST IN 'foo' TO 'bar' WITH detached ??`, `COPY BACKUP`},
+
 		{`CREATE UNIQUE ??`, `CREATE`},
 		{`CREATE UNIQUE INDEX ??`, `CREATE INDEX`},
 		{`CREATE INDEX IF NOT ??`, `CREATE INDEX`},
@@ -190,6 +196,9 @@ func TestContextualHelp(t *testing.T) {
 
 		{`CREATE EXTERNAL CONNECTION ??`, `CREATE EXTERNAL CONNECTION`},
 
+		{`CREATE RESOURCE GROUP ??`, `CREATE RESOURCE GROUP`},
+		{`CREATE RESOURCE GROUP foo WITH ??`, `CREATE RESOURCE GROUP`},
+
 		{`CREATE VIRTUAL CLUSTER ??`, `CREATE VIRTUAL CLUSTER`},
 		{`CREATE TENANT ??`, `CREATE VIRTUAL CLUSTER`},
 
@@ -246,6 +255,8 @@ func TestContextualHelp(t *testing.T) {
 
 		{`DROP EXTERNAL CONNECTION blah ??`, `DROP EXTERNAL CONNECTION`},
 
+		{`DROP RESOURCE GROUP ??`, `DROP RESOURCE GROUP`},
+
 		{`DROP USER ??`, `DROP ROLE`},
 		{`DROP USER IF ??`, `DROP ROLE`},
 		{`DROP USER IF EXISTS bluh ??`, `DROP ROLE`},
@@ -402,6 +413,10 @@ func TestContextualHelp(t *testing.T) {
 		{`SHOW STATEMENTS ??`, `SHOW STATEMENTS`},
 		{`SHOW LOCAL STATEMENTS ??`, `SHOW STATEMENTS`},
 
+		{`SHOW PLAN BASELINES ??`, `SHOW PLAN BASELINES`},
+
+		{`SHOW RESOURCE GROUPS ??`, `SHOW RESOURCE GROUPS`},
+
 		{`SHOW STATEMENT HINTS FOR ??`, `SHOW STATEMENT HINTS`},
 		{`SHOW STATEMENT HINTS ??`, `SHOW STATEMENT HINTS`},
 
@@ -417,6 +432,7 @@ func TestContextualHelp(t *testing.T) {
 		{`SHOW SCHEDULES ??`, `SHOW SCHEDULES`},
 
 		{`SHOW BACKUP 'foo' ??`, `SHOW BACKUP`},
+		{`SHOW BACKUP SCHEDULE 123 ??`, `SHOW BACKUP`},
 
 		{`SHOW CLUSTER SETTING all ??`, `SHOW CLUSTER SETTING`},
 		{`SHOW ALL CLUSTER ??`, `SHOW CLUSTER SETTING`},
@@ -276,6 +276,9 @@ func (u *sqlSymUnion) viewOptions() *tree.ViewOptions {
 func (u *sqlSymUnion) strPtr() *string {
     return u.val.(*string)
 }
+func (u *sqlSymUnion) explainOptionList() tree.ExplainOptionList {
+    return u.val.(tree.ExplainOptionList)
+}
 func (u *sqlSymUnion) strs() []string {
     return u.val.([]string)
 }
@@ -778,6 +781,9 @@ func (u *sqlSymUnion) checkExternalConnectionOptions() *tree.CheckExternalConnec
 func (u *sqlSymUnion) restoreOptions() *tree.RestoreOptions {
   return u.val.(*tree.RestoreOptions)
 }
+func (u *sqlSymUnion) copyBackupOptions() *tree.CopyBackupOptions {
+  return u.val.(*tree.CopyBackupOptions)
+}
 func (u *sqlSymUnion) transactionModes() tree.TransactionModes {
     return u.val.(tree.TransactionModes)
 }
@@ -1016,7 +1022,7 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC AS_JSON AT_AT
 %token <str> ASENSITIVE ASYMMETRIC AT ATOMIC ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY AVOID_FULL_SCAN
 
-%token <str> BACKUP BACKUPS BACKWARD BATCH BEFORE BEGIN BETWEEN BIDIRECTIONAL BIGINT BIGSERIAL BINARY BIT
+%token <str> BACKUP BACKUPS BACKWARD BASELINES BATCH BEFORE BEGIN BETWEEN BIDIRECTIONAL BIGINT BIGSERIAL BINARY BIT
 %token <str> BUCKET_COUNT
 %token <str> BOOLEAN BOTH BOX2D BY BYPASSRLS
 
@@ -1031,8 +1037,8 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %token <str> CURRENT_USER CURSOR CYCLE
 
 %token <str> DATA DATABASE DATABASES DATE DAY DEBUG DEBUG_IDS DEC DECIMAL DEFAULT DEFAULTS DEFINER
-%token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS
-%token <str> DISABLE DISCARD DISTANCE DISTINCT DO DOMAIN DOUBLE DROP
+%token <str> DEALLOCATE DECLARE DEDUPLICATE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS
+%token <str> DIFF DISABLE DISCARD DISTANCE DISTINCT DO DOMAIN DOUBLE DROP
 
 %token <str> EACH ELSE ENABLE ENCODING ENCRYPTED ENCRYPTION_PASSPHRASE END ENUM ENUMS ERRORS ESCAPE
 %token <str> EXCEPT EXCLUDE EXCLUDING EXCLUSIVE EXPLICIT EXISTS EXECUTE EXECUTION EXPERIMENTAL
@@ -1044,13 +1050,13 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %token <str> FILES FILTER FINGERPRINTS
 %token <str> FIRST FIRST_CONTAINED_BY FIRST_CONTAINS FLOAT FLOAT4 FLOAT8 FLOORDIV FOLLOWING FOR FORCE FORCE_INDEX
 %token <str> FORCE_INVERTED_INDEX FORCE_NOT_NULL FORCE_NULL FORCE_QUOTE FORCE_ZIGZAG
-%token <str> FOREIGN FORMAT FORWARD FREEZE FROM FULL FUNCTION FUNCTIONS
+%token <str> FORECAST FOREIGN FORMAT FORWARD FREEZE FROM FULL FUNCTION FUNCTIONS
 
 %token <str> GENERATED GEOGRAPHY GEOMETRY GEOMETRYM GEOMETRYZ GEOMETRYZM
 %token <str> GEOMETRYCOLLECTION GEOMETRYCOLLECTIONM GEOMETRYCOLLECTIONZ GEOMETRYCOLLECTIONZM
 %token <str> GLOBAL GOAL GRANT GRANTEE GRANTS GREATEST GROUP GROUPING GROUPS
 
-%token <str> HANDLER HAVING HASH HEADER HIGH HINTS HISTOGRAM HOLD HOUR
+%token <str> HANDLER HAVING HASH HEADER HIGH HINTS HISTOGRAM HOLD HOUR HYPOTHETICAL
 
 %token <str> IDENTITY
 %token <str> IF IFERROR IFNULL IGNORE_FOREIGN_KEYS ILIKE IMMEDIATE IMMEDIATELY IMMUTABLE IMPORT IN INCLUDE
@@ -1095,13 +1101,13 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %token <str> RANGE RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REDACT REF REFERENCES REFERENCING REFRESH
 %token <str> REGCLASS REGION REGIONAL REGIONS REGNAMESPACE REGPROC REGPROCEDURE REGROLE REGTYPE REINDEX
 %token <str> RELATIVE RELOCATE REMOVE_PATH REMOVE_REGIONS RENAME REPEATABLE REPLACE REPLICATED REPLICATION
-%token <str> RELEASE RESET RESOLVED RESTART RESTORE RESTRICT RESTRICTED RESTRICTIVE RESUME RETENTION RETURNING RETURN RETURNS REVISION REVISION_HISTORY
+%token <str> RELEASE RESET RESOLVED RESOURCE RESTART RESTORE RESTRICT RESTRICTED RESTRICTIVE RESUME RETENTION RETURNING RETURN RETURNS REVERT REVISION REVISION_HISTORY
 %token <str> REVOKE RIGHT ROLE ROLES ROLLBACK ROLLUP ROUTINES ROW ROWS RSHIFT RULE RUN RUNNING
 
 %token <str> SAVEPOINT SCANS SCATTER SCHEDULE SCHEDULES SCROLL SCHEMA SCHEMA_ONLY SCHEMAS SCRUB
 %token <str> SEARCH SECOND SECONDARY SECURITY SECURITY_INVOKER SELECT SEQUENCE SEQUENCES
 %token <str> SERIALIZABLE SERVER SERVICE SESSION SESSIONS SESSION_USER SET SETOF SETS SETTING SETTINGS
-%token <str> SHARE SHARED SHOW SIMILAR SIMPLE SIZE SKIP SKIP_LOCALITIES_CHECK SKIP_MISSING_FOREIGN_KEYS
+%token <str> SHARE SHARED SHOW SIGNING_KEY SIGNING_KMS SIMILAR SIMPLE SIZE SKIP SKIP_LOCALITIES_CHECK SKIP_MISSING_FOREIGN_KEYS
 %token <str> SKIP_MISSING_SEQUENCES SKIP_MISSING_SEQUENCE_OWNERS SKIP_MISSING_VIEWS SKIP_MISSING_UDFS SMALLINT SMALLSERIAL
 %token <str> SNAPSHOT SOME SOURCE SPLIT SQL SQLLOGIN
 %token <str> STABLE START STATE STATEMENT STATISTICS STATUS STDIN STDOUT STOP STRAIGHT STREAM STRICT STRING STORAGE STORE STORED STORING SUBJECT SUBSTRING SUPER
@@ -1197,6 +1203,8 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %type <tree.Statement> alter_table_set_schema_stmt
 %type <tree.Statement> alter_table_locality_stmt
 %type <tree.Statement> alter_table_logged_stmt
+%type <tree.Statement> alter_table_read_only_stmt
+%type <tree.Statement> alter_table_revert_stmt
 %type <tree.Statement> alter_table_owner_stmt
 
 // ALTER VIRTUAL CLUSTER
@@ -1307,6 +1315,7 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %type <tree.Statement> comment_stmt
 %type <tree.Statement> commit_stmt
 %type <tree.Statement> copy_stmt
+%type <tree.Statement> copy_backup_stmt
 
 %type <tree.Statement> create_stmt
 %type <tree.Statement> create_schedule_stmt
@@ -1315,8 +1324,10 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %type <tree.Statement> create_database_stmt
 %type <tree.Statement> create_extension_stmt
 %type <tree.Statement> create_external_connection_stmt
+%type <tree.Statement> create_resource_group_stmt
 %type <tree.Statement> create_language_stmt
 %type <tree.Statement> alter_external_connection_stmt
+%type <tree.Statement> alter_resource_group_stmt
 %type <tree.Statement> create_index_stmt
 %type <tree.Statement> create_role_stmt
 %type <tree.Statement> create_schedule_for_backup_stmt
@@ -1354,6 +1365,7 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %type <tree.Statement> drop_ddl_stmt
 %type <tree.Statement> drop_database_stmt
 %type <tree.Statement> drop_external_connection_stmt
+%type <tree.Statement> drop_resource_group_stmt
 %type <tree.Statement> drop_index_stmt
 %type <tree.Statement> drop_role_stmt
 %type <tree.Statement> drop_provisioned_roles_stmt
@@ -1432,6 +1444,7 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %type <tree.Statement> show_enums_stmt
 %type <tree.Statement> show_external_connections_stmt
 %type <tree.Statement> show_fingerprints_stmt opt_with_show_fingerprints_options fingerprint_options_list fingerprint_options
+%type <tree.Statement> show_plan_baselines_stmt
 %type <tree.Statement> show_statement_hints_stmt opt_with_show_hints_options show_hints_options_list show_hints_options
 %type <bool> experimental_or_not_fingerprints
 %type <tree.Statement> show_functions_stmt
@@ -1447,6 +1460,7 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %type <tree.Statement> show_locality_stmt
 %type <tree.Statement> show_survival_goal_stmt
 %type <tree.Statement> show_regions_stmt
+%type <tree.Statement> show_resource_groups_stmt
 %type <tree.Statement> show_roles_stmt
 %type <tree.Statement> show_schemas_stmt
 %type <tree.Statement> show_sequences_stmt
@@ -1507,6 +1521,7 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %type <[]tree.KVOption> kv_option_list opt_with_options var_set_list opt_with_schedule_options
 %type <*tree.BackupOptions> opt_with_backup_options backup_options backup_options_list
 %type <*tree.RestoreOptions> opt_with_restore_options restore_options restore_options_list
+%type <*tree.CopyBackupOptions> opt_with_copy_backup_options copy_backup_options copy_backup_options_list
 %type <*tree.TenantReplicationOptions> opt_with_replication_options replication_options replication_options_list source_replication_options source_replication_options_list
 %type <tree.ShowBackupDetails> show_backup_details
 %type <*tree.ShowJobOptions> show_job_options show_job_options_list
@@ -1518,8 +1533,9 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %type <str> import_format
 %type <str> storage_parameter_key
 %type <[]string> storage_parameter_key_list
-%type <tree.StorageParam> storage_parameter
+%type <tree.StorageParam> storage_parameter materialized_view_param
 %type <[]tree.StorageParam> storage_parameter_list opt_table_with opt_with_storage_parameter_list
+%type <[]tree.StorageParam> materialized_view_param_list opt_materialized_view_with
 
 %type <*tree.Select> select_no_parens
 %type <tree.SelectStatement> select_clause select_with_parens simple_select values_clause table_clause simple_select_clause
@@ -1733,7 +1749,8 @@ func (u *sqlSymUnion) filterType() tree.FilterType {
 %type <str> opt_changefeed_family
 
 %type <str> explain_option_name
-%type <[]string> explain_option_list opt_enum_val_list enum_val_list
+%type <tree.ExplainOptionList> explain_option_list
+%type <[]string> explain_hypothetical_index_list opt_enum_val_list enum_val_list
 %type <[]tree.CompositeTypeElem> composite_type_list opt_composite_type_list
 %type <*tree.CreateType> domain_constraint_list_opt
 
@@ -1978,6 +1995,7 @@ stmt_without_legacy_transaction:
 | analyze_stmt               // EXTEND WITH HELP: ANALYZE
 | call_stmt
 | copy_stmt
+| copy_backup_stmt           // EXTEND WITH HELP: COPY BACKUP
 | comment_stmt
 | execute_stmt               // EXTEND WITH HELP: EXECUTE
 | deallocate_stmt            // EXTEND WITH HELP: DEALLOCATE
@@ -2008,6 +2026,7 @@ stmt_without_legacy_transaction:
 alter_stmt:
   alter_ddl_stmt      // help texts in sub-rule
 | alter_external_connection_stmt // EXTEND WITH HELP: ALTER EXTERNAL CONNECTION
+| alter_resource_group_stmt // EXTEND WITH HELP: ALTER RESOURCE GROUP
 | alter_role_stmt     // EXTEND WITH HELP: ALTER ROLE
 | alter_virtual_cluster_stmt   /* SKIP DOC */
 | alter_unsupported_stmt
@@ -2070,6 +2089,8 @@ alter_ddl_stmt:
 //   ALTER TABLE ... SET SCHEMA <newschemaname>
 //   ALTER TABLE ... SET LOCALITY [REGIONAL BY [TABLE IN <region> | ROW] | GLOBAL]
 //   ALTER TABLE ... {ENABLE | DISABLE | FORCE | NO FORCE} ROW LEVEL SECURITY
+//   ALTER TABLE ... SET {READ ONLY | READ WRITE}
+//   ALTER TABLE ... REVERT TO SYSTEM TIME <expr> FROM <collectionURI>
 //
 // Column qualifiers:
 //   [CONSTRAINT <constraintname>] {NULL | NOT NULL | UNIQUE | PRIMARY KEY | CHECK (<expr>) | DEFAULT <expr>}
@@ -2095,6 +2116,8 @@ alter_table_stmt:
 | alter_table_set_schema_stmt
 | alter_table_locality_stmt
 | alter_table_logged_stmt
+| alter_table_read_only_stmt
+| alter_table_revert_stmt
 | alter_table_owner_stmt
 // ALTER TABLE has its error help token here because the ALTER TABLE
 // prefix is spread over multiple non-terminals.
@@ -3755,6 +3778,9 @@ opt_clear_data:
 //    kms="[kms_provider]://[kms_host]/[master_key_identifier]?[parameters]" : encrypt backups using KMS
 //    detached: execute backup job asynchronously, without waiting for its completion
 //    include_all_virtual_clusters: enable backups of all virtual clusters during a cluster backup
+//    signing_kms="[kms_provider]://[kms_host]/[master_key_identifier]?[parameters]" : sign backups using KMS
+//    signing_key="[base64 signing key wrapped by signing_kms]" : sign backups with this key
+//    deduplicate: store the files of full backups in a pool shared by the backups of the collection
 //
 // %SeeAlso: RESTORE, WEBDOCS/backup.html
 backup_stmt:
@@ -3893,6 +3919,22 @@ backup_options:
   {
     $$.val = &tree.BackupOptions{RevisionStream: true}
   }
+| SIGNING_KMS '=' string_or_placeholder
+  {
+    $$.val = &tree.BackupOptions{SigningKMSURI: $3.expr()}
+  }
+| SIGNING_KEY '=' string_or_placeholder
+  {
+    $$.val = &tree.BackupOptions{SigningKey: $3.expr()}
+  }
+| DEDUPLICATE
+  {
+    $$.val = &tree.BackupOptions{Deduplicate: tree.MakeDBool(true)}
+  }
+| DEDUPLICATE '=' a_expr
+  {
+    $$.val = &tree.BackupOptions{Deduplicate: $3.expr()}
+  }
 
 include_all_clusters:
   INCLUDE_ALL_SECONDARY_TENANTS { /* SKIP DOC */ }
@@ -4200,6 +4242,64 @@ create_external_connection_stmt:
 	}
  | CREATE EXTERNAL CONNECTION error // SHOW HELP: CREATE EXTERNAL CONNECTION
 
+// %Help: CREATE RESOURCE GROUP - create a new admission control resource group
+// %Category: Misc
+// %Text:
+// CREATE RESOURCE GROUP [IF NOT EXISTS] <name> [WITH <option> = <value> [, ...]]
+//
+// Options:
+//   cpu_weight = '<int>'                share of CPU relative to other groups (default 20)
+//   cpu_limit = '<float>'               fraction of CPU the group may use, in (0, 1] (default 1)
+//   roles = '<role>[, ...]'             roles whose sessions run in the group
+//   application_names = '<app>[, ...]'  application names whose sessions run in the group
+//   databases = '<db>[, ...]'           databases whose sessions run in the group
+// %SeeAlso: ALTER RESOURCE GROUP, DROP RESOURCE GROUP, SHOW RESOURCE GROUPS
+create_resource_group_stmt:
+  CREATE RESOURCE GROUP name opt_with_options
+  {
+    $$.val = &tree.CreateResourceGroup{Name: tree.Name($4), Options: $5.kvOptions()}
+  }
+| CREATE RESOURCE GROUP IF NOT EXISTS name opt_with_options
+  {
+    $$.val = &tree.CreateResourceGroup{Name: tree.Name($7), IfNotExists: true, Options: $8.kvOptions()}
+  }
+| CREATE RESOURCE GROUP error // SHOW HELP: CREATE RESOURCE GROUP
+
+// %Help: ALTER RESOURCE GROUP - change an admission control resource group
+// %Category: Misc
+// %Text:
+// ALTER RESOURCE GROUP [IF EXISTS] <name> SET <option> = <value> [, ...]
+//
+// See CREATE RESOURCE GROUP for the list of options. Options that are not
+// specified keep their current value.
+// %SeeAlso: CREATE RESOURCE GROUP, DROP RESOURCE GROUP, SHOW RESOURCE GROUPS
+alter_resource_group_stmt:
+  ALTER RESOURCE GROUP name SET kv_option_list
+  {
+    $$.val = &tree.AlterResourceGroup{Name: tree.Name($4), Options: $6.kvOptions()}
+  }
+| ALTER RESOURCE GROUP IF EXISTS name SET kv_option_list
+  {
+    $$.val = &tree.AlterResourceGroup{Name: tree.Name($6), IfExists: true, Options: $8.kvOptions()}
+  }
+| ALTER RESOURCE GROUP error // SHOW HELP: ALTER RESOURCE GROUP
+
+// %Help: DROP RESOURCE GROUP - remove an admission control resource group
+// %Category: Misc
+// %Text:
+// DROP RESOURCE GROUP [IF EXISTS] <name>
+// %SeeAlso: CREATE RESOURCE GROUP, ALTER RESOURCE GROUP, SHOW RESOURCE GROUPS
+drop_resource_group_stmt:
+  DROP RESOURCE GROUP name
+  {
+    $$.val = &tree.DropResourceGroup{Name: tree.Name($4)}
+  }
+| DROP RESOURCE GROUP IF EXISTS name
+  {
+    $$.val = &tree.DropResourceGroup{Name: tree.Name($6), IfExists: true}
+  }
+| DROP RESOURCE GROUP error // SHOW HELP: DROP RESOURCE GROUP
+
 // %Help: CHECK EXTERNAL CONNECTION - check the status of an external connection
 // %Category: Misc
 // %Text:
@@ -4305,6 +4405,10 @@ drop_external_connection_stmt:
 //    skip_localities_check: ignore difference of zone configuration between restore cluster and backup cluster
 //    new_db_name: renames the restored database. only applies to database restores
 //    include_all_virtual_clusters: enable backups of all virtual clusters during a cluster backup
+//    where: only restore the rows of a single restored table which satisfy the predicate
+//    columns: only restore the values of the listed columns of a single restored table
+//    signing_kms="[kms_provider]://[kms_host]/[master_key_identifier]?[parameters]" : verify the signatures of backups using KMS
+//    signing_key="[base64 signing key wrapped by signing_kms]" : verify the signatures of backups with this key
 // %SeeAlso: BACKUP, WEBDOCS/restore.html
 restore_stmt:
   RESTORE FROM error
@@ -4478,6 +4582,22 @@ restore_options:
   {
     $$.val = &tree.RestoreOptions{Grants: true}
   }
+| WHERE '=' string_or_placeholder
+  {
+    $$.val = &tree.RestoreOptions{Where: $3.expr()}
+  }
+| COLUMNS '=' string_or_placeholder
+  {
+    $$.val = &tree.RestoreOptions{Columns: $3.expr()}
+  }
+| SIGNING_KMS '=' string_or_placeholder
+  {
+    $$.val = &tree.RestoreOptions{SigningKMSURI: $3.expr()}
+  }
+| SIGNING_KEY '=' string_or_placeholder
+  {
+    $$.val = &tree.RestoreOptions{SigningKey: $3.expr()}
+  }
 
 virtual_cluster_opt:
   TENANT  { /* SKIP DOC */ }
@@ -4667,6 +4787,88 @@ copy_stmt:
      return unimplementedWithIssue(sqllex, 96590)
    }
 
+// %Help: COPY BACKUP - copy backups to another collection
+// %Category: CCL
+// %Text:
+// COPY BACKUP [<subdir> | LATEST] IN <collection> TO <collection>
+//        [ AS OF SYSTEM TIME <expr> ]
+//        [ WITH <option> [= <value>] [, ...] ]
+//
+// Without a subdirectory the whole collection is copied, including its
+// revision log. With one, the chain of that full backup is copied, ending at
+// the backup needed to restore as of the given time if AS OF SYSTEM TIME is
+// specified.
+//
+// Collections:
+//    "[scheme]://[host]/[path to collection]?[parameters]"
+//
+// Options:
+//    kms="[kms_provider]://[kms_host]/[master_key_identifier]?[parameters]" : unwrap the data key of the backups
+//    new_kms="[kms_provider]://[kms_host]/[master_key_identifier]?[parameters]" : wrap the data key of the copy
+//    detached: execute copy job asynchronously, without waiting for its completion
+// %SeeAlso: BACKUP, RESTORE
+copy_backup_stmt:
+  COPY BACKUP IN string_or_placeholder TO string_or_placeholder opt_as_of_clause opt_with_copy_backup_options
+  {
+    $$.val = &tree.CopyBackup{
+      From: $4.expr(),
+      To: $6.expr(),
+      AsOf: $7.asOfClause(),
+      Options: *($8.copyBackupOptions()),
+    }
+  }
+| COPY BACKUP string_or_placeholder IN string_or_placeholder TO string_or_placeholder opt_as_of_clause opt_with_copy_backup_options
+  {
+    $$.val = &tree.CopyBackup{
+      Subdir: $3.expr(),
+      From: $5.expr(),
+      To: $7.expr(),
+      AsOf: $8.asOfClause(),
+      Options: *($9.copyBackupOptions()),
+    }
+  }
+
+opt_with_copy_backup_options:
+  WITH copy_backup_options_list
+  {
+    $$.val = $2.copyBackupOptions()
+  }
+| WITH OPTIONS '(' copy_backup_options_list ')'
+  {
+    $$.val = $4.copyBackupOptions()
+  }
+| /* EMPTY */
+  {
+    $$.val = &tree.CopyBackupOptions{}
+  }
+
+copy_backup_options_list:
+  // Require at least one option
+  copy_backup_options
+  {
+    $$.val = $1.copyBackupOptions()
+  }
+| copy_backup_options_list ',' copy_backup_options
+  {
+    if err := $1.copyBackupOptions().CombineWith($3.copyBackupOptions()); err != nil {
+      return setErr(sqllex, err)
+    }
+  }
+
+copy_backup_options:
+  KMS '=' string_or_placeholder_opt_list
+  {
+    $$.val = &tree.CopyBackupOptions{DecryptionKMSURI: $3.stringOrPlaceholderOptList()}
+  }
+| NEW_KMS '=' string_or_placeholder_opt_list
+  {
+    $$.val = &tree.CopyBackupOptions{NewKMSURI: $3.stringOrPlaceholderOptList()}
+  }
+| DETACHED
+  {
+    $$.val = &tree.CopyBackupOptions{Detached: true}
+  }
+
 opt_with_copy_options:
   opt_with copy_options_list
   {
@@ -5033,6 +5235,7 @@ create_stmt:
 | create_extension_stmt  // EXTEND WITH HELP: CREATE EXTENSION
 | create_language_stmt   /* SKIP DOC */
 | create_external_connection_stmt // EXTEND WITH HELP: CREATE EXTERNAL CONNECTION
+| create_resource_group_stmt      // EXTEND WITH HELP: CREATE RESOURCE GROUP
 | create_virtual_cluster_stmt     // EXTEND WITH HELP: CREATE VIRTUAL CLUSTER
 | create_logical_replication_stream_stmt     // EXTEND WITH HELP: CREATE LOGICAL REPLICATION STREAM
 | create_schedule_stmt   // help texts in sub-rule
@@ -6885,6 +7088,7 @@ drop_stmt:
 | drop_provisioned_roles_stmt      // EXTEND WITH HELP: DROP PROVISIONED ROLES
 | drop_schedule_stmt               // EXTEND WITH HELP: DROP SCHEDULES
 | drop_external_connection_stmt // EXTEND WITH HELP: DROP EXTERNAL CONNECTION
+| drop_resource_group_stmt      // EXTEND WITH HELP: DROP RESOURCE GROUP
 | drop_virtual_cluster_stmt     // EXTEND WITH HELP: DROP VIRTUAL CLUSTER
 | drop_unsupported   {}
 | DROP error                    // SHOW HELP: DROP
@@ -7249,6 +7453,7 @@ analyze_target:
 //
 // Plan options:
 //     TYPES, VERBOSE, OPT
+//     HYPOTHETICAL INDEXES ('CREATE INDEX ...' [, ...])
 //
 // %SeeAlso: WEBDOCS/explain.html
 explain_stmt:
@@ -7264,7 +7469,7 @@ explain_stmt:
 | EXPLAIN '(' explain_option_list ')' explainable_stmt
   {
     var err error
-    $$.val, err = tree.MakeExplain($3.strs(), $5.stmt())
+    $$.val, err = tree.MakeExplainFromOptionList($3.explainOptionList(), $5.stmt())
     if err != nil {
       return setErr(sqllex, err)
     }
@@ -7288,7 +7493,9 @@ explain_stmt:
 | EXPLAIN ANALYZE '(' explain_option_list ')' explainable_stmt
   {
     var err error
-    $$.val, err = tree.MakeExplain(append($4.strs(), "ANALYZE"), $6.stmt())
+    opts := $4.explainOptionList()
+    opts.Names = append(opts.Names, "ANALYZE")
+    $$.val, err = tree.MakeExplainFromOptionList(opts, $6.stmt())
     if err != nil {
       return setErr(sqllex, err)
     }
@@ -7296,7 +7503,9 @@ explain_stmt:
 | EXPLAIN ANALYSE '(' explain_option_list ')' explainable_stmt
   {
     var err error
-    $$.val, err = tree.MakeExplain(append($4.strs(), "ANALYZE"), $6.stmt())
+    opts := $4.explainOptionList()
+    opts.Names = append(opts.Names, "ANALYZE")
+    $$.val, err = tree.MakeExplainFromOptionList(opts, $6.stmt())
     if err != nil {
       return setErr(sqllex, err)
     }
@@ -7370,9 +7579,31 @@ copy_to_stmt:
 explain_option_list:
   explain_option_name
   {
-    $$.val = []string{$1}
+    $$.val = tree.ExplainOptionList{Names: []string{$1}}
+  }
+| HYPOTHETICAL INDEXES '(' explain_hypothetical_index_list ')'
+  {
+    $$.val = tree.ExplainOptionList{HypotheticalIndexes: $4.strs()}
   }
 | explain_option_list ',' explain_option_name
+  {
+    opts := $1.explainOptionList()
+    opts.Names = append(opts.Names, $3)
+    $$.val = opts
+  }
+| explain_option_list ',' HYPOTHETICAL INDEXES '(' explain_hypothetical_index_list ')'
+  {
+    opts := $1.explainOptionList()
+    opts.HypotheticalIndexes = append(opts.HypotheticalIndexes, $6.strs()...)
+    $$.val = opts
+  }
+
+explain_hypothetical_index_list:
+  SCONST
+  {
+    $$.val = []string{$1}
+  }
+| explain_hypothetical_index_list ',' SCONST
   {
     $$.val = append($1.strs(), $3)
   }
@@ -7536,6 +7767,7 @@ backup_kms:
 // Options:
 //     REPLICATION STATUS
 //     CAPABILITIES
+//     STORAGE STATUS
 show_virtual_cluster_stmt:
   SHOW virtual_cluster_spec_opt_all opt_show_virtual_cluster_options
   {
@@ -7604,6 +7836,11 @@ show_virtual_cluster_options:
     /* SKIP DOC */
     $$.val = tree.ShowTenantOptions{WithPriorReplication: true}
   }
+| STORAGE STATUS
+  {
+    /* SKIP DOC */
+    $$.val = tree.ShowTenantOptions{WithStorageStatus: true}
+  }
 | show_virtual_cluster_options ',' REPLICATION STATUS
   {
     /* SKIP DOC */
@@ -7625,6 +7862,13 @@ show_virtual_cluster_options:
     o.WithPriorReplication = true
     $$.val = o
   }
+| show_virtual_cluster_options ',' STORAGE STATUS
+  {
+    /* SKIP DOC */
+    o := $1.showTenantOpts()
+    o.WithStorageStatus = true
+    $$.val = o
+  }
 
 // %Help: SHOW LOGICAL REPLICATION JOBS - display metadata about logical replication jobs
 // %Category: Experimental
@@ -9021,6 +9265,7 @@ show_stmt:
 | show_ranges_stmt           // EXTEND WITH HELP: SHOW RANGES
 | show_range_for_row_stmt
 | show_regions_stmt          // EXTEND WITH HELP: SHOW REGIONS
+| show_resource_groups_stmt  // EXTEND WITH HELP: SHOW RESOURCE GROUPS
 | show_survival_goal_stmt    // EXTEND_WITH_HELP: SHOW SURVIVAL GOAL
 | show_roles_stmt            // EXTEND WITH HELP: SHOW ROLES
 | show_savepoint_stmt        // EXTEND WITH HELP: SHOW SAVEPOINT
@@ -9043,6 +9288,7 @@ show_stmt:
 | SHOW error                 // SHOW HELP: SHOW
 | show_last_query_stats_stmt
 | show_full_scans_stmt
+| show_plan_baselines_stmt
 | show_statement_hints_stmt
 | show_default_privileges_stmt // EXTEND WITH HELP: SHOW DEFAULT PRIVILEGES
 | show_completions_stmt
@@ -9395,7 +9641,11 @@ show_histogram_stmt:
 
 // %Help: SHOW BACKUP - list backup contents
 // %Category: CCL
-// %Text: SHOW BACKUP [SCHEMAS|FILES|RANGES] <location>
+// %Text:
+// SHOW BACKUP [SCHEMAS|FILES|RANGES] <location>
+// SHOW BACKUP TABLE <tablename> FROM <subdirectory> IN <collectionURI> [AS OF SYSTEM TIME <expr>]
+// SHOW BACKUP DIFF TABLE <tablename> FROM <subdirectory> TO <subdirectory> IN <collectionURI>
+// SHOW BACKUP SCHEDULE <schedule_id> FORECAST
 // %SeeAlso: WEBDOCS/show-backup.html
 show_backup_stmt:
   SHOW BACKUPS IN string_or_placeholder_opt_list opt_show_backups_time_filter_clause opt_with_show_backups_options
@@ -9416,6 +9666,34 @@ show_backup_stmt:
 			Options: *$8.showBackupOptions(),
 		}
 	}
+| SHOW BACKUP TABLE table_name FROM string_or_placeholder IN string_or_placeholder_opt_list opt_as_of_clause opt_with_show_backup_options
+	{
+		$$.val = &tree.ShowBackup{
+			From:    true,
+			Details:    tree.BackupTableDetails,
+			Table:    $4.unresolvedObjectName(),
+			Path:    $6.expr(),
+			InCollection: $8.stringOrPlaceholderOptList(),
+			AsOf:    $9.asOfClause(),
+			Options: *$10.showBackupOptions(),
+		}
+	}
+| SHOW BACKUP DIFF TABLE table_name FROM string_or_placeholder TO string_or_placeholder IN string_or_placeholder_opt_list opt_with_show_backup_options
+	{
+		$$.val = &tree.ShowBackup{
+			From:    true,
+			Details:    tree.BackupDiffDetails,
+			Table:    $5.unresolvedObjectName(),
+			Path:    $7.expr(),
+			DiffTo:    $9.expr(),
+			InCollection: $11.stringOrPlaceholderOptList(),
+			Options: *$12.showBackupOptions(),
+		}
+	}
+| SHOW BACKUP SCHEDULE iconst64 FORECAST
+	{
+		$$.val = &tree.ShowBackupScheduleForecast{ScheduleID: uint64($4.int64())}
+	}
 | SHOW BACKUP string_or_placeholder IN string_or_placeholder_opt_list opt_with_show_backup_options
 	{
 		$$.val = &tree.ShowBackup{
@@ -9547,6 +9825,14 @@ show_backup_options:
  {
  $$.val = &tree.ShowBackupOptions{Privileges: true}
  }
+ | SIGNING_KMS '=' string_or_placeholder
+ {
+ $$.val = &tree.ShowBackupOptions{SigningKMSURI: $3.expr()}
+ }
+ | SIGNING_KEY '=' string_or_placeholder
+ {
+ $$.val = &tree.ShowBackupOptions{SigningKey: $3.expr()}
+ }
 
 opt_show_backups_time_filter_clause:
   NEWER THAN a_expr
@@ -10627,6 +10913,21 @@ show_default_session_variables_for_role_stmt:
      }
 | SHOW DEFAULT SESSION VARIABLES FOR role_or_group_or_user error // SHOW HELP: SHOW DEFAULT SESSION VARIABLES FOR ROLE
 
+// %Help: SHOW RESOURCE GROUPS - list admission control resource groups
+// %Category: Misc
+// %Text:
+// SHOW RESOURCE GROUPS
+//
+// Shows the resource groups with their options, mapping rules, and the CPU
+// time used and the work waiting in each group on the current node.
+// %SeeAlso: CREATE RESOURCE GROUP, ALTER RESOURCE GROUP, DROP RESOURCE GROUP
+show_resource_groups_stmt:
+  SHOW RESOURCE GROUPS
+  {
+    $$.val = &tree.ShowResourceGroups{}
+  }
+| SHOW RESOURCE GROUPS error // SHOW HELP: SHOW RESOURCE GROUPS
+
 // %Help: SHOW ROLES - list defined roles
 // %Category: Priv
 // %Text:
@@ -10980,6 +11281,23 @@ fingerprint_options:
     $$.val = &tree.ShowFingerprintOptions{ExcludedUserColumns: $4.stringOrPlaceholderOptList()}
   }
 
+// %Help: SHOW PLAN BASELINES - list plan baselines
+// %Category: Misc
+// %Text:
+// SHOW PLAN BASELINES
+//
+// Shows the accepted plans of statement fingerprints that are recorded when
+// sql.hints.plan_baselines.enabled is set, together with the regressed plans
+// that were rolled back by pinning the accepted plan.
+//
+// %SeeAlso: SHOW STATEMENT HINTS
+show_plan_baselines_stmt:
+  SHOW PLAN BASELINES
+  {
+    $$.val = &tree.ShowPlanBaselines{}
+  }
+| SHOW PLAN BASELINES error // SHOW HELP: SHOW PLAN BASELINES
+
 // %Help: SHOW STATEMENT HINTS - list statement hints for a fingerprint
 // %Category: Misc
 // %Text:
@@ -12803,10 +13121,16 @@ role_or_group_or_user:
 // %Category: DDL
 // %Text:
 // CREATE [TEMPORARY | TEMP] VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] [WITH ( <option> [= <value>] [, ....] )] AS <source>
-// CREATE [TEMPORARY | TEMP] MATERIALIZED VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] AS <source> [WITH [NO] DATA]
+// CREATE [TEMPORARY | TEMP] MATERIALIZED VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] [WITH ( <param> [= <value>] [, ....] )] AS <source> [WITH [NO] DATA]
 //
 // Options:
 //   security_invoker [= { true | false | 1 | 0 }]: controls view permissions (defaults to true if specified without value)
+//
+// Materialized view parameters:
+//   incremental [= { 'sync' | 'async' }]: maintain the view incrementally as the
+//     base tables change (defaults to 'sync' if specified without value)
+//   max_staleness = <interval>: how far an 'async' incremental view may lag behind
+//     its base tables
 // %SeeAlso: CREATE TABLE, SHOW CREATE, WEBDOCS/create-view.html
 create_view_stmt:
   CREATE opt_temp opt_view_recursive VIEW view_name opt_column_list opt_view_with AS select_stmt
@@ -12850,31 +13174,62 @@ create_view_stmt:
       Replace: false,
     }
   }
-| CREATE MATERIALIZED VIEW view_name opt_column_list AS select_stmt opt_with_data
+| CREATE MATERIALIZED VIEW view_name opt_column_list opt_materialized_view_with AS select_stmt opt_with_data
   {
     name := $4.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateView{
       Name: name,
       ColumnNames: $5.nameList(),
-      AsSource: $7.slct(),
+      AsSource: $8.slct(),
       Materialized: true,
-      WithData: $8.bool(),
+      StorageParams: $6.storageParams(),
+      WithData: $9.bool(),
     }
   }
-| CREATE MATERIALIZED VIEW IF NOT EXISTS view_name opt_column_list AS select_stmt opt_with_data
+| CREATE MATERIALIZED VIEW IF NOT EXISTS view_name opt_column_list opt_materialized_view_with AS select_stmt opt_with_data
   {
     name := $7.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateView{
       Name: name,
       ColumnNames: $8.nameList(),
-      AsSource: $10.slct(),
+      AsSource: $11.slct(),
       Materialized: true,
       IfNotExists: true,
-      WithData: $11.bool(),
+      StorageParams: $9.storageParams(),
+      WithData: $12.bool(),
     }
   }
 | CREATE opt_temp opt_view_recursive VIEW error // SHOW HELP: CREATE VIEW
 
+// Materialized views accept storage parameters without a value, e.g.
+// WITH (incremental).
+opt_materialized_view_with:
+  /* EMPTY */
+  {
+    $$.val = nil
+  }
+| WITH '(' materialized_view_param_list ')'
+  {
+    $$.val = $3.storageParams()
+  }
+
+materialized_view_param_list:
+  materialized_view_param
+  {
+    $$.val = []tree.StorageParam{$1.storageParam()}
+  }
+| materialized_view_param_list ',' materialized_view_param
+  {
+    $$.val = append($1.storageParams(), $3.storageParam())
+  }
+
+materialized_view_param:
+  storage_parameter
+| storage_parameter_key
+  {
+    $$.val = tree.StorageParam{Key: $1}
+  }
+
 opt_with_data:
   WITH NO DATA error
   {
@@ -13381,6 +13736,8 @@ opt_index_access_method:
         val = idxtype.FORWARD
       case "cspann", "hnsw":
         val = idxtype.VECTOR
+      case "columnar":
+        val = idxtype.COLUMNAR
       case "hash", "spgist", "brin":
         return unimplemented(sqllex, "index using " + $2)
       default:
@@ -13667,6 +14024,50 @@ alter_table_logged_stmt:
     }
   }
 
+alter_table_revert_stmt:
+  ALTER TABLE relation_expr REVERT TO SYSTEM TIME a_expr FROM string_or_placeholder
+  {
+    $$.val = &tree.AlterTableRevert{
+      Name: $3.unresolvedObjectName(),
+      AsOf: tree.AsOfClause{Expr: $8.expr()},
+      Collection: $10.expr(),
+    }
+  }
+
+alter_table_read_only_stmt:
+  ALTER TABLE relation_expr SET READ ONLY
+  {
+    $$.val = &tree.AlterTableSetReadOnly{
+      Name: $3.unresolvedObjectName(),
+      ReadOnly: true,
+      IfExists: false,
+    }
+  }
+| ALTER TABLE IF EXISTS relation_expr SET READ ONLY
+  {
+    $$.val = &tree.AlterTableSetReadOnly{
+      Name: $5.unresolvedObjectName(),
+      ReadOnly: true,
+      IfExists: true,
+    }
+  }
+| ALTER TABLE relation_expr SET READ WRITE
+  {
+    $$.val = &tree.AlterTableSetReadOnly{
+      Name: $3.unresolvedObjectName(),
+      ReadOnly: false,
+      IfExists: false,
+    }
+  }
+| ALTER TABLE IF EXISTS relation_expr SET READ WRITE
+  {
+    $$.val = &tree.AlterTableSetReadOnly{
+      Name: $5.unresolvedObjectName(),
+      ReadOnly: false,
+      IfExists: true,
+    }
+  }
+
 alter_table_owner_stmt:
   ALTER TABLE relation_expr OWNER TO role_spec
   {
@@ -19309,6 +19710,7 @@ unreserved_keyword:
 | BACKUP
 | BACKUPS
 | BACKWARD
+| BASELINES
 | BATCH
 | BEFORE
 | BEGIN
@@ -19369,6 +19771,7 @@ unreserved_keyword:
 | DEBUG
 | DEBUG_IDS
 | DECLARE
+| DEDUPLICATE
 | DELETE
 | DEFAULTS
 | DEFERRED
@@ -19378,6 +19781,7 @@ unreserved_keyword:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DIFF
 | DISABLE
 | DISCARD
 | DOMAIN
@@ -19423,6 +19827,7 @@ unreserved_keyword:
 | FORCE_INDEX
 | FORCE_INVERTED_INDEX
 | FORCE_ZIGZAG
+| FORECAST
 | FORWARD
 | FREEZE
 | FUNCTION
@@ -19448,6 +19853,7 @@ unreserved_keyword:
 | HISTOGRAM
 | HOLD
 | HOUR
+| HYPOTHETICAL
 | IDENTITY
 | IMMEDIATE
 | IMMEDIATELY
@@ -19643,6 +20049,7 @@ unreserved_keyword:
 | REPLICATION
 | RESET
 | RESOLVED
+| RESOURCE
 | RESTART
 | RESTORE
 | RESTRICT
@@ -19652,6 +20059,7 @@ unreserved_keyword:
 | RETENTION
 | RETURN
 | RETURNS
+| REVERT
 | REVISION
 | REVISION_HISTORY
 | REVOKE
@@ -19694,6 +20102,8 @@ unreserved_keyword:
 | SHARE
 | SHARED
 | SHOW
+| SIGNING_KEY
+| SIGNING_KMS
 | SIMPLE
 | SIZE
 | SKIP
@@ -19831,6 +20241,7 @@ bare_label_keywords:
 | BACKUP
 | BACKUPS
 | BACKWARD
+| BASELINES
 | BATCH
 | BEFORE
 | BEGIN
@@ -19915,6 +20326,7 @@ bare_label_keywords:
 | DEC
 | DECIMAL
 | DECLARE
+| DEDUPLICATE
 | DEFAULT
 | DEFAULTS
 | DEFERRABLE
@@ -19927,6 +20339,7 @@ bare_label_keywords:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DIFF
 | DISABLE
 | DISCARD
 | DISTINCT
@@ -19980,6 +20393,7 @@ bare_label_keywords:
 | FORCE_INDEX
 | FORCE_INVERTED_INDEX
 | FORCE_ZIGZAG
+| FORECAST
 | FOREIGN
 | FORMAT
 | FORWARD
@@ -20011,6 +20425,7 @@ bare_label_keywords:
 | HINTS
 | HISTOGRAM
 | HOLD
+| HYPOTHETICAL
 | IDENTITY
 | IF
 | IFERROR
@@ -20246,6 +20661,7 @@ bare_label_keywords:
 | REPLICATION
 | RESET
 | RESOLVED
+| RESOURCE
 | RESTART
 | RESTORE
 | RESTRICT
@@ -20255,6 +20671,7 @@ bare_label_keywords:
 | RETENTION
 | RETURN
 | RETURNS
+| REVERT
 | REVISION
 | REVISION_HISTORY
 | REVOKE
@@ -20300,6 +20717,8 @@ bare_label_keywords:
 | SHARE
 | SHARED
 | SHOW
+| SIGNING_KEY
+| SIGNING_KMS
 | SIMILAR
 | SIMPLE
 | SIZE
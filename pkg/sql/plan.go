@@ -14,6 +14,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec/execbuilder"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
@@ -196,6 +197,7 @@ var _ planNode = &alterSchemaNode{}
 var _ planNode = &alterSequenceNode{}
 var _ planNode = &alterTableNode{}
 var _ planNode = &alterTableOwnerNode{}
+var _ planNode = &alterTableSetReadOnlyNode{}
 var _ planNode = &alterTableSetSchemaNode{}
 var _ planNode = &alterTypeNode{}
 var _ planNode = &bufferNode{}
@@ -327,6 +329,11 @@ type planTop struct {
 	mem     *memo.Memo
 	catalog optPlanningCatalog
 
+	// checkpoints describes the materialized CTEs after which the main query
+	// may be re-optimized. It is only set when adaptive re-optimization is
+	// enabled; see maybeReoptimizeMainQuery.
+	checkpoints []execbuilder.Checkpoint
+
 	// auditEventBuilders becomes non-nil if the current statement
 	// is eligible for auditing (see sql/audit_logging.go)
 	auditEventBuilders []auditlogging.AuditEventBuilder
@@ -585,13 +592,23 @@ func (p *planner) maybePlanHook(ctx context.Context, stmt tree.Statement) (planN
 			}, header, p.execCfg.Stopper), nil
 		}
 
-		if fn, header, avoidBuffering, err := planHook.fn(ctx, stmt, p); err != nil {
+		fn, header, avoidBuffering, err := planHook.fn(ctx, stmt, p)
+		cleanups := p.planHookCleanups
+		p.planHookCleanups = nil
+		if err != nil || fn == nil {
+			for _, cleanup := range cleanups {
+				cleanup(ctx)
+			}
+		}
+		if err != nil {
 			return nil, err
 		} else if fn != nil {
 			if avoidBuffering {
 				p.curPlan.avoidBuffering = true
 			}
-			return newHookFnNode(planHook.name, fn, header, p.execCfg.Stopper), nil
+			n := newHookFnNode(planHook.name, fn, header, p.execCfg.Stopper)
+			n.cleanups = cleanups
+			return n, nil
 		}
 	}
 	return nil, nil
@@ -703,6 +720,19 @@ const (
 	// recorded in canary/stable experiment buckets even if StatsRollout is
 	// Canary or Stable.
 	planFlagCanaryAndStableStatsDiffer
+
+	// planFlagReoptimized is set if the main query was re-optimized after its
+	// materialized CTEs produced row counts that differed significantly from
+	// the optimizer's estimates.
+	planFlagReoptimized
+
+	// planFlagResultCacheHit is set if the results of the statement were served
+	// from the query result cache.
+	planFlagResultCacheHit
+
+	// planFlagResultCacheMiss is set if the statement was eligible for the
+	// query result cache but its results were not cached.
+	planFlagResultCacheMiss
 )
 
 // IsSet returns true if the receiver has all of the given flags set.
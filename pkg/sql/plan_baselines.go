@@ -0,0 +1,487 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/hintpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/hints"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats/persistedsqlstats/sqlstatsutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/cockroach/pkg/util/log/logpb"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// planBaselineStatsWindow is how far back updatePlanBaselines looks at SQL
+// statistics when comparing the plans of a statement fingerprint.
+const planBaselineStatsWindow = 2 * time.Hour
+
+// planBaselineKey identifies the plan baseline of a statement fingerprint.
+// Baselines are scoped to the database that the statements were executed in.
+type planBaselineKey struct {
+	fingerprint string
+	database    string
+}
+
+// updatePlanBaselines records the accepted plan of statement fingerprints from
+// recent SQL statistics, and rolls back plan regressions by pinning the
+// accepted plan with a statement hint. It runs after flushes of SQL statistics
+// in the SQL activity update job. Failures to update the baseline of a single
+// fingerprint are logged, and don't prevent updating the others.
+func updatePlanBaselines(ctx context.Context, execCfg *ExecutorConfig) error {
+	if !execCfg.Settings.Version.IsActive(
+		ctx, clusterversion.V26_2_StatementHintsTypeNameEnabledColumnsAdded,
+	) {
+		return nil
+	}
+	plans, err := getRecentPlanStats(
+		ctx, execCfg.InternalDB.Executor(), timeutil.Now().Add(-planBaselineStatsWindow),
+	)
+	if err != nil {
+		return err
+	}
+	var baselines []hints.PlanBaselineRow
+	if err := execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) (err error) {
+		baselines, err = hints.GetPlanBaselinesFromDB(ctx, txn)
+		return err
+	}); err != nil {
+		return err
+	}
+	byKey := make(map[planBaselineKey]*hints.PlanBaselineRow, len(baselines))
+	for i := range baselines {
+		byKey[planBaselineKey{baselines[i].Fingerprint, baselines[i].Database}] = &baselines[i]
+	}
+
+	keys := make([]planBaselineKey, 0, len(plans))
+	for key := range plans {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].fingerprint != keys[j].fingerprint {
+			return keys[i].fingerprint < keys[j].fingerprint
+		}
+		return keys[i].database < keys[j].database
+	})
+	for _, key := range keys {
+		row := byKey[key]
+		var baseline *hintpb.PlanBaseline
+		if row != nil {
+			baseline = &row.Baseline
+		}
+		var err error
+		switch action, plan := hints.EvaluatePlanBaseline(&execCfg.Settings.SV, baseline, plans[key]); action {
+		case hints.BaselineAccept:
+			err = acceptPlanBaseline(ctx, execCfg, key, row, plan)
+		case hints.BaselineRegress:
+			err = pinPlanBaseline(ctx, execCfg, key, row, plan)
+		}
+		if err != nil {
+			log.Dev.Warningf(ctx, "could not update plan baseline for %q: %v", key.fingerprint, err)
+		}
+	}
+	return nil
+}
+
+// getRecentPlanStats returns the latency of the plans of each statement
+// fingerprint executed by applications since the given time, merged across
+// aggregation intervals, nodes and applications.
+func getRecentPlanStats(
+	ctx context.Context, ex isql.Executor, since time.Time,
+) (map[planBaselineKey][]hints.PlanStats, error) {
+	const opName = "get-plan-baseline-stats"
+	it, err := ex.QueryIteratorEx(
+		ctx, opName, nil /* txn */, sessiondata.NodeUserSessionDataOverride, `
+SELECT
+  metadata->>'query',
+  COALESCE(metadata->>'db', ''),
+  plan_hash,
+  (statistics->'statistics'->>'cnt')::INT8,
+  (statistics->'statistics'->'svcLat'->>'mean')::FLOAT8,
+  (statistics->'statistics'->'svcLat'->>'sqDiff')::FLOAT8,
+  statistics->'statistics'->'planGists'->>0
+FROM system.statement_statistics
+WHERE aggregated_ts >= $1 AND app_name NOT LIKE '$ internal%'`,
+		since,
+	)
+	if err != nil {
+		return nil, err
+	}
+	type planKey struct {
+		planBaselineKey
+		hash uint64
+	}
+	merged := make(map[planKey]*hints.PlanStats)
+	var ok bool
+	for ok, err = it.Next(ctx); ok; ok, err = it.Next(ctx) {
+		row := it.Cur()
+		if row[0] == tree.DNull || row[3] == tree.DNull || row[6] == tree.DNull {
+			continue
+		}
+		hash, err := sqlstatsutil.DecodeBytesToUint64([]byte(tree.MustBeDBytes(row[2])))
+		if err != nil {
+			return nil, errors.CombineErrors(err, it.Close())
+		}
+		key := planKey{
+			planBaselineKey: planBaselineKey{
+				fingerprint: string(tree.MustBeDString(row[0])),
+				database:    string(tree.MustBeDString(row[1])),
+			},
+			hash: hash,
+		}
+		count := int64(tree.MustBeDInt(row[3]))
+		lat := appstatspb.NumericStat{
+			Mean:         float64(tree.MustBeDFloat(row[4])),
+			SquaredDiffs: float64(tree.MustBeDFloat(row[5])),
+		}
+		p, found := merged[key]
+		if !found {
+			merged[key] = &hints.PlanStats{
+				Gist: string(tree.MustBeDString(row[6])),
+				Hash: hash,
+				Latency: hintpb.PlanLatency{
+					Count: count, Mean: lat.Mean, SquaredDiffs: lat.SquaredDiffs,
+				},
+			}
+			continue
+		}
+		lat = appstatspb.AddNumericStats(appstatspb.NumericStat{
+			Mean: p.Latency.Mean, SquaredDiffs: p.Latency.SquaredDiffs,
+		}, lat, p.Latency.Count, count)
+		p.Latency = hintpb.PlanLatency{
+			Count: p.Latency.Count + count, Mean: lat.Mean, SquaredDiffs: lat.SquaredDiffs,
+		}
+	}
+	if err := errors.CombineErrors(err, it.Close()); err != nil {
+		return nil, err
+	}
+
+	res := make(map[planBaselineKey][]hints.PlanStats)
+	for key, p := range merged {
+		res[key.planBaselineKey] = append(res[key.planBaselineKey], *p)
+	}
+	for _, plans := range res {
+		sort.Slice(plans, func(i, j int) bool { return plans[i].Hash < plans[j].Hash })
+	}
+	return res, nil
+}
+
+// acceptPlanBaseline records the plan as the accepted plan of a statement
+// fingerprint, replacing the baseline in row if there is one.
+func acceptPlanBaseline(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	key planBaselineKey,
+	row *hints.PlanBaselineRow,
+	plan hints.PlanStats,
+) error {
+	baseline := &hintpb.PlanBaseline{
+		PlanGist: plan.Gist,
+		PlanHash: plan.Hash,
+		Latency:  plan.Latency,
+	}
+	return execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		if row != nil {
+			return hints.UpdatePlanBaselineInDB(ctx, txn, row.HintID, baseline)
+		}
+		var hint hintpb.StatementHintUnion
+		hint.SetValue(baseline)
+		_, err := hints.InsertHintIntoDB(ctx, execCfg.Settings, txn, key.fingerprint, hint, key.database)
+		return err
+	})
+}
+
+// pinPlanBaseline rolls back a regression to the given plan by adding a
+// statement hint that pins the accepted plan of the baseline in row, and
+// records the regressed plan as a candidate awaiting review.
+func pinPlanBaseline(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	key planBaselineKey,
+	row *hints.PlanBaselineRow,
+	plan hints.PlanStats,
+) error {
+	donorSQL, err := planPinDonorSQL(ctx, execCfg, key.fingerprint, row.Baseline.PlanGist)
+	if err != nil {
+		return err
+	}
+	// A hint that the regressed plan satisfies as well would not roll back the
+	// regression.
+	if regressedSQL, err := planPinDonorSQL(
+		ctx, execCfg, key.fingerprint, plan.Gist,
+	); err == nil && regressedSQL == donorSQL {
+		log.Dev.Infof(ctx,
+			"not pinning the plan baseline for %q: the hints that pin its accepted plan would not change the regressed plan",
+			key.fingerprint)
+		return nil
+	}
+	baseline := row.Baseline
+	baseline.State = hintpb.PlanBaseline_CANDIDATE
+	baseline.RegressedPlanGist = plan.Gist
+	baseline.RegressedPlanHash = plan.Hash
+	baseline.RegressedLatency = plan.Latency
+	if err := execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) (err error) {
+		var pin hintpb.StatementHintUnion
+		pin.SetValue(&hintpb.InjectHints{DonorSQL: donorSQL})
+		baseline.PinHintID, err = hints.InsertHintIntoDB(
+			ctx, execCfg.Settings, txn, key.fingerprint, pin, key.database,
+		)
+		if err != nil {
+			return err
+		}
+		return hints.UpdatePlanBaselineInDB(ctx, txn, row.HintID, &baseline)
+	}); err != nil {
+		return err
+	}
+	InsertEventRecords(ctx, execCfg, LogEverywhere, &eventpb.PinPlanBaseline{
+		CommonEventDetails:   logpb.CommonEventDetails{Timestamp: timeutil.Now().UnixNano()},
+		StatementFingerprint: key.fingerprint,
+		Database:             key.database,
+		PlanGist:             baseline.PlanGist,
+		MeanLatency:          baseline.Latency.Mean,
+		RegressedPlanGist:    plan.Gist,
+		RegressedMeanLatency: plan.Latency.Mean,
+		DonorSQL:             donorSQL,
+		HintID:               baseline.PinHintID,
+	})
+	return nil
+}
+
+// planPinDonorSQL returns the donor statement of an InjectHints hint that pins
+// the plan with the given gist for statements with the given fingerprint.
+func planPinDonorSQL(
+	ctx context.Context, execCfg *ExecutorConfig, fingerprint string, gist string,
+) (string, error) {
+	const opName = "decode-plan-baseline-gist"
+	rows, err := execCfg.InternalDB.Executor().QueryBufferedEx(
+		ctx, opName, nil /* txn */, sessiondata.NodeUserSessionDataOverride,
+		`SELECT crdb_internal.decode_plan_gist($1)`, gist,
+	)
+	if err != nil {
+		return "", err
+	}
+	plan := make([]string, len(rows))
+	for i, row := range rows {
+		plan[i] = string(tree.MustBeDString(row[0]))
+	}
+	fingerprintFlags := tree.FmtFlags(tree.QueryFormattingForFingerprintsMask.Get(
+		&execCfg.Settings.SV,
+	))
+	return makePlanPinDonorSQL(fingerprint, plan, fingerprintFlags)
+}
+
+// makePlanPinDonorSQL returns the donor statement of an InjectHints hint that
+// pins a plan, given as the lines of its decoded gist, for statements with the
+// given fingerprint. The hint forces each table of the statement to be read
+// through the index that the plan reads it through. Tables that the plan reads
+// through several indexes are left unhinted.
+//
+// If all of the plan's joins use the same algorithm, and the statement has as
+// many joins as the plan, each join of the statement is hinted to use it as
+// well. Lookup and inverted joins are only hinted if the tables they look up
+// are the right-hand sides of the statement's joins, since join hints also fix
+// the order of the joins as written.
+func makePlanPinDonorSQL(
+	fingerprint string, plan []string, fingerprintFlags tree.FmtFlags,
+) (string, error) {
+	v := planPinVisitor{
+		indexes:      make(map[string]tree.UnrestrictedName),
+		lookupTables: make(map[string]struct{}),
+	}
+	var joinHints []string
+	var op string
+	for _, line := range plan {
+		line = strings.TrimLeft(line, " │├└─")
+		if o, ok := strings.CutPrefix(line, "• "); ok {
+			op, _, _ = strings.Cut(o, " (")
+			if hint, isJoin := planJoinHint(op); isJoin {
+				joinHints = append(joinHints, hint)
+			}
+			continue
+		}
+		attr, ok := strings.CutPrefix(line, "table: ")
+		if !ok {
+			continue
+		}
+		attr, _ = strings.CutSuffix(attr, " (partial index)")
+		table, index, ok := strings.Cut(attr, "@")
+		if !ok {
+			continue
+		}
+		if op == "lookup join" || op == "inverted join" {
+			v.lookupTables[table] = struct{}{}
+		}
+		name := tree.UnrestrictedName(unquotePlanIdent(index))
+		if prev, seen := v.indexes[table]; seen && prev != name {
+			name = ""
+		}
+		v.indexes[table] = name
+	}
+
+	stmt, err := parser.ParseOne(fingerprint)
+	if err != nil {
+		return "", err
+	}
+	tree.WalkStmt(&v, stmt.AST)
+	if joinHint := uniformJoinHint(joinHints); joinHint != "" &&
+		len(joinHints) == len(v.joins) && v.pinJoins(joinHint) {
+		for _, join := range v.joins {
+			if join.JoinType == "" {
+				join.JoinType = tree.AstInner
+			}
+			join.Hint = joinHint
+			v.pinned++
+		}
+	}
+	if v.pinned == 0 {
+		return "", errors.New("plan has no index scans or joins to pin")
+	}
+
+	// Check that the hint applies to the fingerprint, as
+	// crdb_rewrite_inline_hints does for hints added by hand.
+	donorSQL := tree.FormatStatementHideConstants(stmt.AST, fingerprintFlags)
+	target, err := parser.ParseOne(fingerprint)
+	if err != nil {
+		return "", err
+	}
+	donor, err := tree.NewHintInjectionDonor(stmt.AST, fingerprintFlags)
+	if err != nil {
+		return "", err
+	}
+	if err := donor.Validate(target.AST, fingerprintFlags); err != nil {
+		return "", err
+	}
+	return donorSQL, nil
+}
+
+// uniformJoinHint returns the join hint of the joins of a plan if they are all
+// the same, and the empty string otherwise.
+func uniformJoinHint(joinHints []string) string {
+	if len(joinHints) == 0 {
+		return ""
+	}
+	for _, hint := range joinHints[1:] {
+		if hint != joinHints[0] {
+			return ""
+		}
+	}
+	return joinHints[0]
+}
+
+// planJoinHint returns the join hint that forces the algorithm of a join
+// operator of a plan, as named in EXPLAIN output, and whether the operator is a
+// join. The hint is empty for joins whose algorithm can't be hinted.
+func planJoinHint(op string) (hint string, isJoin bool) {
+	switch op {
+	case "hash join":
+		return tree.AstHash, true
+	case "merge join":
+		return tree.AstMerge, true
+	case "lookup join":
+		return tree.AstLookup, true
+	case "inverted join":
+		return tree.AstInverted, true
+	}
+	return "", strings.HasSuffix(op, " join")
+}
+
+// unquotePlanIdent reverses the quoting of an identifier in EXPLAIN output.
+func unquotePlanIdent(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return strings.ReplaceAll(s[1:len(s)-1], `""`, `"`)
+	}
+	return s
+}
+
+// planPinVisitor adds an index hint to each table of a statement that a plan
+// reads through a single index, and collects the joins of the statement.
+type planPinVisitor struct {
+	// indexes maps the names of the tables read by the plan, as formatted in
+	// EXPLAIN output, to the index they are read through. The index is empty
+	// for tables that are read through several indexes.
+	indexes map[string]tree.UnrestrictedName
+	// lookupTables are the names of the tables looked up by the lookup and
+	// inverted joins of the plan.
+	lookupTables map[string]struct{}
+	// joins are the joins of the statement, which have no hints.
+	joins []*tree.JoinTableExpr
+	// pinned is the number of index and join hints that were added.
+	pinned int
+}
+
+// pinJoins returns whether the joins of the statement can all be hinted to use
+// the given algorithm.
+func (v *planPinVisitor) pinJoins(hint string) bool {
+	for _, join := range v.joins {
+		if join.Hint != "" {
+			return false
+		}
+		if hint != tree.AstLookup && hint != tree.AstInverted {
+			continue
+		}
+		right, ok := tree.StripTableParens(join.Right).(*tree.AliasedTableExpr)
+		if !ok {
+			return false
+		}
+		tn, ok := right.Expr.(*tree.TableName)
+		if !ok {
+			return false
+		}
+		if _, ok := v.lookupTables[tn.ObjectName.String()]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+var _ tree.ExtendedVisitor = &planPinVisitor{}
+
+func (v *planPinVisitor) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	return true, expr
+}
+
+func (v *planPinVisitor) VisitPost(expr tree.Expr) tree.Expr { return expr }
+
+func (v *planPinVisitor) VisitTablePre(expr tree.TableExpr) (recurse bool, newExpr tree.TableExpr) {
+	if join, ok := expr.(*tree.JoinTableExpr); ok {
+		v.joins = append(v.joins, join)
+		return true, expr
+	}
+	t, ok := expr.(*tree.AliasedTableExpr)
+	if !ok || t.IndexFlags != nil {
+		return true, expr
+	}
+	tn, ok := t.Expr.(*tree.TableName)
+	if !ok {
+		return true, expr
+	}
+	if index := v.indexes[tn.ObjectName.String()]; index != "" {
+		t.IndexFlags = &tree.IndexFlags{Index: index}
+		v.pinned++
+	}
+	return true, expr
+}
+
+func (v *planPinVisitor) VisitTablePost(expr tree.TableExpr) tree.TableExpr { return expr }
+
+func (v *planPinVisitor) VisitStatementPre(
+	expr tree.Statement,
+) (recurse bool, newExpr tree.Statement) {
+	return true, expr
+}
+
+func (v *planPinVisitor) VisitStatementPost(expr tree.Statement) tree.Statement { return expr }
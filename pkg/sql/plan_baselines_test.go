@@ -0,0 +1,144 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakePlanPinDonorSQL(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	fingerprintFlags := tree.FmtFlags(tree.QueryFormattingForFingerprintsMask.Default())
+	testCases := []struct {
+		name        string
+		fingerprint string
+		plan        []string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:        "index scan",
+			fingerprint: "SELECT * FROM t WHERE a = _",
+			plan: []string{
+				"• scan",
+				"  table: t@t_a_idx",
+				"  spans: 1+ spans",
+			},
+			expected: "SELECT * FROM t@t_a_idx WHERE a = _",
+		},
+		{
+			name:        "table read through several indexes",
+			fingerprint: "SELECT * FROM t WHERE (a = _) OR (b = _)",
+			plan: []string{
+				"• distinct",
+				"│",
+				"└── • union all",
+				"    │",
+				"    ├── • scan",
+				"    │     table: t@t_a_idx",
+				"    │",
+				"    └── • scan",
+				"          table: t@t_b_idx",
+			},
+			expectError: true,
+		},
+		{
+			name:        "hash join",
+			fingerprint: "SELECT * FROM t1 INNER JOIN t2 ON t1.id = t2.id",
+			plan: []string{
+				"• hash join",
+				"│ equality: (id) = (id)",
+				"│",
+				"├── • scan",
+				"│     table: t1@t1_pkey",
+				"│",
+				"└── • scan",
+				"      table: t2@t2_pkey",
+			},
+			expected: "SELECT * FROM t1@t1_pkey INNER HASH JOIN t2@t2_pkey ON t1.id = t2.id",
+		},
+		{
+			name:        "lookup join into the right-hand side",
+			fingerprint: "SELECT * FROM t1 JOIN t2 ON t1.id = t2.id",
+			plan: []string{
+				"• lookup join",
+				"│ table: t2@t2_pkey",
+				"│ equality: (id) = (id)",
+				"│",
+				"└── • scan",
+				"      table: t1@t1_pkey",
+			},
+			expected: "SELECT * FROM t1@t1_pkey INNER LOOKUP JOIN t2@t2_pkey ON t1.id = t2.id",
+		},
+		{
+			name:        "lookup join into the left-hand side",
+			fingerprint: "SELECT * FROM t1 JOIN t2 ON t1.id = t2.id",
+			plan: []string{
+				"• lookup join",
+				"│ table: t1@t1_pkey",
+				"│ equality: (id) = (id)",
+				"│",
+				"└── • scan",
+				"      table: t2@t2_pkey",
+			},
+			expected: "SELECT * FROM t1@t1_pkey JOIN t2@t2_pkey ON t1.id = t2.id",
+		},
+		{
+			name:        "joins with different algorithms",
+			fingerprint: "SELECT * FROM t1 JOIN t2 ON t1.id = t2.id JOIN t3 ON t2.id = t3.id",
+			plan: []string{
+				"• merge join",
+				"│ equality: (id) = (id)",
+				"│",
+				"├── • hash join",
+				"│   │ equality: (id) = (id)",
+				"│   │",
+				"│   ├── • scan",
+				"│   │     table: t1@t1_pkey",
+				"│   │",
+				"│   └── • scan",
+				"│         table: t2@t2_pkey",
+				"│",
+				"└── • scan",
+				"      table: t3@t3_pkey",
+			},
+			expected: "SELECT * FROM t1@t1_pkey JOIN t2@t2_pkey ON t1.id = t2.id JOIN t3@t3_pkey ON t2.id = t3.id",
+		},
+		{
+			name:        "join of a plan without a statement join",
+			fingerprint: "SELECT * FROM t1, t2 WHERE t1.id = t2.id",
+			plan: []string{
+				"• hash join",
+				"│ equality: (id) = (id)",
+				"│",
+				"├── • scan",
+				"│     table: t1@t1_pkey",
+				"│",
+				"└── • scan",
+				"      table: t2@t2_pkey",
+			},
+			expected: "SELECT * FROM t1@t1_pkey, t2@t2_pkey WHERE t1.id = t2.id",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			donorSQL, err := makePlanPinDonorSQL(tc.fingerprint, tc.plan, fingerprintFlags)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, donorSQL)
+		})
+	}
+}
@@ -73,6 +73,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&alterTableNode{}):                                "alter table",
 	reflect.TypeOf(&alterTableOwnerNode{}):                           "alter table owner",
 	reflect.TypeOf(&alterTableSetLocalityNode{}):                     "alter table set locality",
+	reflect.TypeOf(&alterTableSetReadOnlyNode{}):                     "alter table set read only",
 	reflect.TypeOf(&alterTableSetSchemaNode{}):                       "alter table set schema",
 	reflect.TypeOf(&alterViewSetOptionsNode{}):                       "alter view set options",
 	reflect.TypeOf(&alterViewResetOptionsNode{}):                     "alter view reset options",
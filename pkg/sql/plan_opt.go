@@ -7,6 +7,7 @@ package sql
 
 import (
 	"context"
+	"fmt"
 	"slices"
 	"strings"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/optbuilder"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/xform"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -1029,7 +1031,17 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 		return m == tree.ExplainPlan || m == tree.ExplainDistSQL || m == tree.ExplainGist
 	}
 	e, isExplain := opc.p.stmt.AST.(*tree.Explain)
-	if isExplain && explainModeShowsRec(e.Mode) && p.SessionData().IndexRecommendationsEnabled {
+	var hypIndexes *explainHypotheticalIndexes
+	if isExplain && len(e.HypotheticalIndexes) > 0 {
+		// EXPLAIN (HYPOTHETICAL INDEXES ...) rebuilds the memo with the
+		// hypothetical indexes, instead of recommending indexes.
+		var err error
+		hypIndexes, bld, err = opc.buildHypotheticalIndexMemo(ctx, ast)
+		if err != nil {
+			return nil, err
+		}
+		f = opc.optimizer.Factory()
+	} else if isExplain && explainModeShowsRec(e.Mode) && p.SessionData().IndexRecommendationsEnabled {
 		indexRecs, err := opc.makeQueryIndexRecommendation(ctx)
 		if err != nil {
 			return nil, err
@@ -1042,6 +1054,10 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 			return nil, err
 		}
 	}
+	if hypIndexes != nil {
+		hypIndexes.cost = explainedStmtCost(opc.optimizer.Memo())
+		opc.p.instrumentation.explainHypotheticalIndexes = hypIndexes
+	}
 
 	// If this statement doesn't have placeholders and we have not constant-folded
 	// any VolatilityStable operators, add it to the cache.
@@ -1167,6 +1183,9 @@ func (opc *optPlanningCtx) runExecBuilder(
 	}
 	planTop.mem = mem
 	planTop.catalog = opc.catalog
+	if opc.p.canReoptimize(mem) {
+		planTop.checkpoints = bld.Checkpoints()
+	}
 	return nil
 }
 
@@ -1259,6 +1278,100 @@ func (opc *optPlanningCtx) makeQueryIndexRecommendation(
 	return indexRecs, nil
 }
 
+// explainHypotheticalIndexes contains the hypothetical indexes of an EXPLAIN
+// (HYPOTHETICAL INDEXES ...) statement, along with the estimated costs of the
+// explained statement with and without them.
+type explainHypotheticalIndexes struct {
+	// indexes contains the definitions of the hypothetical indexes, which are
+	// named if they were not in the EXPLAIN statement.
+	indexes []*tree.CreateIndex
+	// cost is the estimated cost of the statement with the hypothetical
+	// indexes.
+	cost memo.Cost
+	// baselineCost is the estimated cost of the statement without the
+	// hypothetical indexes.
+	baselineCost memo.Cost
+}
+
+// format returns the rows that are added to the output of EXPLAIN
+// (HYPOTHETICAL INDEXES ...).
+func (h *explainHypotheticalIndexes) format() []string {
+	rows := make([]string, 0, len(h.indexes)+2)
+	rows = append(rows, fmt.Sprintf("hypothetical indexes: %d", len(h.indexes)))
+	for i, def := range h.indexes {
+		rows = append(rows, fmt.Sprintf("%d. %s", i+1, tree.AsString(def)))
+	}
+	change := "n/a"
+	if h.baselineCost.C > 0 {
+		change = fmt.Sprintf("%+.2f%%", (h.cost.C-h.baselineCost.C)/h.baselineCost.C*100)
+	}
+	rows = append(rows, fmt.Sprintf(
+		"estimated cost: %.2f (without hypothetical indexes: %.2f, change: %s)",
+		h.cost.C, h.baselineCost.C, change,
+	))
+	return rows
+}
+
+// buildHypotheticalIndexMemo builds a memo for an EXPLAIN (HYPOTHETICAL
+// INDEXES ...) statement in which the tables have the hypothetical indexes.
+// The statement is first optimized as-is to find the estimated cost of the
+// explained statement without the hypothetical indexes. It is then rebuilt
+// using a catalog which resolves the tables to hypothetical tables, which
+// (unlike index recommendations, which update the table metadata of the built
+// memo) allows the predicates of hypothetical partial indexes to be built with
+// the statement. The rebuilt memo must still be optimized by the caller.
+func (opc *optPlanningCtx) buildHypotheticalIndexMemo(
+	ctx context.Context, ast tree.Statement,
+) (*explainHypotheticalIndexes, *optbuilder.Builder, error) {
+	p := opc.p
+	telemetry.Inc(sqltelemetry.ExplainHypotheticalIndexes)
+	explainStmt := ast.(*tree.Explain)
+	res := &explainHypotheticalIndexes{
+		indexes: make([]*tree.CreateIndex, len(explainStmt.HypotheticalIndexes)),
+	}
+	for i, sql := range explainStmt.HypotheticalIndexes {
+		stmt, err := parser.ParseOne(sql)
+		if err != nil {
+			return nil, nil, pgerror.Wrapf(err, pgcode.Syntax, "invalid hypothetical index %q", sql)
+		}
+		def, ok := stmt.AST.(*tree.CreateIndex)
+		if !ok {
+			return nil, nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"hypothetical index must be defined by a CREATE INDEX statement: %q", sql)
+		}
+		res.indexes[i] = def
+	}
+	hypTables, err := indexrec.BuildHypTablesFromIndexDefs(ctx, opc.catalog, res.indexes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := opc.optimizer.Optimize(); err != nil {
+		return nil, nil, err
+	}
+	res.baselineCost = explainedStmtCost(opc.optimizer.Memo())
+
+	hypCatalog := indexrec.NewHypotheticalCatalog(opc.catalog, hypTables)
+	opc.optimizer.Init(ctx, p.EvalContext(), hypCatalog)
+	f := opc.optimizer.Factory()
+	f.FoldingControl().AllowStableFolds()
+	f.Metadata().SetHintIDs(opc.p.GetHintIDs())
+	bld := optbuilder.New(ctx, &p.semaCtx, p.EvalContext(), hypCatalog, f, ast)
+	if err := bld.Build(); err != nil {
+		return nil, nil, err
+	}
+	return res, bld, nil
+}
+
+// explainedStmtCost returns the estimated cost of the statement explained by
+// the root EXPLAIN expression of an optimized memo.
+func explainedStmtCost(m *memo.Memo) memo.Cost {
+	if explainExpr, ok := m.RootExpr().(*memo.ExplainExpr); ok {
+		return explainExpr.Input.Cost()
+	}
+	return m.RootExpr().(memo.RelExpr).Cost()
+}
+
 // Optimizer returns the Optimizer associated with this planning context.
 func (opc *optPlanningCtx) Optimizer() interface{} {
 	return &opc.optimizer
@@ -0,0 +1,226 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec/execbuilder"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec/explain"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/optbuilder"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/xform"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// reoptimization describes a materialized CTE whose actual row count differed
+// from the optimizer's estimate enough to trigger the re-optimization of the
+// main query.
+type reoptimization struct {
+	label             string
+	estimatedRowCount float64
+	actualRowCount    int
+}
+
+// canReoptimize returns true if the main query of the statement planned with
+// the given memo may be re-optimized after its materialized CTEs have been
+// executed.
+//
+// Mutations are never re-optimized, since their checks and cascades are tied
+// to the original plan. Statements with injected hints are not re-optimized
+// either, since the hints were chosen for the original plan.
+func (p *planner) canReoptimize(mem *memo.Memo) bool {
+	sd := p.SessionData()
+	if !sd.OptimizerAdaptiveReoptimization ||
+		sd.ExperimentalDistSQLPlanningMode != sessiondatapb.ExperimentalDistSQLPlanningOff {
+		return false
+	}
+	if p.stmt.ASTWithInjectedHints != nil {
+		return false
+	}
+	if _, isCanned := p.stmt.AST.(*tree.CannedOptPlan); isCanned {
+		return false
+	}
+	_, isWith := mem.RootExpr().(*memo.WithExpr)
+	return isWith && !mem.RootExpr().Relational().CanMutate
+}
+
+// exceedsReoptimizationThreshold returns true if the given estimated and
+// actual row counts differ by more than the given factor.
+func exceedsReoptimizationThreshold(estimated float64, actual int, threshold float64) bool {
+	estimated = math.Max(estimated, 1)
+	actualF := math.Max(float64(actual), 1)
+	return math.Max(estimated/actualF, actualF/estimated) > threshold
+}
+
+// maybeReoptimizeMainQuery is called after the subqueries of the current plan
+// have been executed and before its main query is. If one of the materialized
+// CTEs at the root of the query produced a number of rows that differs from
+// the optimizer's estimate by more than the
+// optimizer_adaptive_reoptimization_threshold factor, the main query is
+// re-optimized with the observed row counts of all of them, and the new plan,
+// which reads the rows of the CTEs from their existing buffers, replaces the
+// main query of the current plan.
+//
+// Re-optimization is best-effort: if it fails, the original plan is kept.
+func (p *planner) maybeReoptimizeMainQuery(ctx context.Context) {
+	checkpoints := p.curPlan.checkpoints
+	if len(checkpoints) == 0 {
+		return
+	}
+	threshold := p.SessionData().OptimizerAdaptiveReoptimizationThreshold
+	rowCounts := make(map[opt.WithID]int, len(checkpoints))
+	var reoptimizations []reoptimization
+	for i := range checkpoints {
+		cp := &checkpoints[i]
+		buf, ok := p.curPlan.subqueryPlans[cp.SubqueryIdx].plan.planNode.(*bufferNode)
+		if !ok {
+			return
+		}
+		actual := buf.rows.rows.Len()
+		rowCounts[cp.ID] = actual
+		if exceedsReoptimizationThreshold(cp.EstimatedRowCount, actual, threshold) {
+			reoptimizations = append(reoptimizations, reoptimization{
+				label:             cp.Label,
+				estimatedRowCount: cp.EstimatedRowCount,
+				actualRowCount:    actual,
+			})
+		}
+	}
+	if len(reoptimizations) == 0 {
+		return
+	}
+	result, explainPlan, err := p.reoptimizeMainQuery(ctx, checkpoints, rowCounts)
+	if err != nil {
+		log.VEventf(ctx, 1, "failed to re-optimize the main query: %v", err)
+		return
+	}
+	if result == nil {
+		return
+	}
+	log.VEventf(ctx, 2, "re-optimized the main query after %s", reoptimizations[0].label)
+	p.curPlan.main.Close(ctx)
+	p.curPlan.main = result.main
+	p.curPlan.flags.Set(planFlagReoptimized)
+	if explainPlan != nil {
+		// The CTEs have already been executed as part of the original plan, so
+		// keep them in the EXPLAIN output.
+		if oldPlan := p.instrumentation.explainPlan; oldPlan != nil {
+			explainPlan.Subqueries = oldPlan.Subqueries
+		}
+		p.instrumentation.RecordExplainPlan(explainPlan)
+	}
+	p.instrumentation.reoptimizations = reoptimizations
+}
+
+// reoptimizeMainQuery builds a new plan for the main query of the current
+// statement, in which the materialized CTEs described by the given checkpoints
+// produce the given numbers of rows. It returns nil if the main query of the
+// new plan can't replace the one of the current plan.
+func (p *planner) reoptimizeMainQuery(
+	ctx context.Context, checkpoints []execbuilder.Checkpoint, rowCounts map[opt.WithID]int,
+) (_ *planComponents, _ *explain.Plan, retErr error) {
+	defer errorutil.MaybeCatchPanic(&retErr, nil /* errCallback */)
+
+	// Rebuild the normalized memo of the statement. Since building and
+	// normalization are deterministic, the IDs of the With expressions and
+	// their columns are the same as in the original memo.
+	opc := &p.optPlanningCtx
+	var o xform.Optimizer
+	o.Init(ctx, p.EvalContext(), opc.catalog)
+	f := o.Factory()
+	f.FoldingControl().AllowStableFolds()
+	bld := optbuilder.New(ctx, &p.semaCtx, p.EvalContext(), opc.catalog, f, p.stmt.AST)
+	if err := bld.Build(); err != nil {
+		return nil, nil, err
+	}
+	normMemo := o.DetachMemo(ctx)
+
+	// Copy the normalized memo, replacing the materialized CTEs by references
+	// to their buffers with the observed row counts.
+	f = o.Factory()
+	f.FoldingControl().AllowStableFolds()
+	replaced := 0
+	var replaceFn norm.ReplaceFunc
+	replaceFn = func(e opt.Expr) opt.Expr {
+		if with, ok := e.(*memo.WithExpr); ok {
+			if rowCount, ok := rowCounts[with.ID]; ok {
+				replaced++
+				f.Metadata().AddWithBinding(with.ID, f.ConstructFakeRel(&memo.FakeRelPrivate{
+					Props: observedBindingProps(with.Binding.Relational(), rowCount),
+				}))
+				return f.CopyAndReplaceDefault(with.Main, replaceFn)
+			}
+		}
+		return f.CopyAndReplaceDefault(e, replaceFn)
+	}
+	f.CopyAndReplace(normMemo, normMemo.RootExpr(), normMemo.RootProps(), replaceFn)
+	if replaced != len(rowCounts) {
+		return nil, nil, errors.AssertionFailedf(
+			"found %d of %d materialized CTEs in the rebuilt memo", replaced, len(rowCounts),
+		)
+	}
+	if _, err := o.Optimize(); err != nil {
+		return nil, nil, err
+	}
+
+	// Build the new plan. Its WithScan expressions read the buffers of the
+	// original plan.
+	execMemo := o.Memo()
+	var ef exec.Factory = newExecFactory(ctx, p)
+	shouldBuildExplainPlan := p.instrumentation.ShouldBuildExplainPlan()
+	if shouldBuildExplainPlan {
+		ef = explain.NewFactory(ef, p.SemaCtx(), p.EvalContext())
+	}
+	eb := execbuilder.New(
+		ctx, ef, &o, execMemo, opc.catalog, execMemo.RootExpr(),
+		p.SemaCtx(), p.EvalContext(), p.autoCommit, statements.IsANSIDML(p.stmt.AST),
+	)
+	eb.DisableTelemetry()
+	eb.AddCheckpoints(checkpoints)
+	plan, err := eb.Build()
+	if err != nil {
+		return nil, nil, err
+	}
+	var explainPlan *explain.Plan
+	var result *planComponents
+	if shouldBuildExplainPlan {
+		explainPlan = plan.(*explain.Plan)
+		result = explainPlan.WrappedPlan.(*planComponents)
+	} else {
+		result = plan.(*planComponents)
+	}
+	if len(result.subqueryPlans) > 0 || len(result.cascades) > 0 || len(result.checkPlans) > 0 ||
+		len(result.triggers) > 0 || !result.main.planColumns().TypesEqual(p.curPlan.main.planColumns()) {
+		result.close(ctx)
+		return nil, nil, nil
+	}
+	return result, explainPlan, nil
+}
+
+// observedBindingProps returns the logical properties of a With binding with
+// the given properties that produced the given number of rows.
+func observedBindingProps(binding *props.Relational, rowCount int) *props.Relational {
+	bindingProps := &props.Relational{}
+	bindingProps.OutputCols = binding.OutputCols
+	bindingProps.NotNullCols = binding.NotNullCols
+	bindingProps.FuncDeps.CopyFrom(&binding.FuncDeps)
+	bindingProps.Cardinality = props.Cardinality{Min: uint32(rowCount), Max: uint32(rowCount)}
+	bindingProps.Statistics().Available = true
+	// Row count must be greater than 0 or the stats code will throw an error.
+	bindingProps.Statistics().RowCount = math.Max(float64(rowCount), 1)
+	return bindingProps
+}
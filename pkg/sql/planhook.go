@@ -124,6 +124,12 @@ type PlanHookState interface {
 	LookupTenantInfo(ctx context.Context, tenantSpec *tree.TenantSpec, op string) (*mtinfopb.TenantInfo, error)
 	GetAvailableTenantID(ctx context.Context, name roachpb.TenantName) (roachpb.TenantID, error)
 	InternalSQLTxn() descs.Txn
+	// DeferPlanHookCleanup registers fn to be called when the plan produced by
+	// the plan hook being invoked is closed, whether or not it was executed. It
+	// is used to release resources acquired during planning which are needed
+	// by the returned PlanHookRowFn. If the plan hook returns an error, fn is
+	// called immediately.
+	DeferPlanHookCleanup(fn func(context.Context))
 }
 
 var _ jobsauth.AuthorizationAccessor = PlanHookState(nil)
@@ -156,6 +162,9 @@ type hookFnNode struct {
 	f       PlanHookRowFn
 	header  colinfo.ResultColumns
 	stopper *stop.Stopper
+	// cleanups are the functions registered with DeferPlanHookCleanup while
+	// planning the node. They are called when the node is closed.
+	cleanups []func(context.Context)
 
 	run hookFnRun
 }
@@ -224,4 +233,8 @@ func (f *hookFnNode) Close(ctx context.Context) {
 		// Block until the worker goroutine exits.
 		<-f.run.errCh
 	}
+	for _, cleanup := range f.cleanups {
+		cleanup(ctx)
+	}
+	f.cleanups = nil
 }
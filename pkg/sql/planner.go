@@ -285,6 +285,11 @@ type planner struct {
 	// be reused for an old prepared statement after a new statement has been prepared.
 	curPlan planTop
 
+	// planHookCleanups are the functions registered with DeferPlanHookCleanup
+	// by the plan hook currently being invoked. They are handed off to the
+	// hookFnNode planned by the hook.
+	planHookCleanups []func(context.Context)
+
 	// Avoid allocations by embedding commonly used objects and visitors.
 	txCtx     transform.ExprTransformContext
 	tableName tree.TableName
@@ -672,6 +677,11 @@ func (p *planner) ExecMon() *mon.BytesMonitor {
 	return p.execMon
 }
 
+// DeferPlanHookCleanup is part of the PlanHookState interface.
+func (p *planner) DeferPlanHookCleanup(fn func(context.Context)) {
+	p.planHookCleanups = append(p.planHookCleanups, fn)
+}
+
 // MaybeResolveSystemRoleOID is part of the eval.Planner interface.
 func (p *planner) MaybeResolveSystemRoleOID(ctx context.Context, roleOID oid.Oid) (string, bool) {
 	h := makeOidHasher()
@@ -1248,6 +1258,22 @@ func (p *planner) SetStatementHintEnabled(
 	)
 }
 
+// ResolvePlanBaseline is part of the eval.Planner interface.
+func (p *planner) ResolvePlanBaseline(
+	ctx context.Context, statementFingerprint string, optDatabase string, accept bool,
+) (bool, int64, error) {
+	// Plan baselines are identified by the hint_type column, which older versions
+	// don't have.
+	if !p.execCfg.Settings.Version.IsActive(
+		ctx, clusterversion.V26_2_StatementHintsTypeNameEnabledColumnsAdded,
+	) {
+		return false, 0, nil
+	}
+	return hints.ResolvePlanBaselineInDB(
+		ctx, p.InternalSQLTxn(), statementFingerprint, optDatabase, accept,
+	)
+}
+
 // ValidateSessionVariableHint is part of the eval.Planner interface.
 func (p *planner) ValidateSessionVariableHint(
 	ctx context.Context, varName, varValue string, safeUpdates bool,
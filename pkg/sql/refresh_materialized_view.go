@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
 )
 
 type refreshMaterializedViewNode struct {
@@ -34,6 +35,13 @@ func (p *planner) RefreshMaterializedView(
 	if !desc.MaterializedView() {
 		return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a materialized view", desc.Name)
 	}
+	if desc.GetIncrementalRefresh() != nil {
+		return nil, errors.WithHint(
+			pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"%q is an incrementally maintained materialized view", desc.Name),
+			"Incrementally maintained views are kept up to date automatically and cannot be refreshed.",
+		)
+	}
 
 	if err := p.CheckPrivilege(ctx, desc, privilege.MAINTAIN); err != nil {
 		return nil, err
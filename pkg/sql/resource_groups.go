@@ -0,0 +1,198 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/resourcegroups"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
+)
+
+var resourceGroupOptionValidation = exprutil.KVOptionValidationMap{
+	resourcegroups.OptCPUWeight:        exprutil.KVStringOptRequireValue,
+	resourcegroups.OptCPULimit:         exprutil.KVStringOptRequireValue,
+	resourcegroups.OptIOWeight:         exprutil.KVStringOptRequireValue,
+	resourcegroups.OptIOLimit:          exprutil.KVStringOptRequireValue,
+	resourcegroups.OptRoles:            exprutil.KVStringOptRequireValue,
+	resourcegroups.OptApplicationNames: exprutil.KVStringOptRequireValue,
+	resourcegroups.OptDatabases:        exprutil.KVStringOptRequireValue,
+}
+
+type createResourceGroupNode struct {
+	zeroInputPlanNode
+	n *tree.CreateResourceGroup
+}
+
+// CreateResourceGroup represents a CREATE RESOURCE GROUP statement.
+func (p *planner) CreateResourceGroup(
+	ctx context.Context, n *tree.CreateResourceGroup,
+) (planNode, error) {
+	if err := p.checkCanManageResourceGroups(ctx, n.StatementTag()); err != nil {
+		return nil, err
+	}
+	return &createResourceGroupNode{n: n}, nil
+}
+
+func (n *createResourceGroupNode) startExec(params runParams) error {
+	p, ctx := params.p, params.ctx
+	opts, err := p.ExprEvaluator(n.n.StatementTag()).KVOptions(
+		ctx, n.n.Options, resourceGroupOptionValidation,
+	)
+	if err != nil {
+		return err
+	}
+	groups, err := resourcegroups.Load(ctx, p.InternalSQLTxn())
+	if err != nil {
+		return err
+	}
+	name := string(n.n.Name)
+	for i := range groups {
+		if groups[i].Name == name {
+			if n.n.IfNotExists {
+				return nil
+			}
+			return pgerror.Newf(pgcode.DuplicateObject, "resource group %q already exists", name)
+		}
+	}
+	g := resourcegroups.MakeResourceGroup(name, resourcegroups.NextID(groups))
+	if err := g.ApplyOptions(opts); err != nil {
+		return err
+	}
+	if err := resourcegroups.CheckMappings(append(groups, g)); err != nil {
+		return err
+	}
+	return resourcegroups.Upsert(ctx, p.InternalSQLTxn(), &g)
+}
+
+func (*createResourceGroupNode) Next(runParams) (bool, error) { return false, nil }
+func (*createResourceGroupNode) Values() tree.Datums          { return nil }
+func (*createResourceGroupNode) Close(context.Context)        {}
+
+type alterResourceGroupNode struct {
+	zeroInputPlanNode
+	n *tree.AlterResourceGroup
+}
+
+// AlterResourceGroup represents an ALTER RESOURCE GROUP statement.
+func (p *planner) AlterResourceGroup(
+	ctx context.Context, n *tree.AlterResourceGroup,
+) (planNode, error) {
+	if err := p.checkCanManageResourceGroups(ctx, n.StatementTag()); err != nil {
+		return nil, err
+	}
+	return &alterResourceGroupNode{n: n}, nil
+}
+
+func (n *alterResourceGroupNode) startExec(params runParams) error {
+	p, ctx := params.p, params.ctx
+	opts, err := p.ExprEvaluator(n.n.StatementTag()).KVOptions(
+		ctx, n.n.Options, resourceGroupOptionValidation,
+	)
+	if err != nil {
+		return err
+	}
+	groups, err := resourcegroups.Load(ctx, p.InternalSQLTxn())
+	if err != nil {
+		return err
+	}
+	name := string(n.n.Name)
+	for i := range groups {
+		if groups[i].Name != name {
+			continue
+		}
+		g := &groups[i]
+		if err := g.ApplyOptions(opts); err != nil {
+			return err
+		}
+		if err := resourcegroups.CheckMappings(groups); err != nil {
+			return err
+		}
+		return resourcegroups.Upsert(ctx, p.InternalSQLTxn(), g)
+	}
+	if n.n.IfExists {
+		return nil
+	}
+	return pgerror.Newf(pgcode.UndefinedObject, "resource group %q does not exist", name)
+}
+
+func (*alterResourceGroupNode) Next(runParams) (bool, error) { return false, nil }
+func (*alterResourceGroupNode) Values() tree.Datums          { return nil }
+func (*alterResourceGroupNode) Close(context.Context)        {}
+
+type dropResourceGroupNode struct {
+	zeroInputPlanNode
+	n *tree.DropResourceGroup
+}
+
+// DropResourceGroup represents a DROP RESOURCE GROUP statement.
+func (p *planner) DropResourceGroup(
+	ctx context.Context, n *tree.DropResourceGroup,
+) (planNode, error) {
+	if err := p.checkCanManageResourceGroups(ctx, n.StatementTag()); err != nil {
+		return nil, err
+	}
+	return &dropResourceGroupNode{n: n}, nil
+}
+
+func (n *dropResourceGroupNode) startExec(params runParams) error {
+	name := string(n.n.Name)
+	found, err := resourcegroups.Delete(params.ctx, params.p.InternalSQLTxn(), name)
+	if err != nil {
+		return err
+	}
+	if !found && !n.n.IfExists {
+		return pgerror.Newf(pgcode.UndefinedObject, "resource group %q does not exist", name)
+	}
+	return nil
+}
+
+func (*dropResourceGroupNode) Next(runParams) (bool, error) { return false, nil }
+func (*dropResourceGroupNode) Values() tree.Datums          { return nil }
+func (*dropResourceGroupNode) Close(context.Context)        {}
+
+// resourceGroupID returns the ID of the resource group that the work of the
+// current statement runs in, or 0 if the session isn't mapped to one.
+func (p *planner) resourceGroupID() admissionpb.ResourceGroupID {
+	c := p.execCfg.ResourceGroupsCache
+	if c == nil {
+		return 0
+	}
+	sd := p.SessionData()
+	return c.Classify(sd.SessionUser(), sd.ApplicationName, sd.Database)
+}
+
+// checkCanManageResourceGroups returns an error if the current user may not
+// create, alter or drop resource groups, or if the cluster hasn't been
+// upgraded to a version with the resource_groups table. Resource groups
+// govern the admission of the work of every tenant on a node, so they are
+// managed like cluster settings.
+func (p *planner) checkCanManageResourceGroups(ctx context.Context, op string) error {
+	if !p.execCfg.Settings.Version.IsActive(ctx, clusterversion.V26_3_AddResourceGroupsTable) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is not supported until the cluster version is finalized", op)
+	}
+	if !p.execCfg.Codec.ForSystemTenant() {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"%s can only be run by the system tenant", op)
+	}
+	ok, err := p.HasGlobalPrivilegeOrRoleOption(ctx, privilege.MODIFYCLUSTERSETTING)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"only users with the %s privilege are allowed to run %s",
+			privilege.MODIFYCLUSTERSETTING.DisplayName(), op)
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package resourcegroups
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed/rangefeedcache"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/util/admission"
+	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/startup"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+)
+
+// Applier installs the configuration of the resource groups into the CPU
+// admission queue of a node, and reports the live usage of the groups. It is
+// implemented by *admission.CPUGrantCoordinators.
+type Applier interface {
+	SetResourceGroupConfig(config admission.ResourceGroupConfigSet)
+	ResourceGroupUsage() map[admissionpb.ResourceGroupID]admission.ResourceGroupUsage
+}
+
+// Cache keeps a copy of system.resource_groups up to date with a rangefeed.
+// It classifies the work of sessions into resource groups and, if it has an
+// Applier, installs the configuration of the groups into admission control
+// whenever the table changes. Because it is kept up to date via a rangefeed,
+// there can be a delay between when a group is changed and when the change
+// takes effect.
+type Cache struct {
+	clock   *hlc.Clock
+	f       *rangefeed.Factory
+	stopper *stop.Stopper
+	codec   keys.SQLCodec
+	db      isql.DB
+	applier Applier
+
+	// snapshot is the current immutable state of the cache.
+	snapshot atomic.Pointer[snapshot]
+}
+
+// snapshot is the state of the cache built from one read of the table.
+type snapshot struct {
+	groups []ResourceGroup
+	byRole map[string]admissionpb.ResourceGroupID
+	byApp  map[string]admissionpb.ResourceGroupID
+	byDB   map[string]admissionpb.ResourceGroupID
+}
+
+// NewCache creates a new Cache. The applier may be nil, in which case the
+// cache only classifies work.
+func NewCache(
+	clock *hlc.Clock,
+	f *rangefeed.Factory,
+	stopper *stop.Stopper,
+	codec keys.SQLCodec,
+	db isql.DB,
+	applier Applier,
+) *Cache {
+	c := &Cache{clock: clock, f: f, stopper: stopper, codec: codec, db: db, applier: applier}
+	c.snapshot.Store(makeSnapshot(nil /* groups */))
+	return c
+}
+
+// Start begins watching for updates in the resource_groups table. If the
+// table doesn't exist yet because the cluster hasn't been upgraded, the
+// rangefeed is started once the upgrade creates it.
+func (c *Cache) Start(ctx context.Context, sysTableResolver catalog.SystemTableIDResolver) error {
+	// We need to retry unavailable replicas here. This is only meant to be called
+	// at server startup.
+	lookup := func(ctx context.Context) (descpb.ID, error) {
+		return sysTableResolver.LookupSystemTableID(ctx, systemschema.ResourceGroupsTable.GetName())
+	}
+	tableID, err := startup.RunIdempotentWithRetryEx(ctx,
+		c.stopper.ShouldQuiesce(), "get-resource-groups-table-ID", lookup)
+	if err != nil {
+		return err
+	}
+	if tableID != descpb.InvalidID {
+		return c.startRangefeed(ctx, tableID)
+	}
+	return c.stopper.RunAsyncTask(ctx, "wait-for-resource-groups-table", func(ctx context.Context) {
+		ctx, cancel := c.stopper.WithCancelOnQuiesce(ctx)
+		defer cancel()
+		opts := retry.Options{InitialBackoff: time.Second, MaxBackoff: time.Minute}
+		for r := retry.StartWithCtx(ctx, opts); r.Next(); {
+			tableID, err := lookup(ctx)
+			if err != nil {
+				log.Dev.Warningf(ctx, "failed to look up the resource_groups table: %v", err)
+				continue
+			}
+			if tableID == descpb.InvalidID {
+				continue
+			}
+			if err := c.startRangefeed(ctx, tableID); err != nil {
+				log.Dev.Warningf(ctx, "failed to start the resource_groups rangefeed: %v", err)
+			}
+			return
+		}
+	})
+}
+
+// bufferEvent signals a change to the resource_groups table. The cache reads
+// the whole table on every change, so the events don't carry the rows.
+type bufferEvent struct {
+	ts hlc.Timestamp
+}
+
+// Timestamp implements the rangefeedbuffer.Event interface.
+func (e *bufferEvent) Timestamp() hlc.Timestamp {
+	return e.ts
+}
+
+func (c *Cache) startRangefeed(ctx context.Context, tableID descpb.ID) error {
+	tablePrefix := c.codec.TablePrefix(uint32(tableID))
+	tableSpan := roachpb.Span{
+		Key:    tablePrefix,
+		EndKey: tablePrefix.PrefixEnd(),
+	}
+	watcher := rangefeedcache.NewWatcher(
+		"resource-groups-watcher",
+		c.clock, c.f,
+		0, /* bufferSize */
+		[]roachpb.Span{tableSpan},
+		false, /* withPrevValue */
+		true,  /* withRowTSInInitialScan */
+		func(ctx context.Context, kv *kvpb.RangeFeedValue) (*bufferEvent, bool) {
+			return &bufferEvent{ts: kv.Timestamp()}, true
+		},
+		func(ctx context.Context, update rangefeedcache.Update[*bufferEvent]) {
+			// Ignore empty updates that only bump the resolved timestamp.
+			if update.Type == rangefeedcache.CompleteUpdate || len(update.Events) > 0 {
+				c.refresh(ctx)
+			}
+		},
+		nil, /* knobs */
+	)
+
+	// Kick off the rangefeedcache which will retry until the stopper stops. This
+	// function only returns an error if we're shutting down.
+	return rangefeedcache.Start(ctx, c.stopper, watcher, nil /* onError */)
+}
+
+// refresh reads the resource_groups table and installs its contents. If the
+// read fails, the previous contents are kept until the next change.
+func (c *Cache) refresh(ctx context.Context) {
+	var groups []ResourceGroup
+	opts := retry.Options{InitialBackoff: 100 * time.Millisecond, MaxRetries: 5}
+	var err error
+	for r := retry.StartWithCtx(ctx, opts); r.Next(); {
+		err = c.db.Txn(ctx, func(ctx context.Context, txn isql.Txn) (err error) {
+			groups, err = Load(ctx, txn)
+			return err
+		})
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		log.Dev.Warningf(ctx, "failed to read the resource_groups table: %v", err)
+		return
+	}
+	c.snapshot.Store(makeSnapshot(groups))
+	if c.applier == nil {
+		return
+	}
+	configs := make(map[admissionpb.ResourceGroupID]admission.ResourceGroupConfig, len(groups))
+	for i := range groups {
+		configs[groups[i].ID] = groups[i].Config()
+	}
+	set, err := admission.MakeResourceGroupConfigSet(configs)
+	if err != nil {
+		log.Dev.Warningf(ctx, "invalid resource group configuration: %v", err)
+		return
+	}
+	c.applier.SetResourceGroupConfig(set)
+}
+
+func makeSnapshot(groups []ResourceGroup) *snapshot {
+	s := &snapshot{
+		groups: groups,
+		byRole: make(map[string]admissionpb.ResourceGroupID),
+		byApp:  make(map[string]admissionpb.ResourceGroupID),
+		byDB:   make(map[string]admissionpb.ResourceGroupID),
+	}
+	// CheckMappings prevents a name from being mapped to several groups, but
+	// the groups are ordered by name so that the result is deterministic
+	// regardless.
+	add := func(m map[string]admissionpb.ResourceGroupID, names []string, id admissionpb.ResourceGroupID) {
+		for _, name := range names {
+			if _, ok := m[name]; !ok {
+				m[name] = id
+			}
+		}
+	}
+	for i := range groups {
+		g := &groups[i]
+		add(s.byRole, g.Roles, g.ID)
+		add(s.byApp, g.ApplicationNames, g.ID)
+		add(s.byDB, g.Databases, g.ID)
+	}
+	return s
+}
+
+// Classify returns the ID of the resource group that the work of a session
+// with the given user, application name and current database runs in, or 0 if
+// the session isn't mapped to a group. A group that lists the user takes
+// precedence over a group that lists the application name, which takes
+// precedence over a group that lists the database.
+func (c *Cache) Classify(
+	user username.SQLUsername, appName string, database string,
+) admissionpb.ResourceGroupID {
+	s := c.snapshot.Load()
+	if id, ok := s.byRole[user.Normalized()]; ok {
+		return id
+	}
+	if id, ok := s.byApp[appName]; ok {
+		return id
+	}
+	if id, ok := s.byDB[database]; ok {
+		return id
+	}
+	return 0
+}
+
+// Usage returns the live usage of the resource groups on this node, keyed by
+// resource group ID, or nil if the cache has no Applier.
+func (c *Cache) Usage() map[admissionpb.ResourceGroupID]admission.ResourceGroupUsage {
+	if c.applier == nil {
+		return nil
+	}
+	return c.applier.ResourceGroupUsage()
+}
@@ -0,0 +1,308 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package resourcegroups implements the admission control resource groups
+// defined with CREATE RESOURCE GROUP. The groups are stored in the
+// system.resource_groups table, and each SQL server keeps a copy of them up to
+// date with a rangefeed. The copy is used to route the work of sessions to
+// their resource group and, on KV nodes, to configure the CPU admission queue.
+package resourcegroups
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/admission"
+	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// The options of CREATE and ALTER RESOURCE GROUP.
+const (
+	OptCPUWeight        = "cpu_weight"
+	OptCPULimit         = "cpu_limit"
+	OptIOWeight         = "io_weight"
+	OptIOLimit          = "io_limit"
+	OptRoles            = "roles"
+	OptApplicationNames = "application_names"
+	OptDatabases        = "databases"
+)
+
+const (
+	// DefaultWeight is the CPU and IO weight of a resource group that doesn't
+	// specify one. It matches the weight of the built-in low group.
+	DefaultWeight = 20
+	// MaxWeight is the largest CPU or IO weight of a resource group.
+	MaxWeight = 10000
+	// DefaultLimit is the CPU and IO limit of a resource group that doesn't
+	// specify one, which lets the group use all of the resource.
+	DefaultLimit = 1.0
+)
+
+// ResourceGroup is a resource group, as stored in system.resource_groups.
+type ResourceGroup struct {
+	Name string
+	ID   admissionpb.ResourceGroupID
+	// CPUWeight is the share of CPU of the group relative to the other groups
+	// when CPU is contended.
+	CPUWeight int64
+	// CPULimit is the fraction of the CPU of a node that the group may use,
+	// in (0, 1].
+	CPULimit float64
+	// IOWeight and IOLimit are the IO counterparts of CPUWeight and CPULimit.
+	// The IO admission queues don't enforce them yet, so they can't be set
+	// and always hold the defaults.
+	//
+	// TODO(admission): with replication flow control, writes are admitted
+	// into the store work queues below raft, where only the tenant and the
+	// priority encoded in the raft entry are known. Enforcing IOWeight needs
+	// the resource group ID in that encoding, and IOLimit needs per-group IO
+	// tokens.
+	IOWeight int64
+	IOLimit  float64
+	// Roles, ApplicationNames and Databases are the mapping rules of the
+	// group: the work of a session whose user, application_name or current
+	// database is listed runs in the group. Roles are normalized usernames.
+	Roles            []string
+	ApplicationNames []string
+	Databases        []string
+}
+
+// MakeResourceGroup returns a resource group with the given name and ID and
+// the default options.
+func MakeResourceGroup(name string, id admissionpb.ResourceGroupID) ResourceGroup {
+	return ResourceGroup{
+		Name:      name,
+		ID:        id,
+		CPUWeight: DefaultWeight,
+		CPULimit:  DefaultLimit,
+		IOWeight:  DefaultWeight,
+		IOLimit:   DefaultLimit,
+	}
+}
+
+// ApplyOptions sets the options of the resource group from the given option
+// values. Options that are not in opts are left unchanged.
+func (g *ResourceGroup) ApplyOptions(opts map[string]string) error {
+	for k, v := range opts {
+		var err error
+		switch k {
+		case OptCPUWeight:
+			g.CPUWeight, err = parseWeight(k, v)
+		case OptCPULimit:
+			g.CPULimit, err = parseLimit(k, v)
+		case OptIOWeight, OptIOLimit:
+			err = unimplemented.Newf("resource group "+k,
+				"%s is not supported yet: the IO admission queues don't enforce resource groups", k)
+		case OptRoles:
+			g.Roles = parseList(v, func(s string) string {
+				u, _ := username.MakeSQLUsernameFromUserInput(s, username.PurposeValidation)
+				return u.Normalized()
+			})
+		case OptApplicationNames:
+			g.ApplicationNames = parseList(v, nil /* normalize */)
+		case OptDatabases:
+			g.Databases = parseList(v, nil /* normalize */)
+		default:
+			return pgerror.Newf(pgcode.InvalidParameterValue, "unknown resource group option %q", k)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseWeight(opt, v string) (int64, error) {
+	w, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+	if err != nil || w < 1 || w > MaxWeight {
+		return 0, pgerror.Newf(pgcode.InvalidParameterValue,
+			"%s must be an integer between 1 and %d, got %q", opt, MaxWeight, v)
+	}
+	return w, nil
+}
+
+func parseLimit(opt, v string) (float64, error) {
+	l, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil || math.IsNaN(l) || l <= 0 || l > 1 {
+		return 0, pgerror.Newf(pgcode.InvalidParameterValue,
+			"%s must be a number in (0, 1], got %q", opt, v)
+	}
+	return l, nil
+}
+
+// parseList splits a comma-separated list of names, dropping empty entries
+// and duplicates. The result is sorted.
+func parseList(v string, normalize func(string) string) []string {
+	var res []string
+	seen := make(map[string]struct{})
+	for _, s := range strings.Split(v, ",") {
+		s = strings.TrimSpace(s)
+		if normalize != nil {
+			s = normalize(s)
+		}
+		if _, ok := seen[s]; ok || s == "" {
+			continue
+		}
+		seen[s] = struct{}{}
+		res = append(res, s)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Config returns the CPU admission control configuration of the resource
+// group.
+func (g *ResourceGroup) Config() admission.ResourceGroupConfig {
+	return admission.ResourceGroupConfig{
+		Weight:    uint32(g.CPUWeight),
+		BurstFrac: g.CPULimit,
+		MaxCPU:    g.CPULimit >= 1,
+	}
+}
+
+// Datums returns the columns of the resource group, in the order of the
+// columns of system.resource_groups.
+func (g *ResourceGroup) Datums() tree.Datums {
+	return tree.Datums{
+		tree.NewDString(g.Name),
+		tree.NewDInt(tree.DInt(g.ID)),
+		tree.NewDInt(tree.DInt(g.CPUWeight)),
+		tree.NewDFloat(tree.DFloat(g.CPULimit)),
+		tree.NewDInt(tree.DInt(g.IOWeight)),
+		tree.NewDFloat(tree.DFloat(g.IOLimit)),
+		stringsToDatum(g.Roles),
+		stringsToDatum(g.ApplicationNames),
+		stringsToDatum(g.Databases),
+	}
+}
+
+// CheckMappings returns an error if a role, application name or database is
+// mapped to more than one of the given resource groups, since the work of a
+// session must run in a single group.
+func CheckMappings(groups []ResourceGroup) error {
+	for _, m := range []struct {
+		kind  string
+		names func(g *ResourceGroup) []string
+	}{
+		{kind: "role", names: func(g *ResourceGroup) []string { return g.Roles }},
+		{kind: "application name", names: func(g *ResourceGroup) []string { return g.ApplicationNames }},
+		{kind: "database", names: func(g *ResourceGroup) []string { return g.Databases }},
+	} {
+		owners := make(map[string]string)
+		for i := range groups {
+			g := &groups[i]
+			for _, name := range m.names(g) {
+				if owner, ok := owners[name]; ok {
+					return pgerror.Newf(pgcode.DuplicateObject,
+						"%s %q is already mapped to resource group %q", m.kind, name, owner)
+				}
+				owners[name] = g.Name
+			}
+		}
+	}
+	return nil
+}
+
+// NextID returns the ID to assign to a new resource group, given the existing
+// ones. IDs are never reused while a group with a larger ID exists.
+func NextID(groups []ResourceGroup) admissionpb.ResourceGroupID {
+	id := admissionpb.MinUserDefinedResourceGroupID
+	for i := range groups {
+		if groups[i].ID >= id {
+			id = groups[i].ID + 1
+		}
+	}
+	return id
+}
+
+const selectResourceGroupsQuery = `
+SELECT name, id, cpu_weight, cpu_limit, io_weight, io_limit, roles, application_names, databases
+  FROM system.resource_groups
+ ORDER BY name`
+
+// Load reads all the resource groups from system.resource_groups, ordered by
+// name.
+func Load(ctx context.Context, txn isql.Txn) ([]ResourceGroup, error) {
+	rows, err := txn.QueryBufferedEx(
+		ctx, "load-resource-groups", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		selectResourceGroupsQuery,
+	)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]ResourceGroup, 0, len(rows))
+	for _, row := range rows {
+		g := ResourceGroup{
+			Name:             string(tree.MustBeDString(row[0])),
+			ID:               admissionpb.ResourceGroupID(tree.MustBeDInt(row[1])),
+			CPUWeight:        int64(tree.MustBeDInt(row[2])),
+			CPULimit:         float64(tree.MustBeDFloat(row[3])),
+			IOWeight:         int64(tree.MustBeDInt(row[4])),
+			IOLimit:          float64(tree.MustBeDFloat(row[5])),
+			Roles:            datumToStrings(row[6]),
+			ApplicationNames: datumToStrings(row[7]),
+			Databases:        datumToStrings(row[8]),
+		}
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
+// Upsert writes the given resource group to system.resource_groups.
+func Upsert(ctx context.Context, txn isql.Txn, g *ResourceGroup) error {
+	_, err := txn.ExecEx(
+		ctx, "upsert-resource-group", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`UPSERT INTO system.resource_groups
+       (name, id, cpu_weight, cpu_limit, io_weight, io_limit, roles, application_names, databases)
+     VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		g.Name, int64(g.ID), g.CPUWeight, g.CPULimit, g.IOWeight, g.IOLimit,
+		stringsToDatum(g.Roles), stringsToDatum(g.ApplicationNames), stringsToDatum(g.Databases),
+	)
+	return err
+}
+
+// Delete removes the resource group with the given name from
+// system.resource_groups. It returns false if there is no such group.
+func Delete(ctx context.Context, txn isql.Txn, name string) (bool, error) {
+	n, err := txn.ExecEx(
+		ctx, "delete-resource-group", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`DELETE FROM system.resource_groups WHERE name = $1`, name,
+	)
+	return n > 0, err
+}
+
+func datumToStrings(d tree.Datum) []string {
+	arr := tree.MustBeDArray(d)
+	if arr.Len() == 0 {
+		return nil
+	}
+	res := make([]string, arr.Len())
+	for i, elem := range arr.Array {
+		res[i] = string(tree.MustBeDString(elem))
+	}
+	return res
+}
+
+func stringsToDatum(strs []string) *tree.DArray {
+	arr := tree.NewDArray(types.String)
+	for _, s := range strs {
+		if err := arr.Append(tree.NewDString(s)); err != nil {
+			panic(errors.NewAssertionErrorWithWrappedErrf(err, "appending to a string array"))
+		}
+	}
+	return arr
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package resourcegroups
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/util/admission"
+	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyOptions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	g := MakeResourceGroup("analytics", admissionpb.MinUserDefinedResourceGroupID)
+	require.NoError(t, g.ApplyOptions(map[string]string{
+		OptCPUWeight:        "50",
+		OptCPULimit:         "0.25",
+		OptRoles:            "Reporter, etl,,reporter",
+		OptApplicationNames: "dashboard",
+	}))
+	require.Equal(t, int64(50), g.CPUWeight)
+	require.Equal(t, 0.25, g.CPULimit)
+	require.Equal(t, int64(DefaultWeight), g.IOWeight)
+	require.Equal(t, DefaultLimit, g.IOLimit)
+	require.Equal(t, []string{"etl", "reporter"}, g.Roles)
+	require.Equal(t, []string{"dashboard"}, g.ApplicationNames)
+	require.Nil(t, g.Databases)
+	require.Equal(t, admission.ResourceGroupConfig{Weight: 50, BurstFrac: 0.25}, g.Config())
+
+	// Options that aren't specified are left unchanged, and an empty list
+	// clears the mapping.
+	require.NoError(t, g.ApplyOptions(map[string]string{OptCPULimit: "1", OptRoles: ""}))
+	require.Equal(t, int64(50), g.CPUWeight)
+	require.Nil(t, g.Roles)
+	require.Equal(t, admission.ResourceGroupConfig{Weight: 50, BurstFrac: 1, MaxCPU: true}, g.Config())
+
+	for _, opts := range []map[string]string{
+		{OptCPUWeight: "0"},
+		{OptCPUWeight: "1.5"},
+		{OptCPUWeight: "10001"},
+		{OptCPULimit: "0"},
+		{OptCPULimit: "1.5"},
+		{OptCPULimit: "NaN"},
+		// The IO options aren't enforced yet, so they are rejected.
+		{OptIOWeight: "50"},
+		{OptIOLimit: "0.5"},
+		{"memory_limit": "1"},
+	} {
+		require.Error(t, g.ApplyOptions(opts), "%v", opts)
+	}
+}
+
+func TestCheckMappings(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	groups := []ResourceGroup{
+		{Name: "a", Roles: []string{"etl"}, Databases: []string{"reports"}},
+		{Name: "b", Roles: []string{"reporter"}, ApplicationNames: []string{"reports"}},
+	}
+	require.NoError(t, CheckMappings(groups))
+
+	groups[1].Databases = []string{"reports"}
+	require.EqualError(t, CheckMappings(groups),
+		`database "reports" is already mapped to resource group "a"`)
+}
+
+func TestNextID(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	require.Equal(t, admissionpb.MinUserDefinedResourceGroupID, NextID(nil))
+	require.Equal(t, admissionpb.ResourceGroupID(106), NextID([]ResourceGroup{{ID: 100}, {ID: 105}}))
+}
+
+func TestClassify(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := NewCache(nil, nil, nil, keys.SystemSQLCodec, nil, nil /* applier */)
+	c.snapshot.Store(makeSnapshot([]ResourceGroup{
+		{Name: "a", ID: 100, Databases: []string{"reports"}},
+		{Name: "b", ID: 101, ApplicationNames: []string{"dashboard"}},
+		{Name: "c", ID: 102, Roles: []string{"etl"}},
+	}))
+
+	etl := username.MakeSQLUsernameFromPreNormalizedString("etl")
+	other := username.MakeSQLUsernameFromPreNormalizedString("other")
+	require.Equal(t, admissionpb.ResourceGroupID(102), c.Classify(etl, "dashboard", "reports"))
+	require.Equal(t, admissionpb.ResourceGroupID(101), c.Classify(other, "dashboard", "reports"))
+	require.Equal(t, admissionpb.ResourceGroupID(100), c.Classify(other, "psql", "reports"))
+	require.Equal(t, admissionpb.ResourceGroupID(0), c.Classify(other, "psql", "defaultdb"))
+	require.Nil(t, c.Usage())
+}
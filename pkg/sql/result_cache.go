@@ -0,0 +1,223 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/resultcache"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessionphase"
+	"github.com/cockroachdb/crlib/crtime"
+)
+
+// resultCacheKey returns the key under which the results of the statement
+// planned by p are stored in the query result cache, along with the IDs of the
+// tables that the statement reads. ok is false if the results can't be cached.
+//
+// Only SELECT statements executed in implicit transactions whose plans only
+// contain immutable expressions are cached. Since the statement is looked up
+// after it has been planned, privilege checks and name resolution happen as
+// usual, and the key only needs to identify the statement and the versions of
+// the descriptors it depends on. The key includes the statement text rather
+// than its fingerprint, because the fingerprint omits constants.
+func (ex *connExecutor) resultCacheKey(p *planner) (key string, tables []descpb.ID, ok bool) {
+	if ex.server.cfg.ResultCache == nil || !p.SessionData().ResultCacheEnabled ||
+		ex.executorType == executorTypeInternal || getPausablePortalInfo(p) != nil {
+		return "", nil, false
+	}
+	if _, isSelect := p.stmt.AST.(*tree.Select); !isSelect {
+		return "", nil, false
+	}
+	if !p.extendedEvalCtx.TxnImplicit || p.extendedEvalCtx.AsOfSystemTime != nil ||
+		ex.state.isHistorical.Load() {
+		return "", nil, false
+	}
+	if p.instrumentation.collectBundle || p.instrumentation.outputMode != unmodifiedOutput {
+		return "", nil, false
+	}
+	flags := p.curPlan.flags
+	if flags.IsSet(planFlagContainsMutation) || flags.IsSet(planFlagIsDDL) ||
+		flags.IsSet(planFlagContainsLocking) || flags.IsSet(planFlagUsesRLS) {
+		return "", nil, false
+	}
+	mem := p.curPlan.mem
+	if mem == nil || mem.RootExpr() == nil {
+		return "", nil, false
+	}
+	rel := mem.RootExpr().Relational()
+	if rel.CanMutate || rel.VolatilitySet.HasStable() || rel.VolatilitySet.HasVolatile() {
+		return "", nil, false
+	}
+	md := mem.Metadata()
+	// The tables read by routines aren't tracked in the metadata of the
+	// statement.
+	if md.HasUserDefinedRoutines() {
+		return "", nil, false
+	}
+
+	type dep struct {
+		id      descpb.ID
+		version uint64
+	}
+	var deps []dep
+	for _, tm := range md.AllTables() {
+		if tm.Table.IsVirtualTable() {
+			return "", nil, false
+		}
+		deps = append(deps, dep{id: descpb.ID(tm.Table.ID()), version: tm.Table.Version()})
+	}
+	for _, seq := range md.AllSequences() {
+		deps = append(deps, dep{id: descpb.ID(seq.ID()), version: seq.Version()})
+	}
+	if len(deps) == 0 {
+		return "", nil, false
+	}
+	slices.SortFunc(deps, func(a, b dep) int {
+		return cmp.Compare(a.id, b.id)
+	})
+	deps = slices.Compact(deps)
+
+	var sb strings.Builder
+	sb.WriteString(p.stmt.SQL)
+	sb.WriteByte(0)
+	sb.WriteString(p.User().Normalized())
+	for _, d := range deps {
+		sb.WriteByte(0)
+		sb.WriteString(strconv.FormatUint(uint64(d.id), 10))
+		sb.WriteByte('@')
+		sb.WriteString(strconv.FormatUint(d.version, 10))
+		tables = append(tables, d.id)
+	}
+	for _, typ := range md.AllUserDefinedTypes() {
+		sb.WriteByte(0)
+		sb.WriteString(strconv.FormatUint(uint64(typ.Oid()), 10))
+		sb.WriteByte('@')
+		sb.WriteString(strconv.FormatUint(uint64(typ.TypeMeta.Version), 10))
+	}
+	if ph := p.EvalContext().Placeholders; ph != nil {
+		for _, v := range ph.Values {
+			sb.WriteByte(0)
+			sb.WriteString(tree.AsStringWithFlags(v, tree.FmtParsable))
+		}
+	}
+	return sb.String(), tables, true
+}
+
+// serveFromResultCache returns the given cached rows as the results of the
+// statement planned by p instead of executing it, and records the statistics
+// of the statement.
+func (ex *connExecutor) serveFromResultCache(
+	ctx context.Context, p *planner, res RestrictedCommandResult, rows []tree.Datums,
+) error {
+	p.curPlan.flags.Set(planFlagResultCacheHit)
+	ex.sessionTracing.TraceExecStart(ctx, "result cache")
+	ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.PlannerStartExecStmt, crtime.NowMono())
+	for _, row := range rows {
+		if err := res.AddRow(ctx, row); err != nil {
+			// Errors from AddRow are communication errors, which are returned
+			// rather than set on the result.
+			return err
+		}
+	}
+	ex.sessionTracing.TraceExecEnd(ctx, res.Err(), res.RowsAffected())
+	ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.PlannerEndExecStmt, crtime.NowMono())
+
+	var stats topLevelQueryStats
+	populateQueryLevelStats(ctx, p, ex.server.cfg, &stats, &ex.cpuStatsCollector)
+	ex.recordStatementSummary(
+		ctx, p, int(ex.state.mu.autoRetryCounter), p.autoRetryStmtCounter,
+		res.RowsAffected(), res.Err(), stats,
+	)
+	if ex.server.cfg.TestingKnobs.AfterExecute != nil {
+		ex.server.cfg.TestingKnobs.AfterExecute(ctx, p.stmt.String(), false /* isInternal */, res.Err())
+	}
+	return nil
+}
+
+// recordResultCacheWrites adds the tables that the mutation statement planned
+// by p may write to the set of tables whose cached results are invalidated
+// when the transaction commits. Writes performed by triggers are only observed
+// by the rangefeeds of the cache.
+func (ex *connExecutor) recordResultCacheWrites(p *planner) {
+	if ex.server.cfg.ResultCache == nil || p.curPlan.mem == nil {
+		return
+	}
+	writes := &ex.extraTxnState.resultCacheWrites
+	for _, tm := range p.curPlan.mem.Metadata().AllTables() {
+		writes.Add(descpb.ID(tm.Table.ID()))
+		// Cascades are planned separately, so they aren't part of the metadata.
+		for i, n := 0, tm.Table.InboundForeignKeyCount(); i < n; i++ {
+			writes.Add(descpb.ID(tm.Table.InboundForeignKey(i).OriginTableID()))
+		}
+	}
+}
+
+// resultCacheWriter wraps the result of a statement whose results can be
+// cached, and keeps a copy of the rows that it returns. The rows are held until
+// the statement finishes, so their size is bounded by the maximum size of a
+// cache entry.
+type resultCacheWriter struct {
+	RestrictedCommandResult
+	rows []tree.Datums
+	size int64
+	// maxSize is the maximum size of the copied rows.
+	maxSize int64
+	// discarded is set once the results can no longer be cached.
+	discarded bool
+}
+
+var _ RestrictedCommandResult = &resultCacheWriter{}
+
+func newResultCacheWriter(res RestrictedCommandResult, maxSize int64) *resultCacheWriter {
+	return &resultCacheWriter{RestrictedCommandResult: res, maxSize: maxSize}
+}
+
+// AddRow is part of the RestrictedCommandResult interface.
+func (w *resultCacheWriter) AddRow(ctx context.Context, row tree.Datums) error {
+	if !w.discarded {
+		w.size += resultcache.RowSize(row)
+		if w.size > w.maxSize {
+			w.discard()
+		} else {
+			w.rows = append(w.rows, append(tree.Datums(nil), row...))
+		}
+	}
+	return w.RestrictedCommandResult.AddRow(ctx, row)
+}
+
+// SupportsAddBatch is part of the RestrictedCommandResult interface. The rows
+// are copied as datums, so they must not be passed in batches.
+func (w *resultCacheWriter) SupportsAddBatch() bool {
+	return false
+}
+
+// TruncateBufferedResults is part of the RestrictedCommandResult interface.
+func (w *resultCacheWriter) TruncateBufferedResults(idx int) bool {
+	w.discard()
+	return w.RestrictedCommandResult.TruncateBufferedResults(idx)
+}
+
+func (w *resultCacheWriter) discard() {
+	w.discarded = true
+	w.rows = nil
+}
+
+// maybePut stores the copied rows in the query result cache if the statement
+// succeeded.
+func (w *resultCacheWriter) maybePut(
+	ctx context.Context, p *planner, rc *resultcache.Cache, key string, tables []descpb.ID,
+) {
+	if w.discarded || w.Err() != nil {
+		return
+	}
+	rc.Put(ctx, key, w.rows, p.Txn().ReadTimestamp(), tables)
+}
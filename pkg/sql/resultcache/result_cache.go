@@ -0,0 +1,379 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package resultcache implements an in-memory cache of the results of
+// read-only queries. Cached results are invalidated when the tables that the
+// queries read are written to.
+package resultcache
+
+import (
+	"context"
+	"time"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// MaxSize is the maximum amount of memory used by the cached results on a
+// node. The least recently used results are evicted to stay under it.
+var MaxSize = settings.RegisterByteSizeSetting(
+	settings.ApplicationLevel,
+	"sql.result_cache.max_size",
+	"maximum amount of memory used by the query result cache on each node",
+	64<<20, /* 64 MiB */
+)
+
+// MaxEntrySize is the maximum size of the results of a single query that are
+// cached. Queries with larger results are executed but not cached.
+var MaxEntrySize = settings.RegisterByteSizeSetting(
+	settings.ApplicationLevel,
+	"sql.result_cache.max_entry_size",
+	"maximum size of the results of a single query stored in the query result cache",
+	1<<20, /* 1 MiB */
+)
+
+// MaxStaleness bounds the staleness of the results served from the cache.
+// Writes made on other nodes are observed asynchronously via rangefeeds, and a
+// cached result is only served if the rangefeeds on the tables it depends on
+// have observed all writes up to MaxStaleness before the reading transaction's
+// timestamp. A value of 0 only serves results that are known to be up to date,
+// which rarely happens since rangefeeds lag behind the present.
+var MaxStaleness = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.result_cache.max_staleness",
+	"maximum staleness of the results served from the query result cache with "+
+		"respect to writes made on other nodes; requires kv.rangefeed.enabled",
+	10*time.Second,
+	settings.NonNegativeDuration,
+)
+
+// Cache caches the results of read-only queries. Every result depends on a set
+// of tables, and the cache watches each of these tables with a rangefeed for
+// as long as it holds results that depend on it. A result is invalidated once
+// a write to one of its tables at a timestamp above the result's timestamp is
+// observed, either by a rangefeed or, for writes made by transactions on this
+// node, via Invalidate.
+type Cache struct {
+	ambientCtx log.AmbientContext
+	settings   *cluster.Settings
+	f          *rangefeed.Factory
+	stopper    *stop.Stopper
+	codec      keys.SQLCodec
+	mon        *mon.BytesMonitor
+
+	mu struct {
+		syncutil.Mutex
+		acc     mon.BoundAccount
+		entries *cache.UnorderedCache
+		tables  map[descpb.ID]*tableWatch
+		// toClose accumulates the rangefeeds of tables that no longer have any
+		// entries. They are closed after the mutex is released, since closing
+		// a rangefeed waits for its callbacks, which acquire the mutex.
+		toClose []*rangefeed.RangeFeed
+	}
+}
+
+// entry is a cached query result.
+type entry struct {
+	key  string
+	rows []tree.Datums
+	// ts is the timestamp at which the query read its tables.
+	ts     hlc.Timestamp
+	tables []descpb.ID
+	size   int64
+}
+
+// tableWatch tracks the writes to a table.
+type tableWatch struct {
+	feed *rangefeed.RangeFeed
+	// startTS is the timestamp from which the rangefeed observes writes.
+	startTS hlc.Timestamp
+	// frontier is the timestamp up to which all writes have been observed.
+	frontier hlc.Timestamp
+	// writeTS is the timestamp of the latest write observed.
+	writeTS hlc.Timestamp
+	// entries are the keys of the cached results that depend on the table.
+	entries map[string]struct{}
+}
+
+// NewCache creates a new Cache whose memory usage is tracked under the given
+// monitor.
+func NewCache(
+	ctx context.Context,
+	ambientCtx log.AmbientContext,
+	settings *cluster.Settings,
+	f *rangefeed.Factory,
+	stopper *stop.Stopper,
+	codec keys.SQLCodec,
+	parentMon *mon.BytesMonitor,
+) *Cache {
+	c := &Cache{
+		ambientCtx: ambientCtx,
+		settings:   settings,
+		f:          f,
+		stopper:    stopper,
+		codec:      codec,
+	}
+	c.mon = mon.NewMonitorInheritWithLimit(
+		mon.MakeName("query-result-cache"), 0 /* limit */, parentMon, true, /* longLiving */
+	)
+	c.mon.StartNoReserved(ctx, parentMon)
+	c.mu.acc = c.mon.MakeBoundAccount()
+	c.mu.tables = make(map[descpb.ID]*tableWatch)
+	c.mu.entries = cache.NewUnorderedCache(cache.Config{
+		Policy: cache.CacheLRU,
+		ShouldEvict: func(_ int, _, _ interface{}) bool {
+			return c.mu.acc.Used() > MaxSize.Get(&settings.SV)
+		},
+		OnEvictedEntry: func(e *cache.Entry) {
+			c.removeEntryLocked(e.Value.(*entry))
+		},
+	})
+	stopper.AddCloser(stop.CloserFn(c.stop))
+	return c
+}
+
+// stop releases the entries and closes the rangefeeds of the cache.
+func (c *Cache) stop() {
+	ctx := c.ambientCtx.AnnotateCtx(context.Background())
+	c.mu.Lock()
+	c.mu.entries.Clear()
+	c.mu.acc.Clear(ctx)
+	c.unlockAndCloseFeeds(false /* async */)
+	c.mon.Stop(ctx)
+}
+
+// Get returns the cached result for the given key if it can be served to a
+// query that reads at the given timestamp. The returned rows must not be
+// modified.
+func (c *Cache) Get(key string, readTS hlc.Timestamp) (_ []tree.Datums, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.mu.entries.Get(key)
+	if !ok {
+		return nil, false
+	}
+	e := v.(*entry)
+	if readTS.Less(e.ts) {
+		// The result reflects writes that the reader must not observe.
+		return nil, false
+	}
+	minFrontier := readTS.Add(-MaxStaleness.Get(&c.settings.SV).Nanoseconds(), 0)
+	for _, id := range e.tables {
+		if w, ok := c.mu.tables[id]; !ok || w.frontier.Less(minFrontier) {
+			return nil, false
+		}
+	}
+	return e.rows, true
+}
+
+// Put caches the result of a query that read the given tables at the given
+// timestamp. The cache takes ownership of the rows. The result is dropped if
+// it is too large, or if a write to one of its tables after ts has already
+// been observed.
+func (c *Cache) Put(
+	ctx context.Context, key string, rows []tree.Datums, ts hlc.Timestamp, tables []descpb.ID,
+) {
+	size := int64(len(key)) + entryOverhead + int64(len(tables))*int64(unsafe.Sizeof(descpb.ID(0)))
+	for _, row := range rows {
+		size += RowSize(row)
+	}
+	if size > MaxEntrySize.Get(&c.settings.SV) || size > MaxSize.Get(&c.settings.SV) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.unlockAndCloseFeeds(false /* async */)
+	for _, id := range tables {
+		if w, ok := c.mu.tables[id]; ok && (ts.Less(w.writeTS) || ts.Less(w.startTS)) {
+			// Either the result is already stale, or the rangefeed might have
+			// missed writes between ts and the time it was started.
+			return
+		}
+	}
+	if v, ok := c.mu.entries.StealthyGet(key); ok {
+		if !v.(*entry).ts.Less(ts) {
+			return
+		}
+		c.mu.entries.Del(key)
+	}
+	for {
+		if err := c.mu.acc.Grow(ctx, size); err == nil {
+			break
+		}
+		lru := c.mu.entries.LRUEntry()
+		if lru == nil {
+			return
+		}
+		c.mu.entries.DelEntry(lru)
+	}
+	for _, id := range tables {
+		w, ok := c.mu.tables[id]
+		if !ok {
+			var err error
+			if w, err = c.watchTableLocked(id, ts); err != nil {
+				log.Dev.Warningf(ctx, "failed to watch table %d for the query result cache: %v", id, err)
+				// Undo the registration of the entry with the tables that are
+				// already watched.
+				c.removeEntryLocked(&entry{key: key, tables: tables})
+				c.mu.acc.Shrink(ctx, size)
+				return
+			}
+		}
+		w.entries[key] = struct{}{}
+	}
+	c.mu.entries.Add(key, &entry{key: key, rows: rows, ts: ts, tables: tables, size: size})
+}
+
+// Invalidate invalidates the results that depend on the given tables and are
+// older than ts. It is called when a transaction on this node that wrote to
+// the tables commits at ts, so that the node observes its own writes without
+// waiting for the rangefeeds.
+func (c *Cache) Invalidate(tables []descpb.ID, ts hlc.Timestamp) {
+	c.mu.Lock()
+	defer c.unlockAndCloseFeeds(false /* async */)
+	for _, id := range tables {
+		if w, ok := c.mu.tables[id]; ok {
+			c.recordWriteLocked(w, ts)
+		}
+	}
+}
+
+// Len returns the number of cached results.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.mu.entries.Len()
+}
+
+// watchTableLocked starts a rangefeed that observes the writes to the given
+// table after ts.
+func (c *Cache) watchTableLocked(id descpb.ID, ts hlc.Timestamp) (*tableWatch, error) {
+	w := &tableWatch{startTS: ts, frontier: ts, entries: make(map[string]struct{})}
+	onWrite := func(ts hlc.Timestamp) {
+		c.mu.Lock()
+		// The rangefeed of w itself may need to be closed, which can't be done
+		// synchronously from one of its callbacks.
+		defer c.unlockAndCloseFeeds(true /* async */)
+		if c.mu.tables[id] == w {
+			c.recordWriteLocked(w, ts)
+		}
+	}
+	ctx := c.ambientCtx.AnnotateCtx(context.Background())
+	feed, err := c.f.RangeFeed(
+		ctx,
+		"query-result-cache",
+		[]roachpb.Span{c.codec.TableSpan(uint32(id))},
+		ts,
+		func(_ context.Context, v *kvpb.RangeFeedValue) {
+			onWrite(v.Timestamp())
+		},
+		rangefeed.WithOnDeleteRange(func(_ context.Context, v *kvpb.RangeFeedDeleteRange) {
+			onWrite(v.Timestamp)
+		}),
+		rangefeed.WithOnSSTable(func(_ context.Context, sst *kvpb.RangeFeedSSTable, _ roachpb.Span) {
+			onWrite(sst.WriteTS)
+		}),
+		rangefeed.WithOnFrontierAdvance(func(_ context.Context, frontier hlc.Timestamp) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			w.frontier.Forward(frontier)
+		}),
+		rangefeed.WithOnInternalError(func(ctx context.Context, err error) {
+			log.Dev.Warningf(ctx, "query result cache rangefeed on table %d failed: %v", id, err)
+			// The writes to the table can no longer be observed, so invalidate
+			// every result that depends on it.
+			onWrite(hlc.MaxTimestamp)
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	w.feed = feed
+	c.mu.tables[id] = w
+	return w, nil
+}
+
+// recordWriteLocked records a write to the table watched by w at the given
+// timestamp, and removes the results that it invalidates.
+func (c *Cache) recordWriteLocked(w *tableWatch, ts hlc.Timestamp) {
+	w.writeTS.Forward(ts)
+	for key := range w.entries {
+		if v, ok := c.mu.entries.StealthyGet(key); ok && v.(*entry).ts.Less(ts) {
+			c.mu.entries.Del(key)
+		}
+	}
+}
+
+// removeEntryLocked unregisters e from the tables it depends on, stops watching
+// the tables that no longer have any entries, and releases the memory of e.
+func (c *Cache) removeEntryLocked(e *entry) {
+	for _, id := range e.tables {
+		w, ok := c.mu.tables[id]
+		if !ok {
+			continue
+		}
+		delete(w.entries, e.key)
+		if len(w.entries) == 0 {
+			delete(c.mu.tables, id)
+			c.mu.toClose = append(c.mu.toClose, w.feed)
+		}
+	}
+	if e.size > 0 {
+		c.mu.acc.Shrink(c.ambientCtx.AnnotateCtx(context.Background()), e.size)
+	}
+}
+
+// unlockAndCloseFeeds releases the mutex and closes the rangefeeds of the
+// tables that no longer have any entries. If async is set, the rangefeeds are
+// closed in a separate task.
+func (c *Cache) unlockAndCloseFeeds(async bool) {
+	toClose := c.mu.toClose
+	c.mu.toClose = nil
+	c.mu.Unlock()
+	if len(toClose) == 0 {
+		return
+	}
+	closeFeeds := func(context.Context) {
+		for _, f := range toClose {
+			f.Close()
+		}
+	}
+	if !async {
+		closeFeeds(context.Background())
+		return
+	}
+	ctx := c.ambientCtx.AnnotateCtx(context.Background())
+	// If the stopper is quiescing, the rangefeeds are stopped along with it.
+	_ = c.stopper.RunAsyncTask(ctx, "close-query-result-cache-rangefeeds", closeFeeds)
+}
+
+const (
+	entryOverhead = int64(unsafe.Sizeof(entry{}) + unsafe.Sizeof(cache.Entry{}))
+	rowOverhead   = int64(unsafe.Sizeof(tree.Datums{}))
+	datumOverhead = int64(unsafe.Sizeof(tree.Datum(nil)))
+)
+
+// RowSize returns the memory used by a cached row.
+func RowSize(row tree.Datums) int64 {
+	size := rowOverhead
+	for _, d := range row {
+		size += datumOverhead + int64(d.Size())
+	}
+	return size
+}
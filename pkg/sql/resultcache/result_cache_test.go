@@ -0,0 +1,193 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package resultcache_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/resultcache"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// testCache is a Cache backed by the rangefeeds of a test server, along with
+// the IDs of two tables that its results can depend on.
+type testCache struct {
+	*resultcache.Cache
+	s      serverutils.ApplicationLayerInterface
+	sqlDB  *sqlutils.SQLRunner
+	t1, t2 descpb.ID
+}
+
+func newTestCache(t *testing.T) (_ *testCache, cleanup func()) {
+	ctx := context.Background()
+	srv := serverutils.StartServerOnly(t, base.TestServerArgs{})
+	s := srv.ApplicationLayer()
+	sqlDB := sqlutils.MakeSQLRunner(s.SQLConn(t))
+	sqlDB.Exec(t, `SET CLUSTER SETTING kv.rangefeed.enabled = true`)
+	sqlDB.Exec(t, `CREATE TABLE t1 (k INT PRIMARY KEY)`)
+	sqlDB.Exec(t, `CREATE TABLE t2 (k INT PRIMARY KEY)`)
+	var t1, t2 descpb.ID
+	sqlDB.QueryRow(t, `SELECT 't1'::REGCLASS::OID, 't2'::REGCLASS::OID`).Scan(&t1, &t2)
+
+	// The cache is stopped before the server, so that its rangefeeds are
+	// closed while the server is still running.
+	stopper := stop.NewStopper()
+	parentMon := mon.NewUnlimitedMonitor(ctx, mon.Options{
+		Name:     mon.MakeName("test"),
+		Settings: s.ClusterSettings(),
+	})
+	c := resultcache.NewCache(
+		ctx, log.MakeTestingAmbientCtxWithNewTracer(), s.ClusterSettings(),
+		s.RangeFeedFactory().(*rangefeed.Factory), stopper, s.Codec(), parentMon,
+	)
+	return &testCache{Cache: c, s: s, sqlDB: sqlDB, t1: t1, t2: t2}, func() {
+		stopper.Stop(ctx)
+		parentMon.Stop(ctx)
+		srv.Stopper().Stop(ctx)
+	}
+}
+
+func (c *testCache) get(key string, readTS hlc.Timestamp) bool {
+	_, ok := c.Get(key, readTS)
+	return ok
+}
+
+func testRows(s string) []tree.Datums {
+	return []tree.Datums{{tree.NewDString(s)}}
+}
+
+func TestCacheGet(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	c, cleanup := newTestCache(t)
+	defer cleanup()
+
+	ts := c.s.Clock().Now()
+	c.Put(ctx, "k", testRows("a"), ts, []descpb.ID{c.t1})
+	rows, ok := c.Get("k", ts)
+	require.True(t, ok)
+	require.Equal(t, testRows("a"), rows)
+	require.False(t, c.get("missing", ts))
+
+	// A reader below the timestamp of the result must not observe it.
+	require.False(t, c.get("k", ts.Prev()))
+
+	// A reader far ahead of the writes observed by the rangefeed is only served
+	// if the rangefeed is within sql.result_cache.max_staleness of it.
+	future := ts.AddDuration(time.Hour)
+	require.False(t, c.get("k", future))
+	resultcache.MaxStaleness.Override(ctx, &c.s.ClusterSettings().SV, 2*time.Hour)
+	require.True(t, c.get("k", future))
+}
+
+func TestCachePutAfterWrite(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	c, cleanup := newTestCache(t)
+	defer cleanup()
+
+	// The first result of a table starts its rangefeed at the result's
+	// timestamp.
+	startTS := c.s.Clock().Now()
+	c.Put(ctx, "start", testRows("a"), startTS, []descpb.ID{c.t1})
+	// Keep the rangefeed running after the invalidation below.
+	c.Put(ctx, "keep", testRows("a"), startTS.AddDuration(time.Hour), []descpb.ID{c.t1})
+
+	// A result read before the rangefeed's start time is dropped, since the
+	// rangefeed may have missed writes that invalidate it.
+	c.Put(ctx, "before-start", testRows("a"), startTS.Prev(), []descpb.ID{c.t1})
+	require.False(t, c.get("before-start", startTS))
+
+	// A write after the results invalidates them, and results read before the
+	// write are dropped.
+	writeTS := c.s.Clock().Now()
+	c.Invalidate([]descpb.ID{c.t1}, writeTS)
+	require.False(t, c.get("start", writeTS))
+	c.Put(ctx, "before-write", testRows("a"), writeTS.Prev(), []descpb.ID{c.t1})
+	require.False(t, c.get("before-write", writeTS))
+	c.Put(ctx, "after-write", testRows("a"), writeTS, []descpb.ID{c.t1})
+	require.True(t, c.get("after-write", writeTS))
+
+	// Results of other tables are unaffected.
+	c.Put(ctx, "other", testRows("a"), startTS.Prev(), []descpb.ID{c.t2})
+	require.True(t, c.get("other", writeTS))
+}
+
+func TestCacheRangefeedInvalidation(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	c, cleanup := newTestCache(t)
+	defer cleanup()
+
+	ts := c.s.Clock().Now()
+	c.Put(ctx, "t1", testRows("a"), ts, []descpb.ID{c.t1})
+	c.Put(ctx, "t2", testRows("a"), ts, []descpb.ID{c.t2})
+	c.Put(ctx, "both", testRows("a"), ts, []descpb.ID{c.t1, c.t2})
+	require.Equal(t, 3, c.Len())
+
+	// Writes that don't go through Invalidate, like writes on other nodes, are
+	// observed by the rangefeeds.
+	c.sqlDB.Exec(t, `INSERT INTO t1 VALUES (1)`)
+	testutils.SucceedsSoon(t, func() error {
+		if n := c.Len(); n != 1 {
+			return errors.Errorf("expected 1 cached result, found %d", n)
+		}
+		return nil
+	})
+	require.True(t, c.get("t2", ts))
+}
+
+func TestCacheEviction(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	c, cleanup := newTestCache(t)
+	defer cleanup()
+
+	// Each result takes a bit more than 1 KiB, so only two of them fit.
+	sv := &c.s.ClusterSettings().SV
+	resultcache.MaxSize.Override(ctx, sv, 3<<10)
+	ts := c.s.Clock().Now()
+	rows := testRows(strings.Repeat("a", 1<<10))
+	c.Put(ctx, "a", rows, ts, []descpb.ID{c.t1})
+	c.Put(ctx, "b", rows, ts, []descpb.ID{c.t1})
+	// Use a, so that b is the least recently used result.
+	require.True(t, c.get("a", ts))
+	c.Put(ctx, "c", rows, ts, []descpb.ID{c.t1})
+	require.Equal(t, 2, c.Len())
+	require.True(t, c.get("a", ts))
+	require.False(t, c.get("b", ts))
+	require.True(t, c.get("c", ts))
+
+	// Results larger than sql.result_cache.max_entry_size aren't cached.
+	resultcache.MaxEntrySize.Override(ctx, sv, 1<<10)
+	c.Put(ctx, "d", rows, ts, []descpb.ID{c.t1})
+	require.False(t, c.get("d", ts))
+	require.True(t, c.get("a", ts))
+}
@@ -83,6 +83,20 @@ func ConvertBatchError(
 		}
 		return newLockNotAvailableError(v.Reason, decodeKeyFn)
 	}
+	if err := origPErr.GoError(); errors.HasType(err, (*kvpb.ReadOnlySpanError)(nil)) {
+		return errors.WithHint(
+			pgerror.Wrapf(err, pgcode.ReadOnlySQLTransaction,
+				"cannot write to read-only table %q", tableDesc.GetName()),
+			"use ALTER TABLE ... SET READ WRITE to allow writes to the table",
+		)
+	}
+	if err := origPErr.GoError(); errors.HasType(err, (*kvpb.TenantStorageQuotaExceededError)(nil)) {
+		return errors.WithHint(
+			pgerror.WithCandidateCode(err, pgcode.DiskFull),
+			"delete data to bring the storage usage below the quota, or ask an "+
+				"administrator to raise the storage_quota_bytes capability",
+		)
+	}
 	return origPErr.GoError()
 }
 
@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/columnarenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/rowencpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
@@ -567,6 +568,14 @@ func (rh *RowHelper) deleteIndexEntry(
 		} else {
 			b.Put(key, deleteEncoding)
 		}
+	} else if index.GetType() == idxtype.COLUMNAR {
+		// The row may also be stored in a block of the columnar index, so
+		// its deletion has to be recorded with a tombstone delta.
+		tombstone := columnarenc.MakeTombstone()
+		if traceKV {
+			log.VEventf(ctx, 2, "Put (tombstone) %s", *key)
+		}
+		b.Put(key, &tombstone)
 	} else {
 		delFn(ctx, b, key, needsLock, traceKV, rh, dirs)
 	}
@@ -0,0 +1,322 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package columnarenc
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+	"github.com/golang/snappy"
+)
+
+// blockFormatVersion is the first byte of every encoded block.
+//
+// A block is encoded as follows, where every integer is an unsigned varint and
+// every chunk is compressed with snappy and prefixed by its length:
+//
+//	version numRows numCols keysChunk column...
+//
+// The keys chunk holds the length-prefixed index key of every row, in
+// increasing order. Each column is encoded as
+//
+//	columnID nullCount len(min) min len(max) max valuesChunk
+//
+// where min and max are value-encoded (and empty if all values are NULL) and
+// the values chunk holds the value encoding of every row's value, including
+// NULLs. Columns are stored in increasing order of their IDs.
+const blockFormatVersion = 1
+
+// BlockBuilder accumulates rows and encodes them into a block.
+type BlockBuilder struct {
+	colIDs  []descpb.ColumnID
+	rowKeys [][]byte
+	rows    []tree.Datums
+}
+
+// MakeBlockBuilder returns a BlockBuilder for blocks of the given columns,
+// which must be in increasing order.
+func MakeBlockBuilder(colIDs []descpb.ColumnID) BlockBuilder {
+	return BlockBuilder{colIDs: colIDs}
+}
+
+// Add adds a row to the block. Rows must be added in increasing order of their
+// index keys, and the values of the row must be ordered like the columns of
+// the builder. The builder retains both slices until Finish is called.
+func (bb *BlockBuilder) Add(rowKey []byte, row tree.Datums) {
+	bb.rowKeys = append(bb.rowKeys, rowKey)
+	bb.rows = append(bb.rows, row)
+}
+
+// Len returns the number of rows added since the last call to Finish.
+func (bb *BlockBuilder) Len() int {
+	return len(bb.rowKeys)
+}
+
+// Finish encodes the rows added to the builder into a block and resets the
+// builder. It returns the key and the value of the block.
+func (bb *BlockBuilder) Finish(
+	ctx context.Context, cmpCtx tree.CompareContext,
+) (roachpb.Key, roachpb.Value, error) {
+	if len(bb.rowKeys) == 0 {
+		return nil, roachpb.Value{}, errors.AssertionFailedf("cannot encode an empty columnar block")
+	}
+	buf := []byte{blockFormatVersion}
+	buf = binary.AppendUvarint(buf, uint64(len(bb.rowKeys)))
+	buf = binary.AppendUvarint(buf, uint64(len(bb.colIDs)))
+
+	var scratch []byte
+	for _, rowKey := range bb.rowKeys {
+		scratch = binary.AppendUvarint(scratch, uint64(len(rowKey)))
+		scratch = append(scratch, rowKey...)
+	}
+	buf = appendChunk(buf, scratch)
+
+	var minBuf, maxBuf []byte
+	for i, colID := range bb.colIDs {
+		var minVal, maxVal tree.Datum
+		nullCount := 0
+		scratch = scratch[:0]
+		for _, row := range bb.rows {
+			d := row[i]
+			var err error
+			if scratch, err = valueside.Encode(scratch, valueside.NoColumnID, d); err != nil {
+				return nil, roachpb.Value{}, err
+			}
+			if d == tree.DNull {
+				nullCount++
+				continue
+			}
+			if minVal == nil {
+				minVal, maxVal = d, d
+				continue
+			}
+			if c, err := d.Compare(ctx, cmpCtx, minVal); err != nil {
+				return nil, roachpb.Value{}, err
+			} else if c < 0 {
+				minVal = d
+			}
+			if c, err := d.Compare(ctx, cmpCtx, maxVal); err != nil {
+				return nil, roachpb.Value{}, err
+			} else if c > 0 {
+				maxVal = d
+			}
+		}
+		minBuf, maxBuf = minBuf[:0], maxBuf[:0]
+		if minVal != nil {
+			var err error
+			if minBuf, err = valueside.Encode(minBuf, valueside.NoColumnID, minVal); err != nil {
+				return nil, roachpb.Value{}, err
+			}
+			if maxBuf, err = valueside.Encode(maxBuf, valueside.NoColumnID, maxVal); err != nil {
+				return nil, roachpb.Value{}, err
+			}
+		}
+		buf = binary.AppendUvarint(buf, uint64(colID))
+		buf = binary.AppendUvarint(buf, uint64(nullCount))
+		buf = binary.AppendUvarint(buf, uint64(len(minBuf)))
+		buf = append(buf, minBuf...)
+		buf = binary.AppendUvarint(buf, uint64(len(maxBuf)))
+		buf = append(buf, maxBuf...)
+		buf = appendChunk(buf, scratch)
+	}
+
+	key := MakeBlockKey(bb.rowKeys[0])
+	var value roachpb.Value
+	value.SetBytes(buf)
+	bb.rowKeys = bb.rowKeys[:0]
+	bb.rows = bb.rows[:0]
+	return key, value, nil
+}
+
+func appendChunk(buf, data []byte) []byte {
+	compressed := snappy.Encode(nil, data)
+	buf = binary.AppendUvarint(buf, uint64(len(compressed)))
+	return append(buf, compressed...)
+}
+
+// Block is a decoded columnar block. The values of each column are only
+// decompressed when they are requested.
+type Block struct {
+	rowKeys [][]byte
+	cols    []blockColumn
+}
+
+type blockColumn struct {
+	id        descpb.ColumnID
+	nullCount int
+	min, max  []byte
+	chunk     []byte
+}
+
+// DecodeBlock decodes the value of a block. The returned block aliases the
+// given value.
+func DecodeBlock(v roachpb.Value) (*Block, error) {
+	buf, err := v.GetBytes()
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) == 0 || buf[0] != blockFormatVersion {
+		return nil, errors.AssertionFailedf("unsupported columnar block format")
+	}
+	r := blockReader{buf: buf[1:]}
+	numRows := int(r.uvarint())
+	numCols := int(r.uvarint())
+	keysChunk := r.bytes()
+	if r.err != nil {
+		return nil, r.err
+	}
+	keysBuf, err := snappy.Decode(nil, keysChunk)
+	if err != nil {
+		return nil, errors.Wrap(err, "decompressing columnar block keys")
+	}
+	b := &Block{
+		rowKeys: make([][]byte, 0, numRows),
+		cols:    make([]blockColumn, numCols),
+	}
+	kr := blockReader{buf: keysBuf}
+	for i := 0; i < numRows; i++ {
+		b.rowKeys = append(b.rowKeys, kr.bytes())
+	}
+	if kr.err != nil {
+		return nil, kr.err
+	}
+	for i := range b.cols {
+		c := &b.cols[i]
+		c.id = descpb.ColumnID(r.uvarint())
+		c.nullCount = int(r.uvarint())
+		c.min = r.bytes()
+		c.max = r.bytes()
+		c.chunk = r.bytes()
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return b, nil
+}
+
+// NumRows returns the number of rows in the block.
+func (b *Block) NumRows() int {
+	return len(b.rowKeys)
+}
+
+// RowKey returns the index key of the i-th row of the block.
+func (b *Block) RowKey(i int) []byte {
+	return b.rowKeys[i]
+}
+
+// LastRowKey returns the index key of the last row of the block. Deltas with
+// greater row keys don't apply to the block.
+func (b *Block) LastRowKey() []byte {
+	return b.rowKeys[len(b.rowKeys)-1]
+}
+
+func (b *Block) column(id descpb.ColumnID) *blockColumn {
+	for i := range b.cols {
+		if b.cols[i].id == id {
+			return &b.cols[i]
+		}
+	}
+	return nil
+}
+
+// ColumnBounds returns the value-encoded minimum and maximum value of the given
+// column in the block, along with the number of NULL values. min and max are
+// empty if all values are NULL. ok is false if the block doesn't store the
+// column.
+func (b *Block) ColumnBounds(
+	id descpb.ColumnID,
+) (minVal, maxVal []byte, nullCount int, ok bool) {
+	c := b.column(id)
+	if c == nil {
+		return nil, nil, 0, false
+	}
+	return c.min, c.max, c.nullCount, true
+}
+
+// ColumnValues decompresses the given column and appends the value encoding of
+// the column's value in every row of the block to values. buf is used as
+// scratch space for the decompressed column, and is returned so it can be
+// reused by the caller; the returned values alias it. If the block doesn't
+// store the column, every value is NULL and nil values are appended.
+func (b *Block) ColumnValues(
+	id descpb.ColumnID, buf []byte, values [][]byte,
+) (_ [][]byte, _ []byte, err error) {
+	c := b.column(id)
+	if c == nil {
+		for range b.rowKeys {
+			values = append(values, nil)
+		}
+		return values, buf, nil
+	}
+	n, err := snappy.DecodedLen(c.chunk)
+	if err != nil {
+		return nil, buf, errors.Wrap(err, "decompressing columnar block")
+	}
+	if cap(buf) < n {
+		buf = make([]byte, n)
+	}
+	buf, err = snappy.Decode(buf[:cap(buf)], c.chunk)
+	if err != nil {
+		return nil, buf, errors.Wrap(err, "decompressing columnar block")
+	}
+	rest := buf
+	for range b.rowKeys {
+		_, dataOffset, _, typ, err := encoding.DecodeValueTag(rest)
+		if err != nil {
+			return nil, buf, err
+		}
+		l, err := encoding.PeekValueLengthWithOffsetsAndType(rest, dataOffset, typ)
+		if err != nil {
+			return nil, buf, err
+		}
+		values = append(values, rest[:l:l])
+		rest = rest[l:]
+	}
+	if len(rest) != 0 {
+		return nil, buf, errors.AssertionFailedf("unexpected trailing data in columnar block")
+	}
+	return values, buf, nil
+}
+
+// blockReader decodes the integers and byte strings of a block, remembering the
+// first error that it encounters.
+type blockReader struct {
+	buf []byte
+	err error
+}
+
+func (r *blockReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.err = errors.AssertionFailedf("malformed columnar block")
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *blockReader) bytes() []byte {
+	l := r.uvarint()
+	if r.err != nil {
+		return nil
+	}
+	if uint64(len(r.buf)) < l {
+		r.err = errors.AssertionFailedf("malformed columnar block")
+		return nil
+	}
+	b := r.buf[:l:l]
+	r.buf = r.buf[l:]
+	return b
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package columnarenc implements the KV encoding of columnar secondary indexes.
+//
+// A columnar index is keyed by the primary key of its table and contains two
+// kinds of entries:
+//
+//   - Deltas are written transactionally by every INSERT, UPDATE and DELETE. A
+//     delta lives at the row's index key with column family 1 and holds either
+//     the value-encoded index columns of the row, or a tombstone if the row was
+//     deleted.
+//   - Blocks are written by the columnar index compactor. A block lives at the
+//     index key of its first row with column family 0 and holds a run of rows
+//     in column-chunked, compressed form (a PAX layout), along with the minimum
+//     and maximum value of every column.
+//
+// Since family 0 sorts before family 1, a block sorts before the delta of its
+// first row, and all of the deltas that apply to the rows of a block sort
+// between the block and the next block. Readers therefore merge every block
+// with the deltas that follow it, with the deltas taking precedence.
+package columnarenc
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// BlockFamilyID is the column family suffix of block keys.
+	BlockFamilyID = 0
+	// DeltaFamilyID is the column family suffix of delta keys.
+	DeltaFamilyID = 1
+)
+
+// IndexColumnIDs returns the IDs of the columns whose values are stored in the
+// given columnar index, in increasing order. These are the primary key columns
+// of the table followed by the columns listed in the index definition.
+func IndexColumnIDs(idx catalog.Index) []descpb.ColumnID {
+	return idx.CollectKeyColumnIDs().Union(idx.CollectSecondaryStoredColumnIDs()).Ordered()
+}
+
+// MakeBlockKey returns the key of the block whose first row has the given
+// index key. The row key must not have a column family suffix.
+func MakeBlockKey(rowKey []byte) roachpb.Key {
+	return keys.MakeFamilyKey(rowKey[:len(rowKey):len(rowKey)], BlockFamilyID)
+}
+
+// MakeDeltaKey returns the key of the delta for the row with the given index
+// key. The row key must not have a column family suffix.
+func MakeDeltaKey(rowKey []byte) roachpb.Key {
+	return keys.MakeFamilyKey(rowKey[:len(rowKey):len(rowKey)], DeltaFamilyID)
+}
+
+// DecodeKey splits the key of a columnar index entry into the index key of its
+// row and reports whether the entry is a block (as opposed to a delta).
+func DecodeKey(key roachpb.Key) (rowKey roachpb.Key, isBlock bool, _ error) {
+	n, err := keys.GetRowPrefixLength(key)
+	if err != nil {
+		return nil, false, err
+	}
+	familyID, err := keys.DecodeFamilyKey(key)
+	if err != nil {
+		return nil, false, err
+	}
+	switch familyID {
+	case BlockFamilyID:
+		isBlock = true
+	case DeltaFamilyID:
+	default:
+		return nil, false, errors.AssertionFailedf(
+			"unexpected column family %d in columnar index key %s", familyID, key)
+	}
+	return key[:n:n], isBlock, nil
+}
+
+// EncodeDeltaValue returns the value of the delta for a row. colIDs must be the
+// result of IndexColumnIDs, and colMap maps column IDs to positions in values.
+// NULL values are omitted.
+func EncodeDeltaValue(
+	colIDs []descpb.ColumnID, colMap catalog.TableColMap, values []tree.Datum,
+) (roachpb.Value, error) {
+	var buf []byte
+	var lastColID descpb.ColumnID
+	for _, colID := range colIDs {
+		ord, ok := colMap.Get(colID)
+		if !ok || values[ord] == tree.DNull {
+			continue
+		}
+		var err error
+		buf, err = valueside.Encode(buf, valueside.MakeColumnIDDelta(lastColID, colID), values[ord])
+		if err != nil {
+			return roachpb.Value{}, err
+		}
+		lastColID = colID
+	}
+	var v roachpb.Value
+	v.SetTuple(buf)
+	return v, nil
+}
+
+// MakeTombstone returns the value of the delta that records the deletion of a
+// row. Deletes can't simply remove the row's delta, because the row may also
+// be stored in a block.
+func MakeTombstone() roachpb.Value {
+	var v roachpb.Value
+	v.SetBytes(nil)
+	return v
+}
+
+// DecodeDeltaValue returns the value-encoded columns stored in a delta, in the
+// format written by EncodeDeltaValue. live is false if the delta is a
+// tombstone.
+func DecodeDeltaValue(v roachpb.Value) (columns []byte, live bool, _ error) {
+	switch v.GetTag() {
+	case roachpb.ValueType_TUPLE:
+		columns, err := v.GetTuple()
+		return columns, true, err
+	case roachpb.ValueType_BYTES:
+		return nil, false, nil
+	default:
+		return nil, false, errors.AssertionFailedf(
+			"unexpected value type %s in columnar index delta", v.GetTag())
+	}
+}
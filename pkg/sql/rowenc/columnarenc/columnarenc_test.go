@@ -0,0 +1,157 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package columnarenc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/columnarenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func makeRowKey(pk int64) []byte {
+	key := keys.SystemSQLCodec.IndexPrefix(104, 2)
+	return encoding.EncodeVarintAscending(key, pk)
+}
+
+func TestKeys(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	rowKey := makeRowKey(7)
+	blockKey := columnarenc.MakeBlockKey(rowKey)
+	deltaKey := columnarenc.MakeDeltaKey(rowKey)
+	require.Less(t, blockKey.Compare(deltaKey), 0)
+	// The block of a row sorts before the deltas of the rows that follow it.
+	require.Less(t, deltaKey.Compare(columnarenc.MakeBlockKey(makeRowKey(8))), 0)
+
+	decoded, isBlock, err := columnarenc.DecodeKey(blockKey)
+	require.NoError(t, err)
+	require.True(t, isBlock)
+	require.Equal(t, rowKey, []byte(decoded))
+
+	decoded, isBlock, err = columnarenc.DecodeKey(deltaKey)
+	require.NoError(t, err)
+	require.False(t, isBlock)
+	require.Equal(t, rowKey, []byte(decoded))
+}
+
+func TestDeltaValue(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	var colMap catalog.TableColMap
+	colMap.Set(1, 0)
+	colMap.Set(3, 1)
+	colIDs := []descpb.ColumnID{1, 3}
+
+	v, err := columnarenc.EncodeDeltaValue(
+		colIDs, colMap, []tree.Datum{tree.NewDInt(5), tree.NewDString("foo")},
+	)
+	require.NoError(t, err)
+	cols, live, err := columnarenc.DecodeDeltaValue(v)
+	require.NoError(t, err)
+	require.True(t, live)
+
+	var a tree.DatumAlloc
+	_, _, colIDDelta, typ, err := encoding.DecodeValueTag(cols)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), colIDDelta)
+	require.Equal(t, encoding.Int, typ)
+	d, rest, err := valueside.Decode(&a, types.Int, cols)
+	require.NoError(t, err)
+	require.Equal(t, tree.NewDInt(5), d)
+	_, _, colIDDelta, _, err = encoding.DecodeValueTag(rest)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), colIDDelta)
+	d, rest, err = valueside.Decode(&a, types.String, rest)
+	require.NoError(t, err)
+	require.Equal(t, tree.NewDString("foo"), d)
+	require.Empty(t, rest)
+
+	_, live, err = columnarenc.DecodeDeltaValue(columnarenc.MakeTombstone())
+	require.NoError(t, err)
+	require.False(t, live)
+}
+
+func TestBlock(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	evalCtx := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	defer evalCtx.Stop(ctx)
+
+	const numRows = 100
+	colIDs := []descpb.ColumnID{1, 2}
+	bb := columnarenc.MakeBlockBuilder(colIDs)
+	for i := 0; i < numRows; i++ {
+		var s tree.Datum = tree.DNull
+		if i%10 != 0 {
+			s = tree.NewDString(string(rune('a' + i%26)))
+		}
+		bb.Add(makeRowKey(int64(i)), tree.Datums{tree.NewDInt(tree.DInt(i * 2)), s})
+	}
+	require.Equal(t, numRows, bb.Len())
+	key, value, err := bb.Finish(ctx, evalCtx)
+	require.NoError(t, err)
+	require.Equal(t, 0, bb.Len())
+	require.Equal(t, columnarenc.MakeBlockKey(makeRowKey(0)), key)
+
+	b, err := columnarenc.DecodeBlock(value)
+	require.NoError(t, err)
+	require.Equal(t, numRows, b.NumRows())
+	require.Equal(t, makeRowKey(42), b.RowKey(42))
+	require.Equal(t, makeRowKey(numRows-1), b.LastRowKey())
+
+	var a tree.DatumAlloc
+	decode := func(typ *types.T, enc []byte) tree.Datum {
+		d, rest, err := valueside.Decode(&a, typ, enc)
+		require.NoError(t, err)
+		require.Empty(t, rest)
+		return d
+	}
+
+	minVal, maxVal, nullCount, ok := b.ColumnBounds(1)
+	require.True(t, ok)
+	require.Equal(t, 0, nullCount)
+	require.Equal(t, tree.NewDInt(0), decode(types.Int, minVal))
+	require.Equal(t, tree.NewDInt(2*(numRows-1)), decode(types.Int, maxVal))
+
+	minVal, maxVal, nullCount, ok = b.ColumnBounds(2)
+	require.True(t, ok)
+	require.Equal(t, numRows/10, nullCount)
+	require.Equal(t, tree.NewDString("a"), decode(types.String, minVal))
+	require.Equal(t, tree.NewDString("z"), decode(types.String, maxVal))
+
+	_, _, _, ok = b.ColumnBounds(3)
+	require.False(t, ok)
+
+	var buf []byte
+	values, buf, err := b.ColumnValues(2, buf, nil /* values */)
+	require.NoError(t, err)
+	require.Len(t, values, numRows)
+	for i, enc := range values {
+		if i%10 == 0 {
+			require.Equal(t, tree.DNull, decode(types.String, enc))
+		} else {
+			require.Equal(t, tree.NewDString(string(rune('a'+i%26))), decode(types.String, enc))
+		}
+	}
+	values, _, err = b.ColumnValues(1, buf, values[:0])
+	require.NoError(t, err)
+	for i, enc := range values {
+		require.Equal(t, tree.NewDInt(tree.DInt(i*2)), decode(types.Int, enc))
+	}
+}
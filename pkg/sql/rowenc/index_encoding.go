@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/columnarenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/rowencpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
@@ -1350,7 +1351,7 @@ func encodeSecondaryIndexKeyWithKeyPrefix(
 	var secondaryKeys [][]byte
 	var err error
 	switch secondaryIndex.GetType() {
-	case idxtype.FORWARD:
+	case idxtype.FORWARD, idxtype.COLUMNAR:
 		var secondaryIndexKey []byte
 		secondaryIndexKey, containsNull, err = EncodeIndexKey(
 			tableDesc, secondaryIndex, colMap, values, keyPrefix)
@@ -1424,6 +1425,20 @@ func encodeSecondaryIndexWithKeyPrefix(
 		return []IndexEntry{}, err
 	}
 
+	if secondaryIndex.GetType() == idxtype.COLUMNAR {
+		entry, err := encodeColumnarIndexDelta(secondaryIndex, colMap, secondaryKeys[0], values)
+		if err != nil {
+			return []IndexEntry{}, err
+		}
+		entries := []IndexEntry{entry}
+		if secondaryIndex.UseDeletePreservingEncoding() {
+			if err := wrapIndexEntries(entries); err != nil {
+				return nil, err
+			}
+		}
+		return entries, nil
+	}
+
 	// Add the extra columns - they are encoded in ascending order which is done
 	// by passing nil for the encoding directions.
 	extraKey, err := EncodeColumns(
@@ -1483,6 +1498,23 @@ func encodeSecondaryIndexWithKeyPrefix(
 	return entries, nil
 }
 
+// encodeColumnarIndexDelta returns the delta entry that a write of the given
+// row adds to a columnar index. rowKey is the index key of the row. Blocks of a
+// columnar index are only written by the compactor.
+func encodeColumnarIndexDelta(
+	index catalog.Index, colMap catalog.TableColMap, rowKey []byte, values []tree.Datum,
+) (IndexEntry, error) {
+	value, err := columnarenc.EncodeDeltaValue(columnarenc.IndexColumnIDs(index), colMap, values)
+	if err != nil {
+		return IndexEntry{}, err
+	}
+	return IndexEntry{
+		Key:    columnarenc.MakeDeltaKey(rowKey),
+		Value:  value,
+		Family: columnarenc.DeltaFamilyID,
+	}, nil
+}
+
 // MakeFamilyToColumnMap creates a map that caches the slice of columns encoded
 // into the value for a particular family.
 func MakeFamilyToColumnMap(
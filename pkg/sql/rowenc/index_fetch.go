@@ -42,6 +42,7 @@ func InitIndexFetchSpec(
 		EncodingType:        index.GetEncodingType(),
 		NumKeySuffixColumns: uint32(index.NumKeySuffixColumns()),
 		GeoConfig:           index.GetGeoConfig(),
+		IsColumnarIndex:     index.GetType() == idxtype.COLUMNAR,
 	}
 
 	maxKeysPerRow := table.IndexKeysPerRow(index)
@@ -505,6 +505,24 @@ func NewProcessor(
 		}
 		return NewTxnLDRDepResolverProcessor(ctx, flowCtx, processorID, *core.TxnLdrDepResolver, post, inputs[0])
 	}
+	if core.BackupTableReader != nil {
+		if err := checkNumIn(inputs, 0); err != nil {
+			return nil, err
+		}
+		if NewBackupTableReaderProcessor == nil {
+			return nil, errors.New("BackupTableReader processor unimplemented")
+		}
+		return NewBackupTableReaderProcessor(ctx, flowCtx, processorID, *core.BackupTableReader, post)
+	}
+	if core.CopyBackup != nil {
+		if err := checkNumIn(inputs, 0); err != nil {
+			return nil, err
+		}
+		if NewCopyBackupProcessor == nil {
+			return nil, errors.New("CopyBackup processor unimplemented")
+		}
+		return NewCopyBackupProcessor(ctx, flowCtx, processorID, *core.CopyBackup, post)
+	}
 
 	return nil, errors.Errorf("unsupported processor core %q", core)
 }
@@ -580,6 +598,10 @@ var NewLogicalReplicationOfflineScanProcessor func(context.Context, *execinfra.F
 
 var NewCompactBackupsProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.CompactBackupsSpec, *execinfrapb.PostProcessSpec) (execinfra.Processor, error)
 
+var NewBackupTableReaderProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.BackupTableReaderSpec, *execinfrapb.PostProcessSpec) (execinfra.Processor, error)
+
+var NewCopyBackupProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.CopyBackupSpec, *execinfrapb.PostProcessSpec) (execinfra.Processor, error)
+
 var NewIngestFileProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.IngestFileSpec) (execinfra.Processor, error)
 
 var NewRevlogLocalMergeProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.RevlogLocalMergeSpec, *execinfrapb.PostProcessSpec) (execinfra.Processor, error)
@@ -101,6 +101,9 @@ func newSampleAggregator(
 		if s.GenerateHistogram && len(s.Columns) != 1 {
 			return nil, errors.Errorf("histograms require one column")
 		}
+		if s.MCVMaxCount > 0 && len(s.Columns) < 2 {
+			return nil, errors.Errorf("most common values require multiple columns")
+		}
 	}
 
 	// Limit the memory use by creating a child monitor with a hard limit.
@@ -149,6 +152,11 @@ func newSampleAggregator(
 		if spec.Sketches[i].GenerateHistogram {
 			sampleCols.Add(int(spec.Sketches[i].Columns[0]))
 		}
+		if spec.Sketches[i].MCVMaxCount > 0 {
+			for _, c := range spec.Sketches[i].Columns {
+				sampleCols.Add(int(c))
+			}
+		}
 	}
 
 	s.sr.Init(
@@ -533,6 +541,12 @@ func (s *sampleAggregator) writeResults(ctx context.Context) error {
 					return err
 				}
 				histogram = &h
+			} else if si.spec.MCVMaxCount > 0 && len(s.sr.Get()) != 0 {
+				h, err := s.generateMultiColumnValues(ctx, &si)
+				if err != nil {
+					return err
+				}
+				histogram = &h
 			}
 
 			columnIDs := make([]descpb.ColumnID, len(si.spec.Columns))
@@ -670,6 +684,23 @@ func (s *sampleAggregator) generateHistogram(
 	return h, err
 }
 
+// generateMultiColumnValues returns the most common values and the functional
+// dependency degrees of the columns of a multi-column sketch, computed from the
+// samples.
+func (s *sampleAggregator) generateMultiColumnValues(
+	ctx context.Context, si *sketchInfo,
+) (stats.HistogramData, error) {
+	colIdxs := make([]int, len(si.spec.Columns))
+	colTypes := make([]*types.T, len(si.spec.Columns))
+	for i, c := range si.spec.Columns {
+		colIdxs[i] = int(c)
+		colTypes[i] = s.inTypes[c]
+	}
+	return stats.BuildMultiColumnValues(
+		ctx, &s.tempMemAcc, s.sr.Get(), colIdxs, colTypes, si.numRows, int(si.spec.MCVMaxCount),
+	)
+}
+
 var _ execinfra.DoesNotUseTxn = &sampleAggregator{}
 
 // DoesNotUseTxn implements the DoesNotUseTxn interface.
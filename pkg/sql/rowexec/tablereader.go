@@ -16,6 +16,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execreleasable"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
@@ -84,6 +86,10 @@ func newTableReader(
 	if nodeID, ok := flowCtx.NodeID.OptionalNodeID(); ok && nodeID == 0 {
 		return nil, errors.AssertionFailedf("attempting to create a tableReader with uninitialized NodeID")
 	}
+	if spec.FetchSpec.IsColumnarIndex {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"columnar index %q can only be read by the vectorized engine", spec.FetchSpec.IndexName)
+	}
 
 	if spec.LimitHint > 0 {
 		// Parallelize shouldn't be set when there's a limit hint, but double-check
@@ -778,6 +778,12 @@ func recreateAllSecondaryIndexes(
 	}
 	// Recreate each secondary index.
 	scpb.ForEachSecondaryIndex(publicTableElts, func(currentStatus scpb.Status, _ scpb.TargetStatus, idx *scpb.SecondaryIndex) {
+		// A columnar index is keyed by the primary key, so it would have to be
+		// re-keyed rather than recreated with a new key suffix.
+		if idx.Type == idxtype.COLUMNAR {
+			panic(pgerror.New(pgcode.FeatureNotSupported,
+				"cannot alter the primary key of a table with a columnar index"))
+		}
 		out := makeIndexSpec(b, idx.TableID, idx.IndexID)
 		// Create new index partitioning when overriding the partitioning.
 		// The secondary index partitioning desc might not be the same as the primary
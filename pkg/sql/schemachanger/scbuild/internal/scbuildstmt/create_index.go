@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/geo/geoindex"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
@@ -61,6 +62,11 @@ func CreateIndex(b BuildCtx, n *tree.CreateIndex) {
 			Invisibility:   n.Invisibility.Value,
 		},
 	}
+	if n.Type == idxtype.COLUMNAR &&
+		!b.EvalCtx().Settings.Version.IsActive(b, clusterversion.V26_3_ColumnarIndexes) {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"columnar indexes are not supported until the cluster version is finalized"))
+	}
 	if n.Type == idxtype.VECTOR {
 		// Disable vector indexes by default in 25.2.
 		// TODO(andyk): Remove this check after 25.2.
@@ -182,6 +188,10 @@ func CreateIndex(b BuildCtx, n *tree.CreateIndex) {
 			"%s indexes can't be unique", strings.ToLower(n.Type.String())))
 	}
 
+	if n.Type == idxtype.COLUMNAR {
+		n = makeColumnarIndexDefinition(b, n, relation, partitioning, sourceIndex)
+	}
+
 	// Assign the ID here, since we may have added columns
 	// and made a new primary key above.
 	idxSpec.secondary.SourceIndexID = sourceIndex.IndexID
@@ -223,6 +233,13 @@ func CreateIndex(b BuildCtx, n *tree.CreateIndex) {
 		if len(n.Columns) > 1 {
 			b.IncrementSchemaChangeIndexCounter("multi_column_vector")
 		}
+
+	case idxtype.COLUMNAR:
+		b.IncrementSchemaChangeIndexCounter("columnar")
+		b.EvalCtx().ClientNoticeSender.BufferClientNotice(b,
+			pgnotice.Newf("columnar indexes are always scanned in full by a single, non-distributed scan, "+
+				"and their deltas are only compacted after scans of the index"),
+		)
 	}
 
 	// Assign the secondary constraint ID now, since we may have added a check
@@ -291,6 +308,77 @@ func CreateIndex(b BuildCtx, n *tree.CreateIndex) {
 	}
 }
 
+// makeColumnarIndexDefinition validates the definition of a columnar index and
+// returns a copy of it in which the key columns are the columns of the source
+// primary index and the listed columns are stored. A columnar index is keyed by
+// the primary key so that its blocks can be maintained in primary key order;
+// the columns listed in its definition are the ones whose values it stores.
+func makeColumnarIndexDefinition(
+	b BuildCtx,
+	n *tree.CreateIndex,
+	relation scpb.Element,
+	partitioning *scpb.TablePartitioning,
+	sourceIndex *scpb.PrimaryIndex,
+) *tree.CreateIndex {
+	if _, ok := relation.(*scpb.Table); !ok {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"columnar indexes can only be created on tables"))
+	}
+	if n.Predicate != nil {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"columnar indexes cannot be partial"))
+	}
+	if partitioning != nil || n.PartitionByIndex != nil || sourceIndex.Sharding != nil {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"columnar indexes are not supported on partitioned or hash-sharded tables"))
+	}
+	tableID := screl.GetDescID(relation)
+	keyCols := mustRetrieveKeyIndexColumns(b, tableID, sourceIndex.IndexID)
+	var pkCols catalog.TableColSet
+	for _, keyCol := range keyCols {
+		if keyCol.Implicit {
+			panic(pgerror.New(pgcode.FeatureNotSupported,
+				"columnar indexes are not supported on partitioned or hash-sharded tables"))
+		}
+		pkCols.Add(keyCol.ColumnID)
+	}
+	ret := *n
+	ret.Columns = make(tree.IndexElemList, 0, len(keyCols))
+	ret.Storing = make(tree.NameList, 0, len(n.Columns))
+	for _, columnNode := range n.Columns {
+		if columnNode.Expr != nil {
+			panic(pgerror.New(pgcode.FeatureNotSupported,
+				"columnar indexes cannot contain expressions"))
+		}
+		if columnNode.Direction != tree.DefaultDirection {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"%s does not support the %s option", idxtype.ErrorText(n.Type), columnNode.Direction))
+		}
+		if columnNode.OpClass != "" {
+			panic(pgerror.New(pgcode.DatatypeMismatch,
+				"operator classes are only allowed for the last column of an inverted or vector index"))
+		}
+		colID := getColumnIDFromColumnName(b, tableID, columnNode.Column, true /* required */)
+		if pkCols.Contains(colID) {
+			// Primary key columns are always part of a columnar index.
+			continue
+		}
+		ret.Storing = append(ret.Storing, columnNode.Column)
+	}
+	if len(ret.Storing) == 0 {
+		panic(pgerror.New(pgcode.InvalidObjectDefinition,
+			"columnar index must contain at least one column that is not part of the primary key"))
+	}
+	for _, keyCol := range keyCols {
+		colName := mustRetrieveColumnNameElem(b, tableID, keyCol.ColumnID)
+		ret.Columns = append(ret.Columns, tree.IndexElem{
+			Column:    tree.Name(colName.Name),
+			Direction: getIndexColDir(keyCol),
+		})
+	}
+	return &ret
+}
+
 func nextRelationIndexID(b BuildCtx, relation scpb.Element) catid.IndexID {
 	switch t := relation.(type) {
 	case *scpb.Table:
@@ -58,7 +58,9 @@ func executeValidateIndexes(
 	for typ, indexes := range indexTypes {
 		var err error
 		switch typ {
-		case idxtype.FORWARD:
+		case idxtype.FORWARD, idxtype.COLUMNAR:
+			// Columnar indexes contain one entry per row until they are
+			// compacted, so they can be validated like forward indexes.
 			err = deps.Validator().ValidateForwardIndexes(ctx, deps.TransactionalJobRegistry().CurrentJob(), table, indexes, execOverride)
 		case idxtype.INVERTED:
 			err = deps.Validator().ValidateInvertedIndexes(ctx, deps.TransactionalJobRegistry().CurrentJob(), table, indexes, execOverride)
@@ -215,6 +215,12 @@ var StartCompactionJob func(
 	start, end hlc.Timestamp,
 ) (jobspb.JobID, error)
 
+var ForecastBackupSchedule func(
+	ctx context.Context,
+	planner interface{},
+	scheduleID jobspb.ScheduleID,
+) (json.JSON, error)
+
 // builtins contains the built-in functions indexed by name.
 //
 // For use in other packages, see AllBuiltinNames and GetBuiltinProperties().
@@ -9730,6 +9736,32 @@ WHERE object_id = table_descriptor_id
 			},
 		},
 	),
+	"crdb_internal.backup_schedule_forecast": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true, // applicable only on the gateway
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "schedule_id", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.Jsonb),
+			Info: "Forecasts the sizes of the full and incremental backups taken by a backup schedule, " +
+				"the storage they use over the backup.forecast.retention window, and the savings of compacting them.",
+			Volatility: volatility.Volatile,
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if ForecastBackupSchedule == nil {
+					return nil, errors.Newf("missing ForecastBackupSchedule")
+				}
+				scheduleID := jobspb.ScheduleID(tree.MustBeDInt(args[0]))
+				j, err := ForecastBackupSchedule(ctx, evalCtx.Planner, scheduleID)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDJSON(j), nil
+			},
+		},
+	),
 	"crdb_internal.process_vector_index_fixups": makeBuiltin(
 		tree.FunctionProperties{
 			Category:     builtinconstants.CategoryTesting,
@@ -10140,6 +10172,20 @@ WHERE object_id = table_descriptor_id
 		sessionVariableHintOverload,
 		sessionVariableHintWithDatabaseOverload,
 	),
+	"information_schema.crdb_accept_plan_baseline": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemRepair,
+			DistsqlBlocklist: true,
+		},
+		resolvePlanBaselineOverload(true /* accept */),
+	),
+	"information_schema.crdb_reject_plan_baseline": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemRepair,
+			DistsqlBlocklist: true,
+		},
+		resolvePlanBaselineOverload(false /* accept */),
+	),
 	"crdb_internal.clear_statement_hints_cache": makeBuiltin(
 		tree.FunctionProperties{
 			Category:         builtinconstants.CategorySystemRepair,
@@ -13462,6 +13508,68 @@ var rewriteInlineHintsWithDatabaseOverload = tree.Overload{
 	Volatility: volatility.Volatile,
 }
 
+// resolvePlanBaselineOverload returns the overload of
+// information_schema.crdb_accept_plan_baseline if accept is true, and of
+// information_schema.crdb_reject_plan_baseline otherwise.
+func resolvePlanBaselineOverload(accept bool) tree.Overload {
+	info := `This function accepts the regressed plan of the plan baseline for a statement ` +
+		`fingerprint in the given database. The regressed plan becomes the accepted plan, and ` +
+		`the statement hint that pins the previous plan is deleted. It returns false if the ` +
+		`plan baseline has no regressed plan awaiting review.`
+	if !accept {
+		info = `This function rejects the regressed plan of the plan baseline for a statement ` +
+			`fingerprint in the given database. The statement hint that pins the previous plan ` +
+			`is kept. It returns false if the plan baseline has no regressed plan awaiting review.`
+	}
+	return tree.Overload{
+		Types: tree.ParamTypes{
+			{Name: "statement_fingerprint", Typ: types.String},
+			{Name: "database", Typ: types.String},
+		},
+		ReturnType: tree.FixedReturnType(types.Bool),
+		Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+			// The user must have REPAIRCLUSTER to use this builtin.
+			if err := evalCtx.SessionAccessor.CheckPrivilege(
+				ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.REPAIRCLUSTER,
+			); err != nil {
+				return nil, err
+			}
+			arg := string(tree.MustBeDString(args[0]))
+			fingerprintFlags := tree.FmtFlags(tree.QueryFormattingForFingerprintsMask.Get(
+				&evalCtx.Settings.SV,
+			))
+			fingerprintArg, err := parserutils.FingerprintStatement(parserutils.FingerprintTagStatementFingerprint, arg, fingerprintFlags)
+			if err != nil {
+				return nil, err
+			}
+			if fingerprintArg != arg {
+				evalCtx.ClientNoticeSender.BufferClientNotice(
+					ctx, pgnotice.Newf("statement fingerprint changed to: %s", fingerprintArg),
+				)
+			}
+			database := string(tree.MustBeDString(args[1]))
+			resolved, pinHintID, err := evalCtx.Planner.ResolvePlanBaseline(ctx, fingerprintArg, database, accept)
+			if err != nil {
+				return nil, err
+			}
+			if !resolved {
+				return tree.DBoolFalse, nil
+			}
+			if err := evalCtx.Planner.LogEvent(ctx, &eventpb.ResolvePlanBaseline{
+				StatementFingerprint: fingerprintArg,
+				Database:             database,
+				Accepted:             accept,
+				HintID:               pinHintID,
+			}); err != nil {
+				return nil, err
+			}
+			return tree.DBoolTrue, nil
+		},
+		Info:       info,
+		Volatility: volatility.Volatile,
+	}
+}
+
 var setStatementHintEnabledByRowIDOverload = tree.Overload{
 	Types: tree.ParamTypes{
 		{Name: "enabled", Typ: types.Bool},
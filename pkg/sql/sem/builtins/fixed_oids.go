@@ -2950,6 +2950,9 @@ var builtinOidsArray = []string{
 	2995: `st_3dshortestline(geometry_a: geometry, geometry_b: geometry) -> geometry`,
 	2996: `st_3dperimeter(geometry: geometry) -> float`,
 	2997: `pg_get_function_sqlbody(func_oid: oid) -> string`,
+	2998: `crdb_internal.backup_schedule_forecast(schedule_id: int) -> jsonb`,
+	2999: `information_schema.crdb_accept_plan_baseline(statement_fingerprint: string, database: string) -> bool`,
+	3000: `information_schema.crdb_reject_plan_baseline(statement_fingerprint: string, database: string) -> bool`,
 }
 
 var builtinOidsBySignature map[string]oid.Oid
@@ -527,6 +527,12 @@ type Planner interface {
 	// Returns the number of affected rows.
 	SetStatementHintEnabled(ctx context.Context, rowID int64, statementFingerprint string, enabled bool, optDatabase string) (int64, error)
 
+	// ResolvePlanBaseline accepts or rejects the regressed plan of the plan
+	// baseline for the given statement fingerprint and database. It returns
+	// false if the baseline has no regressed plan awaiting review, and
+	// otherwise the ID of the hint that pins the previous plan.
+	ResolvePlanBaseline(ctx context.Context, statementFingerprint string, optDatabase string, accept bool) (resolved bool, pinHintID int64, err error)
+
 	// ValidateSessionVariableHint checks that a session variable with the given
 	// name exists, is writable, and is allowed to be overridden via statement
 	// hints. It also validates the value by attempting a dry-run set. Variables
@@ -24,9 +24,10 @@ func (t T) CanBeUnique() bool {
 // ordering on the last key column in the index. For example, a vector index
 // groups nearby vectors, but does not define a linear ordering among them. As
 // another example, an inverted index only defines a linear ordering for tokens,
-// not for the original JSONB or ARRAY data type.
+// not for the original JSONB or ARRAY data type. A columnar index is keyed by
+// the table's primary key, and so is ordered the same way as the primary index.
 func (t T) HasLinearOrdering() bool {
-	return t == FORWARD
+	return t == FORWARD || t == COLUMNAR
 }
 
 // HasScannablePrefix is true if compound indexes of this type can be used with
@@ -68,14 +69,16 @@ func (t T) SupportsOpClass() bool {
 }
 
 // ErrorText describes the type of the index using the phrase "an inverted
-// index" or "a vector index". This is intended to be included in errors that
-// apply to multiple index types.
+// index", "a vector index" or "a columnar index". This is intended to be
+// included in errors that apply to multiple index types.
 func ErrorText(t T) redact.SafeString {
 	switch t {
 	case INVERTED:
 		return "an inverted index"
 	case VECTOR:
 		return "a vector index"
+	case COLUMNAR:
+		return "a columnar index"
 	default:
 		return "an index"
 	}
@@ -0,0 +1,26 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+// AlterResourceGroup represents an ALTER RESOURCE GROUP statement.
+type AlterResourceGroup struct {
+	Name     Name
+	IfExists bool
+	Options  KVOptions
+}
+
+var _ Statement = &AlterResourceGroup{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterResourceGroup) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER RESOURCE GROUP ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" SET ")
+	ctx.FormatNode(&node.Options)
+}
@@ -763,6 +763,51 @@ func (node *AlterTableSetLogged) Format(ctx *FmtCtx) {
 	}
 }
 
+// AlterTableSetReadOnly represents an ALTER TABLE SET {READ ONLY | READ WRITE}
+// command.
+type AlterTableSetReadOnly struct {
+	Name     *UnresolvedObjectName
+	IfExists bool
+	ReadOnly bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableSetReadOnly) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER TABLE ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(node.Name)
+	if node.ReadOnly {
+		ctx.WriteString(" SET READ ONLY")
+	} else {
+		ctx.WriteString(" SET READ WRITE")
+	}
+}
+
+// AlterTableRevert represents an ALTER TABLE REVERT TO SYSTEM TIME statement,
+// which rewrites the rows of a table changed since the given time using the
+// revision log of a backup collection.
+type AlterTableRevert struct {
+	Name *UnresolvedObjectName
+	// AsOf is the time the table is reverted to.
+	AsOf AsOfClause
+	// Collection is the backup collection whose revision log covers the table.
+	Collection Expr
+}
+
+var _ Statement = &AlterTableRevert{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableRevert) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER TABLE ")
+	ctx.FormatNode(node.Name)
+	ctx.WriteString(" REVERT TO SYSTEM TIME ")
+	ctx.FormatNode(node.AsOf.Expr)
+	ctx.WriteString(" FROM ")
+	ctx.FormatURI(node.Collection)
+}
+
 // AlterTableOwner represents an ALTER TABLE OWNER TO command.
 type AlterTableOwner struct {
 	Name           *UnresolvedObjectName
@@ -51,6 +51,14 @@ type BackupOptions struct {
 	// `LOG` to avoid introducing a new unreserved keyword; the
 	// internal subsystem is still named `revlog`.)
 	RevisionStream bool
+	// SigningKMSURI is the KMS whose key signs the backup.
+	SigningKMSURI Expr
+	// SigningKey is the key which signs the backup, wrapped by the KMS at
+	// SigningKMSURI and encoded in base64.
+	SigningKey Expr
+	// Deduplicate stores the files of full backups content-addressed in a pool
+	// shared by the backups of the collection.
+	Deduplicate Expr
 }
 
 var _ NodeFormatter = &BackupOptions{}
@@ -137,12 +145,28 @@ type RestoreOptions struct {
 	ExperimentalCopy                 bool
 	RemoveRegions                    bool
 	Grants                           bool
+	// Where and Columns restrict the rows and the columns of a table which
+	// are restored.
+	Where   Expr
+	Columns Expr
+	// SigningKMSURI is the KMS whose key must have signed the backups that
+	// are restored.
+	SigningKMSURI Expr
+	// SigningKey is the key which must have signed the backups that are
+	// restored, wrapped by the KMS at SigningKMSURI and encoded in base64.
+	SigningKey Expr
 }
 
 func (opts *RestoreOptions) OnlineImpl() bool {
 	return opts.ExperimentalCopy || opts.ExperimentalOnline
 }
 
+// FiltersRows returns true if the restore only restores some of the rows or
+// columns of the table it restores.
+func (opts *RestoreOptions) FiltersRows() bool {
+	return opts.Where != nil || opts.Columns != nil
+}
+
 var _ NodeFormatter = &RestoreOptions{}
 
 // Restore represents a RESTORE statement.
@@ -314,6 +338,21 @@ func (o *BackupOptions) Format(ctx *FmtCtx) {
 		maybeAddSep()
 		ctx.WriteString("revision stream")
 	}
+	if o.SigningKMSURI != nil {
+		maybeAddSep()
+		ctx.WriteString("signing_kms = ")
+		ctx.FormatURI(o.SigningKMSURI)
+	}
+	if o.SigningKey != nil {
+		maybeAddSep()
+		ctx.WriteString("signing_key = ")
+		ctx.FormatURI(o.SigningKey)
+	}
+	if o.Deduplicate != nil {
+		maybeAddSep()
+		ctx.WriteString("deduplicate = ")
+		ctx.FormatNode(o.Deduplicate)
+	}
 }
 
 // CombineWith merges other backup options into this backup options struct.
@@ -382,6 +421,21 @@ func (o *BackupOptions) CombineWith(other *BackupOptions) error {
 	} else {
 		o.RevisionStream = other.RevisionStream
 	}
+	if o.SigningKMSURI == nil {
+		o.SigningKMSURI = other.SigningKMSURI
+	} else if other.SigningKMSURI != nil {
+		return errors.New("signing_kms specified multiple times")
+	}
+	if o.SigningKey == nil {
+		o.SigningKey = other.SigningKey
+	} else if other.SigningKey != nil {
+		return errors.New("signing_key specified multiple times")
+	}
+	if o.Deduplicate == nil {
+		o.Deduplicate = other.Deduplicate
+	} else if other.Deduplicate != nil {
+		return errors.New("deduplicate option specified multiple times")
+	}
 	return nil
 }
 
@@ -396,7 +450,10 @@ func (o BackupOptions) IsDefault() bool {
 		o.IncludeAllSecondaryTenants == options.IncludeAllSecondaryTenants &&
 		o.UpdatesClusterMonitoringMetrics == options.UpdatesClusterMonitoringMetrics &&
 		o.Strict == options.Strict &&
-		o.RevisionStream == options.RevisionStream
+		o.RevisionStream == options.RevisionStream &&
+		o.SigningKMSURI == options.SigningKMSURI &&
+		o.SigningKey == options.SigningKey &&
+		o.Deduplicate == options.Deduplicate
 }
 
 // Format implements the NodeFormatter interface.
@@ -522,6 +579,29 @@ func (o *RestoreOptions) Format(ctx *FmtCtx) {
 		maybeAddSep()
 		ctx.WriteString("grants")
 	}
+
+	if o.Where != nil {
+		maybeAddSep()
+		ctx.WriteString("where = ")
+		ctx.FormatNode(o.Where)
+	}
+
+	if o.Columns != nil {
+		maybeAddSep()
+		ctx.WriteString("columns = ")
+		ctx.FormatNode(o.Columns)
+	}
+
+	if o.SigningKMSURI != nil {
+		maybeAddSep()
+		ctx.WriteString("signing_kms = ")
+		ctx.FormatURI(o.SigningKMSURI)
+	}
+	if o.SigningKey != nil {
+		maybeAddSep()
+		ctx.WriteString("signing_key = ")
+		ctx.FormatURI(o.SigningKey)
+	}
 }
 
 // CombineWith merges other backup options into this backup options struct.
@@ -681,6 +761,29 @@ func (o *RestoreOptions) CombineWith(other *RestoreOptions) error {
 		o.Grants = other.Grants
 	}
 
+	if o.Where == nil {
+		o.Where = other.Where
+	} else if other.Where != nil {
+		return errors.New("where specified multiple times")
+	}
+
+	if o.Columns == nil {
+		o.Columns = other.Columns
+	} else if other.Columns != nil {
+		return errors.New("columns specified multiple times")
+	}
+
+	if o.SigningKMSURI == nil {
+		o.SigningKMSURI = other.SigningKMSURI
+	} else if other.SigningKMSURI != nil {
+		return errors.New("signing_kms specified multiple times")
+	}
+	if o.SigningKey == nil {
+		o.SigningKey = other.SigningKey
+	} else if other.SigningKey != nil {
+		return errors.New("signing_key specified multiple times")
+	}
+
 	return nil
 }
 
@@ -707,7 +810,11 @@ func (o RestoreOptions) IsDefault() bool {
 		o.ExperimentalOnline == options.ExperimentalOnline &&
 		o.ExperimentalCopy == options.ExperimentalCopy &&
 		o.RemoveRegions == options.RemoveRegions &&
-		o.Grants == options.Grants
+		o.Grants == options.Grants &&
+		o.Where == options.Where &&
+		o.Columns == options.Columns &&
+		o.SigningKMSURI == options.SigningKMSURI &&
+		o.SigningKey == options.SigningKey
 }
 
 // BackupTargetList represents a list of targets.
@@ -0,0 +1,124 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
+)
+
+// CopyBackup represents a COPY BACKUP statement, which copies a backup
+// collection, or the chain of a single full backup within it, to another
+// storage location.
+type CopyBackup struct {
+	// Subdir names the full backup whose chain is copied. If nil, the whole
+	// collection is copied.
+	Subdir Expr
+	// From is the collection the backup is copied from.
+	From Expr
+	// To is the collection the backup is copied into.
+	To Expr
+	// AsOf, if set, ends the copied chain at the first backup needed to
+	// restore as of the given time.
+	AsOf    AsOfClause
+	Options CopyBackupOptions
+}
+
+var _ Statement = &CopyBackup{}
+
+// Format implements the NodeFormatter interface.
+func (node *CopyBackup) Format(ctx *FmtCtx) {
+	ctx.WriteString("COPY BACKUP ")
+	if node.Subdir != nil {
+		ctx.FormatNode(node.Subdir)
+		ctx.WriteString(" ")
+	}
+	ctx.WriteString("IN ")
+	ctx.FormatURI(node.From)
+	ctx.WriteString(" TO ")
+	ctx.FormatURI(node.To)
+	if node.AsOf.Expr != nil {
+		ctx.WriteString(" ")
+		ctx.FormatNode(&node.AsOf)
+	}
+	if !node.Options.IsDefault() {
+		ctx.WriteString(" WITH OPTIONS (")
+		ctx.FormatNode(&node.Options)
+		ctx.WriteString(")")
+	}
+}
+
+// CopyBackupOptions describes options for the COPY BACKUP execution.
+type CopyBackupOptions struct {
+	// DecryptionKMSURI are the KMS URIs that may unwrap the data key of the
+	// copied backups. They are only needed to re-encrypt the copy.
+	DecryptionKMSURI StringOrPlaceholderOptList
+	// NewKMSURI are the KMS URIs the data key of the copied backups is
+	// wrapped with at the destination, in place of the source's.
+	NewKMSURI StringOrPlaceholderOptList
+	Detached  bool
+}
+
+var _ NodeFormatter = &CopyBackupOptions{}
+
+// Format implements the NodeFormatter interface.
+func (o *CopyBackupOptions) Format(ctx *FmtCtx) {
+	var addSep bool
+	maybeAddSep := func() {
+		if addSep {
+			ctx.WriteString(", ")
+		}
+		addSep = true
+	}
+	if o.DecryptionKMSURI != nil {
+		maybeAddSep()
+		ctx.WriteString("kms = ")
+		ctx.FormatURIs(o.DecryptionKMSURI)
+	}
+	if o.NewKMSURI != nil {
+		maybeAddSep()
+		ctx.WriteString("new_kms = ")
+		ctx.FormatURIs(o.NewKMSURI)
+	}
+	if o.Detached {
+		maybeAddSep()
+		ctx.WriteString("detached")
+	}
+}
+
+// CombineWith merges other options into this options struct. An error is
+// returned if the same option merged multiple times.
+func (o *CopyBackupOptions) CombineWith(other *CopyBackupOptions) error {
+	if o.DecryptionKMSURI == nil {
+		o.DecryptionKMSURI = other.DecryptionKMSURI
+	} else if other.DecryptionKMSURI != nil {
+		return errors.New("kms specified multiple times")
+	}
+
+	if o.NewKMSURI == nil {
+		o.NewKMSURI = other.NewKMSURI
+	} else if other.NewKMSURI != nil {
+		return errors.New("new_kms specified multiple times")
+	}
+
+	if o.Detached {
+		if other.Detached {
+			return errors.New("detached option specified multiple times")
+		}
+	} else {
+		o.Detached = other.Detached
+	}
+	return nil
+}
+
+// IsDefault returns true if this copy backup options struct has default
+// value.
+func (o CopyBackupOptions) IsDefault() bool {
+	options := CopyBackupOptions{}
+	return cmp.Equal(o.DecryptionKMSURI, options.DecryptionKMSURI) &&
+		cmp.Equal(o.NewKMSURI, options.NewKMSURI) &&
+		o.Detached == options.Detached
+}
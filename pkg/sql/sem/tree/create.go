@@ -276,6 +276,9 @@ func (node *CreateIndex) Format(ctx *FmtCtx) {
 	}
 	ctx.WriteString("ON ")
 	ctx.FormatNode(&node.Table)
+	if node.Type == idxtype.COLUMNAR {
+		ctx.WriteString(" USING COLUMNAR")
+	}
 
 	ctx.WriteString(" (")
 	ctx.FormatNode(&node.Columns)
@@ -2053,6 +2056,9 @@ type CreateView struct {
 	Replace      bool
 	Materialized bool
 	WithData     bool
+	// StorageParams contains the parameters of a materialized view, e.g.
+	// WITH (incremental).
+	StorageParams StorageParams
 }
 
 // Format implements the NodeFormatter interface.
@@ -2091,6 +2097,12 @@ func (node *CreateView) Format(ctx *FmtCtx) {
 		ctx.WriteString(` )`)
 	}
 
+	if node.StorageParams != nil {
+		ctx.WriteString(" WITH (")
+		ctx.FormatNode(&node.StorageParams)
+		ctx.WriteString(")")
+	}
+
 	ctx.WriteString(" AS ")
 	ctx.FormatNode(node.AsSource)
 	if node.Materialized && node.WithData {
@@ -2318,6 +2330,28 @@ func (node *CreateExternalConnection) Format(ctx *FmtCtx) {
 	ctx.FormatURI(node.As)
 }
 
+// CreateResourceGroup represents a CREATE RESOURCE GROUP statement.
+type CreateResourceGroup struct {
+	Name        Name
+	IfNotExists bool
+	Options     KVOptions
+}
+
+var _ Statement = &CreateResourceGroup{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateResourceGroup) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE RESOURCE GROUP ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	if node.Options != nil {
+		ctx.WriteString(" WITH ")
+		ctx.FormatNode(&node.Options)
+	}
+}
+
 // CreateTenant represents a CREATE VIRTUAL CLUSTER statement.
 type CreateTenant struct {
 	IfNotExists bool
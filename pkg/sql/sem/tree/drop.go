@@ -312,6 +312,23 @@ func (node *DropExternalConnection) Format(ctx *FmtCtx) {
 	}
 }
 
+// DropResourceGroup represents a DROP RESOURCE GROUP statement.
+type DropResourceGroup struct {
+	Name     Name
+	IfExists bool
+}
+
+var _ Statement = &DropResourceGroup{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropResourceGroup) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP RESOURCE GROUP ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+}
+
 // DropTenant represents a DROP VIRTUAL CLUSTER command.
 type DropTenant struct {
 	TenantSpec *TenantSpec
@@ -37,6 +37,18 @@ type ExplainAnalyze struct {
 type ExplainOptions struct {
 	Mode  ExplainMode
 	Flags [numExplainFlags + 1]bool
+
+	// HypotheticalIndexes contains the CREATE INDEX statements of indexes that
+	// the statement is planned with, without the indexes being built.
+	HypotheticalIndexes []string
+}
+
+// ExplainOptionList contains the options of an EXPLAIN statement as they are
+// written in the statement, before they are validated by
+// MakeExplainFromOptionList.
+type ExplainOptionList struct {
+	Names               []string
+	HypotheticalIndexes []string
 }
 
 // ExplainMode indicates the mode of the explain. The default is ExplainPlan.
@@ -169,6 +181,16 @@ func (node *Explain) Format(ctx *FmtCtx) {
 			b.Add(ctx, f.String())
 		}
 	}
+	if len(node.HypotheticalIndexes) > 0 {
+		b.Add(ctx, "HYPOTHETICAL INDEXES (")
+		for i, idx := range node.HypotheticalIndexes {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			ctx.FormatNode(NewStrVal(idx))
+		}
+		ctx.WriteByte(')')
+	}
 	b.Finish(ctx)
 	ctx.FormatNode(node.Statement)
 }
@@ -185,6 +207,16 @@ func (node *Explain) Doc(p *PrettyCfg) pretty.Doc {
 			opts = append(opts, pretty.Keyword(f.String()))
 		}
 	}
+	if len(node.HypotheticalIndexes) > 0 {
+		idxs := make([]pretty.Doc, len(node.HypotheticalIndexes))
+		for i, idx := range node.HypotheticalIndexes {
+			idxs[i] = p.Doc(NewStrVal(idx))
+		}
+		opts = append(opts, pretty.ConcatSpace(
+			pretty.Keyword("HYPOTHETICAL INDEXES"),
+			p.bracket("(", p.commaSeparated(idxs...), ")"),
+		))
+	}
 	if len(opts) > 0 {
 		d = pretty.ConcatSpace(
 			d,
@@ -235,6 +267,13 @@ func (node *ExplainAnalyze) Doc(p *PrettyCfg) pretty.Doc {
 // MakeExplain parses the EXPLAIN option strings and generates an Explain
 // or ExplainAnalyze statement.
 func MakeExplain(options []string, stmt Statement) (Statement, error) {
+	return MakeExplainFromOptionList(ExplainOptionList{Names: options}, stmt)
+}
+
+// MakeExplainFromOptionList is like MakeExplain, but also accepts options
+// that are not simple names, such as HYPOTHETICAL INDEXES.
+func MakeExplainFromOptionList(list ExplainOptionList, stmt Statement) (Statement, error) {
+	options := list.Names
 	for i := range options {
 		options[i] = strings.ToUpper(options[i])
 	}
@@ -298,6 +337,18 @@ func MakeExplain(options []string, stmt Statement) (Statement, error) {
 		}
 	}
 
+	if len(list.HypotheticalIndexes) > 0 {
+		if analyze {
+			return nil, pgerror.Newf(pgcode.Syntax,
+				"HYPOTHETICAL INDEXES cannot be used with EXPLAIN ANALYZE")
+		}
+		if opts.Mode != ExplainPlan && opts.Mode != ExplainOpt {
+			return nil, pgerror.Newf(pgcode.Syntax,
+				"HYPOTHETICAL INDEXES cannot be used with %s", opts.Mode)
+		}
+		opts.HypotheticalIndexes = list.HypotheticalIndexes
+	}
+
 	if analyze {
 		if opts.Mode != ExplainDistSQL && opts.Mode != ExplainDebug && opts.Mode != ExplainPlan {
 			return nil, pgerror.Newf(pgcode.Syntax, "EXPLAIN ANALYZE cannot be used with %s", opts.Mode)
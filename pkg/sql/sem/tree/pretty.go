@@ -1422,6 +1422,15 @@ func (node *CreateView) Doc(p *PrettyCfg) pretty.Doc {
 			),
 		)
 	}
+	if node.StorageParams != nil {
+		d = pretty.ConcatSpace(
+			d,
+			pretty.ConcatSpace(
+				pretty.Keyword("WITH"),
+				p.bracket("(", p.Doc(&node.StorageParams), ")"),
+			),
+		)
+	}
 	d = p.nestUnder(
 		pretty.ConcatSpace(d, pretty.Keyword("AS")),
 		p.Doc(node.AsSource),
@@ -1713,7 +1722,7 @@ func (node *ShardedIndexDef) Doc(p *PrettyCfg) pretty.Doc {
 func (node *CreateIndex) Doc(p *PrettyCfg) pretty.Doc {
 	// Final layout:
 	// CREATE [UNIQUE] [INVERTED | VECTOR] INDEX [name]
-	//    ON tbl (cols...)
+	//    ON tbl [USING COLUMNAR] (cols...)
 	//    [STORING ( ... )]
 	//    [INTERLEAVE ...]
 	//    [PARTITION BY ...]
@@ -1744,10 +1753,12 @@ func (node *CreateIndex) Doc(p *PrettyCfg) pretty.Doc {
 	}
 
 	clauses := make([]pretty.Doc, 0, 7)
-	clauses = append(clauses, pretty.Fold(pretty.ConcatSpace,
-		pretty.Keyword("ON"),
-		p.Doc(&node.Table),
-		p.bracket("(", p.Doc(&node.Columns), ")")))
+	on := []pretty.Doc{pretty.Keyword("ON"), p.Doc(&node.Table)}
+	if node.Type == idxtype.COLUMNAR {
+		on = append(on, pretty.Keyword("USING COLUMNAR"))
+	}
+	on = append(on, p.bracket("(", p.Doc(&node.Columns), ")"))
+	clauses = append(clauses, pretty.Fold(pretty.ConcatSpace, on...))
 
 	if node.Sharded != nil {
 		clauses = append(clauses, p.Doc(node.Sharded))
@@ -16,6 +16,7 @@ package tree
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
@@ -86,6 +87,12 @@ const (
 	// BackupValidateDetails identifies a SHOW BACKUP VALIDATION
 	// statement.
 	BackupValidateDetails
+	// BackupTableDetails identifies a SHOW BACKUP TABLE statement, which
+	// returns the rows of a table read directly from the backup.
+	BackupTableDetails
+	// BackupDiffDetails identifies a SHOW BACKUP DIFF TABLE statement, which
+	// returns the rows of a table which differ between two backups.
+	BackupDiffDetails
 )
 
 // ShowBackup represents a SHOW BACKUP statement.
@@ -101,6 +108,13 @@ type ShowBackup struct {
 	Details      ShowBackupDetails
 	Options      ShowBackupOptions
 	TimeRange    ShowBackupTimeFilter
+	// Table and AsOf are only set for SHOW BACKUP TABLE, and Table for SHOW
+	// BACKUP DIFF TABLE.
+	Table *UnresolvedObjectName
+	AsOf  AsOfClause
+	// DiffTo is only set for SHOW BACKUP DIFF TABLE, and identifies the backup
+	// which is compared to the backup identified by Path.
+	DiffTo Expr
 }
 
 // Format implements the NodeFormatter interface.
@@ -128,6 +142,14 @@ func (node *ShowBackup) Format(ctx *FmtCtx) {
 		ctx.WriteString("FILES ")
 	case BackupSchemaDetails:
 		ctx.WriteString("SCHEMAS ")
+	case BackupTableDetails:
+		ctx.WriteString("TABLE ")
+		ctx.FormatNode(node.Table)
+		ctx.WriteString(" ")
+	case BackupDiffDetails:
+		ctx.WriteString("DIFF TABLE ")
+		ctx.FormatNode(node.Table)
+		ctx.WriteString(" ")
 	}
 
 	if node.From {
@@ -135,8 +157,16 @@ func (node *ShowBackup) Format(ctx *FmtCtx) {
 	}
 
 	ctx.FormatNode(node.Path)
+	if node.DiffTo != nil {
+		ctx.WriteString(" TO ")
+		ctx.FormatNode(node.DiffTo)
+	}
 	ctx.WriteString(" IN ")
 	ctx.FormatURIs(node.InCollection)
+	if node.AsOf.Expr != nil {
+		ctx.WriteString(" ")
+		ctx.FormatNode(&node.AsOf)
+	}
 
 	if !node.Options.IsDefault() {
 		ctx.WriteString(" WITH OPTIONS (")
@@ -145,6 +175,23 @@ func (node *ShowBackup) Format(ctx *FmtCtx) {
 	}
 }
 
+// ShowBackupScheduleForecast represents a SHOW BACKUP SCHEDULE ... FORECAST
+// statement.
+type ShowBackupScheduleForecast struct {
+	ScheduleID uint64
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ShowBackupScheduleForecast) Format(ctx *FmtCtx) {
+	ctx.WriteString("SHOW BACKUP SCHEDULE ")
+	if ctx.HasFlags(FmtHideConstants) || ctx.HasFlags(FmtAnonymize) {
+		ctx.WriteString("123")
+	} else {
+		ctx.WriteString(strconv.FormatUint(node.ScheduleID, 10))
+	}
+	ctx.WriteString(" FORECAST")
+}
+
 // ShowBackupTimeFilter represents the NEWER THAN <expr> OLDER THAN <expr>
 // option for SHOW BACKUPS.
 type ShowBackupTimeFilter struct {
@@ -188,6 +235,13 @@ type ShowBackupOptions struct {
 
 	RevisionStartTime bool
 	Debug             bool
+
+	// SigningKMSURI is the KMS whose key must have signed the backups that
+	// are checked.
+	SigningKMSURI Expr
+	// SigningKey is the key which must have signed the backups that are
+	// checked, wrapped by the KMS at SigningKMSURI and encoded in base64.
+	SigningKey Expr
 }
 
 var _ NodeFormatter = &ShowBackupOptions{}
@@ -237,6 +291,16 @@ func (o *ShowBackupOptions) Format(ctx *FmtCtx) {
 		maybeAddSep()
 		ctx.WriteString("skip size")
 	}
+	if o.SigningKMSURI != nil {
+		maybeAddSep()
+		ctx.WriteString("signing_kms = ")
+		ctx.FormatURI(o.SigningKMSURI)
+	}
+	if o.SigningKey != nil {
+		maybeAddSep()
+		ctx.WriteString("signing_key = ")
+		ctx.FormatURI(o.SigningKey)
+	}
 
 	// The following are only used in connection-check SHOW.
 	if o.CheckConnectionConcurrency != nil {
@@ -279,7 +343,9 @@ func (o ShowBackupOptions) IsDefault() bool {
 		o.CheckConnectionDuration == options.CheckConnectionDuration &&
 		o.CheckConnectionConcurrency == options.CheckConnectionConcurrency &&
 		o.RevisionStartTime == options.RevisionStartTime &&
-		o.Debug == options.Debug
+		o.Debug == options.Debug &&
+		o.SigningKMSURI == options.SigningKMSURI &&
+		o.SigningKey == options.SigningKey
 }
 
 func combineBools(v1 bool, v2 bool, label string) (bool, error) {
@@ -343,6 +409,14 @@ func (o *ShowBackupOptions) CombineWith(other *ShowBackupOptions) error {
 	if err != nil {
 		return err
 	}
+	o.SigningKMSURI, err = combineExpr(o.SigningKMSURI, other.SigningKMSURI, "signing_kms")
+	if err != nil {
+		return err
+	}
+	o.SigningKey, err = combineExpr(o.SigningKey, other.SigningKey, "signing_key")
+	if err != nil {
+		return err
+	}
 
 	o.CheckConnectionTransferSize, err = combineExpr(o.CheckConnectionTransferSize, other.CheckConnectionTransferSize,
 		"transfer")
@@ -1360,6 +1434,16 @@ func (s ShowFingerprintOptions) IsDefault() bool {
 
 var _ NodeFormatter = &ShowFingerprintOptions{}
 
+// ShowPlanBaselines represents a SHOW PLAN BASELINES statement.
+type ShowPlanBaselines struct{}
+
+var _ Statement = &ShowPlanBaselines{}
+
+// Format implements the NodeFormatter interface.
+func (n *ShowPlanBaselines) Format(ctx *FmtCtx) {
+	ctx.WriteString("SHOW PLAN BASELINES")
+}
+
 // ShowStatementHints represents a SHOW STATEMENT HINTS statement.
 type ShowStatementHints struct {
 	Expr    Expr
@@ -1435,6 +1519,7 @@ type ShowTenantOptions struct {
 	WithReplication      bool
 	WithPriorReplication bool
 	WithCapabilities     bool
+	WithStorageStatus    bool
 }
 
 // ShowTenant represents a SHOW VIRTUAL CLUSTER statement.
@@ -1458,6 +1543,9 @@ func (node *ShowTenant) Format(ctx *FmtCtx) {
 	if node.WithCapabilities {
 		withs = append(withs, "CAPABILITIES")
 	}
+	if node.WithStorageStatus {
+		withs = append(withs, "STORAGE STATUS")
+	}
 	if len(withs) > 0 {
 		ctx.WriteString(" WITH ")
 		ctx.WriteString(strings.Join(withs, ", "))
@@ -1796,6 +1884,16 @@ func (node *ShowExternalConnections) Format(ctx *FmtCtx) {
 
 var _ Statement = &ShowExternalConnections{}
 
+// ShowResourceGroups represents a SHOW RESOURCE GROUPS statement.
+type ShowResourceGroups struct{}
+
+// Format implements the NodeFormatter interface.
+func (node *ShowResourceGroups) Format(ctx *FmtCtx) {
+	ctx.WriteString("SHOW RESOURCE GROUPS")
+}
+
+var _ Statement = &ShowResourceGroups{}
+
 // ShowTriggers represents a SHOW TRIGGERS statement.
 type ShowTriggers struct {
 	Table *UnresolvedObjectName
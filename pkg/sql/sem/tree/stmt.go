@@ -191,10 +191,10 @@ func CanWriteData(stmt Statement) bool {
 	case *Insert, *Delete, *Update, *Truncate:
 		return true
 	// Import operations.
-	case *CopyFrom, *Import, *Restore:
+	case *CopyFrom, *Import, *Restore, *AlterTableRevert:
 		return true
 	// Backup creates a job and allows you to write into userfiles.
-	case *Backup:
+	case *Backup, *CopyBackup:
 		return true
 	// CockroachDB extensions.
 	case *Split, *Unsplit, *Relocate, *RelocateRange, *Scatter:
@@ -214,7 +214,7 @@ func ReturnsAtMostOneRow(stmt Statement) bool {
 	case *CopyFrom, *Import, *Restore:
 		return true
 	// Backup creates a job and allows you to write into userfiles.
-	case *Backup:
+	case *Backup, *CopyBackup:
 		return true
 	// CockroachDB extensions.
 	case *Scatter:
@@ -287,8 +287,11 @@ var _ ZoneConfigStatement = &AlterDatabaseSetZoneConfigExtension{}
 
 var _ CCLOnlyStatement = &AlterBackup{}
 var _ CCLOnlyStatement = &AlterBackupSchedule{}
+var _ CCLOnlyStatement = &AlterTableRevert{}
 var _ CCLOnlyStatement = &Backup{}
+var _ CCLOnlyStatement = &CopyBackup{}
 var _ CCLOnlyStatement = &ShowBackup{}
+var _ CCLOnlyStatement = &ShowBackupScheduleForecast{}
 var _ CCLOnlyStatement = &Restore{}
 var _ CCLOnlyStatement = &CreateChangefeed{}
 var _ CCLOnlyStatement = &AlterChangefeed{}
@@ -599,6 +602,30 @@ func (*AlterTableSetLogged) StatementTag() string { return "ALTER TABLE" }
 
 func (*AlterTableSetLogged) hiddenFromShowQueries() {}
 
+// StatementReturnType implements the Statement interface.
+func (*AlterTableSetReadOnly) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*AlterTableSetReadOnly) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterTableSetReadOnly) StatementTag() string { return "ALTER TABLE" }
+
+func (*AlterTableSetReadOnly) hiddenFromShowQueries() {}
+
+// StatementReturnType implements the Statement interface.
+func (*AlterTableRevert) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*AlterTableRevert) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterTableRevert) StatementTag() string { return "ALTER TABLE" }
+
+func (*AlterTableRevert) cclOnlyStatement() {}
+
+func (*AlterTableRevert) planHookStatement() {}
+
 // StatementReturnType implements the Statement interface.
 func (*AlterTableSetSchema) StatementReturnType() StatementReturnType { return DDL }
 
@@ -981,6 +1008,19 @@ func (*CommitTransaction) StatementType() StatementType { return TypeTCL }
 // StatementTag returns a short string identifying the type of statement.
 func (*CommitTransaction) StatementTag() string { return "COMMIT" }
 
+// StatementReturnType implements the Statement interface.
+func (*CopyBackup) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*CopyBackup) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CopyBackup) StatementTag() string { return "COPY BACKUP" }
+
+func (*CopyBackup) cclOnlyStatement() {}
+
+func (*CopyBackup) planHookStatement() {}
+
 // StatementReturnType implements the Statement interface.
 func (*CopyFrom) StatementReturnType() StatementReturnType { return CopyIn }
 
@@ -1077,6 +1117,24 @@ func (*AlterExternalConnection) StatementType() StatementType { return TypeDDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*AlterExternalConnection) StatementTag() string { return "ALTER EXTERNAL CONNECTION" }
 
+// StatementReturnType implements the Statement interface.
+func (*CreateResourceGroup) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*CreateResourceGroup) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateResourceGroup) StatementTag() string { return "CREATE RESOURCE GROUP" }
+
+// StatementReturnType implements the Statement interface.
+func (*AlterResourceGroup) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*AlterResourceGroup) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterResourceGroup) StatementTag() string { return "ALTER RESOURCE GROUP" }
+
 // StatementReturnType implements the Statement interface.
 func (*CheckExternalConnection) StatementReturnType() StatementReturnType { return Rows }
 
@@ -1143,6 +1201,15 @@ func (*DropExternalConnection) StatementType() StatementType { return TypeDDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*DropExternalConnection) StatementTag() string { return "DROP EXTERNAL CONNECTION" }
 
+// StatementReturnType implements the Statement interface.
+func (*DropResourceGroup) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*DropResourceGroup) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropResourceGroup) StatementTag() string { return "DROP RESOURCE GROUP" }
+
 // StatementReturnType implements the Statement interface.
 func (*CreateIndex) StatementReturnType() StatementReturnType { return DDL }
 
@@ -1967,6 +2034,19 @@ func (*ShowBackup) cclOnlyStatement() {}
 
 func (*ShowBackup) planHookStatement() {}
 
+// StatementReturnType implements the Statement interface.
+func (*ShowBackupScheduleForecast) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*ShowBackupScheduleForecast) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowBackupScheduleForecast) StatementTag() string { return "SHOW BACKUP SCHEDULE FORECAST" }
+
+func (*ShowBackupScheduleForecast) cclOnlyStatement() {}
+
+func (*ShowBackupScheduleForecast) planHookStatement() {}
+
 // StatementReturnType implements the Statement interface.
 func (*ShowDatabases) StatementReturnType() StatementReturnType { return Rows }
 
@@ -2039,6 +2119,15 @@ func (*ShowPartitions) StatementType() StatementType { return TypeDML }
 // StatementTag returns a short string identifying the type of the statement.
 func (*ShowPartitions) StatementTag() string { return "SHOW PARTITIONS" }
 
+// StatementReturnType implements the Statement interface.
+func (*ShowPlanBaselines) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*ShowPlanBaselines) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowPlanBaselines) StatementTag() string { return "SHOW PLAN BASELINES" }
+
 // StatementReturnType implements the Statement interface.
 func (*ShowPolicies) StatementReturnType() StatementReturnType { return Rows }
 
@@ -2411,6 +2500,15 @@ func (*ShowExternalConnections) StatementTag() string {
 	return "SHOW EXTERNAL CONNECTIONS"
 }
 
+// StatementReturnType implements the Statement interface.
+func (*ShowResourceGroups) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*ShowResourceGroups) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowResourceGroups) StatementTag() string { return "SHOW RESOURCE GROUPS" }
+
 // StatementReturnType implements the Statement interface.
 func (*ShowCommitTimestamp) StatementReturnType() StatementReturnType { return Rows }
 
@@ -2687,6 +2785,8 @@ func (n *AlterTableSetVisible) String() string                { return AsString(
 func (n *AlterTableSetNotNull) String() string                { return AsString(n) }
 func (n *AlterTableOwner) String() string                     { return AsString(n) }
 func (n *AlterTableSetLogged) String() string                 { return AsString(n) }
+func (n *AlterTableSetReadOnly) String() string               { return AsString(n) }
+func (n *AlterTableRevert) String() string                    { return AsString(n) }
 func (n *AlterTableSetSchema) String() string                 { return AsString(n) }
 func (n *AlterViewSetOptions) String() string                 { return AsString(n) }
 func (n *AlterViewResetOptions) String() string               { return AsString(n) }
@@ -2699,6 +2799,7 @@ func (n *AlterTenantService) String() string                  { return AsString(
 func (n *AlterType) String() string                           { return AsString(n) }
 func (n *AlterRole) String() string                           { return AsString(n) }
 func (n *AlterRoleSet) String() string                        { return AsString(n) }
+func (n *AlterResourceGroup) String() string                  { return AsString(n) }
 func (n *AlterSequence) String() string                       { return AsString(n) }
 func (n *Analyze) String() string                             { return AsString(n) }
 func (n *Backup) String() string                              { return AsString(n) }
@@ -2721,6 +2822,7 @@ func (n *CommentOnTable) String() string                      { return AsString(
 func (n *CommentOnType) String() string                       { return AsString(n) }
 func (n *CommitPrepared) String() string                      { return AsString(n) }
 func (n *CommitTransaction) String() string                   { return AsString(n) }
+func (n *CopyBackup) String() string                          { return AsString(n) }
 func (n *CopyFrom) String() string                            { return AsString(n) }
 func (n *CopyTo) String() string                              { return AsString(n) }
 func (n *CreateChangefeed) String() string                    { return AsString(n) }
@@ -2733,6 +2835,7 @@ func (n *CreateIndex) String() string                         { return AsString(
 func (n *CreateLogicalReplicationStream) String() string      { return AsString(n) }
 func (n *CreatePolicy) String() string                        { return AsString(n) }
 func (n *CreateRole) String() string                          { return AsString(n) }
+func (n *CreateResourceGroup) String() string                 { return AsString(n) }
 func (n *CreateTable) String() string                         { return AsString(n) }
 func (n *CreateTenant) String() string                        { return AsString(n) }
 func (n *CreateTenantFromReplication) String() string         { return AsString(n) }
@@ -2757,6 +2860,7 @@ func (n *DropType) String() string                            { return AsString(
 func (n *DropView) String() string                            { return AsString(n) }
 func (n *DropProvisionedRoles) String() string                { return AsString(n) }
 func (n *DropRole) String() string                            { return AsString(n) }
+func (n *DropResourceGroup) String() string                   { return AsString(n) }
 func (n *DropTenant) String() string                          { return AsString(n) }
 func (n *Execute) String() string                             { return AsString(n) }
 func (n *Explain) String() string                             { return AsString(n) }
@@ -2808,6 +2912,7 @@ func (n *SetTransaction) String() string                      { return AsString(
 func (n *SetTracing) String() string                          { return AsString(n) }
 func (n *SetVar) String() string                              { return AsString(n) }
 func (n *ShowBackup) String() string                          { return AsString(n) }
+func (n *ShowBackupScheduleForecast) String() string          { return AsString(n) }
 func (n *ShowClusterSetting) String() string                  { return AsString(n) }
 func (n *ShowClusterSettingList) String() string              { return AsString(n) }
 func (n *ShowTenantClusterSetting) String() string            { return AsString(n) }
@@ -2838,6 +2943,7 @@ func (n *ShowJobs) String() string                            { return AsString(
 func (n *ShowChangefeedJobs) String() string                  { return AsString(n) }
 func (n *ShowLastQueryStatistics) String() string             { return AsString(n) }
 func (n *ShowPartitions) String() string                      { return AsString(n) }
+func (n *ShowPlanBaselines) String() string                   { return AsString(n) }
 func (n *ShowPolicies) String() string                        { return AsString(n) }
 func (n *ShowQueries) String() string                         { return AsString(n) }
 func (n *ShowRanges) String() string                          { return AsString(n) }
@@ -2845,6 +2951,7 @@ func (n *ShowRangeForRow) String() string                     { return AsString(
 func (n *ShowRegions) String() string                         { return AsString(n) }
 func (n *ShowRoleGrants) String() string                      { return AsString(n) }
 func (n *ShowRoles) String() string                           { return AsString(n) }
+func (n *ShowResourceGroups) String() string                  { return AsString(n) }
 func (n *ShowSavepointStatus) String() string                 { return AsString(n) }
 func (n *ShowSchemas) String() string                         { return AsString(n) }
 func (n *ShowSequences) String() string                       { return AsString(n) }
@@ -137,6 +137,8 @@ var sessionVarDescriptions = map[string]string{
 	"opt_split_scan_limit":                                            "Sets the maximum number of UNION ALL statements a Scan may be split into during query optimization to avoid a sort.",
 	"optimizer_span_limit":                                            "Sets the maximum number of constraint spans allowed in a scan during query optimization. 0 means no limit.",
 	"optimizer":                                                       "Controls whether the cost-based optimizer is enabled.",
+	"optimizer_adaptive_reoptimization":                               "Controls whether the optimizer re-plans the remainder of a query after a materialized CTE returns a row count that differs significantly from its estimate.",
+	"optimizer_adaptive_reoptimization_threshold":                     "Sets the factor by which the row count of a materialized CTE must differ from its estimate for the optimizer to re-plan the remainder of the query.",
 	"optimizer_always_use_histograms":                                 "Ensures that the optimizer always uses histograms to calculate statistics if available.",
 	"optimizer_check_input_min_row_count":                             "Sets a lower bound on row count estimates for the buffer scan of foreign key and uniqueness checks.",
 	"optimizer_clamp_inequality_selectivity":                          "Controls whether the optimizer clamps selectivity estimates for inequality predicates to improve cardinality estimation accuracy.",
@@ -179,6 +181,7 @@ var sessionVarDescriptions = map[string]string{
 	"optimizer_use_trigram_similarity_optimization":                   "Controls whether the optimizer should generate improved plans for queries with trigram similarity filters.",
 	"optimizer_use_virtual_computed_column_stats":                     "Controls whether the optimizer should use statistics on virtual computed columns for cardinality estimation.",
 	"override_multi_region_zone_config":                               "Controls whether zone configurations can be modified for multi-region databases and their objects.",
+	"parallelism":                                                     "Sets the maximum number of concurrent partitions into which scans in local plans are split, along with the aggregations and sorts above them. 0 only partitions local scans by leaseholder, and 1 disables the parallelization of local scans.",
 	"parallelize_multi_key_lookup_joins_avg_lookup_ratio":             "Sets the average lookup ratio threshold for parallelizing multi-key lookup joins.",
 	"parallelize_multi_key_lookup_joins_avg_lookup_row_size":          "Sets the average lookup row size threshold for parallelizing multi-key lookup joins.",
 	"parallelize_multi_key_lookup_joins_enabled":                      "Controls whether the join reader should parallelize lookup batches. When enabled, this increases the speed of lookup joins with multiple looked up rows at the cost of increased memory usage.",
@@ -198,6 +201,7 @@ var sessionVarDescriptions = map[string]string{
 	"register_latch_wait_contention_events":                           "Controls whether contention events are registered for latch wait operations.",
 	"reorder_joins_limit":                                             "Sets the number of joins at which the optimizer should stop attempting to reorder.",
 	"require_explicit_primary_keys":                                   "Controls whether CREATE TABLE statements should error out if no primary key is provided.",
+	"result_cache_enabled":                                            "Controls whether the results of read-only statements in implicit transactions are cached and served from the query result cache.",
 	"results_buffer_size":                                             "Specifies the size at which the pgwire results buffer will self-flush.",
 	"role":                                                            "The current role for the session.",
 	"row_security":                                                    "Controls whether row level security is enabled.",
@@ -95,6 +95,9 @@ type InternalExecutorOverride struct {
 	// NewSchemaChangerMode, if set, overrides the use_declarative_schema_changer
 	// session variable.
 	NewSchemaChangerMode *sessiondatapb.NewSchemaChangerMode
+	// AllowMaterializedViewMutation, if true, allows statements to modify
+	// materialized views. It is used to maintain incremental views.
+	AllowMaterializedViewMutation bool
 }
 
 // NoSessionDataOverride is the empty InternalExecutorOverride which does not
@@ -1025,6 +1025,22 @@ func (m *SessionDataMutator) SetOptimizerMinRowCount(val float64) {
 	m.Data.OptimizerMinRowCount = val
 }
 
+func (m *SessionDataMutator) SetOptimizerAdaptiveReoptimization(val bool) {
+	m.Data.OptimizerAdaptiveReoptimization = val
+}
+
+func (m *SessionDataMutator) SetOptimizerAdaptiveReoptimizationThreshold(val float64) {
+	m.Data.OptimizerAdaptiveReoptimizationThreshold = val
+}
+
+func (m *SessionDataMutator) SetResultCacheEnabled(val bool) {
+	m.Data.ResultCacheEnabled = val
+}
+
+func (m *SessionDataMutator) SetParallelism(val int64) {
+	m.Data.Parallelism = val
+}
+
 func (m *SessionDataMutator) SetBufferedWritesEnabled(b bool) {
 	m.Data.BufferedWritesEnabled = b
 	if m.SessionDataMutatorCallbacks.SetBufferedWritesEnabled != nil {
@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/idxtype"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
@@ -159,9 +160,17 @@ func ShowCreateTable(
 		}
 	}
 
+	var columnarIndexes []catalog.Index
 	for _, idx := range desc.PublicNonPrimaryIndexes() {
 		// Showing the primary index is handled above.
 
+		// Columnar indexes can't be defined inside CREATE TABLE, so they are
+		// shown as separate CREATE INDEX statements below.
+		if idx.GetType() == idxtype.COLUMNAR {
+			columnarIndexes = append(columnarIndexes, idx)
+			continue
+		}
+
 		// Build the PARTITION BY clause.
 		var partitionBuf bytes.Buffer
 		if err := ShowCreatePartitioning(
@@ -227,6 +236,26 @@ func ShowCreateTable(
 		}
 	}
 
+	for _, idx := range columnarIndexes {
+		idxStr, err := catformat.IndexForDisplay(
+			ctx,
+			desc,
+			tn,
+			idx,
+			"", /* partition */
+			fmtFlags,
+			p.EvalContext(),
+			p.SemaCtx(),
+			p.SessionData(),
+			catformat.IndexDisplayShowCreate,
+		)
+		if err != nil {
+			return "", err
+		}
+		f.WriteString(";\n")
+		f.WriteString(idxStr)
+	}
+
 	if !displayOptions.IgnoreComments {
 		if err := showComments(tn, desc, selectComment(ctx, p, desc.GetID()), &f.Buffer); err != nil {
 			return "", err
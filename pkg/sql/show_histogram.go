@@ -72,8 +72,11 @@ func (p *planner) ShowHistogram(ctx context.Context, n *tree.ShowHistogram) (pla
 				return nil, err
 			}
 
-			v := p.newContainerValuesNode(showHistogramColumns, len(histogram.Buckets))
 			resolver := descs.NewDistSQLTypeResolver(p.descCollection, p.InternalSQLTxn().KV())
+			if len(histogram.MCVColumnTypes) > 0 {
+				return p.showMultiColumnValues(ctx, histogram, &resolver)
+			}
+			v := p.newContainerValuesNode(showHistogramColumns, len(histogram.Buckets))
 			if err := typedesc.EnsureTypeIsHydrated(ctx, histogram.ColumnType, &resolver); err != nil {
 				return nil, err
 			}
@@ -98,3 +101,34 @@ func (p *planner) ShowHistogram(ctx context.Context, n *tree.ShowHistogram) (pla
 		},
 	}, nil
 }
+
+// showMultiColumnValues returns the most common values of a multi-column
+// statistic as histogram buckets that contain only equal rows. The upper bound
+// of each bucket is the tuple of values.
+func (p *planner) showMultiColumnValues(
+	ctx context.Context, histogram *stats.HistogramData, resolver *descs.DistSQLTypeResolver,
+) (planNode, error) {
+	for _, typ := range histogram.MCVColumnTypes {
+		if err := typedesc.EnsureTypeIsHydrated(ctx, typ, resolver); err != nil {
+			return nil, err
+		}
+	}
+	mcvs, _, err := histogram.DecodeMultiColumnValues()
+	if err != nil {
+		return nil, err
+	}
+	v := p.newContainerValuesNode(showHistogramColumns, len(mcvs))
+	for _, mcv := range mcvs {
+		row := tree.Datums{
+			tree.NewDString(tree.NewDTuple(types.MakeTuple(histogram.MCVColumnTypes), mcv.Values...).String()),
+			tree.NewDInt(0),
+			tree.NewDFloat(0),
+			tree.NewDInt(tree.DInt(int64(mcv.NumEq))),
+		}
+		if _, err := v.rows.AddRow(ctx, row); err != nil {
+			v.Close(ctx)
+			return nil, err
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/hintpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/hints"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
+)
+
+// ShowPlanBaselines returns a SHOW PLAN BASELINES statement.
+func (p *planner) ShowPlanBaselines(
+	ctx context.Context, n *tree.ShowPlanBaselines,
+) (planNode, error) {
+	hasPrivileges, err := p.HasPrivilege(
+		ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.VIEWCLUSTERMETADATA, p.User(),
+	)
+	if err != nil {
+		return nil, err
+	} else if !hasPrivileges {
+		return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must have %s privilege to run `SHOW PLAN BASELINES`",
+			privilege.VIEWCLUSTERMETADATA.DisplayName(),
+		)
+	}
+
+	sqltelemetry.IncrementShowCounter(sqltelemetry.PlanBaselines)
+
+	columns := colinfo.ShowPlanBaselinesColumns
+	return &delayedNode{
+		name:    n.StatementTag(),
+		columns: columns,
+		constructor: func(ctx context.Context, p *planner) (planNode, error) {
+			v := p.newContainerValuesNode(columns, 0)
+			// Plan baselines are identified by the hint_type column, which older
+			// versions don't have.
+			if !p.execCfg.Settings.Version.IsActive(
+				ctx, clusterversion.V26_2_StatementHintsTypeNameEnabledColumnsAdded,
+			) {
+				return v, nil
+			}
+			baselines, err := hints.GetPlanBaselinesFromDB(ctx, p.InternalSQLTxn())
+			if err != nil {
+				v.Close(ctx)
+				return nil, err
+			}
+			for i := range baselines {
+				r := &baselines[i]
+				b := &r.Baseline
+				database, pinHintID := tree.DNull, tree.DNull
+				if r.Database != "" {
+					database = tree.NewDString(r.Database)
+				}
+				if b.PinHintID != 0 {
+					pinHintID = tree.NewDInt(tree.DInt(b.PinHintID))
+				}
+				regressedGist, regressedExecutions, regressedLatency := tree.DNull, tree.DNull, tree.DNull
+				if b.State != hintpb.PlanBaseline_ACCEPTED {
+					regressedGist = tree.NewDString(b.RegressedPlanGist)
+					regressedExecutions = tree.NewDInt(tree.DInt(b.RegressedLatency.Count))
+					regressedLatency = tree.NewDFloat(tree.DFloat(b.RegressedLatency.Mean))
+				}
+				if _, err := v.rows.AddRow(ctx, tree.Datums{
+					tree.NewDInt(tree.DInt(r.HintID)),
+					tree.NewDString(r.Fingerprint),
+					database,
+					tree.NewDString(b.State.String()),
+					tree.NewDString(b.PlanGist),
+					tree.NewDInt(tree.DInt(b.Latency.Count)),
+					tree.NewDFloat(tree.DFloat(b.Latency.Mean)),
+					regressedGist,
+					regressedExecutions,
+					regressedLatency,
+					pinHintID,
+				}); err != nil {
+					v.Close(ctx)
+					return nil, err
+				}
+			}
+			return v, nil
+		},
+	}, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/resourcegroups"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/admission"
+	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
+)
+
+var showResourceGroupsColumns = colinfo.ResultColumns{
+	{Name: "name", Typ: types.String},
+	{Name: "id", Typ: types.Int},
+	{Name: "cpu_weight", Typ: types.Int},
+	{Name: "cpu_limit", Typ: types.Float},
+	{Name: "io_weight", Typ: types.Int},
+	{Name: "io_limit", Typ: types.Float},
+	{Name: "roles", Typ: types.StringArray},
+	{Name: "application_names", Typ: types.StringArray},
+	{Name: "databases", Typ: types.StringArray},
+	// cpu_used is the CPU time, in nanoseconds, that the group was granted on
+	// the gateway node in the current one-second interval, and waiting is the
+	// number of requests of the group queued for CPU on that node. Both are
+	// NULL on SQL servers that don't run a KV node.
+	{Name: "cpu_used", Typ: types.Int},
+	{Name: "waiting", Typ: types.Int},
+}
+
+// ShowResourceGroups returns a SHOW RESOURCE GROUPS statement.
+func (p *planner) ShowResourceGroups(
+	ctx context.Context, n *tree.ShowResourceGroups,
+) (planNode, error) {
+	hasView, err := p.HasGlobalPrivilegeOrRoleOption(ctx, privilege.VIEWCLUSTERSETTING)
+	if err != nil {
+		return nil, err
+	}
+	if !hasView {
+		hasModify, err := p.HasGlobalPrivilegeOrRoleOption(ctx, privilege.MODIFYCLUSTERSETTING)
+		if err != nil {
+			return nil, err
+		}
+		if !hasModify {
+			return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+				"only users with the %s or %s privilege are allowed to run `SHOW RESOURCE GROUPS`",
+				privilege.VIEWCLUSTERSETTING.DisplayName(), privilege.MODIFYCLUSTERSETTING.DisplayName(),
+			)
+		}
+	}
+
+	sqltelemetry.IncrementShowCounter(sqltelemetry.ResourceGroups)
+
+	columns := showResourceGroupsColumns
+	return &delayedNode{
+		name:    n.StatementTag(),
+		columns: columns,
+		constructor: func(ctx context.Context, p *planner) (planNode, error) {
+			v := p.newContainerValuesNode(columns, 0)
+			if !p.execCfg.Settings.Version.IsActive(ctx, clusterversion.V26_3_AddResourceGroupsTable) {
+				return v, nil
+			}
+			groups, err := resourcegroups.Load(ctx, p.InternalSQLTxn())
+			if err != nil {
+				v.Close(ctx)
+				return nil, err
+			}
+			var usage map[admissionpb.ResourceGroupID]admission.ResourceGroupUsage
+			if c := p.execCfg.ResourceGroupsCache; c != nil {
+				usage = c.Usage()
+			}
+			for i := range groups {
+				g := &groups[i]
+				used, waiting := tree.DNull, tree.DNull
+				if usage != nil {
+					u := usage[g.ID]
+					used = tree.NewDInt(tree.DInt(u.Used))
+					waiting = tree.NewDInt(tree.DInt(u.Waiting))
+				}
+				row := append(g.Datums(), used, waiting)
+				if _, err := v.rows.AddRow(ctx, row); err != nil {
+					v.Close(ctx)
+					return nil, err
+				}
+			}
+			return v, nil
+		},
+	}, nil
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tenantstoragequota"
 	"github.com/cockroachdb/cockroach/pkg/multitenant/mtinfopb"
 	"github.com/cockroachdb/cockroach/pkg/multitenant/tenantcapabilities"
 	"github.com/cockroachdb/cockroach/pkg/multitenant/tenantcapabilitiespb"
@@ -33,6 +34,9 @@ type tenantValues struct {
 	replicationInfo    *streampb.StreamIngestionStats
 	protectedTimestamp hlc.Timestamp
 	capabilities       []showTenantNodeCapability
+	// storageUsage is only set if the tenant's storage usage is tracked, i.e.
+	// if it has a storage quota.
+	storageUsage *tenantstoragequota.Usage
 }
 
 type showTenantNodeCapability struct {
@@ -46,6 +50,7 @@ type showTenantNode struct {
 	withReplication      bool
 	withPriorReplication bool
 	withCapabilities     bool
+	withStorageStatus    bool
 	columns              colinfo.ResultColumns
 	tenantIDIndex        int
 	tenantIds            []roachpb.TenantID
@@ -75,6 +80,7 @@ func (p *planner) ShowTenant(ctx context.Context, n *tree.ShowTenant) (planNode,
 		withReplication:      n.WithReplication,
 		withPriorReplication: n.WithPriorReplication,
 		withCapabilities:     n.WithCapabilities,
+		withStorageStatus:    n.WithStorageStatus,
 		initTenantValues:     true,
 	}
 
@@ -90,6 +96,9 @@ func (p *planner) ShowTenant(ctx context.Context, n *tree.ShowTenant) (planNode,
 	if n.WithCapabilities {
 		node.columns = append(node.columns, colinfo.TenantColumnsWithCapabilities...)
 	}
+	if n.WithStorageStatus {
+		node.columns = append(node.columns, colinfo.TenantColumnsWithStorageStatus...)
+	}
 
 	return node, nil
 }
@@ -136,6 +145,16 @@ func (n *showTenantNode) getTenantValues(
 		values.capabilities = showTenantNodeCapabilities
 	}
 
+	// Add storage usage if requested. This is the usage that the storage quota
+	// is enforced against, so it is only known for tenants with a quota, and
+	// only as of the last refresh of the usage.
+	if n.withStorageStatus {
+		tenID := roachpb.MustMakeTenantID(tenantInfo.ID)
+		if usage, found := params.p.ExecCfg().TenantStorageQuota.GetUsage(tenID); found {
+			values.storageUsage = &usage
+		}
+	}
+
 	// Tenant status + replication status fields.
 	jobId := tenantInfo.PhysicalReplicationConsumerJobID
 	if jobId == 0 {
@@ -296,6 +315,18 @@ func (n *showTenantNode) Values() tree.Datums {
 		)
 	}
 
+	if n.withStorageStatus {
+		logicalBytes := tree.DNull
+		if v.storageUsage != nil {
+			logicalBytes = tree.NewDInt(tree.DInt(v.storageUsage.LogicalBytes))
+		}
+		storageQuota := tree.DNull
+		if quota := tenantInfo.Capabilities.StorageQuotaBytes; quota > 0 {
+			storageQuota = tree.NewDInt(tree.DInt(quota))
+		}
+		result = append(result, logicalBytes, storageQuota)
+	}
+
 	return result
 }
 
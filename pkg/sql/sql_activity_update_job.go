@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/hints"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
@@ -119,6 +120,11 @@ func (j *sqlActivityUpdateJob) Resume(ctx context.Context, execCtxI interface{})
 				}
 				metrics.UpdateLatency.RecordValue(timeutil.Now().UnixNano() - startTime)
 			}
+			if hints.PlanBaselinesEnabled.Get(&settings.SV) {
+				if err := updatePlanBaselines(ctx, execCtx.ExecCfg()); err != nil {
+					log.Dev.Warningf(ctx, "error updating plan baselines: %v", err)
+				}
+			}
 		case <-ctx.Done():
 			return nil
 		case <-stopper.ShouldQuiesce():
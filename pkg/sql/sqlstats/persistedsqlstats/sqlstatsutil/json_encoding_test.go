@@ -54,6 +54,9 @@ func TestSQLStatsJsonEncoding(t *testing.T) {
          "failureCount":    {{.Int64}},
          "genericCount":    {{.Int64}},
          "stmtHintsCount":  {{.Int64}},
+         "reoptimizedCount": {{.Int64}},
+         "resultCacheHitCount": {{.Int64}},
+         "resultCacheMissCount": {{.Int64}},
          "maxRetries":      {{.Int64}},
          "lastExecAt":      "{{stringifyTime .Time}}",
          "numRows": {
@@ -133,6 +136,28 @@ func TestSQLStatsJsonEncoding(t *testing.T) {
 				     "mean": {{.Float}},
 				     "sqDiff": {{.Float}}
 				   }
+				 },
+				 "writeBufferStats": {
+				   "count": {{.Int64}},
+				   "bufferedWrites": {
+				     "mean": {{.Float}},
+				     "sqDiff": {{.Float}}
+				   },
+				   "flushedWrites": {
+				     "mean": {{.Float}},
+				     "sqDiff": {{.Float}}
+				   },
+				   "readHits": {
+				     "mean": {{.Float}},
+				     "sqDiff": {{.Float}}
+				   },
+				   "bufferedBytes": {
+				     "mean": {{.Float}},
+				     "sqDiff": {{.Float}}
+				   },
+				   "memoryLimitFlushCount": {{.Int64}},
+				   "unsupportedRequestFlushCount": {{.Int64}},
+				   "disabledFlushCount": {{.Int64}}
 				 }
        },
        "execution_statistics": {
@@ -276,6 +301,9 @@ func TestSQLStatsJsonEncoding(t *testing.T) {
 		stableStats, err := statsField.FetchValKey("stableStats")
 		require.NoError(t, err)
 		require.Nil(t, stableStats, "stableStats should be omitted when zero")
+		writeBufferStats, err := statsField.FetchValKey("writeBufferStats")
+		require.NoError(t, err)
+		require.Nil(t, writeBufferStats, "writeBufferStats should be omitted when zero")
 
 		// Decode and verify roundtrip produces zero values.
 		var decoded appstatspb.StatementStatistics
@@ -283,6 +311,7 @@ func TestSQLStatsJsonEncoding(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, appstatspb.ExperimentStatsInfo{}, decoded.CanaryStats)
 		require.Equal(t, appstatspb.ExperimentStatsInfo{}, decoded.StableStats)
+		require.Equal(t, appstatspb.WriteBufferStatistics{}, decoded.WriteBufferStats)
 	})
 
 	// Verify that when CanaryStats and StableStats have non-zero counts, the
@@ -578,6 +607,28 @@ func TestSQLStatsJsonEncoding(t *testing.T) {
     "kvCPUTimeNanos": {
       "mean": {{.Float}},
       "sqDiff": {{.Float}}
+    },
+    "writeBufferStats": {
+      "count": {{.Int64}},
+      "bufferedWrites": {
+        "mean": {{.Float}},
+        "sqDiff": {{.Float}}
+      },
+      "flushedWrites": {
+        "mean": {{.Float}},
+        "sqDiff": {{.Float}}
+      },
+      "readHits": {
+        "mean": {{.Float}},
+        "sqDiff": {{.Float}}
+      },
+      "bufferedBytes": {
+        "mean": {{.Float}},
+        "sqDiff": {{.Float}}
+      },
+      "memoryLimitFlushCount": {{.Int64}},
+      "unsupportedRequestFlushCount": {{.Int64}},
+      "disabledFlushCount": {{.Int64}}
     }
   },
   "execution_statistics": {
@@ -48,6 +48,7 @@ var (
 	_ jsonMarshaler = (*int32Array)(nil)
 	_ jsonMarshaler = &latencyInfo{}
 	_ jsonMarshaler = &experimentStatsInfo{}
+	_ jsonMarshaler = &writeBufferStats{}
 )
 
 type txnStats appstatspb.TransactionStatistics
@@ -307,12 +308,27 @@ func (t *innerTxnStats) jsonFields() jsonFields {
 	}
 }
 
+// writeBufferJsonFields returns the JSON fields for write buffering stats,
+// which are only encoded for transactions which used the write buffer.
+func (t *innerTxnStats) writeBufferJsonFields() jsonFields {
+	return jsonFields{
+		{"writeBufferStats", (*writeBufferStats)(&t.WriteBufferStats)},
+	}
+}
+
 func (t *innerTxnStats) decodeJSON(js json.JSON) error {
-	return t.jsonFields().decodeJSON(js)
+	if err := t.jsonFields().decodeJSON(js); err != nil {
+		return err
+	}
+	return t.writeBufferJsonFields().decodeJSON(js)
 }
 
 func (t *innerTxnStats) encodeJSON() (json.JSON, error) {
-	return t.jsonFields().encodeJSON()
+	fields := t.jsonFields()
+	if t.WriteBufferStats.Count > 0 {
+		fields = append(fields, t.writeBufferJsonFields()...)
+	}
+	return fields.encodeJSON()
 }
 
 type innerStmtStats appstatspb.StatementStatistics
@@ -345,6 +361,9 @@ func (s *innerStmtStats) jsonFields() jsonFields {
 		{"failureCount", (*jsonInt)(&s.FailureCount)},
 		{"genericCount", (*jsonInt)(&s.GenericCount)},
 		{"stmtHintsCount", (*jsonInt)(&s.StmtHintsCount)},
+		{"reoptimizedCount", (*jsonInt)(&s.ReoptimizedCount)},
+		{"resultCacheHitCount", (*jsonInt)(&s.ResultCacheHitCount)},
+		{"resultCacheMissCount", (*jsonInt)(&s.ResultCacheMissCount)},
 		{"sqlType", (*jsonString)(&s.SQLType)},
 	}
 }
@@ -368,6 +387,15 @@ func (s *innerStmtStats) stableJsonFields() jsonFields {
 	}
 }
 
+// writeBufferJsonFields returns the JSON fields for write buffering stats.
+// Like canaryJsonFields, these are conditionally encoded, only for statements
+// which used the transaction write buffer.
+func (s *innerStmtStats) writeBufferJsonFields() jsonFields {
+	return jsonFields{
+		{"writeBufferStats", (*writeBufferStats)(&s.WriteBufferStats)},
+	}
+}
+
 func (s *innerStmtStats) decodeJSON(js json.JSON) error {
 	if err := s.jsonFields().decodeJSON(js); err != nil {
 		return err
@@ -378,7 +406,10 @@ func (s *innerStmtStats) decodeJSON(js json.JSON) error {
 	if err := s.canaryJsonFields().decodeJSON(js); err != nil {
 		return err
 	}
-	return s.stableJsonFields().decodeJSON(js)
+	if err := s.stableJsonFields().decodeJSON(js); err != nil {
+		return err
+	}
+	return s.writeBufferJsonFields().decodeJSON(js)
 }
 
 func (s *innerStmtStats) encodeJSON() (json.JSON, error) {
@@ -393,6 +424,9 @@ func (s *innerStmtStats) encodeJSON() (json.JSON, error) {
 	if s.StableStats.Count > 0 {
 		fields = append(fields, s.stableJsonFields()...)
 	}
+	if s.WriteBufferStats.Count > 0 {
+		fields = append(fields, s.writeBufferJsonFields()...)
+	}
 	return fields.encodeJSON()
 }
 
@@ -500,6 +534,29 @@ func (e *experimentStatsInfo) encodeJSON() (json.JSON, error) {
 	return e.jsonFields().encodeJSON()
 }
 
+type writeBufferStats appstatspb.WriteBufferStatistics
+
+func (w *writeBufferStats) jsonFields() jsonFields {
+	return jsonFields{
+		{"count", (*jsonInt)(&w.Count)},
+		{"bufferedWrites", (*numericStats)(&w.BufferedWrites)},
+		{"flushedWrites", (*numericStats)(&w.FlushedWrites)},
+		{"readHits", (*numericStats)(&w.ReadHits)},
+		{"bufferedBytes", (*numericStats)(&w.BufferedBytes)},
+		{"memoryLimitFlushCount", (*jsonInt)(&w.MemoryLimitFlushCount)},
+		{"unsupportedRequestFlushCount", (*jsonInt)(&w.UnsupportedRequestFlushCount)},
+		{"disabledFlushCount", (*jsonInt)(&w.DisabledFlushCount)},
+	}
+}
+
+func (w *writeBufferStats) decodeJSON(js json.JSON) error {
+	return w.jsonFields().decodeJSON(js)
+}
+
+func (w *writeBufferStats) encodeJSON() (json.JSON, error) {
+	return w.jsonFields().encodeJSON()
+}
+
 type jsonFields []jsonField
 
 func (jf jsonFields) decodeJSON(js json.JSON) (err error) {
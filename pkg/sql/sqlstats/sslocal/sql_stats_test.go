@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
@@ -2041,3 +2042,62 @@ func populateSqlStats(t testing.TB, sqlStats *sslocal.SQLStats, expectedCountSta
 	err = sqlStats.AddAppStats(context.Background(), "app", txnContainer)
 	require.NoError(t, err)
 }
+
+// TestTransactionWriteBufferStats verifies that the transaction-level stats
+// include the write buffer flush performed by an explicit COMMIT, which is not
+// attributed to any statement.
+func TestTransactionWriteBufferStats(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	const appName = "write_buffer_stats"
+	var mu struct {
+		syncutil.Mutex
+		txnStats *sqlstats.RecordedTxnStats
+	}
+	var params base.TestServerArgs
+	params.Knobs.SQLExecutor = &sql.ExecutorTestingKnobs{
+		OnRecordTxnFinish: func(isInternal bool, _ *sessionphase.Times, stmt string, txnStats *sqlstats.RecordedTxnStats) {
+			if isInternal || txnStats.Application != appName {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			mu.txnStats = txnStats
+		},
+	}
+	s := serverutils.StartServerOnly(t, params)
+	defer s.Stopper().Stop(ctx)
+
+	conn, err := s.SQLConn(t).Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+	db := sqlutils.MakeSQLRunner(conn)
+	db.Exec(t, "CREATE TABLE t (k INT PRIMARY KEY, v INT)")
+	db.Exec(t, "SET kv_transaction_buffered_writes_enabled = true")
+	db.Exec(t, "SET application_name = $1", appName)
+
+	db.Exec(t, "BEGIN")
+	db.Exec(t, "INSERT INTO t VALUES (1, 1)")
+	db.Exec(t, "INSERT INTO t VALUES (2, 2)")
+	db.Exec(t, "COMMIT")
+
+	mu.Lock()
+	txnStats := mu.txnStats
+	mu.Unlock()
+	require.NotNil(t, txnStats)
+	wb := txnStats.WriteBufferStats
+	require.Equal(t, int64(2), wb.BufferedWrites)
+	require.Equal(t, int64(2), wb.FlushedWrites)
+	require.Equal(t, kv.WriteBufferFlushedOnCommit, wb.FlushReason)
+
+	// The flush on COMMIT is reflected in the aggregated transaction stats.
+	db.CheckQueryResults(t, `
+SELECT (statistics->'statistics'->'writeBufferStats'->>'count')::INT,
+       (statistics->'statistics'->'writeBufferStats'->'flushedWrites'->>'mean')::FLOAT
+  FROM crdb_internal.transaction_statistics
+ WHERE app_name = '`+appName+`'
+   AND statistics->'statistics'->'writeBufferStats' IS NOT NULL`, [][]string{{"1", "2"}},
+	)
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 	"unsafe"
 
+	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
@@ -85,6 +86,15 @@ func (s *Container) RecordStatement(ctx context.Context, value *sqlstats.Recorde
 	if value.AppliedStmtHints {
 		stats.mu.data.StmtHintsCount++
 	}
+	if value.Reoptimized {
+		stats.mu.data.ReoptimizedCount++
+	}
+	switch value.ResultCache {
+	case sqlstats.ResultCacheHit:
+		stats.mu.data.ResultCacheHitCount++
+	case sqlstats.ResultCacheMiss:
+		stats.mu.data.ResultCacheMissCount++
+	}
 	// Track canary and stable stats separately: these latencies use their own
 	// counts (not the overall Count) for Welford's running average, since they
 	// represent only the subsets of executions that participated in the canary
@@ -100,6 +110,9 @@ func (s *Container) RecordStatement(ctx context.Context, value *sqlstats.Recorde
 		stats.mu.data.StableStats.RunLat.Record(stats.mu.data.StableStats.Count, value.RunLatencySec)
 		stats.mu.data.StableStats.PlanLat.Record(stats.mu.data.StableStats.Count, value.PlanLatencySec)
 	}
+	// Like the canary stats, write buffering stats use their own count, since
+	// they only cover executions which used the transaction write buffer.
+	recordWriteBufferStats(&stats.mu.data.WriteBufferStats, value.WriteBufferStats)
 	if value.AutoRetryCount == 0 {
 		stats.mu.data.FirstAttemptCount++
 	} else if int64(value.AutoRetryCount) > stats.mu.data.MaxRetries {
@@ -284,10 +297,32 @@ func (s *Container) RecordTransaction(ctx context.Context, value *sqlstats.Recor
 		stats.mu.data.ExecStats.MVCCIteratorStats.RangeKeyContainedPoints.Record(stats.mu.data.ExecStats.Count, float64(value.ExecStats.MvccRangeKeyContainedPoints))
 		stats.mu.data.ExecStats.MVCCIteratorStats.RangeKeySkippedPoints.Record(stats.mu.data.ExecStats.Count, float64(value.ExecStats.MvccRangeKeySkippedPoints))
 	}
+	recordWriteBufferStats(&stats.mu.data.WriteBufferStats, value.WriteBufferStats)
 
 	return nil
 }
 
+// recordWriteBufferStats records an execution's use of the transaction write
+// buffer, if it used the buffer at all.
+func recordWriteBufferStats(data *appstatspb.WriteBufferStatistics, wb kv.WriteBufferStats) {
+	if wb.Empty() {
+		return
+	}
+	data.Count++
+	data.BufferedWrites.Record(data.Count, float64(wb.BufferedWrites))
+	data.FlushedWrites.Record(data.Count, float64(wb.FlushedWrites))
+	data.ReadHits.Record(data.Count, float64(wb.ReadHits))
+	data.BufferedBytes.Record(data.Count, float64(wb.BufferedBytes))
+	switch wb.FlushReason {
+	case kv.WriteBufferFlushedMemoryLimit:
+		data.MemoryLimitFlushCount++
+	case kv.WriteBufferFlushedUnsupportedRequest:
+		data.UnsupportedRequestFlushCount++
+	case kv.WriteBufferFlushedDisabled:
+		data.DisabledFlushCount++
+	}
+}
+
 func (s *Container) recordTransactionHighLevelStats(
 	transactionTimeSec float64, committed bool, implicit bool,
 ) {
@@ -13,6 +13,7 @@ import (
 	"time"
 	"unsafe"
 
+	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
@@ -58,6 +59,20 @@ type TransactionVisitor func(context.Context, *appstatspb.CollectedTransactionSt
 // the visitor, the iteration is aborted.
 type AggregatedTransactionVisitor func(appName string, statistics *appstatspb.TxnStats) error
 
+// ResultCacheOutcome describes how an execution of a statement used the query
+// result cache.
+type ResultCacheOutcome int8
+
+const (
+	// ResultCacheNotUsed means the statement was not eligible for the cache.
+	ResultCacheNotUsed ResultCacheOutcome = iota
+	// ResultCacheHit means the results were served from the cache.
+	ResultCacheHit
+	// ResultCacheMiss means the statement was eligible for the cache, but its
+	// results were not cached and it had to be executed.
+	ResultCacheMiss
+)
+
 // RecordedStmtStats stores the statistics of a statement to be recorded.
 type RecordedStmtStats struct {
 	FingerprintID            appstatspb.StmtFingerprintID
@@ -76,6 +91,8 @@ type RecordedStmtStats struct {
 	Failed                   bool
 	Generic                  bool
 	AppliedStmtHints         bool
+	Reoptimized              bool
+	ResultCache              ResultCacheOutcome
 	AutoRetryReason          error
 	RowsAffected             int
 	IdleLatencySec           float64
@@ -102,6 +119,7 @@ type RecordedStmtStats struct {
 	QueryTags                []sqlcommenter.QueryTag
 	UnderOuterTxn            bool
 	StatsRollout             eval.StatsRolloutSelection
+	WriteBufferStats         kv.WriteBufferStats
 
 	// AggregatedTs and AggInterval, when non-zero, override the Container's
 	// own computation so that all statements in a transaction share the same
@@ -176,6 +194,7 @@ type RecordedTxnStats struct {
 	RowsWritten             int64
 	BytesRead               int64
 	KVCPUTimeNanos          time.Duration
+	WriteBufferStats        kv.WriteBufferStats
 	Priority                roachpb.UserPriority
 	TxnErr                  error
 	Application             string
@@ -425,6 +444,24 @@ func (b *RecordedStatementStatsBuilder) AppliedStatementHints() *RecordedStateme
 	return b
 }
 
+func (b *RecordedStatementStatsBuilder) Reoptimized() *RecordedStatementStatsBuilder {
+	if b == nil {
+		return b
+	}
+	b.stmtStats.Reoptimized = true
+	return b
+}
+
+func (b *RecordedStatementStatsBuilder) ResultCache(
+	outcome ResultCacheOutcome,
+) *RecordedStatementStatsBuilder {
+	if b == nil {
+		return b
+	}
+	b.stmtStats.ResultCache = outcome
+	return b
+}
+
 func (b *RecordedStatementStatsBuilder) CanaryStatsRollout(
 	sel eval.StatsRolloutSelection,
 ) *RecordedStatementStatsBuilder {
@@ -435,6 +472,16 @@ func (b *RecordedStatementStatsBuilder) CanaryStatsRollout(
 	return b
 }
 
+func (b *RecordedStatementStatsBuilder) WriteBufferStats(
+	stats kv.WriteBufferStats,
+) *RecordedStatementStatsBuilder {
+	if b == nil {
+		return b
+	}
+	b.stmtStats.WriteBufferStats = stats
+	return b
+}
+
 // Build returns the final RecordedStmtStats struct. It returns nil if not all
 // required fields have been set or if the builder itself is nil. In test
 // builds, it panics if not all required fields have been set.
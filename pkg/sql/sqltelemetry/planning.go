@@ -126,6 +126,10 @@ var ExplainGist = telemetry.GetCounterOnce("sql.plan.explain-gist")
 // EXPLAIN (FINGERPRINT) is run.
 var ExplainFingerprint = telemetry.GetCounterOnce("sql.plan.explain-fingerprint")
 
+// ExplainHypotheticalIndexes is to be incremented whenever
+// EXPLAIN (HYPOTHETICAL INDEXES ...) is run.
+var ExplainHypotheticalIndexes = telemetry.GetCounterOnce("sql.plan.explain-hypothetical-indexes")
+
 // CreateStatisticsUseCounter is to be incremented whenever a non-automatic
 // run of CREATE STATISTICS occurs.
 var CreateStatisticsUseCounter = telemetry.GetCounterOnce("sql.plan.stats.created")
@@ -65,6 +65,10 @@ const (
 	InspectErrors
 	// StatementHints represents the SHOW STATEMENT HINTS command.
 	StatementHints
+	// PlanBaselines represents the SHOW PLAN BASELINES command.
+	PlanBaselines
+	// ResourceGroups represents the SHOW RESOURCE GROUPS command.
+	ResourceGroups
 )
 
 var showTelemetryNameMap = map[ShowTelemetryType]string{
@@ -92,6 +96,8 @@ var showTelemetryNameMap = map[ShowTelemetryType]string{
 	LogicalReplicationJobs:   "logical_replication_jobs",
 	InspectErrors:            "inspect_errors",
 	StatementHints:           "statement_hints",
+	PlanBaselines:            "plan_baselines",
+	ResourceGroups:           "resource_groups",
 }
 
 func (s ShowTelemetryType) String() string {
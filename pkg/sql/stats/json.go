@@ -13,6 +13,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -41,6 +42,13 @@ type JSONStatistic struct {
 	PartialPredicate    string            `json:"partial_predicate,omitempty"`
 	FullStatisticID     uint64            `json:"full_statistic_id,omitempty"`
 	DelayDelete         bool              `json:"delay_delete"`
+	// MCVColumnTypes, MostCommonValues, and Dependencies are only set for
+	// multi-column statistics. MCVColumnTypes contains the string
+	// representations of the column types, parsable with
+	// tree.GetTypeFromValidSQLSyntax.
+	MCVColumnTypes   []string              `json:"mcv_col_types,omitempty"`
+	MostCommonValues []JSONMostCommonValue `json:"mcvs,omitempty"`
+	Dependencies     []JSONDependency      `json:"dependencies,omitempty"`
 }
 
 // JSONHistoBucket is a struct used for JSON marshaling and unmarshaling of
@@ -56,8 +64,33 @@ type JSONHistoBucket struct {
 	UpperBound string `json:"upper_bound"`
 }
 
-// SetHistogram fills in the HistogramColumnType and HistogramBuckets fields.
+// JSONMostCommonValue is a struct used for JSON marshaling and unmarshaling
+// of the most common values of multi-column statistics.
+//
+// See HistogramData for a description of the fields.
+type JSONMostCommonValue struct {
+	NumEq int64 `json:"num_eq"`
+	// Values are the string representations of the datums of the tuple;
+	// parsable with sqlbase.ParseDatumStringAs.
+	Values []string `json:"values"`
+}
+
+// JSONDependency is a struct used for JSON marshaling and unmarshaling of the
+// functional dependency degrees of multi-column statistics.
+//
+// See HistogramData for a description of the fields.
+type JSONDependency struct {
+	FromColumn uint32  `json:"from"`
+	ToColumn   uint32  `json:"to"`
+	Degree     float64 `json:"degree"`
+}
+
+// SetHistogram fills in the HistogramColumnType and HistogramBuckets fields,
+// or the most common values and dependencies of a multi-column statistic.
 func (js *JSONStatistic) SetHistogram(ctx context.Context, h *HistogramData) error {
+	if len(h.MCVColumnTypes) > 0 {
+		return js.setMultiColumnValues(h)
+	}
 	typ := h.ColumnType
 	if typ == nil {
 		return fmt.Errorf("histogram type is unset")
@@ -86,6 +119,37 @@ func (js *JSONStatistic) SetHistogram(ctx context.Context, h *HistogramData) err
 	return nil
 }
 
+// setMultiColumnValues fills in the MCVColumnTypes, MostCommonValues, and
+// Dependencies fields.
+func (js *JSONStatistic) setMultiColumnValues(h *HistogramData) error {
+	mcvs, deps, err := h.DecodeMultiColumnValues()
+	if err != nil {
+		return err
+	}
+	js.HistogramVersion = h.Version
+	js.MCVColumnTypes = make([]string, len(h.MCVColumnTypes))
+	for i, typ := range h.MCVColumnTypes {
+		js.MCVColumnTypes[i] = typ.SQLStringFullyQualified()
+	}
+	js.MostCommonValues = make([]JSONMostCommonValue, len(mcvs))
+	for i := range mcvs {
+		values := make([]string, len(mcvs[i].Values))
+		for j, d := range mcvs[i].Values {
+			values[j] = tree.AsStringWithFlags(d, tree.FmtExport|tree.FmtAlwaysQualifyUserDefinedTypeNames)
+		}
+		js.MostCommonValues[i] = JSONMostCommonValue{NumEq: int64(mcvs[i].NumEq), Values: values}
+	}
+	js.Dependencies = make([]JSONDependency, len(deps))
+	for i := range deps {
+		js.Dependencies[i] = JSONDependency{
+			FromColumn: uint32(deps[i].From),
+			ToColumn:   uint32(deps[i].To),
+			Degree:     deps[i].Degree,
+		}
+	}
+	return nil
+}
+
 // DecodeAndSetHistogram decodes a histogram marshaled as a Bytes datum and
 // fills in the JSONStatistic histogram fields.
 func (js *JSONStatistic) DecodeAndSetHistogram(
@@ -105,18 +169,26 @@ func (js *JSONStatistic) DecodeAndSetHistogram(
 	if err := protoutil.Unmarshal([]byte(*datum.(*tree.DBytes)), h); err != nil {
 		return err
 	}
-	// If the serialized column type is user defined, then it needs to be
+	// If the serialized column types are user defined, then they need to be
 	// hydrated before use.
-	if h.ColumnType.UserDefined() {
+	hydrate := func(typ *types.T) (*types.T, error) {
+		if typ == nil || !typ.UserDefined() {
+			return typ, nil
+		}
 		resolver := semaCtx.GetTypeResolver()
 		if resolver == nil {
-			return errors.AssertionFailedf("attempt to resolve user defined type with nil TypeResolver")
+			return nil, errors.AssertionFailedf("attempt to resolve user defined type with nil TypeResolver")
 		}
-		typ, err := resolver.ResolveTypeByOID(ctx, h.ColumnType.Oid())
-		if err != nil {
+		return resolver.ResolveTypeByOID(ctx, typ.Oid())
+	}
+	var err error
+	if h.ColumnType, err = hydrate(h.ColumnType); err != nil {
+		return err
+	}
+	for i := range h.MCVColumnTypes {
+		if h.MCVColumnTypes[i], err = hydrate(h.MCVColumnTypes[i]); err != nil {
 			return err
 		}
-		h.ColumnType = typ
 	}
 	return js.SetHistogram(ctx, h)
 }
@@ -126,6 +198,9 @@ func (js *JSONStatistic) GetHistogram(
 	ctx context.Context, semaCtx *tree.SemaContext, evalCtx *eval.Context,
 ) (*HistogramData, error) {
 	if js.HistogramColumnType == "" {
+		if len(js.MCVColumnTypes) > 0 {
+			return js.getMultiColumnValues(ctx, semaCtx, evalCtx)
+		}
 		return nil, nil
 	}
 	h := &HistogramData{}
@@ -157,6 +232,65 @@ func (js *JSONStatistic) GetHistogram(
 	return h, nil
 }
 
+// getMultiColumnValues converts the json most common values and dependencies
+// of a multi-column statistic into HistogramData.
+func (js *JSONStatistic) getMultiColumnValues(
+	ctx context.Context, semaCtx *tree.SemaContext, evalCtx *eval.Context,
+) (*HistogramData, error) {
+	if len(js.MCVColumnTypes) != len(js.Columns) {
+		return nil, errors.Newf(
+			"expected %d most common value column types, found %d", len(js.Columns), len(js.MCVColumnTypes),
+		)
+	}
+	h := &HistogramData{
+		Version:        js.HistogramVersion,
+		MCVColumnTypes: make([]*types.T, len(js.MCVColumnTypes)),
+	}
+	for i, typStr := range js.MCVColumnTypes {
+		colTypeRef, err := parser.GetTypeFromValidSQLSyntax(typStr)
+		if err != nil {
+			return nil, err
+		}
+		if h.MCVColumnTypes[i], err = tree.ResolveType(ctx, colTypeRef, semaCtx.GetTypeResolver()); err != nil {
+			return nil, err
+		}
+	}
+	h.MostCommonValues = make([]HistogramData_MostCommonValue, len(js.MostCommonValues))
+	for i := range js.MostCommonValues {
+		mcv := &js.MostCommonValues[i]
+		if len(mcv.Values) != len(h.MCVColumnTypes) {
+			return nil, errors.Newf(
+				"expected %d values in most common value, found %d", len(h.MCVColumnTypes), len(mcv.Values),
+			)
+		}
+		var encoded []byte
+		for j, typ := range h.MCVColumnTypes {
+			val, err := rowenc.ParseDatumStringAs(ctx, typ, mcv.Values[j], evalCtx, semaCtx)
+			if err != nil {
+				return nil, err
+			}
+			if encoded, err = valueside.Encode(encoded, valueside.NoColumnID, val); err != nil {
+				return nil, err
+			}
+		}
+		h.MostCommonValues[i].NumEq = mcv.NumEq
+		h.MostCommonValues[i].Values = encoded
+	}
+	h.Dependencies = make([]HistogramData_Dependency, len(js.Dependencies))
+	for i := range js.Dependencies {
+		dep := &js.Dependencies[i]
+		if int(dep.FromColumn) >= len(js.Columns) || int(dep.ToColumn) >= len(js.Columns) {
+			return nil, errors.Newf("invalid dependency from column %d to column %d", dep.FromColumn, dep.ToColumn)
+		}
+		h.Dependencies[i] = HistogramData_Dependency{
+			FromColumn: dep.FromColumn,
+			ToColumn:   dep.ToColumn,
+			Degree:     dep.Degree,
+		}
+	}
+	return h, nil
+}
+
 // IsPartial returns true if this statistic was collected with USING EXTREMES
 // or with a WHERE clause.
 func (js *JSONStatistic) IsPartial() bool {
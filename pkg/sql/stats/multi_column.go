@@ -0,0 +1,262 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package stats
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/errors"
+)
+
+// MultiColumnMCVsMaxCount is the maximum number of most common values that
+// are collected for each multi-column statistic. Collection of most common
+// values and functional dependency degrees for multi-column statistics is
+// disabled when it is zero.
+//
+// It is disabled by default for two reasons. Automatic statistics collect a
+// multi-column statistic for every index prefix, so enabling it changes the
+// selectivity estimates, and potentially the plans, of existing queries on any
+// table with a multi-column index. It also makes the samplers keep every column
+// of every multi-column statistic in their sampled rows rather than only the
+// histogram columns, which increases the memory used by statistics collection.
+//
+// Multi-column histograms, i.e. buckets with tuple bounds that would also
+// cover range predicates on the trailing columns, are not collected: the most
+// common values and dependency degrees only refine the selectivity of
+// equality conjunctions.
+var MultiColumnMCVsMaxCount = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"sql.stats.multi_column_most_common_values.max_count",
+	"maximum number of most common value tuples collected for each multi-column statistic, "+
+		"along with the degrees of functional dependencies between its columns; 0 disables collection",
+	0,
+	settings.NonNegativeIntWithMaximum(10000),
+)
+
+// BuildMultiColumnValues computes the most common values and the functional
+// dependency degrees of the columns of a multi-column statistic from a sample
+// of rows. colIdxs are the indexes of the columns of the statistic in the
+// sampled rows, which must already be decoded, and colTypes are their types.
+// numRows is the total number of rows from which the rows were sampled.
+//
+// A tuple is one of the most common values if it appears at least twice in the
+// sample and more often than the average tuple. At most maxValues tuples are
+// kept. The degree of the dependency from column a to column b is the fraction
+// of sampled rows for which all sampled rows with the same value of a have the
+// same value of b, as with Postgres' extended statistics.
+func BuildMultiColumnValues(
+	ctx context.Context,
+	memAcc *mon.BoundAccount,
+	samples []SampledRow,
+	colIdxs []int,
+	colTypes []*types.T,
+	numRows int64,
+	maxValues int,
+) (HistogramData, error) {
+	h := HistogramData{Version: HistVersion, MCVColumnTypes: colTypes}
+	if len(samples) == 0 {
+		return h, nil
+	}
+
+	// Value-encode every column of every sampled row, so that tuples and
+	// values can be grouped by their encodings. NULLs are left empty.
+	encoded := make([][]string, len(samples))
+	var buf []byte
+	for i := range samples {
+		encoded[i] = make([]string, len(colIdxs))
+		for j, colIdx := range colIdxs {
+			d := samples[i].Row[colIdx].Datum
+			if d == nil {
+				return HistogramData{}, errors.AssertionFailedf("value in column %d not decoded", colIdx)
+			}
+			if d == tree.DNull {
+				continue
+			}
+			var err error
+			buf, err = valueside.Encode(buf[:0], valueside.NoColumnID, d)
+			if err != nil {
+				return HistogramData{}, err
+			}
+			encoded[i][j] = string(buf)
+			if err := memAcc.Grow(ctx, int64(len(buf))); err != nil {
+				return HistogramData{}, err
+			}
+		}
+	}
+
+	// Find the most common tuples.
+	counts := make(map[string]int64)
+	var nonNullSamples int64
+	for i := range encoded {
+		var key []byte
+		for _, v := range encoded[i] {
+			if v == "" {
+				key = nil
+				break
+			}
+			key = append(key, v...)
+		}
+		if key != nil {
+			counts[string(key)]++
+			nonNullSamples++
+		}
+	}
+	if len(counts) > 0 {
+		avgCount := float64(nonNullSamples) / float64(len(counts))
+		for key, count := range counts {
+			if count >= 2 && float64(count) > avgCount {
+				h.MostCommonValues = append(h.MostCommonValues, HistogramData_MostCommonValue{
+					NumEq:  count,
+					Values: []byte(key),
+				})
+			}
+		}
+	}
+	sort.Slice(h.MostCommonValues, func(i, j int) bool {
+		a, b := &h.MostCommonValues[i], &h.MostCommonValues[j]
+		if a.NumEq != b.NumEq {
+			return a.NumEq > b.NumEq
+		}
+		return string(a.Values) < string(b.Values)
+	})
+	if len(h.MostCommonValues) > maxValues {
+		h.MostCommonValues = h.MostCommonValues[:maxValues]
+	}
+	// Scale the counts from the sample to the table.
+	scale := float64(numRows) / float64(len(samples))
+	for i := range h.MostCommonValues {
+		h.MostCommonValues[i].NumEq = int64(math.Round(float64(h.MostCommonValues[i].NumEq) * scale))
+	}
+
+	// Compute the degree of the dependency between each ordered pair of
+	// columns, ignoring rows with NULLs in either column.
+	type group struct {
+		to         string
+		count      int64
+		consistent bool
+	}
+	for from := range colIdxs {
+		for to := range colIdxs {
+			if from == to {
+				continue
+			}
+			groups := make(map[string]*group)
+			var total int64
+			for i := range encoded {
+				f, t := encoded[i][from], encoded[i][to]
+				if f == "" || t == "" {
+					continue
+				}
+				total++
+				g, ok := groups[f]
+				if !ok {
+					groups[f] = &group{to: t, count: 1, consistent: true}
+					continue
+				}
+				g.count++
+				if g.to != t {
+					g.consistent = false
+				}
+			}
+			if total == 0 {
+				continue
+			}
+			var supporting int64
+			for _, g := range groups {
+				if g.consistent {
+					supporting += g.count
+				}
+			}
+			h.Dependencies = append(h.Dependencies, HistogramData_Dependency{
+				FromColumn: uint32(from),
+				ToColumn:   uint32(to),
+				Degree:     float64(supporting) / float64(total),
+			})
+		}
+	}
+	return h, nil
+}
+
+// DecodeMultiColumnValues decodes the most common values and the functional
+// dependency degrees of a multi-column statistic. Tuples containing enum values
+// that were dropped are skipped.
+func (h *HistogramData) DecodeMultiColumnValues() (
+	mcvs []cat.MostCommonValue,
+	deps []cat.ColumnDependency,
+	_ error,
+) {
+	if len(h.MCVColumnTypes) == 0 {
+		return nil, nil, nil
+	}
+	var a tree.DatumAlloc
+	mcvs = make([]cat.MostCommonValue, 0, len(h.MostCommonValues))
+EachValue:
+	for i := range h.MostCommonValues {
+		b := h.MostCommonValues[i].Values
+		values := make(tree.Datums, len(h.MCVColumnTypes))
+		for j, typ := range h.MCVColumnTypes {
+			var err error
+			values[j], b, err = valueside.Decode(&a, typ, b)
+			if err != nil {
+				if typ.Family() == types.EnumFamily && errors.Is(err, types.EnumValueNotFound) {
+					continue EachValue
+				}
+				return nil, nil, err
+			}
+		}
+		if len(b) != 0 {
+			return nil, nil, errors.AssertionFailedf(
+				"%d bytes left over after decoding most common value", len(b),
+			)
+		}
+		mcvs = append(mcvs, cat.MostCommonValue{
+			NumEq:  float64(h.MostCommonValues[i].NumEq),
+			Values: values,
+		})
+	}
+	deps = make([]cat.ColumnDependency, len(h.Dependencies))
+	for i := range h.Dependencies {
+		deps[i] = cat.ColumnDependency{
+			From:   int(h.Dependencies[i].FromColumn),
+			To:     int(h.Dependencies[i].ToColumn),
+			Degree: h.Dependencies[i].Degree,
+		}
+	}
+	return mcvs, deps, nil
+}
+
+// TypeCheckMultiColumn returns an error if the types of the most common values
+// of a multi-column statistic do not match the types of the columns.
+func (histogramData *HistogramData) TypeCheckMultiColumn(
+	colTypes []*types.T, table string, columns []string, createdAt TS,
+) error {
+	if histogramData == nil || len(histogramData.MCVColumnTypes) == 0 {
+		return nil
+	}
+	if len(histogramData.MCVColumnTypes) != len(colTypes) {
+		return errors.Newf(
+			"most common values for table %v columns %v created_at %s have %d columns, expected %d",
+			table, columns, createdAt, len(histogramData.MCVColumnTypes), len(colTypes),
+		)
+	}
+	for i, typ := range histogramData.MCVColumnTypes {
+		if !hasIdenticalHistogramEncoding(typ, colTypes[i]) {
+			return errors.Newf(
+				"most common values for table %v column %v created_at %s do not match column type %v: %v",
+				table, columns[i], createdAt, colTypes[i].SQLStringForError(), typ.SQLStringForError(),
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package stats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMultiColumnValues(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	colTypes := []*types.T{types.Int, types.Int}
+	datum := func(v int) tree.Datum {
+		if v < 0 {
+			return tree.DNull
+		}
+		return tree.NewDInt(tree.DInt(v))
+	}
+	// A value of -1 is NULL.
+	var samples []SampledRow
+	for _, r := range [][2]int{
+		{1, 2}, {1, 2}, {1, 2}, {1, 2}, {3, 4}, {3, 4}, {5, 6}, {5, 7}, {8, -1}, {9, 9},
+	} {
+		samples = append(samples, SampledRow{Row: rowenc.EncDatumRow{
+			rowenc.DatumToEncDatum(types.Int, datum(r[0])),
+			rowenc.DatumToEncDatum(types.Int, datum(r[1])),
+		}})
+	}
+
+	h, err := BuildMultiColumnValues(
+		ctx, mon.NewStandaloneUnlimitedAccount(), samples, []int{0, 1}, colTypes,
+		100 /* numRows */, 10, /* maxValues */
+	)
+	require.NoError(t, err)
+
+	mcvs, deps, err := h.DecodeMultiColumnValues()
+	require.NoError(t, err)
+	require.Equal(t, []cat.MostCommonValue{
+		{NumEq: 40, Values: tree.Datums{datum(1), datum(2)}},
+		{NumEq: 20, Values: tree.Datums{datum(3), datum(4)}},
+	}, mcvs)
+	require.Len(t, deps, 2)
+	require.Equal(t, 0, deps[0].From)
+	require.Equal(t, 1, deps[0].To)
+	require.InDelta(t, 7.0/9, deps[0].Degree, 1e-9)
+	require.Equal(t, cat.ColumnDependency{From: 1, To: 0, Degree: 1}, deps[1])
+
+	// Only the most common tuple is kept when maxValues is one.
+	h, err = BuildMultiColumnValues(
+		ctx, mon.NewStandaloneUnlimitedAccount(), samples, []int{0, 1}, colTypes,
+		100 /* numRows */, 1, /* maxValues */
+	)
+	require.NoError(t, err)
+	require.Len(t, h.MostCommonValues, 1)
+	require.Equal(t, int64(40), h.MostCommonValues[0].NumEq)
+
+	// The most common values must match the types of the columns.
+	require.NoError(t, h.TypeCheckMultiColumn(colTypes, "t", []string{"a", "b"}, TS{}))
+	require.Error(t, h.TypeCheckMultiColumn(
+		[]*types.T{types.Int, types.String}, "t", []string{"a", "b"}, TS{},
+	))
+}
@@ -66,6 +66,11 @@ type TableStatistic struct {
 
 	// Histogram is the decoded histogram data.
 	Histogram []cat.HistogramBucket
+
+	// MostCommonValues and Dependencies are the decoded most common values and
+	// functional dependency degrees of a multi-column statistic.
+	MostCommonValues []cat.MostCommonValue
+	Dependencies     []cat.ColumnDependency
 }
 
 // A TableStatisticsCache contains an LRU cache of []*TableStatistic objects,
@@ -180,6 +185,12 @@ const tableStatOverhead = int64(unsafe.Sizeof(TableStatistic{}))
 // histogramBucketOverhead is the fixed overhead per HistogramBucket.
 const histogramBucketOverhead = int64(unsafe.Sizeof(cat.HistogramBucket{}))
 
+// mostCommonValueOverhead is the fixed overhead per MostCommonValue.
+const mostCommonValueOverhead = int64(unsafe.Sizeof(cat.MostCommonValue{}))
+
+// columnDependencyOverhead is the fixed overhead per ColumnDependency.
+const columnDependencyOverhead = int64(unsafe.Sizeof(cat.ColumnDependency{}))
+
 // estimateStatsSliceSize returns the estimated memory footprint of a slice of
 // TableStatistic pointers. We estimate the in-memory Go struct size rather
 // than using proto.Size(), which returns the wire-format size. Additionally,
@@ -199,6 +210,13 @@ func estimateStatsSliceSize(stats []*TableStatistic) int64 {
 				size += int64(s.Histogram[i].UpperBound.Size())
 			}
 		}
+		size += int64(cap(s.MostCommonValues)) * mostCommonValueOverhead
+		for i := range s.MostCommonValues {
+			for _, d := range s.MostCommonValues[i].Values {
+				size += int64(d.Size())
+			}
+		}
+		size += int64(cap(s.Dependencies)) * columnDependencyOverhead
 	}
 	return size
 }
@@ -1074,7 +1092,8 @@ func (sc *TableStatisticsCache) parseStats(
 	}
 	res := &TableStatistic{TableStatisticProto: *tsp}
 	var udt *types.T
-	if res.HistogramData != nil && (len(res.HistogramData.Buckets) > 0 || res.RowCount == res.NullCount) {
+	if res.HistogramData != nil && len(res.HistogramData.MCVColumnTypes) == 0 &&
+		(len(res.HistogramData.Buckets) > 0 || res.RowCount == res.NullCount) {
 		// Hydrate the type in case any user defined types are present.
 		// There are cases where typ is nil, so don't do anything if so.
 		if typ := res.HistogramData.ColumnType; typ != nil && typ.UserDefined() {
@@ -1106,6 +1125,32 @@ func (sc *TableStatisticsCache) parseStats(
 		// the memory to be GCed.
 		res.HistogramData.Buckets = nil
 	}
+	if res.HistogramData != nil && len(res.HistogramData.MCVColumnTypes) > 0 {
+		// Hydrate the types of the columns of a multi-column statistic.
+		for i, typ := range res.HistogramData.MCVColumnTypes {
+			if typ == nil || !typ.UserDefined() {
+				continue
+			}
+			if typeResolver != nil {
+				res.HistogramData.MCVColumnTypes[i], err = typeResolver.ResolveTypeByOID(ctx, typ.Oid())
+			} else {
+				err = sc.db.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+					resolver := descs.NewDistSQLTypeResolver(txn.Descriptors(), txn.KV())
+					res.HistogramData.MCVColumnTypes[i], err = resolver.ResolveTypeByOID(ctx, typ.Oid())
+					return err
+				})
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		res.MostCommonValues, res.Dependencies, err = res.HistogramData.DecodeMultiColumnValues()
+		if err != nil {
+			return nil, nil, err
+		}
+		// Nil out the encoded values to allow for the memory to be GCed.
+		res.HistogramData.MostCommonValues = nil
+	}
 	return res, udt, nil
 }
 
@@ -1428,18 +1473,24 @@ func (sc *TableStatisticsCache) getTableStatsFromDB(
 			continue
 		}
 
-		// Keep track of user-defined types used in histograms.
-		if udt != nil {
-			// TODO(49698): If we ever support multi-column histograms we'll need to
-			// build this mapping in a different way.
-			if len(stats.ColumnIDs) == 1 {
-				colID := stats.ColumnIDs[0]
-				if udts == nil {
-					udts = make(map[descpb.ColumnID]*types.T)
-				}
-				// Keep the first type we see for the column.
-				if _, ok := udts[colID]; !ok {
-					udts[colID] = udt
+		// Keep track of user-defined types used in histograms and in the most
+		// common values of multi-column statistics.
+		addUDT := func(colID descpb.ColumnID, typ *types.T) {
+			if udts == nil {
+				udts = make(map[descpb.ColumnID]*types.T)
+			}
+			// Keep the first type we see for the column.
+			if _, ok := udts[colID]; !ok {
+				udts[colID] = typ
+			}
+		}
+		if udt != nil && len(stats.ColumnIDs) == 1 {
+			addUDT(stats.ColumnIDs[0], udt)
+		}
+		if stats.HistogramData != nil && len(stats.HistogramData.MCVColumnTypes) == len(stats.ColumnIDs) {
+			for i, typ := range stats.HistogramData.MCVColumnTypes {
+				if typ != nil && typ.UserDefined() {
+					addUDT(stats.ColumnIDs[i], typ)
 				}
 			}
 		}
@@ -63,6 +63,11 @@ func (p *planner) AlterTenantCapability(
 			// translates to true.
 			missingValueDefault = tree.DBoolTrue
 			revokeValue = tree.DBoolFalse
+		case tenantcapabilities.Int64Capability:
+			desiredType = types.Int
+			// Revoking an integer capability resets it to zero, which means no
+			// limit.
+			revokeValue = tree.DZero
 		case tenantcapabilities.SpanConfigBoundsCapability:
 			desiredType = types.Bytes
 		default:
@@ -149,6 +154,13 @@ func (n *alterTenantCapabilityNode) startExec(params runParams) error {
 				}
 				c.Value(dst).Set(val)
 
+			case tenantcapabilities.Int64Capability:
+				// Granting all capabilities lifts any limit, while "REVOKE ALL"
+				// leaves limits in place.
+				if !n.n.IsRevoke {
+					c.Value(dst).Set(0)
+				}
+
 			case tenantcapabilities.SpanConfigBoundsCapability:
 				// "REVOKE" on span config bounds has no meaning currently.
 				if !n.n.IsRevoke {
@@ -178,6 +190,16 @@ func (n *alterTenantCapabilityNode) startExec(params runParams) error {
 					return err
 				}
 				c.Value(dst).Set(boolValue)
+			case tenantcapabilities.Int64Capability:
+				intValue, err := paramparse.DatumAsInt(ctx, p.EvalContext(), update.Name, typedExpr)
+				if err != nil {
+					return err
+				}
+				if intValue < 0 {
+					return pgerror.Newf(pgcode.InvalidParameterValue,
+						"value of capability %q must be non-negative", capability)
+				}
+				c.Value(dst).Set(intValue)
 			case tenantcapabilities.SpanConfigBoundsCapability:
 				if n.n.IsRevoke {
 					return pgerror.Newf(pgcode.InvalidParameterValue, "cannot REVOKE CAPABILITY %q", capability)
@@ -68,6 +68,11 @@ func (t *truncateNode) startExec(params runParams) error {
 		if err := p.checkTruncatePrivilege(ctx, tableDesc); err != nil {
 			return err
 		}
+		if err := CheckIncrementalViewDependents(
+			ctx, p.txn, p.Descriptors(), tableDesc, "TRUNCATE", true, /* allowAsync */
+		); err != nil {
+			return err
+		}
 
 		toTruncate[tableDesc.ID] = tn.FQString()
 		toTraverse = append(toTraverse, *tableDesc)
@@ -100,6 +105,11 @@ func (t *truncateNode) startExec(params runParams) error {
 			if err := p.checkTruncatePrivilege(ctx, other); err != nil {
 				return err
 			}
+			if err := CheckIncrementalViewDependents(
+				ctx, p.txn, p.Descriptors(), other, "TRUNCATE", true, /* allowAsync */
+			); err != nil {
+				return err
+			}
 			otherName, err := p.getQualifiedTableName(ctx, other)
 			if err != nil {
 				return err
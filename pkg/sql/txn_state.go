@@ -150,6 +150,12 @@ type txnState struct {
 	// txnInstrumentationHelper contains state used to manage transaction
 	// bundle collection.
 	txnInstrumentationHelper txnInstrumentationHelper
+
+	// finishedTxnWriteBufferStats are the write buffer stats of the most
+	// recently finished transaction, captured by finishSQLTxn before the KV
+	// txn is released. Unlike the per-statement stats, they include the flush
+	// performed by COMMIT.
+	finishedTxnWriteBufferStats kv.WriteBufferStats
 }
 
 // txnType represents the type of a SQL transaction.
@@ -347,6 +353,7 @@ func (ts *txnState) finishSQLTxn() (txnID uuid.UUID, commitTimestamp hlc.Timesta
 		ts.mu.Lock()
 		defer ts.mu.Unlock()
 		txnID = ts.mu.txn.ID()
+		ts.finishedTxnWriteBufferStats = ts.mu.txn.WriteBufferStats()
 		if ts.mu.txn.IsCommitted() {
 			var err error
 			timestamp, err = ts.mu.txn.CommitTimestamp()
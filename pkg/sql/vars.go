@@ -2738,6 +2738,30 @@ var varGen = map[string]sessionVar{
 		},
 	},
 
+	// CockroachDB extension.
+	`parallelism`: {
+		Description:  sessionVarDescriptions["parallelism"],
+		GetStringVal: makeIntGetStringValFn(`parallelism`),
+		Set: func(_ context.Context, m sessionmutator.SessionDataMutator, s string) error {
+			b, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return err
+			}
+			if b < 0 || b > maxLocalScansConcurrency {
+				return pgerror.Newf(pgcode.InvalidParameterValue,
+					"parallelism must be between 0 and %d: %d", maxLocalScansConcurrency, b)
+			}
+			m.SetParallelism(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return strconv.FormatInt(evalCtx.SessionData().Parallelism, 10), nil
+		},
+		GlobalDefault: func(sv *settings.Values) string {
+			return "0"
+		},
+	},
+
 	// CockroachDB extension.
 	`parallelize_multi_key_lookup_joins_enabled`: {
 		Description:        sessionVarDescriptions["parallelize_multi_key_lookup_joins_enabled"],
@@ -4360,6 +4384,66 @@ var varGen = map[string]sessionVar{
 		},
 	},
 
+	// CockroachDB extension.
+	`optimizer_adaptive_reoptimization`: {
+		Description:  sessionVarDescriptions["optimizer_adaptive_reoptimization"],
+		GetStringVal: makePostgresBoolGetStringValFn(`optimizer_adaptive_reoptimization`),
+		Set: func(_ context.Context, m sessionmutator.SessionDataMutator, s string) error {
+			b, err := paramparse.ParseBoolVar("optimizer_adaptive_reoptimization", s)
+			if err != nil {
+				return err
+			}
+			m.SetOptimizerAdaptiveReoptimization(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return formatBoolAsPostgresSetting(evalCtx.SessionData().OptimizerAdaptiveReoptimization), nil
+		},
+		GlobalDefault: globalFalse,
+	},
+
+	// CockroachDB extension.
+	`optimizer_adaptive_reoptimization_threshold`: {
+		Description:  sessionVarDescriptions["optimizer_adaptive_reoptimization_threshold"],
+		GetStringVal: makeFloatGetStringValFn(`optimizer_adaptive_reoptimization_threshold`),
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return formatFloatAsPostgresSetting(evalCtx.SessionData().OptimizerAdaptiveReoptimizationThreshold), nil
+		},
+		GlobalDefault: func(sv *settings.Values) string {
+			return "10"
+		},
+		Set: func(_ context.Context, m sessionmutator.SessionDataMutator, s string) error {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return err
+			}
+			if f < 1 {
+				return pgerror.New(pgcode.InvalidParameterValue,
+					"optimizer_adaptive_reoptimization_threshold must be at least 1")
+			}
+			m.SetOptimizerAdaptiveReoptimizationThreshold(f)
+			return nil
+		},
+	},
+
+	// CockroachDB extension.
+	`result_cache_enabled`: {
+		Description:  sessionVarDescriptions["result_cache_enabled"],
+		GetStringVal: makePostgresBoolGetStringValFn(`result_cache_enabled`),
+		Set: func(_ context.Context, m sessionmutator.SessionDataMutator, s string) error {
+			b, err := paramparse.ParseBoolVar("result_cache_enabled", s)
+			if err != nil {
+				return err
+			}
+			m.SetResultCacheEnabled(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return formatBoolAsPostgresSetting(evalCtx.SessionData().ResultCacheEnabled), nil
+		},
+		GlobalDefault: globalFalse,
+	},
+
 	// CockroachDB extension.
 	`kv_transaction_buffered_writes_enabled`: {
 		Description:        sessionVarDescriptions["kv_transaction_buffered_writes_enabled"],
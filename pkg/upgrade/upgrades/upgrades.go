@@ -210,6 +210,16 @@ var upgrades = []upgradebase.Upgrade{
 		alterStatementsTablePK,
 		upgrade.RestoreActionImplemented("statementsRestoreFunc skips restore of pre-V26_3 backups, which are guaranteed empty"),
 	),
+
+	upgrade.NewTenantUpgrade(
+		"create resource_groups table",
+		clusterversion.V26_3_AddResourceGroupsTable.Version(),
+		upgrade.NoPrecondition,
+		createResourceGroupsTable,
+		upgrade.RestoreActionNotRequired(
+			"restore for a cluster predating this table can leave it empty",
+		),
+	),
 	// Note: when starting a new release version, the first upgrade (for
 	// Vxy_zStart) must be a newFirstUpgrade. Keep this comment at the bottom.
 }
@@ -226,6 +226,11 @@ type ResourceGroupID uint64
 // SafeValue implements redact.SafeValue.
 func (ResourceGroupID) SafeValue() {}
 
+// MinUserDefinedResourceGroupID is the smallest ID of a resource group defined
+// with CREATE RESOURCE GROUP. Smaller IDs are reserved for the resource groups
+// that admission control defines itself.
+const MinUserDefinedResourceGroupID ResourceGroupID = 100
+
 // WorkClassFromPri translates a WorkPriority to its given WorkClass.
 func WorkClassFromPri(pri WorkPriority) WorkClass {
 	class := RegularWorkClass
@@ -12,6 +12,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
 	"github.com/cockroachdb/cockroach/pkg/util/buildutil"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
 	"github.com/cockroachdb/cockroach/pkg/util/goschedstats"
@@ -202,6 +203,17 @@ func (coord *CPUGrantCoordinators) SetResourceGroupConfig(config ResourceGroupCo
 	q.refreshResourceGroupConfig()
 }
 
+// ResourceGroupUsage returns the live usage of the resource groups in the
+// RM-mode WorkQueue, keyed by resource group ID. It is empty when RM mode is
+// off.
+func (coord *CPUGrantCoordinators) ResourceGroupUsage() map[admissionpb.ResourceGroupID]ResourceGroupUsage {
+	q, ok := coord.cpuTimeCoord.queues[rmQueueTier].(*WorkQueue)
+	if !ok {
+		panic(errors.AssertionFailedf("queues[%d] is not a *WorkQueue", rmQueueTier))
+	}
+	return q.resourceGroupUsage()
+}
+
 // GetRunnableCountCallback returns a callback of type
 // goschedstats.RunnableCountCallback.
 func (coord *CPUGrantCoordinators) GetRunnableCountCallback() goschedstats.RunnableCountCallback {
@@ -287,6 +299,7 @@ func makeCPUTimeTokenGrantCoordinator(
 			waitTimeNanos:  metrics.WaitTimeNanosPerTenant[tier],
 			tokensUsed:     metrics.TokensUsedPerTenant[tier],
 			tokensReturned: metrics.TokensReturnedPerTenant[tier],
+			waiting:        metrics.WaitingPerTenant[tier],
 		}
 		opts.configHolder = configHolder
 		requesters[tier] = makeWorkQueue(
@@ -85,6 +85,15 @@ var (
 		Measurement: "Tokens",
 		Unit:        metric.Unit_COUNT,
 	}
+
+	cpuTimeTokenWaitingPerTenantMetaBase = metric.Metadata{
+		Name: "admission.cpu_time_tokens.per_tenant.waiting.%s",
+		Help: crstrings.UnwrapText(`
+			Number of requests per tenant or resource group currently
+			waiting for admission in CPU time token admission control`),
+		Measurement: "Requests",
+		Unit:        metric.Unit_COUNT,
+	}
 )
 
 // cpuTimeTokenMetrics tracks metrics for the CPU time token admission
@@ -140,6 +149,11 @@ type cpuTimeTokenMetrics struct {
 	// they give per-tenant visibility into token flow.
 	TokensUsedPerTenant     [numResourceTiers]*aggmetric.AggCounter
 	TokensReturnedPerTenant [numResourceTiers]*aggmetric.AggCounter
+
+	// WaitingPerTenant tracks the number of requests of each group that
+	// are queued for admission. With kind="rg", it and TokensUsedPerTenant
+	// give the queued and CPU-granted time series of each resource group.
+	WaitingPerTenant [numResourceTiers]*aggmetric.AggGauge
 }
 
 func makeCPUTimeTokenMetrics() *cpuTimeTokenMetrics {
@@ -177,6 +191,11 @@ func makeCPUTimeTokenMetrics() *cpuTimeTokenMetrics {
 		returnedMeta.Name = fmt.Sprintf(
 			"admission.cpu_time_tokens.per_tenant.tokens_returned.%s", tierStr)
 		m.TokensReturnedPerTenant[tier] = b.Counter(returnedMeta)
+
+		waitingMeta := cpuTimeTokenWaitingPerTenantMetaBase
+		waitingMeta.Name = fmt.Sprintf(
+			"admission.cpu_time_tokens.per_tenant.waiting.%s", tierStr)
+		m.WaitingPerTenant[tier] = b.Gauge(waitingMeta)
 	}
 	for tier := resourceTier(0); tier < numResourceTiers; tier++ {
 		for qual := burstQualification(0); qual < numBurstQualifications; qual++ {
@@ -8,6 +8,7 @@ package admission
 import (
 	"sort"
 
+	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
@@ -82,6 +83,29 @@ func (s ResourceGroupConfigSet) GetOrDefault(k groupKey) ResourceGroupConfig {
 	}
 }
 
+// MakeResourceGroupConfigSet returns the config set for RM mode consisting of
+// the default high/low groups and the given user-defined groups, keyed by
+// their IDs. Every ID in groups must be at least
+// admissionpb.MinUserDefinedResourceGroupID.
+func MakeResourceGroupConfigSet(
+	groups map[admissionpb.ResourceGroupID]ResourceGroupConfig,
+) (ResourceGroupConfigSet, error) {
+	s := make(ResourceGroupConfigSet, len(defaultRMResourceGroupConfig)+len(groups))
+	for k, cfg := range defaultRMResourceGroupConfig {
+		s[k] = cfg
+	}
+	for id, cfg := range groups {
+		if id < admissionpb.MinUserDefinedResourceGroupID {
+			return nil, errors.AssertionFailedf("resource group ID %d is reserved", id)
+		}
+		if cfg.Weight == 0 {
+			return nil, errors.AssertionFailedf("resource group %d has zero weight", id)
+		}
+		s[rgGroupKey(uint64(id))] = cfg
+	}
+	return s, nil
+}
+
 // defaultRMResourceGroupConfig seeds the holder until an explicit Set
 // replaces it. The two ids match priorityToResourceGroupKey (high/low).
 //
@@ -99,8 +123,9 @@ var defaultRMResourceGroupConfig = ResourceGroupConfigSet{
 // zero-weight group. Weight=20 mirrors the low default; MaxCPU=false keeps
 // an unconfigured group from bypassing the burst-fullness gate.
 //
-// TODO(wenyihu6): once SQL DDL (CREATE/ALTER RESOURCE GROUP) is wired
-// through, decide whether unknown rgKind IDs should be a hard error.
+// Work is also routed to an unknown user-defined rgKind ID between a DROP
+// RESOURCE GROUP and the moment the sessions that were mapped to the group
+// observe the drop.
 var defaultRGGroupConfig = ResourceGroupConfig{Weight: 20, BurstFrac: 0.2, MaxCPU: false}
 
 // defaultTenantGroupConfig is the fallback for tenantKind keys: every tenant
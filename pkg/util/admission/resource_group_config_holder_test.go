@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
 	"github.com/stretchr/testify/require"
 )
 
@@ -124,3 +125,34 @@ func TestResourceGroupConfigHolderSnapshot(t *testing.T) {
 		rgGroupKey(42): {Weight: 100, MaxCPU: true},
 	}, h.Snapshot())
 }
+
+// TestMakeResourceGroupConfigSet verifies that user-defined groups are added
+// alongside the default high/low groups, and that reserved IDs are rejected.
+func TestMakeResourceGroupConfigSet(t *testing.T) {
+	t.Run("adds_user_defined_groups", func(t *testing.T) {
+		s, err := MakeResourceGroupConfigSet(map[admissionpb.ResourceGroupID]ResourceGroupConfig{
+			admissionpb.MinUserDefinedResourceGroupID: {Weight: 50, BurstFrac: 0.5},
+		})
+		require.NoError(t, err)
+		userKey := rgGroupKey(uint64(admissionpb.MinUserDefinedResourceGroupID))
+		require.Equal(t, ResourceGroupConfigSet{
+			rgGroupKey(highResourceGroupID): {Weight: 80, BurstFrac: 0.8, MaxCPU: true},
+			rgGroupKey(lowResourceGroupID):  {Weight: 20, BurstFrac: 0.2, MaxCPU: false},
+			userKey:                         {Weight: 50, BurstFrac: 0.5},
+		}, s)
+	})
+
+	t.Run("rejects_reserved_id", func(t *testing.T) {
+		_, err := MakeResourceGroupConfigSet(map[admissionpb.ResourceGroupID]ResourceGroupConfig{
+			admissionpb.ResourceGroupID(highResourceGroupID): {Weight: 50, BurstFrac: 0.5},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("rejects_zero_weight", func(t *testing.T) {
+		_, err := MakeResourceGroupConfigSet(map[admissionpb.ResourceGroupID]ResourceGroupConfig{
+			admissionpb.MinUserDefinedResourceGroupID: {BurstFrac: 0.5},
+		})
+		require.Error(t, err)
+	})
+}
@@ -389,6 +389,19 @@ func (h *SQLCPUHandle) AtGateway() bool {
 	return h.atGateway
 }
 
+// HasWaitingWork returns true if CPU time token admission is enabled for the
+// handle and work is waiting in its queue for tokens, i.e. the node is short
+// on CPU. SQL uses this to avoid planning additional concurrency for a
+// statement, which would only add to the work competing for tokens. It is safe
+// to call on a nil handle.
+func (h *SQLCPUHandle) HasWaitingWork() bool {
+	if h == nil || h.wq == nil {
+		return false
+	}
+	waiting, _ := h.wq.hasWaitingRequests()
+	return waiting
+}
+
 // IsGoroutineRegistered returns true if the calling goroutine already has a
 // registered handle. Unlike RegisterGoroutine, this does not create a new
 // handle as a side effect.
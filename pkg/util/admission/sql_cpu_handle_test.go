@@ -36,6 +36,8 @@ func TestSQLCPUHandleFastAndSlowPath(t *testing.T) {
 	provider := &sqlCPUProviderImpl{}
 	h := newSQLCPUAdmissionHandle(
 		WorkInfo{TenantID: tenantID}, true, provider, q)
+	// Nothing is waiting in the queue.
+	require.False(t, h.HasWaitingWork())
 
 	// 1) Slow path: reservation is 0, must call Admit.
 	// heuristic(1ms) = 1ms + min(1ms, 1ms) = 2ms requested.
@@ -162,6 +164,8 @@ func TestSQLCPUHandleNoWorkQueue(t *testing.T) {
 	provider := &sqlCPUProviderImpl{}
 	h := newSQLCPUAdmissionHandle(
 		WorkInfo{TenantID: tenantID}, true, provider, nil)
+	require.False(t, h.HasWaitingWork())
+	require.False(t, (*SQLCPUHandle)(nil).HasWaitingWork())
 
 	require.NoError(t, h.reportAndAcquireConsumedCPU(ctx, 1*time.Millisecond, false))
 	require.NoError(t, h.reportAndAcquireConsumedCPU(ctx, 2*time.Millisecond, true))
@@ -369,13 +369,14 @@ type WorkQueue struct {
 	knobs      *TestingKnobs
 }
 
-// groupAggMetrics groups the parent AggCounters from which per-group
-// child counters are created via AddChild.
+// groupAggMetrics groups the parent AggCounters and AggGauges from which
+// per-group child metrics are created via AddChild.
 type groupAggMetrics struct {
 	admittedCount  *aggmetric.AggCounter
 	waitTimeNanos  *aggmetric.AggCounter
 	tokensUsed     *aggmetric.AggCounter
 	tokensReturned *aggmetric.AggCounter
+	waiting        *aggmetric.AggGauge
 }
 
 var _ requester = &WorkQueue{}
@@ -731,13 +732,18 @@ func priorityToResourceGroupKey(pri admissionpb.WorkPriority) groupKey {
 
 // groupKeyForWorkLocked returns the composite groupKey for the given
 // WorkInfo. In RM mode (cpuTimeTokenACMode == resourceManagerMode), the
-// key is derived from WorkInfo.Priority with kind=rgKind; otherwise it
-// is the TenantID with kind=tenantKind.
+// key is WorkInfo.ResourceGroupID with kind=rgKind if the work belongs to
+// a user-defined resource group, and is otherwise derived from
+// WorkInfo.Priority with kind=rgKind; outside of RM mode it is the
+// TenantID with kind=tenantKind.
 //
 // REQUIRES: q.mu is held.
 func (q *WorkQueue) groupKeyForWorkLocked(info WorkInfo) groupKey {
 	q.mu.AssertHeld()
 	if cpuTimeTokenACMode.Get(&q.settings.SV) == resourceManagerMode {
+		if info.ResourceGroupID >= admissionpb.MinUserDefinedResourceGroupID {
+			return rgGroupKey(uint64(info.ResourceGroupID))
+		}
 		return priorityToResourceGroupKey(info.Priority)
 	}
 	return tenantGroupKey(info.TenantID.ToUint64())
@@ -990,6 +996,9 @@ func (q *WorkQueue) Admit(ctx context.Context, info WorkInfo) (AdmitResponse, er
 	} else {
 		heap.Push(&group.openEpochsHeap, work)
 	}
+	if group.perGroupMetrics.waiting != nil {
+		group.perGroupMetrics.waiting.Inc(1)
+	}
 	if !inGroupHeap {
 		heap.Push(&q.mu.groupHeap, group)
 	}
@@ -1061,6 +1070,9 @@ func (q *WorkQueue) Admit(ctx context.Context, info WorkInfo) (AdmitResponse, er
 			} else {
 				group.openEpochsHeap.remove(work)
 			}
+			if group.perGroupMetrics.waiting != nil {
+				group.perGroupMetrics.waiting.Dec(1)
+			}
 			if !isInGroupHeap(group) {
 				q.mu.groupHeap.remove(group)
 			}
@@ -1246,6 +1258,9 @@ func (q *WorkQueue) granted(grantChainID grantChainID) int64 {
 		group.perGroupMetrics.admittedCount.Inc(1)
 		group.perGroupMetrics.waitTimeNanos.Inc(waitDur.Nanoseconds())
 	}
+	if group.perGroupMetrics.waiting != nil {
+		group.perGroupMetrics.waiting.Dec(1)
+	}
 	if !isInGroupHeap(group) {
 		q.mu.groupHeap.remove(group)
 	}
@@ -1547,6 +1562,35 @@ func (q *WorkQueue) refreshResourceGroupConfig() {
 	}
 }
 
+// ResourceGroupUsage describes the live usage of a resource group in a
+// WorkQueue.
+type ResourceGroupUsage struct {
+	// Used is the amount of the queue's resource (slots, tokens or CPU time
+	// tokens) consumed by the group in the current accounting interval, which
+	// is reset every second.
+	Used uint64
+	// Waiting is the number of requests of the group waiting for admission.
+	Waiting int
+}
+
+// resourceGroupUsage returns the live usage of the rgKind groups in the
+// queue, keyed by resource group ID.
+func (q *WorkQueue) resourceGroupUsage() map[admissionpb.ResourceGroupID]ResourceGroupUsage {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	usage := make(map[admissionpb.ResourceGroupID]ResourceGroupUsage)
+	for k, group := range q.mu.groups {
+		if k.kind != rgKind {
+			continue
+		}
+		usage[admissionpb.ResourceGroupID(k.id)] = ResourceGroupUsage{
+			Used:    group.used,
+			Waiting: len(group.waitingWorkHeap),
+		}
+	}
+	return usage
+}
+
 // applyConfigLocked upserts each entry in the holder snapshot into
 // q.mu.groups: existing groups have their weight and maxCPU updated
 // (with a heap fix if either changes the heap order), and missing
@@ -1821,12 +1865,13 @@ type groupInfo struct {
 }
 
 // groupMetrics groups the per-group metric children that are created
-// via AggCounter.AddChild for each group.
+// via AggCounter.AddChild and AggGauge.AddChild for each group.
 type groupMetrics struct {
 	admittedCount  *aggmetric.Counter
 	waitTimeNanos  *aggmetric.Counter
 	tokensUsed     *aggmetric.Counter
 	tokensReturned *aggmetric.Counter
+	waiting        *aggmetric.Gauge
 }
 
 // groupHeap is a heap of groups with waiting work, ordered by burst
@@ -1880,6 +1925,9 @@ func newGroupInfo(
 		ti.perGroupMetrics.waitTimeNanos = aggMetrics.waitTimeNanos.AddChild(kindStr, idStr)
 		ti.perGroupMetrics.tokensUsed = aggMetrics.tokensUsed.AddChild(kindStr, idStr)
 		ti.perGroupMetrics.tokensReturned = aggMetrics.tokensReturned.AddChild(kindStr, idStr)
+		if aggMetrics.waiting != nil {
+			ti.perGroupMetrics.waiting = aggMetrics.waiting.AddChild(kindStr, idStr)
+		}
 	}
 	return ti
 }
@@ -1894,6 +1942,9 @@ func releaseGroupInfo(ti *groupInfo) {
 		ti.perGroupMetrics.tokensUsed.Unlink()
 		ti.perGroupMetrics.tokensReturned.Unlink()
 	}
+	if ti.perGroupMetrics.waiting != nil {
+		ti.perGroupMetrics.waiting.Unlink()
+	}
 	// NB: {waitingWorkHeap,openEpochsHeap}.Pop nil the slice elements when
 	// removing, so we are not inadvertently holding any references.
 	if cap(ti.waitingWorkHeap) > 100 {
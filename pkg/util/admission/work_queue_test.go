@@ -422,6 +422,7 @@ func runCPUTimeTokenWorkQueueTest(t *testing.T, path string) {
 					waitTimeNanos:  cpuMetrics.WaitTimeNanosPerTenant[systemTenant],
 					tokensUsed:     cpuMetrics.TokensUsedPerTenant[systemTenant],
 					tokensReturned: cpuMetrics.TokensReturnedPerTenant[systemTenant],
+					waiting:        cpuMetrics.WaitingPerTenant[systemTenant],
 				}
 				opts.configHolder = newResourceGroupConfigHolder()
 				q = makeWorkQueue(log.MakeTestingAmbientContext(tracing.NewTracer()),
@@ -640,6 +641,7 @@ func TestCPUTimeTokenEstimation(t *testing.T) {
 		waitTimeNanos:  cpuMetrics.WaitTimeNanosPerTenant[systemTenant],
 		tokensUsed:     cpuMetrics.TokensUsedPerTenant[systemTenant],
 		tokensReturned: cpuMetrics.TokensReturnedPerTenant[systemTenant],
+		waiting:        cpuMetrics.WaitingPerTenant[systemTenant],
 	}
 	timeSource = timeutil.NewManualTime(initialTime)
 	opts.timeSource = timeSource
@@ -782,6 +784,7 @@ func makeCPUTimeTokenWorkQueue(
 		waitTimeNanos:  cpuMetrics.WaitTimeNanosPerTenant[systemTenant],
 		tokensUsed:     cpuMetrics.TokensUsedPerTenant[systemTenant],
 		tokensReturned: cpuMetrics.TokensReturnedPerTenant[systemTenant],
+		waiting:        cpuMetrics.WaitingPerTenant[systemTenant],
 	}
 	opts.timeSource = timeutil.NewManualTime(initialTime)
 	opts.disableEpochClosingGoroutine = true
@@ -903,6 +906,43 @@ func TestSQLCPUAdmission(t *testing.T) {
 // the code that decrements groupInfo.used for tokens. It would also trigger
 // a used-after-free bug where the groupInfo being used in Admit had been
 // returned to the sync.Pool because the used value was reset.
+// TestCPUTimeTokenWaitingMetric verifies that the per-group waiting gauge
+// tracks requests that are queued for admission, and is decremented when a
+// queued request is canceled.
+func TestCPUTimeTokenWaitingMetric(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	q, tg, _, cleanup := makeCPUTimeTokenWorkQueue(t)
+	defer cleanup()
+	tg.mu.Lock()
+	tg.mu.returnValueFromTryGet = false
+	tg.mu.Unlock()
+
+	tenantID := roachpb.MustMakeTenantID(1)
+	waiting := func() int64 {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		group, ok := q.mu.groups[tenantGroupKey(tenantID.ToUint64())]
+		if !ok {
+			return 0
+		}
+		return group.perGroupMetrics.waiting.Value()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := q.Admit(ctx, WorkInfo{TenantID: tenantID, RequestedCount: 1})
+		errCh <- err
+	}()
+	require.Eventually(t, func() bool { return waiting() == 1 }, 10*time.Second, time.Millisecond)
+
+	cancel()
+	require.Error(t, <-errCh)
+	require.Equal(t, int64(0), waiting())
+}
+
 func TestWorkQueueTokenResetRace(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)